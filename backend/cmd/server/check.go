@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/repository/postgres"
+	"github.com/TitleKung-01/code-tree-backend/internal/service/integrity"
+)
+
+const usage = `usage: server [command]
+
+commands:
+  (none)            start the API server
+  check-structure   check trees.structure against nodes (-repair to fix, -tree ID for one tree)
+`
+
+// runCommand รัน subcommand แล้วคืน exit code (0 = ปกติ, 1 = เจอปัญหาแต่ไม่ได้ซ่อม, 2 = ใช้ผิด / error)
+func runCommand(db *postgres.DB, args []string) int {
+	switch args[0] {
+	case "check-structure":
+		return checkStructure(db, args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+}
+
+// checkStructure ตรวจ (และซ่อมถ้าสั่ง) structure ของทุก tree หรือ tree เดียว พิมพ์เฉพาะ tree ที่มีปัญหา
+func checkStructure(db *postgres.DB, args []string) int {
+	fs := flag.NewFlagSet("check-structure", flag.ContinueOnError)
+	repair := fs.Bool("repair", false, "write the repaired structure (default is a dry run)")
+	treeID := fs.String("tree", "", "check only this tree")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	checker := integrity.NewChecker(
+		postgres.NewTreeRepo(db),
+		postgres.NewNodeRepo(db),
+		postgres.NewAuditRepo(db),
+		postgres.NewTxManager(db),
+	)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	report := func(res *integrity.Result) {
+		if len(res.Issues) == 0 {
+			return
+		}
+		status := "dry run"
+		if res.Repaired {
+			status = fmt.Sprintf("repaired, revision %d", res.Revision)
+		}
+		fmt.Printf("tree %s: %d issue(s) (%s)\n", res.TreeID, len(res.Issues), status)
+		for _, issue := range res.Issues {
+			fmt.Printf("  - %s: %s\n", issue.Kind, issue)
+		}
+	}
+
+	broken := 0
+	if *treeID != "" {
+		res, err := checker.CheckTree(ctx, *treeID, *repair, "")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "check-structure: %v\n", err)
+			return 2
+		}
+		report(res)
+		if len(res.Issues) > 0 {
+			broken = 1
+		}
+	} else {
+		var err error
+		broken, err = checker.CheckAll(ctx, *repair, report)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "check-structure: %v\n", err)
+			return 2
+		}
+	}
+
+	fmt.Printf("%d tree(s) with issues\n", broken)
+	if broken > 0 && !*repair {
+		return 1
+	}
+	return 0
+}
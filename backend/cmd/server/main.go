@@ -1,6 +1,7 @@
 package main
 
 import (
+    "context"
     "fmt"
     "log/slog"
     "net/http"
@@ -15,9 +16,14 @@ import (
     "github.com/TitleKung-01/code-tree-backend/gen/node/v1/nodev1connect"
     "github.com/TitleKung-01/code-tree-backend/gen/tree/v1/treev1connect"
     "github.com/TitleKung-01/code-tree-backend/internal/config"
+    "github.com/TitleKung-01/code-tree-backend/internal/domain/share"
     "github.com/TitleKung-01/code-tree-backend/internal/middleware"
     "github.com/TitleKung-01/code-tree-backend/internal/repository/postgres"
+    "github.com/TitleKung-01/code-tree-backend/internal/render"
+    "github.com/TitleKung-01/code-tree-backend/internal/supabase"
     nodeService "github.com/TitleKung-01/code-tree-backend/internal/service/node"
+    previewService "github.com/TitleKung-01/code-tree-backend/internal/service/preview"
+    trashService "github.com/TitleKung-01/code-tree-backend/internal/service/trash"
     treeService "github.com/TitleKung-01/code-tree-backend/internal/service/tree"
 )
 
@@ -39,14 +45,60 @@ func main() {
     }
     defer db.Close()
 
+    // ==================== Subcommands ====================
+    // เช่น ./server check-structure -repair → ทำงานแล้วจบ ไม่เปิด server
+    if len(os.Args) > 1 {
+        code := runCommand(db, os.Args[1:])
+        db.Close()
+        os.Exit(code)
+    }
+
     // ==================== Repositories ====================
     treeRepo := postgres.NewTreeRepo(db)
     nodeRepo := postgres.NewNodeRepo(db)
     shareRepo := postgres.NewShareRepo(db)
+    eventRepo := postgres.NewEventRepo(db)
+    auditRepo := postgres.NewAuditRepo(db)
+    snapshotRepo := postgres.NewSnapshotRepo(db)
+    txManager := postgres.NewTxManager(db)
+
+    // ==================== Realtime Events ====================
+    ctx, stop := context.WithCancel(context.Background())
+    defer stop()
+
+    eventListener := postgres.NewEventListener(cfg.DatabaseListenURL, eventRepo)
+    go eventListener.Run(ctx)
+
+    // ==================== Trash ====================
+    trashPurger := trashService.NewPurger(treeRepo, nodeRepo, cfg.TrashRetention)
+    go trashPurger.Run(ctx)
+
+    // ==================== Invitations ====================
+    // ไม่มี service role key = เก็บคำเชิญไว้ ให้ผู้ถูกเชิญสมัครเอง
+    var inviteSender share.InviteSender
+    if cfg.SupabaseServiceKey != "" {
+        inviteSender = supabase.NewInviteSender(cfg.SupabaseURL, cfg.SupabaseServiceKey)
+    } else {
+        slog.Warn("SUPABASE_SERVICE_ROLE_KEY not set, invitation emails are disabled")
+    }
 
     // ==================== Services ====================
-    treeSvc := treeService.NewService(treeRepo, shareRepo)
-    nodeSvc := nodeService.NewService(nodeRepo, treeRepo, shareRepo)
+    treeSvc := treeService.NewService(treeRepo, nodeRepo, shareRepo, auditRepo, inviteSender, txManager)
+    nodeSvc := nodeService.NewService(nodeRepo, treeRepo, shareRepo, eventRepo, auditRepo, snapshotRepo, eventListener, txManager, cfg.TrashRetention)
+
+    // ==================== Renderer ====================
+    pngRenderer, err := render.NewPNGRenderer(cfg.RenderFontPath)
+    if err != nil {
+        slog.Error("failed to load render font", "path", cfg.RenderFontPath, "error", err)
+        os.Exit(1)
+    }
+    if !pngRenderer.HasFont() {
+        slog.Warn("RENDER_FONT_PATH not set, PNG labels support ASCII only")
+    }
+    if cfg.PublicBaseURL == "" {
+        slog.Warn("PUBLIC_BASE_URL not set, share preview links use the request Host")
+    }
+    previewSvc := previewService.NewService(treeRepo, nodeRepo, shareRepo, pngRenderer, cfg.PublicBaseURL)
 
     // ==================== Auth Middleware ====================
     authMiddleware, err := middleware.NewAuthMiddleware(cfg.SupabaseURL, cfg.SupabaseJWTSecret)
@@ -54,6 +106,7 @@ func main() {
         slog.Error("failed to create auth middleware", "error", err)
         os.Exit(1)
     }
+    invitationClaimer := middleware.NewInvitationClaimer(shareRepo)
 
     // ==================== Mux ====================
     mux := http.NewServeMux()
@@ -66,13 +119,22 @@ func main() {
 
     // gRPC Services
     treePath, treeHandler := treev1connect.NewTreeServiceHandler(treeSvc)
-    mux.Handle(treePath, authMiddleware.WrapOptional(treeHandler))
+    mux.Handle(treePath, authMiddleware.WrapOptional(invitationClaimer.Wrap(treeHandler)))
     slog.Info("registered service", "path", treePath)
 
     nodePath, nodeHandler := nodev1connect.NewNodeServiceHandler(nodeSvc)
-    mux.Handle(nodePath, authMiddleware.WrapOptional(nodeHandler))
+    mux.Handle(nodePath, authMiddleware.WrapOptional(invitationClaimer.Wrap(nodeHandler)))
     slog.Info("registered service", "path", nodePath)
 
+    // Export download (public tree ไม่ต้อง login)
+    mux.Handle("GET /export/{treeId}", authMiddleware.WrapOptional(http.HandlerFunc(nodeSvc.HandleExport)))
+
+    // Share link render + Open Graph preview (public, ใช้ share token)
+    mux.HandleFunc("GET /share/{token}/tree.svg", previewSvc.HandleRenderSVG)
+    mux.HandleFunc("GET /share/{token}/tree.png", previewSvc.HandleRenderPNG)
+    mux.HandleFunc("GET /share/{token}/og", previewSvc.HandleOGMetadata)
+    mux.HandleFunc("GET /share/{token}/og.png", previewSvc.HandleOGImage)
+
     // ==================== CORS ====================
     slog.Info("CORS allowed origins", "origins", cfg.AllowedOrigins)
     corsHandler := cors.New(cors.Options{
@@ -97,6 +159,7 @@ func main() {
         signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
         <-sigChan
         slog.Info("shutting down server...")
+        stop()
         server.Close()
     }()
 
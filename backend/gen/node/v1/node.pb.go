@@ -7,6 +7,7 @@
 package nodev1
 
 import (
+	v1 "github.com/TitleKung-01/code-tree-backend/gen/tree/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -73,6 +74,395 @@ func (NodeStatus) EnumDescriptor() ([]byte, []int) {
 	return file_node_v1_node_proto_rawDescGZIP(), []int{0}
 }
 
+// ★ Bulk import: นำเข้าสายรหัสทั้งสายจากไฟล์
+// columns: nickname, first_name, last_name, student_id, generation, status,
+//
+//	phone, email, line_id, discord, facebook, parent_student_ids (คั่นด้วย ;)
+type ImportFormat int32
+
+const (
+	ImportFormat_IMPORT_FORMAT_UNSPECIFIED   ImportFormat = 0
+	ImportFormat_IMPORT_FORMAT_CSV           ImportFormat = 1
+	ImportFormat_IMPORT_FORMAT_XLSX          ImportFormat = 2
+	ImportFormat_IMPORT_FORMAT_JSON_SNAPSHOT ImportFormat = 3 // ไฟล์จาก ExportTree (EXPORT_FORMAT_JSON_SNAPSHOT)
+)
+
+// Enum value maps for ImportFormat.
+var (
+	ImportFormat_name = map[int32]string{
+		0: "IMPORT_FORMAT_UNSPECIFIED",
+		1: "IMPORT_FORMAT_CSV",
+		2: "IMPORT_FORMAT_XLSX",
+		3: "IMPORT_FORMAT_JSON_SNAPSHOT",
+	}
+	ImportFormat_value = map[string]int32{
+		"IMPORT_FORMAT_UNSPECIFIED":   0,
+		"IMPORT_FORMAT_CSV":           1,
+		"IMPORT_FORMAT_XLSX":          2,
+		"IMPORT_FORMAT_JSON_SNAPSHOT": 3,
+	}
+)
+
+func (x ImportFormat) Enum() *ImportFormat {
+	p := new(ImportFormat)
+	*p = x
+	return p
+}
+
+func (x ImportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_node_v1_node_proto_enumTypes[1].Descriptor()
+}
+
+func (ImportFormat) Type() protoreflect.EnumType {
+	return &file_node_v1_node_proto_enumTypes[1]
+}
+
+func (x ImportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportFormat.Descriptor instead.
+func (ImportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{1}
+}
+
+// ★ Export: ดึงข้อมูลทั้ง tree ออกเป็นไฟล์ (เก็บเส้น multi-parent ครบทุกแบบ)
+type ExportFormat int32
+
+const (
+	ExportFormat_EXPORT_FORMAT_UNSPECIFIED   ExportFormat = 0 // = JSON snapshot
+	ExportFormat_EXPORT_FORMAT_JSON_SNAPSHOT ExportFormat = 1 // ครบทุก field, import กลับได้
+	ExportFormat_EXPORT_FORMAT_DOT           ExportFormat = 2 // Graphviz
+	ExportFormat_EXPORT_FORMAT_GRAPHML       ExportFormat = 3
+	ExportFormat_EXPORT_FORMAT_GEDCOM        ExportFormat = 4
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "EXPORT_FORMAT_UNSPECIFIED",
+		1: "EXPORT_FORMAT_JSON_SNAPSHOT",
+		2: "EXPORT_FORMAT_DOT",
+		3: "EXPORT_FORMAT_GRAPHML",
+		4: "EXPORT_FORMAT_GEDCOM",
+	}
+	ExportFormat_value = map[string]int32{
+		"EXPORT_FORMAT_UNSPECIFIED":   0,
+		"EXPORT_FORMAT_JSON_SNAPSHOT": 1,
+		"EXPORT_FORMAT_DOT":           2,
+		"EXPORT_FORMAT_GRAPHML":       3,
+		"EXPORT_FORMAT_GEDCOM":        4,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_node_v1_node_proto_enumTypes[2].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_node_v1_node_proto_enumTypes[2]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{2}
+}
+
+// ★ Realtime: ติดตามการเปลี่ยนแปลงของ tree (server-streaming)
+type TreeEventType int32
+
+const (
+	TreeEventType_TREE_EVENT_TYPE_UNSPECIFIED          TreeEventType = 0
+	TreeEventType_TREE_EVENT_TYPE_NODE_CREATED         TreeEventType = 1
+	TreeEventType_TREE_EVENT_TYPE_NODE_UPDATED         TreeEventType = 2
+	TreeEventType_TREE_EVENT_TYPE_NODE_DELETED         TreeEventType = 3
+	TreeEventType_TREE_EVENT_TYPE_NODE_MOVED           TreeEventType = 4 // parent ชุดใหม่ไม่ซ้ำชุดเดิม (ย้ายสาย / ตัดเป็น root)
+	TreeEventType_TREE_EVENT_TYPE_NODE_PARENTS_CHANGED TreeEventType = 5 // เพิ่ม/ตัด parent บางตัว
+)
+
+// Enum value maps for TreeEventType.
+var (
+	TreeEventType_name = map[int32]string{
+		0: "TREE_EVENT_TYPE_UNSPECIFIED",
+		1: "TREE_EVENT_TYPE_NODE_CREATED",
+		2: "TREE_EVENT_TYPE_NODE_UPDATED",
+		3: "TREE_EVENT_TYPE_NODE_DELETED",
+		4: "TREE_EVENT_TYPE_NODE_MOVED",
+		5: "TREE_EVENT_TYPE_NODE_PARENTS_CHANGED",
+	}
+	TreeEventType_value = map[string]int32{
+		"TREE_EVENT_TYPE_UNSPECIFIED":          0,
+		"TREE_EVENT_TYPE_NODE_CREATED":         1,
+		"TREE_EVENT_TYPE_NODE_UPDATED":         2,
+		"TREE_EVENT_TYPE_NODE_DELETED":         3,
+		"TREE_EVENT_TYPE_NODE_MOVED":           4,
+		"TREE_EVENT_TYPE_NODE_PARENTS_CHANGED": 5,
+	}
+)
+
+func (x TreeEventType) Enum() *TreeEventType {
+	p := new(TreeEventType)
+	*p = x
+	return p
+}
+
+func (x TreeEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TreeEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_node_v1_node_proto_enumTypes[3].Descriptor()
+}
+
+func (TreeEventType) Type() protoreflect.EnumType {
+	return &file_node_v1_node_proto_enumTypes[3]
+}
+
+func (x TreeEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TreeEventType.Descriptor instead.
+func (TreeEventType) EnumDescriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{3}
+}
+
+type SnapshotChangeType int32
+
+const (
+	SnapshotChangeType_SNAPSHOT_CHANGE_TYPE_UNSPECIFIED SnapshotChangeType = 0
+	SnapshotChangeType_SNAPSHOT_CHANGE_TYPE_ADDED       SnapshotChangeType = 1 // มีตอนนี้ ไม่มีใน snapshot
+	SnapshotChangeType_SNAPSHOT_CHANGE_TYPE_REMOVED     SnapshotChangeType = 2 // มีใน snapshot ไม่มีตอนนี้
+	SnapshotChangeType_SNAPSHOT_CHANGE_TYPE_MODIFIED    SnapshotChangeType = 3
+)
+
+// Enum value maps for SnapshotChangeType.
+var (
+	SnapshotChangeType_name = map[int32]string{
+		0: "SNAPSHOT_CHANGE_TYPE_UNSPECIFIED",
+		1: "SNAPSHOT_CHANGE_TYPE_ADDED",
+		2: "SNAPSHOT_CHANGE_TYPE_REMOVED",
+		3: "SNAPSHOT_CHANGE_TYPE_MODIFIED",
+	}
+	SnapshotChangeType_value = map[string]int32{
+		"SNAPSHOT_CHANGE_TYPE_UNSPECIFIED": 0,
+		"SNAPSHOT_CHANGE_TYPE_ADDED":       1,
+		"SNAPSHOT_CHANGE_TYPE_REMOVED":     2,
+		"SNAPSHOT_CHANGE_TYPE_MODIFIED":    3,
+	}
+)
+
+func (x SnapshotChangeType) Enum() *SnapshotChangeType {
+	p := new(SnapshotChangeType)
+	*p = x
+	return p
+}
+
+func (x SnapshotChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SnapshotChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_node_v1_node_proto_enumTypes[4].Descriptor()
+}
+
+func (SnapshotChangeType) Type() protoreflect.EnumType {
+	return &file_node_v1_node_proto_enumTypes[4]
+}
+
+func (x SnapshotChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SnapshotChangeType.Descriptor instead.
+func (SnapshotChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{4}
+}
+
+// ★ Trash: node / tree ที่ลบแล้วอยู่ในถังขยะจนกว่าจะครบ retention แล้วถูกลบจริง
+type TrashItemType int32
+
+const (
+	TrashItemType_TRASH_ITEM_TYPE_UNSPECIFIED TrashItemType = 0
+	TrashItemType_TRASH_ITEM_TYPE_TREE        TrashItemType = 1
+	TrashItemType_TRASH_ITEM_TYPE_NODE        TrashItemType = 2
+)
+
+// Enum value maps for TrashItemType.
+var (
+	TrashItemType_name = map[int32]string{
+		0: "TRASH_ITEM_TYPE_UNSPECIFIED",
+		1: "TRASH_ITEM_TYPE_TREE",
+		2: "TRASH_ITEM_TYPE_NODE",
+	}
+	TrashItemType_value = map[string]int32{
+		"TRASH_ITEM_TYPE_UNSPECIFIED": 0,
+		"TRASH_ITEM_TYPE_TREE":        1,
+		"TRASH_ITEM_TYPE_NODE":        2,
+	}
+)
+
+func (x TrashItemType) Enum() *TrashItemType {
+	p := new(TrashItemType)
+	*p = x
+	return p
+}
+
+func (x TrashItemType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TrashItemType) Descriptor() protoreflect.EnumDescriptor {
+	return file_node_v1_node_proto_enumTypes[5].Descriptor()
+}
+
+func (TrashItemType) Type() protoreflect.EnumType {
+	return &file_node_v1_node_proto_enumTypes[5]
+}
+
+func (x TrashItemType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TrashItemType.Descriptor instead.
+func (TrashItemType) EnumDescriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{5}
+}
+
+// SharedDescendantPolicy วิธีจัดการ descendant ที่มี parent อยู่นอกสาย (multi-parent)
+type SharedDescendantPolicy int32
+
+const (
+	SharedDescendantPolicy_SHARED_DESCENDANT_POLICY_UNSPECIFIED SharedDescendantPolicy = 0 // = LEAVE
+	// ไม่แตะ: อยู่กับ parent นอกสายต่อ (descendants ของมันที่ไม่มีทางอื่นก็อยู่ต่อด้วย)
+	// ลบ = ตัดแค่เส้นจากในสาย, คัดลอก = ไม่คัดลอก, ย้าย = ตัดเส้นจากในสายแล้วทิ้งไว้ที่เดิม
+	SharedDescendantPolicy_SHARED_DESCENDANT_POLICY_LEAVE SharedDescendantPolicy = 1
+	// เอาไปด้วย: ลบ = ลบด้วย, คัดลอก = คัดลอกด้วย (สำเนาต่อกับ parent ในสายเท่านั้น),
+	// ย้าย = ย้ายไปด้วยและตัดเส้นจาก parent นอกสาย
+	SharedDescendantPolicy_SHARED_DESCENDANT_POLICY_TAKE SharedDescendantPolicy = 2
+)
+
+// Enum value maps for SharedDescendantPolicy.
+var (
+	SharedDescendantPolicy_name = map[int32]string{
+		0: "SHARED_DESCENDANT_POLICY_UNSPECIFIED",
+		1: "SHARED_DESCENDANT_POLICY_LEAVE",
+		2: "SHARED_DESCENDANT_POLICY_TAKE",
+	}
+	SharedDescendantPolicy_value = map[string]int32{
+		"SHARED_DESCENDANT_POLICY_UNSPECIFIED": 0,
+		"SHARED_DESCENDANT_POLICY_LEAVE":       1,
+		"SHARED_DESCENDANT_POLICY_TAKE":        2,
+	}
+)
+
+func (x SharedDescendantPolicy) Enum() *SharedDescendantPolicy {
+	p := new(SharedDescendantPolicy)
+	*p = x
+	return p
+}
+
+func (x SharedDescendantPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SharedDescendantPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_node_v1_node_proto_enumTypes[6].Descriptor()
+}
+
+func (SharedDescendantPolicy) Type() protoreflect.EnumType {
+	return &file_node_v1_node_proto_enumTypes[6]
+}
+
+func (x SharedDescendantPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SharedDescendantPolicy.Descriptor instead.
+func (SharedDescendantPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{6}
+}
+
+// ความสัมพันธ์ของ node_b เมื่อมองจาก node_a
+type RelationshipKind int32
+
+const (
+	RelationshipKind_RELATIONSHIP_KIND_UNSPECIFIED RelationshipKind = 0
+	RelationshipKind_RELATIONSHIP_KIND_UNRELATED   RelationshipKind = 1 // ไม่มีพี่รหัสร่วมกัน
+	RelationshipKind_RELATIONSHIP_KIND_SELF        RelationshipKind = 2
+	RelationshipKind_RELATIONSHIP_KIND_ANCESTOR    RelationshipKind = 3 // b เป็นพี่รหัส (ทุกชั้น) ของ a
+	RelationshipKind_RELATIONSHIP_KIND_DESCENDANT  RelationshipKind = 4 // b เป็นน้องรหัส (ทุกชั้น) ของ a
+	RelationshipKind_RELATIONSHIP_KIND_SIBLING     RelationshipKind = 5 // มีพี่รหัสคนเดียวกัน
+	RelationshipKind_RELATIONSHIP_KIND_COUSIN      RelationshipKind = 6 // มีพี่รหัสร่วมกันหลายชั้นขึ้นไป
+)
+
+// Enum value maps for RelationshipKind.
+var (
+	RelationshipKind_name = map[int32]string{
+		0: "RELATIONSHIP_KIND_UNSPECIFIED",
+		1: "RELATIONSHIP_KIND_UNRELATED",
+		2: "RELATIONSHIP_KIND_SELF",
+		3: "RELATIONSHIP_KIND_ANCESTOR",
+		4: "RELATIONSHIP_KIND_DESCENDANT",
+		5: "RELATIONSHIP_KIND_SIBLING",
+		6: "RELATIONSHIP_KIND_COUSIN",
+	}
+	RelationshipKind_value = map[string]int32{
+		"RELATIONSHIP_KIND_UNSPECIFIED": 0,
+		"RELATIONSHIP_KIND_UNRELATED":   1,
+		"RELATIONSHIP_KIND_SELF":        2,
+		"RELATIONSHIP_KIND_ANCESTOR":    3,
+		"RELATIONSHIP_KIND_DESCENDANT":  4,
+		"RELATIONSHIP_KIND_SIBLING":     5,
+		"RELATIONSHIP_KIND_COUSIN":      6,
+	}
+)
+
+func (x RelationshipKind) Enum() *RelationshipKind {
+	p := new(RelationshipKind)
+	*p = x
+	return p
+}
+
+func (x RelationshipKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RelationshipKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_node_v1_node_proto_enumTypes[7].Descriptor()
+}
+
+func (RelationshipKind) Type() protoreflect.EnumType {
+	return &file_node_v1_node_proto_enumTypes[7]
+}
+
+func (x RelationshipKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RelationshipKind.Descriptor instead.
+func (RelationshipKind) EnumDescriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{7}
+}
+
 type Node struct {
 	state        protoimpl.MessageState `protogen:"open.v1"`
 	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -85,20 +475,24 @@ type Node struct {
 	Generation   int32                  `protobuf:"varint,8,opt,name=generation,proto3" json:"generation,omitempty"`
 	PhotoUrl     string                 `protobuf:"bytes,9,opt,name=photo_url,json=photoUrl,proto3" json:"photo_url,omitempty"`
 	Status       NodeStatus             `protobuf:"varint,10,opt,name=status,proto3,enum=node.v1.NodeStatus" json:"status,omitempty"`
-	SiblingOrder int32                  `protobuf:"varint,11,opt,name=sibling_order,json=siblingOrder,proto3" json:"sibling_order,omitempty"`
+	SiblingOrder int32                  `protobuf:"varint,11,opt,name=sibling_order,json=siblingOrder,proto3" json:"sibling_order,omitempty"` // ลำดับใน children ของ parent_ids[0] (root = ลำดับใน rootIds)
 	PositionX    float64                `protobuf:"fixed64,12,opt,name=position_x,json=positionX,proto3" json:"position_x,omitempty"`
 	PositionY    float64                `protobuf:"fixed64,13,opt,name=position_y,json=positionY,proto3" json:"position_y,omitempty"`
 	CreatedAt    string                 `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt    string                 `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	ParentIds    []string               `protobuf:"bytes,16,rep,name=parent_ids,json=parentIds,proto3" json:"parent_ids,omitempty"` // รองรับ multi-parent (DAG)
 	// ช่องทางติดต่อ
-	Phone         string `protobuf:"bytes,17,opt,name=phone,proto3" json:"phone,omitempty"`
-	Email         string `protobuf:"bytes,18,opt,name=email,proto3" json:"email,omitempty"`
-	LineId        string `protobuf:"bytes,19,opt,name=line_id,json=lineId,proto3" json:"line_id,omitempty"`
-	Discord       string `protobuf:"bytes,20,opt,name=discord,proto3" json:"discord,omitempty"`
-	Facebook      string `protobuf:"bytes,21,opt,name=facebook,proto3" json:"facebook,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Phone         string           `protobuf:"bytes,17,opt,name=phone,proto3" json:"phone,omitempty"`
+	Email         string           `protobuf:"bytes,18,opt,name=email,proto3" json:"email,omitempty"`
+	LineId        string           `protobuf:"bytes,19,opt,name=line_id,json=lineId,proto3" json:"line_id,omitempty"`
+	Discord       string           `protobuf:"bytes,20,opt,name=discord,proto3" json:"discord,omitempty"`
+	Facebook      string           `protobuf:"bytes,21,opt,name=facebook,proto3" json:"facebook,omitempty"`
+	SiblingOrders map[string]int32 `protobuf:"bytes,22,rep,name=sibling_orders,json=siblingOrders,proto3" json:"sibling_orders,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // parent_id → ลำดับใน children ของ parent นั้น (multi-parent)
+	// visibility ที่ตั้งไว้ราย node (ส่งให้ editor / owner เท่านั้น)
+	// ช่องทางติดต่อที่ caller ไม่มีสิทธิ์เห็นจะเป็น "" ใน field ด้านบน
+	ContactPrivacy *v1.ContactPrivacy `protobuf:"bytes,23,opt,name=contact_privacy,json=contactPrivacy,proto3" json:"contact_privacy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Node) Reset() {
@@ -278,6 +672,20 @@ func (x *Node) GetFacebook() string {
 	return ""
 }
 
+func (x *Node) GetSiblingOrders() map[string]int32 {
+	if x != nil {
+		return x.SiblingOrders
+	}
+	return nil
+}
+
+func (x *Node) GetContactPrivacy() *v1.ContactPrivacy {
+	if x != nil {
+		return x.ContactPrivacy
+	}
+	return nil
+}
+
 type CreateNodeRequest struct {
 	state      protoimpl.MessageState `protogen:"open.v1"`
 	TreeId     string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
@@ -291,13 +699,19 @@ type CreateNodeRequest struct {
 	Generation int32                  `protobuf:"varint,9,opt,name=generation,proto3" json:"generation,omitempty"`
 	ParentIds  []string               `protobuf:"bytes,10,rep,name=parent_ids,json=parentIds,proto3" json:"parent_ids,omitempty"` // multi-parent: ถ้ามี จะใช้แทน parent_id
 	// ช่องทางติดต่อ
-	Phone         string `protobuf:"bytes,11,opt,name=phone,proto3" json:"phone,omitempty"`
-	Email         string `protobuf:"bytes,12,opt,name=email,proto3" json:"email,omitempty"`
-	LineId        string `protobuf:"bytes,13,opt,name=line_id,json=lineId,proto3" json:"line_id,omitempty"`
-	Discord       string `protobuf:"bytes,14,opt,name=discord,proto3" json:"discord,omitempty"`
-	Facebook      string `protobuf:"bytes,15,opt,name=facebook,proto3" json:"facebook,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Phone    string `protobuf:"bytes,11,opt,name=phone,proto3" json:"phone,omitempty"`
+	Email    string `protobuf:"bytes,12,opt,name=email,proto3" json:"email,omitempty"`
+	LineId   string `protobuf:"bytes,13,opt,name=line_id,json=lineId,proto3" json:"line_id,omitempty"`
+	Discord  string `protobuf:"bytes,14,opt,name=discord,proto3" json:"discord,omitempty"`
+	Facebook string `protobuf:"bytes,15,opt,name=facebook,proto3" json:"facebook,omitempty"`
+	// structure_revision ที่ client เห็นล่าสุด ถ้าไม่ตรงกับ server จะได้ CodeAborted
+	ExpectedRevision *int64 `protobuf:"varint,16,opt,name=expected_revision,json=expectedRevision,proto3,oneof" json:"expected_revision,omitempty"`
+	// ลำดับใน children ของ parent ตัวแรก (หรือใน rootIds) ไม่ส่ง = ต่อท้าย
+	SiblingOrder *int32 `protobuf:"varint,17,opt,name=sibling_order,json=siblingOrder,proto3,oneof" json:"sibling_order,omitempty"`
+	// visibility ราย node (ไม่ส่ง = ใช้ค่าของ tree)
+	ContactPrivacy *v1.ContactPrivacy `protobuf:"bytes,18,opt,name=contact_privacy,json=contactPrivacy,proto3" json:"contact_privacy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CreateNodeRequest) Reset() {
@@ -435,11 +849,33 @@ func (x *CreateNodeRequest) GetFacebook() string {
 	return ""
 }
 
+func (x *CreateNodeRequest) GetExpectedRevision() int64 {
+	if x != nil && x.ExpectedRevision != nil {
+		return *x.ExpectedRevision
+	}
+	return 0
+}
+
+func (x *CreateNodeRequest) GetSiblingOrder() int32 {
+	if x != nil && x.SiblingOrder != nil {
+		return *x.SiblingOrder
+	}
+	return 0
+}
+
+func (x *CreateNodeRequest) GetContactPrivacy() *v1.ContactPrivacy {
+	if x != nil {
+		return x.ContactPrivacy
+	}
+	return nil
+}
+
 type CreateNodeResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Node          *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Node              *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	StructureRevision int64                  `protobuf:"varint,2,opt,name=structure_revision,json=structureRevision,proto3" json:"structure_revision,omitempty"` // revision ใหม่หลังแก้
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *CreateNodeResponse) Reset() {
@@ -479,6 +915,13 @@ func (x *CreateNodeResponse) GetNode() *Node {
 	return nil
 }
 
+func (x *CreateNodeResponse) GetStructureRevision() int64 {
+	if x != nil {
+		return x.StructureRevision
+	}
+	return 0
+}
+
 type UpdateNodeRequest struct {
 	state      protoimpl.MessageState `protogen:"open.v1"`
 	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -490,13 +933,15 @@ type UpdateNodeRequest struct {
 	Status     NodeStatus             `protobuf:"varint,7,opt,name=status,proto3,enum=node.v1.NodeStatus" json:"status,omitempty"`
 	Generation int32                  `protobuf:"varint,8,opt,name=generation,proto3" json:"generation,omitempty"`
 	// ช่องทางติดต่อ
-	Phone         string `protobuf:"bytes,9,opt,name=phone,proto3" json:"phone,omitempty"`
-	Email         string `protobuf:"bytes,10,opt,name=email,proto3" json:"email,omitempty"`
-	LineId        string `protobuf:"bytes,11,opt,name=line_id,json=lineId,proto3" json:"line_id,omitempty"`
-	Discord       string `protobuf:"bytes,12,opt,name=discord,proto3" json:"discord,omitempty"`
-	Facebook      string `protobuf:"bytes,13,opt,name=facebook,proto3" json:"facebook,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Phone    string `protobuf:"bytes,9,opt,name=phone,proto3" json:"phone,omitempty"`
+	Email    string `protobuf:"bytes,10,opt,name=email,proto3" json:"email,omitempty"`
+	LineId   string `protobuf:"bytes,11,opt,name=line_id,json=lineId,proto3" json:"line_id,omitempty"`
+	Discord  string `protobuf:"bytes,12,opt,name=discord,proto3" json:"discord,omitempty"`
+	Facebook string `protobuf:"bytes,13,opt,name=facebook,proto3" json:"facebook,omitempty"`
+	// visibility ราย node (ไม่ส่ง = คงค่าเดิม, field เป็น UNSPECIFIED = ใช้ค่าของ tree)
+	ContactPrivacy *v1.ContactPrivacy `protobuf:"bytes,14,opt,name=contact_privacy,json=contactPrivacy,proto3" json:"contact_privacy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *UpdateNodeRequest) Reset() {
@@ -620,6 +1065,13 @@ func (x *UpdateNodeRequest) GetFacebook() string {
 	return ""
 }
 
+func (x *UpdateNodeRequest) GetContactPrivacy() *v1.ContactPrivacy {
+	if x != nil {
+		return x.ContactPrivacy
+	}
+	return nil
+}
+
 type UpdateNodeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Node          *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
@@ -664,11 +1116,13 @@ func (x *UpdateNodeResponse) GetNode() *Node {
 	return nil
 }
 
+// ลบ = ย้ายลงถังขยะ (กู้คืนด้วย RestoreFromTrash) children ย้ายขึ้นไปต่อกับ parent
 type DeleteNodeRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedRevision *int64                 `protobuf:"varint,2,opt,name=expected_revision,json=expectedRevision,proto3,oneof" json:"expected_revision,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *DeleteNodeRequest) Reset() {
@@ -708,10 +1162,18 @@ func (x *DeleteNodeRequest) GetId() string {
 	return ""
 }
 
+func (x *DeleteNodeRequest) GetExpectedRevision() int64 {
+	if x != nil && x.ExpectedRevision != nil {
+		return *x.ExpectedRevision
+	}
+	return 0
+}
+
 type DeleteNodeResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	StructureRevision int64                  `protobuf:"varint,1,opt,name=structure_revision,json=structureRevision,proto3" json:"structure_revision,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *DeleteNodeResponse) Reset() {
@@ -744,13 +1206,21 @@ func (*DeleteNodeResponse) Descriptor() ([]byte, []int) {
 	return file_node_v1_node_proto_rawDescGZIP(), []int{6}
 }
 
+func (x *DeleteNodeResponse) GetStructureRevision() int64 {
+	if x != nil {
+		return x.StructureRevision
+	}
+	return 0
+}
+
 type MoveNodeRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	NewParentId   string                 `protobuf:"bytes,2,opt,name=new_parent_id,json=newParentId,proto3" json:"new_parent_id,omitempty"` // ย้ายไปอยู่ใต้ parent ใหม่
-	SiblingOrder  int32                  `protobuf:"varint,3,opt,name=sibling_order,json=siblingOrder,proto3" json:"sibling_order,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	NodeId           string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	NewParentId      string                 `protobuf:"bytes,2,opt,name=new_parent_id,json=newParentId,proto3" json:"new_parent_id,omitempty"`         // ย้ายไปอยู่ใต้ parent ใหม่
+	SiblingOrder     *int32                 `protobuf:"varint,3,opt,name=sibling_order,json=siblingOrder,proto3,oneof" json:"sibling_order,omitempty"` // ลำดับใน children ของ parent ใหม่ ไม่ส่ง = ต่อท้าย
+	ExpectedRevision *int64                 `protobuf:"varint,4,opt,name=expected_revision,json=expectedRevision,proto3,oneof" json:"expected_revision,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *MoveNodeRequest) Reset() {
@@ -798,17 +1268,25 @@ func (x *MoveNodeRequest) GetNewParentId() string {
 }
 
 func (x *MoveNodeRequest) GetSiblingOrder() int32 {
-	if x != nil {
-		return x.SiblingOrder
+	if x != nil && x.SiblingOrder != nil {
+		return *x.SiblingOrder
+	}
+	return 0
+}
+
+func (x *MoveNodeRequest) GetExpectedRevision() int64 {
+	if x != nil && x.ExpectedRevision != nil {
+		return *x.ExpectedRevision
 	}
 	return 0
 }
 
 type MoveNodeResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Node          *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Node              *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	StructureRevision int64                  `protobuf:"varint,2,opt,name=structure_revision,json=structureRevision,proto3" json:"structure_revision,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *MoveNodeResponse) Reset() {
@@ -848,8 +1326,15 @@ func (x *MoveNodeResponse) GetNode() *Node {
 	return nil
 }
 
-type GetTreeNodesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *MoveNodeResponse) GetStructureRevision() int64 {
+	if x != nil {
+		return x.StructureRevision
+	}
+	return 0
+}
+
+type GetTreeNodesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -893,10 +1378,11 @@ func (x *GetTreeNodesRequest) GetTreeId() string {
 }
 
 type GetTreeNodesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Nodes         []*Node                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Nodes             []*Node                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	StructureRevision int64                  `protobuf:"varint,2,opt,name=structure_revision,json=structureRevision,proto3" json:"structure_revision,omitempty"` // ใช้เป็น expected_revision ตอนแก้ครั้งถัดไป
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *GetTreeNodesResponse) Reset() {
@@ -936,11 +1422,19 @@ func (x *GetTreeNodesResponse) GetNodes() []*Node {
 	return nil
 }
 
+func (x *GetTreeNodesResponse) GetStructureRevision() int64 {
+	if x != nil {
+		return x.StructureRevision
+	}
+	return 0
+}
+
 type UnlinkNodeRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"` // node ที่จะตัดสาย (set parent = null / ลบ parent ทั้งหมด)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	NodeId           string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"` // node ที่จะตัดสาย (set parent = null / ลบ parent ทั้งหมด)
+	ExpectedRevision *int64                 `protobuf:"varint,2,opt,name=expected_revision,json=expectedRevision,proto3,oneof" json:"expected_revision,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *UnlinkNodeRequest) Reset() {
@@ -980,11 +1474,19 @@ func (x *UnlinkNodeRequest) GetNodeId() string {
 	return ""
 }
 
+func (x *UnlinkNodeRequest) GetExpectedRevision() int64 {
+	if x != nil && x.ExpectedRevision != nil {
+		return *x.ExpectedRevision
+	}
+	return 0
+}
+
 type UnlinkNodeResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Node          *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Node              *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	StructureRevision int64                  `protobuf:"varint,2,opt,name=structure_revision,json=structureRevision,proto3" json:"structure_revision,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *UnlinkNodeResponse) Reset() {
@@ -1024,13 +1526,22 @@ func (x *UnlinkNodeResponse) GetNode() *Node {
 	return nil
 }
 
+func (x *UnlinkNodeResponse) GetStructureRevision() int64 {
+	if x != nil {
+		return x.StructureRevision
+	}
+	return 0
+}
+
 // ★ NEW: เพิ่มพี่ให้ node (รองรับ multi-parent)
 type AddParentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`       // node ที่จะเพิ่มพี่
-	ParentId      string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"` // parent ที่จะเพิ่ม
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	NodeId           string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`       // node ที่จะเพิ่มพี่
+	ParentId         string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"` // parent ที่จะเพิ่ม
+	ExpectedRevision *int64                 `protobuf:"varint,3,opt,name=expected_revision,json=expectedRevision,proto3,oneof" json:"expected_revision,omitempty"`
+	SiblingOrder     *int32                 `protobuf:"varint,4,opt,name=sibling_order,json=siblingOrder,proto3,oneof" json:"sibling_order,omitempty"` // ลำดับใน children ของ parent นี้ ไม่ส่ง = ต่อท้าย
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *AddParentRequest) Reset() {
@@ -1077,11 +1588,26 @@ func (x *AddParentRequest) GetParentId() string {
 	return ""
 }
 
+func (x *AddParentRequest) GetExpectedRevision() int64 {
+	if x != nil && x.ExpectedRevision != nil {
+		return *x.ExpectedRevision
+	}
+	return 0
+}
+
+func (x *AddParentRequest) GetSiblingOrder() int32 {
+	if x != nil && x.SiblingOrder != nil {
+		return *x.SiblingOrder
+	}
+	return 0
+}
+
 type AddParentResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Node          *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Node              *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	StructureRevision int64                  `protobuf:"varint,2,opt,name=structure_revision,json=structureRevision,proto3" json:"structure_revision,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *AddParentResponse) Reset() {
@@ -1121,13 +1647,21 @@ func (x *AddParentResponse) GetNode() *Node {
 	return nil
 }
 
+func (x *AddParentResponse) GetStructureRevision() int64 {
+	if x != nil {
+		return x.StructureRevision
+	}
+	return 0
+}
+
 // ★ NEW: ตัดสายจาก parent เฉพาะตัว
 type RemoveParentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`       // node ที่จะตัดสาย
-	ParentId      string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"` // parent ที่จะตัดออก
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	NodeId           string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`       // node ที่จะตัดสาย
+	ParentId         string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"` // parent ที่จะตัดออก
+	ExpectedRevision *int64                 `protobuf:"varint,3,opt,name=expected_revision,json=expectedRevision,proto3,oneof" json:"expected_revision,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *RemoveParentRequest) Reset() {
@@ -1174,11 +1708,19 @@ func (x *RemoveParentRequest) GetParentId() string {
 	return ""
 }
 
+func (x *RemoveParentRequest) GetExpectedRevision() int64 {
+	if x != nil && x.ExpectedRevision != nil {
+		return *x.ExpectedRevision
+	}
+	return 0
+}
+
 type RemoveParentResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Node          *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Node              *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	StructureRevision int64                  `protobuf:"varint,2,opt,name=structure_revision,json=structureRevision,proto3" json:"structure_revision,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *RemoveParentResponse) Reset() {
@@ -1218,6 +1760,170 @@ func (x *RemoveParentResponse) GetNode() *Node {
 	return nil
 }
 
+func (x *RemoveParentResponse) GetStructureRevision() int64 {
+	if x != nil {
+		return x.StructureRevision
+	}
+	return 0
+}
+
+// ★ Layout: บันทึกตำแหน่งการ์ดบน canvas หลาย node ในครั้งเดียว
+type NodePosition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	PositionX     float64                `protobuf:"fixed64,2,opt,name=position_x,json=positionX,proto3" json:"position_x,omitempty"`
+	PositionY     float64                `protobuf:"fixed64,3,opt,name=position_y,json=positionY,proto3" json:"position_y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodePosition) Reset() {
+	*x = NodePosition{}
+	mi := &file_node_v1_node_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodePosition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodePosition) ProtoMessage() {}
+
+func (x *NodePosition) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodePosition.ProtoReflect.Descriptor instead.
+func (*NodePosition) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *NodePosition) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *NodePosition) GetPositionX() float64 {
+	if x != nil {
+		return x.PositionX
+	}
+	return 0
+}
+
+func (x *NodePosition) GetPositionY() float64 {
+	if x != nil {
+		return x.PositionY
+	}
+	return 0
+}
+
+type UpdateLayoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	Positions     []*NodePosition        `protobuf:"bytes,2,rep,name=positions,proto3" json:"positions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLayoutRequest) Reset() {
+	*x = UpdateLayoutRequest{}
+	mi := &file_node_v1_node_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLayoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLayoutRequest) ProtoMessage() {}
+
+func (x *UpdateLayoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLayoutRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLayoutRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateLayoutRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *UpdateLayoutRequest) GetPositions() []*NodePosition {
+	if x != nil {
+		return x.Positions
+	}
+	return nil
+}
+
+type UpdateLayoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpdatedCount  int32                  `protobuf:"varint,1,opt,name=updated_count,json=updatedCount,proto3" json:"updated_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLayoutResponse) Reset() {
+	*x = UpdateLayoutResponse{}
+	mi := &file_node_v1_node_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLayoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLayoutResponse) ProtoMessage() {}
+
+func (x *UpdateLayoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLayoutResponse.ProtoReflect.Descriptor instead.
+func (*UpdateLayoutResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateLayoutResponse) GetUpdatedCount() int32 {
+	if x != nil {
+		return x.UpdatedCount
+	}
+	return 0
+}
+
 // ★ Public: ดู nodes ผ่าน share token (ไม่ต้อง login)
 type GetNodesByShareTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1228,7 +1934,7 @@ type GetNodesByShareTokenRequest struct {
 
 func (x *GetNodesByShareTokenRequest) Reset() {
 	*x = GetNodesByShareTokenRequest{}
-	mi := &file_node_v1_node_proto_msgTypes[17]
+	mi := &file_node_v1_node_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1240,7 +1946,7 @@ func (x *GetNodesByShareTokenRequest) String() string {
 func (*GetNodesByShareTokenRequest) ProtoMessage() {}
 
 func (x *GetNodesByShareTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_node_v1_node_proto_msgTypes[17]
+	mi := &file_node_v1_node_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1253,7 +1959,7 @@ func (x *GetNodesByShareTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNodesByShareTokenRequest.ProtoReflect.Descriptor instead.
 func (*GetNodesByShareTokenRequest) Descriptor() ([]byte, []int) {
-	return file_node_v1_node_proto_rawDescGZIP(), []int{17}
+	return file_node_v1_node_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *GetNodesByShareTokenRequest) GetShareToken() string {
@@ -1272,7 +1978,7 @@ type GetNodesByShareTokenResponse struct {
 
 func (x *GetNodesByShareTokenResponse) Reset() {
 	*x = GetNodesByShareTokenResponse{}
-	mi := &file_node_v1_node_proto_msgTypes[18]
+	mi := &file_node_v1_node_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1284,7 +1990,7 @@ func (x *GetNodesByShareTokenResponse) String() string {
 func (*GetNodesByShareTokenResponse) ProtoMessage() {}
 
 func (x *GetNodesByShareTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_node_v1_node_proto_msgTypes[18]
+	mi := &file_node_v1_node_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1297,7 +2003,7 @@ func (x *GetNodesByShareTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNodesByShareTokenResponse.ProtoReflect.Descriptor instead.
 func (*GetNodesByShareTokenResponse) Descriptor() ([]byte, []int) {
-	return file_node_v1_node_proto_rawDescGZIP(), []int{18}
+	return file_node_v1_node_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *GetNodesByShareTokenResponse) GetNodes() []*Node {
@@ -1307,77 +2013,2705 @@ func (x *GetNodesByShareTokenResponse) GetNodes() []*Node {
 	return nil
 }
 
-var File_node_v1_node_proto protoreflect.FileDescriptor
+type ImportNodesRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TreeId           string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	Format           ImportFormat           `protobuf:"varint,2,opt,name=format,proto3,enum=node.v1.ImportFormat" json:"format,omitempty"`
+	Data             []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	DryRun           bool                   `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // true = ตรวจอย่างเดียว ไม่บันทึก
+	ExpectedRevision *int64                 `protobuf:"varint,5,opt,name=expected_revision,json=expectedRevision,proto3,oneof" json:"expected_revision,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
 
-const file_node_v1_node_proto_rawDesc = "" +
-	"\n" +
-	"\x12node/v1/node.proto\x12\anode.v1\"\xfb\x04\n" +
-	"\x04Node\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\atree_id\x18\x02 \x01(\tR\x06treeId\x12 \n" +
-	"\tparent_id\x18\x03 \x01(\tH\x00R\bparentId\x88\x01\x01\x12\x1a\n" +
-	"\bnickname\x18\x04 \x01(\tR\bnickname\x12\x1d\n" +
-	"\n" +
-	"first_name\x18\x05 \x01(\tR\tfirstName\x12\x1b\n" +
-	"\tlast_name\x18\x06 \x01(\tR\blastName\x12\x1d\n" +
-	"\n" +
-	"student_id\x18\a \x01(\tR\tstudentId\x12\x1e\n" +
-	"\n" +
-	"generation\x18\b \x01(\x05R\n" +
-	"generation\x12\x1b\n" +
-	"\tphoto_url\x18\t \x01(\tR\bphotoUrl\x12+\n" +
-	"\x06status\x18\n" +
-	" \x01(\x0e2\x13.node.v1.NodeStatusR\x06status\x12#\n" +
-	"\rsibling_order\x18\v \x01(\x05R\fsiblingOrder\x12\x1d\n" +
-	"\n" +
-	"position_x\x18\f \x01(\x01R\tpositionX\x12\x1d\n" +
-	"\n" +
-	"position_y\x18\r \x01(\x01R\tpositionY\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x0e \x01(\tR\tcreatedAt\x12\x1d\n" +
-	"\n" +
-	"updated_at\x18\x0f \x01(\tR\tupdatedAt\x12\x1d\n" +
-	"\n" +
-	"parent_ids\x18\x10 \x03(\tR\tparentIds\x12\x14\n" +
-	"\x05phone\x18\x11 \x01(\tR\x05phone\x12\x14\n" +
-	"\x05email\x18\x12 \x01(\tR\x05email\x12\x17\n" +
-	"\aline_id\x18\x13 \x01(\tR\x06lineId\x12\x18\n" +
-	"\adiscord\x18\x14 \x01(\tR\adiscord\x12\x1a\n" +
-	"\bfacebook\x18\x15 \x01(\tR\bfacebookB\f\n" +
-	"\n" +
-	"_parent_id\"\xd7\x03\n" +
-	"\x11CreateNodeRequest\x12\x17\n" +
-	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12 \n" +
-	"\tparent_id\x18\x02 \x01(\tH\x00R\bparentId\x88\x01\x01\x12\x1a\n" +
-	"\bnickname\x18\x03 \x01(\tR\bnickname\x12\x1d\n" +
-	"\n" +
-	"first_name\x18\x04 \x01(\tR\tfirstName\x12\x1b\n" +
-	"\tlast_name\x18\x05 \x01(\tR\blastName\x12\x1d\n" +
-	"\n" +
-	"student_id\x18\x06 \x01(\tR\tstudentId\x12\x1b\n" +
-	"\tphoto_url\x18\a \x01(\tR\bphotoUrl\x12+\n" +
-	"\x06status\x18\b \x01(\x0e2\x13.node.v1.NodeStatusR\x06status\x12\x1e\n" +
-	"\n" +
-	"generation\x18\t \x01(\x05R\n" +
-	"generation\x12\x1d\n" +
-	"\n" +
-	"parent_ids\x18\n" +
-	" \x03(\tR\tparentIds\x12\x14\n" +
-	"\x05phone\x18\v \x01(\tR\x05phone\x12\x14\n" +
-	"\x05email\x18\f \x01(\tR\x05email\x12\x17\n" +
-	"\aline_id\x18\r \x01(\tR\x06lineId\x12\x18\n" +
-	"\adiscord\x18\x0e \x01(\tR\adiscord\x12\x1a\n" +
-	"\bfacebook\x18\x0f \x01(\tR\bfacebookB\f\n" +
-	"\n" +
-	"_parent_id\"7\n" +
-	"\x12CreateNodeResponse\x12!\n" +
-	"\x04node\x18\x01 \x01(\v2\r.node.v1.NodeR\x04node\"\xff\x02\n" +
-	"\x11UpdateNodeRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
-	"\bnickname\x18\x02 \x01(\tR\bnickname\x12\x1d\n" +
-	"\n" +
-	"first_name\x18\x03 \x01(\tR\tfirstName\x12\x1b\n" +
-	"\tlast_name\x18\x04 \x01(\tR\blastName\x12\x1d\n" +
+func (x *ImportNodesRequest) Reset() {
+	*x = ImportNodesRequest{}
+	mi := &file_node_v1_node_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportNodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportNodesRequest) ProtoMessage() {}
+
+func (x *ImportNodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportNodesRequest.ProtoReflect.Descriptor instead.
+func (*ImportNodesRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ImportNodesRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *ImportNodesRequest) GetFormat() ImportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ImportFormat_IMPORT_FORMAT_UNSPECIFIED
+}
+
+func (x *ImportNodesRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportNodesRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportNodesRequest) GetExpectedRevision() int64 {
+	if x != nil && x.ExpectedRevision != nil {
+		return *x.ExpectedRevision
+	}
+	return 0
+}
+
+type ImportIssue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"` // แถวในไฟล์ (header = 1)
+	Column        string                 `protobuf:"bytes,2,opt,name=column,proto3" json:"column,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportIssue) Reset() {
+	*x = ImportIssue{}
+	mi := &file_node_v1_node_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportIssue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportIssue) ProtoMessage() {}
+
+func (x *ImportIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportIssue.ProtoReflect.Descriptor instead.
+func (*ImportIssue) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ImportIssue) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportIssue) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *ImportIssue) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ImportNodesResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TotalRows         int32                  `protobuf:"varint,1,opt,name=total_rows,json=totalRows,proto3" json:"total_rows,omitempty"`
+	Issues            []*ImportIssue         `protobuf:"bytes,2,rep,name=issues,proto3" json:"issues,omitempty"` // มี issue = ไม่ import อะไรเลย
+	Applied           bool                   `protobuf:"varint,3,opt,name=applied,proto3" json:"applied,omitempty"`
+	Nodes             []*Node                `protobuf:"bytes,4,rep,name=nodes,proto3" json:"nodes,omitempty"` // node ที่สร้างใหม่ (เมื่อ applied)
+	StructureRevision int64                  `protobuf:"varint,5,opt,name=structure_revision,json=structureRevision,proto3" json:"structure_revision,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ImportNodesResponse) Reset() {
+	*x = ImportNodesResponse{}
+	mi := &file_node_v1_node_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportNodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportNodesResponse) ProtoMessage() {}
+
+func (x *ImportNodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportNodesResponse.ProtoReflect.Descriptor instead.
+func (*ImportNodesResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ImportNodesResponse) GetTotalRows() int32 {
+	if x != nil {
+		return x.TotalRows
+	}
+	return 0
+}
+
+func (x *ImportNodesResponse) GetIssues() []*ImportIssue {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+func (x *ImportNodesResponse) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+func (x *ImportNodesResponse) GetNodes() []*Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *ImportNodesResponse) GetStructureRevision() int64 {
+	if x != nil {
+		return x.StructureRevision
+	}
+	return 0
+}
+
+type ExportTreeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	Format        ExportFormat           `protobuf:"varint,2,opt,name=format,proto3,enum=node.v1.ExportFormat" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTreeRequest) Reset() {
+	*x = ExportTreeRequest{}
+	mi := &file_node_v1_node_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTreeRequest) ProtoMessage() {}
+
+func (x *ExportTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTreeRequest.ProtoReflect.Descriptor instead.
+func (*ExportTreeRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ExportTreeRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *ExportTreeRequest) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_EXPORT_FORMAT_UNSPECIFIED
+}
+
+type ExportTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTreeResponse) Reset() {
+	*x = ExportTreeResponse{}
+	mi := &file_node_v1_node_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTreeResponse) ProtoMessage() {}
+
+func (x *ExportTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTreeResponse.ProtoReflect.Descriptor instead.
+func (*ExportTreeResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ExportTreeResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ExportTreeResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportTreeResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type WatchTreeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	LastEventId   int64                  `protobuf:"varint,2,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"` // resume: event id ล่าสุดที่เห็น (0 = รับเฉพาะ event ใหม่)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTreeRequest) Reset() {
+	*x = WatchTreeRequest{}
+	mi := &file_node_v1_node_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTreeRequest) ProtoMessage() {}
+
+func (x *WatchTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTreeRequest.ProtoReflect.Descriptor instead.
+func (*WatchTreeRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *WatchTreeRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *WatchTreeRequest) GetLastEventId() int64 {
+	if x != nil {
+		return x.LastEventId
+	}
+	return 0
+}
+
+type WatchTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"` // เรียงตามลำดับ commit (ใช้เป็น last_event_id ตอน resume)
+	Type          TreeEventType          `protobuf:"varint,2,opt,name=type,proto3,enum=node.v1.TreeEventType" json:"type,omitempty"`
+	NodeId        string                 `protobuf:"bytes,3,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Node          *Node                  `protobuf:"bytes,4,opt,name=node,proto3" json:"node,omitempty"` // ข้อมูลล่าสุดของ node (ไม่มีถ้า node ถูกลบไปแล้ว)
+	OldParentIds  []string               `protobuf:"bytes,5,rep,name=old_parent_ids,json=oldParentIds,proto3" json:"old_parent_ids,omitempty"`
+	NewParentIds  []string               `protobuf:"bytes,6,rep,name=new_parent_ids,json=newParentIds,proto3" json:"new_parent_ids,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTreeResponse) Reset() {
+	*x = WatchTreeResponse{}
+	mi := &file_node_v1_node_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTreeResponse) ProtoMessage() {}
+
+func (x *WatchTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTreeResponse.ProtoReflect.Descriptor instead.
+func (*WatchTreeResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *WatchTreeResponse) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *WatchTreeResponse) GetType() TreeEventType {
+	if x != nil {
+		return x.Type
+	}
+	return TreeEventType_TREE_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *WatchTreeResponse) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *WatchTreeResponse) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *WatchTreeResponse) GetOldParentIds() []string {
+	if x != nil {
+		return x.OldParentIds
+	}
+	return nil
+}
+
+func (x *WatchTreeResponse) GetNewParentIds() []string {
+	if x != nil {
+		return x.NewParentIds
+	}
+	return nil
+}
+
+func (x *WatchTreeResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// ★ Snapshot / restore / undo
+// snapshot อัตโนมัติถูกสร้างก่อนการแก้ node ทุกครั้ง (ยกเว้น UpdateLayout)
+type Snapshot struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TreeId            string                 `protobuf:"bytes,2,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	CreatedBy         string                 `protobuf:"bytes,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	Reason            string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"` // manual | undo | restore | ชื่อ action ของ audit เช่น node_deleted
+	Label             string                 `protobuf:"bytes,5,opt,name=label,proto3" json:"label,omitempty"`
+	Scope             string                 `protobuf:"bytes,11,opt,name=scope,proto3" json:"scope,omitempty"`                      // ส่วนที่ undo ย้อนให้: tree | subtree | node
+	NodeId            *string                `protobuf:"bytes,6,opt,name=node_id,json=nodeId,proto3,oneof" json:"node_id,omitempty"` // node ที่การแก้ถัดจากนี้ไปแตะ (ไม่มีเมื่อ scope = tree)
+	UndoneAt          *string                `protobuf:"bytes,7,opt,name=undone_at,json=undoneAt,proto3,oneof" json:"undone_at,omitempty"`
+	StructureRevision int64                  `protobuf:"varint,8,opt,name=structure_revision,json=structureRevision,proto3" json:"structure_revision,omitempty"`
+	NodeCount         int32                  `protobuf:"varint,9,opt,name=node_count,json=nodeCount,proto3" json:"node_count,omitempty"` // จำนวน node ที่เก็บไว้ (scope subtree / node เก็บเฉพาะ node ในขอบเขต)
+	CreatedAt         string                 `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	mi := &file_node_v1_node_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Snapshot) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Snapshot) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *Snapshot) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Snapshot) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Snapshot) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *Snapshot) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *Snapshot) GetNodeId() string {
+	if x != nil && x.NodeId != nil {
+		return *x.NodeId
+	}
+	return ""
+}
+
+func (x *Snapshot) GetUndoneAt() string {
+	if x != nil && x.UndoneAt != nil {
+		return *x.UndoneAt
+	}
+	return ""
+}
+
+func (x *Snapshot) GetStructureRevision() int64 {
+	if x != nil {
+		return x.StructureRevision
+	}
+	return 0
+}
+
+func (x *Snapshot) GetNodeCount() int32 {
+	if x != nil {
+		return x.NodeCount
+	}
+	return 0
+}
+
+func (x *Snapshot) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type CreateSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSnapshotRequest) Reset() {
+	*x = CreateSnapshotRequest{}
+	mi := &file_node_v1_node_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSnapshotRequest) ProtoMessage() {}
+
+func (x *CreateSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*CreateSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateSnapshotRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *CreateSnapshotRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+type CreateSnapshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Snapshot      *Snapshot              `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSnapshotResponse) Reset() {
+	*x = CreateSnapshotResponse{}
+	mi := &file_node_v1_node_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSnapshotResponse) ProtoMessage() {}
+
+func (x *CreateSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*CreateSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CreateSnapshotResponse) GetSnapshot() *Snapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+type ListSnapshotsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // default 50, สูงสุด 200
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSnapshotsRequest) Reset() {
+	*x = ListSnapshotsRequest{}
+	mi := &file_node_v1_node_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsRequest) ProtoMessage() {}
+
+func (x *ListSnapshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListSnapshotsRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *ListSnapshotsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListSnapshotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Snapshots     []*Snapshot            `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSnapshotsResponse) Reset() {
+	*x = ListSnapshotsResponse{}
+	mi := &file_node_v1_node_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsResponse) ProtoMessage() {}
+
+func (x *ListSnapshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListSnapshotsResponse) GetSnapshots() []*Snapshot {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+type SnapshotChange struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	NodeId          string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Nickname        string                 `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Type            SnapshotChangeType     `protobuf:"varint,3,opt,name=type,proto3,enum=node.v1.SnapshotChangeType" json:"type,omitempty"`
+	Fields          []string               `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"` // MODIFIED: field ที่เปลี่ยน ("parents" = ย้ายสาย)
+	ParentIdsBefore []string               `protobuf:"bytes,5,rep,name=parent_ids_before,json=parentIdsBefore,proto3" json:"parent_ids_before,omitempty"`
+	ParentIdsAfter  []string               `protobuf:"bytes,6,rep,name=parent_ids_after,json=parentIdsAfter,proto3" json:"parent_ids_after,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SnapshotChange) Reset() {
+	*x = SnapshotChange{}
+	mi := &file_node_v1_node_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotChange) ProtoMessage() {}
+
+func (x *SnapshotChange) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotChange.ProtoReflect.Descriptor instead.
+func (*SnapshotChange) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SnapshotChange) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *SnapshotChange) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *SnapshotChange) GetType() SnapshotChangeType {
+	if x != nil {
+		return x.Type
+	}
+	return SnapshotChangeType_SNAPSHOT_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *SnapshotChange) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *SnapshotChange) GetParentIdsBefore() []string {
+	if x != nil {
+		return x.ParentIdsBefore
+	}
+	return nil
+}
+
+func (x *SnapshotChange) GetParentIdsAfter() []string {
+	if x != nil {
+		return x.ParentIdsAfter
+	}
+	return nil
+}
+
+// DiffSnapshot เทียบ snapshot กับสถานะปัจจุบัน (before = snapshot, after = ตอนนี้)
+type DiffSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	SnapshotId    string                 `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffSnapshotRequest) Reset() {
+	*x = DiffSnapshotRequest{}
+	mi := &file_node_v1_node_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffSnapshotRequest) ProtoMessage() {}
+
+func (x *DiffSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*DiffSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DiffSnapshotRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *DiffSnapshotRequest) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+type DiffSnapshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*SnapshotChange      `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffSnapshotResponse) Reset() {
+	*x = DiffSnapshotResponse{}
+	mi := &file_node_v1_node_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffSnapshotResponse) ProtoMessage() {}
+
+func (x *DiffSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*DiffSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DiffSnapshotResponse) GetChanges() []*SnapshotChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type RestoreSnapshotRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TreeId           string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	SnapshotId       string                 `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	RootNodeId       *string                `protobuf:"bytes,3,opt,name=root_node_id,json=rootNodeId,proto3,oneof" json:"root_node_id,omitempty"` // ไม่ส่ง = ทั้ง tree, ส่ง = เฉพาะ node นี้กับ descendants
+	ExpectedRevision *int64                 `protobuf:"varint,4,opt,name=expected_revision,json=expectedRevision,proto3,oneof" json:"expected_revision,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RestoreSnapshotRequest) Reset() {
+	*x = RestoreSnapshotRequest{}
+	mi := &file_node_v1_node_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreSnapshotRequest) ProtoMessage() {}
+
+func (x *RestoreSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*RestoreSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RestoreSnapshotRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *RestoreSnapshotRequest) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+func (x *RestoreSnapshotRequest) GetRootNodeId() string {
+	if x != nil && x.RootNodeId != nil {
+		return *x.RootNodeId
+	}
+	return ""
+}
+
+func (x *RestoreSnapshotRequest) GetExpectedRevision() int64 {
+	if x != nil && x.ExpectedRevision != nil {
+		return *x.ExpectedRevision
+	}
+	return 0
+}
+
+type RestoreSnapshotResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Nodes             []*Node                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"` // ทุก node ของ tree หลัง restore
+	StructureRevision int64                  `protobuf:"varint,2,opt,name=structure_revision,json=structureRevision,proto3" json:"structure_revision,omitempty"`
+	Backup            *Snapshot              `protobuf:"bytes,3,opt,name=backup,proto3" json:"backup,omitempty"` // สถานะก่อน restore (restore กลับหรือ Undo ได้)
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RestoreSnapshotResponse) Reset() {
+	*x = RestoreSnapshotResponse{}
+	mi := &file_node_v1_node_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreSnapshotResponse) ProtoMessage() {}
+
+func (x *RestoreSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*RestoreSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RestoreSnapshotResponse) GetNodes() []*Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *RestoreSnapshotResponse) GetStructureRevision() int64 {
+	if x != nil {
+		return x.StructureRevision
+	}
+	return 0
+}
+
+func (x *RestoreSnapshotResponse) GetBackup() *Snapshot {
+	if x != nil {
+		return x.Backup
+	}
+	return nil
+}
+
+// Undo / Redo การแก้ล่าสุดของ caller เอง (ไม่ย้อนของคนอื่น)
+type UndoRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TreeId           string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	ExpectedRevision *int64                 `protobuf:"varint,2,opt,name=expected_revision,json=expectedRevision,proto3,oneof" json:"expected_revision,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UndoRequest) Reset() {
+	*x = UndoRequest{}
+	mi := &file_node_v1_node_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndoRequest) ProtoMessage() {}
+
+func (x *UndoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndoRequest.ProtoReflect.Descriptor instead.
+func (*UndoRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UndoRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *UndoRequest) GetExpectedRevision() int64 {
+	if x != nil && x.ExpectedRevision != nil {
+		return *x.ExpectedRevision
+	}
+	return 0
+}
+
+type UndoResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Nodes             []*Node                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	StructureRevision int64                  `protobuf:"varint,2,opt,name=structure_revision,json=structureRevision,proto3" json:"structure_revision,omitempty"`
+	UndoneReason      string                 `protobuf:"bytes,3,opt,name=undone_reason,json=undoneReason,proto3" json:"undone_reason,omitempty"` // การแก้ที่ถูกย้อน เช่น node_deleted
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UndoResponse) Reset() {
+	*x = UndoResponse{}
+	mi := &file_node_v1_node_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndoResponse) ProtoMessage() {}
+
+func (x *UndoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndoResponse.ProtoReflect.Descriptor instead.
+func (*UndoResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *UndoResponse) GetNodes() []*Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *UndoResponse) GetStructureRevision() int64 {
+	if x != nil {
+		return x.StructureRevision
+	}
+	return 0
+}
+
+func (x *UndoResponse) GetUndoneReason() string {
+	if x != nil {
+		return x.UndoneReason
+	}
+	return ""
+}
+
+type RedoRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TreeId           string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	ExpectedRevision *int64                 `protobuf:"varint,2,opt,name=expected_revision,json=expectedRevision,proto3,oneof" json:"expected_revision,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RedoRequest) Reset() {
+	*x = RedoRequest{}
+	mi := &file_node_v1_node_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedoRequest) ProtoMessage() {}
+
+func (x *RedoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedoRequest.ProtoReflect.Descriptor instead.
+func (*RedoRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RedoRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *RedoRequest) GetExpectedRevision() int64 {
+	if x != nil && x.ExpectedRevision != nil {
+		return *x.ExpectedRevision
+	}
+	return 0
+}
+
+type RedoResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Nodes             []*Node                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	StructureRevision int64                  `protobuf:"varint,2,opt,name=structure_revision,json=structureRevision,proto3" json:"structure_revision,omitempty"`
+	RedoneReason      string                 `protobuf:"bytes,3,opt,name=redone_reason,json=redoneReason,proto3" json:"redone_reason,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RedoResponse) Reset() {
+	*x = RedoResponse{}
+	mi := &file_node_v1_node_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedoResponse) ProtoMessage() {}
+
+func (x *RedoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedoResponse.ProtoReflect.Descriptor instead.
+func (*RedoResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RedoResponse) GetNodes() []*Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *RedoResponse) GetStructureRevision() int64 {
+	if x != nil {
+		return x.StructureRevision
+	}
+	return 0
+}
+
+func (x *RedoResponse) GetRedoneReason() string {
+	if x != nil {
+		return x.RedoneReason
+	}
+	return ""
+}
+
+type TrashItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          TrashItemType          `protobuf:"varint,1,opt,name=type,proto3,enum=node.v1.TrashItemType" json:"type,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	TreeId        string                 `protobuf:"bytes,3,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"` // ชื่อ tree / ชื่อเล่นของ node
+	DeletedBy     *string                `protobuf:"bytes,5,opt,name=deleted_by,json=deletedBy,proto3,oneof" json:"deleted_by,omitempty"`
+	DeletedAt     string                 `protobuf:"bytes,6,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	PurgeAt       string                 `protobuf:"bytes,7,opt,name=purge_at,json=purgeAt,proto3" json:"purge_at,omitempty"`       // หลังจากนี้จะถูกลบจริง กู้คืนไม่ได้
+	ParentIds     []string               `protobuf:"bytes,8,rep,name=parent_ids,json=parentIds,proto3" json:"parent_ids,omitempty"` // node: parent เดิม (กู้คืนแล้วกลับไปต่อที่เดิม)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrashItem) Reset() {
+	*x = TrashItem{}
+	mi := &file_node_v1_node_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrashItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrashItem) ProtoMessage() {}
+
+func (x *TrashItem) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrashItem.ProtoReflect.Descriptor instead.
+func (*TrashItem) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *TrashItem) GetType() TrashItemType {
+	if x != nil {
+		return x.Type
+	}
+	return TrashItemType_TRASH_ITEM_TYPE_UNSPECIFIED
+}
+
+func (x *TrashItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TrashItem) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *TrashItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TrashItem) GetDeletedBy() string {
+	if x != nil && x.DeletedBy != nil {
+		return *x.DeletedBy
+	}
+	return ""
+}
+
+func (x *TrashItem) GetDeletedAt() string {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return ""
+}
+
+func (x *TrashItem) GetPurgeAt() string {
+	if x != nil {
+		return x.PurgeAt
+	}
+	return ""
+}
+
+func (x *TrashItem) GetParentIds() []string {
+	if x != nil {
+		return x.ParentIds
+	}
+	return nil
+}
+
+// tree_id ว่าง = tree ของ caller ที่อยู่ในถังขยะ, มี tree_id = node ในถังขยะของ tree นั้น (editor ขึ้นไป)
+type ListTrashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashRequest) Reset() {
+	*x = ListTrashRequest{}
+	mi := &file_node_v1_node_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashRequest) ProtoMessage() {}
+
+func (x *ListTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashRequest.ProtoReflect.Descriptor instead.
+func (*ListTrashRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListTrashRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+type ListTrashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*TrashItem           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashResponse) Reset() {
+	*x = ListTrashResponse{}
+	mi := &file_node_v1_node_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashResponse) ProtoMessage() {}
+
+func (x *ListTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashResponse.ProtoReflect.Descriptor instead.
+func (*ListTrashResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListTrashResponse) GetItems() []*TrashItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type RestoreFromTrashRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Type             TrashItemType          `protobuf:"varint,1,opt,name=type,proto3,enum=node.v1.TrashItemType" json:"type,omitempty"`
+	Id               string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedRevision *int64                 `protobuf:"varint,3,opt,name=expected_revision,json=expectedRevision,proto3,oneof" json:"expected_revision,omitempty"` // เฉพาะ node
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RestoreFromTrashRequest) Reset() {
+	*x = RestoreFromTrashRequest{}
+	mi := &file_node_v1_node_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreFromTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreFromTrashRequest) ProtoMessage() {}
+
+func (x *RestoreFromTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreFromTrashRequest.ProtoReflect.Descriptor instead.
+func (*RestoreFromTrashRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *RestoreFromTrashRequest) GetType() TrashItemType {
+	if x != nil {
+		return x.Type
+	}
+	return TrashItemType_TRASH_ITEM_TYPE_UNSPECIFIED
+}
+
+func (x *RestoreFromTrashRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RestoreFromTrashRequest) GetExpectedRevision() int64 {
+	if x != nil && x.ExpectedRevision != nil {
+		return *x.ExpectedRevision
+	}
+	return 0
+}
+
+type RestoreFromTrashResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Node              *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"` // เฉพาะ node (tree: client โหลด tree ใหม่เอง)
+	StructureRevision int64                  `protobuf:"varint,2,opt,name=structure_revision,json=structureRevision,proto3" json:"structure_revision,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RestoreFromTrashResponse) Reset() {
+	*x = RestoreFromTrashResponse{}
+	mi := &file_node_v1_node_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreFromTrashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreFromTrashResponse) ProtoMessage() {}
+
+func (x *RestoreFromTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreFromTrashResponse.ProtoReflect.Descriptor instead.
+func (*RestoreFromTrashResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *RestoreFromTrashResponse) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *RestoreFromTrashResponse) GetStructureRevision() int64 {
+	if x != nil {
+		return x.StructureRevision
+	}
+	return 0
+}
+
+type DeleteSubtreeRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	NodeId           string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"` // root ของสาย
+	SharedPolicy     SharedDescendantPolicy `protobuf:"varint,2,opt,name=shared_policy,json=sharedPolicy,proto3,enum=node.v1.SharedDescendantPolicy" json:"shared_policy,omitempty"`
+	ExpectedRevision *int64                 `protobuf:"varint,3,opt,name=expected_revision,json=expectedRevision,proto3,oneof" json:"expected_revision,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DeleteSubtreeRequest) Reset() {
+	*x = DeleteSubtreeRequest{}
+	mi := &file_node_v1_node_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSubtreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSubtreeRequest) ProtoMessage() {}
+
+func (x *DeleteSubtreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSubtreeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSubtreeRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *DeleteSubtreeRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *DeleteSubtreeRequest) GetSharedPolicy() SharedDescendantPolicy {
+	if x != nil {
+		return x.SharedPolicy
+	}
+	return SharedDescendantPolicy_SHARED_DESCENDANT_POLICY_UNSPECIFIED
+}
+
+func (x *DeleteSubtreeRequest) GetExpectedRevision() int64 {
+	if x != nil && x.ExpectedRevision != nil {
+		return *x.ExpectedRevision
+	}
+	return 0
+}
+
+// ทุก node ในสายลงถังขยะ (Undo คืนทั้งสายพร้อมกัน)
+type DeleteSubtreeResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	DeletedNodeIds    []string               `protobuf:"bytes,1,rep,name=deleted_node_ids,json=deletedNodeIds,proto3" json:"deleted_node_ids,omitempty"`
+	SharedNodeIds     []string               `protobuf:"bytes,2,rep,name=shared_node_ids,json=sharedNodeIds,proto3" json:"shared_node_ids,omitempty"` // descendant ที่มี parent นอกสาย (ถูกจัดการตาม shared_policy)
+	StructureRevision int64                  `protobuf:"varint,3,opt,name=structure_revision,json=structureRevision,proto3" json:"structure_revision,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DeleteSubtreeResponse) Reset() {
+	*x = DeleteSubtreeResponse{}
+	mi := &file_node_v1_node_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSubtreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSubtreeResponse) ProtoMessage() {}
+
+func (x *DeleteSubtreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSubtreeResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSubtreeResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *DeleteSubtreeResponse) GetDeletedNodeIds() []string {
+	if x != nil {
+		return x.DeletedNodeIds
+	}
+	return nil
+}
+
+func (x *DeleteSubtreeResponse) GetSharedNodeIds() []string {
+	if x != nil {
+		return x.SharedNodeIds
+	}
+	return nil
+}
+
+func (x *DeleteSubtreeResponse) GetStructureRevision() int64 {
+	if x != nil {
+		return x.StructureRevision
+	}
+	return 0
+}
+
+type CopySubtreeRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	NodeId           string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`                          // root ของสายต้นทาง
+	TargetTreeId     string                 `protobuf:"bytes,2,opt,name=target_tree_id,json=targetTreeId,proto3" json:"target_tree_id,omitempty"`      // ว่าง = tree เดียวกัน
+	NewParentId      *string                `protobuf:"bytes,3,opt,name=new_parent_id,json=newParentId,proto3,oneof" json:"new_parent_id,omitempty"`   // parent ของสำเนา root ใน tree ปลายทาง ไม่ส่ง = เป็น root
+	SiblingOrder     *int32                 `protobuf:"varint,4,opt,name=sibling_order,json=siblingOrder,proto3,oneof" json:"sibling_order,omitempty"` // ลำดับใน children ของ parent ใหม่ ไม่ส่ง = ต่อท้าย
+	SharedPolicy     SharedDescendantPolicy `protobuf:"varint,5,opt,name=shared_policy,json=sharedPolicy,proto3,enum=node.v1.SharedDescendantPolicy" json:"shared_policy,omitempty"`
+	ExpectedRevision *int64                 `protobuf:"varint,6,opt,name=expected_revision,json=expectedRevision,proto3,oneof" json:"expected_revision,omitempty"` // revision ของ tree ปลายทาง
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CopySubtreeRequest) Reset() {
+	*x = CopySubtreeRequest{}
+	mi := &file_node_v1_node_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CopySubtreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopySubtreeRequest) ProtoMessage() {}
+
+func (x *CopySubtreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopySubtreeRequest.ProtoReflect.Descriptor instead.
+func (*CopySubtreeRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *CopySubtreeRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *CopySubtreeRequest) GetTargetTreeId() string {
+	if x != nil {
+		return x.TargetTreeId
+	}
+	return ""
+}
+
+func (x *CopySubtreeRequest) GetNewParentId() string {
+	if x != nil && x.NewParentId != nil {
+		return *x.NewParentId
+	}
+	return ""
+}
+
+func (x *CopySubtreeRequest) GetSiblingOrder() int32 {
+	if x != nil && x.SiblingOrder != nil {
+		return *x.SiblingOrder
+	}
+	return 0
+}
+
+func (x *CopySubtreeRequest) GetSharedPolicy() SharedDescendantPolicy {
+	if x != nil {
+		return x.SharedPolicy
+	}
+	return SharedDescendantPolicy_SHARED_DESCENDANT_POLICY_UNSPECIFIED
+}
+
+func (x *CopySubtreeRequest) GetExpectedRevision() int64 {
+	if x != nil && x.ExpectedRevision != nil {
+		return *x.ExpectedRevision
+	}
+	return 0
+}
+
+type CopySubtreeResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Nodes             []*Node                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`                                                                                        // สำเนาทั้งหมด (root ก่อน)
+	IdMap             map[string]string      `protobuf:"bytes,2,rep,name=id_map,json=idMap,proto3" json:"id_map,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // id ต้นทาง → id สำเนา
+	SharedNodeIds     []string               `protobuf:"bytes,3,rep,name=shared_node_ids,json=sharedNodeIds,proto3" json:"shared_node_ids,omitempty"`
+	ClearedStudentIds []string               `protobuf:"bytes,4,rep,name=cleared_student_ids,json=clearedStudentIds,proto3" json:"cleared_student_ids,omitempty"` // id สำเนาที่ student_id ชนใน tree ปลายทางเลยถูกเว้นว่าง
+	StructureRevision int64                  `protobuf:"varint,5,opt,name=structure_revision,json=structureRevision,proto3" json:"structure_revision,omitempty"`  // revision ของ tree ปลายทาง
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CopySubtreeResponse) Reset() {
+	*x = CopySubtreeResponse{}
+	mi := &file_node_v1_node_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CopySubtreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopySubtreeResponse) ProtoMessage() {}
+
+func (x *CopySubtreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopySubtreeResponse.ProtoReflect.Descriptor instead.
+func (*CopySubtreeResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *CopySubtreeResponse) GetNodes() []*Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *CopySubtreeResponse) GetIdMap() map[string]string {
+	if x != nil {
+		return x.IdMap
+	}
+	return nil
+}
+
+func (x *CopySubtreeResponse) GetSharedNodeIds() []string {
+	if x != nil {
+		return x.SharedNodeIds
+	}
+	return nil
+}
+
+func (x *CopySubtreeResponse) GetClearedStudentIds() []string {
+	if x != nil {
+		return x.ClearedStudentIds
+	}
+	return nil
+}
+
+func (x *CopySubtreeResponse) GetStructureRevision() int64 {
+	if x != nil {
+		return x.StructureRevision
+	}
+	return 0
+}
+
+// ย้ายทั้งสายภายใน tree เดียวกัน (root ของสายออกจาก parent เดิมทั้งหมด)
+type MoveSubtreeRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	NodeId           string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	NewParentId      *string                `protobuf:"bytes,2,opt,name=new_parent_id,json=newParentId,proto3,oneof" json:"new_parent_id,omitempty"` // ไม่ส่ง = เป็น root
+	SiblingOrder     *int32                 `protobuf:"varint,3,opt,name=sibling_order,json=siblingOrder,proto3,oneof" json:"sibling_order,omitempty"`
+	SharedPolicy     SharedDescendantPolicy `protobuf:"varint,4,opt,name=shared_policy,json=sharedPolicy,proto3,enum=node.v1.SharedDescendantPolicy" json:"shared_policy,omitempty"`
+	ExpectedRevision *int64                 `protobuf:"varint,5,opt,name=expected_revision,json=expectedRevision,proto3,oneof" json:"expected_revision,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *MoveSubtreeRequest) Reset() {
+	*x = MoveSubtreeRequest{}
+	mi := &file_node_v1_node_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveSubtreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveSubtreeRequest) ProtoMessage() {}
+
+func (x *MoveSubtreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveSubtreeRequest.ProtoReflect.Descriptor instead.
+func (*MoveSubtreeRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *MoveSubtreeRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *MoveSubtreeRequest) GetNewParentId() string {
+	if x != nil && x.NewParentId != nil {
+		return *x.NewParentId
+	}
+	return ""
+}
+
+func (x *MoveSubtreeRequest) GetSiblingOrder() int32 {
+	if x != nil && x.SiblingOrder != nil {
+		return *x.SiblingOrder
+	}
+	return 0
+}
+
+func (x *MoveSubtreeRequest) GetSharedPolicy() SharedDescendantPolicy {
+	if x != nil {
+		return x.SharedPolicy
+	}
+	return SharedDescendantPolicy_SHARED_DESCENDANT_POLICY_UNSPECIFIED
+}
+
+func (x *MoveSubtreeRequest) GetExpectedRevision() int64 {
+	if x != nil && x.ExpectedRevision != nil {
+		return *x.ExpectedRevision
+	}
+	return 0
+}
+
+type MoveSubtreeResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Nodes             []*Node                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"` // node ในสายหลังย้าย (รุ่นคำนวณใหม่แล้ว)
+	SharedNodeIds     []string               `protobuf:"bytes,2,rep,name=shared_node_ids,json=sharedNodeIds,proto3" json:"shared_node_ids,omitempty"`
+	StructureRevision int64                  `protobuf:"varint,3,opt,name=structure_revision,json=structureRevision,proto3" json:"structure_revision,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *MoveSubtreeResponse) Reset() {
+	*x = MoveSubtreeResponse{}
+	mi := &file_node_v1_node_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveSubtreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveSubtreeResponse) ProtoMessage() {}
+
+func (x *MoveSubtreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveSubtreeResponse.ProtoReflect.Descriptor instead.
+func (*MoveSubtreeResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *MoveSubtreeResponse) GetNodes() []*Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *MoveSubtreeResponse) GetSharedNodeIds() []string {
+	if x != nil {
+		return x.SharedNodeIds
+	}
+	return nil
+}
+
+func (x *MoveSubtreeResponse) GetStructureRevision() int64 {
+	if x != nil {
+		return x.StructureRevision
+	}
+	return 0
+}
+
+type LineageNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Depth         int32                  `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"` // ห่างจาก node ที่ถาม (1 = parent / child ตรง)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LineageNode) Reset() {
+	*x = LineageNode{}
+	mi := &file_node_v1_node_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineageNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineageNode) ProtoMessage() {}
+
+func (x *LineageNode) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineageNode.ProtoReflect.Descriptor instead.
+func (*LineageNode) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *LineageNode) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *LineageNode) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+type GetAncestorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	MaxDepth      int32                  `protobuf:"varint,2,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"` // 0 = ไม่จำกัด
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAncestorsRequest) Reset() {
+	*x = GetAncestorsRequest{}
+	mi := &file_node_v1_node_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAncestorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAncestorsRequest) ProtoMessage() {}
+
+func (x *GetAncestorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAncestorsRequest.ProtoReflect.Descriptor instead.
+func (*GetAncestorsRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetAncestorsRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *GetAncestorsRequest) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+// เรียงจากใกล้ไปไกล (multi-parent = ผ่านทุก parent, แต่ละ node มาครั้งเดียวที่ระยะสั้นสุด)
+type GetAncestorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ancestors     []*LineageNode         `protobuf:"bytes,1,rep,name=ancestors,proto3" json:"ancestors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAncestorsResponse) Reset() {
+	*x = GetAncestorsResponse{}
+	mi := &file_node_v1_node_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAncestorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAncestorsResponse) ProtoMessage() {}
+
+func (x *GetAncestorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAncestorsResponse.ProtoReflect.Descriptor instead.
+func (*GetAncestorsResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetAncestorsResponse) GetAncestors() []*LineageNode {
+	if x != nil {
+		return x.Ancestors
+	}
+	return nil
+}
+
+type GetDescendantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	MaxDepth      int32                  `protobuf:"varint,2,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"` // 0 = ไม่จำกัด
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDescendantsRequest) Reset() {
+	*x = GetDescendantsRequest{}
+	mi := &file_node_v1_node_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDescendantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDescendantsRequest) ProtoMessage() {}
+
+func (x *GetDescendantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDescendantsRequest.ProtoReflect.Descriptor instead.
+func (*GetDescendantsRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetDescendantsRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *GetDescendantsRequest) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+type GetDescendantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Descendants   []*LineageNode         `protobuf:"bytes,1,rep,name=descendants,proto3" json:"descendants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDescendantsResponse) Reset() {
+	*x = GetDescendantsResponse{}
+	mi := &file_node_v1_node_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDescendantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDescendantsResponse) ProtoMessage() {}
+
+func (x *GetDescendantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDescendantsResponse.ProtoReflect.Descriptor instead.
+func (*GetDescendantsResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetDescendantsResponse) GetDescendants() []*LineageNode {
+	if x != nil {
+		return x.Descendants
+	}
+	return nil
+}
+
+type FindRelationshipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodeAId       string                 `protobuf:"bytes,1,opt,name=node_a_id,json=nodeAId,proto3" json:"node_a_id,omitempty"`
+	NodeBId       string                 `protobuf:"bytes,2,opt,name=node_b_id,json=nodeBId,proto3" json:"node_b_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindRelationshipRequest) Reset() {
+	*x = FindRelationshipRequest{}
+	mi := &file_node_v1_node_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindRelationshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindRelationshipRequest) ProtoMessage() {}
+
+func (x *FindRelationshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindRelationshipRequest.ProtoReflect.Descriptor instead.
+func (*FindRelationshipRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *FindRelationshipRequest) GetNodeAId() string {
+	if x != nil {
+		return x.NodeAId
+	}
+	return ""
+}
+
+func (x *FindRelationshipRequest) GetNodeBId() string {
+	if x != nil {
+		return x.NodeBId
+	}
+	return ""
+}
+
+type FindRelationshipResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Kind             RelationshipKind       `protobuf:"varint,1,opt,name=kind,proto3,enum=node.v1.RelationshipKind" json:"kind,omitempty"`
+	Label            string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`                                                       // เช่น "พี่รหัส 2 ชั้น", "ร่วมพี่รหัสเดียวกัน"
+	CommonAncestorId *string                `protobuf:"bytes,3,opt,name=common_ancestor_id,json=commonAncestorId,proto3,oneof" json:"common_ancestor_id,omitempty"` // พี่รหัสร่วมที่ทำให้เส้นสั้นที่สุด
+	Up               int32                  `protobuf:"varint,4,opt,name=up,proto3" json:"up,omitempty"`                                                            // จำนวนชั้นจาก a ขึ้นไปถึง common ancestor
+	Down             int32                  `protobuf:"varint,5,opt,name=down,proto3" json:"down,omitempty"`                                                        // จำนวนชั้นจาก common ancestor ลงมาถึง b
+	Path             []string               `protobuf:"bytes,6,rep,name=path,proto3" json:"path,omitempty"`                                                         // node id จาก a → common ancestor → b
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *FindRelationshipResponse) Reset() {
+	*x = FindRelationshipResponse{}
+	mi := &file_node_v1_node_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindRelationshipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindRelationshipResponse) ProtoMessage() {}
+
+func (x *FindRelationshipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindRelationshipResponse.ProtoReflect.Descriptor instead.
+func (*FindRelationshipResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *FindRelationshipResponse) GetKind() RelationshipKind {
+	if x != nil {
+		return x.Kind
+	}
+	return RelationshipKind_RELATIONSHIP_KIND_UNSPECIFIED
+}
+
+func (x *FindRelationshipResponse) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *FindRelationshipResponse) GetCommonAncestorId() string {
+	if x != nil && x.CommonAncestorId != nil {
+		return *x.CommonAncestorId
+	}
+	return ""
+}
+
+func (x *FindRelationshipResponse) GetUp() int32 {
+	if x != nil {
+		return x.Up
+	}
+	return 0
+}
+
+func (x *FindRelationshipResponse) GetDown() int32 {
+	if x != nil {
+		return x.Down
+	}
+	return 0
+}
+
+func (x *FindRelationshipResponse) GetPath() []string {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+type SearchNodesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	TreeId        *string                `protobuf:"bytes,2,opt,name=tree_id,json=treeId,proto3,oneof" json:"tree_id,omitempty"`                 // ไม่ส่ง = ทุก tree ที่เป็นเจ้าของ / ถูกแชร์ (ต้อง login)
+	Generations   []int32                `protobuf:"varint,3,rep,packed,name=generations,proto3" json:"generations,omitempty"`                   // ว่าง = ทุกรุ่น
+	Statuses      []NodeStatus           `protobuf:"varint,4,rep,packed,name=statuses,proto3,enum=node.v1.NodeStatus" json:"statuses,omitempty"` // ว่าง = ทุกสถานะ
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                // 0 = 20, มากสุด 100
+	PageToken     string                 `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`              // next_page_token จากหน้าก่อน
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchNodesRequest) Reset() {
+	*x = SearchNodesRequest{}
+	mi := &file_node_v1_node_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchNodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchNodesRequest) ProtoMessage() {}
+
+func (x *SearchNodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchNodesRequest.ProtoReflect.Descriptor instead.
+func (*SearchNodesRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *SearchNodesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchNodesRequest) GetTreeId() string {
+	if x != nil && x.TreeId != nil {
+		return *x.TreeId
+	}
+	return ""
+}
+
+func (x *SearchNodesRequest) GetGenerations() []int32 {
+	if x != nil {
+		return x.Generations
+	}
+	return nil
+}
+
+func (x *SearchNodesRequest) GetStatuses() []NodeStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *SearchNodesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchNodesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type SearchHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"` // มาก = ตรงกว่า (เทียบกันได้เฉพาะในการค้นครั้งเดียวกัน)
+	TreeName      string                 `protobuf:"bytes,3,opt,name=tree_name,json=treeName,proto3" json:"tree_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHit) Reset() {
+	*x = SearchHit{}
+	mi := &file_node_v1_node_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHit) ProtoMessage() {}
+
+func (x *SearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHit.ProtoReflect.Descriptor instead.
+func (*SearchHit) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *SearchHit) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *SearchHit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchHit) GetTreeName() string {
+	if x != nil {
+		return x.TreeName
+	}
+	return ""
+}
+
+// เรียงตามคะแนน ผลที่ตรงแค่ช่องทางติดต่อที่ caller ไม่เห็นถูกตัดออก (หน้าหนึ่งอาจได้น้อยกว่า page_size)
+type SearchNodesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hits          []*SearchHit           `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // "" = หมดแล้ว
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchNodesResponse) Reset() {
+	*x = SearchNodesResponse{}
+	mi := &file_node_v1_node_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchNodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchNodesResponse) ProtoMessage() {}
+
+func (x *SearchNodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_node_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchNodesResponse.ProtoReflect.Descriptor instead.
+func (*SearchNodesResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_node_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *SearchNodesResponse) GetHits() []*SearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *SearchNodesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+var File_node_v1_node_proto protoreflect.FileDescriptor
+
+const file_node_v1_node_proto_rawDesc = "" +
+	"\n" +
+	"\x12node/v1/node.proto\x12\anode.v1\x1a\x12tree/v1/tree.proto\"\xc8\x06\n" +
+	"\x04Node\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atree_id\x18\x02 \x01(\tR\x06treeId\x12 \n" +
+	"\tparent_id\x18\x03 \x01(\tH\x00R\bparentId\x88\x01\x01\x12\x1a\n" +
+	"\bnickname\x18\x04 \x01(\tR\bnickname\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x05 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x06 \x01(\tR\blastName\x12\x1d\n" +
+	"\n" +
+	"student_id\x18\a \x01(\tR\tstudentId\x12\x1e\n" +
+	"\n" +
+	"generation\x18\b \x01(\x05R\n" +
+	"generation\x12\x1b\n" +
+	"\tphoto_url\x18\t \x01(\tR\bphotoUrl\x12+\n" +
+	"\x06status\x18\n" +
+	" \x01(\x0e2\x13.node.v1.NodeStatusR\x06status\x12#\n" +
+	"\rsibling_order\x18\v \x01(\x05R\fsiblingOrder\x12\x1d\n" +
+	"\n" +
+	"position_x\x18\f \x01(\x01R\tpositionX\x12\x1d\n" +
+	"\n" +
+	"position_y\x18\r \x01(\x01R\tpositionY\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\tR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"parent_ids\x18\x10 \x03(\tR\tparentIds\x12\x14\n" +
+	"\x05phone\x18\x11 \x01(\tR\x05phone\x12\x14\n" +
+	"\x05email\x18\x12 \x01(\tR\x05email\x12\x17\n" +
+	"\aline_id\x18\x13 \x01(\tR\x06lineId\x12\x18\n" +
+	"\adiscord\x18\x14 \x01(\tR\adiscord\x12\x1a\n" +
+	"\bfacebook\x18\x15 \x01(\tR\bfacebook\x12G\n" +
+	"\x0esibling_orders\x18\x16 \x03(\v2 .node.v1.Node.SiblingOrdersEntryR\rsiblingOrders\x12@\n" +
+	"\x0fcontact_privacy\x18\x17 \x01(\v2\x17.tree.v1.ContactPrivacyR\x0econtactPrivacy\x1a@\n" +
+	"\x12SiblingOrdersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_parent_id\"\x9d\x05\n" +
+	"\x11CreateNodeRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12 \n" +
+	"\tparent_id\x18\x02 \x01(\tH\x00R\bparentId\x88\x01\x01\x12\x1a\n" +
+	"\bnickname\x18\x03 \x01(\tR\bnickname\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x04 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x05 \x01(\tR\blastName\x12\x1d\n" +
+	"\n" +
+	"student_id\x18\x06 \x01(\tR\tstudentId\x12\x1b\n" +
+	"\tphoto_url\x18\a \x01(\tR\bphotoUrl\x12+\n" +
+	"\x06status\x18\b \x01(\x0e2\x13.node.v1.NodeStatusR\x06status\x12\x1e\n" +
+	"\n" +
+	"generation\x18\t \x01(\x05R\n" +
+	"generation\x12\x1d\n" +
+	"\n" +
+	"parent_ids\x18\n" +
+	" \x03(\tR\tparentIds\x12\x14\n" +
+	"\x05phone\x18\v \x01(\tR\x05phone\x12\x14\n" +
+	"\x05email\x18\f \x01(\tR\x05email\x12\x17\n" +
+	"\aline_id\x18\r \x01(\tR\x06lineId\x12\x18\n" +
+	"\adiscord\x18\x0e \x01(\tR\adiscord\x12\x1a\n" +
+	"\bfacebook\x18\x0f \x01(\tR\bfacebook\x120\n" +
+	"\x11expected_revision\x18\x10 \x01(\x03H\x01R\x10expectedRevision\x88\x01\x01\x12(\n" +
+	"\rsibling_order\x18\x11 \x01(\x05H\x02R\fsiblingOrder\x88\x01\x01\x12@\n" +
+	"\x0fcontact_privacy\x18\x12 \x01(\v2\x17.tree.v1.ContactPrivacyR\x0econtactPrivacyB\f\n" +
+	"\n" +
+	"_parent_idB\x14\n" +
+	"\x12_expected_revisionB\x10\n" +
+	"\x0e_sibling_order\"f\n" +
+	"\x12CreateNodeResponse\x12!\n" +
+	"\x04node\x18\x01 \x01(\v2\r.node.v1.NodeR\x04node\x12-\n" +
+	"\x12structure_revision\x18\x02 \x01(\x03R\x11structureRevision\"\xc1\x03\n" +
+	"\x11UpdateNodeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bnickname\x18\x02 \x01(\tR\bnickname\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x03 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x04 \x01(\tR\blastName\x12\x1d\n" +
 	"\n" +
 	"student_id\x18\x05 \x01(\tR\tstudentId\x12\x1b\n" +
 	"\tphoto_url\x18\x06 \x01(\tR\bphotoUrl\x12+\n" +
@@ -1390,47 +4724,334 @@ const file_node_v1_node_proto_rawDesc = "" +
 	" \x01(\tR\x05email\x12\x17\n" +
 	"\aline_id\x18\v \x01(\tR\x06lineId\x12\x18\n" +
 	"\adiscord\x18\f \x01(\tR\adiscord\x12\x1a\n" +
-	"\bfacebook\x18\r \x01(\tR\bfacebook\"7\n" +
+	"\bfacebook\x18\r \x01(\tR\bfacebook\x12@\n" +
+	"\x0fcontact_privacy\x18\x0e \x01(\v2\x17.tree.v1.ContactPrivacyR\x0econtactPrivacy\"7\n" +
 	"\x12UpdateNodeResponse\x12!\n" +
-	"\x04node\x18\x01 \x01(\v2\r.node.v1.NodeR\x04node\"#\n" +
+	"\x04node\x18\x01 \x01(\v2\r.node.v1.NodeR\x04node\"k\n" +
 	"\x11DeleteNodeRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x14\n" +
-	"\x12DeleteNodeResponse\"s\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x120\n" +
+	"\x11expected_revision\x18\x02 \x01(\x03H\x00R\x10expectedRevision\x88\x01\x01B\x14\n" +
+	"\x12_expected_revision\"C\n" +
+	"\x12DeleteNodeResponse\x12-\n" +
+	"\x12structure_revision\x18\x01 \x01(\x03R\x11structureRevision\"\xd2\x01\n" +
 	"\x0fMoveNodeRequest\x12\x17\n" +
 	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\"\n" +
-	"\rnew_parent_id\x18\x02 \x01(\tR\vnewParentId\x12#\n" +
-	"\rsibling_order\x18\x03 \x01(\x05R\fsiblingOrder\"5\n" +
+	"\rnew_parent_id\x18\x02 \x01(\tR\vnewParentId\x12(\n" +
+	"\rsibling_order\x18\x03 \x01(\x05H\x00R\fsiblingOrder\x88\x01\x01\x120\n" +
+	"\x11expected_revision\x18\x04 \x01(\x03H\x01R\x10expectedRevision\x88\x01\x01B\x10\n" +
+	"\x0e_sibling_orderB\x14\n" +
+	"\x12_expected_revision\"d\n" +
 	"\x10MoveNodeResponse\x12!\n" +
-	"\x04node\x18\x01 \x01(\v2\r.node.v1.NodeR\x04node\".\n" +
+	"\x04node\x18\x01 \x01(\v2\r.node.v1.NodeR\x04node\x12-\n" +
+	"\x12structure_revision\x18\x02 \x01(\x03R\x11structureRevision\".\n" +
 	"\x13GetTreeNodesRequest\x12\x17\n" +
-	"\atree_id\x18\x01 \x01(\tR\x06treeId\";\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\"j\n" +
 	"\x14GetTreeNodesResponse\x12#\n" +
-	"\x05nodes\x18\x01 \x03(\v2\r.node.v1.NodeR\x05nodes\",\n" +
+	"\x05nodes\x18\x01 \x03(\v2\r.node.v1.NodeR\x05nodes\x12-\n" +
+	"\x12structure_revision\x18\x02 \x01(\x03R\x11structureRevision\"t\n" +
 	"\x11UnlinkNodeRequest\x12\x17\n" +
-	"\anode_id\x18\x01 \x01(\tR\x06nodeId\"7\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x120\n" +
+	"\x11expected_revision\x18\x02 \x01(\x03H\x00R\x10expectedRevision\x88\x01\x01B\x14\n" +
+	"\x12_expected_revision\"f\n" +
 	"\x12UnlinkNodeResponse\x12!\n" +
-	"\x04node\x18\x01 \x01(\v2\r.node.v1.NodeR\x04node\"H\n" +
+	"\x04node\x18\x01 \x01(\v2\r.node.v1.NodeR\x04node\x12-\n" +
+	"\x12structure_revision\x18\x02 \x01(\x03R\x11structureRevision\"\xcc\x01\n" +
 	"\x10AddParentRequest\x12\x17\n" +
 	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x1b\n" +
-	"\tparent_id\x18\x02 \x01(\tR\bparentId\"6\n" +
+	"\tparent_id\x18\x02 \x01(\tR\bparentId\x120\n" +
+	"\x11expected_revision\x18\x03 \x01(\x03H\x00R\x10expectedRevision\x88\x01\x01\x12(\n" +
+	"\rsibling_order\x18\x04 \x01(\x05H\x01R\fsiblingOrder\x88\x01\x01B\x14\n" +
+	"\x12_expected_revisionB\x10\n" +
+	"\x0e_sibling_order\"e\n" +
 	"\x11AddParentResponse\x12!\n" +
-	"\x04node\x18\x01 \x01(\v2\r.node.v1.NodeR\x04node\"K\n" +
+	"\x04node\x18\x01 \x01(\v2\r.node.v1.NodeR\x04node\x12-\n" +
+	"\x12structure_revision\x18\x02 \x01(\x03R\x11structureRevision\"\x93\x01\n" +
 	"\x13RemoveParentRequest\x12\x17\n" +
 	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x1b\n" +
-	"\tparent_id\x18\x02 \x01(\tR\bparentId\"9\n" +
+	"\tparent_id\x18\x02 \x01(\tR\bparentId\x120\n" +
+	"\x11expected_revision\x18\x03 \x01(\x03H\x00R\x10expectedRevision\x88\x01\x01B\x14\n" +
+	"\x12_expected_revision\"h\n" +
 	"\x14RemoveParentResponse\x12!\n" +
-	"\x04node\x18\x01 \x01(\v2\r.node.v1.NodeR\x04node\">\n" +
+	"\x04node\x18\x01 \x01(\v2\r.node.v1.NodeR\x04node\x12-\n" +
+	"\x12structure_revision\x18\x02 \x01(\x03R\x11structureRevision\"e\n" +
+	"\fNodePosition\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x1d\n" +
+	"\n" +
+	"position_x\x18\x02 \x01(\x01R\tpositionX\x12\x1d\n" +
+	"\n" +
+	"position_y\x18\x03 \x01(\x01R\tpositionY\"c\n" +
+	"\x13UpdateLayoutRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x123\n" +
+	"\tpositions\x18\x02 \x03(\v2\x15.node.v1.NodePositionR\tpositions\";\n" +
+	"\x14UpdateLayoutResponse\x12#\n" +
+	"\rupdated_count\x18\x01 \x01(\x05R\fupdatedCount\">\n" +
 	"\x1bGetNodesByShareTokenRequest\x12\x1f\n" +
 	"\vshare_token\x18\x01 \x01(\tR\n" +
 	"shareToken\"C\n" +
 	"\x1cGetNodesByShareTokenResponse\x12#\n" +
-	"\x05nodes\x18\x01 \x03(\v2\r.node.v1.NodeR\x05nodes*w\n" +
+	"\x05nodes\x18\x01 \x03(\v2\r.node.v1.NodeR\x05nodes\"\xd1\x01\n" +
+	"\x12ImportNodesRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12-\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x15.node.v1.ImportFormatR\x06format\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\x120\n" +
+	"\x11expected_revision\x18\x05 \x01(\x03H\x00R\x10expectedRevision\x88\x01\x01B\x14\n" +
+	"\x12_expected_revision\"S\n" +
+	"\vImportIssue\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x16\n" +
+	"\x06column\x18\x02 \x01(\tR\x06column\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xd0\x01\n" +
+	"\x13ImportNodesResponse\x12\x1d\n" +
+	"\n" +
+	"total_rows\x18\x01 \x01(\x05R\ttotalRows\x12,\n" +
+	"\x06issues\x18\x02 \x03(\v2\x14.node.v1.ImportIssueR\x06issues\x12\x18\n" +
+	"\aapplied\x18\x03 \x01(\bR\aapplied\x12#\n" +
+	"\x05nodes\x18\x04 \x03(\v2\r.node.v1.NodeR\x05nodes\x12-\n" +
+	"\x12structure_revision\x18\x05 \x01(\x03R\x11structureRevision\"[\n" +
+	"\x11ExportTreeRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12-\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x15.node.v1.ExportFormatR\x06format\"g\n" +
+	"\x12ExportTreeResponse\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"O\n" +
+	"\x10WatchTreeRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12\"\n" +
+	"\rlast_event_id\x18\x02 \x01(\x03R\vlastEventId\"\x81\x02\n" +
+	"\x11WatchTreeResponse\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12*\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x16.node.v1.TreeEventTypeR\x04type\x12\x17\n" +
+	"\anode_id\x18\x03 \x01(\tR\x06nodeId\x12!\n" +
+	"\x04node\x18\x04 \x01(\v2\r.node.v1.NodeR\x04node\x12$\n" +
+	"\x0eold_parent_ids\x18\x05 \x03(\tR\foldParentIds\x12$\n" +
+	"\x0enew_parent_ids\x18\x06 \x03(\tR\fnewParentIds\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\"\xdd\x02\n" +
+	"\bSnapshot\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atree_id\x18\x02 \x01(\tR\x06treeId\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x03 \x01(\tR\tcreatedBy\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05label\x18\x05 \x01(\tR\x05label\x12\x14\n" +
+	"\x05scope\x18\v \x01(\tR\x05scope\x12\x1c\n" +
+	"\anode_id\x18\x06 \x01(\tH\x00R\x06nodeId\x88\x01\x01\x12 \n" +
+	"\tundone_at\x18\a \x01(\tH\x01R\bundoneAt\x88\x01\x01\x12-\n" +
+	"\x12structure_revision\x18\b \x01(\x03R\x11structureRevision\x12\x1d\n" +
+	"\n" +
+	"node_count\x18\t \x01(\x05R\tnodeCount\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\tR\tcreatedAtB\n" +
+	"\n" +
+	"\b_node_idB\f\n" +
+	"\n" +
+	"_undone_at\"F\n" +
+	"\x15CreateSnapshotRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\"G\n" +
+	"\x16CreateSnapshotResponse\x12-\n" +
+	"\bsnapshot\x18\x01 \x01(\v2\x11.node.v1.SnapshotR\bsnapshot\"E\n" +
+	"\x14ListSnapshotsRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"H\n" +
+	"\x15ListSnapshotsResponse\x12/\n" +
+	"\tsnapshots\x18\x01 \x03(\v2\x11.node.v1.SnapshotR\tsnapshots\"\xe4\x01\n" +
+	"\x0eSnapshotChange\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x1a\n" +
+	"\bnickname\x18\x02 \x01(\tR\bnickname\x12/\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x1b.node.v1.SnapshotChangeTypeR\x04type\x12\x16\n" +
+	"\x06fields\x18\x04 \x03(\tR\x06fields\x12*\n" +
+	"\x11parent_ids_before\x18\x05 \x03(\tR\x0fparentIdsBefore\x12(\n" +
+	"\x10parent_ids_after\x18\x06 \x03(\tR\x0eparentIdsAfter\"O\n" +
+	"\x13DiffSnapshotRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12\x1f\n" +
+	"\vsnapshot_id\x18\x02 \x01(\tR\n" +
+	"snapshotId\"I\n" +
+	"\x14DiffSnapshotResponse\x121\n" +
+	"\achanges\x18\x01 \x03(\v2\x17.node.v1.SnapshotChangeR\achanges\"\xd2\x01\n" +
+	"\x16RestoreSnapshotRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12\x1f\n" +
+	"\vsnapshot_id\x18\x02 \x01(\tR\n" +
+	"snapshotId\x12%\n" +
+	"\froot_node_id\x18\x03 \x01(\tH\x00R\n" +
+	"rootNodeId\x88\x01\x01\x120\n" +
+	"\x11expected_revision\x18\x04 \x01(\x03H\x01R\x10expectedRevision\x88\x01\x01B\x0f\n" +
+	"\r_root_node_idB\x14\n" +
+	"\x12_expected_revision\"\x98\x01\n" +
+	"\x17RestoreSnapshotResponse\x12#\n" +
+	"\x05nodes\x18\x01 \x03(\v2\r.node.v1.NodeR\x05nodes\x12-\n" +
+	"\x12structure_revision\x18\x02 \x01(\x03R\x11structureRevision\x12)\n" +
+	"\x06backup\x18\x03 \x01(\v2\x11.node.v1.SnapshotR\x06backup\"n\n" +
+	"\vUndoRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x120\n" +
+	"\x11expected_revision\x18\x02 \x01(\x03H\x00R\x10expectedRevision\x88\x01\x01B\x14\n" +
+	"\x12_expected_revision\"\x87\x01\n" +
+	"\fUndoResponse\x12#\n" +
+	"\x05nodes\x18\x01 \x03(\v2\r.node.v1.NodeR\x05nodes\x12-\n" +
+	"\x12structure_revision\x18\x02 \x01(\x03R\x11structureRevision\x12#\n" +
+	"\rundone_reason\x18\x03 \x01(\tR\fundoneReason\"n\n" +
+	"\vRedoRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x120\n" +
+	"\x11expected_revision\x18\x02 \x01(\x03H\x00R\x10expectedRevision\x88\x01\x01B\x14\n" +
+	"\x12_expected_revision\"\x87\x01\n" +
+	"\fRedoResponse\x12#\n" +
+	"\x05nodes\x18\x01 \x03(\v2\r.node.v1.NodeR\x05nodes\x12-\n" +
+	"\x12structure_revision\x18\x02 \x01(\x03R\x11structureRevision\x12#\n" +
+	"\rredone_reason\x18\x03 \x01(\tR\fredoneReason\"\x80\x02\n" +
+	"\tTrashItem\x12*\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x16.node.v1.TrashItemTypeR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x17\n" +
+	"\atree_id\x18\x03 \x01(\tR\x06treeId\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\"\n" +
+	"\n" +
+	"deleted_by\x18\x05 \x01(\tH\x00R\tdeletedBy\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\x06 \x01(\tR\tdeletedAt\x12\x19\n" +
+	"\bpurge_at\x18\a \x01(\tR\apurgeAt\x12\x1d\n" +
+	"\n" +
+	"parent_ids\x18\b \x03(\tR\tparentIdsB\r\n" +
+	"\v_deleted_by\"+\n" +
+	"\x10ListTrashRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\"=\n" +
+	"\x11ListTrashResponse\x12(\n" +
+	"\x05items\x18\x01 \x03(\v2\x12.node.v1.TrashItemR\x05items\"\x9d\x01\n" +
+	"\x17RestoreFromTrashRequest\x12*\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x16.node.v1.TrashItemTypeR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x120\n" +
+	"\x11expected_revision\x18\x03 \x01(\x03H\x00R\x10expectedRevision\x88\x01\x01B\x14\n" +
+	"\x12_expected_revision\"l\n" +
+	"\x18RestoreFromTrashResponse\x12!\n" +
+	"\x04node\x18\x01 \x01(\v2\r.node.v1.NodeR\x04node\x12-\n" +
+	"\x12structure_revision\x18\x02 \x01(\x03R\x11structureRevision\"\xbd\x01\n" +
+	"\x14DeleteSubtreeRequest\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12D\n" +
+	"\rshared_policy\x18\x02 \x01(\x0e2\x1f.node.v1.SharedDescendantPolicyR\fsharedPolicy\x120\n" +
+	"\x11expected_revision\x18\x03 \x01(\x03H\x00R\x10expectedRevision\x88\x01\x01B\x14\n" +
+	"\x12_expected_revision\"\x98\x01\n" +
+	"\x15DeleteSubtreeResponse\x12(\n" +
+	"\x10deleted_node_ids\x18\x01 \x03(\tR\x0edeletedNodeIds\x12&\n" +
+	"\x0fshared_node_ids\x18\x02 \x03(\tR\rsharedNodeIds\x12-\n" +
+	"\x12structure_revision\x18\x03 \x01(\x03R\x11structureRevision\"\xd8\x02\n" +
+	"\x12CopySubtreeRequest\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12$\n" +
+	"\x0etarget_tree_id\x18\x02 \x01(\tR\ftargetTreeId\x12'\n" +
+	"\rnew_parent_id\x18\x03 \x01(\tH\x00R\vnewParentId\x88\x01\x01\x12(\n" +
+	"\rsibling_order\x18\x04 \x01(\x05H\x01R\fsiblingOrder\x88\x01\x01\x12D\n" +
+	"\rshared_policy\x18\x05 \x01(\x0e2\x1f.node.v1.SharedDescendantPolicyR\fsharedPolicy\x120\n" +
+	"\x11expected_revision\x18\x06 \x01(\x03H\x02R\x10expectedRevision\x88\x01\x01B\x10\n" +
+	"\x0e_new_parent_idB\x10\n" +
+	"\x0e_sibling_orderB\x14\n" +
+	"\x12_expected_revision\"\xbb\x02\n" +
+	"\x13CopySubtreeResponse\x12#\n" +
+	"\x05nodes\x18\x01 \x03(\v2\r.node.v1.NodeR\x05nodes\x12>\n" +
+	"\x06id_map\x18\x02 \x03(\v2'.node.v1.CopySubtreeResponse.IdMapEntryR\x05idMap\x12&\n" +
+	"\x0fshared_node_ids\x18\x03 \x03(\tR\rsharedNodeIds\x12.\n" +
+	"\x13cleared_student_ids\x18\x04 \x03(\tR\x11clearedStudentIds\x12-\n" +
+	"\x12structure_revision\x18\x05 \x01(\x03R\x11structureRevision\x1a8\n" +
+	"\n" +
+	"IdMapEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb2\x02\n" +
+	"\x12MoveSubtreeRequest\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12'\n" +
+	"\rnew_parent_id\x18\x02 \x01(\tH\x00R\vnewParentId\x88\x01\x01\x12(\n" +
+	"\rsibling_order\x18\x03 \x01(\x05H\x01R\fsiblingOrder\x88\x01\x01\x12D\n" +
+	"\rshared_policy\x18\x04 \x01(\x0e2\x1f.node.v1.SharedDescendantPolicyR\fsharedPolicy\x120\n" +
+	"\x11expected_revision\x18\x05 \x01(\x03H\x02R\x10expectedRevision\x88\x01\x01B\x10\n" +
+	"\x0e_new_parent_idB\x10\n" +
+	"\x0e_sibling_orderB\x14\n" +
+	"\x12_expected_revision\"\x91\x01\n" +
+	"\x13MoveSubtreeResponse\x12#\n" +
+	"\x05nodes\x18\x01 \x03(\v2\r.node.v1.NodeR\x05nodes\x12&\n" +
+	"\x0fshared_node_ids\x18\x02 \x03(\tR\rsharedNodeIds\x12-\n" +
+	"\x12structure_revision\x18\x03 \x01(\x03R\x11structureRevision\"F\n" +
+	"\vLineageNode\x12!\n" +
+	"\x04node\x18\x01 \x01(\v2\r.node.v1.NodeR\x04node\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\x05R\x05depth\"K\n" +
+	"\x13GetAncestorsRequest\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x1b\n" +
+	"\tmax_depth\x18\x02 \x01(\x05R\bmaxDepth\"J\n" +
+	"\x14GetAncestorsResponse\x122\n" +
+	"\tancestors\x18\x01 \x03(\v2\x14.node.v1.LineageNodeR\tancestors\"M\n" +
+	"\x15GetDescendantsRequest\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x1b\n" +
+	"\tmax_depth\x18\x02 \x01(\x05R\bmaxDepth\"P\n" +
+	"\x16GetDescendantsResponse\x126\n" +
+	"\vdescendants\x18\x01 \x03(\v2\x14.node.v1.LineageNodeR\vdescendants\"Q\n" +
+	"\x17FindRelationshipRequest\x12\x1a\n" +
+	"\tnode_a_id\x18\x01 \x01(\tR\anodeAId\x12\x1a\n" +
+	"\tnode_b_id\x18\x02 \x01(\tR\anodeBId\"\xe1\x01\n" +
+	"\x18FindRelationshipResponse\x12-\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x19.node.v1.RelationshipKindR\x04kind\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x121\n" +
+	"\x12common_ancestor_id\x18\x03 \x01(\tH\x00R\x10commonAncestorId\x88\x01\x01\x12\x0e\n" +
+	"\x02up\x18\x04 \x01(\x05R\x02up\x12\x12\n" +
+	"\x04down\x18\x05 \x01(\x05R\x04down\x12\x12\n" +
+	"\x04path\x18\x06 \x03(\tR\x04pathB\x15\n" +
+	"\x13_common_ancestor_id\"\xe3\x01\n" +
+	"\x12SearchNodesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1c\n" +
+	"\atree_id\x18\x02 \x01(\tH\x00R\x06treeId\x88\x01\x01\x12 \n" +
+	"\vgenerations\x18\x03 \x03(\x05R\vgenerations\x12/\n" +
+	"\bstatuses\x18\x04 \x03(\x0e2\x13.node.v1.NodeStatusR\bstatuses\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageTokenB\n" +
+	"\n" +
+	"\b_tree_id\"a\n" +
+	"\tSearchHit\x12!\n" +
+	"\x04node\x18\x01 \x01(\v2\r.node.v1.NodeR\x04node\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x1b\n" +
+	"\ttree_name\x18\x03 \x01(\tR\btreeName\"e\n" +
+	"\x13SearchNodesResponse\x12&\n" +
+	"\x04hits\x18\x01 \x03(\v2\x12.node.v1.SearchHitR\x04hits\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*w\n" +
 	"\n" +
 	"NodeStatus\x12\x1b\n" +
 	"\x17NODE_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
 	"\x14NODE_STATUS_STUDYING\x10\x01\x12\x19\n" +
 	"\x15NODE_STATUS_GRADUATED\x10\x02\x12\x17\n" +
-	"\x13NODE_STATUS_RETIRED\x10\x032\xad\x05\n" +
+	"\x13NODE_STATUS_RETIRED\x10\x03*}\n" +
+	"\fImportFormat\x12\x1d\n" +
+	"\x19IMPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11IMPORT_FORMAT_CSV\x10\x01\x12\x16\n" +
+	"\x12IMPORT_FORMAT_XLSX\x10\x02\x12\x1f\n" +
+	"\x1bIMPORT_FORMAT_JSON_SNAPSHOT\x10\x03*\x9a\x01\n" +
+	"\fExportFormat\x12\x1d\n" +
+	"\x19EXPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bEXPORT_FORMAT_JSON_SNAPSHOT\x10\x01\x12\x15\n" +
+	"\x11EXPORT_FORMAT_DOT\x10\x02\x12\x19\n" +
+	"\x15EXPORT_FORMAT_GRAPHML\x10\x03\x12\x18\n" +
+	"\x14EXPORT_FORMAT_GEDCOM\x10\x04*\xe0\x01\n" +
+	"\rTreeEventType\x12\x1f\n" +
+	"\x1bTREE_EVENT_TYPE_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cTREE_EVENT_TYPE_NODE_CREATED\x10\x01\x12 \n" +
+	"\x1cTREE_EVENT_TYPE_NODE_UPDATED\x10\x02\x12 \n" +
+	"\x1cTREE_EVENT_TYPE_NODE_DELETED\x10\x03\x12\x1e\n" +
+	"\x1aTREE_EVENT_TYPE_NODE_MOVED\x10\x04\x12(\n" +
+	"$TREE_EVENT_TYPE_NODE_PARENTS_CHANGED\x10\x05*\x9f\x01\n" +
+	"\x12SnapshotChangeType\x12$\n" +
+	" SNAPSHOT_CHANGE_TYPE_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aSNAPSHOT_CHANGE_TYPE_ADDED\x10\x01\x12 \n" +
+	"\x1cSNAPSHOT_CHANGE_TYPE_REMOVED\x10\x02\x12!\n" +
+	"\x1dSNAPSHOT_CHANGE_TYPE_MODIFIED\x10\x03*d\n" +
+	"\rTrashItemType\x12\x1f\n" +
+	"\x1bTRASH_ITEM_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14TRASH_ITEM_TYPE_TREE\x10\x01\x12\x18\n" +
+	"\x14TRASH_ITEM_TYPE_NODE\x10\x02*\x89\x01\n" +
+	"\x16SharedDescendantPolicy\x12(\n" +
+	"$SHARED_DESCENDANT_POLICY_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eSHARED_DESCENDANT_POLICY_LEAVE\x10\x01\x12!\n" +
+	"\x1dSHARED_DESCENDANT_POLICY_TAKE\x10\x02*\xf1\x01\n" +
+	"\x10RelationshipKind\x12!\n" +
+	"\x1dRELATIONSHIP_KIND_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bRELATIONSHIP_KIND_UNRELATED\x10\x01\x12\x1a\n" +
+	"\x16RELATIONSHIP_KIND_SELF\x10\x02\x12\x1e\n" +
+	"\x1aRELATIONSHIP_KIND_ANCESTOR\x10\x03\x12 \n" +
+	"\x1cRELATIONSHIP_KIND_DESCENDANT\x10\x04\x12\x1d\n" +
+	"\x19RELATIONSHIP_KIND_SIBLING\x10\x05\x12\x1c\n" +
+	"\x18RELATIONSHIP_KIND_COUSIN\x10\x062\xc5\x10\n" +
 	"\vNodeService\x12E\n" +
 	"\n" +
 	"CreateNode\x12\x1a.node.v1.CreateNodeRequest\x1a\x1b.node.v1.CreateNodeResponse\x12E\n" +
@@ -1443,7 +5064,27 @@ const file_node_v1_node_proto_rawDesc = "" +
 	"UnlinkNode\x12\x1a.node.v1.UnlinkNodeRequest\x1a\x1b.node.v1.UnlinkNodeResponse\x12K\n" +
 	"\fGetTreeNodes\x12\x1c.node.v1.GetTreeNodesRequest\x1a\x1d.node.v1.GetTreeNodesResponse\x12B\n" +
 	"\tAddParent\x12\x19.node.v1.AddParentRequest\x1a\x1a.node.v1.AddParentResponse\x12K\n" +
-	"\fRemoveParent\x12\x1c.node.v1.RemoveParentRequest\x1a\x1d.node.v1.RemoveParentResponse\x12c\n" +
+	"\fRemoveParent\x12\x1c.node.v1.RemoveParentRequest\x1a\x1d.node.v1.RemoveParentResponse\x12K\n" +
+	"\fUpdateLayout\x12\x1c.node.v1.UpdateLayoutRequest\x1a\x1d.node.v1.UpdateLayoutResponse\x12H\n" +
+	"\vImportNodes\x12\x1b.node.v1.ImportNodesRequest\x1a\x1c.node.v1.ImportNodesResponse\x12E\n" +
+	"\n" +
+	"ExportTree\x12\x1a.node.v1.ExportTreeRequest\x1a\x1b.node.v1.ExportTreeResponse\x12Q\n" +
+	"\x0eCreateSnapshot\x12\x1e.node.v1.CreateSnapshotRequest\x1a\x1f.node.v1.CreateSnapshotResponse\x12N\n" +
+	"\rListSnapshots\x12\x1d.node.v1.ListSnapshotsRequest\x1a\x1e.node.v1.ListSnapshotsResponse\x12K\n" +
+	"\fDiffSnapshot\x12\x1c.node.v1.DiffSnapshotRequest\x1a\x1d.node.v1.DiffSnapshotResponse\x12T\n" +
+	"\x0fRestoreSnapshot\x12\x1f.node.v1.RestoreSnapshotRequest\x1a .node.v1.RestoreSnapshotResponse\x123\n" +
+	"\x04Undo\x12\x14.node.v1.UndoRequest\x1a\x15.node.v1.UndoResponse\x123\n" +
+	"\x04Redo\x12\x14.node.v1.RedoRequest\x1a\x15.node.v1.RedoResponse\x12B\n" +
+	"\tListTrash\x12\x19.node.v1.ListTrashRequest\x1a\x1a.node.v1.ListTrashResponse\x12W\n" +
+	"\x10RestoreFromTrash\x12 .node.v1.RestoreFromTrashRequest\x1a!.node.v1.RestoreFromTrashResponse\x12N\n" +
+	"\rDeleteSubtree\x12\x1d.node.v1.DeleteSubtreeRequest\x1a\x1e.node.v1.DeleteSubtreeResponse\x12H\n" +
+	"\vCopySubtree\x12\x1b.node.v1.CopySubtreeRequest\x1a\x1c.node.v1.CopySubtreeResponse\x12H\n" +
+	"\vMoveSubtree\x12\x1b.node.v1.MoveSubtreeRequest\x1a\x1c.node.v1.MoveSubtreeResponse\x12K\n" +
+	"\fGetAncestors\x12\x1c.node.v1.GetAncestorsRequest\x1a\x1d.node.v1.GetAncestorsResponse\x12Q\n" +
+	"\x0eGetDescendants\x12\x1e.node.v1.GetDescendantsRequest\x1a\x1f.node.v1.GetDescendantsResponse\x12W\n" +
+	"\x10FindRelationship\x12 .node.v1.FindRelationshipRequest\x1a!.node.v1.FindRelationshipResponse\x12H\n" +
+	"\vSearchNodes\x12\x1b.node.v1.SearchNodesRequest\x1a\x1c.node.v1.SearchNodesResponse\x12D\n" +
+	"\tWatchTree\x12\x19.node.v1.WatchTreeRequest\x1a\x1a.node.v1.WatchTreeResponse0\x01\x12c\n" +
 	"\x14GetNodesByShareToken\x12$.node.v1.GetNodesByShareTokenRequest\x1a%.node.v1.GetNodesByShareTokenResponseB>Z<github.com/TitleKung-01/code-tree-backend/gen/node/v1;nodev1b\x06proto3"
 
 var (
@@ -1458,65 +5099,194 @@ func file_node_v1_node_proto_rawDescGZIP() []byte {
 	return file_node_v1_node_proto_rawDescData
 }
 
-var file_node_v1_node_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_node_v1_node_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_node_v1_node_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_node_v1_node_proto_msgTypes = make([]protoimpl.MessageInfo, 66)
 var file_node_v1_node_proto_goTypes = []any{
 	(NodeStatus)(0),                      // 0: node.v1.NodeStatus
-	(*Node)(nil),                         // 1: node.v1.Node
-	(*CreateNodeRequest)(nil),            // 2: node.v1.CreateNodeRequest
-	(*CreateNodeResponse)(nil),           // 3: node.v1.CreateNodeResponse
-	(*UpdateNodeRequest)(nil),            // 4: node.v1.UpdateNodeRequest
-	(*UpdateNodeResponse)(nil),           // 5: node.v1.UpdateNodeResponse
-	(*DeleteNodeRequest)(nil),            // 6: node.v1.DeleteNodeRequest
-	(*DeleteNodeResponse)(nil),           // 7: node.v1.DeleteNodeResponse
-	(*MoveNodeRequest)(nil),              // 8: node.v1.MoveNodeRequest
-	(*MoveNodeResponse)(nil),             // 9: node.v1.MoveNodeResponse
-	(*GetTreeNodesRequest)(nil),          // 10: node.v1.GetTreeNodesRequest
-	(*GetTreeNodesResponse)(nil),         // 11: node.v1.GetTreeNodesResponse
-	(*UnlinkNodeRequest)(nil),            // 12: node.v1.UnlinkNodeRequest
-	(*UnlinkNodeResponse)(nil),           // 13: node.v1.UnlinkNodeResponse
-	(*AddParentRequest)(nil),             // 14: node.v1.AddParentRequest
-	(*AddParentResponse)(nil),            // 15: node.v1.AddParentResponse
-	(*RemoveParentRequest)(nil),          // 16: node.v1.RemoveParentRequest
-	(*RemoveParentResponse)(nil),         // 17: node.v1.RemoveParentResponse
-	(*GetNodesByShareTokenRequest)(nil),  // 18: node.v1.GetNodesByShareTokenRequest
-	(*GetNodesByShareTokenResponse)(nil), // 19: node.v1.GetNodesByShareTokenResponse
+	(ImportFormat)(0),                    // 1: node.v1.ImportFormat
+	(ExportFormat)(0),                    // 2: node.v1.ExportFormat
+	(TreeEventType)(0),                   // 3: node.v1.TreeEventType
+	(SnapshotChangeType)(0),              // 4: node.v1.SnapshotChangeType
+	(TrashItemType)(0),                   // 5: node.v1.TrashItemType
+	(SharedDescendantPolicy)(0),          // 6: node.v1.SharedDescendantPolicy
+	(RelationshipKind)(0),                // 7: node.v1.RelationshipKind
+	(*Node)(nil),                         // 8: node.v1.Node
+	(*CreateNodeRequest)(nil),            // 9: node.v1.CreateNodeRequest
+	(*CreateNodeResponse)(nil),           // 10: node.v1.CreateNodeResponse
+	(*UpdateNodeRequest)(nil),            // 11: node.v1.UpdateNodeRequest
+	(*UpdateNodeResponse)(nil),           // 12: node.v1.UpdateNodeResponse
+	(*DeleteNodeRequest)(nil),            // 13: node.v1.DeleteNodeRequest
+	(*DeleteNodeResponse)(nil),           // 14: node.v1.DeleteNodeResponse
+	(*MoveNodeRequest)(nil),              // 15: node.v1.MoveNodeRequest
+	(*MoveNodeResponse)(nil),             // 16: node.v1.MoveNodeResponse
+	(*GetTreeNodesRequest)(nil),          // 17: node.v1.GetTreeNodesRequest
+	(*GetTreeNodesResponse)(nil),         // 18: node.v1.GetTreeNodesResponse
+	(*UnlinkNodeRequest)(nil),            // 19: node.v1.UnlinkNodeRequest
+	(*UnlinkNodeResponse)(nil),           // 20: node.v1.UnlinkNodeResponse
+	(*AddParentRequest)(nil),             // 21: node.v1.AddParentRequest
+	(*AddParentResponse)(nil),            // 22: node.v1.AddParentResponse
+	(*RemoveParentRequest)(nil),          // 23: node.v1.RemoveParentRequest
+	(*RemoveParentResponse)(nil),         // 24: node.v1.RemoveParentResponse
+	(*NodePosition)(nil),                 // 25: node.v1.NodePosition
+	(*UpdateLayoutRequest)(nil),          // 26: node.v1.UpdateLayoutRequest
+	(*UpdateLayoutResponse)(nil),         // 27: node.v1.UpdateLayoutResponse
+	(*GetNodesByShareTokenRequest)(nil),  // 28: node.v1.GetNodesByShareTokenRequest
+	(*GetNodesByShareTokenResponse)(nil), // 29: node.v1.GetNodesByShareTokenResponse
+	(*ImportNodesRequest)(nil),           // 30: node.v1.ImportNodesRequest
+	(*ImportIssue)(nil),                  // 31: node.v1.ImportIssue
+	(*ImportNodesResponse)(nil),          // 32: node.v1.ImportNodesResponse
+	(*ExportTreeRequest)(nil),            // 33: node.v1.ExportTreeRequest
+	(*ExportTreeResponse)(nil),           // 34: node.v1.ExportTreeResponse
+	(*WatchTreeRequest)(nil),             // 35: node.v1.WatchTreeRequest
+	(*WatchTreeResponse)(nil),            // 36: node.v1.WatchTreeResponse
+	(*Snapshot)(nil),                     // 37: node.v1.Snapshot
+	(*CreateSnapshotRequest)(nil),        // 38: node.v1.CreateSnapshotRequest
+	(*CreateSnapshotResponse)(nil),       // 39: node.v1.CreateSnapshotResponse
+	(*ListSnapshotsRequest)(nil),         // 40: node.v1.ListSnapshotsRequest
+	(*ListSnapshotsResponse)(nil),        // 41: node.v1.ListSnapshotsResponse
+	(*SnapshotChange)(nil),               // 42: node.v1.SnapshotChange
+	(*DiffSnapshotRequest)(nil),          // 43: node.v1.DiffSnapshotRequest
+	(*DiffSnapshotResponse)(nil),         // 44: node.v1.DiffSnapshotResponse
+	(*RestoreSnapshotRequest)(nil),       // 45: node.v1.RestoreSnapshotRequest
+	(*RestoreSnapshotResponse)(nil),      // 46: node.v1.RestoreSnapshotResponse
+	(*UndoRequest)(nil),                  // 47: node.v1.UndoRequest
+	(*UndoResponse)(nil),                 // 48: node.v1.UndoResponse
+	(*RedoRequest)(nil),                  // 49: node.v1.RedoRequest
+	(*RedoResponse)(nil),                 // 50: node.v1.RedoResponse
+	(*TrashItem)(nil),                    // 51: node.v1.TrashItem
+	(*ListTrashRequest)(nil),             // 52: node.v1.ListTrashRequest
+	(*ListTrashResponse)(nil),            // 53: node.v1.ListTrashResponse
+	(*RestoreFromTrashRequest)(nil),      // 54: node.v1.RestoreFromTrashRequest
+	(*RestoreFromTrashResponse)(nil),     // 55: node.v1.RestoreFromTrashResponse
+	(*DeleteSubtreeRequest)(nil),         // 56: node.v1.DeleteSubtreeRequest
+	(*DeleteSubtreeResponse)(nil),        // 57: node.v1.DeleteSubtreeResponse
+	(*CopySubtreeRequest)(nil),           // 58: node.v1.CopySubtreeRequest
+	(*CopySubtreeResponse)(nil),          // 59: node.v1.CopySubtreeResponse
+	(*MoveSubtreeRequest)(nil),           // 60: node.v1.MoveSubtreeRequest
+	(*MoveSubtreeResponse)(nil),          // 61: node.v1.MoveSubtreeResponse
+	(*LineageNode)(nil),                  // 62: node.v1.LineageNode
+	(*GetAncestorsRequest)(nil),          // 63: node.v1.GetAncestorsRequest
+	(*GetAncestorsResponse)(nil),         // 64: node.v1.GetAncestorsResponse
+	(*GetDescendantsRequest)(nil),        // 65: node.v1.GetDescendantsRequest
+	(*GetDescendantsResponse)(nil),       // 66: node.v1.GetDescendantsResponse
+	(*FindRelationshipRequest)(nil),      // 67: node.v1.FindRelationshipRequest
+	(*FindRelationshipResponse)(nil),     // 68: node.v1.FindRelationshipResponse
+	(*SearchNodesRequest)(nil),           // 69: node.v1.SearchNodesRequest
+	(*SearchHit)(nil),                    // 70: node.v1.SearchHit
+	(*SearchNodesResponse)(nil),          // 71: node.v1.SearchNodesResponse
+	nil,                                  // 72: node.v1.Node.SiblingOrdersEntry
+	nil,                                  // 73: node.v1.CopySubtreeResponse.IdMapEntry
+	(*v1.ContactPrivacy)(nil),            // 74: tree.v1.ContactPrivacy
 }
 var file_node_v1_node_proto_depIdxs = []int32{
 	0,  // 0: node.v1.Node.status:type_name -> node.v1.NodeStatus
-	0,  // 1: node.v1.CreateNodeRequest.status:type_name -> node.v1.NodeStatus
-	1,  // 2: node.v1.CreateNodeResponse.node:type_name -> node.v1.Node
-	0,  // 3: node.v1.UpdateNodeRequest.status:type_name -> node.v1.NodeStatus
-	1,  // 4: node.v1.UpdateNodeResponse.node:type_name -> node.v1.Node
-	1,  // 5: node.v1.MoveNodeResponse.node:type_name -> node.v1.Node
-	1,  // 6: node.v1.GetTreeNodesResponse.nodes:type_name -> node.v1.Node
-	1,  // 7: node.v1.UnlinkNodeResponse.node:type_name -> node.v1.Node
-	1,  // 8: node.v1.AddParentResponse.node:type_name -> node.v1.Node
-	1,  // 9: node.v1.RemoveParentResponse.node:type_name -> node.v1.Node
-	1,  // 10: node.v1.GetNodesByShareTokenResponse.nodes:type_name -> node.v1.Node
-	2,  // 11: node.v1.NodeService.CreateNode:input_type -> node.v1.CreateNodeRequest
-	4,  // 12: node.v1.NodeService.UpdateNode:input_type -> node.v1.UpdateNodeRequest
-	6,  // 13: node.v1.NodeService.DeleteNode:input_type -> node.v1.DeleteNodeRequest
-	8,  // 14: node.v1.NodeService.MoveNode:input_type -> node.v1.MoveNodeRequest
-	12, // 15: node.v1.NodeService.UnlinkNode:input_type -> node.v1.UnlinkNodeRequest
-	10, // 16: node.v1.NodeService.GetTreeNodes:input_type -> node.v1.GetTreeNodesRequest
-	14, // 17: node.v1.NodeService.AddParent:input_type -> node.v1.AddParentRequest
-	16, // 18: node.v1.NodeService.RemoveParent:input_type -> node.v1.RemoveParentRequest
-	18, // 19: node.v1.NodeService.GetNodesByShareToken:input_type -> node.v1.GetNodesByShareTokenRequest
-	3,  // 20: node.v1.NodeService.CreateNode:output_type -> node.v1.CreateNodeResponse
-	5,  // 21: node.v1.NodeService.UpdateNode:output_type -> node.v1.UpdateNodeResponse
-	7,  // 22: node.v1.NodeService.DeleteNode:output_type -> node.v1.DeleteNodeResponse
-	9,  // 23: node.v1.NodeService.MoveNode:output_type -> node.v1.MoveNodeResponse
-	13, // 24: node.v1.NodeService.UnlinkNode:output_type -> node.v1.UnlinkNodeResponse
-	11, // 25: node.v1.NodeService.GetTreeNodes:output_type -> node.v1.GetTreeNodesResponse
-	15, // 26: node.v1.NodeService.AddParent:output_type -> node.v1.AddParentResponse
-	17, // 27: node.v1.NodeService.RemoveParent:output_type -> node.v1.RemoveParentResponse
-	19, // 28: node.v1.NodeService.GetNodesByShareToken:output_type -> node.v1.GetNodesByShareTokenResponse
-	20, // [20:29] is the sub-list for method output_type
-	11, // [11:20] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	72, // 1: node.v1.Node.sibling_orders:type_name -> node.v1.Node.SiblingOrdersEntry
+	74, // 2: node.v1.Node.contact_privacy:type_name -> tree.v1.ContactPrivacy
+	0,  // 3: node.v1.CreateNodeRequest.status:type_name -> node.v1.NodeStatus
+	74, // 4: node.v1.CreateNodeRequest.contact_privacy:type_name -> tree.v1.ContactPrivacy
+	8,  // 5: node.v1.CreateNodeResponse.node:type_name -> node.v1.Node
+	0,  // 6: node.v1.UpdateNodeRequest.status:type_name -> node.v1.NodeStatus
+	74, // 7: node.v1.UpdateNodeRequest.contact_privacy:type_name -> tree.v1.ContactPrivacy
+	8,  // 8: node.v1.UpdateNodeResponse.node:type_name -> node.v1.Node
+	8,  // 9: node.v1.MoveNodeResponse.node:type_name -> node.v1.Node
+	8,  // 10: node.v1.GetTreeNodesResponse.nodes:type_name -> node.v1.Node
+	8,  // 11: node.v1.UnlinkNodeResponse.node:type_name -> node.v1.Node
+	8,  // 12: node.v1.AddParentResponse.node:type_name -> node.v1.Node
+	8,  // 13: node.v1.RemoveParentResponse.node:type_name -> node.v1.Node
+	25, // 14: node.v1.UpdateLayoutRequest.positions:type_name -> node.v1.NodePosition
+	8,  // 15: node.v1.GetNodesByShareTokenResponse.nodes:type_name -> node.v1.Node
+	1,  // 16: node.v1.ImportNodesRequest.format:type_name -> node.v1.ImportFormat
+	31, // 17: node.v1.ImportNodesResponse.issues:type_name -> node.v1.ImportIssue
+	8,  // 18: node.v1.ImportNodesResponse.nodes:type_name -> node.v1.Node
+	2,  // 19: node.v1.ExportTreeRequest.format:type_name -> node.v1.ExportFormat
+	3,  // 20: node.v1.WatchTreeResponse.type:type_name -> node.v1.TreeEventType
+	8,  // 21: node.v1.WatchTreeResponse.node:type_name -> node.v1.Node
+	37, // 22: node.v1.CreateSnapshotResponse.snapshot:type_name -> node.v1.Snapshot
+	37, // 23: node.v1.ListSnapshotsResponse.snapshots:type_name -> node.v1.Snapshot
+	4,  // 24: node.v1.SnapshotChange.type:type_name -> node.v1.SnapshotChangeType
+	42, // 25: node.v1.DiffSnapshotResponse.changes:type_name -> node.v1.SnapshotChange
+	8,  // 26: node.v1.RestoreSnapshotResponse.nodes:type_name -> node.v1.Node
+	37, // 27: node.v1.RestoreSnapshotResponse.backup:type_name -> node.v1.Snapshot
+	8,  // 28: node.v1.UndoResponse.nodes:type_name -> node.v1.Node
+	8,  // 29: node.v1.RedoResponse.nodes:type_name -> node.v1.Node
+	5,  // 30: node.v1.TrashItem.type:type_name -> node.v1.TrashItemType
+	51, // 31: node.v1.ListTrashResponse.items:type_name -> node.v1.TrashItem
+	5,  // 32: node.v1.RestoreFromTrashRequest.type:type_name -> node.v1.TrashItemType
+	8,  // 33: node.v1.RestoreFromTrashResponse.node:type_name -> node.v1.Node
+	6,  // 34: node.v1.DeleteSubtreeRequest.shared_policy:type_name -> node.v1.SharedDescendantPolicy
+	6,  // 35: node.v1.CopySubtreeRequest.shared_policy:type_name -> node.v1.SharedDescendantPolicy
+	8,  // 36: node.v1.CopySubtreeResponse.nodes:type_name -> node.v1.Node
+	73, // 37: node.v1.CopySubtreeResponse.id_map:type_name -> node.v1.CopySubtreeResponse.IdMapEntry
+	6,  // 38: node.v1.MoveSubtreeRequest.shared_policy:type_name -> node.v1.SharedDescendantPolicy
+	8,  // 39: node.v1.MoveSubtreeResponse.nodes:type_name -> node.v1.Node
+	8,  // 40: node.v1.LineageNode.node:type_name -> node.v1.Node
+	62, // 41: node.v1.GetAncestorsResponse.ancestors:type_name -> node.v1.LineageNode
+	62, // 42: node.v1.GetDescendantsResponse.descendants:type_name -> node.v1.LineageNode
+	7,  // 43: node.v1.FindRelationshipResponse.kind:type_name -> node.v1.RelationshipKind
+	0,  // 44: node.v1.SearchNodesRequest.statuses:type_name -> node.v1.NodeStatus
+	8,  // 45: node.v1.SearchHit.node:type_name -> node.v1.Node
+	70, // 46: node.v1.SearchNodesResponse.hits:type_name -> node.v1.SearchHit
+	9,  // 47: node.v1.NodeService.CreateNode:input_type -> node.v1.CreateNodeRequest
+	11, // 48: node.v1.NodeService.UpdateNode:input_type -> node.v1.UpdateNodeRequest
+	13, // 49: node.v1.NodeService.DeleteNode:input_type -> node.v1.DeleteNodeRequest
+	15, // 50: node.v1.NodeService.MoveNode:input_type -> node.v1.MoveNodeRequest
+	19, // 51: node.v1.NodeService.UnlinkNode:input_type -> node.v1.UnlinkNodeRequest
+	17, // 52: node.v1.NodeService.GetTreeNodes:input_type -> node.v1.GetTreeNodesRequest
+	21, // 53: node.v1.NodeService.AddParent:input_type -> node.v1.AddParentRequest
+	23, // 54: node.v1.NodeService.RemoveParent:input_type -> node.v1.RemoveParentRequest
+	26, // 55: node.v1.NodeService.UpdateLayout:input_type -> node.v1.UpdateLayoutRequest
+	30, // 56: node.v1.NodeService.ImportNodes:input_type -> node.v1.ImportNodesRequest
+	33, // 57: node.v1.NodeService.ExportTree:input_type -> node.v1.ExportTreeRequest
+	38, // 58: node.v1.NodeService.CreateSnapshot:input_type -> node.v1.CreateSnapshotRequest
+	40, // 59: node.v1.NodeService.ListSnapshots:input_type -> node.v1.ListSnapshotsRequest
+	43, // 60: node.v1.NodeService.DiffSnapshot:input_type -> node.v1.DiffSnapshotRequest
+	45, // 61: node.v1.NodeService.RestoreSnapshot:input_type -> node.v1.RestoreSnapshotRequest
+	47, // 62: node.v1.NodeService.Undo:input_type -> node.v1.UndoRequest
+	49, // 63: node.v1.NodeService.Redo:input_type -> node.v1.RedoRequest
+	52, // 64: node.v1.NodeService.ListTrash:input_type -> node.v1.ListTrashRequest
+	54, // 65: node.v1.NodeService.RestoreFromTrash:input_type -> node.v1.RestoreFromTrashRequest
+	56, // 66: node.v1.NodeService.DeleteSubtree:input_type -> node.v1.DeleteSubtreeRequest
+	58, // 67: node.v1.NodeService.CopySubtree:input_type -> node.v1.CopySubtreeRequest
+	60, // 68: node.v1.NodeService.MoveSubtree:input_type -> node.v1.MoveSubtreeRequest
+	63, // 69: node.v1.NodeService.GetAncestors:input_type -> node.v1.GetAncestorsRequest
+	65, // 70: node.v1.NodeService.GetDescendants:input_type -> node.v1.GetDescendantsRequest
+	67, // 71: node.v1.NodeService.FindRelationship:input_type -> node.v1.FindRelationshipRequest
+	69, // 72: node.v1.NodeService.SearchNodes:input_type -> node.v1.SearchNodesRequest
+	35, // 73: node.v1.NodeService.WatchTree:input_type -> node.v1.WatchTreeRequest
+	28, // 74: node.v1.NodeService.GetNodesByShareToken:input_type -> node.v1.GetNodesByShareTokenRequest
+	10, // 75: node.v1.NodeService.CreateNode:output_type -> node.v1.CreateNodeResponse
+	12, // 76: node.v1.NodeService.UpdateNode:output_type -> node.v1.UpdateNodeResponse
+	14, // 77: node.v1.NodeService.DeleteNode:output_type -> node.v1.DeleteNodeResponse
+	16, // 78: node.v1.NodeService.MoveNode:output_type -> node.v1.MoveNodeResponse
+	20, // 79: node.v1.NodeService.UnlinkNode:output_type -> node.v1.UnlinkNodeResponse
+	18, // 80: node.v1.NodeService.GetTreeNodes:output_type -> node.v1.GetTreeNodesResponse
+	22, // 81: node.v1.NodeService.AddParent:output_type -> node.v1.AddParentResponse
+	24, // 82: node.v1.NodeService.RemoveParent:output_type -> node.v1.RemoveParentResponse
+	27, // 83: node.v1.NodeService.UpdateLayout:output_type -> node.v1.UpdateLayoutResponse
+	32, // 84: node.v1.NodeService.ImportNodes:output_type -> node.v1.ImportNodesResponse
+	34, // 85: node.v1.NodeService.ExportTree:output_type -> node.v1.ExportTreeResponse
+	39, // 86: node.v1.NodeService.CreateSnapshot:output_type -> node.v1.CreateSnapshotResponse
+	41, // 87: node.v1.NodeService.ListSnapshots:output_type -> node.v1.ListSnapshotsResponse
+	44, // 88: node.v1.NodeService.DiffSnapshot:output_type -> node.v1.DiffSnapshotResponse
+	46, // 89: node.v1.NodeService.RestoreSnapshot:output_type -> node.v1.RestoreSnapshotResponse
+	48, // 90: node.v1.NodeService.Undo:output_type -> node.v1.UndoResponse
+	50, // 91: node.v1.NodeService.Redo:output_type -> node.v1.RedoResponse
+	53, // 92: node.v1.NodeService.ListTrash:output_type -> node.v1.ListTrashResponse
+	55, // 93: node.v1.NodeService.RestoreFromTrash:output_type -> node.v1.RestoreFromTrashResponse
+	57, // 94: node.v1.NodeService.DeleteSubtree:output_type -> node.v1.DeleteSubtreeResponse
+	59, // 95: node.v1.NodeService.CopySubtree:output_type -> node.v1.CopySubtreeResponse
+	61, // 96: node.v1.NodeService.MoveSubtree:output_type -> node.v1.MoveSubtreeResponse
+	64, // 97: node.v1.NodeService.GetAncestors:output_type -> node.v1.GetAncestorsResponse
+	66, // 98: node.v1.NodeService.GetDescendants:output_type -> node.v1.GetDescendantsResponse
+	68, // 99: node.v1.NodeService.FindRelationship:output_type -> node.v1.FindRelationshipResponse
+	71, // 100: node.v1.NodeService.SearchNodes:output_type -> node.v1.SearchNodesResponse
+	36, // 101: node.v1.NodeService.WatchTree:output_type -> node.v1.WatchTreeResponse
+	29, // 102: node.v1.NodeService.GetNodesByShareToken:output_type -> node.v1.GetNodesByShareTokenResponse
+	75, // [75:103] is the sub-list for method output_type
+	47, // [47:75] is the sub-list for method input_type
+	47, // [47:47] is the sub-list for extension type_name
+	47, // [47:47] is the sub-list for extension extendee
+	0,  // [0:47] is the sub-list for field type_name
 }
 
 func init() { file_node_v1_node_proto_init() }
@@ -1526,13 +5296,30 @@ func file_node_v1_node_proto_init() {
 	}
 	file_node_v1_node_proto_msgTypes[0].OneofWrappers = []any{}
 	file_node_v1_node_proto_msgTypes[1].OneofWrappers = []any{}
+	file_node_v1_node_proto_msgTypes[5].OneofWrappers = []any{}
+	file_node_v1_node_proto_msgTypes[7].OneofWrappers = []any{}
+	file_node_v1_node_proto_msgTypes[11].OneofWrappers = []any{}
+	file_node_v1_node_proto_msgTypes[13].OneofWrappers = []any{}
+	file_node_v1_node_proto_msgTypes[15].OneofWrappers = []any{}
+	file_node_v1_node_proto_msgTypes[22].OneofWrappers = []any{}
+	file_node_v1_node_proto_msgTypes[29].OneofWrappers = []any{}
+	file_node_v1_node_proto_msgTypes[37].OneofWrappers = []any{}
+	file_node_v1_node_proto_msgTypes[39].OneofWrappers = []any{}
+	file_node_v1_node_proto_msgTypes[41].OneofWrappers = []any{}
+	file_node_v1_node_proto_msgTypes[43].OneofWrappers = []any{}
+	file_node_v1_node_proto_msgTypes[46].OneofWrappers = []any{}
+	file_node_v1_node_proto_msgTypes[48].OneofWrappers = []any{}
+	file_node_v1_node_proto_msgTypes[50].OneofWrappers = []any{}
+	file_node_v1_node_proto_msgTypes[52].OneofWrappers = []any{}
+	file_node_v1_node_proto_msgTypes[60].OneofWrappers = []any{}
+	file_node_v1_node_proto_msgTypes[61].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_node_v1_node_proto_rawDesc), len(file_node_v1_node_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   19,
+			NumEnums:      8,
+			NumMessages:   66,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -51,6 +51,54 @@ const (
 	// NodeServiceRemoveParentProcedure is the fully-qualified name of the NodeService's RemoveParent
 	// RPC.
 	NodeServiceRemoveParentProcedure = "/node.v1.NodeService/RemoveParent"
+	// NodeServiceUpdateLayoutProcedure is the fully-qualified name of the NodeService's UpdateLayout
+	// RPC.
+	NodeServiceUpdateLayoutProcedure = "/node.v1.NodeService/UpdateLayout"
+	// NodeServiceImportNodesProcedure is the fully-qualified name of the NodeService's ImportNodes RPC.
+	NodeServiceImportNodesProcedure = "/node.v1.NodeService/ImportNodes"
+	// NodeServiceExportTreeProcedure is the fully-qualified name of the NodeService's ExportTree RPC.
+	NodeServiceExportTreeProcedure = "/node.v1.NodeService/ExportTree"
+	// NodeServiceCreateSnapshotProcedure is the fully-qualified name of the NodeService's
+	// CreateSnapshot RPC.
+	NodeServiceCreateSnapshotProcedure = "/node.v1.NodeService/CreateSnapshot"
+	// NodeServiceListSnapshotsProcedure is the fully-qualified name of the NodeService's ListSnapshots
+	// RPC.
+	NodeServiceListSnapshotsProcedure = "/node.v1.NodeService/ListSnapshots"
+	// NodeServiceDiffSnapshotProcedure is the fully-qualified name of the NodeService's DiffSnapshot
+	// RPC.
+	NodeServiceDiffSnapshotProcedure = "/node.v1.NodeService/DiffSnapshot"
+	// NodeServiceRestoreSnapshotProcedure is the fully-qualified name of the NodeService's
+	// RestoreSnapshot RPC.
+	NodeServiceRestoreSnapshotProcedure = "/node.v1.NodeService/RestoreSnapshot"
+	// NodeServiceUndoProcedure is the fully-qualified name of the NodeService's Undo RPC.
+	NodeServiceUndoProcedure = "/node.v1.NodeService/Undo"
+	// NodeServiceRedoProcedure is the fully-qualified name of the NodeService's Redo RPC.
+	NodeServiceRedoProcedure = "/node.v1.NodeService/Redo"
+	// NodeServiceListTrashProcedure is the fully-qualified name of the NodeService's ListTrash RPC.
+	NodeServiceListTrashProcedure = "/node.v1.NodeService/ListTrash"
+	// NodeServiceRestoreFromTrashProcedure is the fully-qualified name of the NodeService's
+	// RestoreFromTrash RPC.
+	NodeServiceRestoreFromTrashProcedure = "/node.v1.NodeService/RestoreFromTrash"
+	// NodeServiceDeleteSubtreeProcedure is the fully-qualified name of the NodeService's DeleteSubtree
+	// RPC.
+	NodeServiceDeleteSubtreeProcedure = "/node.v1.NodeService/DeleteSubtree"
+	// NodeServiceCopySubtreeProcedure is the fully-qualified name of the NodeService's CopySubtree RPC.
+	NodeServiceCopySubtreeProcedure = "/node.v1.NodeService/CopySubtree"
+	// NodeServiceMoveSubtreeProcedure is the fully-qualified name of the NodeService's MoveSubtree RPC.
+	NodeServiceMoveSubtreeProcedure = "/node.v1.NodeService/MoveSubtree"
+	// NodeServiceGetAncestorsProcedure is the fully-qualified name of the NodeService's GetAncestors
+	// RPC.
+	NodeServiceGetAncestorsProcedure = "/node.v1.NodeService/GetAncestors"
+	// NodeServiceGetDescendantsProcedure is the fully-qualified name of the NodeService's
+	// GetDescendants RPC.
+	NodeServiceGetDescendantsProcedure = "/node.v1.NodeService/GetDescendants"
+	// NodeServiceFindRelationshipProcedure is the fully-qualified name of the NodeService's
+	// FindRelationship RPC.
+	NodeServiceFindRelationshipProcedure = "/node.v1.NodeService/FindRelationship"
+	// NodeServiceSearchNodesProcedure is the fully-qualified name of the NodeService's SearchNodes RPC.
+	NodeServiceSearchNodesProcedure = "/node.v1.NodeService/SearchNodes"
+	// NodeServiceWatchTreeProcedure is the fully-qualified name of the NodeService's WatchTree RPC.
+	NodeServiceWatchTreeProcedure = "/node.v1.NodeService/WatchTree"
 	// NodeServiceGetNodesByShareTokenProcedure is the fully-qualified name of the NodeService's
 	// GetNodesByShareToken RPC.
 	NodeServiceGetNodesByShareTokenProcedure = "/node.v1.NodeService/GetNodesByShareToken"
@@ -66,6 +114,33 @@ type NodeServiceClient interface {
 	GetTreeNodes(context.Context, *connect.Request[v1.GetTreeNodesRequest]) (*connect.Response[v1.GetTreeNodesResponse], error)
 	AddParent(context.Context, *connect.Request[v1.AddParentRequest]) (*connect.Response[v1.AddParentResponse], error)
 	RemoveParent(context.Context, *connect.Request[v1.RemoveParentRequest]) (*connect.Response[v1.RemoveParentResponse], error)
+	UpdateLayout(context.Context, *connect.Request[v1.UpdateLayoutRequest]) (*connect.Response[v1.UpdateLayoutResponse], error)
+	// ★ Bulk import
+	ImportNodes(context.Context, *connect.Request[v1.ImportNodesRequest]) (*connect.Response[v1.ImportNodesResponse], error)
+	// ★ Export (ดาวน์โหลดผ่าน HTTP ได้ที่ GET /export/{treeId}?format=...)
+	ExportTree(context.Context, *connect.Request[v1.ExportTreeRequest]) (*connect.Response[v1.ExportTreeResponse], error)
+	// ★ Snapshot / restore / undo
+	CreateSnapshot(context.Context, *connect.Request[v1.CreateSnapshotRequest]) (*connect.Response[v1.CreateSnapshotResponse], error)
+	ListSnapshots(context.Context, *connect.Request[v1.ListSnapshotsRequest]) (*connect.Response[v1.ListSnapshotsResponse], error)
+	DiffSnapshot(context.Context, *connect.Request[v1.DiffSnapshotRequest]) (*connect.Response[v1.DiffSnapshotResponse], error)
+	RestoreSnapshot(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.RestoreSnapshotResponse], error)
+	Undo(context.Context, *connect.Request[v1.UndoRequest]) (*connect.Response[v1.UndoResponse], error)
+	Redo(context.Context, *connect.Request[v1.RedoRequest]) (*connect.Response[v1.RedoResponse], error)
+	// ★ Trash
+	ListTrash(context.Context, *connect.Request[v1.ListTrashRequest]) (*connect.Response[v1.ListTrashResponse], error)
+	RestoreFromTrash(context.Context, *connect.Request[v1.RestoreFromTrashRequest]) (*connect.Response[v1.RestoreFromTrashResponse], error)
+	// ★ Subtree
+	DeleteSubtree(context.Context, *connect.Request[v1.DeleteSubtreeRequest]) (*connect.Response[v1.DeleteSubtreeResponse], error)
+	CopySubtree(context.Context, *connect.Request[v1.CopySubtreeRequest]) (*connect.Response[v1.CopySubtreeResponse], error)
+	MoveSubtree(context.Context, *connect.Request[v1.MoveSubtreeRequest]) (*connect.Response[v1.MoveSubtreeResponse], error)
+	// ★ Lineage
+	GetAncestors(context.Context, *connect.Request[v1.GetAncestorsRequest]) (*connect.Response[v1.GetAncestorsResponse], error)
+	GetDescendants(context.Context, *connect.Request[v1.GetDescendantsRequest]) (*connect.Response[v1.GetDescendantsResponse], error)
+	FindRelationship(context.Context, *connect.Request[v1.FindRelationshipRequest]) (*connect.Response[v1.FindRelationshipResponse], error)
+	// ★ Search
+	SearchNodes(context.Context, *connect.Request[v1.SearchNodesRequest]) (*connect.Response[v1.SearchNodesResponse], error)
+	// ★ Realtime (server-streaming)
+	WatchTree(context.Context, *connect.Request[v1.WatchTreeRequest]) (*connect.ServerStreamForClient[v1.WatchTreeResponse], error)
 	// ★ Public (ไม่ต้อง login)
 	GetNodesByShareToken(context.Context, *connect.Request[v1.GetNodesByShareTokenRequest]) (*connect.Response[v1.GetNodesByShareTokenResponse], error)
 }
@@ -129,6 +204,120 @@ func NewNodeServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(nodeServiceMethods.ByName("RemoveParent")),
 			connect.WithClientOptions(opts...),
 		),
+		updateLayout: connect.NewClient[v1.UpdateLayoutRequest, v1.UpdateLayoutResponse](
+			httpClient,
+			baseURL+NodeServiceUpdateLayoutProcedure,
+			connect.WithSchema(nodeServiceMethods.ByName("UpdateLayout")),
+			connect.WithClientOptions(opts...),
+		),
+		importNodes: connect.NewClient[v1.ImportNodesRequest, v1.ImportNodesResponse](
+			httpClient,
+			baseURL+NodeServiceImportNodesProcedure,
+			connect.WithSchema(nodeServiceMethods.ByName("ImportNodes")),
+			connect.WithClientOptions(opts...),
+		),
+		exportTree: connect.NewClient[v1.ExportTreeRequest, v1.ExportTreeResponse](
+			httpClient,
+			baseURL+NodeServiceExportTreeProcedure,
+			connect.WithSchema(nodeServiceMethods.ByName("ExportTree")),
+			connect.WithClientOptions(opts...),
+		),
+		createSnapshot: connect.NewClient[v1.CreateSnapshotRequest, v1.CreateSnapshotResponse](
+			httpClient,
+			baseURL+NodeServiceCreateSnapshotProcedure,
+			connect.WithSchema(nodeServiceMethods.ByName("CreateSnapshot")),
+			connect.WithClientOptions(opts...),
+		),
+		listSnapshots: connect.NewClient[v1.ListSnapshotsRequest, v1.ListSnapshotsResponse](
+			httpClient,
+			baseURL+NodeServiceListSnapshotsProcedure,
+			connect.WithSchema(nodeServiceMethods.ByName("ListSnapshots")),
+			connect.WithClientOptions(opts...),
+		),
+		diffSnapshot: connect.NewClient[v1.DiffSnapshotRequest, v1.DiffSnapshotResponse](
+			httpClient,
+			baseURL+NodeServiceDiffSnapshotProcedure,
+			connect.WithSchema(nodeServiceMethods.ByName("DiffSnapshot")),
+			connect.WithClientOptions(opts...),
+		),
+		restoreSnapshot: connect.NewClient[v1.RestoreSnapshotRequest, v1.RestoreSnapshotResponse](
+			httpClient,
+			baseURL+NodeServiceRestoreSnapshotProcedure,
+			connect.WithSchema(nodeServiceMethods.ByName("RestoreSnapshot")),
+			connect.WithClientOptions(opts...),
+		),
+		undo: connect.NewClient[v1.UndoRequest, v1.UndoResponse](
+			httpClient,
+			baseURL+NodeServiceUndoProcedure,
+			connect.WithSchema(nodeServiceMethods.ByName("Undo")),
+			connect.WithClientOptions(opts...),
+		),
+		redo: connect.NewClient[v1.RedoRequest, v1.RedoResponse](
+			httpClient,
+			baseURL+NodeServiceRedoProcedure,
+			connect.WithSchema(nodeServiceMethods.ByName("Redo")),
+			connect.WithClientOptions(opts...),
+		),
+		listTrash: connect.NewClient[v1.ListTrashRequest, v1.ListTrashResponse](
+			httpClient,
+			baseURL+NodeServiceListTrashProcedure,
+			connect.WithSchema(nodeServiceMethods.ByName("ListTrash")),
+			connect.WithClientOptions(opts...),
+		),
+		restoreFromTrash: connect.NewClient[v1.RestoreFromTrashRequest, v1.RestoreFromTrashResponse](
+			httpClient,
+			baseURL+NodeServiceRestoreFromTrashProcedure,
+			connect.WithSchema(nodeServiceMethods.ByName("RestoreFromTrash")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteSubtree: connect.NewClient[v1.DeleteSubtreeRequest, v1.DeleteSubtreeResponse](
+			httpClient,
+			baseURL+NodeServiceDeleteSubtreeProcedure,
+			connect.WithSchema(nodeServiceMethods.ByName("DeleteSubtree")),
+			connect.WithClientOptions(opts...),
+		),
+		copySubtree: connect.NewClient[v1.CopySubtreeRequest, v1.CopySubtreeResponse](
+			httpClient,
+			baseURL+NodeServiceCopySubtreeProcedure,
+			connect.WithSchema(nodeServiceMethods.ByName("CopySubtree")),
+			connect.WithClientOptions(opts...),
+		),
+		moveSubtree: connect.NewClient[v1.MoveSubtreeRequest, v1.MoveSubtreeResponse](
+			httpClient,
+			baseURL+NodeServiceMoveSubtreeProcedure,
+			connect.WithSchema(nodeServiceMethods.ByName("MoveSubtree")),
+			connect.WithClientOptions(opts...),
+		),
+		getAncestors: connect.NewClient[v1.GetAncestorsRequest, v1.GetAncestorsResponse](
+			httpClient,
+			baseURL+NodeServiceGetAncestorsProcedure,
+			connect.WithSchema(nodeServiceMethods.ByName("GetAncestors")),
+			connect.WithClientOptions(opts...),
+		),
+		getDescendants: connect.NewClient[v1.GetDescendantsRequest, v1.GetDescendantsResponse](
+			httpClient,
+			baseURL+NodeServiceGetDescendantsProcedure,
+			connect.WithSchema(nodeServiceMethods.ByName("GetDescendants")),
+			connect.WithClientOptions(opts...),
+		),
+		findRelationship: connect.NewClient[v1.FindRelationshipRequest, v1.FindRelationshipResponse](
+			httpClient,
+			baseURL+NodeServiceFindRelationshipProcedure,
+			connect.WithSchema(nodeServiceMethods.ByName("FindRelationship")),
+			connect.WithClientOptions(opts...),
+		),
+		searchNodes: connect.NewClient[v1.SearchNodesRequest, v1.SearchNodesResponse](
+			httpClient,
+			baseURL+NodeServiceSearchNodesProcedure,
+			connect.WithSchema(nodeServiceMethods.ByName("SearchNodes")),
+			connect.WithClientOptions(opts...),
+		),
+		watchTree: connect.NewClient[v1.WatchTreeRequest, v1.WatchTreeResponse](
+			httpClient,
+			baseURL+NodeServiceWatchTreeProcedure,
+			connect.WithSchema(nodeServiceMethods.ByName("WatchTree")),
+			connect.WithClientOptions(opts...),
+		),
 		getNodesByShareToken: connect.NewClient[v1.GetNodesByShareTokenRequest, v1.GetNodesByShareTokenResponse](
 			httpClient,
 			baseURL+NodeServiceGetNodesByShareTokenProcedure,
@@ -148,6 +337,25 @@ type nodeServiceClient struct {
 	getTreeNodes         *connect.Client[v1.GetTreeNodesRequest, v1.GetTreeNodesResponse]
 	addParent            *connect.Client[v1.AddParentRequest, v1.AddParentResponse]
 	removeParent         *connect.Client[v1.RemoveParentRequest, v1.RemoveParentResponse]
+	updateLayout         *connect.Client[v1.UpdateLayoutRequest, v1.UpdateLayoutResponse]
+	importNodes          *connect.Client[v1.ImportNodesRequest, v1.ImportNodesResponse]
+	exportTree           *connect.Client[v1.ExportTreeRequest, v1.ExportTreeResponse]
+	createSnapshot       *connect.Client[v1.CreateSnapshotRequest, v1.CreateSnapshotResponse]
+	listSnapshots        *connect.Client[v1.ListSnapshotsRequest, v1.ListSnapshotsResponse]
+	diffSnapshot         *connect.Client[v1.DiffSnapshotRequest, v1.DiffSnapshotResponse]
+	restoreSnapshot      *connect.Client[v1.RestoreSnapshotRequest, v1.RestoreSnapshotResponse]
+	undo                 *connect.Client[v1.UndoRequest, v1.UndoResponse]
+	redo                 *connect.Client[v1.RedoRequest, v1.RedoResponse]
+	listTrash            *connect.Client[v1.ListTrashRequest, v1.ListTrashResponse]
+	restoreFromTrash     *connect.Client[v1.RestoreFromTrashRequest, v1.RestoreFromTrashResponse]
+	deleteSubtree        *connect.Client[v1.DeleteSubtreeRequest, v1.DeleteSubtreeResponse]
+	copySubtree          *connect.Client[v1.CopySubtreeRequest, v1.CopySubtreeResponse]
+	moveSubtree          *connect.Client[v1.MoveSubtreeRequest, v1.MoveSubtreeResponse]
+	getAncestors         *connect.Client[v1.GetAncestorsRequest, v1.GetAncestorsResponse]
+	getDescendants       *connect.Client[v1.GetDescendantsRequest, v1.GetDescendantsResponse]
+	findRelationship     *connect.Client[v1.FindRelationshipRequest, v1.FindRelationshipResponse]
+	searchNodes          *connect.Client[v1.SearchNodesRequest, v1.SearchNodesResponse]
+	watchTree            *connect.Client[v1.WatchTreeRequest, v1.WatchTreeResponse]
 	getNodesByShareToken *connect.Client[v1.GetNodesByShareTokenRequest, v1.GetNodesByShareTokenResponse]
 }
 
@@ -191,6 +399,101 @@ func (c *nodeServiceClient) RemoveParent(ctx context.Context, req *connect.Reque
 	return c.removeParent.CallUnary(ctx, req)
 }
 
+// UpdateLayout calls node.v1.NodeService.UpdateLayout.
+func (c *nodeServiceClient) UpdateLayout(ctx context.Context, req *connect.Request[v1.UpdateLayoutRequest]) (*connect.Response[v1.UpdateLayoutResponse], error) {
+	return c.updateLayout.CallUnary(ctx, req)
+}
+
+// ImportNodes calls node.v1.NodeService.ImportNodes.
+func (c *nodeServiceClient) ImportNodes(ctx context.Context, req *connect.Request[v1.ImportNodesRequest]) (*connect.Response[v1.ImportNodesResponse], error) {
+	return c.importNodes.CallUnary(ctx, req)
+}
+
+// ExportTree calls node.v1.NodeService.ExportTree.
+func (c *nodeServiceClient) ExportTree(ctx context.Context, req *connect.Request[v1.ExportTreeRequest]) (*connect.Response[v1.ExportTreeResponse], error) {
+	return c.exportTree.CallUnary(ctx, req)
+}
+
+// CreateSnapshot calls node.v1.NodeService.CreateSnapshot.
+func (c *nodeServiceClient) CreateSnapshot(ctx context.Context, req *connect.Request[v1.CreateSnapshotRequest]) (*connect.Response[v1.CreateSnapshotResponse], error) {
+	return c.createSnapshot.CallUnary(ctx, req)
+}
+
+// ListSnapshots calls node.v1.NodeService.ListSnapshots.
+func (c *nodeServiceClient) ListSnapshots(ctx context.Context, req *connect.Request[v1.ListSnapshotsRequest]) (*connect.Response[v1.ListSnapshotsResponse], error) {
+	return c.listSnapshots.CallUnary(ctx, req)
+}
+
+// DiffSnapshot calls node.v1.NodeService.DiffSnapshot.
+func (c *nodeServiceClient) DiffSnapshot(ctx context.Context, req *connect.Request[v1.DiffSnapshotRequest]) (*connect.Response[v1.DiffSnapshotResponse], error) {
+	return c.diffSnapshot.CallUnary(ctx, req)
+}
+
+// RestoreSnapshot calls node.v1.NodeService.RestoreSnapshot.
+func (c *nodeServiceClient) RestoreSnapshot(ctx context.Context, req *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.RestoreSnapshotResponse], error) {
+	return c.restoreSnapshot.CallUnary(ctx, req)
+}
+
+// Undo calls node.v1.NodeService.Undo.
+func (c *nodeServiceClient) Undo(ctx context.Context, req *connect.Request[v1.UndoRequest]) (*connect.Response[v1.UndoResponse], error) {
+	return c.undo.CallUnary(ctx, req)
+}
+
+// Redo calls node.v1.NodeService.Redo.
+func (c *nodeServiceClient) Redo(ctx context.Context, req *connect.Request[v1.RedoRequest]) (*connect.Response[v1.RedoResponse], error) {
+	return c.redo.CallUnary(ctx, req)
+}
+
+// ListTrash calls node.v1.NodeService.ListTrash.
+func (c *nodeServiceClient) ListTrash(ctx context.Context, req *connect.Request[v1.ListTrashRequest]) (*connect.Response[v1.ListTrashResponse], error) {
+	return c.listTrash.CallUnary(ctx, req)
+}
+
+// RestoreFromTrash calls node.v1.NodeService.RestoreFromTrash.
+func (c *nodeServiceClient) RestoreFromTrash(ctx context.Context, req *connect.Request[v1.RestoreFromTrashRequest]) (*connect.Response[v1.RestoreFromTrashResponse], error) {
+	return c.restoreFromTrash.CallUnary(ctx, req)
+}
+
+// DeleteSubtree calls node.v1.NodeService.DeleteSubtree.
+func (c *nodeServiceClient) DeleteSubtree(ctx context.Context, req *connect.Request[v1.DeleteSubtreeRequest]) (*connect.Response[v1.DeleteSubtreeResponse], error) {
+	return c.deleteSubtree.CallUnary(ctx, req)
+}
+
+// CopySubtree calls node.v1.NodeService.CopySubtree.
+func (c *nodeServiceClient) CopySubtree(ctx context.Context, req *connect.Request[v1.CopySubtreeRequest]) (*connect.Response[v1.CopySubtreeResponse], error) {
+	return c.copySubtree.CallUnary(ctx, req)
+}
+
+// MoveSubtree calls node.v1.NodeService.MoveSubtree.
+func (c *nodeServiceClient) MoveSubtree(ctx context.Context, req *connect.Request[v1.MoveSubtreeRequest]) (*connect.Response[v1.MoveSubtreeResponse], error) {
+	return c.moveSubtree.CallUnary(ctx, req)
+}
+
+// GetAncestors calls node.v1.NodeService.GetAncestors.
+func (c *nodeServiceClient) GetAncestors(ctx context.Context, req *connect.Request[v1.GetAncestorsRequest]) (*connect.Response[v1.GetAncestorsResponse], error) {
+	return c.getAncestors.CallUnary(ctx, req)
+}
+
+// GetDescendants calls node.v1.NodeService.GetDescendants.
+func (c *nodeServiceClient) GetDescendants(ctx context.Context, req *connect.Request[v1.GetDescendantsRequest]) (*connect.Response[v1.GetDescendantsResponse], error) {
+	return c.getDescendants.CallUnary(ctx, req)
+}
+
+// FindRelationship calls node.v1.NodeService.FindRelationship.
+func (c *nodeServiceClient) FindRelationship(ctx context.Context, req *connect.Request[v1.FindRelationshipRequest]) (*connect.Response[v1.FindRelationshipResponse], error) {
+	return c.findRelationship.CallUnary(ctx, req)
+}
+
+// SearchNodes calls node.v1.NodeService.SearchNodes.
+func (c *nodeServiceClient) SearchNodes(ctx context.Context, req *connect.Request[v1.SearchNodesRequest]) (*connect.Response[v1.SearchNodesResponse], error) {
+	return c.searchNodes.CallUnary(ctx, req)
+}
+
+// WatchTree calls node.v1.NodeService.WatchTree.
+func (c *nodeServiceClient) WatchTree(ctx context.Context, req *connect.Request[v1.WatchTreeRequest]) (*connect.ServerStreamForClient[v1.WatchTreeResponse], error) {
+	return c.watchTree.CallServerStream(ctx, req)
+}
+
 // GetNodesByShareToken calls node.v1.NodeService.GetNodesByShareToken.
 func (c *nodeServiceClient) GetNodesByShareToken(ctx context.Context, req *connect.Request[v1.GetNodesByShareTokenRequest]) (*connect.Response[v1.GetNodesByShareTokenResponse], error) {
 	return c.getNodesByShareToken.CallUnary(ctx, req)
@@ -206,6 +509,33 @@ type NodeServiceHandler interface {
 	GetTreeNodes(context.Context, *connect.Request[v1.GetTreeNodesRequest]) (*connect.Response[v1.GetTreeNodesResponse], error)
 	AddParent(context.Context, *connect.Request[v1.AddParentRequest]) (*connect.Response[v1.AddParentResponse], error)
 	RemoveParent(context.Context, *connect.Request[v1.RemoveParentRequest]) (*connect.Response[v1.RemoveParentResponse], error)
+	UpdateLayout(context.Context, *connect.Request[v1.UpdateLayoutRequest]) (*connect.Response[v1.UpdateLayoutResponse], error)
+	// ★ Bulk import
+	ImportNodes(context.Context, *connect.Request[v1.ImportNodesRequest]) (*connect.Response[v1.ImportNodesResponse], error)
+	// ★ Export (ดาวน์โหลดผ่าน HTTP ได้ที่ GET /export/{treeId}?format=...)
+	ExportTree(context.Context, *connect.Request[v1.ExportTreeRequest]) (*connect.Response[v1.ExportTreeResponse], error)
+	// ★ Snapshot / restore / undo
+	CreateSnapshot(context.Context, *connect.Request[v1.CreateSnapshotRequest]) (*connect.Response[v1.CreateSnapshotResponse], error)
+	ListSnapshots(context.Context, *connect.Request[v1.ListSnapshotsRequest]) (*connect.Response[v1.ListSnapshotsResponse], error)
+	DiffSnapshot(context.Context, *connect.Request[v1.DiffSnapshotRequest]) (*connect.Response[v1.DiffSnapshotResponse], error)
+	RestoreSnapshot(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.RestoreSnapshotResponse], error)
+	Undo(context.Context, *connect.Request[v1.UndoRequest]) (*connect.Response[v1.UndoResponse], error)
+	Redo(context.Context, *connect.Request[v1.RedoRequest]) (*connect.Response[v1.RedoResponse], error)
+	// ★ Trash
+	ListTrash(context.Context, *connect.Request[v1.ListTrashRequest]) (*connect.Response[v1.ListTrashResponse], error)
+	RestoreFromTrash(context.Context, *connect.Request[v1.RestoreFromTrashRequest]) (*connect.Response[v1.RestoreFromTrashResponse], error)
+	// ★ Subtree
+	DeleteSubtree(context.Context, *connect.Request[v1.DeleteSubtreeRequest]) (*connect.Response[v1.DeleteSubtreeResponse], error)
+	CopySubtree(context.Context, *connect.Request[v1.CopySubtreeRequest]) (*connect.Response[v1.CopySubtreeResponse], error)
+	MoveSubtree(context.Context, *connect.Request[v1.MoveSubtreeRequest]) (*connect.Response[v1.MoveSubtreeResponse], error)
+	// ★ Lineage
+	GetAncestors(context.Context, *connect.Request[v1.GetAncestorsRequest]) (*connect.Response[v1.GetAncestorsResponse], error)
+	GetDescendants(context.Context, *connect.Request[v1.GetDescendantsRequest]) (*connect.Response[v1.GetDescendantsResponse], error)
+	FindRelationship(context.Context, *connect.Request[v1.FindRelationshipRequest]) (*connect.Response[v1.FindRelationshipResponse], error)
+	// ★ Search
+	SearchNodes(context.Context, *connect.Request[v1.SearchNodesRequest]) (*connect.Response[v1.SearchNodesResponse], error)
+	// ★ Realtime (server-streaming)
+	WatchTree(context.Context, *connect.Request[v1.WatchTreeRequest], *connect.ServerStream[v1.WatchTreeResponse]) error
 	// ★ Public (ไม่ต้อง login)
 	GetNodesByShareToken(context.Context, *connect.Request[v1.GetNodesByShareTokenRequest]) (*connect.Response[v1.GetNodesByShareTokenResponse], error)
 }
@@ -265,6 +595,120 @@ func NewNodeServiceHandler(svc NodeServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(nodeServiceMethods.ByName("RemoveParent")),
 		connect.WithHandlerOptions(opts...),
 	)
+	nodeServiceUpdateLayoutHandler := connect.NewUnaryHandler(
+		NodeServiceUpdateLayoutProcedure,
+		svc.UpdateLayout,
+		connect.WithSchema(nodeServiceMethods.ByName("UpdateLayout")),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceImportNodesHandler := connect.NewUnaryHandler(
+		NodeServiceImportNodesProcedure,
+		svc.ImportNodes,
+		connect.WithSchema(nodeServiceMethods.ByName("ImportNodes")),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceExportTreeHandler := connect.NewUnaryHandler(
+		NodeServiceExportTreeProcedure,
+		svc.ExportTree,
+		connect.WithSchema(nodeServiceMethods.ByName("ExportTree")),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceCreateSnapshotHandler := connect.NewUnaryHandler(
+		NodeServiceCreateSnapshotProcedure,
+		svc.CreateSnapshot,
+		connect.WithSchema(nodeServiceMethods.ByName("CreateSnapshot")),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceListSnapshotsHandler := connect.NewUnaryHandler(
+		NodeServiceListSnapshotsProcedure,
+		svc.ListSnapshots,
+		connect.WithSchema(nodeServiceMethods.ByName("ListSnapshots")),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceDiffSnapshotHandler := connect.NewUnaryHandler(
+		NodeServiceDiffSnapshotProcedure,
+		svc.DiffSnapshot,
+		connect.WithSchema(nodeServiceMethods.ByName("DiffSnapshot")),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceRestoreSnapshotHandler := connect.NewUnaryHandler(
+		NodeServiceRestoreSnapshotProcedure,
+		svc.RestoreSnapshot,
+		connect.WithSchema(nodeServiceMethods.ByName("RestoreSnapshot")),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceUndoHandler := connect.NewUnaryHandler(
+		NodeServiceUndoProcedure,
+		svc.Undo,
+		connect.WithSchema(nodeServiceMethods.ByName("Undo")),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceRedoHandler := connect.NewUnaryHandler(
+		NodeServiceRedoProcedure,
+		svc.Redo,
+		connect.WithSchema(nodeServiceMethods.ByName("Redo")),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceListTrashHandler := connect.NewUnaryHandler(
+		NodeServiceListTrashProcedure,
+		svc.ListTrash,
+		connect.WithSchema(nodeServiceMethods.ByName("ListTrash")),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceRestoreFromTrashHandler := connect.NewUnaryHandler(
+		NodeServiceRestoreFromTrashProcedure,
+		svc.RestoreFromTrash,
+		connect.WithSchema(nodeServiceMethods.ByName("RestoreFromTrash")),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceDeleteSubtreeHandler := connect.NewUnaryHandler(
+		NodeServiceDeleteSubtreeProcedure,
+		svc.DeleteSubtree,
+		connect.WithSchema(nodeServiceMethods.ByName("DeleteSubtree")),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceCopySubtreeHandler := connect.NewUnaryHandler(
+		NodeServiceCopySubtreeProcedure,
+		svc.CopySubtree,
+		connect.WithSchema(nodeServiceMethods.ByName("CopySubtree")),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceMoveSubtreeHandler := connect.NewUnaryHandler(
+		NodeServiceMoveSubtreeProcedure,
+		svc.MoveSubtree,
+		connect.WithSchema(nodeServiceMethods.ByName("MoveSubtree")),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceGetAncestorsHandler := connect.NewUnaryHandler(
+		NodeServiceGetAncestorsProcedure,
+		svc.GetAncestors,
+		connect.WithSchema(nodeServiceMethods.ByName("GetAncestors")),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceGetDescendantsHandler := connect.NewUnaryHandler(
+		NodeServiceGetDescendantsProcedure,
+		svc.GetDescendants,
+		connect.WithSchema(nodeServiceMethods.ByName("GetDescendants")),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceFindRelationshipHandler := connect.NewUnaryHandler(
+		NodeServiceFindRelationshipProcedure,
+		svc.FindRelationship,
+		connect.WithSchema(nodeServiceMethods.ByName("FindRelationship")),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceSearchNodesHandler := connect.NewUnaryHandler(
+		NodeServiceSearchNodesProcedure,
+		svc.SearchNodes,
+		connect.WithSchema(nodeServiceMethods.ByName("SearchNodes")),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceWatchTreeHandler := connect.NewServerStreamHandler(
+		NodeServiceWatchTreeProcedure,
+		svc.WatchTree,
+		connect.WithSchema(nodeServiceMethods.ByName("WatchTree")),
+		connect.WithHandlerOptions(opts...),
+	)
 	nodeServiceGetNodesByShareTokenHandler := connect.NewUnaryHandler(
 		NodeServiceGetNodesByShareTokenProcedure,
 		svc.GetNodesByShareToken,
@@ -289,6 +733,44 @@ func NewNodeServiceHandler(svc NodeServiceHandler, opts ...connect.HandlerOption
 			nodeServiceAddParentHandler.ServeHTTP(w, r)
 		case NodeServiceRemoveParentProcedure:
 			nodeServiceRemoveParentHandler.ServeHTTP(w, r)
+		case NodeServiceUpdateLayoutProcedure:
+			nodeServiceUpdateLayoutHandler.ServeHTTP(w, r)
+		case NodeServiceImportNodesProcedure:
+			nodeServiceImportNodesHandler.ServeHTTP(w, r)
+		case NodeServiceExportTreeProcedure:
+			nodeServiceExportTreeHandler.ServeHTTP(w, r)
+		case NodeServiceCreateSnapshotProcedure:
+			nodeServiceCreateSnapshotHandler.ServeHTTP(w, r)
+		case NodeServiceListSnapshotsProcedure:
+			nodeServiceListSnapshotsHandler.ServeHTTP(w, r)
+		case NodeServiceDiffSnapshotProcedure:
+			nodeServiceDiffSnapshotHandler.ServeHTTP(w, r)
+		case NodeServiceRestoreSnapshotProcedure:
+			nodeServiceRestoreSnapshotHandler.ServeHTTP(w, r)
+		case NodeServiceUndoProcedure:
+			nodeServiceUndoHandler.ServeHTTP(w, r)
+		case NodeServiceRedoProcedure:
+			nodeServiceRedoHandler.ServeHTTP(w, r)
+		case NodeServiceListTrashProcedure:
+			nodeServiceListTrashHandler.ServeHTTP(w, r)
+		case NodeServiceRestoreFromTrashProcedure:
+			nodeServiceRestoreFromTrashHandler.ServeHTTP(w, r)
+		case NodeServiceDeleteSubtreeProcedure:
+			nodeServiceDeleteSubtreeHandler.ServeHTTP(w, r)
+		case NodeServiceCopySubtreeProcedure:
+			nodeServiceCopySubtreeHandler.ServeHTTP(w, r)
+		case NodeServiceMoveSubtreeProcedure:
+			nodeServiceMoveSubtreeHandler.ServeHTTP(w, r)
+		case NodeServiceGetAncestorsProcedure:
+			nodeServiceGetAncestorsHandler.ServeHTTP(w, r)
+		case NodeServiceGetDescendantsProcedure:
+			nodeServiceGetDescendantsHandler.ServeHTTP(w, r)
+		case NodeServiceFindRelationshipProcedure:
+			nodeServiceFindRelationshipHandler.ServeHTTP(w, r)
+		case NodeServiceSearchNodesProcedure:
+			nodeServiceSearchNodesHandler.ServeHTTP(w, r)
+		case NodeServiceWatchTreeProcedure:
+			nodeServiceWatchTreeHandler.ServeHTTP(w, r)
 		case NodeServiceGetNodesByShareTokenProcedure:
 			nodeServiceGetNodesByShareTokenHandler.ServeHTTP(w, r)
 		default:
@@ -332,6 +814,82 @@ func (UnimplementedNodeServiceHandler) RemoveParent(context.Context, *connect.Re
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.RemoveParent is not implemented"))
 }
 
+func (UnimplementedNodeServiceHandler) UpdateLayout(context.Context, *connect.Request[v1.UpdateLayoutRequest]) (*connect.Response[v1.UpdateLayoutResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.UpdateLayout is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) ImportNodes(context.Context, *connect.Request[v1.ImportNodesRequest]) (*connect.Response[v1.ImportNodesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.ImportNodes is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) ExportTree(context.Context, *connect.Request[v1.ExportTreeRequest]) (*connect.Response[v1.ExportTreeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.ExportTree is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) CreateSnapshot(context.Context, *connect.Request[v1.CreateSnapshotRequest]) (*connect.Response[v1.CreateSnapshotResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.CreateSnapshot is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) ListSnapshots(context.Context, *connect.Request[v1.ListSnapshotsRequest]) (*connect.Response[v1.ListSnapshotsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.ListSnapshots is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) DiffSnapshot(context.Context, *connect.Request[v1.DiffSnapshotRequest]) (*connect.Response[v1.DiffSnapshotResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.DiffSnapshot is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) RestoreSnapshot(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.RestoreSnapshotResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.RestoreSnapshot is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) Undo(context.Context, *connect.Request[v1.UndoRequest]) (*connect.Response[v1.UndoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.Undo is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) Redo(context.Context, *connect.Request[v1.RedoRequest]) (*connect.Response[v1.RedoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.Redo is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) ListTrash(context.Context, *connect.Request[v1.ListTrashRequest]) (*connect.Response[v1.ListTrashResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.ListTrash is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) RestoreFromTrash(context.Context, *connect.Request[v1.RestoreFromTrashRequest]) (*connect.Response[v1.RestoreFromTrashResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.RestoreFromTrash is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) DeleteSubtree(context.Context, *connect.Request[v1.DeleteSubtreeRequest]) (*connect.Response[v1.DeleteSubtreeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.DeleteSubtree is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) CopySubtree(context.Context, *connect.Request[v1.CopySubtreeRequest]) (*connect.Response[v1.CopySubtreeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.CopySubtree is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) MoveSubtree(context.Context, *connect.Request[v1.MoveSubtreeRequest]) (*connect.Response[v1.MoveSubtreeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.MoveSubtree is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) GetAncestors(context.Context, *connect.Request[v1.GetAncestorsRequest]) (*connect.Response[v1.GetAncestorsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.GetAncestors is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) GetDescendants(context.Context, *connect.Request[v1.GetDescendantsRequest]) (*connect.Response[v1.GetDescendantsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.GetDescendants is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) FindRelationship(context.Context, *connect.Request[v1.FindRelationshipRequest]) (*connect.Response[v1.FindRelationshipResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.FindRelationship is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) SearchNodes(context.Context, *connect.Request[v1.SearchNodesRequest]) (*connect.Response[v1.SearchNodesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.SearchNodes is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) WatchTree(context.Context, *connect.Request[v1.WatchTreeRequest], *connect.ServerStream[v1.WatchTreeResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.WatchTree is not implemented"))
+}
+
 func (UnimplementedNodeServiceHandler) GetNodesByShareToken(context.Context, *connect.Request[v1.GetNodesByShareTokenRequest]) (*connect.Response[v1.GetNodesByShareTokenResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("node.v1.NodeService.GetNodesByShareToken is not implemented"))
 }
@@ -73,19 +73,242 @@ func (ShareRole) EnumDescriptor() ([]byte, []int) {
 	return file_tree_v1_tree_proto_rawDescGZIP(), []int{0}
 }
 
+// สิ่งที่ลิงก์แชร์ให้สิทธิ์
+type ShareLinkRole int32
+
+const (
+	ShareLinkRole_SHARE_LINK_ROLE_UNSPECIFIED ShareLinkRole = 0
+	ShareLinkRole_SHARE_LINK_ROLE_VIEW        ShareLinkRole = 1 // ดูอย่างเดียว ไม่ต้อง login
+	ShareLinkRole_SHARE_LINK_ROLE_JOIN_VIEWER ShareLinkRole = 2 // login แล้วเข้าร่วมเป็น viewer
+	ShareLinkRole_SHARE_LINK_ROLE_JOIN_EDITOR ShareLinkRole = 3 // login แล้วเข้าร่วมเป็น editor
+)
+
+// Enum value maps for ShareLinkRole.
+var (
+	ShareLinkRole_name = map[int32]string{
+		0: "SHARE_LINK_ROLE_UNSPECIFIED",
+		1: "SHARE_LINK_ROLE_VIEW",
+		2: "SHARE_LINK_ROLE_JOIN_VIEWER",
+		3: "SHARE_LINK_ROLE_JOIN_EDITOR",
+	}
+	ShareLinkRole_value = map[string]int32{
+		"SHARE_LINK_ROLE_UNSPECIFIED": 0,
+		"SHARE_LINK_ROLE_VIEW":        1,
+		"SHARE_LINK_ROLE_JOIN_VIEWER": 2,
+		"SHARE_LINK_ROLE_JOIN_EDITOR": 3,
+	}
+)
+
+func (x ShareLinkRole) Enum() *ShareLinkRole {
+	p := new(ShareLinkRole)
+	*p = x
+	return p
+}
+
+func (x ShareLinkRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ShareLinkRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_tree_v1_tree_proto_enumTypes[1].Descriptor()
+}
+
+func (ShareLinkRole) Type() protoreflect.EnumType {
+	return &file_tree_v1_tree_proto_enumTypes[1]
+}
+
+func (x ShareLinkRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ShareLinkRole.Descriptor instead.
+func (ShareLinkRole) EnumDescriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{1}
+}
+
+// ใครเห็นช่องทางติดต่อ (phone, email, ...) ของ node ได้
+type ContactVisibility int32
+
+const (
+	ContactVisibility_CONTACT_VISIBILITY_UNSPECIFIED ContactVisibility = 0 // ไม่ได้ตั้ง = ใช้ค่าชั้นถัดไป (node → tree → members)
+	ContactVisibility_CONTACT_VISIBILITY_PUBLIC      ContactVisibility = 1 // ทุกคนที่ดู tree ได้ (รวม share link / tree public)
+	ContactVisibility_CONTACT_VISIBILITY_MEMBERS     ContactVisibility = 2 // เฉพาะคนที่ถูกแชร์ tree
+	ContactVisibility_CONTACT_VISIBILITY_EDITORS     ContactVisibility = 3 // เฉพาะ editor / owner
+)
+
+// Enum value maps for ContactVisibility.
+var (
+	ContactVisibility_name = map[int32]string{
+		0: "CONTACT_VISIBILITY_UNSPECIFIED",
+		1: "CONTACT_VISIBILITY_PUBLIC",
+		2: "CONTACT_VISIBILITY_MEMBERS",
+		3: "CONTACT_VISIBILITY_EDITORS",
+	}
+	ContactVisibility_value = map[string]int32{
+		"CONTACT_VISIBILITY_UNSPECIFIED": 0,
+		"CONTACT_VISIBILITY_PUBLIC":      1,
+		"CONTACT_VISIBILITY_MEMBERS":     2,
+		"CONTACT_VISIBILITY_EDITORS":     3,
+	}
+)
+
+func (x ContactVisibility) Enum() *ContactVisibility {
+	p := new(ContactVisibility)
+	*p = x
+	return p
+}
+
+func (x ContactVisibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ContactVisibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_tree_v1_tree_proto_enumTypes[2].Descriptor()
+}
+
+func (ContactVisibility) Type() protoreflect.EnumType {
+	return &file_tree_v1_tree_proto_enumTypes[2]
+}
+
+func (x ContactVisibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ContactVisibility.Descriptor instead.
+func (ContactVisibility) EnumDescriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{2}
+}
+
+// สถานะคำขอโอนความเป็นเจ้าของ
+type OwnershipTransferStatus int32
+
+const (
+	OwnershipTransferStatus_OWNERSHIP_TRANSFER_STATUS_UNSPECIFIED OwnershipTransferStatus = 0
+	OwnershipTransferStatus_OWNERSHIP_TRANSFER_STATUS_PENDING     OwnershipTransferStatus = 1 // รอผู้รับตอบ
+	OwnershipTransferStatus_OWNERSHIP_TRANSFER_STATUS_ACCEPTED    OwnershipTransferStatus = 2
+	OwnershipTransferStatus_OWNERSHIP_TRANSFER_STATUS_DECLINED    OwnershipTransferStatus = 3
+	OwnershipTransferStatus_OWNERSHIP_TRANSFER_STATUS_CANCELLED   OwnershipTransferStatus = 4 // เจ้าของยกเลิกเอง
+)
+
+// Enum value maps for OwnershipTransferStatus.
+var (
+	OwnershipTransferStatus_name = map[int32]string{
+		0: "OWNERSHIP_TRANSFER_STATUS_UNSPECIFIED",
+		1: "OWNERSHIP_TRANSFER_STATUS_PENDING",
+		2: "OWNERSHIP_TRANSFER_STATUS_ACCEPTED",
+		3: "OWNERSHIP_TRANSFER_STATUS_DECLINED",
+		4: "OWNERSHIP_TRANSFER_STATUS_CANCELLED",
+	}
+	OwnershipTransferStatus_value = map[string]int32{
+		"OWNERSHIP_TRANSFER_STATUS_UNSPECIFIED": 0,
+		"OWNERSHIP_TRANSFER_STATUS_PENDING":     1,
+		"OWNERSHIP_TRANSFER_STATUS_ACCEPTED":    2,
+		"OWNERSHIP_TRANSFER_STATUS_DECLINED":    3,
+		"OWNERSHIP_TRANSFER_STATUS_CANCELLED":   4,
+	}
+)
+
+func (x OwnershipTransferStatus) Enum() *OwnershipTransferStatus {
+	p := new(OwnershipTransferStatus)
+	*p = x
+	return p
+}
+
+func (x OwnershipTransferStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OwnershipTransferStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_tree_v1_tree_proto_enumTypes[3].Descriptor()
+}
+
+func (OwnershipTransferStatus) Type() protoreflect.EnumType {
+	return &file_tree_v1_tree_proto_enumTypes[3]
+}
+
+func (x OwnershipTransferStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OwnershipTransferStatus.Descriptor instead.
+func (OwnershipTransferStatus) EnumDescriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{3}
+}
+
+// ลำดับของรายการ tree (UNSPECIFIED = UPDATED_AT)
+type TreeSortField int32
+
+const (
+	TreeSortField_TREE_SORT_FIELD_UNSPECIFIED TreeSortField = 0
+	TreeSortField_TREE_SORT_FIELD_UPDATED_AT  TreeSortField = 1 // แก้ล่าสุดก่อน
+	TreeSortField_TREE_SORT_FIELD_CREATED_AT  TreeSortField = 2 // สร้างล่าสุดก่อน
+	TreeSortField_TREE_SORT_FIELD_NAME        TreeSortField = 3 // ก → ฮ / A → Z
+	TreeSortField_TREE_SORT_FIELD_NODE_COUNT  TreeSortField = 4 // คนเยอะสุดก่อน
+)
+
+// Enum value maps for TreeSortField.
+var (
+	TreeSortField_name = map[int32]string{
+		0: "TREE_SORT_FIELD_UNSPECIFIED",
+		1: "TREE_SORT_FIELD_UPDATED_AT",
+		2: "TREE_SORT_FIELD_CREATED_AT",
+		3: "TREE_SORT_FIELD_NAME",
+		4: "TREE_SORT_FIELD_NODE_COUNT",
+	}
+	TreeSortField_value = map[string]int32{
+		"TREE_SORT_FIELD_UNSPECIFIED": 0,
+		"TREE_SORT_FIELD_UPDATED_AT":  1,
+		"TREE_SORT_FIELD_CREATED_AT":  2,
+		"TREE_SORT_FIELD_NAME":        3,
+		"TREE_SORT_FIELD_NODE_COUNT":  4,
+	}
+)
+
+func (x TreeSortField) Enum() *TreeSortField {
+	p := new(TreeSortField)
+	*p = x
+	return p
+}
+
+func (x TreeSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TreeSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_tree_v1_tree_proto_enumTypes[4].Descriptor()
+}
+
+func (TreeSortField) Type() protoreflect.EnumType {
+	return &file_tree_v1_tree_proto_enumTypes[4]
+}
+
+func (x TreeSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TreeSortField.Descriptor instead.
+func (TreeSortField) EnumDescriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{4}
+}
+
 type Tree struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Faculty       string                 `protobuf:"bytes,4,opt,name=faculty,proto3" json:"faculty,omitempty"`
-	Department    string                 `protobuf:"bytes,5,opt,name=department,proto3" json:"department,omitempty"`
-	CreatedBy     string                 `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	MyRole        ShareRole              `protobuf:"varint,9,opt,name=my_role,json=myRole,proto3,enum=tree.v1.ShareRole" json:"my_role,omitempty"` // role ของ user ปัจจุบันกับ tree นี้
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description       string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Faculty           string                 `protobuf:"bytes,4,opt,name=faculty,proto3" json:"faculty,omitempty"`
+	Department        string                 `protobuf:"bytes,5,opt,name=department,proto3" json:"department,omitempty"`
+	CreatedBy         string                 `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt         string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	MyRole            ShareRole              `protobuf:"varint,9,opt,name=my_role,json=myRole,proto3,enum=tree.v1.ShareRole" json:"my_role,omitempty"`            // role ของ user ปัจจุบันกับ tree นี้
+	StructureRevision int64                  `protobuf:"varint,10,opt,name=structure_revision,json=structureRevision,proto3" json:"structure_revision,omitempty"` // เพิ่มขึ้นทุกครั้งที่ structure ถูกแก้ (ใช้กับ expected_revision)
+	ContactPrivacy    *ContactPrivacy        `protobuf:"bytes,11,opt,name=contact_privacy,json=contactPrivacy,proto3" json:"contact_privacy,omitempty"`           // ค่า default ของทั้ง tree (node ตั้งทับได้)
+	ClonedFrom        *string                `protobuf:"bytes,12,opt,name=cloned_from,json=clonedFrom,proto3,oneof" json:"cloned_from,omitempty"`                 // tree ต้นฉบับ (CloneTree)
+	TemplateId        *string                `protobuf:"bytes,13,opt,name=template_id,json=templateId,proto3,oneof" json:"template_id,omitempty"`                 // template ที่ใช้เริ่ม tree
+	NodeCount         int32                  `protobuf:"varint,14,opt,name=node_count,json=nodeCount,proto3" json:"node_count,omitempty"`                         // มีเฉพาะใน ListMyTrees / ListSharedWithMe / ListAccessibleTrees
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *Tree) Reset() {
@@ -181,35 +404,70 @@ func (x *Tree) GetMyRole() ShareRole {
 	return ShareRole_SHARE_ROLE_UNSPECIFIED
 }
 
-type TreeShare struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	TreeId          string                 `protobuf:"bytes,2,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
-	UserId          string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Role            ShareRole              `protobuf:"varint,4,opt,name=role,proto3,enum=tree.v1.ShareRole" json:"role,omitempty"`
-	UserEmail       string                 `protobuf:"bytes,5,opt,name=user_email,json=userEmail,proto3" json:"user_email,omitempty"`
-	UserDisplayName string                 `protobuf:"bytes,6,opt,name=user_display_name,json=userDisplayName,proto3" json:"user_display_name,omitempty"`
-	UserAvatarUrl   string                 `protobuf:"bytes,7,opt,name=user_avatar_url,json=userAvatarUrl,proto3" json:"user_avatar_url,omitempty"`
-	InvitedBy       string                 `protobuf:"bytes,8,opt,name=invited_by,json=invitedBy,proto3" json:"invited_by,omitempty"`
-	CreatedAt       string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *Tree) GetStructureRevision() int64 {
+	if x != nil {
+		return x.StructureRevision
+	}
+	return 0
 }
 
-func (x *TreeShare) Reset() {
-	*x = TreeShare{}
+func (x *Tree) GetContactPrivacy() *ContactPrivacy {
+	if x != nil {
+		return x.ContactPrivacy
+	}
+	return nil
+}
+
+func (x *Tree) GetClonedFrom() string {
+	if x != nil && x.ClonedFrom != nil {
+		return *x.ClonedFrom
+	}
+	return ""
+}
+
+func (x *Tree) GetTemplateId() string {
+	if x != nil && x.TemplateId != nil {
+		return *x.TemplateId
+	}
+	return ""
+}
+
+func (x *Tree) GetNodeCount() int32 {
+	if x != nil {
+		return x.NodeCount
+	}
+	return 0
+}
+
+// คำเชิญที่ค้างให้ email ที่ยังไม่มีบัญชี (สมัครแล้วจะกลายเป็น TreeShare)
+type TreeInvitation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TreeId        string                 `protobuf:"bytes,2,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Role          ShareRole              `protobuf:"varint,4,opt,name=role,proto3,enum=tree.v1.ShareRole" json:"role,omitempty"`
+	InvitedBy     string                 `protobuf:"bytes,5,opt,name=invited_by,json=invitedBy,proto3" json:"invited_by,omitempty"`
+	SendCount     int32                  `protobuf:"varint,6,opt,name=send_count,json=sendCount,proto3" json:"send_count,omitempty"` // จำนวนครั้งที่ส่ง email เชิญแล้ว
+	LastSentAt    *string                `protobuf:"bytes,7,opt,name=last_sent_at,json=lastSentAt,proto3,oneof" json:"last_sent_at,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TreeInvitation) Reset() {
+	*x = TreeInvitation{}
 	mi := &file_tree_v1_tree_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TreeShare) String() string {
+func (x *TreeInvitation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TreeShare) ProtoMessage() {}
+func (*TreeInvitation) ProtoMessage() {}
 
-func (x *TreeShare) ProtoReflect() protoreflect.Message {
+func (x *TreeInvitation) ProtoReflect() protoreflect.Message {
 	mi := &file_tree_v1_tree_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -221,98 +479,100 @@ func (x *TreeShare) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TreeShare.ProtoReflect.Descriptor instead.
-func (*TreeShare) Descriptor() ([]byte, []int) {
+// Deprecated: Use TreeInvitation.ProtoReflect.Descriptor instead.
+func (*TreeInvitation) Descriptor() ([]byte, []int) {
 	return file_tree_v1_tree_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *TreeShare) GetId() string {
+func (x *TreeInvitation) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *TreeShare) GetTreeId() string {
+func (x *TreeInvitation) GetTreeId() string {
 	if x != nil {
 		return x.TreeId
 	}
 	return ""
 }
 
-func (x *TreeShare) GetUserId() string {
+func (x *TreeInvitation) GetEmail() string {
 	if x != nil {
-		return x.UserId
+		return x.Email
 	}
 	return ""
 }
 
-func (x *TreeShare) GetRole() ShareRole {
+func (x *TreeInvitation) GetRole() ShareRole {
 	if x != nil {
 		return x.Role
 	}
 	return ShareRole_SHARE_ROLE_UNSPECIFIED
 }
 
-func (x *TreeShare) GetUserEmail() string {
-	if x != nil {
-		return x.UserEmail
-	}
-	return ""
-}
-
-func (x *TreeShare) GetUserDisplayName() string {
+func (x *TreeInvitation) GetInvitedBy() string {
 	if x != nil {
-		return x.UserDisplayName
+		return x.InvitedBy
 	}
 	return ""
 }
 
-func (x *TreeShare) GetUserAvatarUrl() string {
+func (x *TreeInvitation) GetSendCount() int32 {
 	if x != nil {
-		return x.UserAvatarUrl
+		return x.SendCount
 	}
-	return ""
+	return 0
 }
 
-func (x *TreeShare) GetInvitedBy() string {
-	if x != nil {
-		return x.InvitedBy
+func (x *TreeInvitation) GetLastSentAt() string {
+	if x != nil && x.LastSentAt != nil {
+		return *x.LastSentAt
 	}
 	return ""
 }
 
-func (x *TreeShare) GetCreatedAt() string {
+func (x *TreeInvitation) GetCreatedAt() string {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return ""
 }
 
-type CreateTreeRequest struct {
+// ลิงก์แชร์ (tree หนึ่งมีได้หลายลิงก์)
+type ShareLink struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Faculty       string                 `protobuf:"bytes,3,opt,name=faculty,proto3" json:"faculty,omitempty"`
-	Department    string                 `protobuf:"bytes,4,opt,name=department,proto3" json:"department,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TreeId        string                 `protobuf:"bytes,2,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	ShareUrl      string                 `protobuf:"bytes,4,opt,name=share_url,json=shareUrl,proto3" json:"share_url,omitempty"`
+	Role          ShareLinkRole          `protobuf:"varint,5,opt,name=role,proto3,enum=tree.v1.ShareLinkRole" json:"role,omitempty"`
+	ExpiresAt     *string                `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"` // ไม่มี = ไม่หมดอายุ
+	MaxUses       *int32                 `protobuf:"varint,7,opt,name=max_uses,json=maxUses,proto3,oneof" json:"max_uses,omitempty"`      // จำนวนครั้งที่เข้าร่วมได้ (ลิงก์ join เท่านั้น) ไม่มี = ไม่จำกัด
+	UseCount      int32                  `protobuf:"varint,8,opt,name=use_count,json=useCount,proto3" json:"use_count,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	RevokedAt     *string                `protobuf:"bytes,10,opt,name=revoked_at,json=revokedAt,proto3,oneof" json:"revoked_at,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Active        bool                   `protobuf:"varint,12,opt,name=active,proto3" json:"active,omitempty"` // ยังใช้ได้ (ไม่ถูกยกเลิก / ไม่หมดอายุ / ยังไม่ครบจำนวน)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTreeRequest) Reset() {
-	*x = CreateTreeRequest{}
+func (x *ShareLink) Reset() {
+	*x = ShareLink{}
 	mi := &file_tree_v1_tree_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTreeRequest) String() string {
+func (x *ShareLink) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTreeRequest) ProtoMessage() {}
+func (*ShareLink) ProtoMessage() {}
 
-func (x *CreateTreeRequest) ProtoReflect() protoreflect.Message {
+func (x *ShareLink) ProtoReflect() protoreflect.Message {
 	mi := &file_tree_v1_tree_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -324,105 +584,122 @@ func (x *CreateTreeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTreeRequest.ProtoReflect.Descriptor instead.
-func (*CreateTreeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ShareLink.ProtoReflect.Descriptor instead.
+func (*ShareLink) Descriptor() ([]byte, []int) {
 	return file_tree_v1_tree_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *CreateTreeRequest) GetName() string {
+func (x *ShareLink) GetId() string {
 	if x != nil {
-		return x.Name
+		return x.Id
 	}
 	return ""
 }
 
-func (x *CreateTreeRequest) GetDescription() string {
+func (x *ShareLink) GetTreeId() string {
 	if x != nil {
-		return x.Description
+		return x.TreeId
 	}
 	return ""
 }
 
-func (x *CreateTreeRequest) GetFaculty() string {
+func (x *ShareLink) GetToken() string {
 	if x != nil {
-		return x.Faculty
+		return x.Token
 	}
 	return ""
 }
 
-func (x *CreateTreeRequest) GetDepartment() string {
+func (x *ShareLink) GetShareUrl() string {
 	if x != nil {
-		return x.Department
+		return x.ShareUrl
 	}
 	return ""
 }
 
-type CreateTreeResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tree          *Tree                  `protobuf:"bytes,1,opt,name=tree,proto3" json:"tree,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ShareLink) GetRole() ShareLinkRole {
+	if x != nil {
+		return x.Role
+	}
+	return ShareLinkRole_SHARE_LINK_ROLE_UNSPECIFIED
 }
 
-func (x *CreateTreeResponse) Reset() {
-	*x = CreateTreeResponse{}
-	mi := &file_tree_v1_tree_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ShareLink) GetExpiresAt() string {
+	if x != nil && x.ExpiresAt != nil {
+		return *x.ExpiresAt
+	}
+	return ""
 }
 
-func (x *CreateTreeResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ShareLink) GetMaxUses() int32 {
+	if x != nil && x.MaxUses != nil {
+		return *x.MaxUses
+	}
+	return 0
 }
 
-func (*CreateTreeResponse) ProtoMessage() {}
+func (x *ShareLink) GetUseCount() int32 {
+	if x != nil {
+		return x.UseCount
+	}
+	return 0
+}
 
-func (x *CreateTreeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[3]
+func (x *ShareLink) GetCreatedBy() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.CreatedBy
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use CreateTreeResponse.ProtoReflect.Descriptor instead.
-func (*CreateTreeResponse) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{3}
+func (x *ShareLink) GetRevokedAt() string {
+	if x != nil && x.RevokedAt != nil {
+		return *x.RevokedAt
+	}
+	return ""
 }
 
-func (x *CreateTreeResponse) GetTree() *Tree {
+func (x *ShareLink) GetCreatedAt() string {
 	if x != nil {
-		return x.Tree
+		return x.CreatedAt
 	}
-	return nil
+	return ""
 }
 
-type GetTreeRequest struct {
+func (x *ShareLink) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+// visibility ราย field ของช่องทางติดต่อ
+type ContactPrivacy struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Phone         ContactVisibility      `protobuf:"varint,1,opt,name=phone,proto3,enum=tree.v1.ContactVisibility" json:"phone,omitempty"`
+	Email         ContactVisibility      `protobuf:"varint,2,opt,name=email,proto3,enum=tree.v1.ContactVisibility" json:"email,omitempty"`
+	LineId        ContactVisibility      `protobuf:"varint,3,opt,name=line_id,json=lineId,proto3,enum=tree.v1.ContactVisibility" json:"line_id,omitempty"`
+	Discord       ContactVisibility      `protobuf:"varint,4,opt,name=discord,proto3,enum=tree.v1.ContactVisibility" json:"discord,omitempty"`
+	Facebook      ContactVisibility      `protobuf:"varint,5,opt,name=facebook,proto3,enum=tree.v1.ContactVisibility" json:"facebook,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTreeRequest) Reset() {
-	*x = GetTreeRequest{}
-	mi := &file_tree_v1_tree_proto_msgTypes[4]
+func (x *ContactPrivacy) Reset() {
+	*x = ContactPrivacy{}
+	mi := &file_tree_v1_tree_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTreeRequest) String() string {
+func (x *ContactPrivacy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTreeRequest) ProtoMessage() {}
+func (*ContactPrivacy) ProtoMessage() {}
 
-func (x *GetTreeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[4]
+func (x *ContactPrivacy) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -433,40 +710,3548 @@ func (x *GetTreeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTreeRequest.ProtoReflect.Descriptor instead.
-func (*GetTreeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ContactPrivacy.ProtoReflect.Descriptor instead.
+func (*ContactPrivacy) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ContactPrivacy) GetPhone() ContactVisibility {
+	if x != nil {
+		return x.Phone
+	}
+	return ContactVisibility_CONTACT_VISIBILITY_UNSPECIFIED
+}
+
+func (x *ContactPrivacy) GetEmail() ContactVisibility {
+	if x != nil {
+		return x.Email
+	}
+	return ContactVisibility_CONTACT_VISIBILITY_UNSPECIFIED
+}
+
+func (x *ContactPrivacy) GetLineId() ContactVisibility {
+	if x != nil {
+		return x.LineId
+	}
+	return ContactVisibility_CONTACT_VISIBILITY_UNSPECIFIED
+}
+
+func (x *ContactPrivacy) GetDiscord() ContactVisibility {
+	if x != nil {
+		return x.Discord
+	}
+	return ContactVisibility_CONTACT_VISIBILITY_UNSPECIFIED
+}
+
+func (x *ContactPrivacy) GetFacebook() ContactVisibility {
+	if x != nil {
+		return x.Facebook
+	}
+	return ContactVisibility_CONTACT_VISIBILITY_UNSPECIFIED
+}
+
+// คำขอโอนความเป็นเจ้าของ tree (เก็บไว้เป็นประวัติทุกคำขอ)
+type OwnershipTransfer struct {
+	state             protoimpl.MessageState  `protogen:"open.v1"`
+	Id                string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TreeId            string                  `protobuf:"bytes,2,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	FromUserId        string                  `protobuf:"bytes,3,opt,name=from_user_id,json=fromUserId,proto3" json:"from_user_id,omitempty"`
+	ToUserId          string                  `protobuf:"bytes,4,opt,name=to_user_id,json=toUserId,proto3" json:"to_user_id,omitempty"`
+	PreviousOwnerRole ShareRole               `protobuf:"varint,5,opt,name=previous_owner_role,json=previousOwnerRole,proto3,enum=tree.v1.ShareRole" json:"previous_owner_role,omitempty"` // role ที่เจ้าของเดิมได้หลังโอนสำเร็จ
+	Status            OwnershipTransferStatus `protobuf:"varint,6,opt,name=status,proto3,enum=tree.v1.OwnershipTransferStatus" json:"status,omitempty"`
+	CreatedAt         string                  `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ResolvedAt        *string                 `protobuf:"bytes,8,opt,name=resolved_at,json=resolvedAt,proto3,oneof" json:"resolved_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *OwnershipTransfer) Reset() {
+	*x = OwnershipTransfer{}
+	mi := &file_tree_v1_tree_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OwnershipTransfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OwnershipTransfer) ProtoMessage() {}
+
+func (x *OwnershipTransfer) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OwnershipTransfer.ProtoReflect.Descriptor instead.
+func (*OwnershipTransfer) Descriptor() ([]byte, []int) {
 	return file_tree_v1_tree_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetTreeRequest) GetId() string {
+func (x *OwnershipTransfer) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-type GetTreeResponse struct {
+func (x *OwnershipTransfer) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *OwnershipTransfer) GetFromUserId() string {
+	if x != nil {
+		return x.FromUserId
+	}
+	return ""
+}
+
+func (x *OwnershipTransfer) GetToUserId() string {
+	if x != nil {
+		return x.ToUserId
+	}
+	return ""
+}
+
+func (x *OwnershipTransfer) GetPreviousOwnerRole() ShareRole {
+	if x != nil {
+		return x.PreviousOwnerRole
+	}
+	return ShareRole_SHARE_ROLE_UNSPECIFIED
+}
+
+func (x *OwnershipTransfer) GetStatus() OwnershipTransferStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OwnershipTransferStatus_OWNERSHIP_TRANSFER_STATUS_UNSPECIFIED
+}
+
+func (x *OwnershipTransfer) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *OwnershipTransfer) GetResolvedAt() string {
+	if x != nil && x.ResolvedAt != nil {
+		return *x.ResolvedAt
+	}
+	return ""
+}
+
+// ค่าของ node ณ ขณะนั้น (ใน audit)
+type AuditNodeState struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tree          *Tree                  `protobuf:"bytes,1,opt,name=tree,proto3" json:"tree,omitempty"`
+	Nickname      string                 `protobuf:"bytes,1,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	FirstName     string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	StudentId     string                 `protobuf:"bytes,4,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	PhotoUrl      string                 `protobuf:"bytes,5,opt,name=photo_url,json=photoUrl,proto3" json:"photo_url,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Generation    int32                  `protobuf:"varint,7,opt,name=generation,proto3" json:"generation,omitempty"`
+	PositionX     float64                `protobuf:"fixed64,8,opt,name=position_x,json=positionX,proto3" json:"position_x,omitempty"`
+	PositionY     float64                `protobuf:"fixed64,9,opt,name=position_y,json=positionY,proto3" json:"position_y,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,10,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTreeResponse) Reset() {
-	*x = GetTreeResponse{}
+func (x *AuditNodeState) Reset() {
+	*x = AuditNodeState{}
+	mi := &file_tree_v1_tree_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditNodeState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditNodeState) ProtoMessage() {}
+
+func (x *AuditNodeState) ProtoReflect() protoreflect.Message {
 	mi := &file_tree_v1_tree_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditNodeState.ProtoReflect.Descriptor instead.
+func (*AuditNodeState) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AuditNodeState) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *AuditNodeState) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *AuditNodeState) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *AuditNodeState) GetStudentId() string {
+	if x != nil {
+		return x.StudentId
+	}
+	return ""
+}
+
+func (x *AuditNodeState) GetPhotoUrl() string {
+	if x != nil {
+		return x.PhotoUrl
+	}
+	return ""
+}
+
+func (x *AuditNodeState) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AuditNodeState) GetGeneration() int32 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *AuditNodeState) GetPositionX() float64 {
+	if x != nil {
+		return x.PositionX
+	}
+	return 0
+}
+
+func (x *AuditNodeState) GetPositionY() float64 {
+	if x != nil {
+		return x.PositionY
+	}
+	return 0
+}
+
+func (x *AuditNodeState) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// สถานะก่อน / หลังแก้
+type AuditSnapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *AuditNodeState        `protobuf:"bytes,1,opt,name=node,proto3,oneof" json:"node,omitempty"`
+	ParentIds     []string               `protobuf:"bytes,2,rep,name=parent_ids,json=parentIds,proto3" json:"parent_ids,omitempty"`
+	ChildIds      []string               `protobuf:"bytes,3,rep,name=child_ids,json=childIds,proto3" json:"child_ids,omitempty"`
+	Fields        map[string]string      `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // ค่าระดับ tree เช่น role, ชื่อ tree
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditSnapshot) Reset() {
+	*x = AuditSnapshot{}
+	mi := &file_tree_v1_tree_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditSnapshot) ProtoMessage() {}
+
+func (x *AuditSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditSnapshot.ProtoReflect.Descriptor instead.
+func (*AuditSnapshot) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AuditSnapshot) GetNode() *AuditNodeState {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *AuditSnapshot) GetParentIds() []string {
+	if x != nil {
+		return x.ParentIds
+	}
+	return nil
+}
+
+func (x *AuditSnapshot) GetChildIds() []string {
+	if x != nil {
+		return x.ChildIds
+	}
+	return nil
+}
+
+func (x *AuditSnapshot) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// การแก้ไขหนึ่งครั้ง
+type AuditEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TreeId        string                 `protobuf:"bytes,2,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	ActorId       string                 `protobuf:"bytes,3,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`               // เช่น node_created, node_moved, share_updated
+	NodeId        string                 `protobuf:"bytes,5,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"` // "" = action ระดับ tree
+	TargetIds     []string               `protobuf:"bytes,6,rep,name=target_ids,json=targetIds,proto3" json:"target_ids,omitempty"`
+	Before        *AuditSnapshot         `protobuf:"bytes,7,opt,name=before,proto3" json:"before,omitempty"` // ไม่มี = เพิ่งสร้าง
+	After         *AuditSnapshot         `protobuf:"bytes,8,opt,name=after,proto3" json:"after,omitempty"`   // ไม่มี = ถูกลบ
+	CreatedAt     string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_tree_v1_tree_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AuditEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTargetIds() []string {
+	if x != nil {
+		return x.TargetIds
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetBefore() *AuditSnapshot {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetAfter() *AuditSnapshot {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// โครง tree ที่บันทึกไว้เริ่ม tree ใหม่ (ไม่มีข้อมูลคน)
+type TreeTemplate struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Faculty         string                 `protobuf:"bytes,4,opt,name=faculty,proto3" json:"faculty,omitempty"`
+	Department      string                 `protobuf:"bytes,5,opt,name=department,proto3" json:"department,omitempty"`
+	NodeCount       int32                  `protobuf:"varint,6,opt,name=node_count,json=nodeCount,proto3" json:"node_count,omitempty"`
+	GenerationCount int32                  `protobuf:"varint,7,opt,name=generation_count,json=generationCount,proto3" json:"generation_count,omitempty"` // จำนวนรุ่นในโครง
+	CreatedAt       string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TreeTemplate) Reset() {
+	*x = TreeTemplate{}
+	mi := &file_tree_v1_tree_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TreeTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TreeTemplate) ProtoMessage() {}
+
+func (x *TreeTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TreeTemplate.ProtoReflect.Descriptor instead.
+func (*TreeTemplate) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TreeTemplate) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TreeTemplate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TreeTemplate) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TreeTemplate) GetFaculty() string {
+	if x != nil {
+		return x.Faculty
+	}
+	return ""
+}
+
+func (x *TreeTemplate) GetDepartment() string {
+	if x != nil {
+		return x.Department
+	}
+	return ""
+}
+
+func (x *TreeTemplate) GetNodeCount() int32 {
+	if x != nil {
+		return x.NodeCount
+	}
+	return 0
+}
+
+func (x *TreeTemplate) GetGenerationCount() int32 {
+	if x != nil {
+		return x.GenerationCount
+	}
+	return 0
+}
+
+func (x *TreeTemplate) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type TreeShare struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TreeId          string                 `protobuf:"bytes,2,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	UserId          string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role            ShareRole              `protobuf:"varint,4,opt,name=role,proto3,enum=tree.v1.ShareRole" json:"role,omitempty"`
+	UserEmail       string                 `protobuf:"bytes,5,opt,name=user_email,json=userEmail,proto3" json:"user_email,omitempty"`
+	UserDisplayName string                 `protobuf:"bytes,6,opt,name=user_display_name,json=userDisplayName,proto3" json:"user_display_name,omitempty"`
+	UserAvatarUrl   string                 `protobuf:"bytes,7,opt,name=user_avatar_url,json=userAvatarUrl,proto3" json:"user_avatar_url,omitempty"`
+	InvitedBy       string                 `protobuf:"bytes,8,opt,name=invited_by,json=invitedBy,proto3" json:"invited_by,omitempty"`
+	CreatedAt       string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TreeShare) Reset() {
+	*x = TreeShare{}
+	mi := &file_tree_v1_tree_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TreeShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TreeShare) ProtoMessage() {}
+
+func (x *TreeShare) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TreeShare.ProtoReflect.Descriptor instead.
+func (*TreeShare) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TreeShare) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TreeShare) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *TreeShare) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TreeShare) GetRole() ShareRole {
+	if x != nil {
+		return x.Role
+	}
+	return ShareRole_SHARE_ROLE_UNSPECIFIED
+}
+
+func (x *TreeShare) GetUserEmail() string {
+	if x != nil {
+		return x.UserEmail
+	}
+	return ""
+}
+
+func (x *TreeShare) GetUserDisplayName() string {
+	if x != nil {
+		return x.UserDisplayName
+	}
+	return ""
+}
+
+func (x *TreeShare) GetUserAvatarUrl() string {
+	if x != nil {
+		return x.UserAvatarUrl
+	}
+	return ""
+}
+
+func (x *TreeShare) GetInvitedBy() string {
+	if x != nil {
+		return x.InvitedBy
+	}
+	return ""
+}
+
+func (x *TreeShare) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type CreateTreeRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description    string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Faculty        string                 `protobuf:"bytes,3,opt,name=faculty,proto3" json:"faculty,omitempty"` // ว่าง + ใช้ template = ค่าของ template
+	Department     string                 `protobuf:"bytes,4,opt,name=department,proto3" json:"department,omitempty"`
+	TemplateId     *string                `protobuf:"bytes,5,opt,name=template_id,json=templateId,proto3,oneof" json:"template_id,omitempty"`        // เริ่มจากโครงของ template (ของ caller เอง)
+	BaseGeneration int32                  `protobuf:"varint,6,opt,name=base_generation,json=baseGeneration,proto3" json:"base_generation,omitempty"` // รุ่นของตำแหน่งบนสุดใน template (0 = รุ่น 1)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateTreeRequest) Reset() {
+	*x = CreateTreeRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTreeRequest) ProtoMessage() {}
+
+func (x *CreateTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTreeRequest.ProtoReflect.Descriptor instead.
+func (*CreateTreeRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateTreeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTreeRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateTreeRequest) GetFaculty() string {
+	if x != nil {
+		return x.Faculty
+	}
+	return ""
+}
+
+func (x *CreateTreeRequest) GetDepartment() string {
+	if x != nil {
+		return x.Department
+	}
+	return ""
+}
+
+func (x *CreateTreeRequest) GetTemplateId() string {
+	if x != nil && x.TemplateId != nil {
+		return *x.TemplateId
+	}
+	return ""
+}
+
+func (x *CreateTreeRequest) GetBaseGeneration() int32 {
+	if x != nil {
+		return x.BaseGeneration
+	}
+	return 0
+}
+
+type CreateTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tree          *Tree                  `protobuf:"bytes,1,opt,name=tree,proto3" json:"tree,omitempty"`
+	NodeCount     int32                  `protobuf:"varint,2,opt,name=node_count,json=nodeCount,proto3" json:"node_count,omitempty"` // จำนวน node ที่สร้างจาก template
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTreeResponse) Reset() {
+	*x = CreateTreeResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTreeResponse) ProtoMessage() {}
+
+func (x *CreateTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTreeResponse.ProtoReflect.Descriptor instead.
+func (*CreateTreeResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateTreeResponse) GetTree() *Tree {
+	if x != nil {
+		return x.Tree
+	}
+	return nil
+}
+
+func (x *CreateTreeResponse) GetNodeCount() int32 {
+	if x != nil {
+		return x.NodeCount
+	}
+	return 0
+}
+
+type GetTreeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTreeRequest) Reset() {
+	*x = GetTreeRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTreeRequest) ProtoMessage() {}
+
+func (x *GetTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTreeRequest.ProtoReflect.Descriptor instead.
+func (*GetTreeRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetTreeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tree          *Tree                  `protobuf:"bytes,1,opt,name=tree,proto3" json:"tree,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTreeResponse) Reset() {
+	*x = GetTreeResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTreeResponse) ProtoMessage() {}
+
+func (x *GetTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTreeResponse.ProtoReflect.Descriptor instead.
+func (*GetTreeResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetTreeResponse) GetTree() *Tree {
+	if x != nil {
+		return x.Tree
+	}
+	return nil
+}
+
+// ตัวเลือกของรายการ tree (ใช้ร่วมกันทุก RPC ที่ list tree ของ user)
+// ไม่ส่ง options มาเลย = ได้ทุก tree ในหน้าเดียว ไม่มี next_page_token (แบบเดิมก่อนมี pagination)
+type TreeListOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Faculty       *string                `protobuf:"bytes,1,opt,name=faculty,proto3,oneof" json:"faculty,omitempty"` // ตรงทั้งคำ ไม่สนตัวพิมพ์
+	Department    *string                `protobuf:"bytes,2,opt,name=department,proto3,oneof" json:"department,omitempty"`
+	Roles         []ShareRole            `protobuf:"varint,3,rep,packed,name=roles,proto3,enum=tree.v1.ShareRole" json:"roles,omitempty"` // ว่าง = ทุก role (tree ที่ตัวเองสร้าง = OWNER)
+	SortBy        TreeSortField          `protobuf:"varint,4,opt,name=sort_by,json=sortBy,proto3,enum=tree.v1.TreeSortField" json:"sort_by,omitempty"`
+	Reverse       bool                   `protobuf:"varint,5,opt,name=reverse,proto3" json:"reverse,omitempty"`                     // กลับทิศจากลำดับปกติของ sort_by
+	PageSize      int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // 0 = 50, มากสุด 200
+	PageToken     string                 `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token จากหน้าก่อน (ต้องใช้ sort_by / reverse เดิม)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TreeListOptions) Reset() {
+	*x = TreeListOptions{}
+	mi := &file_tree_v1_tree_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TreeListOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TreeListOptions) ProtoMessage() {}
+
+func (x *TreeListOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TreeListOptions.ProtoReflect.Descriptor instead.
+func (*TreeListOptions) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TreeListOptions) GetFaculty() string {
+	if x != nil && x.Faculty != nil {
+		return *x.Faculty
+	}
+	return ""
+}
+
+func (x *TreeListOptions) GetDepartment() string {
+	if x != nil && x.Department != nil {
+		return *x.Department
+	}
+	return ""
+}
+
+func (x *TreeListOptions) GetRoles() []ShareRole {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *TreeListOptions) GetSortBy() TreeSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return TreeSortField_TREE_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *TreeListOptions) GetReverse() bool {
+	if x != nil {
+		return x.Reverse
+	}
+	return false
+}
+
+func (x *TreeListOptions) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *TreeListOptions) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListMyTreesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *TreeListOptions       `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyTreesRequest) Reset() {
+	*x = ListMyTreesRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyTreesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyTreesRequest) ProtoMessage() {}
+
+func (x *ListMyTreesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyTreesRequest.ProtoReflect.Descriptor instead.
+func (*ListMyTreesRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListMyTreesRequest) GetOptions() *TreeListOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type ListMyTreesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trees         []*Tree                `protobuf:"bytes,1,rep,name=trees,proto3" json:"trees,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // "" = หมดแล้ว
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyTreesResponse) Reset() {
+	*x = ListMyTreesResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyTreesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyTreesResponse) ProtoMessage() {}
+
+func (x *ListMyTreesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyTreesResponse.ProtoReflect.Descriptor instead.
+func (*ListMyTreesResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListMyTreesResponse) GetTrees() []*Tree {
+	if x != nil {
+		return x.Trees
+	}
+	return nil
+}
+
+func (x *ListMyTreesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type DeleteTreeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTreeRequest) Reset() {
+	*x = DeleteTreeRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTreeRequest) ProtoMessage() {}
+
+func (x *DeleteTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTreeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTreeRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteTreeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTreeResponse) Reset() {
+	*x = DeleteTreeResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTreeResponse) ProtoMessage() {}
+
+func (x *DeleteTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTreeResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTreeResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{18}
+}
+
+// ตั้งค่า visibility ของช่องทางติดต่อทั้ง tree (เจ้าของเท่านั้น)
+type UpdateContactPrivacyRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TreeId         string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	ContactPrivacy *ContactPrivacy        `protobuf:"bytes,2,opt,name=contact_privacy,json=contactPrivacy,proto3" json:"contact_privacy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateContactPrivacyRequest) Reset() {
+	*x = UpdateContactPrivacyRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateContactPrivacyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateContactPrivacyRequest) ProtoMessage() {}
+
+func (x *UpdateContactPrivacyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateContactPrivacyRequest.ProtoReflect.Descriptor instead.
+func (*UpdateContactPrivacyRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateContactPrivacyRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *UpdateContactPrivacyRequest) GetContactPrivacy() *ContactPrivacy {
+	if x != nil {
+		return x.ContactPrivacy
+	}
+	return nil
+}
+
+type UpdateContactPrivacyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tree          *Tree                  `protobuf:"bytes,1,opt,name=tree,proto3" json:"tree,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateContactPrivacyResponse) Reset() {
+	*x = UpdateContactPrivacyResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateContactPrivacyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateContactPrivacyResponse) ProtoMessage() {}
+
+func (x *UpdateContactPrivacyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateContactPrivacyResponse.ProtoReflect.Descriptor instead.
+func (*UpdateContactPrivacyResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateContactPrivacyResponse) GetTree() *Tree {
+	if x != nil {
+		return x.Tree
+	}
+	return nil
+}
+
+// คัดลอก tree ทั้งต้น (ข้อมูล tree + ทุก node + structure ด้วย id ใหม่) caller เป็นเจ้าของ tree ใหม่
+type CloneTreeRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TreeId          string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                               // ว่าง = "<ชื่อเดิม> (สำเนา)"
+	IncludeContacts bool                   `protobuf:"varint,3,opt,name=include_contacts,json=includeContacts,proto3" json:"include_contacts,omitempty"` // คัดลอกช่องทางติดต่อด้วย (เฉพาะที่ caller มีสิทธิ์เห็น)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CloneTreeRequest) Reset() {
+	*x = CloneTreeRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneTreeRequest) ProtoMessage() {}
+
+func (x *CloneTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneTreeRequest.ProtoReflect.Descriptor instead.
+func (*CloneTreeRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CloneTreeRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *CloneTreeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CloneTreeRequest) GetIncludeContacts() bool {
+	if x != nil {
+		return x.IncludeContacts
+	}
+	return false
+}
+
+type CloneTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tree          *Tree                  `protobuf:"bytes,1,opt,name=tree,proto3" json:"tree,omitempty"`
+	NodeCount     int32                  `protobuf:"varint,2,opt,name=node_count,json=nodeCount,proto3" json:"node_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloneTreeResponse) Reset() {
+	*x = CloneTreeResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneTreeResponse) ProtoMessage() {}
+
+func (x *CloneTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneTreeResponse.ProtoReflect.Descriptor instead.
+func (*CloneTreeResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CloneTreeResponse) GetTree() *Tree {
+	if x != nil {
+		return x.Tree
+	}
+	return nil
+}
+
+func (x *CloneTreeResponse) GetNodeCount() int32 {
+	if x != nil {
+		return x.NodeCount
+	}
+	return 0
+}
+
+// บันทึกโครงของ tree เป็น template (ดูได้ = บันทึกได้)
+type SaveTreeAsTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	KeepNames     bool                   `protobuf:"varint,4,opt,name=keep_names,json=keepNames,proto3" json:"keep_names,omitempty"` // เก็บชื่อเล่นเป็น label (ไม่เก็บ = ใช้ "รุ่น N")
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveTreeAsTemplateRequest) Reset() {
+	*x = SaveTreeAsTemplateRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveTreeAsTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveTreeAsTemplateRequest) ProtoMessage() {}
+
+func (x *SaveTreeAsTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveTreeAsTemplateRequest.ProtoReflect.Descriptor instead.
+func (*SaveTreeAsTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SaveTreeAsTemplateRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *SaveTreeAsTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SaveTreeAsTemplateRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SaveTreeAsTemplateRequest) GetKeepNames() bool {
+	if x != nil {
+		return x.KeepNames
+	}
+	return false
+}
+
+type SaveTreeAsTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *TreeTemplate          `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveTreeAsTemplateResponse) Reset() {
+	*x = SaveTreeAsTemplateResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveTreeAsTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveTreeAsTemplateResponse) ProtoMessage() {}
+
+func (x *SaveTreeAsTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveTreeAsTemplateResponse.ProtoReflect.Descriptor instead.
+func (*SaveTreeAsTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SaveTreeAsTemplateResponse) GetTemplate() *TreeTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type ListTreeTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTreeTemplatesRequest) Reset() {
+	*x = ListTreeTemplatesRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTreeTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTreeTemplatesRequest) ProtoMessage() {}
+
+func (x *ListTreeTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTreeTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListTreeTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{25}
+}
+
+type ListTreeTemplatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Templates     []*TreeTemplate        `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTreeTemplatesResponse) Reset() {
+	*x = ListTreeTemplatesResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTreeTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTreeTemplatesResponse) ProtoMessage() {}
+
+func (x *ListTreeTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTreeTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListTreeTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListTreeTemplatesResponse) GetTemplates() []*TreeTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+type DeleteTreeTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTreeTemplateRequest) Reset() {
+	*x = DeleteTreeTemplateRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTreeTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTreeTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteTreeTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTreeTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTreeTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteTreeTemplateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteTreeTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTreeTemplateResponse) Reset() {
+	*x = DeleteTreeTemplateResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTreeTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTreeTemplateResponse) ProtoMessage() {}
+
+func (x *DeleteTreeTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTreeTemplateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTreeTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{28}
+}
+
+// แชร์ tree ให้ user ด้วย email
+type ShareTreeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Role          ShareRole              `protobuf:"varint,3,opt,name=role,proto3,enum=tree.v1.ShareRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareTreeRequest) Reset() {
+	*x = ShareTreeRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareTreeRequest) ProtoMessage() {}
+
+func (x *ShareTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareTreeRequest.ProtoReflect.Descriptor instead.
+func (*ShareTreeRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ShareTreeRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *ShareTreeRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ShareTreeRequest) GetRole() ShareRole {
+	if x != nil {
+		return x.Role
+	}
+	return ShareRole_SHARE_ROLE_UNSPECIFIED
+}
+
+// email ยังไม่มีบัญชี → ได้ invitation แทน share
+type ShareTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Share         *TreeShare             `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	Invitation    *TreeInvitation        `protobuf:"bytes,2,opt,name=invitation,proto3" json:"invitation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareTreeResponse) Reset() {
+	*x = ShareTreeResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareTreeResponse) ProtoMessage() {}
+
+func (x *ShareTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareTreeResponse.ProtoReflect.Descriptor instead.
+func (*ShareTreeResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ShareTreeResponse) GetShare() *TreeShare {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+func (x *ShareTreeResponse) GetInvitation() *TreeInvitation {
+	if x != nil {
+		return x.Invitation
+	}
+	return nil
+}
+
+// อัปเดต role ของ share
+type UpdateShareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          ShareRole              `protobuf:"varint,3,opt,name=role,proto3,enum=tree.v1.ShareRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateShareRequest) Reset() {
+	*x = UpdateShareRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateShareRequest) ProtoMessage() {}
+
+func (x *UpdateShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateShareRequest.ProtoReflect.Descriptor instead.
+func (*UpdateShareRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UpdateShareRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *UpdateShareRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateShareRequest) GetRole() ShareRole {
+	if x != nil {
+		return x.Role
+	}
+	return ShareRole_SHARE_ROLE_UNSPECIFIED
+}
+
+type UpdateShareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Share         *TreeShare             `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateShareResponse) Reset() {
+	*x = UpdateShareResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateShareResponse) ProtoMessage() {}
+
+func (x *UpdateShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateShareResponse.ProtoReflect.Descriptor instead.
+func (*UpdateShareResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UpdateShareResponse) GetShare() *TreeShare {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+// ลบ share (เอาสิทธิ์ออก)
+type RemoveShareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveShareRequest) Reset() {
+	*x = RemoveShareRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveShareRequest) ProtoMessage() {}
+
+func (x *RemoveShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveShareRequest.ProtoReflect.Descriptor instead.
+func (*RemoveShareRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RemoveShareRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *RemoveShareRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RemoveShareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveShareResponse) Reset() {
+	*x = RemoveShareResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveShareResponse) ProtoMessage() {}
+
+func (x *RemoveShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveShareResponse.ProtoReflect.Descriptor instead.
+func (*RemoveShareResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{34}
+}
+
+// ดูรายการคนที่ถูกแชร์ใน tree
+type ListTreeSharesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTreeSharesRequest) Reset() {
+	*x = ListTreeSharesRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTreeSharesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTreeSharesRequest) ProtoMessage() {}
+
+func (x *ListTreeSharesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTreeSharesRequest.ProtoReflect.Descriptor instead.
+func (*ListTreeSharesRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListTreeSharesRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+type ListTreeSharesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shares        []*TreeShare           `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTreeSharesResponse) Reset() {
+	*x = ListTreeSharesResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTreeSharesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTreeSharesResponse) ProtoMessage() {}
+
+func (x *ListTreeSharesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTreeSharesResponse.ProtoReflect.Descriptor instead.
+func (*ListTreeSharesResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListTreeSharesResponse) GetShares() []*TreeShare {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+// ดูคำเชิญที่ค้างของ tree (เจ้าของเท่านั้น)
+type ListTreeInvitationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTreeInvitationsRequest) Reset() {
+	*x = ListTreeInvitationsRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTreeInvitationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTreeInvitationsRequest) ProtoMessage() {}
+
+func (x *ListTreeInvitationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTreeInvitationsRequest.ProtoReflect.Descriptor instead.
+func (*ListTreeInvitationsRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListTreeInvitationsRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+type ListTreeInvitationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invitations   []*TreeInvitation      `protobuf:"bytes,1,rep,name=invitations,proto3" json:"invitations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTreeInvitationsResponse) Reset() {
+	*x = ListTreeInvitationsResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTreeInvitationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTreeInvitationsResponse) ProtoMessage() {}
+
+func (x *ListTreeInvitationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTreeInvitationsResponse.ProtoReflect.Descriptor instead.
+func (*ListTreeInvitationsResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListTreeInvitationsResponse) GetInvitations() []*TreeInvitation {
+	if x != nil {
+		return x.Invitations
+	}
+	return nil
+}
+
+// ส่ง email เชิญซ้ำ
+type ResendTreeInvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	InvitationId  string                 `protobuf:"bytes,2,opt,name=invitation_id,json=invitationId,proto3" json:"invitation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendTreeInvitationRequest) Reset() {
+	*x = ResendTreeInvitationRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendTreeInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendTreeInvitationRequest) ProtoMessage() {}
+
+func (x *ResendTreeInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendTreeInvitationRequest.ProtoReflect.Descriptor instead.
+func (*ResendTreeInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ResendTreeInvitationRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *ResendTreeInvitationRequest) GetInvitationId() string {
+	if x != nil {
+		return x.InvitationId
+	}
+	return ""
+}
+
+type ResendTreeInvitationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invitation    *TreeInvitation        `protobuf:"bytes,1,opt,name=invitation,proto3" json:"invitation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendTreeInvitationResponse) Reset() {
+	*x = ResendTreeInvitationResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendTreeInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendTreeInvitationResponse) ProtoMessage() {}
+
+func (x *ResendTreeInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendTreeInvitationResponse.ProtoReflect.Descriptor instead.
+func (*ResendTreeInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ResendTreeInvitationResponse) GetInvitation() *TreeInvitation {
+	if x != nil {
+		return x.Invitation
+	}
+	return nil
+}
+
+// ยกเลิกคำเชิญ
+type CancelTreeInvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	InvitationId  string                 `protobuf:"bytes,2,opt,name=invitation_id,json=invitationId,proto3" json:"invitation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTreeInvitationRequest) Reset() {
+	*x = CancelTreeInvitationRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTreeInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTreeInvitationRequest) ProtoMessage() {}
+
+func (x *CancelTreeInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTreeInvitationRequest.ProtoReflect.Descriptor instead.
+func (*CancelTreeInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CancelTreeInvitationRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *CancelTreeInvitationRequest) GetInvitationId() string {
+	if x != nil {
+		return x.InvitationId
+	}
+	return ""
+}
+
+type CancelTreeInvitationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTreeInvitationResponse) Reset() {
+	*x = CancelTreeInvitationResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTreeInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTreeInvitationResponse) ProtoMessage() {}
+
+func (x *CancelTreeInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTreeInvitationResponse.ProtoReflect.Descriptor instead.
+func (*CancelTreeInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{42}
+}
+
+// ดูรายการ tree ที่ถูกแชร์มาให้ฉัน
+type ListSharedWithMeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *TreeListOptions       `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharedWithMeRequest) Reset() {
+	*x = ListSharedWithMeRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharedWithMeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharedWithMeRequest) ProtoMessage() {}
+
+func (x *ListSharedWithMeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharedWithMeRequest.ProtoReflect.Descriptor instead.
+func (*ListSharedWithMeRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListSharedWithMeRequest) GetOptions() *TreeListOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type ListSharedWithMeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trees         []*Tree                `protobuf:"bytes,1,rep,name=trees,proto3" json:"trees,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharedWithMeResponse) Reset() {
+	*x = ListSharedWithMeResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharedWithMeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharedWithMeResponse) ProtoMessage() {}
+
+func (x *ListSharedWithMeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharedWithMeResponse.ProtoReflect.Descriptor instead.
+func (*ListSharedWithMeResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListSharedWithMeResponse) GetTrees() []*Tree {
+	if x != nil {
+		return x.Trees
+	}
+	return nil
+}
+
+func (x *ListSharedWithMeResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// tree ที่เป็นเจ้าของ + ถูกแชร์มา รวมเป็นรายการเดียว
+type ListAccessibleTreesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *TreeListOptions       `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccessibleTreesRequest) Reset() {
+	*x = ListAccessibleTreesRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessibleTreesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessibleTreesRequest) ProtoMessage() {}
+
+func (x *ListAccessibleTreesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccessibleTreesRequest.ProtoReflect.Descriptor instead.
+func (*ListAccessibleTreesRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListAccessibleTreesRequest) GetOptions() *TreeListOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type ListAccessibleTreesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trees         []*Tree                `protobuf:"bytes,1,rep,name=trees,proto3" json:"trees,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccessibleTreesResponse) Reset() {
+	*x = ListAccessibleTreesResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessibleTreesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessibleTreesResponse) ProtoMessage() {}
+
+func (x *ListAccessibleTreesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccessibleTreesResponse.ProtoReflect.Descriptor instead.
+func (*ListAccessibleTreesResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListAccessibleTreesResponse) GetTrees() []*Tree {
+	if x != nil {
+		return x.Trees
+	}
+	return nil
+}
+
+func (x *ListAccessibleTreesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// ดู role ของ user ปัจจุบันกับ tree
+type GetMyRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMyRoleRequest) Reset() {
+	*x = GetMyRoleRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMyRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMyRoleRequest) ProtoMessage() {}
+
+func (x *GetMyRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMyRoleRequest.ProtoReflect.Descriptor instead.
+func (*GetMyRoleRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetMyRoleRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+type GetMyRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          ShareRole              `protobuf:"varint,1,opt,name=role,proto3,enum=tree.v1.ShareRole" json:"role,omitempty"`
+	IsCreator     bool                   `protobuf:"varint,2,opt,name=is_creator,json=isCreator,proto3" json:"is_creator,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMyRoleResponse) Reset() {
+	*x = GetMyRoleResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMyRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMyRoleResponse) ProtoMessage() {}
+
+func (x *GetMyRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMyRoleResponse.ProtoReflect.Descriptor instead.
+func (*GetMyRoleResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetMyRoleResponse) GetRole() ShareRole {
+	if x != nil {
+		return x.Role
+	}
+	return ShareRole_SHARE_ROLE_UNSPECIFIED
+}
+
+func (x *GetMyRoleResponse) GetIsCreator() bool {
+	if x != nil {
+		return x.IsCreator
+	}
+	return false
+}
+
+// ขอโอน tree ให้สมาชิก (เจ้าของเท่านั้น) — มีผลเมื่อผู้รับกดยอมรับ
+type TransferOwnershipRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TreeId            string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	ToUserId          string                 `protobuf:"bytes,2,opt,name=to_user_id,json=toUserId,proto3" json:"to_user_id,omitempty"`                                                    // ต้องถูกแชร์ tree อยู่แล้ว
+	PreviousOwnerRole ShareRole              `protobuf:"varint,3,opt,name=previous_owner_role,json=previousOwnerRole,proto3,enum=tree.v1.ShareRole" json:"previous_owner_role,omitempty"` // role ของเจ้าของเดิมหลังโอน
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *TransferOwnershipRequest) Reset() {
+	*x = TransferOwnershipRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferOwnershipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferOwnershipRequest) ProtoMessage() {}
+
+func (x *TransferOwnershipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferOwnershipRequest.ProtoReflect.Descriptor instead.
+func (*TransferOwnershipRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *TransferOwnershipRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *TransferOwnershipRequest) GetToUserId() string {
+	if x != nil {
+		return x.ToUserId
+	}
+	return ""
+}
+
+func (x *TransferOwnershipRequest) GetPreviousOwnerRole() ShareRole {
+	if x != nil {
+		return x.PreviousOwnerRole
+	}
+	return ShareRole_SHARE_ROLE_UNSPECIFIED
+}
+
+type TransferOwnershipResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfer      *OwnershipTransfer     `protobuf:"bytes,1,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferOwnershipResponse) Reset() {
+	*x = TransferOwnershipResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferOwnershipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferOwnershipResponse) ProtoMessage() {}
+
+func (x *TransferOwnershipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferOwnershipResponse.ProtoReflect.Descriptor instead.
+func (*TransferOwnershipResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *TransferOwnershipResponse) GetTransfer() *OwnershipTransfer {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+// ผู้รับตอบคำขอโอน
+type RespondOwnershipTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransferId    string                 `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	Accept        bool                   `protobuf:"varint,2,opt,name=accept,proto3" json:"accept,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RespondOwnershipTransferRequest) Reset() {
+	*x = RespondOwnershipTransferRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespondOwnershipTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondOwnershipTransferRequest) ProtoMessage() {}
+
+func (x *RespondOwnershipTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondOwnershipTransferRequest.ProtoReflect.Descriptor instead.
+func (*RespondOwnershipTransferRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *RespondOwnershipTransferRequest) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *RespondOwnershipTransferRequest) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+type RespondOwnershipTransferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfer      *OwnershipTransfer     `protobuf:"bytes,1,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	Tree          *Tree                  `protobuf:"bytes,2,opt,name=tree,proto3" json:"tree,omitempty"` // tree หลังโอน (เฉพาะตอนยอมรับ)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RespondOwnershipTransferResponse) Reset() {
+	*x = RespondOwnershipTransferResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespondOwnershipTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondOwnershipTransferResponse) ProtoMessage() {}
+
+func (x *RespondOwnershipTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondOwnershipTransferResponse.ProtoReflect.Descriptor instead.
+func (*RespondOwnershipTransferResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *RespondOwnershipTransferResponse) GetTransfer() *OwnershipTransfer {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+func (x *RespondOwnershipTransferResponse) GetTree() *Tree {
+	if x != nil {
+		return x.Tree
+	}
+	return nil
+}
+
+// เจ้าของยกเลิกคำขอที่ยังรออยู่
+type CancelOwnershipTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransferId    string                 `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOwnershipTransferRequest) Reset() {
+	*x = CancelOwnershipTransferRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOwnershipTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOwnershipTransferRequest) ProtoMessage() {}
+
+func (x *CancelOwnershipTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOwnershipTransferRequest.ProtoReflect.Descriptor instead.
+func (*CancelOwnershipTransferRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *CancelOwnershipTransferRequest) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+type CancelOwnershipTransferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfer      *OwnershipTransfer     `protobuf:"bytes,1,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOwnershipTransferResponse) Reset() {
+	*x = CancelOwnershipTransferResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOwnershipTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOwnershipTransferResponse) ProtoMessage() {}
+
+func (x *CancelOwnershipTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOwnershipTransferResponse.ProtoReflect.Descriptor instead.
+func (*CancelOwnershipTransferResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *CancelOwnershipTransferResponse) GetTransfer() *OwnershipTransfer {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+// ประวัติการโอนของ tree (เจ้าของ / co-owner)
+type ListOwnershipTransfersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOwnershipTransfersRequest) Reset() {
+	*x = ListOwnershipTransfersRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOwnershipTransfersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOwnershipTransfersRequest) ProtoMessage() {}
+
+func (x *ListOwnershipTransfersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOwnershipTransfersRequest.ProtoReflect.Descriptor instead.
+func (*ListOwnershipTransfersRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ListOwnershipTransfersRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+type ListOwnershipTransfersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfers     []*OwnershipTransfer   `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOwnershipTransfersResponse) Reset() {
+	*x = ListOwnershipTransfersResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOwnershipTransfersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOwnershipTransfersResponse) ProtoMessage() {}
+
+func (x *ListOwnershipTransfersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOwnershipTransfersResponse.ProtoReflect.Descriptor instead.
+func (*ListOwnershipTransfersResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ListOwnershipTransfersResponse) GetTransfers() []*OwnershipTransfer {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+// คำขอโอนที่รอฉันตอบ
+type ListIncomingOwnershipTransfersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIncomingOwnershipTransfersRequest) Reset() {
+	*x = ListIncomingOwnershipTransfersRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIncomingOwnershipTransfersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIncomingOwnershipTransfersRequest) ProtoMessage() {}
+
+func (x *ListIncomingOwnershipTransfersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIncomingOwnershipTransfersRequest.ProtoReflect.Descriptor instead.
+func (*ListIncomingOwnershipTransfersRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{57}
+}
+
+type ListIncomingOwnershipTransfersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfers     []*OwnershipTransfer   `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIncomingOwnershipTransfersResponse) Reset() {
+	*x = ListIncomingOwnershipTransfersResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIncomingOwnershipTransfersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIncomingOwnershipTransfersResponse) ProtoMessage() {}
+
+func (x *ListIncomingOwnershipTransfersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIncomingOwnershipTransfersResponse.ProtoReflect.Descriptor instead.
+func (*ListIncomingOwnershipTransfersResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ListIncomingOwnershipTransfersResponse) GetTransfers() []*OwnershipTransfer {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+type GenerationCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Generation    int32                  `protobuf:"varint,1,opt,name=generation,proto3" json:"generation,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerationCount) Reset() {
+	*x = GenerationCount{}
+	mi := &file_tree_v1_tree_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerationCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerationCount) ProtoMessage() {}
+
+func (x *GenerationCount) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerationCount.ProtoReflect.Descriptor instead.
+func (*GenerationCount) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GenerationCount) GetGeneration() int32 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *GenerationCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type StatusCounts struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Studying      int32                  `protobuf:"varint,1,opt,name=studying,proto3" json:"studying,omitempty"`
+	Graduated     int32                  `protobuf:"varint,2,opt,name=graduated,proto3" json:"graduated,omitempty"`
+	Retired       int32                  `protobuf:"varint,3,opt,name=retired,proto3" json:"retired,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusCounts) Reset() {
+	*x = StatusCounts{}
+	mi := &file_tree_v1_tree_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusCounts) ProtoMessage() {}
+
+func (x *StatusCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusCounts.ProtoReflect.Descriptor instead.
+func (*StatusCounts) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *StatusCounts) GetStudying() int32 {
+	if x != nil {
+		return x.Studying
+	}
+	return 0
+}
+
+func (x *StatusCounts) GetGraduated() int32 {
+	if x != nil {
+		return x.Graduated
+	}
+	return 0
+}
+
+func (x *StatusCounts) GetRetired() int32 {
+	if x != nil {
+		return x.Retired
+	}
+	return 0
+}
+
+// จำนวนคนที่ถูกเพิ่มในแต่ละเดือน (ตาม created_at ของ node, UTC)
+type TrendPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Month         string                 `protobuf:"bytes,1,opt,name=month,proto3" json:"month,omitempty"` // "2026-02"
+	Added         int32                  `protobuf:"varint,2,opt,name=added,proto3" json:"added,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"` // สะสมถึงสิ้นเดือนนี้
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrendPoint) Reset() {
+	*x = TrendPoint{}
+	mi := &file_tree_v1_tree_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrendPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrendPoint) ProtoMessage() {}
+
+func (x *TrendPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrendPoint.ProtoReflect.Descriptor instead.
+func (*TrendPoint) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *TrendPoint) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *TrendPoint) GetAdded() int32 {
+	if x != nil {
+		return x.Added
+	}
+	return 0
+}
+
+func (x *TrendPoint) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type TreeStats struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	MemberCount          int32                  `protobuf:"varint,1,opt,name=member_count,json=memberCount,proto3" json:"member_count,omitempty"`
+	Generations          []*GenerationCount     `protobuf:"bytes,2,rep,name=generations,proto3" json:"generations,omitempty"` // เรียงตามรุ่น
+	Statuses             *StatusCounts          `protobuf:"bytes,3,opt,name=statuses,proto3" json:"statuses,omitempty"`
+	SeniorCount          int32                  `protobuf:"varint,4,opt,name=senior_count,json=seniorCount,proto3" json:"senior_count,omitempty"` // คนที่มีน้องรหัสอย่างน้อย 1 คน
+	AvgChildrenPerSenior float64                `protobuf:"fixed64,5,opt,name=avg_children_per_senior,json=avgChildrenPerSenior,proto3" json:"avg_children_per_senior,omitempty"`
+	MaxChildren          int32                  `protobuf:"varint,6,opt,name=max_children,json=maxChildren,proto3" json:"max_children,omitempty"`
+	DeepestLineage       int32                  `protobuf:"varint,7,opt,name=deepest_lineage,json=deepestLineage,proto3" json:"deepest_lineage,omitempty"` // จำนวนคนในสายที่ยาวที่สุด (root → ล่างสุด)
+	DeepestPath          []string               `protobuf:"bytes,8,rep,name=deepest_path,json=deepestPath,proto3" json:"deepest_path,omitempty"`           // node id ของสายนั้น
+	RootCount            int32                  `protobuf:"varint,9,opt,name=root_count,json=rootCount,proto3" json:"root_count,omitempty"`
+	OrphanRootIds        []string               `protobuf:"bytes,10,rep,name=orphan_root_ids,json=orphanRootIds,proto3" json:"orphan_root_ids,omitempty"`           // root ที่ไม่มีน้องรหัส (ยังไม่ได้ต่อสาย)
+	MultiParentCount     int32                  `protobuf:"varint,11,opt,name=multi_parent_count,json=multiParentCount,proto3" json:"multi_parent_count,omitempty"` // คนที่มีพี่รหัสมากกว่า 1 คน
+	Trend                []*TrendPoint          `protobuf:"bytes,12,rep,name=trend,proto3" json:"trend,omitempty"`                                                  // ตั้งแต่เดือนแรกถึงเดือนล่าสุด (เดือนที่ไม่มีคนเพิ่ม = 0)
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *TreeStats) Reset() {
+	*x = TreeStats{}
+	mi := &file_tree_v1_tree_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TreeStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TreeStats) ProtoMessage() {}
+
+func (x *TreeStats) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TreeStats.ProtoReflect.Descriptor instead.
+func (*TreeStats) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *TreeStats) GetMemberCount() int32 {
+	if x != nil {
+		return x.MemberCount
+	}
+	return 0
+}
+
+func (x *TreeStats) GetGenerations() []*GenerationCount {
+	if x != nil {
+		return x.Generations
+	}
+	return nil
+}
+
+func (x *TreeStats) GetStatuses() *StatusCounts {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *TreeStats) GetSeniorCount() int32 {
+	if x != nil {
+		return x.SeniorCount
+	}
+	return 0
+}
+
+func (x *TreeStats) GetAvgChildrenPerSenior() float64 {
+	if x != nil {
+		return x.AvgChildrenPerSenior
+	}
+	return 0
+}
+
+func (x *TreeStats) GetMaxChildren() int32 {
+	if x != nil {
+		return x.MaxChildren
+	}
+	return 0
+}
+
+func (x *TreeStats) GetDeepestLineage() int32 {
+	if x != nil {
+		return x.DeepestLineage
+	}
+	return 0
+}
+
+func (x *TreeStats) GetDeepestPath() []string {
+	if x != nil {
+		return x.DeepestPath
+	}
+	return nil
+}
+
+func (x *TreeStats) GetRootCount() int32 {
+	if x != nil {
+		return x.RootCount
+	}
+	return 0
+}
+
+func (x *TreeStats) GetOrphanRootIds() []string {
+	if x != nil {
+		return x.OrphanRootIds
+	}
+	return nil
+}
+
+func (x *TreeStats) GetMultiParentCount() int32 {
+	if x != nil {
+		return x.MultiParentCount
+	}
+	return 0
+}
+
+func (x *TreeStats) GetTrend() []*TrendPoint {
+	if x != nil {
+		return x.Trend
+	}
+	return nil
+}
+
+// ดู tree ได้ = ดูสถิติได้
+type GetTreeStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTreeStatsRequest) Reset() {
+	*x = GetTreeStatsRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTreeStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTreeStatsRequest) ProtoMessage() {}
+
+func (x *GetTreeStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTreeStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetTreeStatsRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetTreeStatsRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+type GetTreeStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         *TreeStats             `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTreeStatsResponse) Reset() {
+	*x = GetTreeStatsResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTreeStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTreeStatsResponse) ProtoMessage() {}
+
+func (x *GetTreeStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTreeStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetTreeStatsResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetTreeStatsResponse) GetStats() *TreeStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type DepartmentStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Department    string                 `protobuf:"bytes,1,opt,name=department,proto3" json:"department,omitempty"`
+	TreeCount     int32                  `protobuf:"varint,2,opt,name=tree_count,json=treeCount,proto3" json:"tree_count,omitempty"`
+	MemberCount   int32                  `protobuf:"varint,3,opt,name=member_count,json=memberCount,proto3" json:"member_count,omitempty"`
+	Statuses      *StatusCounts          `protobuf:"bytes,4,opt,name=statuses,proto3" json:"statuses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DepartmentStats) Reset() {
+	*x = DepartmentStats{}
+	mi := &file_tree_v1_tree_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DepartmentStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepartmentStats) ProtoMessage() {}
+
+func (x *DepartmentStats) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepartmentStats.ProtoReflect.Descriptor instead.
+func (*DepartmentStats) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *DepartmentStats) GetDepartment() string {
+	if x != nil {
+		return x.Department
+	}
+	return ""
+}
+
+func (x *DepartmentStats) GetTreeCount() int32 {
+	if x != nil {
+		return x.TreeCount
+	}
+	return 0
+}
+
+func (x *DepartmentStats) GetMemberCount() int32 {
+	if x != nil {
+		return x.MemberCount
+	}
+	return 0
+}
+
+func (x *DepartmentStats) GetStatuses() *StatusCounts {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+type FacultyStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Faculty       string                 `protobuf:"bytes,1,opt,name=faculty,proto3" json:"faculty,omitempty"`
+	TreeCount     int32                  `protobuf:"varint,2,opt,name=tree_count,json=treeCount,proto3" json:"tree_count,omitempty"`
+	MemberCount   int32                  `protobuf:"varint,3,opt,name=member_count,json=memberCount,proto3" json:"member_count,omitempty"`
+	Statuses      *StatusCounts          `protobuf:"bytes,4,opt,name=statuses,proto3" json:"statuses,omitempty"`
+	Departments   []*DepartmentStats     `protobuf:"bytes,5,rep,name=departments,proto3" json:"departments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FacultyStats) Reset() {
+	*x = FacultyStats{}
+	mi := &file_tree_v1_tree_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTreeResponse) String() string {
+func (x *FacultyStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTreeResponse) ProtoMessage() {}
+func (*FacultyStats) ProtoMessage() {}
 
-func (x *GetTreeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[5]
+func (x *FacultyStats) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -477,39 +4262,69 @@ func (x *GetTreeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTreeResponse.ProtoReflect.Descriptor instead.
-func (*GetTreeResponse) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use FacultyStats.ProtoReflect.Descriptor instead.
+func (*FacultyStats) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *GetTreeResponse) GetTree() *Tree {
+func (x *FacultyStats) GetFaculty() string {
 	if x != nil {
-		return x.Tree
+		return x.Faculty
+	}
+	return ""
+}
+
+func (x *FacultyStats) GetTreeCount() int32 {
+	if x != nil {
+		return x.TreeCount
+	}
+	return 0
+}
+
+func (x *FacultyStats) GetMemberCount() int32 {
+	if x != nil {
+		return x.MemberCount
+	}
+	return 0
+}
+
+func (x *FacultyStats) GetStatuses() *StatusCounts {
+	if x != nil {
+		return x.Statuses
 	}
 	return nil
 }
 
-type ListMyTreesRequest struct {
+func (x *FacultyStats) GetDepartments() []*DepartmentStats {
+	if x != nil {
+		return x.Departments
+	}
+	return nil
+}
+
+// สรุปรวมของ public tree ต่อคณะ / ภาควิชา (ไม่ต้อง login)
+type GetFacultyStatsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Faculty       *string                `protobuf:"bytes,1,opt,name=faculty,proto3,oneof" json:"faculty,omitempty"` // ไม่ส่ง = ทุกคณะ
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListMyTreesRequest) Reset() {
-	*x = ListMyTreesRequest{}
-	mi := &file_tree_v1_tree_proto_msgTypes[6]
+func (x *GetFacultyStatsRequest) Reset() {
+	*x = GetFacultyStatsRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListMyTreesRequest) String() string {
+func (x *GetFacultyStatsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListMyTreesRequest) ProtoMessage() {}
+func (*GetFacultyStatsRequest) ProtoMessage() {}
 
-func (x *ListMyTreesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[6]
+func (x *GetFacultyStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -520,33 +4335,40 @@ func (x *ListMyTreesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListMyTreesRequest.ProtoReflect.Descriptor instead.
-func (*ListMyTreesRequest) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use GetFacultyStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetFacultyStatsRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{67}
 }
 
-type ListMyTreesResponse struct {
+func (x *GetFacultyStatsRequest) GetFaculty() string {
+	if x != nil && x.Faculty != nil {
+		return *x.Faculty
+	}
+	return ""
+}
+
+type GetFacultyStatsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Trees         []*Tree                `protobuf:"bytes,1,rep,name=trees,proto3" json:"trees,omitempty"`
+	Faculties     []*FacultyStats        `protobuf:"bytes,1,rep,name=faculties,proto3" json:"faculties,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListMyTreesResponse) Reset() {
-	*x = ListMyTreesResponse{}
-	mi := &file_tree_v1_tree_proto_msgTypes[7]
+func (x *GetFacultyStatsResponse) Reset() {
+	*x = GetFacultyStatsResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListMyTreesResponse) String() string {
+func (x *GetFacultyStatsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListMyTreesResponse) ProtoMessage() {}
+func (*GetFacultyStatsResponse) ProtoMessage() {}
 
-func (x *ListMyTreesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[7]
+func (x *GetFacultyStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -557,40 +4379,44 @@ func (x *ListMyTreesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListMyTreesResponse.ProtoReflect.Descriptor instead.
-func (*ListMyTreesResponse) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use GetFacultyStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetFacultyStatsResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *ListMyTreesResponse) GetTrees() []*Tree {
+func (x *GetFacultyStatsResponse) GetFaculties() []*FacultyStats {
 	if x != nil {
-		return x.Trees
+		return x.Faculties
 	}
 	return nil
 }
 
-type DeleteTreeRequest struct {
+// จุดที่ trees.structure ไม่ตรงกับตาราง nodes พร้อมสิ่งที่ repair ทำ
+type StructureIssue struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // missing_node | duplicate_root | duplicate_child | missing_edge | root_has_parent | cycle | detached_node
+	NodeId        string                 `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	ParentId      *string                `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"` // เส้น parent → node ที่เกี่ยวข้อง
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteTreeRequest) Reset() {
-	*x = DeleteTreeRequest{}
-	mi := &file_tree_v1_tree_proto_msgTypes[8]
+func (x *StructureIssue) Reset() {
+	*x = StructureIssue{}
+	mi := &file_tree_v1_tree_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTreeRequest) String() string {
+func (x *StructureIssue) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTreeRequest) ProtoMessage() {}
+func (*StructureIssue) ProtoMessage() {}
 
-func (x *DeleteTreeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[8]
+func (x *StructureIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -601,79 +4427,63 @@ func (x *DeleteTreeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTreeRequest.ProtoReflect.Descriptor instead.
-func (*DeleteTreeRequest) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use StructureIssue.ProtoReflect.Descriptor instead.
+func (*StructureIssue) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *DeleteTreeRequest) GetId() string {
+func (x *StructureIssue) GetKind() string {
 	if x != nil {
-		return x.Id
+		return x.Kind
 	}
 	return ""
 }
 
-type DeleteTreeResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *DeleteTreeResponse) Reset() {
-	*x = DeleteTreeResponse{}
-	mi := &file_tree_v1_tree_proto_msgTypes[9]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *StructureIssue) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
 }
 
-func (x *DeleteTreeResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *StructureIssue) GetParentId() string {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return ""
 }
 
-func (*DeleteTreeResponse) ProtoMessage() {}
-
-func (x *DeleteTreeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[9]
+func (x *StructureIssue) GetDescription() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Description
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use DeleteTreeResponse.ProtoReflect.Descriptor instead.
-func (*DeleteTreeResponse) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{9}
+	return ""
 }
 
-// แชร์ tree ให้ user ด้วย email
-type ShareTreeRequest struct {
+// ตรวจ structure แล้วซ่อม (เจ้าของ / co-owner เท่านั้น) dry_run = ตรวจอย่างเดียว ไม่เขียน
+type RepairTreeStructureRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
-	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
-	Role          ShareRole              `protobuf:"varint,3,opt,name=role,proto3,enum=tree.v1.ShareRole" json:"role,omitempty"`
+	DryRun        bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ShareTreeRequest) Reset() {
-	*x = ShareTreeRequest{}
-	mi := &file_tree_v1_tree_proto_msgTypes[10]
+func (x *RepairTreeStructureRequest) Reset() {
+	*x = RepairTreeStructureRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShareTreeRequest) String() string {
+func (x *RepairTreeStructureRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShareTreeRequest) ProtoMessage() {}
+func (*RepairTreeStructureRequest) ProtoMessage() {}
 
-func (x *ShareTreeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[10]
+func (x *RepairTreeStructureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -684,54 +4494,49 @@ func (x *ShareTreeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShareTreeRequest.ProtoReflect.Descriptor instead.
-func (*ShareTreeRequest) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use RepairTreeStructureRequest.ProtoReflect.Descriptor instead.
+func (*RepairTreeStructureRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *ShareTreeRequest) GetTreeId() string {
+func (x *RepairTreeStructureRequest) GetTreeId() string {
 	if x != nil {
 		return x.TreeId
 	}
 	return ""
 }
 
-func (x *ShareTreeRequest) GetEmail() string {
-	if x != nil {
-		return x.Email
-	}
-	return ""
-}
-
-func (x *ShareTreeRequest) GetRole() ShareRole {
+func (x *RepairTreeStructureRequest) GetDryRun() bool {
 	if x != nil {
-		return x.Role
+		return x.DryRun
 	}
-	return ShareRole_SHARE_ROLE_UNSPECIFIED
+	return false
 }
 
-type ShareTreeResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Share         *TreeShare             `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type RepairTreeStructureResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Issues            []*StructureIssue      `protobuf:"bytes,1,rep,name=issues,proto3" json:"issues,omitempty"`      // ว่าง = structure ปกติ
+	Repaired          bool                   `protobuf:"varint,2,opt,name=repaired,proto3" json:"repaired,omitempty"` // เขียน structure ที่แก้แล้วลง DB แล้ว
+	StructureRevision int64                  `protobuf:"varint,3,opt,name=structure_revision,json=structureRevision,proto3" json:"structure_revision,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *ShareTreeResponse) Reset() {
-	*x = ShareTreeResponse{}
-	mi := &file_tree_v1_tree_proto_msgTypes[11]
+func (x *RepairTreeStructureResponse) Reset() {
+	*x = RepairTreeStructureResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShareTreeResponse) String() string {
+func (x *RepairTreeStructureResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShareTreeResponse) ProtoMessage() {}
+func (*RepairTreeStructureResponse) ProtoMessage() {}
 
-func (x *ShareTreeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[11]
+func (x *RepairTreeStructureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -742,43 +4547,61 @@ func (x *ShareTreeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShareTreeResponse.ProtoReflect.Descriptor instead.
-func (*ShareTreeResponse) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use RepairTreeStructureResponse.ProtoReflect.Descriptor instead.
+func (*RepairTreeStructureResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *ShareTreeResponse) GetShare() *TreeShare {
+func (x *RepairTreeStructureResponse) GetIssues() []*StructureIssue {
 	if x != nil {
-		return x.Share
+		return x.Issues
 	}
 	return nil
 }
 
-// อัปเดต role ของ share
-type UpdateShareRequest struct {
+func (x *RepairTreeStructureResponse) GetRepaired() bool {
+	if x != nil {
+		return x.Repaired
+	}
+	return false
+}
+
+func (x *RepairTreeStructureResponse) GetStructureRevision() int64 {
+	if x != nil {
+		return x.StructureRevision
+	}
+	return 0
+}
+
+// ดูประวัติการแก้ของ tree (เจ้าของ / co-owner) ใหม่สุดก่อน
+type ListAuditEventsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Role          ShareRole              `protobuf:"varint,3,opt,name=role,proto3,enum=tree.v1.ShareRole" json:"role,omitempty"`
+	NodeId        *string                `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3,oneof" json:"node_id,omitempty"` // รวม event ที่ node นี้เป็น target (เช่นเป็น parent)
+	ActorId       *string                `protobuf:"bytes,3,opt,name=actor_id,json=actorId,proto3,oneof" json:"actor_id,omitempty"`
+	Since         *string                `protobuf:"bytes,4,opt,name=since,proto3,oneof" json:"since,omitempty"`                    // RFC3339 (รวม)
+	Until         *string                `protobuf:"bytes,5,opt,name=until,proto3,oneof" json:"until,omitempty"`                    // RFC3339 (ไม่รวม)
+	PageSize      int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // 0 = 50, มากสุด 200
+	PageToken     string                 `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token จากหน้าก่อน
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateShareRequest) Reset() {
-	*x = UpdateShareRequest{}
-	mi := &file_tree_v1_tree_proto_msgTypes[12]
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateShareRequest) String() string {
+func (x *ListAuditEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateShareRequest) ProtoMessage() {}
+func (*ListAuditEventsRequest) ProtoMessage() {}
 
-func (x *UpdateShareRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[12]
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -789,54 +4612,83 @@ func (x *UpdateShareRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateShareRequest.ProtoReflect.Descriptor instead.
-func (*UpdateShareRequest) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *UpdateShareRequest) GetTreeId() string {
+func (x *ListAuditEventsRequest) GetTreeId() string {
 	if x != nil {
 		return x.TreeId
 	}
 	return ""
 }
 
-func (x *UpdateShareRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
+func (x *ListAuditEventsRequest) GetNodeId() string {
+	if x != nil && x.NodeId != nil {
+		return *x.NodeId
 	}
 	return ""
 }
 
-func (x *UpdateShareRequest) GetRole() ShareRole {
+func (x *ListAuditEventsRequest) GetActorId() string {
+	if x != nil && x.ActorId != nil {
+		return *x.ActorId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetSince() string {
+	if x != nil && x.Since != nil {
+		return *x.Since
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetUntil() string {
+	if x != nil && x.Until != nil {
+		return *x.Until
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.Role
+		return x.PageSize
 	}
-	return ShareRole_SHARE_ROLE_UNSPECIFIED
+	return 0
 }
 
-type UpdateShareResponse struct {
+func (x *ListAuditEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListAuditEventsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Share         *TreeShare             `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	Events        []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // "" = หมดแล้ว
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateShareResponse) Reset() {
-	*x = UpdateShareResponse{}
-	mi := &file_tree_v1_tree_proto_msgTypes[13]
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateShareResponse) String() string {
+func (x *ListAuditEventsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateShareResponse) ProtoMessage() {}
+func (*ListAuditEventsResponse) ProtoMessage() {}
 
-func (x *UpdateShareResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[13]
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -847,42 +4699,52 @@ func (x *UpdateShareResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateShareResponse.ProtoReflect.Descriptor instead.
-func (*UpdateShareResponse) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *UpdateShareResponse) GetShare() *TreeShare {
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
 	if x != nil {
-		return x.Share
+		return x.Events
 	}
 	return nil
 }
 
-// ลบ share (เอาสิทธิ์ออก)
-type RemoveShareRequest struct {
+func (x *ListAuditEventsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// สร้างลิงก์แชร์ (ต้อง login, เจ้าของเท่านั้น)
+// ไม่ได้ตั้งอะไรเลย = ใช้ลิงก์ดูอย่างเดียวแบบถาวรตัวเดิมถ้ามี
+type GenerateShareLinkRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          ShareLinkRole          `protobuf:"varint,2,opt,name=role,proto3,enum=tree.v1.ShareLinkRole" json:"role,omitempty"`      // UNSPECIFIED = VIEW
+	ExpiresAt     *string                `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"` // RFC3339 ต้องเป็นเวลาในอนาคต
+	MaxUses       *int32                 `protobuf:"varint,4,opt,name=max_uses,json=maxUses,proto3,oneof" json:"max_uses,omitempty"`      // ลิงก์ join เท่านั้น
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveShareRequest) Reset() {
-	*x = RemoveShareRequest{}
-	mi := &file_tree_v1_tree_proto_msgTypes[14]
+func (x *GenerateShareLinkRequest) Reset() {
+	*x = GenerateShareLinkRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveShareRequest) String() string {
+func (x *GenerateShareLinkRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveShareRequest) ProtoMessage() {}
+func (*GenerateShareLinkRequest) ProtoMessage() {}
 
-func (x *RemoveShareRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[14]
+func (x *GenerateShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -893,46 +4755,63 @@ func (x *RemoveShareRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveShareRequest.ProtoReflect.Descriptor instead.
-func (*RemoveShareRequest) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use GenerateShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*GenerateShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *RemoveShareRequest) GetTreeId() string {
+func (x *GenerateShareLinkRequest) GetTreeId() string {
 	if x != nil {
 		return x.TreeId
 	}
 	return ""
 }
 
-func (x *RemoveShareRequest) GetUserId() string {
+func (x *GenerateShareLinkRequest) GetRole() ShareLinkRole {
 	if x != nil {
-		return x.UserId
+		return x.Role
+	}
+	return ShareLinkRole_SHARE_LINK_ROLE_UNSPECIFIED
+}
+
+func (x *GenerateShareLinkRequest) GetExpiresAt() string {
+	if x != nil && x.ExpiresAt != nil {
+		return *x.ExpiresAt
 	}
 	return ""
 }
 
-type RemoveShareResponse struct {
+func (x *GenerateShareLinkRequest) GetMaxUses() int32 {
+	if x != nil && x.MaxUses != nil {
+		return *x.MaxUses
+	}
+	return 0
+}
+
+type GenerateShareLinkResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShareToken    string                 `protobuf:"bytes,1,opt,name=share_token,json=shareToken,proto3" json:"share_token,omitempty"`
+	ShareUrl      string                 `protobuf:"bytes,2,opt,name=share_url,json=shareUrl,proto3" json:"share_url,omitempty"`
+	Link          *ShareLink             `protobuf:"bytes,3,opt,name=link,proto3" json:"link,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveShareResponse) Reset() {
-	*x = RemoveShareResponse{}
-	mi := &file_tree_v1_tree_proto_msgTypes[15]
+func (x *GenerateShareLinkResponse) Reset() {
+	*x = GenerateShareLinkResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveShareResponse) String() string {
+func (x *GenerateShareLinkResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveShareResponse) ProtoMessage() {}
+func (*GenerateShareLinkResponse) ProtoMessage() {}
 
-func (x *RemoveShareResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[15]
+func (x *GenerateShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -943,34 +4822,55 @@ func (x *RemoveShareResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveShareResponse.ProtoReflect.Descriptor instead.
-func (*RemoveShareResponse) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use GenerateShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*GenerateShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{75}
 }
 
-// ดูรายการคนที่ถูกแชร์ใน tree
-type ListTreeSharesRequest struct {
+func (x *GenerateShareLinkResponse) GetShareToken() string {
+	if x != nil {
+		return x.ShareToken
+	}
+	return ""
+}
+
+func (x *GenerateShareLinkResponse) GetShareUrl() string {
+	if x != nil {
+		return x.ShareUrl
+	}
+	return ""
+}
+
+func (x *GenerateShareLinkResponse) GetLink() *ShareLink {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+// ดูลิงก์ทั้งหมดของ tree (เจ้าของเท่านั้น)
+type ListShareLinksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTreeSharesRequest) Reset() {
-	*x = ListTreeSharesRequest{}
-	mi := &file_tree_v1_tree_proto_msgTypes[16]
+func (x *ListShareLinksRequest) Reset() {
+	*x = ListShareLinksRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTreeSharesRequest) String() string {
+func (x *ListShareLinksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTreeSharesRequest) ProtoMessage() {}
+func (*ListShareLinksRequest) ProtoMessage() {}
 
-func (x *ListTreeSharesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[16]
+func (x *ListShareLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -981,40 +4881,40 @@ func (x *ListTreeSharesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTreeSharesRequest.ProtoReflect.Descriptor instead.
-func (*ListTreeSharesRequest) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use ListShareLinksRequest.ProtoReflect.Descriptor instead.
+func (*ListShareLinksRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *ListTreeSharesRequest) GetTreeId() string {
+func (x *ListShareLinksRequest) GetTreeId() string {
 	if x != nil {
 		return x.TreeId
 	}
 	return ""
 }
 
-type ListTreeSharesResponse struct {
+type ListShareLinksResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Shares        []*TreeShare           `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+	Links         []*ShareLink           `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTreeSharesResponse) Reset() {
-	*x = ListTreeSharesResponse{}
-	mi := &file_tree_v1_tree_proto_msgTypes[17]
+func (x *ListShareLinksResponse) Reset() {
+	*x = ListShareLinksResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTreeSharesResponse) String() string {
+func (x *ListShareLinksResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTreeSharesResponse) ProtoMessage() {}
+func (*ListShareLinksResponse) ProtoMessage() {}
 
-func (x *ListTreeSharesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[17]
+func (x *ListShareLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1025,40 +4925,42 @@ func (x *ListTreeSharesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTreeSharesResponse.ProtoReflect.Descriptor instead.
-func (*ListTreeSharesResponse) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ListShareLinksResponse.ProtoReflect.Descriptor instead.
+func (*ListShareLinksResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *ListTreeSharesResponse) GetShares() []*TreeShare {
+func (x *ListShareLinksResponse) GetLinks() []*ShareLink {
 	if x != nil {
-		return x.Shares
+		return x.Links
 	}
 	return nil
 }
 
-// ดูรายการ tree ที่ถูกแชร์มาให้ฉัน
-type ListSharedWithMeRequest struct {
+// ยกเลิกลิงก์ (คนที่เปิดลิงก์นี้จะได้ error, คนที่เข้าร่วมไปแล้วยังอยู่)
+type RevokeShareLinkRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	LinkId        string                 `protobuf:"bytes,2,opt,name=link_id,json=linkId,proto3" json:"link_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSharedWithMeRequest) Reset() {
-	*x = ListSharedWithMeRequest{}
-	mi := &file_tree_v1_tree_proto_msgTypes[18]
+func (x *RevokeShareLinkRequest) Reset() {
+	*x = RevokeShareLinkRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSharedWithMeRequest) String() string {
+func (x *RevokeShareLinkRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSharedWithMeRequest) ProtoMessage() {}
+func (*RevokeShareLinkRequest) ProtoMessage() {}
 
-func (x *ListSharedWithMeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[18]
+func (x *RevokeShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1069,33 +4971,47 @@ func (x *ListSharedWithMeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSharedWithMeRequest.ProtoReflect.Descriptor instead.
-func (*ListSharedWithMeRequest) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use RevokeShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*RevokeShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{78}
 }
 
-type ListSharedWithMeResponse struct {
+func (x *RevokeShareLinkRequest) GetTreeId() string {
+	if x != nil {
+		return x.TreeId
+	}
+	return ""
+}
+
+func (x *RevokeShareLinkRequest) GetLinkId() string {
+	if x != nil {
+		return x.LinkId
+	}
+	return ""
+}
+
+type RevokeShareLinkResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Trees         []*Tree                `protobuf:"bytes,1,rep,name=trees,proto3" json:"trees,omitempty"`
+	Link          *ShareLink             `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSharedWithMeResponse) Reset() {
-	*x = ListSharedWithMeResponse{}
-	mi := &file_tree_v1_tree_proto_msgTypes[19]
+func (x *RevokeShareLinkResponse) Reset() {
+	*x = RevokeShareLinkResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSharedWithMeResponse) String() string {
+func (x *RevokeShareLinkResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSharedWithMeResponse) ProtoMessage() {}
+func (*RevokeShareLinkResponse) ProtoMessage() {}
 
-func (x *ListSharedWithMeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[19]
+func (x *RevokeShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1106,41 +5022,42 @@ func (x *ListSharedWithMeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSharedWithMeResponse.ProtoReflect.Descriptor instead.
-func (*ListSharedWithMeResponse) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use RevokeShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*RevokeShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{79}
 }
 
-func (x *ListSharedWithMeResponse) GetTrees() []*Tree {
+func (x *RevokeShareLinkResponse) GetLink() *ShareLink {
 	if x != nil {
-		return x.Trees
+		return x.Link
 	}
 	return nil
 }
 
-// ดู role ของ user ปัจจุบันกับ tree
-type GetMyRoleRequest struct {
+// เปลี่ยน token: ยกเลิกลิงก์เดิมแล้วสร้างลิงก์ใหม่ที่ตั้งค่าเหมือนเดิม (use_count เริ่มใหม่)
+type RotateShareLinkRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	LinkId        string                 `protobuf:"bytes,2,opt,name=link_id,json=linkId,proto3" json:"link_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMyRoleRequest) Reset() {
-	*x = GetMyRoleRequest{}
-	mi := &file_tree_v1_tree_proto_msgTypes[20]
+func (x *RotateShareLinkRequest) Reset() {
+	*x = RotateShareLinkRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMyRoleRequest) String() string {
+func (x *RotateShareLinkRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMyRoleRequest) ProtoMessage() {}
+func (*RotateShareLinkRequest) ProtoMessage() {}
 
-func (x *GetMyRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[20]
+func (x *RotateShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1151,41 +5068,47 @@ func (x *GetMyRoleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMyRoleRequest.ProtoReflect.Descriptor instead.
-func (*GetMyRoleRequest) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use RotateShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*RotateShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *GetMyRoleRequest) GetTreeId() string {
+func (x *RotateShareLinkRequest) GetTreeId() string {
 	if x != nil {
 		return x.TreeId
 	}
 	return ""
 }
 
-type GetMyRoleResponse struct {
+func (x *RotateShareLinkRequest) GetLinkId() string {
+	if x != nil {
+		return x.LinkId
+	}
+	return ""
+}
+
+type RotateShareLinkResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Role          ShareRole              `protobuf:"varint,1,opt,name=role,proto3,enum=tree.v1.ShareRole" json:"role,omitempty"`
-	IsCreator     bool                   `protobuf:"varint,2,opt,name=is_creator,json=isCreator,proto3" json:"is_creator,omitempty"`
+	Link          *ShareLink             `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMyRoleResponse) Reset() {
-	*x = GetMyRoleResponse{}
-	mi := &file_tree_v1_tree_proto_msgTypes[21]
+func (x *RotateShareLinkResponse) Reset() {
+	*x = RotateShareLinkResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMyRoleResponse) String() string {
+func (x *RotateShareLinkResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMyRoleResponse) ProtoMessage() {}
+func (*RotateShareLinkResponse) ProtoMessage() {}
 
-func (x *GetMyRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[21]
+func (x *RotateShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1196,48 +5119,41 @@ func (x *GetMyRoleResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMyRoleResponse.ProtoReflect.Descriptor instead.
-func (*GetMyRoleResponse) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{21}
-}
-
-func (x *GetMyRoleResponse) GetRole() ShareRole {
-	if x != nil {
-		return x.Role
-	}
-	return ShareRole_SHARE_ROLE_UNSPECIFIED
+// Deprecated: Use RotateShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*RotateShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{81}
 }
 
-func (x *GetMyRoleResponse) GetIsCreator() bool {
+func (x *RotateShareLinkResponse) GetLink() *ShareLink {
 	if x != nil {
-		return x.IsCreator
+		return x.Link
 	}
-	return false
+	return nil
 }
 
-// สร้างลิงก์แชร์ (ต้อง login, เจ้าของเท่านั้น)
-type GenerateShareLinkRequest struct {
+// เข้าร่วม tree ผ่านลิงก์ join (ต้อง login)
+type JoinShareLinkRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TreeId        string                 `protobuf:"bytes,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	ShareToken    string                 `protobuf:"bytes,1,opt,name=share_token,json=shareToken,proto3" json:"share_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateShareLinkRequest) Reset() {
-	*x = GenerateShareLinkRequest{}
-	mi := &file_tree_v1_tree_proto_msgTypes[22]
+func (x *JoinShareLinkRequest) Reset() {
+	*x = JoinShareLinkRequest{}
+	mi := &file_tree_v1_tree_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateShareLinkRequest) String() string {
+func (x *JoinShareLinkRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateShareLinkRequest) ProtoMessage() {}
+func (*JoinShareLinkRequest) ProtoMessage() {}
 
-func (x *GenerateShareLinkRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[22]
+func (x *JoinShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1248,41 +5164,40 @@ func (x *GenerateShareLinkRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateShareLinkRequest.ProtoReflect.Descriptor instead.
-func (*GenerateShareLinkRequest) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use JoinShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*JoinShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{82}
 }
 
-func (x *GenerateShareLinkRequest) GetTreeId() string {
+func (x *JoinShareLinkRequest) GetShareToken() string {
 	if x != nil {
-		return x.TreeId
+		return x.ShareToken
 	}
 	return ""
 }
 
-type GenerateShareLinkResponse struct {
+type JoinShareLinkResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ShareToken    string                 `protobuf:"bytes,1,opt,name=share_token,json=shareToken,proto3" json:"share_token,omitempty"`
-	ShareUrl      string                 `protobuf:"bytes,2,opt,name=share_url,json=shareUrl,proto3" json:"share_url,omitempty"`
+	Tree          *Tree                  `protobuf:"bytes,1,opt,name=tree,proto3" json:"tree,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateShareLinkResponse) Reset() {
-	*x = GenerateShareLinkResponse{}
-	mi := &file_tree_v1_tree_proto_msgTypes[23]
+func (x *JoinShareLinkResponse) Reset() {
+	*x = JoinShareLinkResponse{}
+	mi := &file_tree_v1_tree_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateShareLinkResponse) String() string {
+func (x *JoinShareLinkResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateShareLinkResponse) ProtoMessage() {}
+func (*JoinShareLinkResponse) ProtoMessage() {}
 
-func (x *GenerateShareLinkResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[23]
+func (x *JoinShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tree_v1_tree_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1293,23 +5208,16 @@ func (x *GenerateShareLinkResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateShareLinkResponse.ProtoReflect.Descriptor instead.
-func (*GenerateShareLinkResponse) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{23}
-}
-
-func (x *GenerateShareLinkResponse) GetShareToken() string {
-	if x != nil {
-		return x.ShareToken
-	}
-	return ""
+// Deprecated: Use JoinShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*JoinShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *GenerateShareLinkResponse) GetShareUrl() string {
+func (x *JoinShareLinkResponse) GetTree() *Tree {
 	if x != nil {
-		return x.ShareUrl
+		return x.Tree
 	}
-	return ""
+	return nil
 }
 
 // ดู tree ผ่าน share token (ไม่ต้อง login)
@@ -1322,7 +5230,7 @@ type GetTreeByShareTokenRequest struct {
 
 func (x *GetTreeByShareTokenRequest) Reset() {
 	*x = GetTreeByShareTokenRequest{}
-	mi := &file_tree_v1_tree_proto_msgTypes[24]
+	mi := &file_tree_v1_tree_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1334,7 +5242,7 @@ func (x *GetTreeByShareTokenRequest) String() string {
 func (*GetTreeByShareTokenRequest) ProtoMessage() {}
 
 func (x *GetTreeByShareTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[24]
+	mi := &file_tree_v1_tree_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1347,7 +5255,7 @@ func (x *GetTreeByShareTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTreeByShareTokenRequest.ProtoReflect.Descriptor instead.
 func (*GetTreeByShareTokenRequest) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{24}
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{84}
 }
 
 func (x *GetTreeByShareTokenRequest) GetShareToken() string {
@@ -1360,13 +5268,15 @@ func (x *GetTreeByShareTokenRequest) GetShareToken() string {
 type GetTreeByShareTokenResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Tree          *Tree                  `protobuf:"bytes,1,opt,name=tree,proto3" json:"tree,omitempty"`
+	LinkRole      ShareLinkRole          `protobuf:"varint,2,opt,name=link_role,json=linkRole,proto3,enum=tree.v1.ShareLinkRole" json:"link_role,omitempty"` // ลิงก์ join → client แสดงปุ่มเข้าร่วม
+	LinkExpiresAt *string                `protobuf:"bytes,3,opt,name=link_expires_at,json=linkExpiresAt,proto3,oneof" json:"link_expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetTreeByShareTokenResponse) Reset() {
 	*x = GetTreeByShareTokenResponse{}
-	mi := &file_tree_v1_tree_proto_msgTypes[25]
+	mi := &file_tree_v1_tree_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1378,7 +5288,7 @@ func (x *GetTreeByShareTokenResponse) String() string {
 func (*GetTreeByShareTokenResponse) ProtoMessage() {}
 
 func (x *GetTreeByShareTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tree_v1_tree_proto_msgTypes[25]
+	mi := &file_tree_v1_tree_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1391,7 +5301,7 @@ func (x *GetTreeByShareTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTreeByShareTokenResponse.ProtoReflect.Descriptor instead.
 func (*GetTreeByShareTokenResponse) Descriptor() ([]byte, []int) {
-	return file_tree_v1_tree_proto_rawDescGZIP(), []int{25}
+	return file_tree_v1_tree_proto_rawDescGZIP(), []int{85}
 }
 
 func (x *GetTreeByShareTokenResponse) GetTree() *Tree {
@@ -1401,11 +5311,25 @@ func (x *GetTreeByShareTokenResponse) GetTree() *Tree {
 	return nil
 }
 
+func (x *GetTreeByShareTokenResponse) GetLinkRole() ShareLinkRole {
+	if x != nil {
+		return x.LinkRole
+	}
+	return ShareLinkRole_SHARE_LINK_ROLE_UNSPECIFIED
+}
+
+func (x *GetTreeByShareTokenResponse) GetLinkExpiresAt() string {
+	if x != nil && x.LinkExpiresAt != nil {
+		return *x.LinkExpiresAt
+	}
+	return ""
+}
+
 var File_tree_v1_tree_proto protoreflect.FileDescriptor
 
 const file_tree_v1_tree_proto_rawDesc = "" +
 	"\n" +
-	"\x12tree/v1/tree.proto\x12\atree.v1\"\x90\x02\n" +
+	"\x12tree/v1/tree.proto\x12\atree.v1\"\x8c\x04\n" +
 	"\x04Tree\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -1420,7 +5344,130 @@ const file_tree_v1_tree_proto_rawDesc = "" +
 	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
 	"updated_at\x18\b \x01(\tR\tupdatedAt\x12+\n" +
-	"\amy_role\x18\t \x01(\x0e2\x12.tree.v1.ShareRoleR\x06myRole\"\xa6\x02\n" +
+	"\amy_role\x18\t \x01(\x0e2\x12.tree.v1.ShareRoleR\x06myRole\x12-\n" +
+	"\x12structure_revision\x18\n" +
+	" \x01(\x03R\x11structureRevision\x12@\n" +
+	"\x0fcontact_privacy\x18\v \x01(\v2\x17.tree.v1.ContactPrivacyR\x0econtactPrivacy\x12$\n" +
+	"\vcloned_from\x18\f \x01(\tH\x00R\n" +
+	"clonedFrom\x88\x01\x01\x12$\n" +
+	"\vtemplate_id\x18\r \x01(\tH\x01R\n" +
+	"templateId\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"node_count\x18\x0e \x01(\x05R\tnodeCountB\x0e\n" +
+	"\f_cloned_fromB\x0e\n" +
+	"\f_template_id\"\x8c\x02\n" +
+	"\x0eTreeInvitation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atree_id\x18\x02 \x01(\tR\x06treeId\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12&\n" +
+	"\x04role\x18\x04 \x01(\x0e2\x12.tree.v1.ShareRoleR\x04role\x12\x1d\n" +
+	"\n" +
+	"invited_by\x18\x05 \x01(\tR\tinvitedBy\x12\x1d\n" +
+	"\n" +
+	"send_count\x18\x06 \x01(\x05R\tsendCount\x12%\n" +
+	"\flast_sent_at\x18\a \x01(\tH\x00R\n" +
+	"lastSentAt\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAtB\x0f\n" +
+	"\r_last_sent_at\"\x99\x03\n" +
+	"\tShareLink\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atree_id\x18\x02 \x01(\tR\x06treeId\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12\x1b\n" +
+	"\tshare_url\x18\x04 \x01(\tR\bshareUrl\x12*\n" +
+	"\x04role\x18\x05 \x01(\x0e2\x16.tree.v1.ShareLinkRoleR\x04role\x12\"\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\tH\x00R\texpiresAt\x88\x01\x01\x12\x1e\n" +
+	"\bmax_uses\x18\a \x01(\x05H\x01R\amaxUses\x88\x01\x01\x12\x1b\n" +
+	"\tuse_count\x18\b \x01(\x05R\buseCount\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\t \x01(\tR\tcreatedBy\x12\"\n" +
+	"\n" +
+	"revoked_at\x18\n" +
+	" \x01(\tH\x02R\trevokedAt\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\tR\tcreatedAt\x12\x16\n" +
+	"\x06active\x18\f \x01(\bR\x06activeB\r\n" +
+	"\v_expires_atB\v\n" +
+	"\t_max_usesB\r\n" +
+	"\v_revoked_at\"\x97\x02\n" +
+	"\x0eContactPrivacy\x120\n" +
+	"\x05phone\x18\x01 \x01(\x0e2\x1a.tree.v1.ContactVisibilityR\x05phone\x120\n" +
+	"\x05email\x18\x02 \x01(\x0e2\x1a.tree.v1.ContactVisibilityR\x05email\x123\n" +
+	"\aline_id\x18\x03 \x01(\x0e2\x1a.tree.v1.ContactVisibilityR\x06lineId\x124\n" +
+	"\adiscord\x18\x04 \x01(\x0e2\x1a.tree.v1.ContactVisibilityR\adiscord\x126\n" +
+	"\bfacebook\x18\x05 \x01(\x0e2\x1a.tree.v1.ContactVisibilityR\bfacebook\"\xcf\x02\n" +
+	"\x11OwnershipTransfer\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atree_id\x18\x02 \x01(\tR\x06treeId\x12 \n" +
+	"\ffrom_user_id\x18\x03 \x01(\tR\n" +
+	"fromUserId\x12\x1c\n" +
+	"\n" +
+	"to_user_id\x18\x04 \x01(\tR\btoUserId\x12B\n" +
+	"\x13previous_owner_role\x18\x05 \x01(\x0e2\x12.tree.v1.ShareRoleR\x11previousOwnerRole\x128\n" +
+	"\x06status\x18\x06 \x01(\x0e2 .tree.v1.OwnershipTransferStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12$\n" +
+	"\vresolved_at\x18\b \x01(\tH\x00R\n" +
+	"resolvedAt\x88\x01\x01B\x0e\n" +
+	"\f_resolved_at\"\x9a\x03\n" +
+	"\x0eAuditNodeState\x12\x1a\n" +
+	"\bnickname\x18\x01 \x01(\tR\bnickname\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\x12\x1d\n" +
+	"\n" +
+	"student_id\x18\x04 \x01(\tR\tstudentId\x12\x1b\n" +
+	"\tphoto_url\x18\x05 \x01(\tR\bphotoUrl\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1e\n" +
+	"\n" +
+	"generation\x18\a \x01(\x05R\n" +
+	"generation\x12\x1d\n" +
+	"\n" +
+	"position_x\x18\b \x01(\x01R\tpositionX\x12\x1d\n" +
+	"\n" +
+	"position_y\x18\t \x01(\x01R\tpositionY\x12A\n" +
+	"\bmetadata\x18\n" +
+	" \x03(\v2%.tree.v1.AuditNodeState.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xfd\x01\n" +
+	"\rAuditSnapshot\x120\n" +
+	"\x04node\x18\x01 \x01(\v2\x17.tree.v1.AuditNodeStateH\x00R\x04node\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"parent_ids\x18\x02 \x03(\tR\tparentIds\x12\x1b\n" +
+	"\tchild_ids\x18\x03 \x03(\tR\bchildIds\x12:\n" +
+	"\x06fields\x18\x04 \x03(\v2\".tree.v1.AuditSnapshot.FieldsEntryR\x06fields\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\a\n" +
+	"\x05_node\"\x9d\x02\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\atree_id\x18\x02 \x01(\tR\x06treeId\x12\x19\n" +
+	"\bactor_id\x18\x03 \x01(\tR\aactorId\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x17\n" +
+	"\anode_id\x18\x05 \x01(\tR\x06nodeId\x12\x1d\n" +
+	"\n" +
+	"target_ids\x18\x06 \x03(\tR\ttargetIds\x12.\n" +
+	"\x06before\x18\a \x01(\v2\x16.tree.v1.AuditSnapshotR\x06before\x12,\n" +
+	"\x05after\x18\b \x01(\v2\x16.tree.v1.AuditSnapshotR\x05after\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\"\xf7\x01\n" +
+	"\fTreeTemplate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\afaculty\x18\x04 \x01(\tR\afaculty\x12\x1e\n" +
+	"\n" +
+	"department\x18\x05 \x01(\tR\n" +
+	"department\x12\x1d\n" +
+	"\n" +
+	"node_count\x18\x06 \x01(\x05R\tnodeCount\x12)\n" +
+	"\x10generation_count\x18\a \x01(\x05R\x0fgenerationCount\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\"\xa6\x02\n" +
 	"\tTreeShare\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\atree_id\x18\x02 \x01(\tR\x06treeId\x12\x17\n" +
@@ -1433,32 +5480,84 @@ const file_tree_v1_tree_proto_rawDesc = "" +
 	"\n" +
 	"invited_by\x18\b \x01(\tR\tinvitedBy\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\t \x01(\tR\tcreatedAt\"\x83\x01\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\"\xe2\x01\n" +
 	"\x11CreateTreeRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x18\n" +
 	"\afaculty\x18\x03 \x01(\tR\afaculty\x12\x1e\n" +
 	"\n" +
 	"department\x18\x04 \x01(\tR\n" +
-	"department\"7\n" +
+	"department\x12$\n" +
+	"\vtemplate_id\x18\x05 \x01(\tH\x00R\n" +
+	"templateId\x88\x01\x01\x12'\n" +
+	"\x0fbase_generation\x18\x06 \x01(\x05R\x0ebaseGenerationB\x0e\n" +
+	"\f_template_id\"V\n" +
 	"\x12CreateTreeResponse\x12!\n" +
-	"\x04tree\x18\x01 \x01(\v2\r.tree.v1.TreeR\x04tree\" \n" +
+	"\x04tree\x18\x01 \x01(\v2\r.tree.v1.TreeR\x04tree\x12\x1d\n" +
+	"\n" +
+	"node_count\x18\x02 \x01(\x05R\tnodeCount\" \n" +
 	"\x0eGetTreeRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"4\n" +
 	"\x0fGetTreeResponse\x12!\n" +
-	"\x04tree\x18\x01 \x01(\v2\r.tree.v1.TreeR\x04tree\"\x14\n" +
-	"\x12ListMyTreesRequest\":\n" +
+	"\x04tree\x18\x01 \x01(\v2\r.tree.v1.TreeR\x04tree\"\xa1\x02\n" +
+	"\x0fTreeListOptions\x12\x1d\n" +
+	"\afaculty\x18\x01 \x01(\tH\x00R\afaculty\x88\x01\x01\x12#\n" +
+	"\n" +
+	"department\x18\x02 \x01(\tH\x01R\n" +
+	"department\x88\x01\x01\x12(\n" +
+	"\x05roles\x18\x03 \x03(\x0e2\x12.tree.v1.ShareRoleR\x05roles\x12/\n" +
+	"\asort_by\x18\x04 \x01(\x0e2\x16.tree.v1.TreeSortFieldR\x06sortBy\x12\x18\n" +
+	"\areverse\x18\x05 \x01(\bR\areverse\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageTokenB\n" +
+	"\n" +
+	"\b_facultyB\r\n" +
+	"\v_department\"H\n" +
+	"\x12ListMyTreesRequest\x122\n" +
+	"\aoptions\x18\x01 \x01(\v2\x18.tree.v1.TreeListOptionsR\aoptions\"b\n" +
 	"\x13ListMyTreesResponse\x12#\n" +
-	"\x05trees\x18\x01 \x03(\v2\r.tree.v1.TreeR\x05trees\"#\n" +
+	"\x05trees\x18\x01 \x03(\v2\r.tree.v1.TreeR\x05trees\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"#\n" +
 	"\x11DeleteTreeRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\x14\n" +
-	"\x12DeleteTreeResponse\"i\n" +
+	"\x12DeleteTreeResponse\"x\n" +
+	"\x1bUpdateContactPrivacyRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12@\n" +
+	"\x0fcontact_privacy\x18\x02 \x01(\v2\x17.tree.v1.ContactPrivacyR\x0econtactPrivacy\"A\n" +
+	"\x1cUpdateContactPrivacyResponse\x12!\n" +
+	"\x04tree\x18\x01 \x01(\v2\r.tree.v1.TreeR\x04tree\"j\n" +
+	"\x10CloneTreeRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12)\n" +
+	"\x10include_contacts\x18\x03 \x01(\bR\x0fincludeContacts\"U\n" +
+	"\x11CloneTreeResponse\x12!\n" +
+	"\x04tree\x18\x01 \x01(\v2\r.tree.v1.TreeR\x04tree\x12\x1d\n" +
+	"\n" +
+	"node_count\x18\x02 \x01(\x05R\tnodeCount\"\x89\x01\n" +
+	"\x19SaveTreeAsTemplateRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"keep_names\x18\x04 \x01(\bR\tkeepNames\"O\n" +
+	"\x1aSaveTreeAsTemplateResponse\x121\n" +
+	"\btemplate\x18\x01 \x01(\v2\x15.tree.v1.TreeTemplateR\btemplate\"\x1a\n" +
+	"\x18ListTreeTemplatesRequest\"P\n" +
+	"\x19ListTreeTemplatesResponse\x123\n" +
+	"\ttemplates\x18\x01 \x03(\v2\x15.tree.v1.TreeTemplateR\ttemplates\"+\n" +
+	"\x19DeleteTreeTemplateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1c\n" +
+	"\x1aDeleteTreeTemplateResponse\"i\n" +
 	"\x10ShareTreeRequest\x12\x17\n" +
 	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12&\n" +
-	"\x04role\x18\x03 \x01(\x0e2\x12.tree.v1.ShareRoleR\x04role\"=\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x12.tree.v1.ShareRoleR\x04role\"v\n" +
 	"\x11ShareTreeResponse\x12(\n" +
-	"\x05share\x18\x01 \x01(\v2\x12.tree.v1.TreeShareR\x05share\"n\n" +
+	"\x05share\x18\x01 \x01(\v2\x12.tree.v1.TreeShareR\x05share\x127\n" +
+	"\n" +
+	"invitation\x18\x02 \x01(\v2\x17.tree.v1.TreeInvitationR\n" +
+	"invitation\"n\n" +
 	"\x12UpdateShareRequest\x12\x17\n" +
 	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12&\n" +
@@ -1472,47 +5571,253 @@ const file_tree_v1_tree_proto_rawDesc = "" +
 	"\x15ListTreeSharesRequest\x12\x17\n" +
 	"\atree_id\x18\x01 \x01(\tR\x06treeId\"D\n" +
 	"\x16ListTreeSharesResponse\x12*\n" +
-	"\x06shares\x18\x01 \x03(\v2\x12.tree.v1.TreeShareR\x06shares\"\x19\n" +
-	"\x17ListSharedWithMeRequest\"?\n" +
+	"\x06shares\x18\x01 \x03(\v2\x12.tree.v1.TreeShareR\x06shares\"5\n" +
+	"\x1aListTreeInvitationsRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\"X\n" +
+	"\x1bListTreeInvitationsResponse\x129\n" +
+	"\vinvitations\x18\x01 \x03(\v2\x17.tree.v1.TreeInvitationR\vinvitations\"[\n" +
+	"\x1bResendTreeInvitationRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12#\n" +
+	"\rinvitation_id\x18\x02 \x01(\tR\finvitationId\"W\n" +
+	"\x1cResendTreeInvitationResponse\x127\n" +
+	"\n" +
+	"invitation\x18\x01 \x01(\v2\x17.tree.v1.TreeInvitationR\n" +
+	"invitation\"[\n" +
+	"\x1bCancelTreeInvitationRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12#\n" +
+	"\rinvitation_id\x18\x02 \x01(\tR\finvitationId\"\x1e\n" +
+	"\x1cCancelTreeInvitationResponse\"M\n" +
+	"\x17ListSharedWithMeRequest\x122\n" +
+	"\aoptions\x18\x01 \x01(\v2\x18.tree.v1.TreeListOptionsR\aoptions\"g\n" +
 	"\x18ListSharedWithMeResponse\x12#\n" +
-	"\x05trees\x18\x01 \x03(\v2\r.tree.v1.TreeR\x05trees\"+\n" +
+	"\x05trees\x18\x01 \x03(\v2\r.tree.v1.TreeR\x05trees\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"P\n" +
+	"\x1aListAccessibleTreesRequest\x122\n" +
+	"\aoptions\x18\x01 \x01(\v2\x18.tree.v1.TreeListOptionsR\aoptions\"j\n" +
+	"\x1bListAccessibleTreesResponse\x12#\n" +
+	"\x05trees\x18\x01 \x03(\v2\r.tree.v1.TreeR\x05trees\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"+\n" +
 	"\x10GetMyRoleRequest\x12\x17\n" +
 	"\atree_id\x18\x01 \x01(\tR\x06treeId\"Z\n" +
 	"\x11GetMyRoleResponse\x12&\n" +
 	"\x04role\x18\x01 \x01(\x0e2\x12.tree.v1.ShareRoleR\x04role\x12\x1d\n" +
 	"\n" +
-	"is_creator\x18\x02 \x01(\bR\tisCreator\"3\n" +
+	"is_creator\x18\x02 \x01(\bR\tisCreator\"\x95\x01\n" +
+	"\x18TransferOwnershipRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12\x1c\n" +
+	"\n" +
+	"to_user_id\x18\x02 \x01(\tR\btoUserId\x12B\n" +
+	"\x13previous_owner_role\x18\x03 \x01(\x0e2\x12.tree.v1.ShareRoleR\x11previousOwnerRole\"S\n" +
+	"\x19TransferOwnershipResponse\x126\n" +
+	"\btransfer\x18\x01 \x01(\v2\x1a.tree.v1.OwnershipTransferR\btransfer\"Z\n" +
+	"\x1fRespondOwnershipTransferRequest\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\tR\n" +
+	"transferId\x12\x16\n" +
+	"\x06accept\x18\x02 \x01(\bR\x06accept\"}\n" +
+	" RespondOwnershipTransferResponse\x126\n" +
+	"\btransfer\x18\x01 \x01(\v2\x1a.tree.v1.OwnershipTransferR\btransfer\x12!\n" +
+	"\x04tree\x18\x02 \x01(\v2\r.tree.v1.TreeR\x04tree\"A\n" +
+	"\x1eCancelOwnershipTransferRequest\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\tR\n" +
+	"transferId\"Y\n" +
+	"\x1fCancelOwnershipTransferResponse\x126\n" +
+	"\btransfer\x18\x01 \x01(\v2\x1a.tree.v1.OwnershipTransferR\btransfer\"8\n" +
+	"\x1dListOwnershipTransfersRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\"Z\n" +
+	"\x1eListOwnershipTransfersResponse\x128\n" +
+	"\ttransfers\x18\x01 \x03(\v2\x1a.tree.v1.OwnershipTransferR\ttransfers\"'\n" +
+	"%ListIncomingOwnershipTransfersRequest\"b\n" +
+	"&ListIncomingOwnershipTransfersResponse\x128\n" +
+	"\ttransfers\x18\x01 \x03(\v2\x1a.tree.v1.OwnershipTransferR\ttransfers\"G\n" +
+	"\x0fGenerationCount\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x01 \x01(\x05R\n" +
+	"generation\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"b\n" +
+	"\fStatusCounts\x12\x1a\n" +
+	"\bstudying\x18\x01 \x01(\x05R\bstudying\x12\x1c\n" +
+	"\tgraduated\x18\x02 \x01(\x05R\tgraduated\x12\x18\n" +
+	"\aretired\x18\x03 \x01(\x05R\aretired\"N\n" +
+	"\n" +
+	"TrendPoint\x12\x14\n" +
+	"\x05month\x18\x01 \x01(\tR\x05month\x12\x14\n" +
+	"\x05added\x18\x02 \x01(\x05R\x05added\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\"\x86\x04\n" +
+	"\tTreeStats\x12!\n" +
+	"\fmember_count\x18\x01 \x01(\x05R\vmemberCount\x12:\n" +
+	"\vgenerations\x18\x02 \x03(\v2\x18.tree.v1.GenerationCountR\vgenerations\x121\n" +
+	"\bstatuses\x18\x03 \x01(\v2\x15.tree.v1.StatusCountsR\bstatuses\x12!\n" +
+	"\fsenior_count\x18\x04 \x01(\x05R\vseniorCount\x125\n" +
+	"\x17avg_children_per_senior\x18\x05 \x01(\x01R\x14avgChildrenPerSenior\x12!\n" +
+	"\fmax_children\x18\x06 \x01(\x05R\vmaxChildren\x12'\n" +
+	"\x0fdeepest_lineage\x18\a \x01(\x05R\x0edeepestLineage\x12!\n" +
+	"\fdeepest_path\x18\b \x03(\tR\vdeepestPath\x12\x1d\n" +
+	"\n" +
+	"root_count\x18\t \x01(\x05R\trootCount\x12&\n" +
+	"\x0forphan_root_ids\x18\n" +
+	" \x03(\tR\rorphanRootIds\x12,\n" +
+	"\x12multi_parent_count\x18\v \x01(\x05R\x10multiParentCount\x12)\n" +
+	"\x05trend\x18\f \x03(\v2\x13.tree.v1.TrendPointR\x05trend\".\n" +
+	"\x13GetTreeStatsRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\"@\n" +
+	"\x14GetTreeStatsResponse\x12(\n" +
+	"\x05stats\x18\x01 \x01(\v2\x12.tree.v1.TreeStatsR\x05stats\"\xa6\x01\n" +
+	"\x0fDepartmentStats\x12\x1e\n" +
+	"\n" +
+	"department\x18\x01 \x01(\tR\n" +
+	"department\x12\x1d\n" +
+	"\n" +
+	"tree_count\x18\x02 \x01(\x05R\ttreeCount\x12!\n" +
+	"\fmember_count\x18\x03 \x01(\x05R\vmemberCount\x121\n" +
+	"\bstatuses\x18\x04 \x01(\v2\x15.tree.v1.StatusCountsR\bstatuses\"\xd9\x01\n" +
+	"\fFacultyStats\x12\x18\n" +
+	"\afaculty\x18\x01 \x01(\tR\afaculty\x12\x1d\n" +
+	"\n" +
+	"tree_count\x18\x02 \x01(\x05R\ttreeCount\x12!\n" +
+	"\fmember_count\x18\x03 \x01(\x05R\vmemberCount\x121\n" +
+	"\bstatuses\x18\x04 \x01(\v2\x15.tree.v1.StatusCountsR\bstatuses\x12:\n" +
+	"\vdepartments\x18\x05 \x03(\v2\x18.tree.v1.DepartmentStatsR\vdepartments\"C\n" +
+	"\x16GetFacultyStatsRequest\x12\x1d\n" +
+	"\afaculty\x18\x01 \x01(\tH\x00R\afaculty\x88\x01\x01B\n" +
+	"\n" +
+	"\b_faculty\"N\n" +
+	"\x17GetFacultyStatsResponse\x123\n" +
+	"\tfaculties\x18\x01 \x03(\v2\x15.tree.v1.FacultyStatsR\tfaculties\"\x8f\x01\n" +
+	"\x0eStructureIssue\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x17\n" +
+	"\anode_id\x18\x02 \x01(\tR\x06nodeId\x12 \n" +
+	"\tparent_id\x18\x03 \x01(\tH\x00R\bparentId\x88\x01\x01\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescriptionB\f\n" +
+	"\n" +
+	"_parent_id\"N\n" +
+	"\x1aRepairTreeStructureRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\"\x99\x01\n" +
+	"\x1bRepairTreeStructureResponse\x12/\n" +
+	"\x06issues\x18\x01 \x03(\v2\x17.tree.v1.StructureIssueR\x06issues\x12\x1a\n" +
+	"\brepaired\x18\x02 \x01(\bR\brepaired\x12-\n" +
+	"\x12structure_revision\x18\x03 \x01(\x03R\x11structureRevision\"\x8e\x02\n" +
+	"\x16ListAuditEventsRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12\x1c\n" +
+	"\anode_id\x18\x02 \x01(\tH\x00R\x06nodeId\x88\x01\x01\x12\x1e\n" +
+	"\bactor_id\x18\x03 \x01(\tH\x01R\aactorId\x88\x01\x01\x12\x19\n" +
+	"\x05since\x18\x04 \x01(\tH\x02R\x05since\x88\x01\x01\x12\x19\n" +
+	"\x05until\x18\x05 \x01(\tH\x03R\x05until\x88\x01\x01\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageTokenB\n" +
+	"\n" +
+	"\b_node_idB\v\n" +
+	"\t_actor_idB\b\n" +
+	"\x06_sinceB\b\n" +
+	"\x06_until\"n\n" +
+	"\x17ListAuditEventsResponse\x12+\n" +
+	"\x06events\x18\x01 \x03(\v2\x13.tree.v1.AuditEventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xbf\x01\n" +
 	"\x18GenerateShareLinkRequest\x12\x17\n" +
-	"\atree_id\x18\x01 \x01(\tR\x06treeId\"Y\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12*\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x16.tree.v1.ShareLinkRoleR\x04role\x12\"\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\tH\x00R\texpiresAt\x88\x01\x01\x12\x1e\n" +
+	"\bmax_uses\x18\x04 \x01(\x05H\x01R\amaxUses\x88\x01\x01B\r\n" +
+	"\v_expires_atB\v\n" +
+	"\t_max_uses\"\x81\x01\n" +
 	"\x19GenerateShareLinkResponse\x12\x1f\n" +
 	"\vshare_token\x18\x01 \x01(\tR\n" +
 	"shareToken\x12\x1b\n" +
-	"\tshare_url\x18\x02 \x01(\tR\bshareUrl\"=\n" +
+	"\tshare_url\x18\x02 \x01(\tR\bshareUrl\x12&\n" +
+	"\x04link\x18\x03 \x01(\v2\x12.tree.v1.ShareLinkR\x04link\"0\n" +
+	"\x15ListShareLinksRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\"B\n" +
+	"\x16ListShareLinksResponse\x12(\n" +
+	"\x05links\x18\x01 \x03(\v2\x12.tree.v1.ShareLinkR\x05links\"J\n" +
+	"\x16RevokeShareLinkRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12\x17\n" +
+	"\alink_id\x18\x02 \x01(\tR\x06linkId\"A\n" +
+	"\x17RevokeShareLinkResponse\x12&\n" +
+	"\x04link\x18\x01 \x01(\v2\x12.tree.v1.ShareLinkR\x04link\"J\n" +
+	"\x16RotateShareLinkRequest\x12\x17\n" +
+	"\atree_id\x18\x01 \x01(\tR\x06treeId\x12\x17\n" +
+	"\alink_id\x18\x02 \x01(\tR\x06linkId\"A\n" +
+	"\x17RotateShareLinkResponse\x12&\n" +
+	"\x04link\x18\x01 \x01(\v2\x12.tree.v1.ShareLinkR\x04link\"7\n" +
+	"\x14JoinShareLinkRequest\x12\x1f\n" +
+	"\vshare_token\x18\x01 \x01(\tR\n" +
+	"shareToken\":\n" +
+	"\x15JoinShareLinkResponse\x12!\n" +
+	"\x04tree\x18\x01 \x01(\v2\r.tree.v1.TreeR\x04tree\"=\n" +
 	"\x1aGetTreeByShareTokenRequest\x12\x1f\n" +
 	"\vshare_token\x18\x01 \x01(\tR\n" +
-	"shareToken\"@\n" +
+	"shareToken\"\xb6\x01\n" +
 	"\x1bGetTreeByShareTokenResponse\x12!\n" +
-	"\x04tree\x18\x01 \x01(\v2\r.tree.v1.TreeR\x04tree*k\n" +
+	"\x04tree\x18\x01 \x01(\v2\r.tree.v1.TreeR\x04tree\x123\n" +
+	"\tlink_role\x18\x02 \x01(\x0e2\x16.tree.v1.ShareLinkRoleR\blinkRole\x12+\n" +
+	"\x0flink_expires_at\x18\x03 \x01(\tH\x00R\rlinkExpiresAt\x88\x01\x01B\x12\n" +
+	"\x10_link_expires_at*k\n" +
 	"\tShareRole\x12\x1a\n" +
 	"\x16SHARE_ROLE_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11SHARE_ROLE_VIEWER\x10\x01\x12\x15\n" +
 	"\x11SHARE_ROLE_EDITOR\x10\x02\x12\x14\n" +
-	"\x10SHARE_ROLE_OWNER\x10\x032\xa9\a\n" +
+	"\x10SHARE_ROLE_OWNER\x10\x03*\x8c\x01\n" +
+	"\rShareLinkRole\x12\x1f\n" +
+	"\x1bSHARE_LINK_ROLE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14SHARE_LINK_ROLE_VIEW\x10\x01\x12\x1f\n" +
+	"\x1bSHARE_LINK_ROLE_JOIN_VIEWER\x10\x02\x12\x1f\n" +
+	"\x1bSHARE_LINK_ROLE_JOIN_EDITOR\x10\x03*\x96\x01\n" +
+	"\x11ContactVisibility\x12\"\n" +
+	"\x1eCONTACT_VISIBILITY_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19CONTACT_VISIBILITY_PUBLIC\x10\x01\x12\x1e\n" +
+	"\x1aCONTACT_VISIBILITY_MEMBERS\x10\x02\x12\x1e\n" +
+	"\x1aCONTACT_VISIBILITY_EDITORS\x10\x03*\xe4\x01\n" +
+	"\x17OwnershipTransferStatus\x12)\n" +
+	"%OWNERSHIP_TRANSFER_STATUS_UNSPECIFIED\x10\x00\x12%\n" +
+	"!OWNERSHIP_TRANSFER_STATUS_PENDING\x10\x01\x12&\n" +
+	"\"OWNERSHIP_TRANSFER_STATUS_ACCEPTED\x10\x02\x12&\n" +
+	"\"OWNERSHIP_TRANSFER_STATUS_DECLINED\x10\x03\x12'\n" +
+	"#OWNERSHIP_TRANSFER_STATUS_CANCELLED\x10\x04*\xaa\x01\n" +
+	"\rTreeSortField\x12\x1f\n" +
+	"\x1bTREE_SORT_FIELD_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aTREE_SORT_FIELD_UPDATED_AT\x10\x01\x12\x1e\n" +
+	"\x1aTREE_SORT_FIELD_CREATED_AT\x10\x02\x12\x18\n" +
+	"\x14TREE_SORT_FIELD_NAME\x10\x03\x12\x1e\n" +
+	"\x1aTREE_SORT_FIELD_NODE_COUNT\x10\x042\xce\x17\n" +
 	"\vTreeService\x12E\n" +
 	"\n" +
 	"CreateTree\x12\x1a.tree.v1.CreateTreeRequest\x1a\x1b.tree.v1.CreateTreeResponse\x12<\n" +
 	"\aGetTree\x12\x17.tree.v1.GetTreeRequest\x1a\x18.tree.v1.GetTreeResponse\x12H\n" +
 	"\vListMyTrees\x12\x1b.tree.v1.ListMyTreesRequest\x1a\x1c.tree.v1.ListMyTreesResponse\x12E\n" +
 	"\n" +
-	"DeleteTree\x12\x1a.tree.v1.DeleteTreeRequest\x1a\x1b.tree.v1.DeleteTreeResponse\x12B\n" +
+	"DeleteTree\x12\x1a.tree.v1.DeleteTreeRequest\x1a\x1b.tree.v1.DeleteTreeResponse\x12c\n" +
+	"\x14UpdateContactPrivacy\x12$.tree.v1.UpdateContactPrivacyRequest\x1a%.tree.v1.UpdateContactPrivacyResponse\x12B\n" +
+	"\tCloneTree\x12\x19.tree.v1.CloneTreeRequest\x1a\x1a.tree.v1.CloneTreeResponse\x12]\n" +
+	"\x12SaveTreeAsTemplate\x12\".tree.v1.SaveTreeAsTemplateRequest\x1a#.tree.v1.SaveTreeAsTemplateResponse\x12Z\n" +
+	"\x11ListTreeTemplates\x12!.tree.v1.ListTreeTemplatesRequest\x1a\".tree.v1.ListTreeTemplatesResponse\x12]\n" +
+	"\x12DeleteTreeTemplate\x12\".tree.v1.DeleteTreeTemplateRequest\x1a#.tree.v1.DeleteTreeTemplateResponse\x12B\n" +
 	"\tShareTree\x12\x19.tree.v1.ShareTreeRequest\x1a\x1a.tree.v1.ShareTreeResponse\x12H\n" +
 	"\vUpdateShare\x12\x1b.tree.v1.UpdateShareRequest\x1a\x1c.tree.v1.UpdateShareResponse\x12H\n" +
 	"\vRemoveShare\x12\x1b.tree.v1.RemoveShareRequest\x1a\x1c.tree.v1.RemoveShareResponse\x12Q\n" +
 	"\x0eListTreeShares\x12\x1e.tree.v1.ListTreeSharesRequest\x1a\x1f.tree.v1.ListTreeSharesResponse\x12W\n" +
-	"\x10ListSharedWithMe\x12 .tree.v1.ListSharedWithMeRequest\x1a!.tree.v1.ListSharedWithMeResponse\x12B\n" +
-	"\tGetMyRole\x12\x19.tree.v1.GetMyRoleRequest\x1a\x1a.tree.v1.GetMyRoleResponse\x12Z\n" +
+	"\x10ListSharedWithMe\x12 .tree.v1.ListSharedWithMeRequest\x1a!.tree.v1.ListSharedWithMeResponse\x12`\n" +
+	"\x13ListAccessibleTrees\x12#.tree.v1.ListAccessibleTreesRequest\x1a$.tree.v1.ListAccessibleTreesResponse\x12B\n" +
+	"\tGetMyRole\x12\x19.tree.v1.GetMyRoleRequest\x1a\x1a.tree.v1.GetMyRoleResponse\x12`\n" +
+	"\x13ListTreeInvitations\x12#.tree.v1.ListTreeInvitationsRequest\x1a$.tree.v1.ListTreeInvitationsResponse\x12c\n" +
+	"\x14ResendTreeInvitation\x12$.tree.v1.ResendTreeInvitationRequest\x1a%.tree.v1.ResendTreeInvitationResponse\x12c\n" +
+	"\x14CancelTreeInvitation\x12$.tree.v1.CancelTreeInvitationRequest\x1a%.tree.v1.CancelTreeInvitationResponse\x12Z\n" +
+	"\x11TransferOwnership\x12!.tree.v1.TransferOwnershipRequest\x1a\".tree.v1.TransferOwnershipResponse\x12o\n" +
+	"\x18RespondOwnershipTransfer\x12(.tree.v1.RespondOwnershipTransferRequest\x1a).tree.v1.RespondOwnershipTransferResponse\x12l\n" +
+	"\x17CancelOwnershipTransfer\x12'.tree.v1.CancelOwnershipTransferRequest\x1a(.tree.v1.CancelOwnershipTransferResponse\x12i\n" +
+	"\x16ListOwnershipTransfers\x12&.tree.v1.ListOwnershipTransfersRequest\x1a'.tree.v1.ListOwnershipTransfersResponse\x12\x81\x01\n" +
+	"\x1eListIncomingOwnershipTransfers\x12..tree.v1.ListIncomingOwnershipTransfersRequest\x1a/.tree.v1.ListIncomingOwnershipTransfersResponse\x12K\n" +
+	"\fGetTreeStats\x12\x1c.tree.v1.GetTreeStatsRequest\x1a\x1d.tree.v1.GetTreeStatsResponse\x12T\n" +
+	"\x0fGetFacultyStats\x12\x1f.tree.v1.GetFacultyStatsRequest\x1a .tree.v1.GetFacultyStatsResponse\x12`\n" +
+	"\x13RepairTreeStructure\x12#.tree.v1.RepairTreeStructureRequest\x1a$.tree.v1.RepairTreeStructureResponse\x12T\n" +
+	"\x0fListAuditEvents\x12\x1f.tree.v1.ListAuditEventsRequest\x1a .tree.v1.ListAuditEventsResponse\x12Z\n" +
 	"\x11GenerateShareLink\x12!.tree.v1.GenerateShareLinkRequest\x1a\".tree.v1.GenerateShareLinkResponse\x12`\n" +
-	"\x13GetTreeByShareToken\x12#.tree.v1.GetTreeByShareTokenRequest\x1a$.tree.v1.GetTreeByShareTokenResponseB>Z<github.com/TitleKung-01/code-tree-backend/gen/tree/v1;treev1b\x06proto3"
+	"\x13GetTreeByShareToken\x12#.tree.v1.GetTreeByShareTokenRequest\x1a$.tree.v1.GetTreeByShareTokenResponse\x12Q\n" +
+	"\x0eListShareLinks\x12\x1e.tree.v1.ListShareLinksRequest\x1a\x1f.tree.v1.ListShareLinksResponse\x12T\n" +
+	"\x0fRevokeShareLink\x12\x1f.tree.v1.RevokeShareLinkRequest\x1a .tree.v1.RevokeShareLinkResponse\x12T\n" +
+	"\x0fRotateShareLink\x12\x1f.tree.v1.RotateShareLinkRequest\x1a .tree.v1.RotateShareLinkResponse\x12N\n" +
+	"\rJoinShareLink\x12\x1d.tree.v1.JoinShareLinkRequest\x1a\x1e.tree.v1.JoinShareLinkResponseB>Z<github.com/TitleKung-01/code-tree-backend/gen/tree/v1;treev1b\x06proto3"
 
 var (
 	file_tree_v1_tree_proto_rawDescOnce sync.Once
@@ -1526,80 +5831,243 @@ func file_tree_v1_tree_proto_rawDescGZIP() []byte {
 	return file_tree_v1_tree_proto_rawDescData
 }
 
-var file_tree_v1_tree_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_tree_v1_tree_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_tree_v1_tree_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_tree_v1_tree_proto_msgTypes = make([]protoimpl.MessageInfo, 88)
 var file_tree_v1_tree_proto_goTypes = []any{
-	(ShareRole)(0),                      // 0: tree.v1.ShareRole
-	(*Tree)(nil),                        // 1: tree.v1.Tree
-	(*TreeShare)(nil),                   // 2: tree.v1.TreeShare
-	(*CreateTreeRequest)(nil),           // 3: tree.v1.CreateTreeRequest
-	(*CreateTreeResponse)(nil),          // 4: tree.v1.CreateTreeResponse
-	(*GetTreeRequest)(nil),              // 5: tree.v1.GetTreeRequest
-	(*GetTreeResponse)(nil),             // 6: tree.v1.GetTreeResponse
-	(*ListMyTreesRequest)(nil),          // 7: tree.v1.ListMyTreesRequest
-	(*ListMyTreesResponse)(nil),         // 8: tree.v1.ListMyTreesResponse
-	(*DeleteTreeRequest)(nil),           // 9: tree.v1.DeleteTreeRequest
-	(*DeleteTreeResponse)(nil),          // 10: tree.v1.DeleteTreeResponse
-	(*ShareTreeRequest)(nil),            // 11: tree.v1.ShareTreeRequest
-	(*ShareTreeResponse)(nil),           // 12: tree.v1.ShareTreeResponse
-	(*UpdateShareRequest)(nil),          // 13: tree.v1.UpdateShareRequest
-	(*UpdateShareResponse)(nil),         // 14: tree.v1.UpdateShareResponse
-	(*RemoveShareRequest)(nil),          // 15: tree.v1.RemoveShareRequest
-	(*RemoveShareResponse)(nil),         // 16: tree.v1.RemoveShareResponse
-	(*ListTreeSharesRequest)(nil),       // 17: tree.v1.ListTreeSharesRequest
-	(*ListTreeSharesResponse)(nil),      // 18: tree.v1.ListTreeSharesResponse
-	(*ListSharedWithMeRequest)(nil),     // 19: tree.v1.ListSharedWithMeRequest
-	(*ListSharedWithMeResponse)(nil),    // 20: tree.v1.ListSharedWithMeResponse
-	(*GetMyRoleRequest)(nil),            // 21: tree.v1.GetMyRoleRequest
-	(*GetMyRoleResponse)(nil),           // 22: tree.v1.GetMyRoleResponse
-	(*GenerateShareLinkRequest)(nil),    // 23: tree.v1.GenerateShareLinkRequest
-	(*GenerateShareLinkResponse)(nil),   // 24: tree.v1.GenerateShareLinkResponse
-	(*GetTreeByShareTokenRequest)(nil),  // 25: tree.v1.GetTreeByShareTokenRequest
-	(*GetTreeByShareTokenResponse)(nil), // 26: tree.v1.GetTreeByShareTokenResponse
+	(ShareRole)(0),                                 // 0: tree.v1.ShareRole
+	(ShareLinkRole)(0),                             // 1: tree.v1.ShareLinkRole
+	(ContactVisibility)(0),                         // 2: tree.v1.ContactVisibility
+	(OwnershipTransferStatus)(0),                   // 3: tree.v1.OwnershipTransferStatus
+	(TreeSortField)(0),                             // 4: tree.v1.TreeSortField
+	(*Tree)(nil),                                   // 5: tree.v1.Tree
+	(*TreeInvitation)(nil),                         // 6: tree.v1.TreeInvitation
+	(*ShareLink)(nil),                              // 7: tree.v1.ShareLink
+	(*ContactPrivacy)(nil),                         // 8: tree.v1.ContactPrivacy
+	(*OwnershipTransfer)(nil),                      // 9: tree.v1.OwnershipTransfer
+	(*AuditNodeState)(nil),                         // 10: tree.v1.AuditNodeState
+	(*AuditSnapshot)(nil),                          // 11: tree.v1.AuditSnapshot
+	(*AuditEvent)(nil),                             // 12: tree.v1.AuditEvent
+	(*TreeTemplate)(nil),                           // 13: tree.v1.TreeTemplate
+	(*TreeShare)(nil),                              // 14: tree.v1.TreeShare
+	(*CreateTreeRequest)(nil),                      // 15: tree.v1.CreateTreeRequest
+	(*CreateTreeResponse)(nil),                     // 16: tree.v1.CreateTreeResponse
+	(*GetTreeRequest)(nil),                         // 17: tree.v1.GetTreeRequest
+	(*GetTreeResponse)(nil),                        // 18: tree.v1.GetTreeResponse
+	(*TreeListOptions)(nil),                        // 19: tree.v1.TreeListOptions
+	(*ListMyTreesRequest)(nil),                     // 20: tree.v1.ListMyTreesRequest
+	(*ListMyTreesResponse)(nil),                    // 21: tree.v1.ListMyTreesResponse
+	(*DeleteTreeRequest)(nil),                      // 22: tree.v1.DeleteTreeRequest
+	(*DeleteTreeResponse)(nil),                     // 23: tree.v1.DeleteTreeResponse
+	(*UpdateContactPrivacyRequest)(nil),            // 24: tree.v1.UpdateContactPrivacyRequest
+	(*UpdateContactPrivacyResponse)(nil),           // 25: tree.v1.UpdateContactPrivacyResponse
+	(*CloneTreeRequest)(nil),                       // 26: tree.v1.CloneTreeRequest
+	(*CloneTreeResponse)(nil),                      // 27: tree.v1.CloneTreeResponse
+	(*SaveTreeAsTemplateRequest)(nil),              // 28: tree.v1.SaveTreeAsTemplateRequest
+	(*SaveTreeAsTemplateResponse)(nil),             // 29: tree.v1.SaveTreeAsTemplateResponse
+	(*ListTreeTemplatesRequest)(nil),               // 30: tree.v1.ListTreeTemplatesRequest
+	(*ListTreeTemplatesResponse)(nil),              // 31: tree.v1.ListTreeTemplatesResponse
+	(*DeleteTreeTemplateRequest)(nil),              // 32: tree.v1.DeleteTreeTemplateRequest
+	(*DeleteTreeTemplateResponse)(nil),             // 33: tree.v1.DeleteTreeTemplateResponse
+	(*ShareTreeRequest)(nil),                       // 34: tree.v1.ShareTreeRequest
+	(*ShareTreeResponse)(nil),                      // 35: tree.v1.ShareTreeResponse
+	(*UpdateShareRequest)(nil),                     // 36: tree.v1.UpdateShareRequest
+	(*UpdateShareResponse)(nil),                    // 37: tree.v1.UpdateShareResponse
+	(*RemoveShareRequest)(nil),                     // 38: tree.v1.RemoveShareRequest
+	(*RemoveShareResponse)(nil),                    // 39: tree.v1.RemoveShareResponse
+	(*ListTreeSharesRequest)(nil),                  // 40: tree.v1.ListTreeSharesRequest
+	(*ListTreeSharesResponse)(nil),                 // 41: tree.v1.ListTreeSharesResponse
+	(*ListTreeInvitationsRequest)(nil),             // 42: tree.v1.ListTreeInvitationsRequest
+	(*ListTreeInvitationsResponse)(nil),            // 43: tree.v1.ListTreeInvitationsResponse
+	(*ResendTreeInvitationRequest)(nil),            // 44: tree.v1.ResendTreeInvitationRequest
+	(*ResendTreeInvitationResponse)(nil),           // 45: tree.v1.ResendTreeInvitationResponse
+	(*CancelTreeInvitationRequest)(nil),            // 46: tree.v1.CancelTreeInvitationRequest
+	(*CancelTreeInvitationResponse)(nil),           // 47: tree.v1.CancelTreeInvitationResponse
+	(*ListSharedWithMeRequest)(nil),                // 48: tree.v1.ListSharedWithMeRequest
+	(*ListSharedWithMeResponse)(nil),               // 49: tree.v1.ListSharedWithMeResponse
+	(*ListAccessibleTreesRequest)(nil),             // 50: tree.v1.ListAccessibleTreesRequest
+	(*ListAccessibleTreesResponse)(nil),            // 51: tree.v1.ListAccessibleTreesResponse
+	(*GetMyRoleRequest)(nil),                       // 52: tree.v1.GetMyRoleRequest
+	(*GetMyRoleResponse)(nil),                      // 53: tree.v1.GetMyRoleResponse
+	(*TransferOwnershipRequest)(nil),               // 54: tree.v1.TransferOwnershipRequest
+	(*TransferOwnershipResponse)(nil),              // 55: tree.v1.TransferOwnershipResponse
+	(*RespondOwnershipTransferRequest)(nil),        // 56: tree.v1.RespondOwnershipTransferRequest
+	(*RespondOwnershipTransferResponse)(nil),       // 57: tree.v1.RespondOwnershipTransferResponse
+	(*CancelOwnershipTransferRequest)(nil),         // 58: tree.v1.CancelOwnershipTransferRequest
+	(*CancelOwnershipTransferResponse)(nil),        // 59: tree.v1.CancelOwnershipTransferResponse
+	(*ListOwnershipTransfersRequest)(nil),          // 60: tree.v1.ListOwnershipTransfersRequest
+	(*ListOwnershipTransfersResponse)(nil),         // 61: tree.v1.ListOwnershipTransfersResponse
+	(*ListIncomingOwnershipTransfersRequest)(nil),  // 62: tree.v1.ListIncomingOwnershipTransfersRequest
+	(*ListIncomingOwnershipTransfersResponse)(nil), // 63: tree.v1.ListIncomingOwnershipTransfersResponse
+	(*GenerationCount)(nil),                        // 64: tree.v1.GenerationCount
+	(*StatusCounts)(nil),                           // 65: tree.v1.StatusCounts
+	(*TrendPoint)(nil),                             // 66: tree.v1.TrendPoint
+	(*TreeStats)(nil),                              // 67: tree.v1.TreeStats
+	(*GetTreeStatsRequest)(nil),                    // 68: tree.v1.GetTreeStatsRequest
+	(*GetTreeStatsResponse)(nil),                   // 69: tree.v1.GetTreeStatsResponse
+	(*DepartmentStats)(nil),                        // 70: tree.v1.DepartmentStats
+	(*FacultyStats)(nil),                           // 71: tree.v1.FacultyStats
+	(*GetFacultyStatsRequest)(nil),                 // 72: tree.v1.GetFacultyStatsRequest
+	(*GetFacultyStatsResponse)(nil),                // 73: tree.v1.GetFacultyStatsResponse
+	(*StructureIssue)(nil),                         // 74: tree.v1.StructureIssue
+	(*RepairTreeStructureRequest)(nil),             // 75: tree.v1.RepairTreeStructureRequest
+	(*RepairTreeStructureResponse)(nil),            // 76: tree.v1.RepairTreeStructureResponse
+	(*ListAuditEventsRequest)(nil),                 // 77: tree.v1.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),                // 78: tree.v1.ListAuditEventsResponse
+	(*GenerateShareLinkRequest)(nil),               // 79: tree.v1.GenerateShareLinkRequest
+	(*GenerateShareLinkResponse)(nil),              // 80: tree.v1.GenerateShareLinkResponse
+	(*ListShareLinksRequest)(nil),                  // 81: tree.v1.ListShareLinksRequest
+	(*ListShareLinksResponse)(nil),                 // 82: tree.v1.ListShareLinksResponse
+	(*RevokeShareLinkRequest)(nil),                 // 83: tree.v1.RevokeShareLinkRequest
+	(*RevokeShareLinkResponse)(nil),                // 84: tree.v1.RevokeShareLinkResponse
+	(*RotateShareLinkRequest)(nil),                 // 85: tree.v1.RotateShareLinkRequest
+	(*RotateShareLinkResponse)(nil),                // 86: tree.v1.RotateShareLinkResponse
+	(*JoinShareLinkRequest)(nil),                   // 87: tree.v1.JoinShareLinkRequest
+	(*JoinShareLinkResponse)(nil),                  // 88: tree.v1.JoinShareLinkResponse
+	(*GetTreeByShareTokenRequest)(nil),             // 89: tree.v1.GetTreeByShareTokenRequest
+	(*GetTreeByShareTokenResponse)(nil),            // 90: tree.v1.GetTreeByShareTokenResponse
+	nil,                                            // 91: tree.v1.AuditNodeState.MetadataEntry
+	nil,                                            // 92: tree.v1.AuditSnapshot.FieldsEntry
 }
 var file_tree_v1_tree_proto_depIdxs = []int32{
-	0,  // 0: tree.v1.Tree.my_role:type_name -> tree.v1.ShareRole
-	0,  // 1: tree.v1.TreeShare.role:type_name -> tree.v1.ShareRole
-	1,  // 2: tree.v1.CreateTreeResponse.tree:type_name -> tree.v1.Tree
-	1,  // 3: tree.v1.GetTreeResponse.tree:type_name -> tree.v1.Tree
-	1,  // 4: tree.v1.ListMyTreesResponse.trees:type_name -> tree.v1.Tree
-	0,  // 5: tree.v1.ShareTreeRequest.role:type_name -> tree.v1.ShareRole
-	2,  // 6: tree.v1.ShareTreeResponse.share:type_name -> tree.v1.TreeShare
-	0,  // 7: tree.v1.UpdateShareRequest.role:type_name -> tree.v1.ShareRole
-	2,  // 8: tree.v1.UpdateShareResponse.share:type_name -> tree.v1.TreeShare
-	2,  // 9: tree.v1.ListTreeSharesResponse.shares:type_name -> tree.v1.TreeShare
-	1,  // 10: tree.v1.ListSharedWithMeResponse.trees:type_name -> tree.v1.Tree
-	0,  // 11: tree.v1.GetMyRoleResponse.role:type_name -> tree.v1.ShareRole
-	1,  // 12: tree.v1.GetTreeByShareTokenResponse.tree:type_name -> tree.v1.Tree
-	3,  // 13: tree.v1.TreeService.CreateTree:input_type -> tree.v1.CreateTreeRequest
-	5,  // 14: tree.v1.TreeService.GetTree:input_type -> tree.v1.GetTreeRequest
-	7,  // 15: tree.v1.TreeService.ListMyTrees:input_type -> tree.v1.ListMyTreesRequest
-	9,  // 16: tree.v1.TreeService.DeleteTree:input_type -> tree.v1.DeleteTreeRequest
-	11, // 17: tree.v1.TreeService.ShareTree:input_type -> tree.v1.ShareTreeRequest
-	13, // 18: tree.v1.TreeService.UpdateShare:input_type -> tree.v1.UpdateShareRequest
-	15, // 19: tree.v1.TreeService.RemoveShare:input_type -> tree.v1.RemoveShareRequest
-	17, // 20: tree.v1.TreeService.ListTreeShares:input_type -> tree.v1.ListTreeSharesRequest
-	19, // 21: tree.v1.TreeService.ListSharedWithMe:input_type -> tree.v1.ListSharedWithMeRequest
-	21, // 22: tree.v1.TreeService.GetMyRole:input_type -> tree.v1.GetMyRoleRequest
-	23, // 23: tree.v1.TreeService.GenerateShareLink:input_type -> tree.v1.GenerateShareLinkRequest
-	25, // 24: tree.v1.TreeService.GetTreeByShareToken:input_type -> tree.v1.GetTreeByShareTokenRequest
-	4,  // 25: tree.v1.TreeService.CreateTree:output_type -> tree.v1.CreateTreeResponse
-	6,  // 26: tree.v1.TreeService.GetTree:output_type -> tree.v1.GetTreeResponse
-	8,  // 27: tree.v1.TreeService.ListMyTrees:output_type -> tree.v1.ListMyTreesResponse
-	10, // 28: tree.v1.TreeService.DeleteTree:output_type -> tree.v1.DeleteTreeResponse
-	12, // 29: tree.v1.TreeService.ShareTree:output_type -> tree.v1.ShareTreeResponse
-	14, // 30: tree.v1.TreeService.UpdateShare:output_type -> tree.v1.UpdateShareResponse
-	16, // 31: tree.v1.TreeService.RemoveShare:output_type -> tree.v1.RemoveShareResponse
-	18, // 32: tree.v1.TreeService.ListTreeShares:output_type -> tree.v1.ListTreeSharesResponse
-	20, // 33: tree.v1.TreeService.ListSharedWithMe:output_type -> tree.v1.ListSharedWithMeResponse
-	22, // 34: tree.v1.TreeService.GetMyRole:output_type -> tree.v1.GetMyRoleResponse
-	24, // 35: tree.v1.TreeService.GenerateShareLink:output_type -> tree.v1.GenerateShareLinkResponse
-	26, // 36: tree.v1.TreeService.GetTreeByShareToken:output_type -> tree.v1.GetTreeByShareTokenResponse
-	25, // [25:37] is the sub-list for method output_type
-	13, // [13:25] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	0,   // 0: tree.v1.Tree.my_role:type_name -> tree.v1.ShareRole
+	8,   // 1: tree.v1.Tree.contact_privacy:type_name -> tree.v1.ContactPrivacy
+	0,   // 2: tree.v1.TreeInvitation.role:type_name -> tree.v1.ShareRole
+	1,   // 3: tree.v1.ShareLink.role:type_name -> tree.v1.ShareLinkRole
+	2,   // 4: tree.v1.ContactPrivacy.phone:type_name -> tree.v1.ContactVisibility
+	2,   // 5: tree.v1.ContactPrivacy.email:type_name -> tree.v1.ContactVisibility
+	2,   // 6: tree.v1.ContactPrivacy.line_id:type_name -> tree.v1.ContactVisibility
+	2,   // 7: tree.v1.ContactPrivacy.discord:type_name -> tree.v1.ContactVisibility
+	2,   // 8: tree.v1.ContactPrivacy.facebook:type_name -> tree.v1.ContactVisibility
+	0,   // 9: tree.v1.OwnershipTransfer.previous_owner_role:type_name -> tree.v1.ShareRole
+	3,   // 10: tree.v1.OwnershipTransfer.status:type_name -> tree.v1.OwnershipTransferStatus
+	91,  // 11: tree.v1.AuditNodeState.metadata:type_name -> tree.v1.AuditNodeState.MetadataEntry
+	10,  // 12: tree.v1.AuditSnapshot.node:type_name -> tree.v1.AuditNodeState
+	92,  // 13: tree.v1.AuditSnapshot.fields:type_name -> tree.v1.AuditSnapshot.FieldsEntry
+	11,  // 14: tree.v1.AuditEvent.before:type_name -> tree.v1.AuditSnapshot
+	11,  // 15: tree.v1.AuditEvent.after:type_name -> tree.v1.AuditSnapshot
+	0,   // 16: tree.v1.TreeShare.role:type_name -> tree.v1.ShareRole
+	5,   // 17: tree.v1.CreateTreeResponse.tree:type_name -> tree.v1.Tree
+	5,   // 18: tree.v1.GetTreeResponse.tree:type_name -> tree.v1.Tree
+	0,   // 19: tree.v1.TreeListOptions.roles:type_name -> tree.v1.ShareRole
+	4,   // 20: tree.v1.TreeListOptions.sort_by:type_name -> tree.v1.TreeSortField
+	19,  // 21: tree.v1.ListMyTreesRequest.options:type_name -> tree.v1.TreeListOptions
+	5,   // 22: tree.v1.ListMyTreesResponse.trees:type_name -> tree.v1.Tree
+	8,   // 23: tree.v1.UpdateContactPrivacyRequest.contact_privacy:type_name -> tree.v1.ContactPrivacy
+	5,   // 24: tree.v1.UpdateContactPrivacyResponse.tree:type_name -> tree.v1.Tree
+	5,   // 25: tree.v1.CloneTreeResponse.tree:type_name -> tree.v1.Tree
+	13,  // 26: tree.v1.SaveTreeAsTemplateResponse.template:type_name -> tree.v1.TreeTemplate
+	13,  // 27: tree.v1.ListTreeTemplatesResponse.templates:type_name -> tree.v1.TreeTemplate
+	0,   // 28: tree.v1.ShareTreeRequest.role:type_name -> tree.v1.ShareRole
+	14,  // 29: tree.v1.ShareTreeResponse.share:type_name -> tree.v1.TreeShare
+	6,   // 30: tree.v1.ShareTreeResponse.invitation:type_name -> tree.v1.TreeInvitation
+	0,   // 31: tree.v1.UpdateShareRequest.role:type_name -> tree.v1.ShareRole
+	14,  // 32: tree.v1.UpdateShareResponse.share:type_name -> tree.v1.TreeShare
+	14,  // 33: tree.v1.ListTreeSharesResponse.shares:type_name -> tree.v1.TreeShare
+	6,   // 34: tree.v1.ListTreeInvitationsResponse.invitations:type_name -> tree.v1.TreeInvitation
+	6,   // 35: tree.v1.ResendTreeInvitationResponse.invitation:type_name -> tree.v1.TreeInvitation
+	19,  // 36: tree.v1.ListSharedWithMeRequest.options:type_name -> tree.v1.TreeListOptions
+	5,   // 37: tree.v1.ListSharedWithMeResponse.trees:type_name -> tree.v1.Tree
+	19,  // 38: tree.v1.ListAccessibleTreesRequest.options:type_name -> tree.v1.TreeListOptions
+	5,   // 39: tree.v1.ListAccessibleTreesResponse.trees:type_name -> tree.v1.Tree
+	0,   // 40: tree.v1.GetMyRoleResponse.role:type_name -> tree.v1.ShareRole
+	0,   // 41: tree.v1.TransferOwnershipRequest.previous_owner_role:type_name -> tree.v1.ShareRole
+	9,   // 42: tree.v1.TransferOwnershipResponse.transfer:type_name -> tree.v1.OwnershipTransfer
+	9,   // 43: tree.v1.RespondOwnershipTransferResponse.transfer:type_name -> tree.v1.OwnershipTransfer
+	5,   // 44: tree.v1.RespondOwnershipTransferResponse.tree:type_name -> tree.v1.Tree
+	9,   // 45: tree.v1.CancelOwnershipTransferResponse.transfer:type_name -> tree.v1.OwnershipTransfer
+	9,   // 46: tree.v1.ListOwnershipTransfersResponse.transfers:type_name -> tree.v1.OwnershipTransfer
+	9,   // 47: tree.v1.ListIncomingOwnershipTransfersResponse.transfers:type_name -> tree.v1.OwnershipTransfer
+	64,  // 48: tree.v1.TreeStats.generations:type_name -> tree.v1.GenerationCount
+	65,  // 49: tree.v1.TreeStats.statuses:type_name -> tree.v1.StatusCounts
+	66,  // 50: tree.v1.TreeStats.trend:type_name -> tree.v1.TrendPoint
+	67,  // 51: tree.v1.GetTreeStatsResponse.stats:type_name -> tree.v1.TreeStats
+	65,  // 52: tree.v1.DepartmentStats.statuses:type_name -> tree.v1.StatusCounts
+	65,  // 53: tree.v1.FacultyStats.statuses:type_name -> tree.v1.StatusCounts
+	70,  // 54: tree.v1.FacultyStats.departments:type_name -> tree.v1.DepartmentStats
+	71,  // 55: tree.v1.GetFacultyStatsResponse.faculties:type_name -> tree.v1.FacultyStats
+	74,  // 56: tree.v1.RepairTreeStructureResponse.issues:type_name -> tree.v1.StructureIssue
+	12,  // 57: tree.v1.ListAuditEventsResponse.events:type_name -> tree.v1.AuditEvent
+	1,   // 58: tree.v1.GenerateShareLinkRequest.role:type_name -> tree.v1.ShareLinkRole
+	7,   // 59: tree.v1.GenerateShareLinkResponse.link:type_name -> tree.v1.ShareLink
+	7,   // 60: tree.v1.ListShareLinksResponse.links:type_name -> tree.v1.ShareLink
+	7,   // 61: tree.v1.RevokeShareLinkResponse.link:type_name -> tree.v1.ShareLink
+	7,   // 62: tree.v1.RotateShareLinkResponse.link:type_name -> tree.v1.ShareLink
+	5,   // 63: tree.v1.JoinShareLinkResponse.tree:type_name -> tree.v1.Tree
+	5,   // 64: tree.v1.GetTreeByShareTokenResponse.tree:type_name -> tree.v1.Tree
+	1,   // 65: tree.v1.GetTreeByShareTokenResponse.link_role:type_name -> tree.v1.ShareLinkRole
+	15,  // 66: tree.v1.TreeService.CreateTree:input_type -> tree.v1.CreateTreeRequest
+	17,  // 67: tree.v1.TreeService.GetTree:input_type -> tree.v1.GetTreeRequest
+	20,  // 68: tree.v1.TreeService.ListMyTrees:input_type -> tree.v1.ListMyTreesRequest
+	22,  // 69: tree.v1.TreeService.DeleteTree:input_type -> tree.v1.DeleteTreeRequest
+	24,  // 70: tree.v1.TreeService.UpdateContactPrivacy:input_type -> tree.v1.UpdateContactPrivacyRequest
+	26,  // 71: tree.v1.TreeService.CloneTree:input_type -> tree.v1.CloneTreeRequest
+	28,  // 72: tree.v1.TreeService.SaveTreeAsTemplate:input_type -> tree.v1.SaveTreeAsTemplateRequest
+	30,  // 73: tree.v1.TreeService.ListTreeTemplates:input_type -> tree.v1.ListTreeTemplatesRequest
+	32,  // 74: tree.v1.TreeService.DeleteTreeTemplate:input_type -> tree.v1.DeleteTreeTemplateRequest
+	34,  // 75: tree.v1.TreeService.ShareTree:input_type -> tree.v1.ShareTreeRequest
+	36,  // 76: tree.v1.TreeService.UpdateShare:input_type -> tree.v1.UpdateShareRequest
+	38,  // 77: tree.v1.TreeService.RemoveShare:input_type -> tree.v1.RemoveShareRequest
+	40,  // 78: tree.v1.TreeService.ListTreeShares:input_type -> tree.v1.ListTreeSharesRequest
+	48,  // 79: tree.v1.TreeService.ListSharedWithMe:input_type -> tree.v1.ListSharedWithMeRequest
+	50,  // 80: tree.v1.TreeService.ListAccessibleTrees:input_type -> tree.v1.ListAccessibleTreesRequest
+	52,  // 81: tree.v1.TreeService.GetMyRole:input_type -> tree.v1.GetMyRoleRequest
+	42,  // 82: tree.v1.TreeService.ListTreeInvitations:input_type -> tree.v1.ListTreeInvitationsRequest
+	44,  // 83: tree.v1.TreeService.ResendTreeInvitation:input_type -> tree.v1.ResendTreeInvitationRequest
+	46,  // 84: tree.v1.TreeService.CancelTreeInvitation:input_type -> tree.v1.CancelTreeInvitationRequest
+	54,  // 85: tree.v1.TreeService.TransferOwnership:input_type -> tree.v1.TransferOwnershipRequest
+	56,  // 86: tree.v1.TreeService.RespondOwnershipTransfer:input_type -> tree.v1.RespondOwnershipTransferRequest
+	58,  // 87: tree.v1.TreeService.CancelOwnershipTransfer:input_type -> tree.v1.CancelOwnershipTransferRequest
+	60,  // 88: tree.v1.TreeService.ListOwnershipTransfers:input_type -> tree.v1.ListOwnershipTransfersRequest
+	62,  // 89: tree.v1.TreeService.ListIncomingOwnershipTransfers:input_type -> tree.v1.ListIncomingOwnershipTransfersRequest
+	68,  // 90: tree.v1.TreeService.GetTreeStats:input_type -> tree.v1.GetTreeStatsRequest
+	72,  // 91: tree.v1.TreeService.GetFacultyStats:input_type -> tree.v1.GetFacultyStatsRequest
+	75,  // 92: tree.v1.TreeService.RepairTreeStructure:input_type -> tree.v1.RepairTreeStructureRequest
+	77,  // 93: tree.v1.TreeService.ListAuditEvents:input_type -> tree.v1.ListAuditEventsRequest
+	79,  // 94: tree.v1.TreeService.GenerateShareLink:input_type -> tree.v1.GenerateShareLinkRequest
+	89,  // 95: tree.v1.TreeService.GetTreeByShareToken:input_type -> tree.v1.GetTreeByShareTokenRequest
+	81,  // 96: tree.v1.TreeService.ListShareLinks:input_type -> tree.v1.ListShareLinksRequest
+	83,  // 97: tree.v1.TreeService.RevokeShareLink:input_type -> tree.v1.RevokeShareLinkRequest
+	85,  // 98: tree.v1.TreeService.RotateShareLink:input_type -> tree.v1.RotateShareLinkRequest
+	87,  // 99: tree.v1.TreeService.JoinShareLink:input_type -> tree.v1.JoinShareLinkRequest
+	16,  // 100: tree.v1.TreeService.CreateTree:output_type -> tree.v1.CreateTreeResponse
+	18,  // 101: tree.v1.TreeService.GetTree:output_type -> tree.v1.GetTreeResponse
+	21,  // 102: tree.v1.TreeService.ListMyTrees:output_type -> tree.v1.ListMyTreesResponse
+	23,  // 103: tree.v1.TreeService.DeleteTree:output_type -> tree.v1.DeleteTreeResponse
+	25,  // 104: tree.v1.TreeService.UpdateContactPrivacy:output_type -> tree.v1.UpdateContactPrivacyResponse
+	27,  // 105: tree.v1.TreeService.CloneTree:output_type -> tree.v1.CloneTreeResponse
+	29,  // 106: tree.v1.TreeService.SaveTreeAsTemplate:output_type -> tree.v1.SaveTreeAsTemplateResponse
+	31,  // 107: tree.v1.TreeService.ListTreeTemplates:output_type -> tree.v1.ListTreeTemplatesResponse
+	33,  // 108: tree.v1.TreeService.DeleteTreeTemplate:output_type -> tree.v1.DeleteTreeTemplateResponse
+	35,  // 109: tree.v1.TreeService.ShareTree:output_type -> tree.v1.ShareTreeResponse
+	37,  // 110: tree.v1.TreeService.UpdateShare:output_type -> tree.v1.UpdateShareResponse
+	39,  // 111: tree.v1.TreeService.RemoveShare:output_type -> tree.v1.RemoveShareResponse
+	41,  // 112: tree.v1.TreeService.ListTreeShares:output_type -> tree.v1.ListTreeSharesResponse
+	49,  // 113: tree.v1.TreeService.ListSharedWithMe:output_type -> tree.v1.ListSharedWithMeResponse
+	51,  // 114: tree.v1.TreeService.ListAccessibleTrees:output_type -> tree.v1.ListAccessibleTreesResponse
+	53,  // 115: tree.v1.TreeService.GetMyRole:output_type -> tree.v1.GetMyRoleResponse
+	43,  // 116: tree.v1.TreeService.ListTreeInvitations:output_type -> tree.v1.ListTreeInvitationsResponse
+	45,  // 117: tree.v1.TreeService.ResendTreeInvitation:output_type -> tree.v1.ResendTreeInvitationResponse
+	47,  // 118: tree.v1.TreeService.CancelTreeInvitation:output_type -> tree.v1.CancelTreeInvitationResponse
+	55,  // 119: tree.v1.TreeService.TransferOwnership:output_type -> tree.v1.TransferOwnershipResponse
+	57,  // 120: tree.v1.TreeService.RespondOwnershipTransfer:output_type -> tree.v1.RespondOwnershipTransferResponse
+	59,  // 121: tree.v1.TreeService.CancelOwnershipTransfer:output_type -> tree.v1.CancelOwnershipTransferResponse
+	61,  // 122: tree.v1.TreeService.ListOwnershipTransfers:output_type -> tree.v1.ListOwnershipTransfersResponse
+	63,  // 123: tree.v1.TreeService.ListIncomingOwnershipTransfers:output_type -> tree.v1.ListIncomingOwnershipTransfersResponse
+	69,  // 124: tree.v1.TreeService.GetTreeStats:output_type -> tree.v1.GetTreeStatsResponse
+	73,  // 125: tree.v1.TreeService.GetFacultyStats:output_type -> tree.v1.GetFacultyStatsResponse
+	76,  // 126: tree.v1.TreeService.RepairTreeStructure:output_type -> tree.v1.RepairTreeStructureResponse
+	78,  // 127: tree.v1.TreeService.ListAuditEvents:output_type -> tree.v1.ListAuditEventsResponse
+	80,  // 128: tree.v1.TreeService.GenerateShareLink:output_type -> tree.v1.GenerateShareLinkResponse
+	90,  // 129: tree.v1.TreeService.GetTreeByShareToken:output_type -> tree.v1.GetTreeByShareTokenResponse
+	82,  // 130: tree.v1.TreeService.ListShareLinks:output_type -> tree.v1.ListShareLinksResponse
+	84,  // 131: tree.v1.TreeService.RevokeShareLink:output_type -> tree.v1.RevokeShareLinkResponse
+	86,  // 132: tree.v1.TreeService.RotateShareLink:output_type -> tree.v1.RotateShareLinkResponse
+	88,  // 133: tree.v1.TreeService.JoinShareLink:output_type -> tree.v1.JoinShareLinkResponse
+	100, // [100:134] is the sub-list for method output_type
+	66,  // [66:100] is the sub-list for method input_type
+	66,  // [66:66] is the sub-list for extension type_name
+	66,  // [66:66] is the sub-list for extension extendee
+	0,   // [0:66] is the sub-list for field type_name
 }
 
 func init() { file_tree_v1_tree_proto_init() }
@@ -1607,13 +6075,25 @@ func file_tree_v1_tree_proto_init() {
 	if File_tree_v1_tree_proto != nil {
 		return
 	}
+	file_tree_v1_tree_proto_msgTypes[0].OneofWrappers = []any{}
+	file_tree_v1_tree_proto_msgTypes[1].OneofWrappers = []any{}
+	file_tree_v1_tree_proto_msgTypes[2].OneofWrappers = []any{}
+	file_tree_v1_tree_proto_msgTypes[4].OneofWrappers = []any{}
+	file_tree_v1_tree_proto_msgTypes[6].OneofWrappers = []any{}
+	file_tree_v1_tree_proto_msgTypes[10].OneofWrappers = []any{}
+	file_tree_v1_tree_proto_msgTypes[14].OneofWrappers = []any{}
+	file_tree_v1_tree_proto_msgTypes[67].OneofWrappers = []any{}
+	file_tree_v1_tree_proto_msgTypes[69].OneofWrappers = []any{}
+	file_tree_v1_tree_proto_msgTypes[72].OneofWrappers = []any{}
+	file_tree_v1_tree_proto_msgTypes[74].OneofWrappers = []any{}
+	file_tree_v1_tree_proto_msgTypes[85].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tree_v1_tree_proto_rawDesc), len(file_tree_v1_tree_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   26,
+			NumEnums:      5,
+			NumMessages:   88,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
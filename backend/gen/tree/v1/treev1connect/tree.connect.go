@@ -41,6 +41,20 @@ const (
 	TreeServiceListMyTreesProcedure = "/tree.v1.TreeService/ListMyTrees"
 	// TreeServiceDeleteTreeProcedure is the fully-qualified name of the TreeService's DeleteTree RPC.
 	TreeServiceDeleteTreeProcedure = "/tree.v1.TreeService/DeleteTree"
+	// TreeServiceUpdateContactPrivacyProcedure is the fully-qualified name of the TreeService's
+	// UpdateContactPrivacy RPC.
+	TreeServiceUpdateContactPrivacyProcedure = "/tree.v1.TreeService/UpdateContactPrivacy"
+	// TreeServiceCloneTreeProcedure is the fully-qualified name of the TreeService's CloneTree RPC.
+	TreeServiceCloneTreeProcedure = "/tree.v1.TreeService/CloneTree"
+	// TreeServiceSaveTreeAsTemplateProcedure is the fully-qualified name of the TreeService's
+	// SaveTreeAsTemplate RPC.
+	TreeServiceSaveTreeAsTemplateProcedure = "/tree.v1.TreeService/SaveTreeAsTemplate"
+	// TreeServiceListTreeTemplatesProcedure is the fully-qualified name of the TreeService's
+	// ListTreeTemplates RPC.
+	TreeServiceListTreeTemplatesProcedure = "/tree.v1.TreeService/ListTreeTemplates"
+	// TreeServiceDeleteTreeTemplateProcedure is the fully-qualified name of the TreeService's
+	// DeleteTreeTemplate RPC.
+	TreeServiceDeleteTreeTemplateProcedure = "/tree.v1.TreeService/DeleteTreeTemplate"
 	// TreeServiceShareTreeProcedure is the fully-qualified name of the TreeService's ShareTree RPC.
 	TreeServiceShareTreeProcedure = "/tree.v1.TreeService/ShareTree"
 	// TreeServiceUpdateShareProcedure is the fully-qualified name of the TreeService's UpdateShare RPC.
@@ -53,14 +67,65 @@ const (
 	// TreeServiceListSharedWithMeProcedure is the fully-qualified name of the TreeService's
 	// ListSharedWithMe RPC.
 	TreeServiceListSharedWithMeProcedure = "/tree.v1.TreeService/ListSharedWithMe"
+	// TreeServiceListAccessibleTreesProcedure is the fully-qualified name of the TreeService's
+	// ListAccessibleTrees RPC.
+	TreeServiceListAccessibleTreesProcedure = "/tree.v1.TreeService/ListAccessibleTrees"
 	// TreeServiceGetMyRoleProcedure is the fully-qualified name of the TreeService's GetMyRole RPC.
 	TreeServiceGetMyRoleProcedure = "/tree.v1.TreeService/GetMyRole"
+	// TreeServiceListTreeInvitationsProcedure is the fully-qualified name of the TreeService's
+	// ListTreeInvitations RPC.
+	TreeServiceListTreeInvitationsProcedure = "/tree.v1.TreeService/ListTreeInvitations"
+	// TreeServiceResendTreeInvitationProcedure is the fully-qualified name of the TreeService's
+	// ResendTreeInvitation RPC.
+	TreeServiceResendTreeInvitationProcedure = "/tree.v1.TreeService/ResendTreeInvitation"
+	// TreeServiceCancelTreeInvitationProcedure is the fully-qualified name of the TreeService's
+	// CancelTreeInvitation RPC.
+	TreeServiceCancelTreeInvitationProcedure = "/tree.v1.TreeService/CancelTreeInvitation"
+	// TreeServiceTransferOwnershipProcedure is the fully-qualified name of the TreeService's
+	// TransferOwnership RPC.
+	TreeServiceTransferOwnershipProcedure = "/tree.v1.TreeService/TransferOwnership"
+	// TreeServiceRespondOwnershipTransferProcedure is the fully-qualified name of the TreeService's
+	// RespondOwnershipTransfer RPC.
+	TreeServiceRespondOwnershipTransferProcedure = "/tree.v1.TreeService/RespondOwnershipTransfer"
+	// TreeServiceCancelOwnershipTransferProcedure is the fully-qualified name of the TreeService's
+	// CancelOwnershipTransfer RPC.
+	TreeServiceCancelOwnershipTransferProcedure = "/tree.v1.TreeService/CancelOwnershipTransfer"
+	// TreeServiceListOwnershipTransfersProcedure is the fully-qualified name of the TreeService's
+	// ListOwnershipTransfers RPC.
+	TreeServiceListOwnershipTransfersProcedure = "/tree.v1.TreeService/ListOwnershipTransfers"
+	// TreeServiceListIncomingOwnershipTransfersProcedure is the fully-qualified name of the
+	// TreeService's ListIncomingOwnershipTransfers RPC.
+	TreeServiceListIncomingOwnershipTransfersProcedure = "/tree.v1.TreeService/ListIncomingOwnershipTransfers"
+	// TreeServiceGetTreeStatsProcedure is the fully-qualified name of the TreeService's GetTreeStats
+	// RPC.
+	TreeServiceGetTreeStatsProcedure = "/tree.v1.TreeService/GetTreeStats"
+	// TreeServiceGetFacultyStatsProcedure is the fully-qualified name of the TreeService's
+	// GetFacultyStats RPC.
+	TreeServiceGetFacultyStatsProcedure = "/tree.v1.TreeService/GetFacultyStats"
+	// TreeServiceRepairTreeStructureProcedure is the fully-qualified name of the TreeService's
+	// RepairTreeStructure RPC.
+	TreeServiceRepairTreeStructureProcedure = "/tree.v1.TreeService/RepairTreeStructure"
+	// TreeServiceListAuditEventsProcedure is the fully-qualified name of the TreeService's
+	// ListAuditEvents RPC.
+	TreeServiceListAuditEventsProcedure = "/tree.v1.TreeService/ListAuditEvents"
 	// TreeServiceGenerateShareLinkProcedure is the fully-qualified name of the TreeService's
 	// GenerateShareLink RPC.
 	TreeServiceGenerateShareLinkProcedure = "/tree.v1.TreeService/GenerateShareLink"
 	// TreeServiceGetTreeByShareTokenProcedure is the fully-qualified name of the TreeService's
 	// GetTreeByShareToken RPC.
 	TreeServiceGetTreeByShareTokenProcedure = "/tree.v1.TreeService/GetTreeByShareToken"
+	// TreeServiceListShareLinksProcedure is the fully-qualified name of the TreeService's
+	// ListShareLinks RPC.
+	TreeServiceListShareLinksProcedure = "/tree.v1.TreeService/ListShareLinks"
+	// TreeServiceRevokeShareLinkProcedure is the fully-qualified name of the TreeService's
+	// RevokeShareLink RPC.
+	TreeServiceRevokeShareLinkProcedure = "/tree.v1.TreeService/RevokeShareLink"
+	// TreeServiceRotateShareLinkProcedure is the fully-qualified name of the TreeService's
+	// RotateShareLink RPC.
+	TreeServiceRotateShareLinkProcedure = "/tree.v1.TreeService/RotateShareLink"
+	// TreeServiceJoinShareLinkProcedure is the fully-qualified name of the TreeService's JoinShareLink
+	// RPC.
+	TreeServiceJoinShareLinkProcedure = "/tree.v1.TreeService/JoinShareLink"
 )
 
 // TreeServiceClient is a client for the tree.v1.TreeService service.
@@ -69,16 +134,43 @@ type TreeServiceClient interface {
 	GetTree(context.Context, *connect.Request[v1.GetTreeRequest]) (*connect.Response[v1.GetTreeResponse], error)
 	ListMyTrees(context.Context, *connect.Request[v1.ListMyTreesRequest]) (*connect.Response[v1.ListMyTreesResponse], error)
 	DeleteTree(context.Context, *connect.Request[v1.DeleteTreeRequest]) (*connect.Response[v1.DeleteTreeResponse], error)
+	UpdateContactPrivacy(context.Context, *connect.Request[v1.UpdateContactPrivacyRequest]) (*connect.Response[v1.UpdateContactPrivacyResponse], error)
+	// ★ Clone / templates
+	CloneTree(context.Context, *connect.Request[v1.CloneTreeRequest]) (*connect.Response[v1.CloneTreeResponse], error)
+	SaveTreeAsTemplate(context.Context, *connect.Request[v1.SaveTreeAsTemplateRequest]) (*connect.Response[v1.SaveTreeAsTemplateResponse], error)
+	ListTreeTemplates(context.Context, *connect.Request[v1.ListTreeTemplatesRequest]) (*connect.Response[v1.ListTreeTemplatesResponse], error)
+	DeleteTreeTemplate(context.Context, *connect.Request[v1.DeleteTreeTemplateRequest]) (*connect.Response[v1.DeleteTreeTemplateResponse], error)
 	// ★ Sharing (ต้อง login)
 	ShareTree(context.Context, *connect.Request[v1.ShareTreeRequest]) (*connect.Response[v1.ShareTreeResponse], error)
 	UpdateShare(context.Context, *connect.Request[v1.UpdateShareRequest]) (*connect.Response[v1.UpdateShareResponse], error)
 	RemoveShare(context.Context, *connect.Request[v1.RemoveShareRequest]) (*connect.Response[v1.RemoveShareResponse], error)
 	ListTreeShares(context.Context, *connect.Request[v1.ListTreeSharesRequest]) (*connect.Response[v1.ListTreeSharesResponse], error)
 	ListSharedWithMe(context.Context, *connect.Request[v1.ListSharedWithMeRequest]) (*connect.Response[v1.ListSharedWithMeResponse], error)
+	ListAccessibleTrees(context.Context, *connect.Request[v1.ListAccessibleTreesRequest]) (*connect.Response[v1.ListAccessibleTreesResponse], error)
 	GetMyRole(context.Context, *connect.Request[v1.GetMyRoleRequest]) (*connect.Response[v1.GetMyRoleResponse], error)
+	ListTreeInvitations(context.Context, *connect.Request[v1.ListTreeInvitationsRequest]) (*connect.Response[v1.ListTreeInvitationsResponse], error)
+	ResendTreeInvitation(context.Context, *connect.Request[v1.ResendTreeInvitationRequest]) (*connect.Response[v1.ResendTreeInvitationResponse], error)
+	CancelTreeInvitation(context.Context, *connect.Request[v1.CancelTreeInvitationRequest]) (*connect.Response[v1.CancelTreeInvitationResponse], error)
+	// ★ Ownership transfer
+	TransferOwnership(context.Context, *connect.Request[v1.TransferOwnershipRequest]) (*connect.Response[v1.TransferOwnershipResponse], error)
+	RespondOwnershipTransfer(context.Context, *connect.Request[v1.RespondOwnershipTransferRequest]) (*connect.Response[v1.RespondOwnershipTransferResponse], error)
+	CancelOwnershipTransfer(context.Context, *connect.Request[v1.CancelOwnershipTransferRequest]) (*connect.Response[v1.CancelOwnershipTransferResponse], error)
+	ListOwnershipTransfers(context.Context, *connect.Request[v1.ListOwnershipTransfersRequest]) (*connect.Response[v1.ListOwnershipTransfersResponse], error)
+	ListIncomingOwnershipTransfers(context.Context, *connect.Request[v1.ListIncomingOwnershipTransfersRequest]) (*connect.Response[v1.ListIncomingOwnershipTransfersResponse], error)
+	// ★ Stats
+	GetTreeStats(context.Context, *connect.Request[v1.GetTreeStatsRequest]) (*connect.Response[v1.GetTreeStatsResponse], error)
+	GetFacultyStats(context.Context, *connect.Request[v1.GetFacultyStatsRequest]) (*connect.Response[v1.GetFacultyStatsResponse], error)
+	// ★ Integrity
+	RepairTreeStructure(context.Context, *connect.Request[v1.RepairTreeStructureRequest]) (*connect.Response[v1.RepairTreeStructureResponse], error)
+	// ★ Audit
+	ListAuditEvents(context.Context, *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error)
 	// ★ Public share link
 	GenerateShareLink(context.Context, *connect.Request[v1.GenerateShareLinkRequest]) (*connect.Response[v1.GenerateShareLinkResponse], error)
 	GetTreeByShareToken(context.Context, *connect.Request[v1.GetTreeByShareTokenRequest]) (*connect.Response[v1.GetTreeByShareTokenResponse], error)
+	ListShareLinks(context.Context, *connect.Request[v1.ListShareLinksRequest]) (*connect.Response[v1.ListShareLinksResponse], error)
+	RevokeShareLink(context.Context, *connect.Request[v1.RevokeShareLinkRequest]) (*connect.Response[v1.RevokeShareLinkResponse], error)
+	RotateShareLink(context.Context, *connect.Request[v1.RotateShareLinkRequest]) (*connect.Response[v1.RotateShareLinkResponse], error)
+	JoinShareLink(context.Context, *connect.Request[v1.JoinShareLinkRequest]) (*connect.Response[v1.JoinShareLinkResponse], error)
 }
 
 // NewTreeServiceClient constructs a client for the tree.v1.TreeService service. By default, it uses
@@ -116,6 +208,36 @@ func NewTreeServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(treeServiceMethods.ByName("DeleteTree")),
 			connect.WithClientOptions(opts...),
 		),
+		updateContactPrivacy: connect.NewClient[v1.UpdateContactPrivacyRequest, v1.UpdateContactPrivacyResponse](
+			httpClient,
+			baseURL+TreeServiceUpdateContactPrivacyProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("UpdateContactPrivacy")),
+			connect.WithClientOptions(opts...),
+		),
+		cloneTree: connect.NewClient[v1.CloneTreeRequest, v1.CloneTreeResponse](
+			httpClient,
+			baseURL+TreeServiceCloneTreeProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("CloneTree")),
+			connect.WithClientOptions(opts...),
+		),
+		saveTreeAsTemplate: connect.NewClient[v1.SaveTreeAsTemplateRequest, v1.SaveTreeAsTemplateResponse](
+			httpClient,
+			baseURL+TreeServiceSaveTreeAsTemplateProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("SaveTreeAsTemplate")),
+			connect.WithClientOptions(opts...),
+		),
+		listTreeTemplates: connect.NewClient[v1.ListTreeTemplatesRequest, v1.ListTreeTemplatesResponse](
+			httpClient,
+			baseURL+TreeServiceListTreeTemplatesProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("ListTreeTemplates")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteTreeTemplate: connect.NewClient[v1.DeleteTreeTemplateRequest, v1.DeleteTreeTemplateResponse](
+			httpClient,
+			baseURL+TreeServiceDeleteTreeTemplateProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("DeleteTreeTemplate")),
+			connect.WithClientOptions(opts...),
+		),
 		shareTree: connect.NewClient[v1.ShareTreeRequest, v1.ShareTreeResponse](
 			httpClient,
 			baseURL+TreeServiceShareTreeProcedure,
@@ -146,12 +268,90 @@ func NewTreeServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(treeServiceMethods.ByName("ListSharedWithMe")),
 			connect.WithClientOptions(opts...),
 		),
+		listAccessibleTrees: connect.NewClient[v1.ListAccessibleTreesRequest, v1.ListAccessibleTreesResponse](
+			httpClient,
+			baseURL+TreeServiceListAccessibleTreesProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("ListAccessibleTrees")),
+			connect.WithClientOptions(opts...),
+		),
 		getMyRole: connect.NewClient[v1.GetMyRoleRequest, v1.GetMyRoleResponse](
 			httpClient,
 			baseURL+TreeServiceGetMyRoleProcedure,
 			connect.WithSchema(treeServiceMethods.ByName("GetMyRole")),
 			connect.WithClientOptions(opts...),
 		),
+		listTreeInvitations: connect.NewClient[v1.ListTreeInvitationsRequest, v1.ListTreeInvitationsResponse](
+			httpClient,
+			baseURL+TreeServiceListTreeInvitationsProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("ListTreeInvitations")),
+			connect.WithClientOptions(opts...),
+		),
+		resendTreeInvitation: connect.NewClient[v1.ResendTreeInvitationRequest, v1.ResendTreeInvitationResponse](
+			httpClient,
+			baseURL+TreeServiceResendTreeInvitationProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("ResendTreeInvitation")),
+			connect.WithClientOptions(opts...),
+		),
+		cancelTreeInvitation: connect.NewClient[v1.CancelTreeInvitationRequest, v1.CancelTreeInvitationResponse](
+			httpClient,
+			baseURL+TreeServiceCancelTreeInvitationProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("CancelTreeInvitation")),
+			connect.WithClientOptions(opts...),
+		),
+		transferOwnership: connect.NewClient[v1.TransferOwnershipRequest, v1.TransferOwnershipResponse](
+			httpClient,
+			baseURL+TreeServiceTransferOwnershipProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("TransferOwnership")),
+			connect.WithClientOptions(opts...),
+		),
+		respondOwnershipTransfer: connect.NewClient[v1.RespondOwnershipTransferRequest, v1.RespondOwnershipTransferResponse](
+			httpClient,
+			baseURL+TreeServiceRespondOwnershipTransferProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("RespondOwnershipTransfer")),
+			connect.WithClientOptions(opts...),
+		),
+		cancelOwnershipTransfer: connect.NewClient[v1.CancelOwnershipTransferRequest, v1.CancelOwnershipTransferResponse](
+			httpClient,
+			baseURL+TreeServiceCancelOwnershipTransferProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("CancelOwnershipTransfer")),
+			connect.WithClientOptions(opts...),
+		),
+		listOwnershipTransfers: connect.NewClient[v1.ListOwnershipTransfersRequest, v1.ListOwnershipTransfersResponse](
+			httpClient,
+			baseURL+TreeServiceListOwnershipTransfersProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("ListOwnershipTransfers")),
+			connect.WithClientOptions(opts...),
+		),
+		listIncomingOwnershipTransfers: connect.NewClient[v1.ListIncomingOwnershipTransfersRequest, v1.ListIncomingOwnershipTransfersResponse](
+			httpClient,
+			baseURL+TreeServiceListIncomingOwnershipTransfersProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("ListIncomingOwnershipTransfers")),
+			connect.WithClientOptions(opts...),
+		),
+		getTreeStats: connect.NewClient[v1.GetTreeStatsRequest, v1.GetTreeStatsResponse](
+			httpClient,
+			baseURL+TreeServiceGetTreeStatsProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("GetTreeStats")),
+			connect.WithClientOptions(opts...),
+		),
+		getFacultyStats: connect.NewClient[v1.GetFacultyStatsRequest, v1.GetFacultyStatsResponse](
+			httpClient,
+			baseURL+TreeServiceGetFacultyStatsProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("GetFacultyStats")),
+			connect.WithClientOptions(opts...),
+		),
+		repairTreeStructure: connect.NewClient[v1.RepairTreeStructureRequest, v1.RepairTreeStructureResponse](
+			httpClient,
+			baseURL+TreeServiceRepairTreeStructureProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("RepairTreeStructure")),
+			connect.WithClientOptions(opts...),
+		),
+		listAuditEvents: connect.NewClient[v1.ListAuditEventsRequest, v1.ListAuditEventsResponse](
+			httpClient,
+			baseURL+TreeServiceListAuditEventsProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("ListAuditEvents")),
+			connect.WithClientOptions(opts...),
+		),
 		generateShareLink: connect.NewClient[v1.GenerateShareLinkRequest, v1.GenerateShareLinkResponse](
 			httpClient,
 			baseURL+TreeServiceGenerateShareLinkProcedure,
@@ -164,23 +364,69 @@ func NewTreeServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(treeServiceMethods.ByName("GetTreeByShareToken")),
 			connect.WithClientOptions(opts...),
 		),
+		listShareLinks: connect.NewClient[v1.ListShareLinksRequest, v1.ListShareLinksResponse](
+			httpClient,
+			baseURL+TreeServiceListShareLinksProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("ListShareLinks")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeShareLink: connect.NewClient[v1.RevokeShareLinkRequest, v1.RevokeShareLinkResponse](
+			httpClient,
+			baseURL+TreeServiceRevokeShareLinkProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("RevokeShareLink")),
+			connect.WithClientOptions(opts...),
+		),
+		rotateShareLink: connect.NewClient[v1.RotateShareLinkRequest, v1.RotateShareLinkResponse](
+			httpClient,
+			baseURL+TreeServiceRotateShareLinkProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("RotateShareLink")),
+			connect.WithClientOptions(opts...),
+		),
+		joinShareLink: connect.NewClient[v1.JoinShareLinkRequest, v1.JoinShareLinkResponse](
+			httpClient,
+			baseURL+TreeServiceJoinShareLinkProcedure,
+			connect.WithSchema(treeServiceMethods.ByName("JoinShareLink")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // treeServiceClient implements TreeServiceClient.
 type treeServiceClient struct {
-	createTree          *connect.Client[v1.CreateTreeRequest, v1.CreateTreeResponse]
-	getTree             *connect.Client[v1.GetTreeRequest, v1.GetTreeResponse]
-	listMyTrees         *connect.Client[v1.ListMyTreesRequest, v1.ListMyTreesResponse]
-	deleteTree          *connect.Client[v1.DeleteTreeRequest, v1.DeleteTreeResponse]
-	shareTree           *connect.Client[v1.ShareTreeRequest, v1.ShareTreeResponse]
-	updateShare         *connect.Client[v1.UpdateShareRequest, v1.UpdateShareResponse]
-	removeShare         *connect.Client[v1.RemoveShareRequest, v1.RemoveShareResponse]
-	listTreeShares      *connect.Client[v1.ListTreeSharesRequest, v1.ListTreeSharesResponse]
-	listSharedWithMe    *connect.Client[v1.ListSharedWithMeRequest, v1.ListSharedWithMeResponse]
-	getMyRole           *connect.Client[v1.GetMyRoleRequest, v1.GetMyRoleResponse]
-	generateShareLink   *connect.Client[v1.GenerateShareLinkRequest, v1.GenerateShareLinkResponse]
-	getTreeByShareToken *connect.Client[v1.GetTreeByShareTokenRequest, v1.GetTreeByShareTokenResponse]
+	createTree                     *connect.Client[v1.CreateTreeRequest, v1.CreateTreeResponse]
+	getTree                        *connect.Client[v1.GetTreeRequest, v1.GetTreeResponse]
+	listMyTrees                    *connect.Client[v1.ListMyTreesRequest, v1.ListMyTreesResponse]
+	deleteTree                     *connect.Client[v1.DeleteTreeRequest, v1.DeleteTreeResponse]
+	updateContactPrivacy           *connect.Client[v1.UpdateContactPrivacyRequest, v1.UpdateContactPrivacyResponse]
+	cloneTree                      *connect.Client[v1.CloneTreeRequest, v1.CloneTreeResponse]
+	saveTreeAsTemplate             *connect.Client[v1.SaveTreeAsTemplateRequest, v1.SaveTreeAsTemplateResponse]
+	listTreeTemplates              *connect.Client[v1.ListTreeTemplatesRequest, v1.ListTreeTemplatesResponse]
+	deleteTreeTemplate             *connect.Client[v1.DeleteTreeTemplateRequest, v1.DeleteTreeTemplateResponse]
+	shareTree                      *connect.Client[v1.ShareTreeRequest, v1.ShareTreeResponse]
+	updateShare                    *connect.Client[v1.UpdateShareRequest, v1.UpdateShareResponse]
+	removeShare                    *connect.Client[v1.RemoveShareRequest, v1.RemoveShareResponse]
+	listTreeShares                 *connect.Client[v1.ListTreeSharesRequest, v1.ListTreeSharesResponse]
+	listSharedWithMe               *connect.Client[v1.ListSharedWithMeRequest, v1.ListSharedWithMeResponse]
+	listAccessibleTrees            *connect.Client[v1.ListAccessibleTreesRequest, v1.ListAccessibleTreesResponse]
+	getMyRole                      *connect.Client[v1.GetMyRoleRequest, v1.GetMyRoleResponse]
+	listTreeInvitations            *connect.Client[v1.ListTreeInvitationsRequest, v1.ListTreeInvitationsResponse]
+	resendTreeInvitation           *connect.Client[v1.ResendTreeInvitationRequest, v1.ResendTreeInvitationResponse]
+	cancelTreeInvitation           *connect.Client[v1.CancelTreeInvitationRequest, v1.CancelTreeInvitationResponse]
+	transferOwnership              *connect.Client[v1.TransferOwnershipRequest, v1.TransferOwnershipResponse]
+	respondOwnershipTransfer       *connect.Client[v1.RespondOwnershipTransferRequest, v1.RespondOwnershipTransferResponse]
+	cancelOwnershipTransfer        *connect.Client[v1.CancelOwnershipTransferRequest, v1.CancelOwnershipTransferResponse]
+	listOwnershipTransfers         *connect.Client[v1.ListOwnershipTransfersRequest, v1.ListOwnershipTransfersResponse]
+	listIncomingOwnershipTransfers *connect.Client[v1.ListIncomingOwnershipTransfersRequest, v1.ListIncomingOwnershipTransfersResponse]
+	getTreeStats                   *connect.Client[v1.GetTreeStatsRequest, v1.GetTreeStatsResponse]
+	getFacultyStats                *connect.Client[v1.GetFacultyStatsRequest, v1.GetFacultyStatsResponse]
+	repairTreeStructure            *connect.Client[v1.RepairTreeStructureRequest, v1.RepairTreeStructureResponse]
+	listAuditEvents                *connect.Client[v1.ListAuditEventsRequest, v1.ListAuditEventsResponse]
+	generateShareLink              *connect.Client[v1.GenerateShareLinkRequest, v1.GenerateShareLinkResponse]
+	getTreeByShareToken            *connect.Client[v1.GetTreeByShareTokenRequest, v1.GetTreeByShareTokenResponse]
+	listShareLinks                 *connect.Client[v1.ListShareLinksRequest, v1.ListShareLinksResponse]
+	revokeShareLink                *connect.Client[v1.RevokeShareLinkRequest, v1.RevokeShareLinkResponse]
+	rotateShareLink                *connect.Client[v1.RotateShareLinkRequest, v1.RotateShareLinkResponse]
+	joinShareLink                  *connect.Client[v1.JoinShareLinkRequest, v1.JoinShareLinkResponse]
 }
 
 // CreateTree calls tree.v1.TreeService.CreateTree.
@@ -203,6 +449,31 @@ func (c *treeServiceClient) DeleteTree(ctx context.Context, req *connect.Request
 	return c.deleteTree.CallUnary(ctx, req)
 }
 
+// UpdateContactPrivacy calls tree.v1.TreeService.UpdateContactPrivacy.
+func (c *treeServiceClient) UpdateContactPrivacy(ctx context.Context, req *connect.Request[v1.UpdateContactPrivacyRequest]) (*connect.Response[v1.UpdateContactPrivacyResponse], error) {
+	return c.updateContactPrivacy.CallUnary(ctx, req)
+}
+
+// CloneTree calls tree.v1.TreeService.CloneTree.
+func (c *treeServiceClient) CloneTree(ctx context.Context, req *connect.Request[v1.CloneTreeRequest]) (*connect.Response[v1.CloneTreeResponse], error) {
+	return c.cloneTree.CallUnary(ctx, req)
+}
+
+// SaveTreeAsTemplate calls tree.v1.TreeService.SaveTreeAsTemplate.
+func (c *treeServiceClient) SaveTreeAsTemplate(ctx context.Context, req *connect.Request[v1.SaveTreeAsTemplateRequest]) (*connect.Response[v1.SaveTreeAsTemplateResponse], error) {
+	return c.saveTreeAsTemplate.CallUnary(ctx, req)
+}
+
+// ListTreeTemplates calls tree.v1.TreeService.ListTreeTemplates.
+func (c *treeServiceClient) ListTreeTemplates(ctx context.Context, req *connect.Request[v1.ListTreeTemplatesRequest]) (*connect.Response[v1.ListTreeTemplatesResponse], error) {
+	return c.listTreeTemplates.CallUnary(ctx, req)
+}
+
+// DeleteTreeTemplate calls tree.v1.TreeService.DeleteTreeTemplate.
+func (c *treeServiceClient) DeleteTreeTemplate(ctx context.Context, req *connect.Request[v1.DeleteTreeTemplateRequest]) (*connect.Response[v1.DeleteTreeTemplateResponse], error) {
+	return c.deleteTreeTemplate.CallUnary(ctx, req)
+}
+
 // ShareTree calls tree.v1.TreeService.ShareTree.
 func (c *treeServiceClient) ShareTree(ctx context.Context, req *connect.Request[v1.ShareTreeRequest]) (*connect.Response[v1.ShareTreeResponse], error) {
 	return c.shareTree.CallUnary(ctx, req)
@@ -228,11 +499,76 @@ func (c *treeServiceClient) ListSharedWithMe(ctx context.Context, req *connect.R
 	return c.listSharedWithMe.CallUnary(ctx, req)
 }
 
+// ListAccessibleTrees calls tree.v1.TreeService.ListAccessibleTrees.
+func (c *treeServiceClient) ListAccessibleTrees(ctx context.Context, req *connect.Request[v1.ListAccessibleTreesRequest]) (*connect.Response[v1.ListAccessibleTreesResponse], error) {
+	return c.listAccessibleTrees.CallUnary(ctx, req)
+}
+
 // GetMyRole calls tree.v1.TreeService.GetMyRole.
 func (c *treeServiceClient) GetMyRole(ctx context.Context, req *connect.Request[v1.GetMyRoleRequest]) (*connect.Response[v1.GetMyRoleResponse], error) {
 	return c.getMyRole.CallUnary(ctx, req)
 }
 
+// ListTreeInvitations calls tree.v1.TreeService.ListTreeInvitations.
+func (c *treeServiceClient) ListTreeInvitations(ctx context.Context, req *connect.Request[v1.ListTreeInvitationsRequest]) (*connect.Response[v1.ListTreeInvitationsResponse], error) {
+	return c.listTreeInvitations.CallUnary(ctx, req)
+}
+
+// ResendTreeInvitation calls tree.v1.TreeService.ResendTreeInvitation.
+func (c *treeServiceClient) ResendTreeInvitation(ctx context.Context, req *connect.Request[v1.ResendTreeInvitationRequest]) (*connect.Response[v1.ResendTreeInvitationResponse], error) {
+	return c.resendTreeInvitation.CallUnary(ctx, req)
+}
+
+// CancelTreeInvitation calls tree.v1.TreeService.CancelTreeInvitation.
+func (c *treeServiceClient) CancelTreeInvitation(ctx context.Context, req *connect.Request[v1.CancelTreeInvitationRequest]) (*connect.Response[v1.CancelTreeInvitationResponse], error) {
+	return c.cancelTreeInvitation.CallUnary(ctx, req)
+}
+
+// TransferOwnership calls tree.v1.TreeService.TransferOwnership.
+func (c *treeServiceClient) TransferOwnership(ctx context.Context, req *connect.Request[v1.TransferOwnershipRequest]) (*connect.Response[v1.TransferOwnershipResponse], error) {
+	return c.transferOwnership.CallUnary(ctx, req)
+}
+
+// RespondOwnershipTransfer calls tree.v1.TreeService.RespondOwnershipTransfer.
+func (c *treeServiceClient) RespondOwnershipTransfer(ctx context.Context, req *connect.Request[v1.RespondOwnershipTransferRequest]) (*connect.Response[v1.RespondOwnershipTransferResponse], error) {
+	return c.respondOwnershipTransfer.CallUnary(ctx, req)
+}
+
+// CancelOwnershipTransfer calls tree.v1.TreeService.CancelOwnershipTransfer.
+func (c *treeServiceClient) CancelOwnershipTransfer(ctx context.Context, req *connect.Request[v1.CancelOwnershipTransferRequest]) (*connect.Response[v1.CancelOwnershipTransferResponse], error) {
+	return c.cancelOwnershipTransfer.CallUnary(ctx, req)
+}
+
+// ListOwnershipTransfers calls tree.v1.TreeService.ListOwnershipTransfers.
+func (c *treeServiceClient) ListOwnershipTransfers(ctx context.Context, req *connect.Request[v1.ListOwnershipTransfersRequest]) (*connect.Response[v1.ListOwnershipTransfersResponse], error) {
+	return c.listOwnershipTransfers.CallUnary(ctx, req)
+}
+
+// ListIncomingOwnershipTransfers calls tree.v1.TreeService.ListIncomingOwnershipTransfers.
+func (c *treeServiceClient) ListIncomingOwnershipTransfers(ctx context.Context, req *connect.Request[v1.ListIncomingOwnershipTransfersRequest]) (*connect.Response[v1.ListIncomingOwnershipTransfersResponse], error) {
+	return c.listIncomingOwnershipTransfers.CallUnary(ctx, req)
+}
+
+// GetTreeStats calls tree.v1.TreeService.GetTreeStats.
+func (c *treeServiceClient) GetTreeStats(ctx context.Context, req *connect.Request[v1.GetTreeStatsRequest]) (*connect.Response[v1.GetTreeStatsResponse], error) {
+	return c.getTreeStats.CallUnary(ctx, req)
+}
+
+// GetFacultyStats calls tree.v1.TreeService.GetFacultyStats.
+func (c *treeServiceClient) GetFacultyStats(ctx context.Context, req *connect.Request[v1.GetFacultyStatsRequest]) (*connect.Response[v1.GetFacultyStatsResponse], error) {
+	return c.getFacultyStats.CallUnary(ctx, req)
+}
+
+// RepairTreeStructure calls tree.v1.TreeService.RepairTreeStructure.
+func (c *treeServiceClient) RepairTreeStructure(ctx context.Context, req *connect.Request[v1.RepairTreeStructureRequest]) (*connect.Response[v1.RepairTreeStructureResponse], error) {
+	return c.repairTreeStructure.CallUnary(ctx, req)
+}
+
+// ListAuditEvents calls tree.v1.TreeService.ListAuditEvents.
+func (c *treeServiceClient) ListAuditEvents(ctx context.Context, req *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error) {
+	return c.listAuditEvents.CallUnary(ctx, req)
+}
+
 // GenerateShareLink calls tree.v1.TreeService.GenerateShareLink.
 func (c *treeServiceClient) GenerateShareLink(ctx context.Context, req *connect.Request[v1.GenerateShareLinkRequest]) (*connect.Response[v1.GenerateShareLinkResponse], error) {
 	return c.generateShareLink.CallUnary(ctx, req)
@@ -243,22 +579,69 @@ func (c *treeServiceClient) GetTreeByShareToken(ctx context.Context, req *connec
 	return c.getTreeByShareToken.CallUnary(ctx, req)
 }
 
+// ListShareLinks calls tree.v1.TreeService.ListShareLinks.
+func (c *treeServiceClient) ListShareLinks(ctx context.Context, req *connect.Request[v1.ListShareLinksRequest]) (*connect.Response[v1.ListShareLinksResponse], error) {
+	return c.listShareLinks.CallUnary(ctx, req)
+}
+
+// RevokeShareLink calls tree.v1.TreeService.RevokeShareLink.
+func (c *treeServiceClient) RevokeShareLink(ctx context.Context, req *connect.Request[v1.RevokeShareLinkRequest]) (*connect.Response[v1.RevokeShareLinkResponse], error) {
+	return c.revokeShareLink.CallUnary(ctx, req)
+}
+
+// RotateShareLink calls tree.v1.TreeService.RotateShareLink.
+func (c *treeServiceClient) RotateShareLink(ctx context.Context, req *connect.Request[v1.RotateShareLinkRequest]) (*connect.Response[v1.RotateShareLinkResponse], error) {
+	return c.rotateShareLink.CallUnary(ctx, req)
+}
+
+// JoinShareLink calls tree.v1.TreeService.JoinShareLink.
+func (c *treeServiceClient) JoinShareLink(ctx context.Context, req *connect.Request[v1.JoinShareLinkRequest]) (*connect.Response[v1.JoinShareLinkResponse], error) {
+	return c.joinShareLink.CallUnary(ctx, req)
+}
+
 // TreeServiceHandler is an implementation of the tree.v1.TreeService service.
 type TreeServiceHandler interface {
 	CreateTree(context.Context, *connect.Request[v1.CreateTreeRequest]) (*connect.Response[v1.CreateTreeResponse], error)
 	GetTree(context.Context, *connect.Request[v1.GetTreeRequest]) (*connect.Response[v1.GetTreeResponse], error)
 	ListMyTrees(context.Context, *connect.Request[v1.ListMyTreesRequest]) (*connect.Response[v1.ListMyTreesResponse], error)
 	DeleteTree(context.Context, *connect.Request[v1.DeleteTreeRequest]) (*connect.Response[v1.DeleteTreeResponse], error)
+	UpdateContactPrivacy(context.Context, *connect.Request[v1.UpdateContactPrivacyRequest]) (*connect.Response[v1.UpdateContactPrivacyResponse], error)
+	// ★ Clone / templates
+	CloneTree(context.Context, *connect.Request[v1.CloneTreeRequest]) (*connect.Response[v1.CloneTreeResponse], error)
+	SaveTreeAsTemplate(context.Context, *connect.Request[v1.SaveTreeAsTemplateRequest]) (*connect.Response[v1.SaveTreeAsTemplateResponse], error)
+	ListTreeTemplates(context.Context, *connect.Request[v1.ListTreeTemplatesRequest]) (*connect.Response[v1.ListTreeTemplatesResponse], error)
+	DeleteTreeTemplate(context.Context, *connect.Request[v1.DeleteTreeTemplateRequest]) (*connect.Response[v1.DeleteTreeTemplateResponse], error)
 	// ★ Sharing (ต้อง login)
 	ShareTree(context.Context, *connect.Request[v1.ShareTreeRequest]) (*connect.Response[v1.ShareTreeResponse], error)
 	UpdateShare(context.Context, *connect.Request[v1.UpdateShareRequest]) (*connect.Response[v1.UpdateShareResponse], error)
 	RemoveShare(context.Context, *connect.Request[v1.RemoveShareRequest]) (*connect.Response[v1.RemoveShareResponse], error)
 	ListTreeShares(context.Context, *connect.Request[v1.ListTreeSharesRequest]) (*connect.Response[v1.ListTreeSharesResponse], error)
 	ListSharedWithMe(context.Context, *connect.Request[v1.ListSharedWithMeRequest]) (*connect.Response[v1.ListSharedWithMeResponse], error)
+	ListAccessibleTrees(context.Context, *connect.Request[v1.ListAccessibleTreesRequest]) (*connect.Response[v1.ListAccessibleTreesResponse], error)
 	GetMyRole(context.Context, *connect.Request[v1.GetMyRoleRequest]) (*connect.Response[v1.GetMyRoleResponse], error)
+	ListTreeInvitations(context.Context, *connect.Request[v1.ListTreeInvitationsRequest]) (*connect.Response[v1.ListTreeInvitationsResponse], error)
+	ResendTreeInvitation(context.Context, *connect.Request[v1.ResendTreeInvitationRequest]) (*connect.Response[v1.ResendTreeInvitationResponse], error)
+	CancelTreeInvitation(context.Context, *connect.Request[v1.CancelTreeInvitationRequest]) (*connect.Response[v1.CancelTreeInvitationResponse], error)
+	// ★ Ownership transfer
+	TransferOwnership(context.Context, *connect.Request[v1.TransferOwnershipRequest]) (*connect.Response[v1.TransferOwnershipResponse], error)
+	RespondOwnershipTransfer(context.Context, *connect.Request[v1.RespondOwnershipTransferRequest]) (*connect.Response[v1.RespondOwnershipTransferResponse], error)
+	CancelOwnershipTransfer(context.Context, *connect.Request[v1.CancelOwnershipTransferRequest]) (*connect.Response[v1.CancelOwnershipTransferResponse], error)
+	ListOwnershipTransfers(context.Context, *connect.Request[v1.ListOwnershipTransfersRequest]) (*connect.Response[v1.ListOwnershipTransfersResponse], error)
+	ListIncomingOwnershipTransfers(context.Context, *connect.Request[v1.ListIncomingOwnershipTransfersRequest]) (*connect.Response[v1.ListIncomingOwnershipTransfersResponse], error)
+	// ★ Stats
+	GetTreeStats(context.Context, *connect.Request[v1.GetTreeStatsRequest]) (*connect.Response[v1.GetTreeStatsResponse], error)
+	GetFacultyStats(context.Context, *connect.Request[v1.GetFacultyStatsRequest]) (*connect.Response[v1.GetFacultyStatsResponse], error)
+	// ★ Integrity
+	RepairTreeStructure(context.Context, *connect.Request[v1.RepairTreeStructureRequest]) (*connect.Response[v1.RepairTreeStructureResponse], error)
+	// ★ Audit
+	ListAuditEvents(context.Context, *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error)
 	// ★ Public share link
 	GenerateShareLink(context.Context, *connect.Request[v1.GenerateShareLinkRequest]) (*connect.Response[v1.GenerateShareLinkResponse], error)
 	GetTreeByShareToken(context.Context, *connect.Request[v1.GetTreeByShareTokenRequest]) (*connect.Response[v1.GetTreeByShareTokenResponse], error)
+	ListShareLinks(context.Context, *connect.Request[v1.ListShareLinksRequest]) (*connect.Response[v1.ListShareLinksResponse], error)
+	RevokeShareLink(context.Context, *connect.Request[v1.RevokeShareLinkRequest]) (*connect.Response[v1.RevokeShareLinkResponse], error)
+	RotateShareLink(context.Context, *connect.Request[v1.RotateShareLinkRequest]) (*connect.Response[v1.RotateShareLinkResponse], error)
+	JoinShareLink(context.Context, *connect.Request[v1.JoinShareLinkRequest]) (*connect.Response[v1.JoinShareLinkResponse], error)
 }
 
 // NewTreeServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -292,6 +675,36 @@ func NewTreeServiceHandler(svc TreeServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(treeServiceMethods.ByName("DeleteTree")),
 		connect.WithHandlerOptions(opts...),
 	)
+	treeServiceUpdateContactPrivacyHandler := connect.NewUnaryHandler(
+		TreeServiceUpdateContactPrivacyProcedure,
+		svc.UpdateContactPrivacy,
+		connect.WithSchema(treeServiceMethods.ByName("UpdateContactPrivacy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	treeServiceCloneTreeHandler := connect.NewUnaryHandler(
+		TreeServiceCloneTreeProcedure,
+		svc.CloneTree,
+		connect.WithSchema(treeServiceMethods.ByName("CloneTree")),
+		connect.WithHandlerOptions(opts...),
+	)
+	treeServiceSaveTreeAsTemplateHandler := connect.NewUnaryHandler(
+		TreeServiceSaveTreeAsTemplateProcedure,
+		svc.SaveTreeAsTemplate,
+		connect.WithSchema(treeServiceMethods.ByName("SaveTreeAsTemplate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	treeServiceListTreeTemplatesHandler := connect.NewUnaryHandler(
+		TreeServiceListTreeTemplatesProcedure,
+		svc.ListTreeTemplates,
+		connect.WithSchema(treeServiceMethods.ByName("ListTreeTemplates")),
+		connect.WithHandlerOptions(opts...),
+	)
+	treeServiceDeleteTreeTemplateHandler := connect.NewUnaryHandler(
+		TreeServiceDeleteTreeTemplateProcedure,
+		svc.DeleteTreeTemplate,
+		connect.WithSchema(treeServiceMethods.ByName("DeleteTreeTemplate")),
+		connect.WithHandlerOptions(opts...),
+	)
 	treeServiceShareTreeHandler := connect.NewUnaryHandler(
 		TreeServiceShareTreeProcedure,
 		svc.ShareTree,
@@ -322,12 +735,90 @@ func NewTreeServiceHandler(svc TreeServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(treeServiceMethods.ByName("ListSharedWithMe")),
 		connect.WithHandlerOptions(opts...),
 	)
+	treeServiceListAccessibleTreesHandler := connect.NewUnaryHandler(
+		TreeServiceListAccessibleTreesProcedure,
+		svc.ListAccessibleTrees,
+		connect.WithSchema(treeServiceMethods.ByName("ListAccessibleTrees")),
+		connect.WithHandlerOptions(opts...),
+	)
 	treeServiceGetMyRoleHandler := connect.NewUnaryHandler(
 		TreeServiceGetMyRoleProcedure,
 		svc.GetMyRole,
 		connect.WithSchema(treeServiceMethods.ByName("GetMyRole")),
 		connect.WithHandlerOptions(opts...),
 	)
+	treeServiceListTreeInvitationsHandler := connect.NewUnaryHandler(
+		TreeServiceListTreeInvitationsProcedure,
+		svc.ListTreeInvitations,
+		connect.WithSchema(treeServiceMethods.ByName("ListTreeInvitations")),
+		connect.WithHandlerOptions(opts...),
+	)
+	treeServiceResendTreeInvitationHandler := connect.NewUnaryHandler(
+		TreeServiceResendTreeInvitationProcedure,
+		svc.ResendTreeInvitation,
+		connect.WithSchema(treeServiceMethods.ByName("ResendTreeInvitation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	treeServiceCancelTreeInvitationHandler := connect.NewUnaryHandler(
+		TreeServiceCancelTreeInvitationProcedure,
+		svc.CancelTreeInvitation,
+		connect.WithSchema(treeServiceMethods.ByName("CancelTreeInvitation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	treeServiceTransferOwnershipHandler := connect.NewUnaryHandler(
+		TreeServiceTransferOwnershipProcedure,
+		svc.TransferOwnership,
+		connect.WithSchema(treeServiceMethods.ByName("TransferOwnership")),
+		connect.WithHandlerOptions(opts...),
+	)
+	treeServiceRespondOwnershipTransferHandler := connect.NewUnaryHandler(
+		TreeServiceRespondOwnershipTransferProcedure,
+		svc.RespondOwnershipTransfer,
+		connect.WithSchema(treeServiceMethods.ByName("RespondOwnershipTransfer")),
+		connect.WithHandlerOptions(opts...),
+	)
+	treeServiceCancelOwnershipTransferHandler := connect.NewUnaryHandler(
+		TreeServiceCancelOwnershipTransferProcedure,
+		svc.CancelOwnershipTransfer,
+		connect.WithSchema(treeServiceMethods.ByName("CancelOwnershipTransfer")),
+		connect.WithHandlerOptions(opts...),
+	)
+	treeServiceListOwnershipTransfersHandler := connect.NewUnaryHandler(
+		TreeServiceListOwnershipTransfersProcedure,
+		svc.ListOwnershipTransfers,
+		connect.WithSchema(treeServiceMethods.ByName("ListOwnershipTransfers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	treeServiceListIncomingOwnershipTransfersHandler := connect.NewUnaryHandler(
+		TreeServiceListIncomingOwnershipTransfersProcedure,
+		svc.ListIncomingOwnershipTransfers,
+		connect.WithSchema(treeServiceMethods.ByName("ListIncomingOwnershipTransfers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	treeServiceGetTreeStatsHandler := connect.NewUnaryHandler(
+		TreeServiceGetTreeStatsProcedure,
+		svc.GetTreeStats,
+		connect.WithSchema(treeServiceMethods.ByName("GetTreeStats")),
+		connect.WithHandlerOptions(opts...),
+	)
+	treeServiceGetFacultyStatsHandler := connect.NewUnaryHandler(
+		TreeServiceGetFacultyStatsProcedure,
+		svc.GetFacultyStats,
+		connect.WithSchema(treeServiceMethods.ByName("GetFacultyStats")),
+		connect.WithHandlerOptions(opts...),
+	)
+	treeServiceRepairTreeStructureHandler := connect.NewUnaryHandler(
+		TreeServiceRepairTreeStructureProcedure,
+		svc.RepairTreeStructure,
+		connect.WithSchema(treeServiceMethods.ByName("RepairTreeStructure")),
+		connect.WithHandlerOptions(opts...),
+	)
+	treeServiceListAuditEventsHandler := connect.NewUnaryHandler(
+		TreeServiceListAuditEventsProcedure,
+		svc.ListAuditEvents,
+		connect.WithSchema(treeServiceMethods.ByName("ListAuditEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
 	treeServiceGenerateShareLinkHandler := connect.NewUnaryHandler(
 		TreeServiceGenerateShareLinkProcedure,
 		svc.GenerateShareLink,
@@ -340,6 +831,30 @@ func NewTreeServiceHandler(svc TreeServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(treeServiceMethods.ByName("GetTreeByShareToken")),
 		connect.WithHandlerOptions(opts...),
 	)
+	treeServiceListShareLinksHandler := connect.NewUnaryHandler(
+		TreeServiceListShareLinksProcedure,
+		svc.ListShareLinks,
+		connect.WithSchema(treeServiceMethods.ByName("ListShareLinks")),
+		connect.WithHandlerOptions(opts...),
+	)
+	treeServiceRevokeShareLinkHandler := connect.NewUnaryHandler(
+		TreeServiceRevokeShareLinkProcedure,
+		svc.RevokeShareLink,
+		connect.WithSchema(treeServiceMethods.ByName("RevokeShareLink")),
+		connect.WithHandlerOptions(opts...),
+	)
+	treeServiceRotateShareLinkHandler := connect.NewUnaryHandler(
+		TreeServiceRotateShareLinkProcedure,
+		svc.RotateShareLink,
+		connect.WithSchema(treeServiceMethods.ByName("RotateShareLink")),
+		connect.WithHandlerOptions(opts...),
+	)
+	treeServiceJoinShareLinkHandler := connect.NewUnaryHandler(
+		TreeServiceJoinShareLinkProcedure,
+		svc.JoinShareLink,
+		connect.WithSchema(treeServiceMethods.ByName("JoinShareLink")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/tree.v1.TreeService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case TreeServiceCreateTreeProcedure:
@@ -350,6 +865,16 @@ func NewTreeServiceHandler(svc TreeServiceHandler, opts ...connect.HandlerOption
 			treeServiceListMyTreesHandler.ServeHTTP(w, r)
 		case TreeServiceDeleteTreeProcedure:
 			treeServiceDeleteTreeHandler.ServeHTTP(w, r)
+		case TreeServiceUpdateContactPrivacyProcedure:
+			treeServiceUpdateContactPrivacyHandler.ServeHTTP(w, r)
+		case TreeServiceCloneTreeProcedure:
+			treeServiceCloneTreeHandler.ServeHTTP(w, r)
+		case TreeServiceSaveTreeAsTemplateProcedure:
+			treeServiceSaveTreeAsTemplateHandler.ServeHTTP(w, r)
+		case TreeServiceListTreeTemplatesProcedure:
+			treeServiceListTreeTemplatesHandler.ServeHTTP(w, r)
+		case TreeServiceDeleteTreeTemplateProcedure:
+			treeServiceDeleteTreeTemplateHandler.ServeHTTP(w, r)
 		case TreeServiceShareTreeProcedure:
 			treeServiceShareTreeHandler.ServeHTTP(w, r)
 		case TreeServiceUpdateShareProcedure:
@@ -360,12 +885,46 @@ func NewTreeServiceHandler(svc TreeServiceHandler, opts ...connect.HandlerOption
 			treeServiceListTreeSharesHandler.ServeHTTP(w, r)
 		case TreeServiceListSharedWithMeProcedure:
 			treeServiceListSharedWithMeHandler.ServeHTTP(w, r)
+		case TreeServiceListAccessibleTreesProcedure:
+			treeServiceListAccessibleTreesHandler.ServeHTTP(w, r)
 		case TreeServiceGetMyRoleProcedure:
 			treeServiceGetMyRoleHandler.ServeHTTP(w, r)
+		case TreeServiceListTreeInvitationsProcedure:
+			treeServiceListTreeInvitationsHandler.ServeHTTP(w, r)
+		case TreeServiceResendTreeInvitationProcedure:
+			treeServiceResendTreeInvitationHandler.ServeHTTP(w, r)
+		case TreeServiceCancelTreeInvitationProcedure:
+			treeServiceCancelTreeInvitationHandler.ServeHTTP(w, r)
+		case TreeServiceTransferOwnershipProcedure:
+			treeServiceTransferOwnershipHandler.ServeHTTP(w, r)
+		case TreeServiceRespondOwnershipTransferProcedure:
+			treeServiceRespondOwnershipTransferHandler.ServeHTTP(w, r)
+		case TreeServiceCancelOwnershipTransferProcedure:
+			treeServiceCancelOwnershipTransferHandler.ServeHTTP(w, r)
+		case TreeServiceListOwnershipTransfersProcedure:
+			treeServiceListOwnershipTransfersHandler.ServeHTTP(w, r)
+		case TreeServiceListIncomingOwnershipTransfersProcedure:
+			treeServiceListIncomingOwnershipTransfersHandler.ServeHTTP(w, r)
+		case TreeServiceGetTreeStatsProcedure:
+			treeServiceGetTreeStatsHandler.ServeHTTP(w, r)
+		case TreeServiceGetFacultyStatsProcedure:
+			treeServiceGetFacultyStatsHandler.ServeHTTP(w, r)
+		case TreeServiceRepairTreeStructureProcedure:
+			treeServiceRepairTreeStructureHandler.ServeHTTP(w, r)
+		case TreeServiceListAuditEventsProcedure:
+			treeServiceListAuditEventsHandler.ServeHTTP(w, r)
 		case TreeServiceGenerateShareLinkProcedure:
 			treeServiceGenerateShareLinkHandler.ServeHTTP(w, r)
 		case TreeServiceGetTreeByShareTokenProcedure:
 			treeServiceGetTreeByShareTokenHandler.ServeHTTP(w, r)
+		case TreeServiceListShareLinksProcedure:
+			treeServiceListShareLinksHandler.ServeHTTP(w, r)
+		case TreeServiceRevokeShareLinkProcedure:
+			treeServiceRevokeShareLinkHandler.ServeHTTP(w, r)
+		case TreeServiceRotateShareLinkProcedure:
+			treeServiceRotateShareLinkHandler.ServeHTTP(w, r)
+		case TreeServiceJoinShareLinkProcedure:
+			treeServiceJoinShareLinkHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -391,6 +950,26 @@ func (UnimplementedTreeServiceHandler) DeleteTree(context.Context, *connect.Requ
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.DeleteTree is not implemented"))
 }
 
+func (UnimplementedTreeServiceHandler) UpdateContactPrivacy(context.Context, *connect.Request[v1.UpdateContactPrivacyRequest]) (*connect.Response[v1.UpdateContactPrivacyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.UpdateContactPrivacy is not implemented"))
+}
+
+func (UnimplementedTreeServiceHandler) CloneTree(context.Context, *connect.Request[v1.CloneTreeRequest]) (*connect.Response[v1.CloneTreeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.CloneTree is not implemented"))
+}
+
+func (UnimplementedTreeServiceHandler) SaveTreeAsTemplate(context.Context, *connect.Request[v1.SaveTreeAsTemplateRequest]) (*connect.Response[v1.SaveTreeAsTemplateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.SaveTreeAsTemplate is not implemented"))
+}
+
+func (UnimplementedTreeServiceHandler) ListTreeTemplates(context.Context, *connect.Request[v1.ListTreeTemplatesRequest]) (*connect.Response[v1.ListTreeTemplatesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.ListTreeTemplates is not implemented"))
+}
+
+func (UnimplementedTreeServiceHandler) DeleteTreeTemplate(context.Context, *connect.Request[v1.DeleteTreeTemplateRequest]) (*connect.Response[v1.DeleteTreeTemplateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.DeleteTreeTemplate is not implemented"))
+}
+
 func (UnimplementedTreeServiceHandler) ShareTree(context.Context, *connect.Request[v1.ShareTreeRequest]) (*connect.Response[v1.ShareTreeResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.ShareTree is not implemented"))
 }
@@ -411,10 +990,62 @@ func (UnimplementedTreeServiceHandler) ListSharedWithMe(context.Context, *connec
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.ListSharedWithMe is not implemented"))
 }
 
+func (UnimplementedTreeServiceHandler) ListAccessibleTrees(context.Context, *connect.Request[v1.ListAccessibleTreesRequest]) (*connect.Response[v1.ListAccessibleTreesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.ListAccessibleTrees is not implemented"))
+}
+
 func (UnimplementedTreeServiceHandler) GetMyRole(context.Context, *connect.Request[v1.GetMyRoleRequest]) (*connect.Response[v1.GetMyRoleResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.GetMyRole is not implemented"))
 }
 
+func (UnimplementedTreeServiceHandler) ListTreeInvitations(context.Context, *connect.Request[v1.ListTreeInvitationsRequest]) (*connect.Response[v1.ListTreeInvitationsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.ListTreeInvitations is not implemented"))
+}
+
+func (UnimplementedTreeServiceHandler) ResendTreeInvitation(context.Context, *connect.Request[v1.ResendTreeInvitationRequest]) (*connect.Response[v1.ResendTreeInvitationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.ResendTreeInvitation is not implemented"))
+}
+
+func (UnimplementedTreeServiceHandler) CancelTreeInvitation(context.Context, *connect.Request[v1.CancelTreeInvitationRequest]) (*connect.Response[v1.CancelTreeInvitationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.CancelTreeInvitation is not implemented"))
+}
+
+func (UnimplementedTreeServiceHandler) TransferOwnership(context.Context, *connect.Request[v1.TransferOwnershipRequest]) (*connect.Response[v1.TransferOwnershipResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.TransferOwnership is not implemented"))
+}
+
+func (UnimplementedTreeServiceHandler) RespondOwnershipTransfer(context.Context, *connect.Request[v1.RespondOwnershipTransferRequest]) (*connect.Response[v1.RespondOwnershipTransferResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.RespondOwnershipTransfer is not implemented"))
+}
+
+func (UnimplementedTreeServiceHandler) CancelOwnershipTransfer(context.Context, *connect.Request[v1.CancelOwnershipTransferRequest]) (*connect.Response[v1.CancelOwnershipTransferResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.CancelOwnershipTransfer is not implemented"))
+}
+
+func (UnimplementedTreeServiceHandler) ListOwnershipTransfers(context.Context, *connect.Request[v1.ListOwnershipTransfersRequest]) (*connect.Response[v1.ListOwnershipTransfersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.ListOwnershipTransfers is not implemented"))
+}
+
+func (UnimplementedTreeServiceHandler) ListIncomingOwnershipTransfers(context.Context, *connect.Request[v1.ListIncomingOwnershipTransfersRequest]) (*connect.Response[v1.ListIncomingOwnershipTransfersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.ListIncomingOwnershipTransfers is not implemented"))
+}
+
+func (UnimplementedTreeServiceHandler) GetTreeStats(context.Context, *connect.Request[v1.GetTreeStatsRequest]) (*connect.Response[v1.GetTreeStatsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.GetTreeStats is not implemented"))
+}
+
+func (UnimplementedTreeServiceHandler) GetFacultyStats(context.Context, *connect.Request[v1.GetFacultyStatsRequest]) (*connect.Response[v1.GetFacultyStatsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.GetFacultyStats is not implemented"))
+}
+
+func (UnimplementedTreeServiceHandler) RepairTreeStructure(context.Context, *connect.Request[v1.RepairTreeStructureRequest]) (*connect.Response[v1.RepairTreeStructureResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.RepairTreeStructure is not implemented"))
+}
+
+func (UnimplementedTreeServiceHandler) ListAuditEvents(context.Context, *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.ListAuditEvents is not implemented"))
+}
+
 func (UnimplementedTreeServiceHandler) GenerateShareLink(context.Context, *connect.Request[v1.GenerateShareLinkRequest]) (*connect.Response[v1.GenerateShareLinkResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.GenerateShareLink is not implemented"))
 }
@@ -422,3 +1053,19 @@ func (UnimplementedTreeServiceHandler) GenerateShareLink(context.Context, *conne
 func (UnimplementedTreeServiceHandler) GetTreeByShareToken(context.Context, *connect.Request[v1.GetTreeByShareTokenRequest]) (*connect.Response[v1.GetTreeByShareTokenResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.GetTreeByShareToken is not implemented"))
 }
+
+func (UnimplementedTreeServiceHandler) ListShareLinks(context.Context, *connect.Request[v1.ListShareLinksRequest]) (*connect.Response[v1.ListShareLinksResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.ListShareLinks is not implemented"))
+}
+
+func (UnimplementedTreeServiceHandler) RevokeShareLink(context.Context, *connect.Request[v1.RevokeShareLinkRequest]) (*connect.Response[v1.RevokeShareLinkResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.RevokeShareLink is not implemented"))
+}
+
+func (UnimplementedTreeServiceHandler) RotateShareLink(context.Context, *connect.Request[v1.RotateShareLinkRequest]) (*connect.Response[v1.RotateShareLinkResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.RotateShareLink is not implemented"))
+}
+
+func (UnimplementedTreeServiceHandler) JoinShareLink(context.Context, *connect.Request[v1.JoinShareLinkRequest]) (*connect.Response[v1.JoinShareLinkResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("tree.v1.TreeService.JoinShareLink is not implemented"))
+}
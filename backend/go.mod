@@ -7,6 +7,7 @@ require (
 	github.com/MicahParks/keyfunc/v3 v3.8.0
 	github.com/joho/godotenv v1.5.1
 	github.com/rs/cors v1.11.1
+	golang.org/x/image v0.25.0
 	golang.org/x/net v0.50.0
 	google.golang.org/protobuf v1.36.11
 )
@@ -3,17 +3,24 @@ package config
 import (
     "log/slog"
     "os"
+    "strconv"
     "strings"
+    "time"
 
     "github.com/joho/godotenv"
 )
 
 type Config struct {
-    Port              string
-    DatabaseURL       string
-    SupabaseURL       string
-    SupabaseJWTSecret string
-    AllowedOrigins    []string
+    Port               string
+    DatabaseURL        string
+    DatabaseListenURL  string // connection สำหรับ LISTEN/NOTIFY (ต้องเป็น session/direct ไม่ใช่ transaction pooler)
+    SupabaseURL        string
+    SupabaseJWTSecret  string
+    SupabaseServiceKey string // service role key สำหรับส่ง email เชิญ ("" = เก็บคำเชิญไว้อย่างเดียว ไม่ส่ง email)
+    AllowedOrigins     []string
+    PublicBaseURL      string // URL ของ backend ที่คนภายนอกเห็น ใช้สร้างลิงก์ absolute เช่น og:image ("" = ใช้ Host ของ request)
+    RenderFontPath     string // font .ttf/.otf ที่มีภาษาไทย สำหรับวาด PNG ("" = ASCII อย่างเดียว)
+    TrashRetention     time.Duration // ของในถังขยะอยู่ได้นานเท่านี้ก่อนถูกลบจริง
 }
 
 func Load() *Config {
@@ -33,12 +40,19 @@ func Load() *Config {
 		}
 	}
 
+    databaseURL := getEnv("DATABASE_URL", "")
+
     return &Config{
-        Port:              getEnv("PORT", "8080"),
-        DatabaseURL:       getEnv("DATABASE_URL", ""),
-        SupabaseURL:       getEnv("SUPABASE_URL", ""),
-        SupabaseJWTSecret: getEnv("SUPABASE_JWT_SECRET", ""),
-        AllowedOrigins:    origins,
+        Port:               getEnv("PORT", "8080"),
+        DatabaseURL:        databaseURL,
+        DatabaseListenURL:  getEnv("DATABASE_LISTEN_URL", databaseURL),
+        SupabaseURL:        getEnv("SUPABASE_URL", ""),
+        SupabaseJWTSecret:  getEnv("SUPABASE_JWT_SECRET", ""),
+        SupabaseServiceKey: getEnv("SUPABASE_SERVICE_ROLE_KEY", ""),
+        AllowedOrigins:     origins,
+        PublicBaseURL:      strings.TrimRight(getEnv("PUBLIC_BASE_URL", ""), "/"),
+        RenderFontPath:     getEnv("RENDER_FONT_PATH", ""),
+        TrashRetention:     time.Duration(getEnvInt("TRASH_RETENTION_DAYS", 30)) * 24 * time.Hour,
     }
 }
 
@@ -47,4 +61,17 @@ func getEnv(key, fallback string) string {
         return val
     }
     return fallback
-}
\ No newline at end of file
+}
+
+func getEnvInt(key string, fallback int) int {
+    val := os.Getenv(key)
+    if val == "" {
+        return fallback
+    }
+    n, err := strconv.Atoi(val)
+    if err != nil || n <= 0 {
+        slog.Warn("invalid integer env, using default", "key", key, "value", val, "default", fallback)
+        return fallback
+    }
+    return n
+}
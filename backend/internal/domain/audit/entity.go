@@ -0,0 +1,143 @@
+package audit
+
+import (
+	"slices"
+	"time"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+)
+
+type Action string
+
+const (
+	// tree
+	ActionTreeCreated           Action = "tree_created"
+	ActionTreeDeleted           Action = "tree_deleted"
+	ActionTreeRestored          Action = "tree_restored"
+	ActionTreeCloned            Action = "tree_cloned"
+	ActionContactPrivacyUpdated Action = "contact_privacy_updated"
+	ActionStructureRepaired     Action = "structure_repaired"
+
+	// sharing
+	ActionShareCreated        Action = "share_created"
+	ActionShareUpdated        Action = "share_updated"
+	ActionShareRemoved        Action = "share_removed"
+	ActionInvitationCreated   Action = "invitation_created"
+	ActionInvitationResent    Action = "invitation_resent"
+	ActionInvitationCancelled Action = "invitation_cancelled"
+	ActionLinkCreated         Action = "share_link_created"
+	ActionLinkRevoked         Action = "share_link_revoked"
+	ActionLinkRotated         Action = "share_link_rotated"
+	ActionLinkJoined          Action = "share_link_joined"
+
+	// ownership
+	ActionTransferRequested Action = "ownership_transfer_requested"
+	ActionTransferAccepted  Action = "ownership_transfer_accepted"
+	ActionTransferDeclined  Action = "ownership_transfer_declined"
+	ActionTransferCancelled Action = "ownership_transfer_cancelled"
+
+	// node
+	ActionNodeCreated   Action = "node_created"
+	ActionNodeUpdated   Action = "node_updated"
+	ActionNodeDeleted   Action = "node_deleted"
+	ActionNodeMoved     Action = "node_moved"
+	ActionNodeUnlinked  Action = "node_unlinked"
+	ActionParentAdded   Action = "parent_added"
+	ActionParentRemoved Action = "parent_removed"
+	ActionLayoutUpdated Action = "layout_updated"
+	ActionNodeImported  Action = "node_imported"
+	ActionNodeRestored  Action = "node_restored"
+
+	// subtree
+	ActionSubtreeDeleted Action = "subtree_deleted"
+	ActionSubtreeCopied  Action = "subtree_copied"
+	ActionSubtreeMoved   Action = "subtree_moved"
+
+	// snapshot
+	ActionSnapshotCreated  Action = "snapshot_created"
+	ActionSnapshotRestored Action = "snapshot_restored"
+	ActionUndo             Action = "undo"
+	ActionRedo             Action = "redo"
+)
+
+// Entry การแก้ไขหนึ่งครั้ง (append-only — แก้ / ลบไม่ได้)
+type Entry struct {
+	ID        int64
+	TreeID    string
+	ActorID   string
+	Action    Action
+	NodeID    *string  // node หลักที่ถูกแก้ (nil = action ระดับ tree)
+	TargetIDs []string // id อื่นที่เกี่ยวข้อง เช่น parent, user ที่ถูกแชร์, ลิงก์
+	Before    *Snapshot
+	After     *Snapshot
+	CreatedAt time.Time
+}
+
+// Snapshot สถานะก่อน / หลังแก้
+type Snapshot struct {
+	Node      *NodeState        `json:"node,omitempty"`
+	ParentIDs []string          `json:"parent_ids,omitempty"`
+	ChildIDs  []string          `json:"child_ids,omitempty"`
+	Fields    map[string]string `json:"fields,omitempty"` // ค่าระดับ tree เช่น role, ชื่อ tree
+}
+
+// NodeState ค่า field ของ node ณ ขณะนั้น
+type NodeState struct {
+	Nickname   string            `json:"nickname"`
+	FirstName  string            `json:"first_name,omitempty"`
+	LastName   string            `json:"last_name,omitempty"`
+	StudentID  string            `json:"student_id,omitempty"`
+	PhotoURL   string            `json:"photo_url,omitempty"`
+	Status     string            `json:"status"`
+	Generation int32             `json:"generation"`
+	PositionX  float64           `json:"position_x"`
+	PositionY  float64           `json:"position_y"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+}
+
+// NodeSnapshot เก็บค่าของ n (คัดลอก Metadata แล้ว แก้ n ต่อได้)
+// s != nil = เก็บเส้น parent / children ของ n ใน structure ด้วย
+func NodeSnapshot(n *node.Node, s *tree.TreeStructure) *Snapshot {
+	snap := &Snapshot{
+		Node: &NodeState{
+			Nickname:   n.Nickname,
+			FirstName:  n.FirstName,
+			LastName:   n.LastName,
+			StudentID:  n.StudentID,
+			PhotoURL:   n.PhotoURL,
+			Status:     string(n.Status),
+			Generation: n.Generation,
+			PositionX:  n.PositionX,
+			PositionY:  n.PositionY,
+		},
+	}
+	if len(n.Metadata) > 0 {
+		snap.Node.Metadata = make(map[string]string, len(n.Metadata))
+		for k, v := range n.Metadata {
+			snap.Node.Metadata[k] = v
+		}
+	}
+	if s != nil {
+		snap.ParentIDs = s.FindParentIDs(n.ID)
+		slices.Sort(snap.ParentIDs)
+		snap.ChildIDs = slices.Clone(s.Edges[n.ID].Children)
+	}
+	return snap
+}
+
+// FieldsSnapshot สถานะระดับ tree เป็นคู่ key / value
+func FieldsSnapshot(fields map[string]string) *Snapshot {
+	return &Snapshot{Fields: fields}
+}
+
+// Filter เงื่อนไขค้น audit ของ tree (ใหม่สุดก่อน)
+type Filter struct {
+	TreeID   string
+	NodeID   string // "" = ทุก node (นับทั้ง node หลักและ TargetIDs)
+	ActorID  string
+	Since    *time.Time
+	Until    *time.Time
+	BeforeID int64 // cursor: เอาเฉพาะ id < BeforeID (0 = เริ่มจากล่าสุด)
+	Limit    int
+}
@@ -0,0 +1,8 @@
+package audit
+
+import "errors"
+
+var (
+	ErrInvalidPageToken = errors.New("invalid page token")
+	ErrInvalidTimeRange = errors.New("since and until must be RFC3339 and since must be before until")
+)
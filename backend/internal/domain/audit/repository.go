@@ -0,0 +1,11 @@
+package audit
+
+import "context"
+
+type Repository interface {
+	// Record บันทึก entry (เรียกใน transaction เดียวกับการแก้ จะได้ไม่มีการแก้ที่ไม่มี log)
+	Record(ctx context.Context, entries ...*Entry) error
+
+	// List ค้น entry ตาม filter เรียงจากใหม่ไปเก่า
+	List(ctx context.Context, f Filter) ([]*Entry, error)
+}
@@ -0,0 +1,25 @@
+package event
+
+import "time"
+
+type Type string
+
+const (
+	TypeNodeCreated        Type = "node_created"
+	TypeNodeUpdated        Type = "node_updated"
+	TypeNodeDeleted        Type = "node_deleted"
+	TypeNodeMoved          Type = "node_moved"
+	TypeNodeParentsChanged Type = "node_parents_changed"
+)
+
+// Event การเปลี่ยนแปลงหนึ่งครั้งใน tree (มาจาก trigger ใน DB)
+type Event struct {
+	ID           int64
+	Seq          int64 // ลำดับตอน commit (id เรียงตอน insert ไม่ใช่ตอน commit) ใช้ resume / ตาม event ที่หลุด
+	TreeID       string
+	Type         Type
+	NodeID       string
+	OldParentIDs []string // เฉพาะ node_moved / node_parents_changed
+	NewParentIDs []string
+	CreatedAt    time.Time
+}
@@ -0,0 +1,8 @@
+package event
+
+import "errors"
+
+var (
+	ErrEventNotFound = errors.New("event not found")
+	ErrStreamLagged  = errors.New("event stream fell behind, reconnect with last_event_id")
+)
@@ -0,0 +1,19 @@
+package event
+
+import "context"
+
+type Repository interface {
+	// FindByID หา event ด้วย id
+	FindByID(ctx context.Context, id int64) (*Event, error)
+
+	// ListAfter ดู event ของ tree ที่ seq > afterSeq เรียงตามลำดับ commit (ใช้ resume stream)
+	ListAfter(ctx context.Context, treeID string, afterSeq int64, limit int) ([]*Event, error)
+}
+
+// Broker กระจาย event ใหม่ไปให้คนที่ subscribe tree นั้นอยู่
+type Broker interface {
+	// Subscribe รับ event ใหม่ของ tree แบบ realtime
+	// channel จะถูกปิดถ้าผู้รับอ่านไม่ทัน (ให้ client resume ด้วย event id ล่าสุด)
+	// ต้องเรียก cancel เมื่อเลิกฟัง
+	Subscribe(treeID string) (events <-chan *Event, cancel func())
+}
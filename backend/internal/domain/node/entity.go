@@ -1,6 +1,11 @@
 package node
 
-import "time"
+import (
+	"maps"
+	"time"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/privacy"
+)
 
 type Status string
 
@@ -27,6 +32,23 @@ type Node struct {
 	UpdatedAt  time.Time
 }
 
+// CopyTo สำเนาข้อมูลของ node สำหรับสร้างใหม่ใน treeID (ยังไม่มี id)
+func (n *Node) CopyTo(treeID string) *Node {
+	c := *n
+	c.ID = ""
+	c.TreeID = treeID
+	c.Metadata = maps.Clone(n.Metadata)
+	c.CreatedAt = time.Time{}
+	c.UpdatedAt = time.Time{}
+	return &c
+}
+
+// Position ตำแหน่งการ์ดบน canvas (ใช้บันทึก layout หลาย node พร้อมกัน)
+type Position struct {
+	NodeID string
+	X, Y   float64
+}
+
 // Contact field keys stored in Metadata JSONB
 const (
 	MetaKeyPhone    = "phone"
@@ -36,6 +58,12 @@ const (
 	MetaKeyFacebook = "facebook"
 )
 
+// ContactFields ทุก field ช่องทางติดต่อ (ลำดับตามที่แสดงบนการ์ด)
+var ContactFields = []string{MetaKeyPhone, MetaKeyEmail, MetaKeyLineID, MetaKeyDiscord, MetaKeyFacebook}
+
+// visibilitySuffix ค่า privacy ราย node เก็บใน Metadata เป็น "<field>_visibility"
+const visibilitySuffix = "_visibility"
+
 func (n *Node) Phone() string    { return n.Metadata[MetaKeyPhone] }
 func (n *Node) Email() string    { return n.Metadata[MetaKeyEmail] }
 func (n *Node) LineID() string   { return n.Metadata[MetaKeyLineID] }
@@ -72,3 +100,34 @@ func (n *Node) SetContact(phone, email, lineID, discord, facebook string) {
 		delete(n.Metadata, MetaKeyFacebook)
 	}
 }
+
+// ClearContacts ลบช่องทางติดต่อและ visibility ราย node ทั้งหมด
+func (n *Node) ClearContacts() {
+	n.SetContact("", "", "", "", "")
+	n.SetContactPrivacy(nil)
+}
+
+// ContactPrivacy ค่า visibility ที่ตั้งไว้ราย node (เฉพาะ field ที่ตั้ง)
+func (n *Node) ContactPrivacy() privacy.Settings {
+	s := privacy.Settings{}
+	for _, field := range ContactFields {
+		if v := privacy.Visibility(n.Metadata[field+visibilitySuffix]); v.Valid() {
+			s[field] = v
+		}
+	}
+	return s
+}
+
+// SetContactPrivacy แทนที่ค่า visibility ราย node ทั้งหมด (field ที่ไม่มีใน s = ใช้ค่าของ tree)
+func (n *Node) SetContactPrivacy(s privacy.Settings) {
+	if n.Metadata == nil {
+		n.Metadata = make(map[string]string)
+	}
+	for _, field := range ContactFields {
+		if v, ok := s[field]; ok && v.Valid() {
+			n.Metadata[field+visibilitySuffix] = string(v)
+		} else {
+			delete(n.Metadata, field+visibilitySuffix)
+		}
+	}
+}
@@ -10,4 +10,10 @@ var (
 	ErrSelfParent        = errors.New("cannot set node as its own parent")
 	ErrCrossTreeMove     = errors.New("cannot move node to a different tree")
 	ErrParentNotFound    = errors.New("parent node not found")
+	ErrNotAParent        = errors.New("node is not a child of the given parent")
+	ErrStudentIDTaken    = errors.New("student_id is already used by another node in this tree")
+	ErrDifferentTrees    = errors.New("nodes are in different trees")
+	ErrEmptyQuery        = errors.New("search query is required")
+	ErrInvalidPageToken  = errors.New("invalid page token")
+	ErrInvalidNodeID     = errors.New("invalid node id")
 )
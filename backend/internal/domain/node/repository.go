@@ -1,18 +1,40 @@
 package node
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+)
 
 type Repository interface {
 	// CRUD
 	Create(ctx context.Context, n *Node) error
 	FindByID(ctx context.Context, id string) (*Node, error)
 	Update(ctx context.Context, n *Node) error
-	Delete(ctx context.Context, id string) error
+
+	// Trash: ลบ = ย้ายลงถังขยะพร้อมตำแหน่งเดิม (query อื่นทั้งหมดมองไม่เห็น node ในถังขยะ)
+	MoveToTrash(ctx context.Context, id, deletedBy string, placement tree.Placement) error
+	ListTrash(ctx context.Context, treeID string) ([]*TrashedNode, error)
+	FindTrashed(ctx context.Context, id string) (*TrashedNode, error)
+	// RestoreFromTrash คืน ErrStudentIDTaken ถ้ามี node อื่นใช้ student_id เดียวกันไปแล้ว
+	RestoreFromTrash(ctx context.Context, id string) error
+	// PurgeTrash ลบจริงทุก node ที่อยู่ในถังขยะก่อน before
+	PurgeTrash(ctx context.Context, before time.Time) (int, error)
+
+	// Restore เขียน node กลับด้วย id เดิม (สร้างใหม่ถ้าถูกลบไปแล้ว / เอาออกจากถังขยะ) ใช้ตอน restore snapshot
+	Restore(ctx context.Context, n *Node) error
 
 	// Generation
 	UpdateGeneration(ctx context.Context, id string, generation int32) error
 
+	// Layout: บันทึกตำแหน่งหลาย node ของ tree เดียวกัน คืนจำนวนที่อัพเดทได้จริง
+	UpdatePositions(ctx context.Context, treeID string, positions []Position) (int, error)
+
 	// Query
 	FindByTreeID(ctx context.Context, treeID string) ([]*Node, error)
 	CountByTreeID(ctx context.Context, treeID string) (int, error)
+
+	// Search ค้น node ที่ยังไม่ถูกลบใน f.TreeIDs (ไม่สนใจ tree ที่อยู่ในถังขยะ)
+	Search(ctx context.Context, f SearchFilter) ([]*SearchHit, error)
 }
@@ -0,0 +1,19 @@
+package node
+
+// SearchFilter เงื่อนไขค้น node (เรียงตามคะแนนมากไปน้อย)
+type SearchFilter struct {
+	Query       string   // ตรงกับชื่อเล่น / ชื่อ / นามสกุล / รหัสนักศึกษา (fuzzy) หรือช่องทางติดต่อ (substring)
+	TreeIDs     []string // ค้นเฉพาะ tree เหล่านี้ (service ตรวจสิทธิ์มาแล้ว)
+	Generations []int32  // ว่าง = ทุกรุ่น
+	Statuses    []Status // ว่าง = ทุกสถานะ
+	Offset      int
+	Limit       int
+}
+
+// SearchHit node ที่ตรงกับคำค้น
+type SearchHit struct {
+	Node  *Node
+	Score float64
+	// NameMatch ตรงที่ชื่อ / รหัสนักศึกษา (false = ตรงแค่ช่องทางติดต่อ ต้องตรวจ visibility ก่อนส่งออก)
+	NameMatch bool
+}
@@ -0,0 +1,15 @@
+package node
+
+import (
+	"time"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+)
+
+// TrashedNode node ที่อยู่ในถังขยะ พร้อมตำแหน่งเดิมสำหรับกู้คืน
+type TrashedNode struct {
+	Node      *Node
+	DeletedAt time.Time
+	DeletedBy *string
+	Placement tree.Placement
+}
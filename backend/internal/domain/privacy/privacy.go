@@ -0,0 +1,34 @@
+package privacy
+
+// Visibility ใครเห็นช่องทางติดต่อช่องหนึ่งได้บ้าง
+type Visibility string
+
+const (
+	Public  Visibility = "public"  // ทุกคนที่ดู tree ได้ (รวมผู้เปิด share link / tree public)
+	Members Visibility = "members" // เฉพาะคนที่ถูกแชร์ tree (viewer ขึ้นไป)
+	Editors Visibility = "editors" // เฉพาะ editor / owner
+)
+
+// Default ค่าเมื่อทั้ง node และ tree ไม่ได้ตั้งไว้ — ไม่เปิดเผยให้คนนอก
+const Default = Members
+
+func (v Visibility) Valid() bool {
+	switch v {
+	case Public, Members, Editors:
+		return true
+	}
+	return false
+}
+
+// Settings การตั้งค่าราย field (key = node.MetaKey*) — ไม่มี key = ใช้ค่าจากชั้นถัดไป
+type Settings map[string]Visibility
+
+// Resolve หา visibility ของ field จากชั้นที่ส่งมา (ชั้นแรกสำคัญสุด เช่น node แล้วค่อย tree)
+func Resolve(field string, layers ...Settings) Visibility {
+	for _, l := range layers {
+		if v, ok := l[field]; ok && v.Valid() {
+			return v
+		}
+	}
+	return Default
+}
@@ -10,4 +10,26 @@ var (
 	ErrInvalidRole      = errors.New("invalid share role")
 	ErrNotShareOwner    = errors.New("only tree owner can manage shares")
 	ErrCannotRemoveOwner = errors.New("cannot remove the original tree owner")
+
+	ErrLinkNotFound    = errors.New("share link not found")
+	ErrLinkRevoked     = errors.New("share link has been revoked")
+	ErrLinkExpired     = errors.New("share link has expired")
+	ErrLinkExhausted   = errors.New("share link has reached its maximum uses")
+	ErrInvalidLinkRole = errors.New("invalid share link role")
+	ErrLinkNotJoinable = errors.New("share link is view-only")
+	ErrInvalidExpiry   = errors.New("expires_at must be a future RFC3339 time")
+	ErrInvalidMaxUses  = errors.New("max_uses must be positive and is only allowed on join links")
+
+	ErrInvitationNotFound = errors.New("invitation not found")
+	ErrAlreadyInvited     = errors.New("email is already invited to this tree")
+	ErrResendTooSoon      = errors.New("invitation was sent recently, try again later")
+	ErrInviteEmailOff     = errors.New("sending invitation email is not configured")
+	ErrEmailUnconfirmed   = errors.New("email is not confirmed yet")
+
+	ErrTransferNotFound     = errors.New("ownership transfer not found")
+	ErrTransferPending      = errors.New("tree already has a pending ownership transfer")
+	ErrTransferNotPending   = errors.New("ownership transfer is no longer pending")
+	ErrTransferToNonMember  = errors.New("ownership can only be transferred to an existing member")
+	ErrNotTreeCreator       = errors.New("only the tree owner can transfer ownership")
+	ErrNotTransferRecipient = errors.New("only the recipient can respond to an ownership transfer")
 )
@@ -0,0 +1,24 @@
+package share
+
+import (
+	"context"
+	"time"
+)
+
+// Invitation คำเชิญที่ค้างไว้ให้ email ที่ยังไม่มีบัญชี
+// พอเจ้าของ email สมัครและยืนยัน email แล้วจะกลายเป็น TreeShare
+type Invitation struct {
+	ID         string
+	TreeID     string
+	Email      string
+	Role       Role
+	InvitedBy  *string
+	SendCount  int32
+	LastSentAt *time.Time
+	CreatedAt  time.Time
+}
+
+// InviteSender ส่ง email เชิญให้สมัครใช้งาน
+type InviteSender interface {
+	SendInvite(ctx context.Context, email string) error
+}
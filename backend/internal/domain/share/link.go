@@ -0,0 +1,61 @@
+package share
+
+import "time"
+
+// LinkRole สิ่งที่ลิงก์แชร์ให้สิทธิ์
+type LinkRole string
+
+const (
+	LinkRoleView       LinkRole = "view"        // ดูอย่างเดียว ไม่ต้อง login
+	LinkRoleJoinViewer LinkRole = "join_viewer" // login แล้วเข้าร่วมเป็น viewer
+	LinkRoleJoinEditor LinkRole = "join_editor" // login แล้วเข้าร่วมเป็น editor
+)
+
+func (r LinkRole) IsValid() bool {
+	switch r {
+	case LinkRoleView, LinkRoleJoinViewer, LinkRoleJoinEditor:
+		return true
+	}
+	return false
+}
+
+// JoinRole role ที่ได้เมื่อเข้าร่วมผ่านลิงก์ (false = ลิงก์ดูอย่างเดียว เข้าร่วมไม่ได้)
+func (r LinkRole) JoinRole() (Role, bool) {
+	switch r {
+	case LinkRoleJoinViewer:
+		return RoleViewer, true
+	case LinkRoleJoinEditor:
+		return RoleEditor, true
+	}
+	return "", false
+}
+
+// Link ลิงก์แชร์หนึ่งลิงก์ (tree หนึ่งมีได้หลายลิงก์)
+type Link struct {
+	ID        string
+	TreeID    string
+	Token     string
+	Role      LinkRole
+	ExpiresAt *time.Time // nil = ไม่หมดอายุ
+	MaxUses   *int32     // จำนวนครั้งที่เข้าร่วมได้ (nil = ไม่จำกัด) ใช้กับลิงก์ join เท่านั้น
+	UseCount  int32
+	CreatedBy string
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// Check ตรวจว่าลิงก์ยังเปิดดูได้ ณ เวลา now
+func (l *Link) Check(now time.Time) error {
+	if l.RevokedAt != nil {
+		return ErrLinkRevoked
+	}
+	if l.ExpiresAt != nil && !now.Before(*l.ExpiresAt) {
+		return ErrLinkExpired
+	}
+	return nil
+}
+
+// Exhausted เข้าร่วมครบจำนวนแล้ว
+func (l *Link) Exhausted() bool {
+	return l.MaxUses != nil && l.UseCount >= *l.MaxUses
+}
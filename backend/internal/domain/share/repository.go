@@ -26,4 +26,62 @@ type Repository interface {
 
 	// GetUserRole ดู role ของ user กับ tree (ถ้าไม่มีจะ return ErrShareNotFound)
 	GetUserRole(ctx context.Context, treeID, userID string) (Role, error)
+
+	// ==================== Share links ====================
+
+	// CreateLink สร้างลิงก์ใหม่ (สุ่ม Token ให้)
+	CreateLink(ctx context.Context, l *Link) error
+
+	// FindLinkByToken หาลิงก์จาก token (ถ้าไม่มีจะ return ErrLinkNotFound) — ไม่ได้ตรวจหมดอายุ / ถูกยกเลิก
+	FindLinkByToken(ctx context.Context, token string) (*Link, error)
+
+	// FindLinkByID หาลิงก์ของ tree ด้วย id
+	FindLinkByID(ctx context.Context, treeID, linkID string) (*Link, error)
+
+	// ListLinks ดูลิงก์ทั้งหมดของ tree (รวมที่หมดอายุ / ถูกยกเลิก) ใหม่สุดก่อน
+	ListLinks(ctx context.Context, treeID string) ([]*Link, error)
+
+	// RevokeLink ยกเลิกลิงก์ (ยกเลิกซ้ำได้ คืนค่าเดิม)
+	RevokeLink(ctx context.Context, treeID, linkID string) (*Link, error)
+
+	// UseLink นับการเข้าร่วม 1 ครั้ง (คืน ErrLinkExhausted / ErrLinkRevoked / ErrLinkExpired ถ้าใช้ไม่ได้แล้ว)
+	UseLink(ctx context.Context, linkID string) error
+
+	// ==================== Invitations ====================
+
+	// CreateInvitation สร้างคำเชิญ (email ซ้ำใน tree เดียวกันจะ return ErrAlreadyInvited)
+	CreateInvitation(ctx context.Context, inv *Invitation) error
+
+	// ListInvitations ดูคำเชิญที่ค้างของ tree
+	ListInvitations(ctx context.Context, treeID string) ([]*Invitation, error)
+
+	// MarkInvitationSent นับการส่ง email เชิญ 1 ครั้ง
+	MarkInvitationSent(ctx context.Context, treeID, invitationID string) (*Invitation, error)
+
+	// FindInvitation หาคำเชิญของ tree ด้วย id
+	FindInvitation(ctx context.Context, treeID, invitationID string) (*Invitation, error)
+
+	// DeleteInvitation ยกเลิกคำเชิญ
+	DeleteInvitation(ctx context.Context, treeID, invitationID string) error
+
+	// AcceptInvitations เปลี่ยนคำเชิญของ email ของ user เป็น share (เฉพาะ user ที่ยืนยัน email แล้ว)
+	// ยังไม่ยืนยัน email = ErrEmailUnconfirmed (ยังมีคำเชิญที่รับไม่ได้ค้างอยู่ได้)
+	AcceptInvitations(ctx context.Context, userID string) (int, error)
+
+	// ==================== Ownership transfers ====================
+
+	// CreateTransfer สร้างคำขอโอน (ถ้า tree มีคำขอที่รออยู่แล้วจะ return ErrTransferPending)
+	CreateTransfer(ctx context.Context, t *OwnershipTransfer) error
+
+	// FindTransfer หาคำขอโอนด้วย id
+	FindTransfer(ctx context.Context, transferID string) (*OwnershipTransfer, error)
+
+	// ListTransfers ดูประวัติการโอนของ tree ใหม่สุดก่อน
+	ListTransfers(ctx context.Context, treeID string) ([]*OwnershipTransfer, error)
+
+	// ListPendingTransfersTo ดูคำขอโอนที่รอ user ตอบ
+	ListPendingTransfersTo(ctx context.Context, userID string) ([]*OwnershipTransfer, error)
+
+	// ResolveTransfer ปิดคำขอที่ยังรออยู่ด้วย status (ถ้าไม่ได้รออยู่แล้วจะ return ErrTransferNotPending)
+	ResolveTransfer(ctx context.Context, transferID string, status TransferStatus) (*OwnershipTransfer, error)
 }
@@ -0,0 +1,25 @@
+package share
+
+import "time"
+
+type TransferStatus string
+
+const (
+	TransferPending   TransferStatus = "pending"
+	TransferAccepted  TransferStatus = "accepted"
+	TransferDeclined  TransferStatus = "declined"
+	TransferCancelled TransferStatus = "cancelled"
+)
+
+// OwnershipTransfer คำขอโอนความเป็นเจ้าของ tree (trees.created_by)
+// ผู้รับต้องเป็นสมาชิกอยู่แล้วและต้องกดยอมรับ — เจ้าของเดิมจะเหลือ PreviousOwnerRole
+type OwnershipTransfer struct {
+	ID                string
+	TreeID            string
+	FromUserID        *string // nil = บัญชีถูกลบไปแล้ว
+	ToUserID          *string
+	PreviousOwnerRole Role
+	Status            TransferStatus
+	CreatedAt         time.Time
+	ResolvedAt        *time.Time
+}
@@ -0,0 +1,97 @@
+package snapshot
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+)
+
+type ChangeType string
+
+const (
+	ChangeAdded    ChangeType = "added"    // มีใน to แต่ไม่มีใน from
+	ChangeRemoved  ChangeType = "removed"  // มีใน from แต่ไม่มีใน to
+	ChangeModified ChangeType = "modified" // field หรือ parent เปลี่ยน
+)
+
+// Change ความต่างของ node หนึ่งตัว
+type Change struct {
+	NodeID        string
+	Nickname      string
+	Type          ChangeType
+	Fields        []string // เฉพาะ modified: field ที่เปลี่ยน ("parents" = ย้ายสาย)
+	ParentsBefore []string
+	ParentsAfter  []string
+}
+
+// State node + structure ฝั่งหนึ่งของการเทียบ
+type State struct {
+	Nodes     []*node.Node
+	Structure *tree.TreeStructure
+}
+
+// Diff การเปลี่ยนแปลงจาก from ไปเป็น to (removed → added → modified, แต่ละกลุ่มเรียงตามชื่อเล่น)
+func Diff(from, to State) []Change {
+	fromNodes := make(map[string]*node.Node, len(from.Nodes))
+	for _, n := range from.Nodes {
+		fromNodes[n.ID] = n
+	}
+	toNodes := make(map[string]*node.Node, len(to.Nodes))
+	for _, n := range to.Nodes {
+		toNodes[n.ID] = n
+	}
+
+	var changes []Change
+	for _, a := range from.Nodes {
+		b, ok := toNodes[a.ID]
+		if !ok {
+			changes = append(changes, Change{
+				NodeID:        a.ID,
+				Nickname:      a.Nickname,
+				Type:          ChangeRemoved,
+				ParentsBefore: sortedParents(from.Structure, a.ID),
+			})
+			continue
+		}
+
+		fields := changedFields(a, b)
+		before, after := sortedParents(from.Structure, a.ID), sortedParents(to.Structure, a.ID)
+		if !slices.Equal(before, after) {
+			fields = append(fields, "parents")
+		}
+		if len(fields) > 0 {
+			changes = append(changes, Change{
+				NodeID:        a.ID,
+				Nickname:      b.Nickname,
+				Type:          ChangeModified,
+				Fields:        fields,
+				ParentsBefore: before,
+				ParentsAfter:  after,
+			})
+		}
+	}
+	for _, b := range to.Nodes {
+		if _, ok := fromNodes[b.ID]; !ok {
+			changes = append(changes, Change{
+				NodeID:       b.ID,
+				Nickname:     b.Nickname,
+				Type:         ChangeAdded,
+				ParentsAfter: sortedParents(to.Structure, b.ID),
+			})
+		}
+	}
+
+	order := map[ChangeType]int{ChangeRemoved: 0, ChangeAdded: 1, ChangeModified: 2}
+	slices.SortStableFunc(changes, func(x, y Change) int {
+		return cmp.Or(cmp.Compare(order[x.Type], order[y.Type]), cmp.Compare(x.Nickname, y.Nickname))
+	})
+	return changes
+}
+
+func sortedParents(s *tree.TreeStructure, id string) []string {
+	parents := s.FindParentIDs(id)
+	slices.Sort(parents)
+	return parents
+}
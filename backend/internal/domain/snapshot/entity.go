@@ -0,0 +1,130 @@
+package snapshot
+
+import (
+	"slices"
+	"time"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+)
+
+// Reason ที่มาของ snapshot
+// snapshot อัตโนมัติก่อนการแก้ใช้ชื่อ action ของ audit (เช่น node_deleted)
+type Reason string
+
+const (
+	ReasonManual  Reason = "manual"  // ผู้ใช้กดสร้างเอง (ไม่ถูกลบอัตโนมัติ)
+	ReasonUndo    Reason = "undo"    // สถานะก่อน undo (redo = restore ตัวนี้)
+	ReasonRestore Reason = "restore" // สถานะก่อน RestoreSnapshot
+)
+
+// Scope ส่วนของ tree ที่ undo / redo ย้อนให้
+type Scope string
+
+const (
+	ScopeTree    Scope = "tree"    // ทั้ง tree
+	ScopeSubtree Scope = "subtree" // NodeID กับ descendants (รวมเส้นที่ต่อเข้าหา NodeID)
+	ScopeNode    Scope = "node"    // เฉพาะข้อมูลของ NodeID ไม่แตะ structure
+)
+
+// Snapshot สถานะของ tree ณ ขณะหนึ่ง: structure ทั้งก้อนเสมอ + node เฉพาะในขอบเขตของ Scope (ดู Capture)
+type Snapshot struct {
+	ID                string
+	TreeID            string
+	CreatedBy         string
+	Reason            Reason
+	Label             string
+	Scope             Scope
+	NodeID            *string // node ที่การแก้ถัดจากนี้ไปแตะ (nil เมื่อ Scope = tree)
+	UndoOf            *string // เฉพาะ ReasonUndo: snapshot ที่ถูก undo
+	UndoneAt          *time.Time
+	StructureRevision int64
+	Structure         tree.TreeStructure
+	Nodes             []*node.Node // node ในขอบเขต — List ไม่โหลด Nodes / Structure มา (ใช้ NodeCount)
+	NodeCount         int32        // จำนวน node ที่เก็บไว้ (ไม่ใช่จำนวน node ทั้ง tree เมื่อ Partial)
+	CreatedAt         time.Time
+}
+
+// Capture snapshot ของ t (ยังไม่บันทึก) เก็บ node เฉพาะในขอบเขต ไม่ต้องเขียนทุก node ทุกครั้งที่แก้
+//   - ScopeTree    = ทุก node
+//   - ScopeSubtree = nodeID กับ descendants (nil = ไม่มี node เช่นก่อนสร้าง node ใหม่)
+//   - ScopeNode    = nodeID ตัวเดียว
+//
+// structure เก็บทั้งก้อนเสมอ (มีแค่ id) ใช้ตัดสินตอน restore ว่า node ไหนมีอยู่ตอนนั้น
+func Capture(t *tree.Tree, nodes []*node.Node, userID string, reason Reason, scope Scope, nodeID *string) *Snapshot {
+	snap := &Snapshot{
+		TreeID:            t.ID,
+		CreatedBy:         userID,
+		Reason:            reason,
+		Scope:             scope,
+		NodeID:            nodeID,
+		StructureRevision: t.StructureRevision,
+		Structure:         t.Structure.Clone(),
+	}
+	snap.Nodes = inScope(&snap.Structure, nodes, scope, snap.ScopeNodeID())
+	return snap
+}
+
+// Partial snapshot เก็บ node ไม่ครบทั้ง tree (restore / diff ได้เฉพาะในขอบเขตของมัน)
+func (s *Snapshot) Partial() bool {
+	return s.Scope != ScopeTree
+}
+
+// Comparable node ปัจจุบันที่เทียบกับ snapshot ได้ (ใช้กับ Diff)
+// snapshot แบบ Partial = node ที่ snapshot เก็บไว้ + node ในขอบเขตเดียวกันของ structure ปัจจุบัน
+func (s *Snapshot) Comparable(current *tree.TreeStructure, nodes []*node.Node) []*node.Node {
+	if !s.Partial() {
+		return nodes
+	}
+	keep := s.NodeMap()
+	out := inScope(current, nodes, s.Scope, s.ScopeNodeID())
+	for _, n := range nodes {
+		if _, ok := keep[n.ID]; ok && !slices.Contains(out, n) {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// inScope node ในขอบเขต scope ตาม structure st
+func inScope(st *tree.TreeStructure, nodes []*node.Node, scope Scope, nodeID string) []*node.Node {
+	if scope == ScopeTree {
+		return nodes
+	}
+	ids := map[string]bool{}
+	if _, ok := st.Edges[nodeID]; ok && nodeID != "" {
+		ids[nodeID] = true
+		if scope == ScopeSubtree {
+			ids = subtree(st, nodeID)
+		}
+	}
+	out := []*node.Node{}
+	for _, n := range nodes {
+		if ids[n.ID] {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// IsAuto snapshot ที่ระบบสร้างก่อนการแก้ (นับเป็นประวัติสำหรับ undo)
+func (s *Snapshot) IsAuto() bool {
+	return s.Reason != ReasonManual && s.Reason != ReasonUndo
+}
+
+// ScopeNodeID id ของ NodeID ("" = ทั้ง tree)
+func (s *Snapshot) ScopeNodeID() string {
+	if s.Scope == ScopeTree || s.NodeID == nil {
+		return ""
+	}
+	return *s.NodeID
+}
+
+// NodeMap node ใน snapshot แยกตาม id
+func (s *Snapshot) NodeMap() map[string]*node.Node {
+	m := make(map[string]*node.Node, len(s.Nodes))
+	for _, n := range s.Nodes {
+		m[n.ID] = n
+	}
+	return m
+}
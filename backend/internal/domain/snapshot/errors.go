@@ -0,0 +1,12 @@
+package snapshot
+
+import "errors"
+
+var (
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+	ErrNodeNotInScope   = errors.New("node does not exist in the snapshot or the current tree")
+	ErrOutsideSnapshot  = errors.New("snapshot does not cover this part of the tree")
+	ErrNothingToUndo    = errors.New("nothing to undo")
+	ErrNothingToRedo    = errors.New("nothing to redo")
+	ErrLabelTooLong     = errors.New("snapshot label is too long")
+)
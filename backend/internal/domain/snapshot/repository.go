@@ -0,0 +1,28 @@
+package snapshot
+
+import "context"
+
+type Repository interface {
+	// Create บันทึก snapshot (snapshot อัตโนมัติของ user คนเดียวกันที่เก่าเกิน MaxAutoPerUser จะถูกลบ)
+	Create(ctx context.Context, s *Snapshot) error
+
+	// FindByID หา snapshot ของ tree พร้อม structure + nodes
+	FindByID(ctx context.Context, treeID, id string) (*Snapshot, error)
+
+	// List ดู snapshot ของ tree ใหม่สุดก่อน (ไม่รวม structure / nodes)
+	List(ctx context.Context, treeID string, limit int) ([]*Snapshot, error)
+
+	// LatestUndoable snapshot อัตโนมัติล่าสุดของ user ที่ยังไม่ถูก undo
+	LatestUndoable(ctx context.Context, treeID, userID string) (*Snapshot, error)
+
+	// LatestRedoable snapshot undo ล่าสุดของ user ที่ยังไม่ถูก redo
+	// และ user ยังไม่ได้แก้อะไรใหม่หลังจาก undo นั้น
+	LatestRedoable(ctx context.Context, treeID, userID string) (*Snapshot, error)
+
+	// SetUndone ตั้ง / ล้างสถานะว่า snapshot นี้ถูก undo (หรือ redo) ไปแล้ว
+	SetUndone(ctx context.Context, id string, undone bool) error
+}
+
+// MaxAutoPerUser จำนวน snapshot อัตโนมัติที่เก็บไว้ต่อ user ต่อ tree
+// (undo เป็นของแต่ละคน คนที่แก้บ่อยจะได้ไม่ดันประวัติ undo ของคนอื่นหลุดไป)
+const MaxAutoPerUser = 200
@@ -0,0 +1,274 @@
+package snapshot
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+)
+
+// Plan สิ่งที่ต้องทำให้ tree (หรือ subtree) กลับไปเป็นแบบใน snapshot
+type Plan struct {
+	Upserts   []*node.Node // เขียนค่าจาก snapshot ทับ (สร้างใหม่ด้วย id เดิมถ้าถูกลบไปแล้ว)
+	Deletes   []string     // node ปัจจุบันที่ไม่มีใน snapshot
+	Structure tree.TreeStructure
+}
+
+// ChangedIDs id ของ node ที่ถูกลบหรือเขียนทับ
+func (p *Plan) ChangedIDs() []string {
+	ids := slices.Clone(p.Deletes)
+	for _, n := range p.Upserts {
+		ids = append(ids, n.ID)
+	}
+	return ids
+}
+
+// PlanRestore คำนวณการ restore จาก snap ทับสถานะปัจจุบัน
+//
+// ScopeTree    = ทั้ง tree
+// ScopeNode    = เฉพาะข้อมูลของ nodeID (node ต้องมีทั้งใน snapshot และตอนนี้)
+// ScopeSubtree = เฉพาะ nodeID กับ descendants (ใน snapshot และในปัจจุบัน) ส่วนอื่นของ tree ไม่ถูกแตะ
+//   - node ที่อยู่ใต้ nodeID ตอนนี้แต่ไม่มีใน snapshot เลย → ลบ
+//   - node ที่มีใน snapshot แต่ตอนนั้นอยู่ที่อื่น → ย้ายกลับไปต่อกับ parent เดิมใน snapshot
+//   - parent นอก subtree ที่ถูกลบไปแล้ว → node นั้นเป็น root
+//   - node ใหม่ที่ต่ออยู่กับ node ใน subtree ของ snapshot (แต่ไม่ได้อยู่ใต้ nodeID ตอนนี้) → คงไว้ที่เดิม
+//
+// snapshot แบบ Partial restore ได้เฉพาะในขอบเขตที่เก็บไว้ (ScopeTree / subtree ที่เกินออกไป = ErrOutsideSnapshot)
+func PlanRestore(snap *Snapshot, current *tree.TreeStructure, currentNodes []*node.Node, scope Scope, nodeID string) (*Plan, error) {
+	curNodes := make(map[string]*node.Node, len(currentNodes))
+	for _, n := range currentNodes {
+		curNodes[n.ID] = n
+	}
+	snapNodes := snap.NodeMap()
+
+	switch scope {
+	case ScopeTree:
+		if snap.Partial() {
+			return nil, ErrOutsideSnapshot
+		}
+		plan := &Plan{Structure: snap.Structure.Clone()}
+		for _, n := range snap.Nodes {
+			if c, ok := curNodes[n.ID]; !ok || !sameNode(c, n) {
+				plan.Upserts = append(plan.Upserts, n)
+			}
+		}
+		for _, c := range currentNodes {
+			if _, ok := snapNodes[c.ID]; !ok {
+				plan.Deletes = append(plan.Deletes, c.ID)
+			}
+		}
+		return plan, nil
+
+	case ScopeNode:
+		n, inSnap := snapNodes[nodeID]
+		c, inCur := curNodes[nodeID]
+		if !inSnap || !inCur {
+			return nil, ErrNodeNotInScope
+		}
+		plan := &Plan{Structure: current.Clone()}
+		if !sameNode(c, n) {
+			plan.Upserts = []*node.Node{n}
+		}
+		return plan, nil
+	}
+
+	return planSubtree(snap, snapNodes, curNodes, current, currentNodes, nodeID)
+}
+
+// planSubtree PlanRestore แบบ ScopeSubtree
+func planSubtree(
+	snap *Snapshot,
+	snapNodes, curNodes map[string]*node.Node,
+	current *tree.TreeStructure,
+	currentNodes []*node.Node,
+	rootID string,
+) (*Plan, error) {
+	_, inSnap := snap.Structure.Edges[rootID]
+	_, inCur := current.Edges[rootID]
+	if !inSnap && !inCur {
+		return nil, ErrNodeNotInScope
+	}
+
+	snapSet := map[string]bool{}
+	if inSnap {
+		snapSet = subtree(&snap.Structure, rootID)
+	}
+	curSet := map[string]bool{}
+	if inCur {
+		curSet = subtree(current, rootID)
+	}
+
+	// snapshot แบบ Partial ต้องเก็บ node ของ subtree นี้ไว้ครบ
+	for id := range snapSet {
+		if _, ok := snapNodes[id]; !ok {
+			return nil, ErrOutsideSnapshot
+		}
+	}
+	// node มีอยู่ตอน snapshot ไหม — ดูจาก structure ด้วย เพราะ snapshot แบบ Partial ไม่ได้เก็บทุก node
+	existed := func(id string) bool {
+		_, inNodes := snapNodes[id]
+		_, inStructure := snap.Structure.Edges[id]
+		return inNodes || inStructure
+	}
+
+	plan := &Plan{Structure: current.Clone()}
+	next := &plan.Structure
+
+	// 1. ถอด node ที่เกี่ยวข้องออกจากทุกที่ใน structure ปัจจุบัน
+	touched := func(id string) bool { return snapSet[id] || curSet[id] }
+	next.RootIDs = slices.DeleteFunc(next.RootIDs, touched)
+	for id, e := range next.Edges {
+		if touched(id) {
+			delete(next.Edges, id)
+			continue
+		}
+		e.Children = slices.DeleteFunc(e.Children, touched)
+		next.Edges[id] = e
+	}
+
+	// 2. subtree ตาม snapshot (children ของ node ใน subtree อยู่ใน subtree ทั้งหมด)
+	for _, n := range snap.Nodes {
+		if !snapSet[n.ID] {
+			continue
+		}
+		e := snap.Structure.Edges[n.ID]
+		next.Edges[n.ID] = tree.TreeStructureEdge{Children: slices.Clone(e.Children), Order: e.Order}
+		if c, ok := curNodes[n.ID]; !ok || !sameNode(c, n) {
+			plan.Upserts = append(plan.Upserts, n)
+		}
+	}
+
+	// 3. node ที่อยู่ใต้ rootID ตอนนี้แต่ไม่ได้อยู่ใน subtree ของ snapshot
+	reattach := make([]string, 0)
+	for _, c := range currentNodes {
+		if !curSet[c.ID] || snapSet[c.ID] {
+			continue
+		}
+		if !existed(c.ID) {
+			plan.Deletes = append(plan.Deletes, c.ID)
+			continue
+		}
+		next.Edges[c.ID] = tree.TreeStructureEdge{Children: []string{}, Order: current.Edges[c.ID].Order}
+		reattach = append(reattach, c.ID)
+	}
+
+	// 4. node ที่สร้างหลัง snapshot นอก subtree ปัจจุบัน (ไม่ถูกลบ) ยังต่อกับ parent เดิม
+	for _, n := range snap.Nodes {
+		if !snapSet[n.ID] {
+			continue
+		}
+		e := next.Edges[n.ID]
+		for _, child := range current.Edges[n.ID].Children {
+			if existed(child) || curSet[child] {
+				continue
+			}
+			e.Children = append(e.Children, child)
+		}
+		next.Edges[n.ID] = e
+	}
+
+	// 5. ต่อกับ parent นอก subtree ตามตำแหน่งใน snapshot
+	for _, n := range snap.Nodes {
+		if snapSet[n.ID] {
+			attachLikeSnapshot(next, &snap.Structure, n.ID, snapSet)
+		}
+	}
+	for _, id := range reattach {
+		attachLikeSnapshot(next, &snap.Structure, id, snapSet)
+	}
+
+	// 6. node ที่ไม่เหลือ parent → root
+	referenced := make(map[string]bool, len(next.Edges))
+	for _, id := range next.RootIDs {
+		referenced[id] = true
+	}
+	for _, e := range next.Edges {
+		for _, id := range e.Children {
+			referenced[id] = true
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(next.Edges)) {
+		if !referenced[id] {
+			next.RootIDs = append(next.RootIDs, id)
+		}
+	}
+
+	return plan, nil
+}
+
+// attachLikeSnapshot ใส่ id กลับเข้า children ของ parent (หรือ rootIds) ที่ id เคยอยู่ใน snapshot
+// ข้าม parent ที่อยู่ใน inside (edges ของ parent นั้นมาจาก snapshot แล้ว) และ parent ที่ไม่มีแล้ว
+func attachLikeSnapshot(next, snap *tree.TreeStructure, id string, inside map[string]bool) {
+	if snap.SiblingIndex("", id) >= 0 {
+		next.RootIDs = slices.Insert(next.RootIDs, min(snap.SiblingIndex("", id), len(next.RootIDs)), id)
+		return
+	}
+	parents := snap.FindParentIDs(id)
+	slices.Sort(parents)
+	for _, pid := range parents {
+		if inside[pid] {
+			continue
+		}
+		e, ok := next.Edges[pid]
+		if !ok {
+			continue
+		}
+		e.Children = slices.Insert(e.Children, min(snap.SiblingIndex(pid, id), len(e.Children)), id)
+		next.Edges[pid] = e
+	}
+}
+
+// subtree rootID กับ descendants ทั้งหมด
+func subtree(s *tree.TreeStructure, rootID string) map[string]bool {
+	set := map[string]bool{rootID: true}
+	queue := []string{rootID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, child := range s.Edges[id].Children {
+			if !set[child] {
+				set[child] = true
+				queue = append(queue, child)
+			}
+		}
+	}
+	return set
+}
+
+func sameNode(a, b *node.Node) bool {
+	return len(changedFields(a, b)) == 0
+}
+
+// changedFields ชื่อ field ที่ต่างกันระหว่าง a กับ b
+func changedFields(a, b *node.Node) []string {
+	var fields []string
+	if a.Nickname != b.Nickname {
+		fields = append(fields, "nickname")
+	}
+	if a.FirstName != b.FirstName {
+		fields = append(fields, "first_name")
+	}
+	if a.LastName != b.LastName {
+		fields = append(fields, "last_name")
+	}
+	if a.StudentID != b.StudentID {
+		fields = append(fields, "student_id")
+	}
+	if a.PhotoURL != b.PhotoURL {
+		fields = append(fields, "photo_url")
+	}
+	if a.Status != b.Status {
+		fields = append(fields, "status")
+	}
+	if a.Generation != b.Generation {
+		fields = append(fields, "generation")
+	}
+	if a.PositionX != b.PositionX || a.PositionY != b.PositionY {
+		fields = append(fields, "position")
+	}
+	if !maps.Equal(a.Metadata, b.Metadata) {
+		fields = append(fields, "metadata")
+	}
+	return fields
+}
@@ -0,0 +1,177 @@
+package snapshot
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+)
+
+// fixture: a → b → d, a → c, e (root)
+func fixture() (*tree.Tree, []*node.Node) {
+	s := tree.NewEmptyStructure()
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		s.Edges[id] = tree.TreeStructureEdge{Children: []string{}}
+	}
+	s.RootIDs = []string{"a", "e"}
+	s.Edges["a"] = tree.TreeStructureEdge{Children: []string{"b", "c"}}
+	s.Edges["b"] = tree.TreeStructureEdge{Children: []string{"d"}}
+
+	var nodes []*node.Node
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		nodes = append(nodes, &node.Node{ID: id, Nickname: id})
+	}
+	return &tree.Tree{ID: "t", Structure: s}, nodes
+}
+
+func nodeIDs(nodes []*node.Node) []string {
+	ids := make([]string, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func ptr(s string) *string { return &s }
+
+func TestCaptureStoresOnlyScope(t *testing.T) {
+	tr, nodes := fixture()
+	tests := []struct {
+		scope  Scope
+		nodeID *string
+		want   []string
+	}{
+		{ScopeTree, nil, []string{"a", "b", "c", "d", "e"}},
+		{ScopeSubtree, ptr("b"), []string{"b", "d"}},
+		{ScopeSubtree, nil, []string{}},
+		{ScopeNode, ptr("c"), []string{"c"}},
+		{ScopeNode, ptr("missing"), []string{}},
+	}
+	for _, tt := range tests {
+		snap := Capture(tr, nodes, "u", ReasonRestore, tt.scope, tt.nodeID)
+		if got := nodeIDs(snap.Nodes); !slices.Equal(got, tt.want) {
+			t.Errorf("%s %v: nodes = %v, want %v", tt.scope, tt.nodeID, got, tt.want)
+		}
+		if !reflect.DeepEqual(snap.Structure, tr.Structure) {
+			t.Errorf("%s: structure should be stored in full", tt.scope)
+		}
+	}
+}
+
+// undo ย้าย b ไปอยู่ใต้ e: snapshot แบบ subtree ต้องได้ผลเหมือน snapshot ทั้ง tree
+func TestPartialSubtreeRestoreMatchesFull(t *testing.T) {
+	tr, nodes := fixture()
+	full := Capture(tr, nodes, "u", ReasonManual, ScopeTree, nil)
+	partial := Capture(tr, nodes, "u", ReasonRestore, ScopeSubtree, ptr("b"))
+
+	moved := tr.Structure.Clone()
+	if err := moved.MoveNode("b", "e"); err != nil {
+		t.Fatal(err)
+	}
+	current := slices.Clone(nodes)
+	current[1] = &node.Node{ID: "b", Nickname: "renamed"}
+
+	want, err := PlanRestore(full, &moved, current, ScopeSubtree, "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := PlanRestore(partial, &moved, current, ScopeSubtree, "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Upserts) != 1 || got.Upserts[0].Nickname != "b" {
+		t.Errorf("upserts = %+v, want original b", got.Upserts)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("partial plan = %+v\nwant %+v", got, want)
+	}
+	if !reflect.DeepEqual(got.Structure, tr.Structure) {
+		t.Errorf("structure = %+v, want original %+v", got.Structure, tr.Structure)
+	}
+}
+
+// undo การสร้าง node: snapshot ไม่มี node เลย restore แล้วต้องลบ node ใหม่ทิ้ง
+func TestPartialRestoreOfCreatedNode(t *testing.T) {
+	tr, nodes := fixture()
+	snap := Capture(tr, nodes, "u", Reason("node_created"), ScopeSubtree, nil)
+	snap.NodeID = ptr("x")
+
+	after := tr.Structure.Clone()
+	if err := after.AddNode("x", "c"); err != nil {
+		t.Fatal(err)
+	}
+	current := append(slices.Clone(nodes), &node.Node{ID: "x", Nickname: "x"})
+
+	plan, err := PlanRestore(snap, &after, current, ScopeSubtree, "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(plan.Deletes, []string{"x"}) || len(plan.Upserts) != 0 {
+		t.Errorf("plan = deletes %v upserts %d, want delete x only", plan.Deletes, len(plan.Upserts))
+	}
+	if !reflect.DeepEqual(plan.Structure, tr.Structure) {
+		t.Errorf("structure = %+v, want %+v", plan.Structure, tr.Structure)
+	}
+}
+
+// node ที่ย้ายเข้ามาใต้ subtree หลัง snapshot (มีอยู่แล้วตอนนั้นแต่อยู่ที่อื่น) ต้องไม่ถูกลบ
+func TestPartialRestoreKeepsNodesThatExistedElsewhere(t *testing.T) {
+	tr, nodes := fixture()
+	snap := Capture(tr, nodes, "u", ReasonRestore, ScopeSubtree, ptr("b"))
+
+	after := tr.Structure.Clone()
+	if err := after.MoveNode("e", "d"); err != nil {
+		t.Fatal(err)
+	}
+	plan, err := PlanRestore(snap, &after, nodes, ScopeSubtree, "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Deletes) != 0 {
+		t.Errorf("deletes = %v, want none", plan.Deletes)
+	}
+	if !reflect.DeepEqual(plan.Structure, tr.Structure) {
+		t.Errorf("structure = %+v, want %+v", plan.Structure, tr.Structure)
+	}
+}
+
+func TestPartialRestoreOutsideScope(t *testing.T) {
+	tr, nodes := fixture()
+	snap := Capture(tr, nodes, "u", ReasonRestore, ScopeSubtree, ptr("b"))
+
+	if _, err := PlanRestore(snap, &tr.Structure, nodes, ScopeTree, ""); !errors.Is(err, ErrOutsideSnapshot) {
+		t.Errorf("tree scope: err = %v, want ErrOutsideSnapshot", err)
+	}
+	if _, err := PlanRestore(snap, &tr.Structure, nodes, ScopeSubtree, "a"); !errors.Is(err, ErrOutsideSnapshot) {
+		t.Errorf("wider subtree: err = %v, want ErrOutsideSnapshot", err)
+	}
+	if _, err := PlanRestore(snap, &tr.Structure, nodes, ScopeSubtree, "d"); err != nil {
+		t.Errorf("inner subtree: err = %v, want nil", err)
+	}
+}
+
+func TestComparable(t *testing.T) {
+	tr, nodes := fixture()
+	snap := Capture(tr, nodes, "u", ReasonRestore, ScopeSubtree, ptr("b"))
+
+	// d ย้ายออกไปอยู่ใต้ e, c ย้ายเข้ามาใต้ b
+	after := tr.Structure.Clone()
+	if err := after.MoveNode("d", "e"); err != nil {
+		t.Fatal(err)
+	}
+	if err := after.MoveNode("c", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if got := nodeIDs(snap.Comparable(&after, nodes)); !slices.Equal(got, []string{"b", "c", "d"}) {
+		t.Errorf("comparable = %v, want [b c d]", got)
+	}
+
+	full := Capture(tr, nodes, "u", ReasonManual, ScopeTree, nil)
+	if got := full.Comparable(&after, nodes); len(got) != len(nodes) {
+		t.Errorf("full snapshot should compare every node, got %d", len(got))
+	}
+}
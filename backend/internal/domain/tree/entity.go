@@ -3,6 +3,8 @@ package tree
 import (
 	"encoding/json"
 	"time"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/privacy"
 )
 
 // TreeStructureEdge แต่ละ node ใน structure
@@ -69,6 +71,33 @@ func (s *TreeStructure) FindParentIDs(nodeID string) []string {
 	return parents
 }
 
+// SiblingIndex ลำดับของ nodeID ใน children ของ parentID ("" = ลำดับใน rootIds)
+// คืน -1 ถ้า node ไม่ได้อยู่ใต้ parent นี้
+func (s *TreeStructure) SiblingIndex(parentID, nodeID string) int {
+	list := s.RootIDs
+	if parentID != "" {
+		list = s.Edges[parentID].Children
+	}
+	for i, id := range list {
+		if id == nodeID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Clone สำเนาที่แก้ได้โดยไม่กระทบตัวเดิม
+func (s *TreeStructure) Clone() TreeStructure {
+	c := TreeStructure{
+		RootIDs: append([]string{}, s.RootIDs...),
+		Edges:   make(map[string]TreeStructureEdge, len(s.Edges)),
+	}
+	for id, e := range s.Edges {
+		c.Edges[id] = TreeStructureEdge{Children: append([]string{}, e.Children...), Order: e.Order}
+	}
+	return c
+}
+
 // ToJSON แปลง structure เป็น JSON bytes
 func (s *TreeStructure) ToJSON() ([]byte, error) {
 	return json.Marshal(s)
@@ -90,15 +119,20 @@ func ParseStructure(data []byte) (*TreeStructure, error) {
 }
 
 type Tree struct {
-	ID          string
-	Name        string
-	Description string
-	Faculty     string
-	Department  string
-	CreatedBy   string
-	ShareToken  *string
-	IsPublic    bool
-	Structure   TreeStructure
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID                string
+	Name              string
+	Description       string
+	Faculty           string
+	Department        string
+	CreatedBy         string
+	IsPublic          bool
+	Structure         TreeStructure
+	StructureRevision int64            // เพิ่มขึ้นทุกครั้งที่ Structure ถูกแก้ (optimistic concurrency)
+	ContactPrivacy    privacy.Settings // ใครเห็นช่องทางติดต่อของ node ได้ (node ตั้งทับได้)
+	ClonedFrom        *string          // tree ต้นฉบับ (CloneTree)
+	TemplateID        *string          // template ที่ใช้เริ่ม tree
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	DeletedAt         *time.Time // ไม่ nil = อยู่ในถังขยะ (โหลดมาเฉพาะ ListTrash / FindTrashed)
+	DeletedBy         *string
 }
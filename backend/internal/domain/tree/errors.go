@@ -3,7 +3,18 @@ package tree
 import "errors"
 
 var (
-    ErrTreeNotFound = errors.New("tree not found")
-    ErrTreeNoName   = errors.New("tree name is required")
-    ErrUnauthorized = errors.New("unauthorized to access this tree")
+    ErrTreeNotFound     = errors.New("tree not found")
+    ErrTreeNoName       = errors.New("tree name is required")
+    ErrUnauthorized     = errors.New("unauthorized to access this tree")
+    ErrRevisionConflict = errors.New("tree structure was modified by someone else, please refetch and retry")
+    ErrOwnerChanged     = errors.New("tree owner has changed")
+    ErrInvalidPageToken = errors.New("invalid page token")
+
+    ErrNodeInStructure    = errors.New("node is already in the tree structure")
+    ErrNodeNotInStructure = errors.New("node is not in the tree structure")
+    ErrStructureCycle     = errors.New("link would create a cycle in the tree structure")
+
+    ErrTemplateNotFound = errors.New("tree template not found")
+    ErrTemplateNoName   = errors.New("template name is required")
+    ErrTemplateEmpty    = errors.New("tree has no nodes to save as a template")
 )
\ No newline at end of file
@@ -0,0 +1,172 @@
+package tree
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// IssueKind ประเภทความไม่ตรงกันระหว่าง structure กับ nodes
+type IssueKind string
+
+const (
+	IssueMissingNode    IssueKind = "missing_node"    // rootIds / edges อ้างถึง node ที่ไม่มีแล้ว (ถูกลบ / อยู่ในถังขยะ)
+	IssueDuplicateRoot  IssueKind = "duplicate_root"  // id ซ้ำใน rootIds
+	IssueDuplicateChild IssueKind = "duplicate_child" // id ซ้ำใน children ของ parent เดียวกัน
+	IssueMissingEdge    IssueKind = "missing_edge"    // node ไม่มี entry ใน edges
+	IssueRootHasParent  IssueKind = "root_has_parent" // อยู่ใน rootIds ทั้งที่มี parent
+	IssueCycle          IssueKind = "cycle"           // เส้น parent → child ที่ทำให้วนกลับ
+	IssueDetached       IssueKind = "detached_node"   // ไม่อยู่ใน rootIds และไม่เป็น child ของใคร
+)
+
+// Issue ปัญหาหนึ่งจุด พร้อมสิ่งที่ Repair ทำกับมัน
+type Issue struct {
+	Kind     IssueKind
+	NodeID   string
+	ParentID string // เส้นที่เกี่ยวข้อง ("" = rootIds / ไม่เกี่ยวกับเส้น)
+}
+
+func (i Issue) String() string {
+	switch i.Kind {
+	case IssueMissingNode:
+		if i.ParentID != "" {
+			return fmt.Sprintf("child %s of %s does not exist: removed from children", i.NodeID, i.ParentID)
+		}
+		return fmt.Sprintf("node %s does not exist: removed from structure", i.NodeID)
+	case IssueDuplicateRoot:
+		return fmt.Sprintf("root %s is listed more than once: kept the first", i.NodeID)
+	case IssueDuplicateChild:
+		return fmt.Sprintf("child %s is listed more than once under %s: kept the first", i.NodeID, i.ParentID)
+	case IssueMissingEdge:
+		return fmt.Sprintf("node %s has no edge entry: added an empty one", i.NodeID)
+	case IssueRootHasParent:
+		return fmt.Sprintf("root %s also has a parent: removed from roots", i.NodeID)
+	case IssueCycle:
+		return fmt.Sprintf("link %s -> %s closes a cycle: removed", i.ParentID, i.NodeID)
+	case IssueDetached:
+		return fmt.Sprintf("node %s is not reachable from any root: made a root", i.NodeID)
+	default:
+		return fmt.Sprintf("%s: %s", i.Kind, i.NodeID)
+	}
+}
+
+// Check หาทุกจุดที่ structure ไม่ตรงกับ nodeIDs (node ที่ยังอยู่ใน tree ทั้งหมด) — ว่าง = ปกติ
+func (s *TreeStructure) Check(nodeIDs []string) []Issue {
+	_, issues := s.Repair(nodeIDs)
+	return issues
+}
+
+// Repair คืน structure ที่แก้แล้วกับรายการที่แก้ (s ไม่ถูกแก้)
+// แก้ตามลำดับ: ตัด id ที่ไม่มี / ซ้ำ → เติม edge → เอา root ที่มี parent ออก → ตัดเส้นที่วน → ต่อ node ที่หลุดเป็น root
+// ผลลัพธ์ไม่ขึ้นกับลำดับของ map (ทำซ้ำได้ผลเดิม)
+func (s *TreeStructure) Repair(nodeIDs []string) (TreeStructure, []Issue) {
+	exists := make(map[string]bool, len(nodeIDs))
+	for _, id := range nodeIDs {
+		exists[id] = true
+	}
+	out := NewEmptyStructure()
+	issues := []Issue{}
+
+	seen := map[string]bool{}
+	for _, id := range s.RootIDs {
+		switch {
+		case !exists[id]:
+			issues = append(issues, Issue{Kind: IssueMissingNode, NodeID: id})
+		case seen[id]:
+			issues = append(issues, Issue{Kind: IssueDuplicateRoot, NodeID: id})
+		default:
+			seen[id] = true
+			out.RootIDs = append(out.RootIDs, id)
+		}
+	}
+
+	for _, parentID := range slices.Sorted(maps.Keys(s.Edges)) {
+		edge := s.Edges[parentID]
+		if !exists[parentID] {
+			if !slices.Contains(s.RootIDs, parentID) {
+				issues = append(issues, Issue{Kind: IssueMissingNode, NodeID: parentID})
+			}
+			continue
+		}
+		children := []string{}
+		for _, child := range edge.Children {
+			switch {
+			case !exists[child]:
+				issues = append(issues, Issue{Kind: IssueMissingNode, NodeID: child, ParentID: parentID})
+			case slices.Contains(children, child):
+				issues = append(issues, Issue{Kind: IssueDuplicateChild, NodeID: child, ParentID: parentID})
+			case child == parentID:
+				issues = append(issues, Issue{Kind: IssueCycle, NodeID: child, ParentID: parentID})
+			default:
+				children = append(children, child)
+			}
+		}
+		out.Edges[parentID] = TreeStructureEdge{Children: children, Order: edge.Order}
+	}
+
+	sortedIDs := slices.Sorted(maps.Keys(exists))
+	for _, id := range sortedIDs {
+		if _, ok := out.Edges[id]; !ok {
+			issues = append(issues, Issue{Kind: IssueMissingEdge, NodeID: id})
+			out.Edges[id] = TreeStructureEdge{Children: []string{}}
+		}
+	}
+
+	parents := out.parentIndex()
+	out.RootIDs = slices.DeleteFunc(out.RootIDs, func(id string) bool {
+		if len(parents[id]) == 0 {
+			return false
+		}
+		issues = append(issues, Issue{Kind: IssueRootHasParent, NodeID: id})
+		return true
+	})
+
+	// เดินจากทุก root ตัดเส้นที่ชี้กลับไปหา node บนเส้นทางปัจจุบัน
+	visited := map[string]bool{}
+	onPath := map[string]bool{}
+	var visit func(id string)
+	visit = func(id string) {
+		visited[id] = true
+		onPath[id] = true
+		for _, child := range slices.Clone(out.Edges[id].Children) {
+			switch {
+			case onPath[child]:
+				out.RemoveChild(id, child)
+				issues = append(issues, Issue{Kind: IssueCycle, NodeID: child, ParentID: id})
+			case !visited[child]:
+				visit(child)
+			}
+		}
+		onPath[id] = false
+	}
+	for _, id := range out.RootIDs {
+		visit(id)
+	}
+
+	// node ที่ยังเดินไม่ถึง: ไล่ขึ้นไปหา node ที่ไม่มี parent แล้วต่อเป็น root
+	// ไล่แล้ววนกลับ = กลุ่มนี้เป็น cycle ที่ไม่มี root → ตัดเส้นเข้า node นั้นแล้วให้เป็น root แทน
+	for {
+		i := slices.IndexFunc(sortedIDs, func(id string) bool { return !visited[id] })
+		if i < 0 {
+			break
+		}
+		parents = out.parentIndex()
+		cur, climbed := sortedIDs[i], map[string]bool{}
+		for len(parents[cur]) > 0 && !climbed[cur] {
+			climbed[cur] = true
+			cur = parents[cur][0]
+		}
+		if len(parents[cur]) > 0 {
+			for _, p := range parents[cur] {
+				out.RemoveChild(p, cur)
+				issues = append(issues, Issue{Kind: IssueCycle, NodeID: cur, ParentID: p})
+			}
+		} else {
+			issues = append(issues, Issue{Kind: IssueDetached, NodeID: cur})
+		}
+		out.RootIDs = append(out.RootIDs, cur)
+		visit(cur)
+	}
+
+	return out, issues
+}
@@ -0,0 +1,154 @@
+package tree
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Relative node ในสายพร้อมระยะห่าง (1 = parent / child ตรง)
+type Relative struct {
+	ID    string
+	Depth int
+}
+
+// Ancestors พี่รหัสทุกชั้นของ nodeID เรียงจากใกล้ไปไกล (ผ่านทุก parent ของ multi-parent)
+// maxDepth <= 0 = ไม่จำกัด
+func (s *TreeStructure) Ancestors(nodeID string, maxDepth int) []Relative {
+	parents := s.parentIndex()
+	order, _ := walk(nodeID, maxDepth, func(id string) []string { return parents[id] })
+	return order[1:]
+}
+
+// Descendants น้องรหัสทุกชั้นของ nodeID เรียงจากใกล้ไปไกล (ชั้นเดียวกันตามลำดับ children)
+// maxDepth <= 0 = ไม่จำกัด
+func (s *TreeStructure) Descendants(nodeID string, maxDepth int) []Relative {
+	order, _ := walk(nodeID, maxDepth, func(id string) []string { return s.Edges[id].Children })
+	return order[1:]
+}
+
+// walk BFS จาก start คืน node ตามลำดับที่เจอ (start ก่อน depth 0) + via[id] = node ก่อนหน้าบนเส้นที่สั้นที่สุด
+func walk(start string, maxDepth int, next func(string) []string) ([]Relative, map[string]string) {
+	order := []Relative{{ID: start}}
+	via := map[string]string{start: ""}
+	for i := 0; i < len(order); i++ {
+		cur := order[i]
+		if maxDepth > 0 && cur.Depth >= maxDepth {
+			continue
+		}
+		for _, id := range next(cur.ID) {
+			if _, seen := via[id]; seen {
+				continue
+			}
+			via[id] = cur.ID
+			order = append(order, Relative{ID: id, Depth: cur.Depth + 1})
+		}
+	}
+	return order, via
+}
+
+// ==================== Relationship ====================
+
+// RelationshipKind ความสัมพันธ์ของ b เมื่อมองจาก a
+type RelationshipKind int
+
+const (
+	RelationUnrelated  RelationshipKind = iota // ไม่มีพี่รหัสร่วมกัน
+	RelationSelf                               // node เดียวกัน
+	RelationAncestor                           // b เป็นพี่รหัส (ทุกชั้น) ของ a
+	RelationDescendant                         // b เป็นน้องรหัส (ทุกชั้น) ของ a
+	RelationSibling                            // มีพี่รหัสคนเดียวกัน
+	RelationCousin                             // มีพี่รหัสร่วมกันหลายชั้นขึ้นไป
+)
+
+// Relationship เส้นที่สั้นที่สุดจาก a ไป b ผ่านพี่รหัสร่วม
+type Relationship struct {
+	Kind           RelationshipKind
+	CommonAncestor string   // "" = ไม่เกี่ยวข้องกัน
+	Up             int      // จำนวนชั้นจาก a ขึ้นไปถึง CommonAncestor
+	Down           int      // จำนวนชั้นจาก CommonAncestor ลงมาถึง b
+	Path           []string // a → ... → CommonAncestor → ... → b
+}
+
+// Relationship หาพี่รหัสร่วมที่ทำให้เส้นจาก a ไป b สั้นที่สุด (a / b เองนับเป็นพี่รหัสร่วมได้)
+// เท่ากันเลือกตัวที่อยู่ใกล้ a กว่า แล้วตาม id ให้ผลคงที่
+func (s *TreeStructure) Relationship(a, b string) Relationship {
+	if a == b {
+		return Relationship{Kind: RelationSelf, CommonAncestor: a, Path: []string{a}}
+	}
+
+	parents := s.parentIndex()
+	up := func(id string) []string { return parents[id] }
+	fromA, viaA := walk(a, 0, up)
+	fromB, viaB := walk(b, 0, up)
+
+	distB := make(map[string]int, len(fromB))
+	for _, r := range fromB {
+		distB[r.ID] = r.Depth
+	}
+
+	best := Relationship{Kind: RelationUnrelated}
+	found := false
+	for _, r := range fromA {
+		down, ok := distB[r.ID]
+		if !ok {
+			continue
+		}
+		total, bestTotal := r.Depth+down, best.Up+best.Down
+		if !found || total < bestTotal ||
+			(total == bestTotal && r.Depth < best.Up) ||
+			(total == bestTotal && r.Depth == best.Up && r.ID < best.CommonAncestor) {
+			best = Relationship{CommonAncestor: r.ID, Up: r.Depth, Down: down}
+			found = true
+		}
+	}
+	if !found {
+		return best
+	}
+
+	// a ขึ้นไปถึง common ancestor (ย้อน via แล้วกลับลำดับ) ต่อด้วยลงมาถึง b
+	var path []string
+	for id := best.CommonAncestor; id != ""; id = viaA[id] {
+		path = append(path, id)
+	}
+	slices.Reverse(path)
+	for id := viaB[best.CommonAncestor]; id != ""; id = viaB[id] {
+		path = append(path, id)
+	}
+	best.Path = path
+
+	switch {
+	case best.Down == 0:
+		best.Kind = RelationAncestor
+	case best.Up == 0:
+		best.Kind = RelationDescendant
+	case best.Up == 1 && best.Down == 1:
+		best.Kind = RelationSibling
+	default:
+		best.Kind = RelationCousin
+	}
+	return best
+}
+
+// Label ชื่อเรียกความสัมพันธ์ของ b เมื่อมองจาก a
+func (r Relationship) Label() string {
+	switch r.Kind {
+	case RelationSelf:
+		return "ตัวเอง"
+	case RelationAncestor:
+		if r.Up == 1 {
+			return "พี่รหัส"
+		}
+		return fmt.Sprintf("พี่รหัส %d ชั้น", r.Up)
+	case RelationDescendant:
+		if r.Down == 1 {
+			return "น้องรหัส"
+		}
+		return fmt.Sprintf("น้องรหัส %d ชั้น", r.Down)
+	case RelationSibling:
+		return "ร่วมพี่รหัสเดียวกัน"
+	case RelationCousin:
+		return fmt.Sprintf("ญาติสายรหัส (ขึ้น %d ชั้น ลง %d ชั้น)", r.Up, r.Down)
+	default:
+		return "ไม่เกี่ยวข้องกัน"
+	}
+}
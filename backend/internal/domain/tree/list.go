@@ -0,0 +1,110 @@
+package tree
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/share"
+)
+
+// Summary tree สำหรับหน้ารายการ — ไม่โหลด structure (Tree.Structure ว่างเสมอ)
+type Summary struct {
+	Tree      *Tree
+	Role      share.Role // role ของ user ที่ list (creator = owner)
+	NodeCount int
+}
+
+// Cursor ตำแหน่งของ s ในรายการที่เรียงด้วย sort (ใช้ทำ next page token)
+func (s *Summary) Cursor(sort SortField, reverse bool) Cursor {
+	c := Cursor{Sort: sort, Reverse: reverse, ID: s.Tree.ID}
+	switch sort {
+	case SortName:
+		c.Value = s.Tree.Name
+	case SortNodeCount:
+		c.Value = strconv.Itoa(s.NodeCount)
+	case SortCreatedAt:
+		c.Value = s.Tree.CreatedAt.Format(time.RFC3339Nano)
+	default:
+		c.Value = s.Tree.UpdatedAt.Format(time.RFC3339Nano)
+	}
+	return c
+}
+
+// ListScope tree ชุดไหนของ user
+type ListScope int
+
+const (
+	ScopeAccessible ListScope = iota // เป็นเจ้าของ + ถูกแชร์
+	ScopeOwned                       // created_by = user
+	ScopeShared                      // ถูกแชร์มา (ไม่รวม tree ที่ตัวเองสร้าง)
+)
+
+// SortField ลำดับของรายการ แต่ละแบบมีทิศทางปกติของมัน (Reverse = กลับทิศ)
+type SortField string
+
+const (
+	SortUpdatedAt SortField = "updated_at" // ใหม่สุดก่อน
+	SortCreatedAt SortField = "created_at" // ใหม่สุดก่อน
+	SortName      SortField = "name"       // ก → ฮ / A → Z
+	SortNodeCount SortField = "node_count" // มากสุดก่อน
+)
+
+// Descending ทิศทางจริงของการเรียงหลังคิด Reverse แล้ว
+func (f SortField) Descending(reverse bool) bool {
+	return (f != SortName) != reverse
+}
+
+// ListQuery เงื่อนไข list tree ของ user แบบแบ่งหน้า (cursor)
+type ListQuery struct {
+	UserID     string
+	Scope      ListScope
+	Faculty    string       // "" = ทุกคณะ
+	Department string       // "" = ทุกภาควิชา
+	Roles      []share.Role // ว่าง = ทุก role
+	Sort       SortField
+	Reverse    bool
+	After      *Cursor // nil = หน้าแรก
+	Limit      int     // 0 = ทั้งหมด
+}
+
+// Cursor ตำแหน่งของรายการสุดท้ายในหน้าก่อน (ค่าที่ใช้เรียง + id กันค่าซ้ำ)
+type Cursor struct {
+	Sort    SortField `json:"s"`
+	Reverse bool      `json:"r,omitempty"`
+	Value   string    `json:"v"`
+	ID      string    `json:"id"`
+}
+
+// Encode เป็น page token ที่ส่งให้ client
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor อ่าน page token — ใช้กับการเรียงแบบเดิมเท่านั้น ไม่งั้นคืน ErrInvalidPageToken
+func DecodeCursor(token string, sort SortField, reverse bool) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return nil, ErrInvalidPageToken
+	}
+	if c.Sort != sort || c.Reverse != reverse {
+		return nil, ErrInvalidPageToken
+	}
+	// ค่าต้องแปลงเป็น type ของคอลัมน์ได้ ไม่งั้นไปพังที่ DB
+	switch sort {
+	case SortNodeCount:
+		_, err = strconv.Atoi(c.Value)
+	case SortUpdatedAt, SortCreatedAt:
+		_, err = time.Parse(time.RFC3339Nano, c.Value)
+	}
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	return &c, nil
+}
@@ -0,0 +1,101 @@
+package tree
+
+// การแก้ structure ทีละขั้น (แทน DB functions เดิมใน migrations)
+// ทุก method ตรวจก่อนแล้วค่อยแก้: คืน error = s ไม่ถูกแตะ
+// structure ที่ผ่าน Check มา แก้ด้วย method เหล่านี้แล้วยังผ่าน Check เสมอ
+// parentID "" = rootIds ตามแบบ InsertChild / RemoveChild
+
+// AddNode เพิ่ม node ใหม่ (ยังไม่มีน้อง) ต่อท้าย children ของ parentID หรือต่อท้าย rootIds
+func (s *TreeStructure) AddNode(nodeID, parentID string) error {
+	if _, ok := s.Edges[nodeID]; ok {
+		return ErrNodeInStructure
+	}
+	if parentID != "" {
+		if _, ok := s.Edges[parentID]; !ok {
+			return ErrNodeNotInStructure
+		}
+	}
+	s.Edges[nodeID] = TreeStructureEdge{Children: []string{}}
+	s.InsertChild(parentID, nodeID, nil)
+	return nil
+}
+
+// RemoveNode ลบ node ออกจาก structure — น้องที่ไม่มี parent อื่นย้ายขึ้นไปต่อท้าย parent ของ node (ดู Detach)
+func (s *TreeStructure) RemoveNode(nodeID string) error {
+	if _, ok := s.Edges[nodeID]; !ok {
+		return ErrNodeNotInStructure
+	}
+	s.Detach(nodeID)
+	return nil
+}
+
+// MoveNode ตัด node ออกจากทุก parent (และ rootIds) แล้วต่อท้าย children ของ newParentID ("" = เป็น root)
+// ย้ายไปอยู่ใต้ตัวเองหรือ descendant ของตัวเอง = ErrStructureCycle
+func (s *TreeStructure) MoveNode(nodeID, newParentID string) error {
+	if err := s.checkLink(nodeID, newParentID); err != nil {
+		return err
+	}
+	for _, pid := range s.FindParentIDs(nodeID) {
+		s.RemoveChild(pid, nodeID)
+	}
+	s.RemoveChild("", nodeID)
+	s.InsertChild(newParentID, nodeID, nil)
+	return nil
+}
+
+// AddParent เพิ่ม parentID เป็น parent อีกคนของ node (parent เดิมยังอยู่ — multi-parent / DAG)
+// เป็นลูกของ parentID อยู่แล้ว = ไม่ทำอะไร, node ที่เป็น root จะถูกเอาออกจาก rootIds
+func (s *TreeStructure) AddParent(nodeID, parentID string) error {
+	if parentID == "" {
+		return ErrNodeNotInStructure
+	}
+	if err := s.checkLink(nodeID, parentID); err != nil {
+		return err
+	}
+	if s.SiblingIndex(parentID, nodeID) >= 0 {
+		return nil
+	}
+	s.InsertChild(parentID, nodeID, nil)
+	s.RemoveChild("", nodeID)
+	return nil
+}
+
+// RemoveParent ตัดเส้น parentID → nodeID เส้นเดียว ไม่เหลือ parent = ต่อท้าย rootIds
+// ไม่ได้เป็นลูกของ parentID = ไม่ทำอะไร
+func (s *TreeStructure) RemoveParent(nodeID, parentID string) {
+	if parentID == "" || s.SiblingIndex(parentID, nodeID) < 0 {
+		return
+	}
+	s.RemoveChild(parentID, nodeID)
+	if len(s.FindParentIDs(nodeID)) == 0 && s.SiblingIndex("", nodeID) < 0 {
+		s.RootIDs = append(s.RootIDs, nodeID)
+	}
+}
+
+// Reorder ย้าย node ไปตำแหน่ง order (เริ่มที่ 0) ใน children ของ parentID
+// order เกินขอบถูกปัดให้อยู่หัว / ท้าย, node ไม่ได้อยู่ใต้ parentID = ไม่ทำอะไร
+func (s *TreeStructure) Reorder(parentID, nodeID string, order int32) {
+	if s.SiblingIndex(parentID, nodeID) < 0 {
+		return
+	}
+	s.RemoveChild(parentID, nodeID)
+	order = max(order, 0)
+	s.InsertChild(parentID, nodeID, &order)
+}
+
+// checkLink ตรวจว่าต่อ nodeID ไว้ใต้ parentID ได้ ("" = root ต่อได้เสมอถ้า node มีอยู่)
+func (s *TreeStructure) checkLink(nodeID, parentID string) error {
+	if _, ok := s.Edges[nodeID]; !ok {
+		return ErrNodeNotInStructure
+	}
+	if parentID == "" {
+		return nil
+	}
+	if _, ok := s.Edges[parentID]; !ok {
+		return ErrNodeNotInStructure
+	}
+	if parentID == nodeID || s.IsDescendant(nodeID, parentID) {
+		return ErrStructureCycle
+	}
+	return nil
+}
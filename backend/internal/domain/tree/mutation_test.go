@@ -0,0 +1,482 @@
+package tree
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// build สร้าง structure จาก rootIds + children ของแต่ละ node (node ที่ไม่มีใน edges ได้ edge ว่าง)
+func build(roots []string, edges map[string][]string) TreeStructure {
+	s := NewEmptyStructure()
+	s.RootIDs = append(s.RootIDs, roots...)
+	for id, children := range edges {
+		s.Edges[id] = TreeStructureEdge{Children: append([]string{}, children...)}
+		for _, c := range children {
+			if _, ok := s.Edges[c]; !ok {
+				s.Edges[c] = TreeStructureEdge{Children: []string{}}
+			}
+		}
+	}
+	for _, id := range roots {
+		if _, ok := s.Edges[id]; !ok {
+			s.Edges[id] = TreeStructureEdge{Children: []string{}}
+		}
+	}
+	return s
+}
+
+// base มี root 2 ตัว: a (น้องคือ b, c และ b มีน้องคือ d) กับ e (ไม่มีน้อง)
+func base() TreeStructure {
+	return build([]string{"a", "e"}, map[string][]string{
+		"a": {"b", "c"},
+		"b": {"d"},
+	})
+}
+
+func assertStructure(t *testing.T, got, want TreeStructure) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("structure mismatch\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func assertConsistent(t *testing.T, s TreeStructure) {
+	t.Helper()
+	if issues := s.Check(slices.Collect(maps.Keys(s.Edges))); len(issues) > 0 {
+		t.Errorf("structure is inconsistent: %v", issues)
+	}
+}
+
+func TestAddNode(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodeID  string
+		parent  string
+		want    TreeStructure
+		wantErr error
+	}{
+		{
+			name:   "root appended to rootIds",
+			nodeID: "x",
+			want: build([]string{"a", "e", "x"}, map[string][]string{
+				"a": {"b", "c"}, "b": {"d"},
+			}),
+		},
+		{
+			name:   "child appended to parent",
+			nodeID: "x",
+			parent: "a",
+			want: build([]string{"a", "e"}, map[string][]string{
+				"a": {"b", "c", "x"}, "b": {"d"},
+			}),
+		},
+		{name: "existing node", nodeID: "d", parent: "e", wantErr: ErrNodeInStructure},
+		{name: "unknown parent", nodeID: "x", parent: "zz", wantErr: ErrNodeNotInStructure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := base()
+			err := s.AddNode(tt.nodeID, tt.parent)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				assertStructure(t, s, base())
+				return
+			}
+			assertStructure(t, s, tt.want)
+			assertConsistent(t, s)
+		})
+	}
+}
+
+func TestRemoveNode(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   TreeStructure
+		nodeID  string
+		want    TreeStructure
+		wantErr error
+	}{
+		{
+			name:   "leaf",
+			start:  base(),
+			nodeID: "d",
+			want: build([]string{"a", "e"}, map[string][]string{
+				"a": {"b", "c"},
+			}),
+		},
+		{
+			name:   "children lifted up to parent",
+			start:  base(),
+			nodeID: "b",
+			want: build([]string{"a", "e"}, map[string][]string{
+				"a": {"c", "d"},
+			}),
+		},
+		{
+			name:   "children of root become roots",
+			start:  base(),
+			nodeID: "a",
+			want: build([]string{"e", "b", "c"}, map[string][]string{
+				"b": {"d"},
+			}),
+		},
+		{
+			name:   "child with another parent is not lifted",
+			start:  build([]string{"a", "e"}, map[string][]string{"a": {"b"}, "b": {"d"}, "e": {"d"}}),
+			nodeID: "b",
+			want:   build([]string{"a", "e"}, map[string][]string{"a": {}, "e": {"d"}}),
+		},
+		{
+			name:   "multi-parent node lifts children to first parent by id",
+			start:  build([]string{"a", "e"}, map[string][]string{"a": {"b"}, "e": {"b"}, "b": {"d"}}),
+			nodeID: "b",
+			want:   build([]string{"a", "e"}, map[string][]string{"a": {"d"}, "e": {}}),
+		},
+		{name: "unknown node", start: base(), nodeID: "zz", wantErr: ErrNodeNotInStructure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.start.Clone()
+			err := s.RemoveNode(tt.nodeID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				assertStructure(t, s, tt.start)
+				return
+			}
+			assertStructure(t, s, tt.want)
+			assertConsistent(t, s)
+		})
+	}
+}
+
+func TestMoveNode(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   TreeStructure
+		nodeID  string
+		parent  string
+		want    TreeStructure
+		wantErr error
+	}{
+		{
+			name:   "to another parent",
+			start:  base(),
+			nodeID: "d",
+			parent: "c",
+			want: build([]string{"a", "e"}, map[string][]string{
+				"a": {"b", "c"}, "b": {}, "c": {"d"},
+			}),
+		},
+		{
+			name:   "subtree moves with node",
+			start:  base(),
+			nodeID: "b",
+			parent: "e",
+			want: build([]string{"a", "e"}, map[string][]string{
+				"a": {"c"}, "b": {"d"}, "e": {"b"},
+			}),
+		},
+		{
+			name:   "to root",
+			start:  base(),
+			nodeID: "b",
+			want: build([]string{"a", "e", "b"}, map[string][]string{
+				"a": {"c"}, "b": {"d"},
+			}),
+		},
+		{
+			name:   "root under another root",
+			start:  base(),
+			nodeID: "e",
+			parent: "d",
+			want: build([]string{"a"}, map[string][]string{
+				"a": {"b", "c"}, "b": {"d"}, "d": {"e"},
+			}),
+		},
+		{
+			name:   "drops every old parent",
+			start:  build([]string{"a", "e"}, map[string][]string{"a": {"b"}, "e": {"b"}}),
+			nodeID: "b",
+			want:   build([]string{"a", "e", "b"}, map[string][]string{"a": {}, "e": {}}),
+		},
+		{
+			name:   "same parent goes to the end",
+			start:  base(),
+			nodeID: "b",
+			parent: "a",
+			want: build([]string{"a", "e"}, map[string][]string{
+				"a": {"c", "b"}, "b": {"d"},
+			}),
+		},
+		{name: "under itself", start: base(), nodeID: "b", parent: "b", wantErr: ErrStructureCycle},
+		{name: "under its descendant", start: base(), nodeID: "a", parent: "d", wantErr: ErrStructureCycle},
+		{name: "unknown node", start: base(), nodeID: "zz", parent: "a", wantErr: ErrNodeNotInStructure},
+		{name: "unknown parent", start: base(), nodeID: "d", parent: "zz", wantErr: ErrNodeNotInStructure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.start.Clone()
+			err := s.MoveNode(tt.nodeID, tt.parent)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				assertStructure(t, s, tt.start)
+				return
+			}
+			assertStructure(t, s, tt.want)
+			assertConsistent(t, s)
+		})
+	}
+}
+
+func TestAddParent(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodeID  string
+		parent  string
+		want    TreeStructure
+		wantErr error
+	}{
+		{
+			name:   "second parent keeps the first",
+			nodeID: "d",
+			parent: "c",
+			want: build([]string{"a", "e"}, map[string][]string{
+				"a": {"b", "c"}, "b": {"d"}, "c": {"d"},
+			}),
+		},
+		{
+			name:   "root leaves rootIds",
+			nodeID: "e",
+			parent: "c",
+			want: build([]string{"a"}, map[string][]string{
+				"a": {"b", "c"}, "b": {"d"}, "c": {"e"},
+			}),
+		},
+		{name: "already a parent is a no-op", nodeID: "d", parent: "b", want: base()},
+		{name: "self", nodeID: "b", parent: "b", wantErr: ErrStructureCycle},
+		{name: "descendant as parent", nodeID: "a", parent: "d", wantErr: ErrStructureCycle},
+		{name: "child as parent", nodeID: "b", parent: "d", wantErr: ErrStructureCycle},
+		{name: "empty parent", nodeID: "d", wantErr: ErrNodeNotInStructure},
+		{name: "unknown parent", nodeID: "d", parent: "zz", wantErr: ErrNodeNotInStructure},
+		{name: "unknown node", nodeID: "zz", parent: "a", wantErr: ErrNodeNotInStructure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := base()
+			err := s.AddParent(tt.nodeID, tt.parent)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				assertStructure(t, s, base())
+				return
+			}
+			assertStructure(t, s, tt.want)
+			assertConsistent(t, s)
+		})
+	}
+}
+
+func TestRemoveParent(t *testing.T) {
+	multi := build([]string{"a", "e"}, map[string][]string{
+		"a": {"b", "c"}, "b": {"d"}, "c": {"d"},
+	})
+	tests := []struct {
+		name   string
+		start  TreeStructure
+		nodeID string
+		parent string
+		want   TreeStructure
+	}{
+		{
+			name:   "other parent kept",
+			start:  multi,
+			nodeID: "d",
+			parent: "b",
+			want: build([]string{"a", "e"}, map[string][]string{
+				"a": {"b", "c"}, "b": {}, "c": {"d"},
+			}),
+		},
+		{
+			name:   "last parent makes a root",
+			start:  base(),
+			nodeID: "d",
+			parent: "b",
+			want: build([]string{"a", "e", "d"}, map[string][]string{
+				"a": {"b", "c"}, "b": {},
+			}),
+		},
+		{name: "not a parent", start: base(), nodeID: "d", parent: "c", want: base()},
+		{name: "empty parent", start: base(), nodeID: "e", want: base()},
+		{name: "unknown node", start: base(), nodeID: "zz", parent: "a", want: base()},
+		{name: "unknown parent", start: base(), nodeID: "d", parent: "zz", want: base()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.start.Clone()
+			s.RemoveParent(tt.nodeID, tt.parent)
+			assertStructure(t, s, tt.want)
+			assertConsistent(t, s)
+		})
+	}
+}
+
+func TestReorder(t *testing.T) {
+	wide := build([]string{"r", "s", "t"}, map[string][]string{
+		"r": {"a", "b", "c", "d"},
+	})
+	tests := []struct {
+		name   string
+		nodeID string
+		parent string
+		order  int32
+		want   []string // children ของ parent ("" = rootIds) หลัง reorder
+	}{
+		{name: "to front", nodeID: "c", parent: "r", order: 0, want: []string{"c", "a", "b", "d"}},
+		{name: "to middle", nodeID: "a", parent: "r", order: 2, want: []string{"b", "c", "a", "d"}},
+		{name: "to last", nodeID: "a", parent: "r", order: 3, want: []string{"b", "c", "d", "a"}},
+		{name: "same place", nodeID: "b", parent: "r", order: 1, want: []string{"a", "b", "c", "d"}},
+		{name: "beyond end clamps to last", nodeID: "a", parent: "r", order: 99, want: []string{"b", "c", "d", "a"}},
+		{name: "negative clamps to first", nodeID: "d", parent: "r", order: -5, want: []string{"d", "a", "b", "c"}},
+		{name: "roots", nodeID: "t", order: 0, want: []string{"t", "r", "s"}},
+		{name: "not under parent", nodeID: "s", parent: "r", order: 0, want: []string{"a", "b", "c", "d"}},
+		{name: "unknown node", nodeID: "zz", parent: "r", order: 0, want: []string{"a", "b", "c", "d"}},
+		{name: "unknown node in roots", nodeID: "zz", order: 0, want: []string{"r", "s", "t"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := wide.Clone()
+			s.Reorder(tt.parent, tt.nodeID, tt.order)
+			got := s.RootIDs
+			if tt.parent != "" {
+				got = s.Edges[tt.parent].Children
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+			assertConsistent(t, s)
+		})
+	}
+
+	t.Run("unknown parent", func(t *testing.T) {
+		s := wide.Clone()
+		s.Reorder("zz", "a", 0)
+		assertStructure(t, s, wide)
+	})
+}
+
+// TestMutationProperties ลำดับการแก้แบบสุ่ม: ทุกขั้นต้องผ่าน Check และทุก node ต้องเดินถึงจาก root
+// การแก้ที่คืน error ต้องไม่แตะ structure
+func TestMutationProperties(t *testing.T) {
+	const (
+		seeds = 200
+		steps = 300
+	)
+	for seed := int64(1); seed <= seeds; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		s := NewEmptyStructure()
+		var ids []string
+		next := 0
+
+		// pick บางครั้งคืน "" (root) หรือ id ที่ไม่มีใน structure
+		pick := func() string {
+			switch n := r.Intn(20); {
+			case n == 0 || len(ids) == 0:
+				return ""
+			case n == 1:
+				return "missing"
+			default:
+				return ids[r.Intn(len(ids))]
+			}
+		}
+
+		for step := 0; step < steps; step++ {
+			before := s.Clone()
+			var (
+				op  string
+				err error
+			)
+			switch r.Intn(6) {
+			case 0:
+				id := fmt.Sprintf("n%d", next)
+				next++
+				op = "add " + id
+				if err = s.AddNode(id, pick()); err == nil {
+					ids = append(ids, id)
+				}
+			case 1:
+				id := pick()
+				op = "remove " + id
+				if err = s.RemoveNode(id); err == nil {
+					ids = slices.DeleteFunc(ids, func(x string) bool { return x == id })
+				}
+			case 2:
+				id, p := pick(), pick()
+				op = "move " + id + " → " + p
+				err = s.MoveNode(id, p)
+			case 3:
+				id, p := pick(), pick()
+				op = "add parent " + p + " → " + id
+				err = s.AddParent(id, p)
+			case 4:
+				id, p := pick(), pick()
+				op = "remove parent " + p + " → " + id
+				s.RemoveParent(id, p)
+			case 5:
+				id, p := pick(), pick()
+				op = "reorder " + id + " under " + p
+				s.Reorder(p, id, int32(r.Intn(10)-2))
+			}
+
+			if err != nil {
+				if !errors.Is(err, ErrStructureCycle) && !errors.Is(err, ErrNodeNotInStructure) && !errors.Is(err, ErrNodeInStructure) {
+					t.Fatalf("seed %d step %d %s: unexpected error %v", seed, step, op, err)
+				}
+				if !reflect.DeepEqual(s, before) {
+					t.Fatalf("seed %d step %d %s: failed operation changed the structure", seed, step, op)
+				}
+			}
+			if issues := s.Check(ids); len(issues) > 0 {
+				t.Fatalf("seed %d step %d %s: %v", seed, step, op, issues)
+			}
+			if unreachable := unreachableFromRoots(&s, ids); len(unreachable) > 0 {
+				t.Fatalf("seed %d step %d %s: unreachable nodes %v", seed, step, op, unreachable)
+			}
+		}
+	}
+}
+
+func unreachableFromRoots(s *TreeStructure, ids []string) []string {
+	seen := map[string]bool{}
+	var visit func(id string)
+	visit = func(id string) {
+		if seen[id] {
+			return
+		}
+		seen[id] = true
+		for _, c := range s.Edges[id].Children {
+			visit(c)
+		}
+	}
+	for _, id := range s.RootIDs {
+		visit(id)
+	}
+	var out []string
+	for _, id := range ids {
+		if !seen[id] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
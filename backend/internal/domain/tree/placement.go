@@ -0,0 +1,118 @@
+package tree
+
+import "slices"
+
+// Placement ตำแหน่งเดิมของ node ใน structure (เก็บไว้ตอนลงถังขยะ ใช้วางกลับตอนกู้คืน)
+type Placement struct {
+	Parents   []ParentSlot `json:"parents"`             // parent เดิม + ลำดับใน children
+	RootIndex *int         `json:"rootIndex,omitempty"` // ลำดับใน rootIds (เฉพาะ node ที่เป็น root)
+	Children  []string     `json:"children"`            // children เดิมตามลำดับ
+	Promoted  []string     `json:"promoted"`            // children ที่ถูกย้ายขึ้นไปแทนที่ตอนถอด
+}
+
+type ParentSlot struct {
+	ParentID string `json:"parentId"`
+	Index    int    `json:"index"`
+}
+
+// Detach ถอด nodeID ออกจาก structure (ทุก parent + rootIds) แล้วคืนตำแหน่งเดิม
+// child ที่มี nodeID เป็น parent ตัวเดียวจะย้ายขึ้นไปต่อท้าย parent ตัวแรกของ nodeID
+// (เรียงตาม id) หรือเป็น root ถ้า nodeID เป็น root — child ที่มี parent อื่นอยู่แล้วไม่ถูกย้าย
+func (s *TreeStructure) Detach(nodeID string) Placement {
+	edge := s.Edges[nodeID]
+	p := Placement{
+		Children: slices.Clone(edge.Children),
+		Promoted: []string{},
+	}
+	if p.Children == nil {
+		p.Children = []string{}
+	}
+
+	parents := s.FindParentIDs(nodeID)
+	slices.Sort(parents)
+	for _, pid := range parents {
+		p.Parents = append(p.Parents, ParentSlot{ParentID: pid, Index: s.SiblingIndex(pid, nodeID)})
+	}
+	if idx := s.SiblingIndex("", nodeID); idx >= 0 {
+		p.RootIndex = &idx
+	}
+
+	isNode := func(id string) bool { return id == nodeID }
+	s.RootIDs = slices.DeleteFunc(s.RootIDs, isNode)
+	for _, pid := range parents {
+		e := s.Edges[pid]
+		e.Children = slices.DeleteFunc(e.Children, isNode)
+		s.Edges[pid] = e
+	}
+	delete(s.Edges, nodeID)
+
+	for _, child := range p.Children {
+		if len(s.FindParentIDs(child)) > 0 {
+			continue
+		}
+		p.Promoted = append(p.Promoted, child)
+		if len(parents) > 0 {
+			e := s.Edges[parents[0]]
+			e.Children = append(e.Children, child)
+			s.Edges[parents[0]] = e
+		} else {
+			s.RootIDs = append(s.RootIDs, child)
+		}
+	}
+	return p
+}
+
+// Reattach วาง nodeID กลับตามตำแหน่งที่ Detach คืนมา (nodeID ต้องยังไม่อยู่ใน structure)
+//   - parent ที่ไม่มีแล้วถูกข้าม ไม่เหลือ parent เลย = เป็น root
+//   - child ที่ถูกย้ายขึ้นไปจะกลับมาเฉพาะตัวที่ยังอยู่ที่เดิม (ไม่มีใครย้ายไปไหนต่อ)
+//   - child ที่ทำให้เกิด circular reference ถูกข้าม
+func (s *TreeStructure) Reattach(nodeID string, p Placement) {
+	s.Edges[nodeID] = TreeStructureEdge{Children: []string{}}
+
+	attached := false
+	for _, slot := range p.Parents {
+		e, ok := s.Edges[slot.ParentID]
+		if !ok {
+			continue
+		}
+		if !slices.Contains(e.Children, nodeID) {
+			e.Children = slices.Insert(e.Children, min(max(slot.Index, 0), len(e.Children)), nodeID)
+			s.Edges[slot.ParentID] = e
+		}
+		attached = true
+	}
+	if !attached {
+		idx := len(s.RootIDs)
+		if p.RootIndex != nil {
+			idx = min(max(*p.RootIndex, 0), idx)
+		}
+		s.RootIDs = slices.Insert(s.RootIDs, idx, nodeID)
+	}
+
+	// ที่ที่ child ถูกย้ายขึ้นไปตอน Detach
+	promotedTo := ""
+	if len(p.Parents) > 0 {
+		promotedTo = p.Parents[0].ParentID
+	}
+
+	children := make([]string, 0, len(p.Children))
+	for _, child := range p.Children {
+		if _, ok := s.Edges[child]; !ok || s.IsDescendant(child, nodeID) {
+			continue
+		}
+		if slices.Contains(p.Promoted, child) {
+			if s.SiblingIndex(promotedTo, child) < 0 {
+				continue
+			}
+			if promotedTo == "" {
+				s.RootIDs = slices.DeleteFunc(s.RootIDs, func(id string) bool { return id == child })
+			} else {
+				e := s.Edges[promotedTo]
+				e.Children = slices.DeleteFunc(e.Children, func(id string) bool { return id == child })
+				s.Edges[promotedTo] = e
+			}
+		}
+		children = append(children, child)
+	}
+	s.Edges[nodeID] = TreeStructureEdge{Children: children}
+}
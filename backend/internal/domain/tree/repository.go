@@ -1,22 +1,62 @@
 package tree
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/privacy"
+)
 
 type Repository interface {
 	// CRUD
 	Create(ctx context.Context, t *Tree) error
 	FindByID(ctx context.Context, id string) (*Tree, error)
-	FindByIDs(ctx context.Context, ids []string) ([]*Tree, error)
-	FindByShareToken(ctx context.Context, token string) (*Tree, error)
-	ListByUser(ctx context.Context, userID string) ([]*Tree, error)
-	Delete(ctx context.Context, id string) error
-
-	// Share token
-	GenerateShareToken(ctx context.Context, treeID string) (string, error)
-
-	// Structure operations (เรียก DB functions ที่สร้างไว้ใน migrations)
-	AddNodeToStructure(ctx context.Context, treeID, nodeID string, parentID *string) error
-	RemoveNodeFromStructure(ctx context.Context, treeID, nodeID string) error
-	MoveNodeInStructure(ctx context.Context, treeID, nodeID string, newParentID *string) error
-	AddChildToParent(ctx context.Context, treeID, nodeID, parentID string) error
+
+	// ListIDs id ของทุก tree ที่ไม่อยู่ในถังขยะ (งาน maintenance เช่นตรวจ structure)
+	ListIDs(ctx context.Context) ([]string, error)
+
+	// ListSummaries list tree ของ user ตาม q แบบไม่โหลด structure
+	ListSummaries(ctx context.Context, q ListQuery) ([]*Summary, error)
+
+	// Trash: ลบ = ย้ายลงถังขยะ (query อื่นทั้งหมดมองไม่เห็น tree ในถังขยะ)
+	MoveToTrash(ctx context.Context, id, deletedBy string) error
+	ListTrash(ctx context.Context, ownerID string) ([]*Tree, error)
+	FindTrashed(ctx context.Context, id string) (*Tree, error)
+	RestoreFromTrash(ctx context.Context, id string) error
+	// PurgeTrash ลบจริงทุก tree ที่อยู่ในถังขยะก่อน before (cascade ลบ nodes / shares / snapshots)
+	PurgeTrash(ctx context.Context, before time.Time) (int, error)
+
+	// RollupPublic สรุปจำนวน tree / คนของ public tree ต่อคณะ + ภาควิชา (faculty = "" = ทุกคณะ)
+	RollupPublic(ctx context.Context, faculty string) ([]*Rollup, error)
+
+	// Templates: โครง tree ของ owner (เห็นเฉพาะเจ้าของ)
+	CreateTemplate(ctx context.Context, t *Template) error
+	FindTemplate(ctx context.Context, id string) (*Template, error)
+	ListTemplates(ctx context.Context, ownerID string) ([]*Template, error)
+	DeleteTemplate(ctx context.Context, id, ownerID string) error
+
+	// UpdateContactPrivacy แทนที่ค่า visibility ของช่องทางติดต่อระดับ tree ทั้งหมด
+	UpdateContactPrivacy(ctx context.Context, treeID string, settings privacy.Settings) error
+
+	// UpdateOwner เปลี่ยน created_by จาก fromUserID เป็น toUserID
+	// ถ้าเจ้าของปัจจุบันไม่ใช่ fromUserID แล้วจะคืน ErrOwnerChanged
+	UpdateOwner(ctx context.Context, treeID, fromUserID, toUserID string) error
+
+	// BumpStructureRevision ล็อก tree row แล้วเพิ่ม structure_revision
+	// ถ้า expected != nil และไม่ตรงกับ revision ปัจจุบัน จะคืน ErrRevisionConflict
+	// ต้องเรียกใน transaction เดียวกับ structure operation ที่ตามมา
+	BumpStructureRevision(ctx context.Context, treeID string, expected *int64) (int64, error)
+
+	// LockStructureShared ล็อก tree row แบบ share จนจบ transaction โดยไม่เพิ่ม revision
+	// ใช้ตอนอ่าน structure + nodes ให้ตรงกัน (เช่นสร้าง snapshot) — การแก้ structure ต้องรอ
+	LockStructureShared(ctx context.Context, treeID string) error
+
+	// ReplaceStructure เขียนทับ structure ทั้งก้อน (ใช้ตอน restore snapshot)
+	// ต้องเรียกหลัง BumpStructureRevision ใน transaction เดียวกัน
+	ReplaceStructure(ctx context.Context, treeID string, s TreeStructure) error
+
+	// UpdateStructure ล็อก tree row (FOR UPDATE) อ่าน structure ล่าสุดให้ fn แก้ด้วย method ของ TreeStructure
+	// แล้วเขียนกลับ คืน structure ที่เขียนแล้ว — fn คืน error = ไม่เขียนอะไรและคืน error นั้นตามเดิม
+	// ต้องเรียกหลัง BumpStructureRevision ใน transaction เดียวกัน
+	UpdateStructure(ctx context.Context, treeID string, fn func(s *TreeStructure) error) (*TreeStructure, error)
 }
@@ -0,0 +1,93 @@
+package tree
+
+// StructureStats ตัวเลขที่คิดจาก structure อย่างเดียว (ไม่ต้องใช้ข้อมูลของ node)
+type StructureStats struct {
+	Seniors     int      // node ที่มีน้องรหัสอย่างน้อย 1 คน
+	Links       int      // จำนวนเส้นพี่ → น้องทั้งหมด
+	MaxChildren int      // น้องรหัสมากสุดของพี่คนเดียว
+	DeepestPath []string // root → node ที่ลึกที่สุด (ยาวเท่ากันเอาเส้นแรกตามลำดับ rootIds / children)
+	OrphanRoots []string // root ที่ไม่มีน้องรหัส (ยังไม่ได้ต่อสายกับใคร)
+	MultiParent int      // node ที่มีพี่รหัสมากกว่า 1 คน
+}
+
+// AvgChildren จำนวนน้องรหัสเฉลี่ยต่อพี่ที่มีน้อง
+func (st StructureStats) AvgChildren() float64 {
+	if st.Seniors == 0 {
+		return 0
+	}
+	return float64(st.Links) / float64(st.Seniors)
+}
+
+// Stats คิดตัวเลขสรุปของ structure
+func (s *TreeStructure) Stats() StructureStats {
+	st := StructureStats{DeepestPath: []string{}, OrphanRoots: []string{}}
+	for _, edge := range s.Edges {
+		if n := len(edge.Children); n > 0 {
+			st.Seniors++
+			st.Links += n
+			st.MaxChildren = max(st.MaxChildren, n)
+		}
+	}
+	for _, parents := range s.parentIndex() {
+		if len(parents) > 1 {
+			st.MultiParent++
+		}
+	}
+	for _, id := range s.RootIDs {
+		if len(s.Edges[id].Children) == 0 {
+			st.OrphanRoots = append(st.OrphanRoots, id)
+		}
+	}
+	st.DeepestPath = s.longestPath()
+	return st
+}
+
+// longestPath เส้นจาก root ลงไปที่ยาวที่สุด (นับ node) — เจอ cycle ก็ตัดที่ node ที่วนกลับมา
+func (s *TreeStructure) longestPath() []string {
+	length := map[string]int{} // จำนวน node ของเส้นที่ยาวสุดเริ่มจาก id
+	next := map[string]string{}
+	onPath := map[string]bool{}
+
+	var visit func(id string) int
+	visit = func(id string) int {
+		if l, ok := length[id]; ok {
+			return l
+		}
+		if onPath[id] {
+			return 0
+		}
+		onPath[id] = true
+		best := 0
+		for _, child := range s.Edges[id].Children {
+			if l := visit(child); l > best {
+				best = l
+				next[id] = child
+			}
+		}
+		onPath[id] = false
+		length[id] = best + 1
+		return best + 1
+	}
+
+	start, longest := "", 0
+	for _, id := range s.RootIDs {
+		if l := visit(id); l > longest {
+			start, longest = id, l
+		}
+	}
+
+	path := []string{}
+	for id := start; id != "" && len(path) < longest; id = next[id] {
+		path = append(path, id)
+	}
+	return path
+}
+
+// Rollup ตัวเลขรวมของ public tree ในคณะ + ภาควิชาเดียวกัน
+type Rollup struct {
+	Faculty    string
+	Department string
+	Trees      int
+	Members    int
+	ByStatus   map[string]int // key = สถานะของ node (studying / graduated / retired)
+}
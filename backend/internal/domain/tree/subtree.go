@@ -0,0 +1,128 @@
+package tree
+
+import "slices"
+
+// SharedPolicy วิธีจัดการ descendant ที่มี parent อยู่นอกสาย (multi-parent)
+type SharedPolicy int
+
+const (
+	// SharedLeave descendant ที่มี parent นอกสายไม่อยู่ในสาย (descendants ของมันที่ไม่มีทางอื่นก็ไม่อยู่)
+	SharedLeave SharedPolicy = iota
+	// SharedTake เอาทุก descendant เข้าสาย
+	SharedTake
+)
+
+// Subtree สายของ rootID ที่ operation ระดับสายจะแตะ
+type Subtree struct {
+	RootID  string
+	NodeIDs []string // root ก่อน แล้ว descendants แบบ pre-order (ตามลำดับ children)
+	Shared  []string // descendant ที่มี parent อยู่นอกสาย (SharedLeave = ไม่อยู่ใน NodeIDs)
+}
+
+// Contains ตรวจว่า id อยู่ในสายหรือไม่
+func (sub Subtree) Contains(id string) bool {
+	return slices.Contains(sub.NodeIDs, id)
+}
+
+// Subtree หา node ในสายของ rootID ตาม policy (rootID ต้องอยู่ใน structure)
+// SharedLeave: ตัด shared descendant ออกแล้วหาใหม่ซ้ำจนไม่เหลือ node ที่มี parent นอกสาย
+func (s *TreeStructure) Subtree(rootID string, policy SharedPolicy) Subtree {
+	parents := s.parentIndex()
+	excluded := map[string]bool{}
+	sub := Subtree{RootID: rootID, Shared: []string{}}
+
+	for {
+		sub.NodeIDs = s.collect(rootID, excluded)
+		inside := make(map[string]bool, len(sub.NodeIDs))
+		for _, id := range sub.NodeIDs {
+			inside[id] = true
+		}
+
+		var shared []string
+		for _, id := range sub.NodeIDs[1:] {
+			if slices.ContainsFunc(parents[id], func(p string) bool { return !inside[p] }) {
+				shared = append(shared, id)
+			}
+		}
+		if policy == SharedTake {
+			sub.Shared = append(sub.Shared, shared...)
+			return sub
+		}
+		if len(shared) == 0 {
+			return sub
+		}
+		for _, id := range shared {
+			excluded[id] = true
+		}
+		sub.Shared = append(sub.Shared, shared...)
+	}
+}
+
+// collect pre-order จาก rootID ไม่ลงไปใน excluded
+func (s *TreeStructure) collect(rootID string, excluded map[string]bool) []string {
+	var out []string
+	seen := map[string]bool{}
+	var visit func(id string)
+	visit = func(id string) {
+		if seen[id] || excluded[id] {
+			return
+		}
+		seen[id] = true
+		out = append(out, id)
+		for _, child := range s.Edges[id].Children {
+			visit(child)
+		}
+	}
+	visit(rootID)
+	return out
+}
+
+// parentIndex child → parents ทั้งหมดเรียงตาม id (สร้างครั้งเดียวแทนการเรียก FindParentIDs ทีละ node)
+func (s *TreeStructure) parentIndex() map[string][]string {
+	parents := make(map[string][]string, len(s.Edges))
+	for id, edge := range s.Edges {
+		for _, child := range edge.Children {
+			parents[child] = append(parents[child], id)
+		}
+	}
+	for _, ps := range parents {
+		slices.Sort(ps)
+	}
+	return parents
+}
+
+// RemoveChild ตัดเส้น parentID → childID ("" = เอาออกจาก rootIds) ไม่เติม root ให้ node ที่ไม่เหลือ parent
+func (s *TreeStructure) RemoveChild(parentID, childID string) {
+	isChild := func(id string) bool { return id == childID }
+	if parentID == "" {
+		s.RootIDs = slices.DeleteFunc(s.RootIDs, isChild)
+		return
+	}
+	e, ok := s.Edges[parentID]
+	if !ok {
+		return
+	}
+	e.Children = slices.DeleteFunc(e.Children, isChild)
+	s.Edges[parentID] = e
+}
+
+// InsertChild ใส่ childID ใน children ของ parentID ("" = rootIds) ที่ลำดับ order (nil / เกิน = ต่อท้าย)
+func (s *TreeStructure) InsertChild(parentID, childID string, order *int32) {
+	list := s.RootIDs
+	if parentID != "" {
+		list = s.Edges[parentID].Children
+	}
+	idx := len(list)
+	if order != nil && int(*order) >= 0 && int(*order) < idx {
+		idx = int(*order)
+	}
+	list = slices.Insert(slices.Clone(list), idx, childID)
+
+	if parentID == "" {
+		s.RootIDs = list
+		return
+	}
+	e := s.Edges[parentID]
+	e.Children = list
+	s.Edges[parentID] = e
+}
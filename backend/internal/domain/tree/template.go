@@ -0,0 +1,98 @@
+package tree
+
+import (
+	"fmt"
+	"time"
+)
+
+// Template โครง tree ที่บันทึกไว้เริ่ม tree ใหม่ (ไม่มีข้อมูลคน มีแค่ตำแหน่ง + รุ่น)
+type Template struct {
+	ID          string
+	OwnerID     string
+	Name        string
+	Description string
+	Faculty     string
+	Department  string
+	Structure   TreeStructure // ใช้ TemplateNode.Key แทน node id
+	Nodes       []TemplateNode
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TemplateNode ตำแหน่งหนึ่งใน template
+type TemplateNode struct {
+	Key              string `json:"key"`
+	Label            string `json:"label"`            // "" = ใช้ชื่อแทนตามรุ่น (PlaceholderLabel)
+	GenerationOffset int32  `json:"generationOffset"` // รุ่นนับจากรุ่นบนสุดของ template (0 = บนสุด)
+}
+
+// Generations จำนวนรุ่นใน template
+func (t *Template) Generations() int32 {
+	var maxOffset int32 = -1
+	for _, n := range t.Nodes {
+		maxOffset = max(maxOffset, n.GenerationOffset)
+	}
+	return maxOffset + 1
+}
+
+// PlaceholderLabel ชื่อเล่นชั่วคราวของ node ที่สร้างจาก template ที่ไม่มี label
+func PlaceholderLabel(generation int32) string {
+	return fmt.Sprintf("รุ่น %d", generation)
+}
+
+// Skeleton แปลง structure เป็นโครงของ template
+// key ใหม่เรียงตาม pre-order จาก root, รุ่นนับจากรุ่นต่ำสุดของ node ใน structure
+// labels ไม่มี key = ไม่เก็บชื่อ (ใช้ PlaceholderLabel ตอนสร้าง tree)
+func (s *TreeStructure) Skeleton(generations map[string]int32, labels map[string]string) (TreeStructure, []TemplateNode) {
+	var order []string
+	seen := map[string]bool{}
+	for _, root := range s.RootIDs {
+		for _, id := range s.collect(root, seen) {
+			seen[id] = true
+			order = append(order, id)
+		}
+	}
+
+	keys := make(map[string]string, len(order))
+	var minGen int32
+	for i, id := range order {
+		keys[id] = fmt.Sprintf("n%d", i+1)
+		if i == 0 || generations[id] < minGen {
+			minGen = generations[id]
+		}
+	}
+
+	nodes := make([]TemplateNode, len(order))
+	for i, id := range order {
+		nodes[i] = TemplateNode{
+			Key:              keys[id],
+			Label:            labels[id],
+			GenerationOffset: generations[id] - minGen,
+		}
+	}
+	return s.Remap(keys), nodes
+}
+
+// Remap structure เดิมด้วย id ใหม่ (old → new) id ที่ไม่มีใน ids ถูกตัดทิ้งพร้อมเส้นที่ชี้ไปหา
+func (s *TreeStructure) Remap(ids map[string]string) TreeStructure {
+	out := NewEmptyStructure()
+	for _, id := range s.RootIDs {
+		if newID, ok := ids[id]; ok {
+			out.RootIDs = append(out.RootIDs, newID)
+		}
+	}
+	for id, edge := range s.Edges {
+		newID, ok := ids[id]
+		if !ok {
+			continue
+		}
+		children := []string{}
+		for _, child := range edge.Children {
+			if newChild, ok := ids[child]; ok {
+				children = append(children, newChild)
+			}
+		}
+		out.Edges[newID] = TreeStructureEdge{Children: children, Order: edge.Order}
+	}
+	return out
+}
@@ -0,0 +1,10 @@
+package tx
+
+import "context"
+
+// Manager รวม repository call หลายตัวให้อยู่ใน transaction เดียว (unit of work)
+type Manager interface {
+	// WithinTx รัน fn ภายใน transaction: commit เมื่อ fn คืน nil, rollback เมื่อ fn คืน error
+	// ถ้า ctx อยู่ใน transaction อยู่แล้ว จะใช้ transaction เดิมต่อ (ไม่เปิดซ้อน)
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,352 @@
+package exchange
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+)
+
+var ErrUnsupportedExport = errors.New("unsupported export format")
+
+// Format รูปแบบไฟล์ export
+type Format string
+
+const (
+	FormatSnapshot Format = "json"
+	FormatDOT      Format = "dot"
+	FormatGraphML  Format = "graphml"
+	FormatGEDCOM   Format = "ged"
+)
+
+// ParseFormat แปลงชื่อ format จาก query string (?format=dot)
+func ParseFormat(s string) (Format, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "json", "snapshot":
+		return FormatSnapshot, true
+	case "dot", "gv", "graphviz":
+		return FormatDOT, true
+	case "graphml":
+		return FormatGraphML, true
+	case "ged", "gedcom":
+		return FormatGEDCOM, true
+	}
+	return "", false
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case FormatDOT:
+		return "text/vnd.graphviz; charset=utf-8"
+	case FormatGraphML:
+		return "application/graphml+xml; charset=utf-8"
+	case FormatGEDCOM:
+		return "text/plain; charset=utf-8"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// Filename ชื่อไฟล์สำหรับ Content-Disposition
+func (f Format) Filename(t *tree.Tree) string {
+	name := strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', '"', ':', '*', '?', '<', '>', '|':
+			return '_'
+		}
+		if r < 0x20 {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(t.Name))
+	if name == "" {
+		name = t.ID
+	}
+	return name + "." + string(f)
+}
+
+// Export เขียน tree + node ทั้งหมดในรูปแบบที่เลือก (ทุกแบบเก็บเส้น multi-parent ครบ)
+func Export(w io.Writer, f Format, t *tree.Tree, nodes []*node.Node) error {
+	switch f {
+	case FormatSnapshot:
+		return WriteSnapshot(w, BuildSnapshot(t, nodes))
+	case FormatDOT:
+		return WriteDOT(w, t, nodes)
+	case FormatGraphML:
+		return WriteGraphML(w, t, nodes)
+	case FormatGEDCOM:
+		return WriteGEDCOM(w, t, nodes)
+	default:
+		return ErrUnsupportedExport
+	}
+}
+
+// ==================== DOT (Graphviz) ====================
+
+func WriteDOT(w io.Writer, t *tree.Tree, nodes []*node.Node) error {
+	bw := bufio.NewWriter(w)
+	ordered := orderNodes(&t.Structure, nodes)
+
+	fmt.Fprintf(bw, "digraph %s {\n", dotQuote(t.Name))
+	bw.WriteString("  rankdir=TB;\n")
+	bw.WriteString("  node [shape=box, style=rounded];\n")
+	for _, n := range ordered {
+		label := n.Nickname
+		if full := strings.TrimSpace(n.FirstName + " " + n.LastName); full != "" {
+			label += "\n" + full
+		}
+		if n.StudentID != "" {
+			label += "\n" + n.StudentID
+		}
+		fmt.Fprintf(bw, "  %s [label=%s, generation=%d, status=%s];\n",
+			dotQuote(n.ID), dotQuote(label), n.Generation, dotQuote(string(n.Status)))
+	}
+	for _, e := range structureEdges(&t.Structure, ordered) {
+		fmt.Fprintf(bw, "  %s -> %s;\n", dotQuote(e.parentID), dotQuote(e.childID))
+	}
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+func dotQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r", "", "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// ==================== GraphML ====================
+
+// graphMLKeys attribute ของ node ใน GraphML (id, ชื่อ key)
+var graphMLKeys = []struct{ id, typ string }{
+	{"nickname", "string"},
+	{"first_name", "string"},
+	{"last_name", "string"},
+	{"student_id", "string"},
+	{"photo_url", "string"},
+	{"status", "string"},
+	{"generation", "int"},
+	{"position_x", "double"},
+	{"position_y", "double"},
+	{node.MetaKeyPhone, "string"},
+	{node.MetaKeyEmail, "string"},
+	{node.MetaKeyLineID, "string"},
+	{node.MetaKeyDiscord, "string"},
+	{node.MetaKeyFacebook, "string"},
+}
+
+func WriteGraphML(w io.Writer, t *tree.Tree, nodes []*node.Node) error {
+	bw := bufio.NewWriter(w)
+	ordered := orderNodes(&t.Structure, nodes)
+
+	bw.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	bw.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	bw.WriteString(`  <key id="name" for="graph" attr.name="name" attr.type="string"/>` + "\n")
+	for _, k := range graphMLKeys {
+		fmt.Fprintf(bw, `  <key id="%s" for="node" attr.name="%s" attr.type="%s"/>`+"\n", k.id, k.id, k.typ)
+	}
+	fmt.Fprintf(bw, `  <graph id=%s edgedefault="directed">`+"\n", xmlAttr(t.ID))
+	fmt.Fprintf(bw, `    <data key="name">%s</data>`+"\n", xmlText(t.Name))
+
+	for _, n := range ordered {
+		fmt.Fprintf(bw, `    <node id=%s>`+"\n", xmlAttr(n.ID))
+		values := map[string]string{
+			"nickname":   n.Nickname,
+			"first_name": n.FirstName,
+			"last_name":  n.LastName,
+			"student_id": n.StudentID,
+			"photo_url":  n.PhotoURL,
+			"status":     string(n.Status),
+			"generation": strconv.Itoa(int(n.Generation)),
+			"position_x": strconv.FormatFloat(n.PositionX, 'f', -1, 64),
+			"position_y": strconv.FormatFloat(n.PositionY, 'f', -1, 64),
+		}
+		for k, v := range n.Metadata {
+			values[k] = v
+		}
+		for _, k := range graphMLKeys {
+			if v := values[k.id]; v != "" {
+				fmt.Fprintf(bw, `      <data key="%s">%s</data>`+"\n", k.id, xmlText(v))
+			}
+		}
+		bw.WriteString("    </node>\n")
+	}
+	for i, e := range structureEdges(&t.Structure, ordered) {
+		fmt.Fprintf(bw, `    <edge id="e%d" source=%s target=%s/>`+"\n", i, xmlAttr(e.parentID), xmlAttr(e.childID))
+	}
+
+	bw.WriteString("  </graph>\n")
+	bw.WriteString("</graphml>\n")
+	return bw.Flush()
+}
+
+func xmlText(s string) string {
+	var b strings.Builder
+	// เขียนลง strings.Builder ไม่มีทาง error
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func xmlAttr(s string) string {
+	return `"` + xmlText(s) + `"`
+}
+
+// ==================== GEDCOM ====================
+
+// WriteGEDCOM เขียนแบบ GEDCOM 5.5.1 (lineage-linked)
+// GEDCOM ผูกลูกกับ "ครอบครัว" — สร้าง FAM หนึ่งอันต่อ parent หนึ่งคน (parent เป็น HUSB, ลูกเป็น CHIL)
+// node ที่มีหลาย parent จึงมี FAMC หลายบรรทัด ตรงกับเส้นใน structure ทุกเส้น
+func WriteGEDCOM(w io.Writer, t *tree.Tree, nodes []*node.Node) error {
+	bw := bufio.NewWriter(w)
+	ordered := orderNodes(&t.Structure, nodes)
+	edges := structureEdges(&t.Structure, ordered)
+
+	indiRef := make(map[string]string, len(ordered))
+	for i, n := range ordered {
+		indiRef[n.ID] = fmt.Sprintf("@I%d@", i+1)
+	}
+	famRef := make(map[string]string)
+	var famParents []string
+	childFams := make(map[string][]string)
+	for _, e := range edges {
+		ref, ok := famRef[e.parentID]
+		if !ok {
+			ref = fmt.Sprintf("@F%d@", len(famParents)+1)
+			famRef[e.parentID] = ref
+			famParents = append(famParents, e.parentID)
+		}
+		childFams[e.childID] = append(childFams[e.childID], ref)
+	}
+
+	line := func(level int, tag, value string) {
+		value = strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+		if value == "" {
+			fmt.Fprintf(bw, "%d %s\n", level, tag)
+			return
+		}
+		fmt.Fprintf(bw, "%d %s %s\n", level, tag, value)
+	}
+
+	line(0, "HEAD", "")
+	line(1, "SOUR", "CODE_TREE")
+	line(2, "NAME", "Code Tree")
+	line(1, "GEDC", "")
+	line(2, "VERS", "5.5.1")
+	line(2, "FORM", "LINEAGE-LINKED")
+	line(1, "CHAR", "UTF-8")
+	line(1, "NOTE", t.Name)
+
+	for _, n := range ordered {
+		line(0, indiRef[n.ID]+" INDI", "")
+		if n.FirstName == "" && n.LastName == "" {
+			line(1, "NAME", n.Nickname)
+		} else {
+			line(1, "NAME", strings.TrimSpace(n.FirstName+" /"+n.LastName+"/"))
+			if n.FirstName != "" {
+				line(2, "GIVN", n.FirstName)
+			}
+			if n.LastName != "" {
+				line(2, "SURN", n.LastName)
+			}
+		}
+		line(2, "NICK", n.Nickname)
+		line(1, "REFN", n.ID)
+		if n.StudentID != "" {
+			line(1, "_SID", n.StudentID)
+		}
+		line(1, "_GEN", strconv.Itoa(int(n.Generation)))
+		line(1, "_STATUS", string(n.Status))
+		if n.Email() != "" {
+			line(1, "EMAIL", n.Email())
+		}
+		if n.Phone() != "" {
+			line(1, "PHON", n.Phone())
+		}
+		if ref, ok := famRef[n.ID]; ok {
+			line(1, "FAMS", ref)
+		}
+		for _, ref := range childFams[n.ID] {
+			line(1, "FAMC", ref)
+		}
+	}
+
+	for _, parentID := range famParents {
+		line(0, famRef[parentID]+" FAM", "")
+		line(1, "HUSB", indiRef[parentID])
+		for _, e := range edges {
+			if e.parentID == parentID {
+				line(1, "CHIL", indiRef[e.childID])
+			}
+		}
+	}
+
+	line(0, "TRLR", "")
+	return bw.Flush()
+}
+
+// ==================== Helpers ====================
+
+type edge struct {
+	parentID string
+	childID  string
+}
+
+// orderNodes เรียง node ตาม structure (root ก่อน แล้วไล่ลูกตามลำดับ children แบบ BFS)
+// node ที่ไม่อยู่ใน structure ต่อท้าย เรียงตามรุ่นและชื่อเล่น
+func orderNodes(s *tree.TreeStructure, nodes []*node.Node) []*node.Node {
+	byID := make(map[string]*node.Node, len(nodes))
+	for _, n := range nodes {
+		byID[n.ID] = n
+	}
+
+	ordered := make([]*node.Node, 0, len(nodes))
+	visited := make(map[string]bool, len(nodes))
+	queue := append([]string(nil), s.RootIDs...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		n, ok := byID[id]
+		if !ok || visited[id] {
+			continue
+		}
+		visited[id] = true
+		ordered = append(ordered, n)
+		queue = append(queue, s.Edges[id].Children...)
+	}
+
+	var rest []*node.Node
+	for _, n := range nodes {
+		if !visited[n.ID] {
+			rest = append(rest, n)
+		}
+	}
+	sort.SliceStable(rest, func(a, b int) bool {
+		if rest[a].Generation != rest[b].Generation {
+			return rest[a].Generation < rest[b].Generation
+		}
+		return rest[a].Nickname < rest[b].Nickname
+	})
+	return append(ordered, rest...)
+}
+
+// structureEdges เส้น parent → child ทั้งหมด (เฉพาะที่ทั้งสองฝั่งมี node อยู่จริง)
+func structureEdges(s *tree.TreeStructure, ordered []*node.Node) []edge {
+	exists := make(map[string]bool, len(ordered))
+	for _, n := range ordered {
+		exists[n.ID] = true
+	}
+	var edges []edge
+	for _, n := range ordered {
+		for _, childID := range s.Edges[n.ID].Children {
+			if exists[childID] {
+				edges = append(edges, edge{parentID: n.ID, childID: childID})
+			}
+		}
+	}
+	return edges
+}
@@ -0,0 +1,194 @@
+package exchange
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+)
+
+// ParentRef parent ของ node ที่จะ import: node เดิมใน tree หรือ node ใหม่จากไฟล์เดียวกัน
+type ParentRef struct {
+	ExistingID string // id ของ node ที่มีอยู่แล้วใน tree
+	PlanIndex  int    // index ใน Plan.Nodes (ใช้เมื่อ ExistingID == "")
+}
+
+type PlannedNode struct {
+	Line    int
+	Node    *node.Node
+	Parents []ParentRef
+}
+
+// Plan ลำดับการสร้าง node — parent ที่มาจากไฟล์จะอยู่ก่อน child เสมอ
+type Plan struct {
+	Nodes []*PlannedNode
+}
+
+// PlanRows ตรวจแถวที่อ่านจากไฟล์เทียบกับ node ที่มีอยู่ใน tree แล้วเรียงลำดับการสร้าง
+// ถ้ามี issue จะคืน plan เป็น nil (ต้องแก้ไฟล์ก่อน ไม่ import บางส่วน)
+func PlanRows(treeID string, rows []Row, existing []*node.Node) (*Plan, []Issue) {
+	var issues []Issue
+
+	existingBySID := make(map[string]*node.Node, len(existing))
+	for _, n := range existing {
+		if n.StudentID != "" {
+			existingBySID[n.StudentID] = n
+		}
+	}
+
+	// ตรวจข้อมูลรายแถว + student_id ซ้ำ (ในไฟล์ / กับ unique_student_id_per_tree)
+	rowBySID := make(map[string]int, len(rows))
+	for i, row := range rows {
+		if row.Nickname == "" {
+			issues = append(issues, Issue{Line: row.Line, Column: colNickname, Message: node.ErrNoNickname.Error()})
+		}
+		if row.StudentID == "" {
+			continue
+		}
+		if first, dup := rowBySID[row.StudentID]; dup {
+			issues = append(issues, Issue{
+				Line:    row.Line,
+				Column:  colStudentID,
+				Message: fmt.Sprintf("duplicate student_id %q (same as line %d)", row.StudentID, rows[first].Line),
+			})
+			continue
+		}
+		rowBySID[row.StudentID] = i
+		if _, exists := existingBySID[row.StudentID]; exists {
+			issues = append(issues, Issue{
+				Line:    row.Line,
+				Column:  colStudentID,
+				Message: fmt.Sprintf("student_id %q already exists in this tree", row.StudentID),
+			})
+		}
+	}
+
+	// resolve parent ด้วย student_id: หาในไฟล์ก่อน แล้วค่อยหาใน tree
+	type rowParent struct {
+		existing *node.Node
+		row      int
+	}
+	parents := make([][]rowParent, len(rows))
+	children := make([][]int, len(rows))
+	indegree := make([]int, len(rows))
+	for i, row := range rows {
+		seen := make(map[string]bool)
+		for _, sid := range row.ParentStudentIDs {
+			if seen[sid] {
+				continue
+			}
+			seen[sid] = true
+
+			if sid == row.StudentID {
+				issues = append(issues, Issue{Line: row.Line, Column: colParents, Message: node.ErrSelfParent.Error()})
+				continue
+			}
+			if j, ok := rowBySID[sid]; ok {
+				parents[i] = append(parents[i], rowParent{row: j})
+				children[j] = append(children[j], i)
+				indegree[i]++
+				continue
+			}
+			if n, ok := existingBySID[sid]; ok {
+				parents[i] = append(parents[i], rowParent{existing: n})
+				continue
+			}
+			issues = append(issues, Issue{
+				Line:    row.Line,
+				Column:  colParents,
+				Message: fmt.Sprintf("unknown parent student_id %q", sid),
+			})
+		}
+	}
+
+	// topological sort (Kahn) — แถวที่เหลือค้างคือแถวที่อยู่ในวง (cycle)
+	order := make([]int, 0, len(rows))
+	queue := make([]int, 0, len(rows))
+	for i := range rows {
+		if indegree[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		order = append(order, i)
+		for _, c := range children[i] {
+			indegree[c]--
+			if indegree[c] == 0 {
+				queue = append(queue, c)
+			}
+		}
+	}
+	if len(order) < len(rows) {
+		var lines []string
+		for i := range rows {
+			if indegree[i] > 0 {
+				lines = append(lines, fmt.Sprint(rows[i].Line))
+			}
+		}
+		issues = append(issues, Issue{
+			Line:    rows[firstStuck(indegree)].Line,
+			Column:  colParents,
+			Message: fmt.Sprintf("circular parent reference between lines %s", strings.Join(lines, ", ")),
+		})
+	}
+
+	if len(issues) > 0 {
+		sort.SliceStable(issues, func(a, b int) bool { return issues[a].Line < issues[b].Line })
+		return nil, issues
+	}
+
+	// สร้าง plan ตามลำดับ topo + คำนวณรุ่น (กรอกเอง > parent ตัวแรก + 1 > 0)
+	plan := &Plan{Nodes: make([]*PlannedNode, 0, len(rows))}
+	planIndex := make([]int, len(rows))
+	for _, i := range order {
+		row := rows[i]
+		pn := &PlannedNode{Line: row.Line}
+
+		var generation int32
+		for k, p := range parents[i] {
+			var parentGen int32
+			if p.existing != nil {
+				pn.Parents = append(pn.Parents, ParentRef{ExistingID: p.existing.ID})
+				parentGen = p.existing.Generation
+			} else {
+				idx := planIndex[p.row]
+				pn.Parents = append(pn.Parents, ParentRef{PlanIndex: idx})
+				parentGen = plan.Nodes[idx].Node.Generation
+			}
+			if k == 0 {
+				generation = parentGen + 1
+			}
+		}
+		if row.Generation != nil {
+			generation = *row.Generation
+		}
+
+		pn.Node = &node.Node{
+			TreeID:     treeID,
+			Nickname:   row.Nickname,
+			FirstName:  row.FirstName,
+			LastName:   row.LastName,
+			StudentID:  row.StudentID,
+			Status:     row.Status,
+			Generation: generation,
+		}
+		pn.Node.SetContact(row.Phone, row.Email, row.LineID, row.Discord, row.Facebook)
+
+		planIndex[i] = len(plan.Nodes)
+		plan.Nodes = append(plan.Nodes, pn)
+	}
+
+	return plan, nil
+}
+
+func firstStuck(indegree []int) int {
+	for i, d := range indegree {
+		if d > 0 {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,222 @@
+package exchange
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+)
+
+var (
+	ErrEmptyFile       = errors.New("file has no rows")
+	ErrNoNicknameCol   = errors.New("missing required column: nickname")
+	ErrUnsupportedFile = errors.New("unsupported import format")
+)
+
+// Row ข้อมูลคนหนึ่งคนจากไฟล์ import (1 แถว)
+type Row struct {
+	Line             int // เลขแถวในไฟล์ (นับ header เป็นแถว 1)
+	Nickname         string
+	FirstName        string
+	LastName         string
+	StudentID        string
+	Generation       *int32 // nil = ไม่ได้กรอก
+	Status           node.Status
+	Phone            string
+	Email            string
+	LineID           string
+	Discord          string
+	Facebook         string
+	ParentStudentIDs []string
+}
+
+// Issue ปัญหาที่เจอตอนอ่าน / ตรวจไฟล์ import
+type Issue struct {
+	Line    int
+	Column  string
+	Message string
+}
+
+// column ชื่อ column มาตรฐานของไฟล์ import
+const (
+	colNickname   = "nickname"
+	colFirstName  = "first_name"
+	colLastName   = "last_name"
+	colStudentID  = "student_id"
+	colGeneration = "generation"
+	colStatus     = "status"
+	colPhone      = "phone"
+	colEmail      = "email"
+	colLineID     = "line_id"
+	colDiscord    = "discord"
+	colFacebook   = "facebook"
+	colParents    = "parent_student_ids"
+)
+
+// headerAliases ชื่อ header ที่รับได้ (normalize แล้ว) → column มาตรฐาน
+var headerAliases = map[string]string{
+	"nickname": colNickname, "nick": colNickname, "ชื่อเล่น": colNickname,
+	"first_name": colFirstName, "firstname": colFirstName, "name": colFirstName, "ชื่อ": colFirstName, "ชื่อจริง": colFirstName,
+	"last_name": colLastName, "lastname": colLastName, "surname": colLastName, "นามสกุล": colLastName,
+	"student_id": colStudentID, "studentid": colStudentID, "รหัสนักศึกษา": colStudentID, "รหัส": colStudentID,
+	"generation": colGeneration, "gen": colGeneration, "รุ่น": colGeneration,
+	"status": colStatus, "สถานะ": colStatus,
+	"phone": colPhone, "tel": colPhone, "เบอร์โทร": colPhone, "โทรศัพท์": colPhone,
+	"email": colEmail, "อีเมล": colEmail,
+	"line_id": colLineID, "line": colLineID, "ไลน์": colLineID,
+	"discord":  colDiscord,
+	"facebook": colFacebook, "fb": colFacebook,
+	"parent_student_ids": colParents, "parent_student_id": colParents, "parents": colParents, "parent": colParents, "รหัสพี่": colParents,
+}
+
+// statusAliases ค่า status ที่รับได้ (ไทย / อังกฤษ)
+var statusAliases = map[string]node.Status{
+	"studying": node.StatusStudying, "กำลังศึกษา": node.StatusStudying, "เรียนอยู่": node.StatusStudying,
+	"graduated": node.StatusGraduated, "จบการศึกษา": node.StatusGraduated, "จบแล้ว": node.StatusGraduated,
+	"retired": node.StatusRetired, "พ้นสภาพ": node.StatusRetired, "ลาออก": node.StatusRetired,
+}
+
+// ParseCSV อ่านไฟล์ CSV (รองรับ UTF-8 BOM ที่ Excel ใส่มา)
+func ParseCSV(data []byte) ([]Row, []Issue, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	var records [][]string
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+		records = append(records, rec)
+	}
+	return parseRecords(records)
+}
+
+// ParseXLSX อ่าน sheet แรกของไฟล์ Excel
+func ParseXLSX(data []byte) ([]Row, []Issue, error) {
+	records, err := readFirstSheet(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return parseRecords(records)
+}
+
+// parseRecords แปลงตาราง (แถวแรกเป็น header) เป็น Row
+func parseRecords(records [][]string) ([]Row, []Issue, error) {
+	if len(records) == 0 {
+		return nil, nil, ErrEmptyFile
+	}
+
+	columns := make(map[string]int)
+	for i, h := range records[0] {
+		if col, ok := headerAliases[normalizeHeader(h)]; ok {
+			if _, dup := columns[col]; !dup {
+				columns[col] = i
+			}
+		}
+	}
+	if _, ok := columns[colNickname]; !ok {
+		return nil, nil, ErrNoNicknameCol
+	}
+
+	var rows []Row
+	var issues []Issue
+	for i, rec := range records[1:] {
+		line := i + 2
+		get := func(col string) string {
+			idx, ok := columns[col]
+			if !ok || idx >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[idx])
+		}
+
+		// ข้ามแถวว่างทั้งแถว
+		if isBlank(rec) {
+			continue
+		}
+
+		row := Row{
+			Line:             line,
+			Nickname:         get(colNickname),
+			FirstName:        get(colFirstName),
+			LastName:         get(colLastName),
+			StudentID:        get(colStudentID),
+			Status:           node.StatusStudying,
+			Phone:            get(colPhone),
+			Email:            get(colEmail),
+			LineID:           get(colLineID),
+			Discord:          get(colDiscord),
+			Facebook:         get(colFacebook),
+			ParentStudentIDs: splitList(get(colParents)),
+		}
+
+		if v := get(colGeneration); v != "" {
+			gen, err := strconv.ParseInt(v, 10, 32)
+			if err != nil || gen < 0 {
+				issues = append(issues, Issue{Line: line, Column: colGeneration, Message: fmt.Sprintf("invalid generation %q", v)})
+			} else {
+				g := int32(gen)
+				row.Generation = &g
+			}
+		}
+
+		if v := get(colStatus); v != "" {
+			status, ok := statusAliases[strings.ToLower(v)]
+			if !ok {
+				issues = append(issues, Issue{Line: line, Column: colStatus, Message: fmt.Sprintf("unknown status %q", v)})
+			} else {
+				row.Status = status
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, nil, ErrEmptyFile
+	}
+	return rows, issues, nil
+}
+
+// normalizeHeader ตัดช่องว่าง / ตัวพิมพ์ ให้ header หลายแบบชี้ไป column เดียวกัน
+func normalizeHeader(h string) string {
+	h = strings.TrimPrefix(h, "\ufeff")
+	h = strings.ToLower(strings.TrimSpace(h))
+	h = strings.NewReplacer(" ", "_", "-", "_").Replace(h)
+	return h
+}
+
+// splitList แยกรหัสพี่หลายคนในช่องเดียว (คั่นด้วย ; , | หรือขึ้นบรรทัดใหม่)
+func splitList(v string) []string {
+	fields := strings.FieldsFunc(v, func(r rune) bool {
+		return r == ';' || r == ',' || r == '|' || r == '\n'
+	})
+	out := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f != "" {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func isBlank(rec []string) bool {
+	for _, v := range rec {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
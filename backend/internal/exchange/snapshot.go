@@ -0,0 +1,270 @@
+package exchange
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+)
+
+// SnapshotVersion เวอร์ชันของรูปแบบ JSON snapshot (เพิ่มเมื่อเปลี่ยน schema แบบไม่ compatible)
+const SnapshotVersion = 1
+
+var (
+	ErrInvalidSnapshot     = errors.New("invalid snapshot file")
+	ErrUnsupportedSnapshot = errors.New("unsupported snapshot version")
+)
+
+// Snapshot ข้อมูลทั้ง tree แบบไม่ตกหล่น (structure + ทุก node) ใช้ export / import กลับ
+type Snapshot struct {
+	Version    int                `json:"version"`
+	ExportedAt string             `json:"exportedAt"`
+	Tree       SnapshotTree       `json:"tree"`
+	Structure  tree.TreeStructure `json:"structure"`
+	Nodes      []SnapshotNode     `json:"nodes"`
+}
+
+type SnapshotTree struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Faculty     string `json:"faculty,omitempty"`
+	Department  string `json:"department,omitempty"`
+}
+
+type SnapshotNode struct {
+	ID         string            `json:"id"`
+	Nickname   string            `json:"nickname"`
+	FirstName  string            `json:"firstName,omitempty"`
+	LastName   string            `json:"lastName,omitempty"`
+	StudentID  string            `json:"studentId,omitempty"`
+	PhotoURL   string            `json:"photoUrl,omitempty"`
+	Status     node.Status       `json:"status"`
+	Generation int32             `json:"generation"`
+	PositionX  float64           `json:"positionX"`
+	PositionY  float64           `json:"positionY"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	CreatedAt  string            `json:"createdAt"`
+	UpdatedAt  string            `json:"updatedAt"`
+}
+
+// BuildSnapshot สร้าง snapshot จาก tree + node ทั้งหมด (เรียง node ตาม structure)
+func BuildSnapshot(t *tree.Tree, nodes []*node.Node) *Snapshot {
+	s := &Snapshot{
+		Version:    SnapshotVersion,
+		ExportedAt: time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+		Tree: SnapshotTree{
+			ID:          t.ID,
+			Name:        t.Name,
+			Description: t.Description,
+			Faculty:     t.Faculty,
+			Department:  t.Department,
+		},
+		Structure: t.Structure,
+		Nodes:     make([]SnapshotNode, 0, len(nodes)),
+	}
+	for _, n := range orderNodes(&t.Structure, nodes) {
+		s.Nodes = append(s.Nodes, SnapshotNode{
+			ID:         n.ID,
+			Nickname:   n.Nickname,
+			FirstName:  n.FirstName,
+			LastName:   n.LastName,
+			StudentID:  n.StudentID,
+			PhotoURL:   n.PhotoURL,
+			Status:     n.Status,
+			Generation: n.Generation,
+			PositionX:  n.PositionX,
+			PositionY:  n.PositionY,
+			Metadata:   n.Metadata,
+			CreatedAt:  n.CreatedAt.Format("2006-01-02T15:04:05Z"),
+			UpdatedAt:  n.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		})
+	}
+	return s
+}
+
+// WriteSnapshot เขียน snapshot เป็น JSON (indent ให้อ่าน / diff ได้)
+func WriteSnapshot(w io.Writer, s *Snapshot) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// ParseSnapshot อ่าน JSON snapshot ที่ได้จาก WriteSnapshot
+func ParseSnapshot(data []byte) (*Snapshot, error) {
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	if s.Version != SnapshotVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedSnapshot, s.Version)
+	}
+	if len(s.Nodes) == 0 {
+		return nil, ErrEmptyFile
+	}
+	if s.Structure.Edges == nil {
+		s.Structure.Edges = make(map[string]tree.TreeStructureEdge)
+	}
+	return &s, nil
+}
+
+// PlanSnapshot ตรวจ snapshot แล้วสร้าง plan สำหรับ import เข้า tree (node ได้ id ใหม่ทั้งหมด)
+// เส้น parent → child ทุกเส้นใน structure ถูกเก็บไว้ใน PlannedNode.Parents
+// Line ของ issue / PlannedNode คือลำดับของ node ใน snapshot (เริ่มที่ 1)
+func PlanSnapshot(treeID string, s *Snapshot, existing []*node.Node) (*Plan, []Issue) {
+	var issues []Issue
+
+	existingBySID := make(map[string]bool, len(existing))
+	for _, n := range existing {
+		if n.StudentID != "" {
+			existingBySID[n.StudentID] = true
+		}
+	}
+
+	index := make(map[string]int, len(s.Nodes))
+	seenSID := make(map[string]int)
+	for i, sn := range s.Nodes {
+		line := i + 1
+		if sn.ID == "" {
+			issues = append(issues, Issue{Line: line, Column: "id", Message: "missing node id"})
+			continue
+		}
+		if first, dup := index[sn.ID]; dup {
+			issues = append(issues, Issue{Line: line, Column: "id", Message: fmt.Sprintf("duplicate node id %q (same as node %d)", sn.ID, first+1)})
+			continue
+		}
+		index[sn.ID] = i
+
+		if sn.Nickname == "" {
+			issues = append(issues, Issue{Line: line, Column: colNickname, Message: node.ErrNoNickname.Error()})
+		}
+		if sn.StudentID != "" {
+			if first, dup := seenSID[sn.StudentID]; dup {
+				issues = append(issues, Issue{Line: line, Column: colStudentID, Message: fmt.Sprintf("duplicate student_id %q (same as node %d)", sn.StudentID, first+1)})
+			} else if existingBySID[sn.StudentID] {
+				issues = append(issues, Issue{Line: line, Column: colStudentID, Message: fmt.Sprintf("student_id %q already exists in this tree", sn.StudentID)})
+			}
+			seenSID[sn.StudentID] = i
+		}
+		switch sn.Status {
+		case node.StatusStudying, node.StatusGraduated, node.StatusRetired:
+		default:
+			issues = append(issues, Issue{Line: line, Column: colStatus, Message: fmt.Sprintf("unknown status %q", sn.Status)})
+		}
+	}
+
+	// parent ของแต่ละ node ตามลำดับใน structure (parent ใน rootIds ไม่มี)
+	parents := make([][]int, len(s.Nodes))
+	children := make([][]int, len(s.Nodes))
+	indegree := make([]int, len(s.Nodes))
+	for _, parentID := range sortedEdgeKeys(&s.Structure) {
+		p, ok := index[parentID]
+		if !ok {
+			if len(s.Structure.Edges[parentID].Children) > 0 {
+				issues = append(issues, Issue{Column: "structure", Message: fmt.Sprintf("edge references unknown node %q", parentID)})
+			}
+			continue
+		}
+		for _, childID := range s.Structure.Edges[parentID].Children {
+			c, ok := index[childID]
+			if !ok {
+				issues = append(issues, Issue{Line: p + 1, Column: "structure", Message: fmt.Sprintf("edge references unknown node %q", childID)})
+				continue
+			}
+			if c == p {
+				issues = append(issues, Issue{Line: c + 1, Column: "structure", Message: node.ErrSelfParent.Error()})
+				continue
+			}
+			parents[c] = append(parents[c], p)
+			children[p] = append(children[p], c)
+			indegree[c]++
+		}
+	}
+
+	if len(issues) > 0 {
+		sort.SliceStable(issues, func(a, b int) bool { return issues[a].Line < issues[b].Line })
+		return nil, issues
+	}
+
+	// topological sort: เริ่มจาก rootIds ตามลำดับเดิม แล้วไล่ลูกตามลำดับ children
+	// เพื่อให้ลำดับลูกใต้ parent ที่สร้างใหม่ตรงกับ snapshot
+	order := make([]int, 0, len(s.Nodes))
+	queue := make([]int, 0, len(s.Nodes))
+	queued := make([]bool, len(s.Nodes))
+	for _, id := range s.Structure.RootIDs {
+		if i, ok := index[id]; ok && indegree[i] == 0 && !queued[i] {
+			queue = append(queue, i)
+			queued[i] = true
+		}
+	}
+	for i := range s.Nodes {
+		if indegree[i] == 0 && !queued[i] {
+			queue = append(queue, i)
+			queued[i] = true
+		}
+	}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		order = append(order, i)
+		for _, c := range children[i] {
+			indegree[c]--
+			if indegree[c] == 0 {
+				queue = append(queue, c)
+			}
+		}
+	}
+	if len(order) < len(s.Nodes) {
+		i := firstStuck(indegree)
+		return nil, []Issue{{Line: i + 1, Column: "structure", Message: "structure contains a cycle"}}
+	}
+
+	plan := &Plan{Nodes: make([]*PlannedNode, 0, len(s.Nodes))}
+	planIndex := make([]int, len(s.Nodes))
+	for _, i := range order {
+		sn := s.Nodes[i]
+		pn := &PlannedNode{
+			Line: i + 1,
+			Node: &node.Node{
+				TreeID:     treeID,
+				Nickname:   sn.Nickname,
+				FirstName:  sn.FirstName,
+				LastName:   sn.LastName,
+				StudentID:  sn.StudentID,
+				PhotoURL:   sn.PhotoURL,
+				Status:     sn.Status,
+				Generation: sn.Generation,
+				PositionX:  sn.PositionX,
+				PositionY:  sn.PositionY,
+				Metadata:   sn.Metadata,
+			},
+		}
+		for _, p := range parents[i] {
+			pn.Parents = append(pn.Parents, ParentRef{PlanIndex: planIndex[p]})
+		}
+		planIndex[i] = len(plan.Nodes)
+		plan.Nodes = append(plan.Nodes, pn)
+	}
+	return plan, nil
+}
+
+// sortedEdgeKeys id ของ parent ใน structure แบบเรียงตาม order (ให้ผลลัพธ์คงที่ทุกครั้ง)
+func sortedEdgeKeys(s *tree.TreeStructure) []string {
+	keys := make([]string, 0, len(s.Edges))
+	for id := range s.Edges {
+		keys = append(keys, id)
+	}
+	sort.Slice(keys, func(a, b int) bool {
+		ea, eb := s.Edges[keys[a]], s.Edges[keys[b]]
+		if ea.Order != eb.Order {
+			return ea.Order < eb.Order
+		}
+		return keys[a] < keys[b]
+	})
+	return keys
+}
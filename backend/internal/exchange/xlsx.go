@@ -0,0 +1,181 @@
+package exchange
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// readFirstSheet อ่านค่าทุก cell ของ worksheet แรกในไฟล์ .xlsx
+// อ่านเฉพาะค่า (shared string, inline string, ตัวเลข) ไม่สนใจ style / สูตร
+func readFirstSheet(data []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open xlsx: %w", err)
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var shared []string
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if shared, err = readSharedStrings(f); err != nil {
+			return nil, err
+		}
+	}
+
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("xlsx: worksheet %s not found", sheetPath)
+	}
+	return readSheet(f, shared)
+}
+
+// firstSheetPath หา path ของ sheet แรกตามลำดับใน workbook.xml
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	var wb struct {
+		Sheets []struct {
+			RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	var rels struct {
+		Rels []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodeXML(files["xl/workbook.xml"], &wb); err != nil {
+		return "", err
+	}
+	if err := decodeXML(files["xl/_rels/workbook.xml.rels"], &rels); err != nil {
+		return "", err
+	}
+	if len(wb.Sheets) == 0 {
+		return "", ErrEmptyFile
+	}
+
+	for _, rel := range rels.Rels {
+		if rel.ID != wb.Sheets[0].RID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return "", fmt.Errorf("xlsx: relationship %s not found", wb.Sheets[0].RID)
+}
+
+func readSharedStrings(f *zip.File) ([]string, error) {
+	var sst struct {
+		Items []struct {
+			T string `xml:"t"`
+			R []struct {
+				T string `xml:"t"`
+			} `xml:"r"`
+		} `xml:"si"`
+	}
+	if err := decodeXML(f, &sst); err != nil {
+		return nil, err
+	}
+
+	out := make([]string, len(sst.Items))
+	for i, si := range sst.Items {
+		// rich text แยกเป็นหลาย run ต้องต่อกันเอง
+		if len(si.R) > 0 {
+			var b strings.Builder
+			for _, r := range si.R {
+				b.WriteString(r.T)
+			}
+			out[i] = b.String()
+			continue
+		}
+		out[i] = si.T
+	}
+	return out, nil
+}
+
+func readSheet(f *zip.File, shared []string) ([][]string, error) {
+	var ws struct {
+		Rows []struct {
+			Cells []struct {
+				Ref    string `xml:"r,attr"`
+				Type   string `xml:"t,attr"`
+				Value  string `xml:"v"`
+				Inline string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := decodeXML(f, &ws); err != nil {
+		return nil, err
+	}
+
+	records := make([][]string, 0, len(ws.Rows))
+	for _, row := range ws.Rows {
+		var rec []string
+		for i, c := range row.Cells {
+			col := i
+			if c.Ref != "" {
+				col = columnIndex(c.Ref)
+			}
+			for len(rec) <= col {
+				rec = append(rec, "")
+			}
+
+			switch c.Type {
+			case "s":
+				idx, err := strconv.Atoi(c.Value)
+				if err != nil || idx < 0 || idx >= len(shared) {
+					return nil, fmt.Errorf("xlsx: invalid shared string index %q", c.Value)
+				}
+				rec[col] = shared[idx]
+			case "inlineStr":
+				rec[col] = c.Inline
+			default:
+				rec[col] = c.Value
+			}
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// columnIndex แปลง cell ref เช่น "C12" → 2
+func columnIndex(ref string) int {
+	idx := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		idx = idx*26 + int(r-'A'+1)
+	}
+	return idx - 1
+}
+
+func decodeXML(f *zip.File, v any) error {
+	if f == nil {
+		return fmt.Errorf("xlsx: missing workbook part")
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("xlsx: failed to open %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	if err := xml.NewDecoder(io.LimitReader(rc, 64<<20)).Decode(v); err != nil {
+		return fmt.Errorf("xlsx: failed to parse %s: %w", f.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/share"
+)
+
+const (
+	claimedCacheSize = 10000
+	claimedCacheTTL  = 30 * time.Minute
+)
+
+// InvitationClaimer เปลี่ยนคำเชิญที่ค้างของ user เป็น share ตอน request แรกที่ login แล้ว
+// (สมัครด้วย email/password ต้องยืนยัน email ก่อน trigger handle_new_user จึงรับให้ไม่ได้)
+// ต้องครอบด้านในของ AuthMiddleware เพื่อให้มี user ID ใน context แล้ว
+type InvitationClaimer struct {
+	shares  share.Repository
+	claimed *claimedCache // user ID ที่รับคำเชิญไปแล้ว (email ยืนยันแล้ว) ไม่ต้องถาม DB ทุก request
+}
+
+func NewInvitationClaimer(shares share.Repository) *InvitationClaimer {
+	return &InvitationClaimer{
+		shares:  shares,
+		claimed: newClaimedCache(claimedCacheSize, claimedCacheTTL),
+	}
+}
+
+func (c *InvitationClaimer) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if userID, err := GetUserID(r.Context()); err == nil && !c.claimed.Has(userID) {
+			// พังก็ไม่ block request — รอบหน้าจะลองใหม่
+			// email ยังไม่ยืนยัน = ไม่จำ ยืนยันแล้ว request ถัดไปจะรับได้
+			_, err := c.shares.AcceptInvitations(r.Context(), userID)
+			switch {
+			case err == nil:
+				c.claimed.Add(userID)
+			case !errors.Is(err, share.ErrEmailUnconfirmed):
+				slog.Warn("failed to accept invitations", "user_id", userID, "error", err)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// claimedCache ชุด user ID ที่หมดอายุเองตาม ttl และไล่ตัวเก่าสุดออกเมื่อเต็ม
+// (หมดอายุ = ถาม DB อีกรอบ เผื่อมีคำเชิญใหม่ถึง email ที่สมัครแล้ว)
+type claimedCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	max     int
+	entries map[string]time.Time // user ID → เวลาหมดอายุ
+	order   []string             // ลำดับที่ใส่เข้า ใช้ไล่ตัวเก่าสุดออก
+}
+
+func newClaimedCache(max int, ttl time.Duration) *claimedCache {
+	return &claimedCache{
+		ttl:     ttl,
+		max:     max,
+		entries: make(map[string]time.Time),
+	}
+}
+
+func (c *claimedCache) Has(userID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt, ok := c.entries[userID]
+	return ok && time.Now().Before(expiresAt)
+}
+
+func (c *claimedCache) Add(userID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.entries[userID]; !exists {
+		for len(c.order) >= c.max {
+			delete(c.entries, c.order[0])
+			c.order = c.order[1:]
+		}
+		c.order = append(c.order, userID)
+	}
+	c.entries[userID] = time.Now().Add(c.ttl)
+}
@@ -0,0 +1,200 @@
+package render
+
+import (
+	"math"
+	"sort"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+)
+
+// ขนาดการ์ด / ระยะห่าง ใช้ค่าเดียวกับ layout-engine ฝั่ง frontend
+// เพื่อให้ตำแหน่งที่ผู้ใช้ลากเก็บไว้ (PositionX / PositionY) วางตรงกัน
+const (
+	NodeWidth  = 200.0
+	NodeHeight = 100.0
+	nodeGap    = 60.0
+	rankGap    = 120.0
+	margin     = 40.0
+)
+
+// Box ตำแหน่งการ์ดของ node หนึ่งตัว (X, Y = มุมซ้ายบน)
+type Box struct {
+	Node *node.Node
+	X, Y float64
+}
+
+// Link เส้น parent → child
+type Link struct {
+	From, To *Box
+}
+
+// Layout ผลการจัดวาง tree พร้อมวาด (พิกัดเริ่มที่ 0,0 มีขอบ margin แล้ว)
+type Layout struct {
+	Width, Height float64
+	Boxes         []*Box
+	Links         []Link
+}
+
+// Compute จัดวาง node ตามรุ่น (Generation) — รุ่นละหนึ่งแถว
+// node ที่มีตำแหน่งเก็บไว้แล้วใช้ตำแหน่งเดิม ที่เหลือวางใต้ค่าเฉลี่ยของ parent
+func Compute(s *tree.TreeStructure, nodes []*node.Node) *Layout {
+	l := &Layout{}
+	if len(nodes) == 0 {
+		l.Width, l.Height = 2*margin+NodeWidth, 2*margin+NodeHeight
+		return l
+	}
+
+	byID := make(map[string]*Box, len(nodes))
+	for _, n := range nodes {
+		byID[n.ID] = &Box{Node: n}
+	}
+
+	// ลำดับตาม structure (BFS จาก root) ใช้ตัดสินลำดับซ้าย → ขวา
+	rank := make(map[string]int, len(nodes))
+	queue := append([]string(nil), s.RootIDs...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if _, ok := byID[id]; !ok {
+			continue
+		}
+		if _, seen := rank[id]; seen {
+			continue
+		}
+		rank[id] = len(rank)
+		queue = append(queue, s.Edges[id].Children...)
+	}
+
+	parents := make(map[string][]*Box, len(nodes))
+	for parentID, e := range s.Edges {
+		from, ok := byID[parentID]
+		if !ok {
+			continue
+		}
+		for _, childID := range e.Children {
+			if _, ok := byID[childID]; ok {
+				parents[childID] = append(parents[childID], from)
+			}
+		}
+	}
+
+	// แยก node ที่มีตำแหน่งแล้ว / ต้องจัดเอง ตามรุ่น
+	placed := make(map[*Box]bool, len(nodes))
+	auto := make(map[int32][]*Box)
+	var generations []int32
+	for _, n := range nodes {
+		b := byID[n.ID]
+		l.Boxes = append(l.Boxes, b)
+		if hasPosition(n) {
+			b.X, b.Y = n.PositionX, n.PositionY
+			placed[b] = true
+			continue
+		}
+		if _, ok := auto[n.Generation]; !ok {
+			generations = append(generations, n.Generation)
+		}
+		auto[n.Generation] = append(auto[n.Generation], b)
+	}
+	sort.Slice(generations, func(a, b int) bool { return generations[a] < generations[b] })
+
+	for _, gen := range generations {
+		row := auto[gen]
+		want := make(map[*Box]float64, len(row))
+		for _, b := range row {
+			want[b] = math.NaN()
+			var sum float64
+			var count int
+			for _, p := range parents[b.Node.ID] {
+				if placed[p] {
+					sum += p.X
+					count++
+				}
+			}
+			if count > 0 {
+				want[b] = sum / float64(count)
+			}
+		}
+		sort.SliceStable(row, func(i, j int) bool {
+			wi, wj := want[row[i]], want[row[j]]
+			switch {
+			case !math.IsNaN(wi) && !math.IsNaN(wj) && wi != wj:
+				return wi < wj
+			case math.IsNaN(wi) != math.IsNaN(wj):
+				return !math.IsNaN(wi)
+			}
+			return rankOf(rank, row[i]) < rankOf(rank, row[j])
+		})
+
+		cursor := math.Inf(-1)
+		for _, b := range row {
+			x := cursor
+			if w := want[b]; !math.IsNaN(w) && w > x {
+				x = w
+			}
+			if math.IsInf(x, -1) {
+				x = 0
+			}
+			b.X = x
+			b.Y = float64(gen) * (NodeHeight + rankGap)
+			placed[b] = true
+			cursor = x + NodeWidth + nodeGap
+		}
+	}
+
+	// เลื่อนทั้งภาพให้เริ่มที่ margin
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, b := range l.Boxes {
+		minX, minY = math.Min(minX, b.X), math.Min(minY, b.Y)
+		maxX, maxY = math.Max(maxX, b.X+NodeWidth), math.Max(maxY, b.Y+NodeHeight)
+	}
+	for _, b := range l.Boxes {
+		b.X += margin - minX
+		b.Y += margin - minY
+	}
+	l.Width = maxX - minX + 2*margin
+	l.Height = maxY - minY + 2*margin
+
+	for _, b := range l.Boxes {
+		for _, p := range parents[b.Node.ID] {
+			l.Links = append(l.Links, Link{From: p, To: b})
+		}
+	}
+	sort.SliceStable(l.Links, func(i, j int) bool {
+		return rankOf(rank, l.Links[i].From) < rankOf(rank, l.Links[j].From)
+	})
+	return l
+}
+
+// Top จัดวางเฉพาะ node ใน n รุ่นแรก (นับจากรุ่นต่ำสุดใน tree) ใช้ทำภาพย่อ
+// ตำแหน่งที่เก็บไว้อาจกระจายทั่ว canvas จึงจัดใหม่ตามรุ่นเสมอ
+func Top(s *tree.TreeStructure, nodes []*node.Node, n int) *Layout {
+	if len(nodes) == 0 || n <= 0 {
+		return Compute(s, nil)
+	}
+	lowest := nodes[0].Generation
+	for _, nd := range nodes {
+		lowest = min(lowest, nd.Generation)
+	}
+	var top []*node.Node
+	for _, nd := range nodes {
+		if nd.Generation < lowest+int32(n) {
+			c := *nd
+			c.PositionX, c.PositionY = 0, 0
+			top = append(top, &c)
+		}
+	}
+	return Compute(s, top)
+}
+
+func hasPosition(n *node.Node) bool {
+	return n.PositionX != 0 || n.PositionY != 0
+}
+
+func rankOf(rank map[string]int, b *Box) int {
+	if r, ok := rank[b.Node.ID]; ok {
+		return r
+	}
+	return math.MaxInt
+}
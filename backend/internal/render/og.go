@@ -0,0 +1,64 @@
+package render
+
+import (
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+)
+
+// ขนาดภาพ Open Graph ที่ LINE / Discord / Facebook แนะนำ
+const (
+	OGWidth  = 1200
+	OGHeight = 630
+)
+
+var (
+	colorOGBackground = color.RGBA{0xf8, 0xfa, 0xfc, 0xff} // slate-50
+	colorOGBorder     = color.RGBA{0xe2, 0xe8, 0xf0, 0xff} // slate-200
+)
+
+// Card ข้อมูลบนภาพ preview ของลิงก์แชร์
+type Card struct {
+	Title    string  // ชื่อ tree
+	Subtitle string  // คณะ · สาขา
+	Stats    string  // เช่น "24 คน · 5 รุ่น"
+	Layout   *Layout // ภาพย่อ (ปกติใช้ Top)
+}
+
+// RenderCard วาดภาพ preview ขนาด OGWidth x OGHeight
+func (r *PNGRenderer) RenderCard(w io.Writer, card Card) error {
+	c := r.newCanvas(OGWidth, OGHeight, 1)
+	c.roundRect(0, 0, OGWidth, OGHeight, 0, colorOGBackground)
+
+	c.text(truncate(card.Title, 32), 64, 110, 56, colorText, false)
+	if card.Subtitle != "" {
+		c.text(truncate(card.Subtitle, 60), 64, 162, 28, colorMuted, false)
+	}
+	if card.Stats != "" {
+		c.text(card.Stats, 64, 204, 26, colorMuted, false)
+	}
+
+	// กรอบภาพย่อ
+	const (
+		panelX, panelY = 48.0, 236.0
+		panelW, panelH = OGWidth - 2*panelX, OGHeight - panelY - 48
+		pad            = 16.0
+	)
+	c.roundRect(panelX, panelY, panelW, panelH, 20, colorOGBorder)
+	c.roundRect(panelX+2, panelY+2, panelW-4, panelH-4, 18, colorBackground)
+
+	if l := card.Layout; l != nil && len(l.Boxes) > 0 {
+		areaW, areaH := panelW-2*pad, panelH-2*pad
+		s := math.Min(1, math.Min(areaW/l.Width, areaH/l.Height))
+		x := panelX + pad + (areaW-l.Width*s)/2
+		y := panelY + pad + (areaH-l.Height*s)/2
+
+		// วาดภาพย่อด้วย scale ของตัวเอง แล้วคืนค่าเดิม
+		c.scale = s
+		c.drawLayout(l, x/s, y/s)
+		c.scale = 1
+	}
+
+	return png.Encode(w, c.img)
+}
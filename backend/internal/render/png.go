@@ -0,0 +1,257 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"os"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// maxPixels จำกัดขนาดภาพ PNG (tree ใหญ่มากจะถูกย่อ scale ลงให้พอดี)
+const maxPixels = 40_000_000
+
+// PNGRenderer วาด layout เป็น PNG
+// ภาษาไทยต้องใช้ font ไฟล์ .ttf/.otf ที่มี glyph ไทย (เช่น Noto Sans Thai)
+// ถ้าไม่ได้ตั้งไว้จะใช้ basicfont ซึ่งแสดงได้เฉพาะ ASCII
+type PNGRenderer struct {
+	font *opentype.Font
+}
+
+// NewPNGRenderer โหลด font จาก fontPath ("" = ใช้ basicfont)
+func NewPNGRenderer(fontPath string) (*PNGRenderer, error) {
+	if fontPath == "" {
+		return &PNGRenderer{}, nil
+	}
+	data, err := os.ReadFile(fontPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read font: %w", err)
+	}
+	f, err := opentype.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse font: %w", err)
+	}
+	return &PNGRenderer{font: f}, nil
+}
+
+// HasFont true ถ้าโหลด font จริงไว้ (วาดภาษาไทยได้)
+func (r *PNGRenderer) HasFont() bool {
+	return r.font != nil
+}
+
+// Render วาด layout เป็น PNG
+func (r *PNGRenderer) Render(w io.Writer, l *Layout, opts Options) error {
+	height := l.Height + opts.header()
+	c := r.newCanvas(l.Width, height, opts.Scale)
+
+	if opts.Title != "" {
+		c.text(opts.Title, margin, headerHeight-16, 28, colorText, false)
+	}
+	c.drawLayout(l, 0, opts.header())
+
+	return png.Encode(w, c.img)
+}
+
+// ==================== Canvas ====================
+
+// canvas พื้นที่วาด — พิกัดทุกฟังก์ชันเป็นหน่วยก่อน scale
+type canvas struct {
+	r     *PNGRenderer
+	img   *image.RGBA
+	scale float64
+	z     *vector.Rasterizer
+	clip  image.Rectangle       // พื้นที่ของ shape ที่กำลังวาด (rasterizer มีขนาดเท่านี้)
+	faces map[float64]font.Face // key = ขนาด font หลัง scale (pixel)
+}
+
+func (r *PNGRenderer) newCanvas(width, height, scale float64) *canvas {
+	if scale <= 0 {
+		scale = 1
+	}
+	if px := width * height * scale * scale; px > maxPixels {
+		scale *= math.Sqrt(maxPixels / px)
+	}
+	w, h := int(math.Ceil(width*scale)), int(math.Ceil(height*scale))
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(img, img.Bounds(), image.NewUniform(colorBackground), image.Point{}, draw.Src)
+	return &canvas{
+		r:     r,
+		img:   img,
+		scale: scale,
+		z:     vector.NewRasterizer(0, 0),
+		faces: make(map[float64]font.Face),
+	}
+}
+
+// drawLayout วาดเส้น + การ์ดทั้งหมด เลื่อนไป (dx, dy)
+func (c *canvas) drawLayout(l *Layout, dx, dy float64) {
+	for _, link := range l.Links {
+		x1, y1, x2, y2 := linkEnds(link)
+		x1, y1, x2, y2 = x1+dx, y1+dy, x2+dx, y2+dy
+		my := (y1 + y2) / 2
+		c.strokeCubic([4][2]float64{{x1, y1}, {x1, my}, {x2, my}, {x2, y2}}, 2, colorEdge)
+	}
+
+	for _, b := range l.Boxes {
+		n := b.Node
+		x, y := b.X+dx, b.Y+dy
+		c.roundRect(x, y, NodeWidth, NodeHeight, 12, generationColor(n.Generation))
+		c.roundRect(x+2, y+2, NodeWidth-4, NodeHeight-4, 10, colorCard)
+		c.circle(x+44, y+50, 26, colorPhoto)
+		c.text(initial(n), x+44, y+58, 22, colorMuted, true)
+		c.text(truncate(n.Nickname, 10), x+82, y+46, 18, colorText, false)
+		if name := fullName(n); name != "" {
+			c.text(truncate(name, 16), x+82, y+68, 12, colorMuted, false)
+		}
+		c.circle(x+NodeWidth-16, y+16, 6, statusColor(n.Status))
+	}
+}
+
+// begin เตรียม rasterizer เฉพาะกรอบของ shape (x0,y0)-(x1,y1)
+// ไม่ใช้ rasterizer เต็มภาพ เพราะ Draw / Reset ไล่ทุก pixel ของ rasterizer ทุกครั้ง
+func (c *canvas) begin(x0, y0, x1, y1 float64) {
+	r := image.Rect(
+		int(math.Floor(x0*c.scale))-1, int(math.Floor(y0*c.scale))-1,
+		int(math.Ceil(x1*c.scale))+1, int(math.Ceil(y1*c.scale))+1,
+	)
+	c.clip = r.Intersect(c.img.Bounds())
+	c.z.Reset(c.clip.Dx(), c.clip.Dy())
+}
+
+func (c *canvas) pt(x, y float64) (float32, float32) {
+	return float32(x*c.scale - float64(c.clip.Min.X)), float32(y*c.scale - float64(c.clip.Min.Y))
+}
+
+func (c *canvas) fill(col color.Color) {
+	if c.clip.Empty() {
+		return
+	}
+	c.z.Draw(c.img, c.clip, image.NewUniform(col), image.Point{})
+}
+
+func (c *canvas) roundRect(x, y, w, h, r float64, col color.Color) {
+	c.begin(x, y, x+w, y+h)
+	c.z.MoveTo(c.pt(x+r, y))
+	c.z.LineTo(c.pt(x+w-r, y))
+	c.quadTo(x+w, y, x+w, y+r)
+	c.z.LineTo(c.pt(x+w, y+h-r))
+	c.quadTo(x+w, y+h, x+w-r, y+h)
+	c.z.LineTo(c.pt(x+r, y+h))
+	c.quadTo(x, y+h, x, y+h-r)
+	c.z.LineTo(c.pt(x, y+r))
+	c.quadTo(x, y, x+r, y)
+	c.z.ClosePath()
+	c.fill(col)
+}
+
+func (c *canvas) quadTo(bx, by, x, y float64) {
+	bx32, by32 := c.pt(bx, by)
+	x32, y32 := c.pt(x, y)
+	c.z.QuadTo(bx32, by32, x32, y32)
+}
+
+func (c *canvas) circle(cx, cy, r float64, col color.Color) {
+	// วงกลมจาก cubic bezier 4 ส่วน
+	const k = 0.5522847498
+	c.begin(cx-r, cy-r, cx+r, cy+r)
+	c.z.MoveTo(c.pt(cx+r, cy))
+	c.cubeTo(cx+r, cy+k*r, cx+k*r, cy+r, cx, cy+r)
+	c.cubeTo(cx-k*r, cy+r, cx-r, cy+k*r, cx-r, cy)
+	c.cubeTo(cx-r, cy-k*r, cx-k*r, cy-r, cx, cy-r)
+	c.cubeTo(cx+k*r, cy-r, cx+r, cy-k*r, cx+r, cy)
+	c.z.ClosePath()
+	c.fill(col)
+}
+
+func (c *canvas) cubeTo(bx, by, cx, cy, x, y float64) {
+	bx32, by32 := c.pt(bx, by)
+	cx32, cy32 := c.pt(cx, cy)
+	x32, y32 := c.pt(x, y)
+	c.z.CubeTo(bx32, by32, cx32, cy32, x32, y32)
+}
+
+// strokeCubic วาดเส้นโค้งหนา width — rasterizer เติมได้อย่างเดียว
+// จึงแบ่งโค้งเป็นช่วงสั้น ๆ แล้วสร้าง polygon ขอบซ้าย/ขวาของเส้น
+func (c *canvas) strokeCubic(p [4][2]float64, width float64, col color.Color) {
+	const steps = 24
+	var pts [steps + 1][2]float64
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / steps
+		u := 1 - t
+		for d := 0; d < 2; d++ {
+			pts[i][d] = u*u*u*p[0][d] + 3*u*u*t*p[1][d] + 3*u*t*t*p[2][d] + t*t*t*p[3][d]
+		}
+	}
+
+	half := width / 2
+	x0, y0, x1, y1 := pts[0][0], pts[0][1], pts[0][0], pts[0][1]
+	for _, pt := range pts {
+		x0, y0 = math.Min(x0, pt[0]), math.Min(y0, pt[1])
+		x1, y1 = math.Max(x1, pt[0]), math.Max(y1, pt[1])
+	}
+	c.begin(x0-half, y0-half, x1+half, y1+half)
+
+	normal := func(i int) (float64, float64) {
+		a, b := pts[max(i-1, 0)], pts[min(i+1, steps)]
+		dx, dy := b[0]-a[0], b[1]-a[1]
+		length := math.Hypot(dx, dy)
+		if length == 0 {
+			return 0, 0
+		}
+		return -dy / length * half, dx / length * half
+	}
+
+	nx, ny := normal(0)
+	c.z.MoveTo(c.pt(pts[0][0]+nx, pts[0][1]+ny))
+	for i := 1; i <= steps; i++ {
+		nx, ny := normal(i)
+		c.z.LineTo(c.pt(pts[i][0]+nx, pts[i][1]+ny))
+	}
+	for i := steps; i >= 0; i-- {
+		nx, ny := normal(i)
+		c.z.LineTo(c.pt(pts[i][0]-nx, pts[i][1]-ny))
+	}
+	c.z.ClosePath()
+	c.fill(col)
+}
+
+// text วาดข้อความ baseline ที่ (x, y) — center = จัดกึ่งกลางที่ x
+func (c *canvas) text(s string, x, y, size float64, col color.Color, center bool) {
+	face := c.face(size)
+	d := &font.Drawer{Dst: c.img, Src: image.NewUniform(col), Face: face}
+	px, py := x*c.scale, y*c.scale
+	if center {
+		px -= float64(d.MeasureString(s)) / 64 / 2
+	}
+	d.Dot = fixed.Point26_6{X: fixed.Int26_6(px * 64), Y: fixed.Int26_6(py * 64)}
+	d.DrawString(s)
+}
+
+func (c *canvas) face(size float64) font.Face {
+	if c.r.font == nil {
+		return basicfont.Face7x13
+	}
+	px := size * c.scale
+	if f, ok := c.faces[px]; ok {
+		return f
+	}
+	f, err := opentype.NewFace(c.r.font, &opentype.FaceOptions{
+		Size:    px,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		return basicfont.Face7x13
+	}
+	c.faces[px] = f
+	return f
+}
@@ -0,0 +1,89 @@
+package render
+
+import (
+	"image/color"
+	"math"
+	"unicode/utf8"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+)
+
+// สีเดียวกับการ์ดบน canvas ฝั่ง frontend (tailwind)
+var (
+	colorBackground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	colorCard       = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	colorEdge       = color.RGBA{0x94, 0xa3, 0xb8, 0xff} // slate-400
+	colorText       = color.RGBA{0x11, 0x18, 0x27, 0xff} // gray-900
+	colorMuted      = color.RGBA{0x6b, 0x72, 0x80, 0xff} // gray-500
+	colorPhoto      = color.RGBA{0xe5, 0xe7, 0xeb, 0xff} // gray-200
+)
+
+// statusColor สีจุดสถานะ (studying = emerald-500, graduated = blue-500, retired = gray-400)
+func statusColor(s node.Status) color.RGBA {
+	switch s {
+	case node.StatusStudying:
+		return color.RGBA{0x10, 0xb9, 0x81, 0xff}
+	case node.StatusGraduated:
+		return color.RGBA{0x3b, 0x82, 0xf6, 0xff}
+	default:
+		return color.RGBA{0x9c, 0xa3, 0xaf, 0xff}
+	}
+}
+
+// generationColor สีขอบการ์ดแต่ละรุ่น — golden angle แบบเดียวกับ getGenerationColor ใน layout-engine.ts
+func generationColor(generation int32) color.RGBA {
+	if generation <= 0 {
+		return color.RGBA{0x6b, 0x72, 0x80, 0xff}
+	}
+	hue := math.Mod(float64(generation)*137.508, 360)
+	return hslToRGBA(hue, 0.65, 0.50)
+}
+
+func hslToRGBA(h, s, l float64) color.RGBA {
+	a := s * math.Min(l, 1-l)
+	f := func(n float64) uint8 {
+		k := math.Mod(n+h/30, 12)
+		c := l - a*math.Max(math.Min(math.Min(k-3, 9-k), 1), -1)
+		return uint8(math.Round(255 * c))
+	}
+	return color.RGBA{f(0), f(8), f(4), 0xff}
+}
+
+func hexColor(c color.RGBA) string {
+	const digits = "0123456789abcdef"
+	b := []byte{'#', 0, 0, 0, 0, 0, 0}
+	for i, v := range []uint8{c.R, c.G, c.B} {
+		b[1+2*i] = digits[v>>4]
+		b[2+2*i] = digits[v&0x0f]
+	}
+	return string(b)
+}
+
+// initial ตัวอักษรแรกของชื่อเล่น ใช้แทนรูปในวงกลม
+func initial(n *node.Node) string {
+	r, _ := utf8.DecodeRuneInString(n.Nickname)
+	if r == utf8.RuneError {
+		return "?"
+	}
+	return string(r)
+}
+
+func fullName(n *node.Node) string {
+	switch {
+	case n.FirstName != "" && n.LastName != "":
+		return n.FirstName + " " + n.LastName
+	case n.FirstName != "":
+		return n.FirstName
+	default:
+		return n.LastName
+	}
+}
+
+// truncate ตัดข้อความยาวเกินการ์ด (นับเป็นตัวอักษร ไม่ใช่ byte)
+func truncate(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	r := []rune(s)
+	return string(r[:max-1]) + "…"
+}
@@ -0,0 +1,84 @@
+package render
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// headerHeight พื้นที่ชื่อ tree ด้านบนภาพ (เมื่อมี Title)
+const headerHeight = 64.0
+
+// Options ตัวเลือกการวาด
+type Options struct {
+	Title string  // ชื่อที่แสดงหัวภาพ ("" = ไม่มีหัว)
+	Scale float64 // ขยายภาพ PNG (0 = 1 เท่า) — SVG ไม่ใช้
+}
+
+func (o Options) header() float64 {
+	if o.Title == "" {
+		return 0
+	}
+	return headerHeight
+}
+
+// WriteSVG วาด layout เป็น SVG (ข้อความใช้ font ของเครื่องที่เปิดดู)
+func WriteSVG(w io.Writer, l *Layout, opts Options) error {
+	bw := bufio.NewWriter(w)
+	width, height := l.Width, l.Height+opts.header()
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="0 0 %g %g" font-family="'Noto Sans Thai', 'Sarabun', sans-serif">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hexColor(colorBackground))
+
+	if opts.Title != "" {
+		fmt.Fprintf(bw, `<text x="%g" y="%g" font-size="28" font-weight="700" fill="%s">%s</text>`+"\n",
+			margin, headerHeight-16, hexColor(colorText), svgText(opts.Title))
+	}
+	fmt.Fprintf(bw, `<g transform="translate(0 %g)">`+"\n", opts.header())
+
+	// เส้นวาดก่อนการ์ด ให้การ์ดทับปลายเส้น
+	fmt.Fprintf(bw, `<g fill="none" stroke="%s" stroke-width="2">`+"\n", hexColor(colorEdge))
+	for _, link := range l.Links {
+		x1, y1, x2, y2 := linkEnds(link)
+		my := (y1 + y2) / 2
+		fmt.Fprintf(bw, `<path d="M%g %g C%g %g %g %g %g %g"/>`+"\n", x1, y1, x1, my, x2, my, x2, y2)
+	}
+	bw.WriteString("</g>\n")
+
+	for _, b := range l.Boxes {
+		n := b.Node
+		fmt.Fprintf(bw, `<g transform="translate(%g %g)">`+"\n", b.X, b.Y)
+		fmt.Fprintf(bw, `<rect width="%g" height="%g" rx="12" fill="%s" stroke="%s" stroke-width="2"/>`+"\n",
+			NodeWidth, NodeHeight, hexColor(colorCard), hexColor(generationColor(n.Generation)))
+		fmt.Fprintf(bw, `<circle cx="44" cy="50" r="26" fill="%s"/>`+"\n", hexColor(colorPhoto))
+		fmt.Fprintf(bw, `<text x="44" y="58" font-size="22" text-anchor="middle" fill="%s">%s</text>`+"\n",
+			hexColor(colorMuted), svgText(initial(n)))
+		fmt.Fprintf(bw, `<text x="82" y="46" font-size="18" font-weight="700" fill="%s">%s</text>`+"\n",
+			hexColor(colorText), svgText(truncate(n.Nickname, 10)))
+		if name := fullName(n); name != "" {
+			fmt.Fprintf(bw, `<text x="82" y="68" font-size="12" fill="%s">%s</text>`+"\n",
+				hexColor(colorMuted), svgText(truncate(name, 16)))
+		}
+		fmt.Fprintf(bw, `<circle cx="%g" cy="16" r="6" fill="%s"/>`+"\n", NodeWidth-16, hexColor(statusColor(n.Status)))
+		bw.WriteString("</g>\n")
+	}
+
+	bw.WriteString("</g>\n</svg>\n")
+	return bw.Flush()
+}
+
+// linkEnds จุดต้น (ขอบล่าง parent) / ปลาย (ขอบบน child) ของเส้น
+func linkEnds(link Link) (x1, y1, x2, y2 float64) {
+	return link.From.X + NodeWidth/2, link.From.Y + NodeHeight,
+		link.To.X + NodeWidth/2, link.To.Y
+}
+
+func svgText(s string) string {
+	var b strings.Builder
+	// เขียนลง strings.Builder ไม่มีทาง error
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/audit"
+)
+
+type AuditRepo struct {
+	db *DB
+}
+
+func NewAuditRepo(db *DB) *AuditRepo {
+	return &AuditRepo{db: db}
+}
+
+var _ audit.Repository = (*AuditRepo)(nil)
+
+// auditRecord รูปแบบ JSON ที่ส่งให้ jsonb_to_recordset (insert หลาย entry ในรอบเดียว)
+type auditRecord struct {
+	TreeID    string          `json:"tree_id"`
+	ActorID   string          `json:"actor_id"`
+	Action    audit.Action    `json:"action"`
+	NodeID    *string         `json:"node_id"`
+	TargetIDs []string        `json:"target_ids"`
+	Before    *audit.Snapshot `json:"before"`
+	After     *audit.Snapshot `json:"after"`
+}
+
+// ==================== Record ====================
+
+func (r *AuditRepo) Record(ctx context.Context, entries ...*audit.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	records := make([]auditRecord, len(entries))
+	for i, e := range entries {
+		targets := e.TargetIDs
+		if targets == nil {
+			targets = []string{}
+		}
+		records[i] = auditRecord{
+			TreeID:    e.TreeID,
+			ActorID:   e.ActorID,
+			Action:    e.Action,
+			NodeID:    e.NodeID,
+			TargetIDs: targets,
+			Before:    e.Before,
+			After:     e.After,
+		}
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit events: %w", err)
+	}
+
+	query := `
+		INSERT INTO audit_events (tree_id, actor_id, action, node_id, target_ids, before, after)
+		SELECT e.tree_id, e.actor_id, e.action, e.node_id,
+		       ARRAY(SELECT jsonb_array_elements_text(e.target_ids)),
+		       e.before, e.after
+		FROM jsonb_to_recordset($1::jsonb) AS e(
+			tree_id uuid, actor_id uuid, action text, node_id uuid,
+			target_ids jsonb, before jsonb, after jsonb
+		)
+	`
+
+	if _, err := r.db.conn(ctx).Exec(ctx, query, data); err != nil {
+		return fmt.Errorf("failed to record audit events: %w", err)
+	}
+	return nil
+}
+
+// ==================== List ====================
+
+func (r *AuditRepo) List(ctx context.Context, f audit.Filter) ([]*audit.Entry, error) {
+	conds := []string{"tree_id = $1"}
+	args := []any{f.TreeID}
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, strings.ReplaceAll(cond, "?", fmt.Sprintf("$%d", len(args))))
+	}
+
+	if f.NodeID != "" {
+		add("(node_id::text = ? OR ? = ANY(target_ids))", f.NodeID)
+	}
+	if f.ActorID != "" {
+		add("actor_id::text = ?", f.ActorID)
+	}
+	if f.Since != nil {
+		add("created_at >= ?", *f.Since)
+	}
+	if f.Until != nil {
+		add("created_at < ?", *f.Until)
+	}
+	if f.BeforeID > 0 {
+		add("id < ?", f.BeforeID)
+	}
+	args = append(args, f.Limit)
+
+	query := fmt.Sprintf(`
+		SELECT id, tree_id, actor_id, action, node_id::text, target_ids, before, after, created_at
+		FROM audit_events
+		WHERE %s
+		ORDER BY id DESC
+		LIMIT $%d
+	`, strings.Join(conds, " AND "), len(args))
+
+	rows, err := r.db.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*audit.Entry
+	for rows.Next() {
+		e, err := scanAuditEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func scanAuditEntry(row pgx.Row) (*audit.Entry, error) {
+	e := &audit.Entry{}
+	var beforeJSON, afterJSON []byte
+	err := row.Scan(
+		&e.ID, &e.TreeID, &e.ActorID, &e.Action, &e.NodeID, &e.TargetIDs,
+		&beforeJSON, &afterJSON, &e.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan audit event: %w", err)
+	}
+
+	if len(beforeJSON) > 0 {
+		if err := json.Unmarshal(beforeJSON, &e.Before); err != nil {
+			return nil, fmt.Errorf("failed to decode audit before: %w", err)
+		}
+	}
+	if len(afterJSON) > 0 {
+		if err := json.Unmarshal(afterJSON, &e.After); err != nil {
+			return nil, fmt.Errorf("failed to decode audit after: %w", err)
+		}
+	}
+	return e, nil
+}
@@ -0,0 +1,218 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/event"
+)
+
+const (
+	eventChannel        = "tree_events"
+	subscriberBuffer    = 64
+	listenRetryInterval = 5 * time.Second
+	catchUpPageSize     = 500
+)
+
+// EventListener ฟัง NOTIFY 'tree_events' จาก Postgres แล้วกระจาย event ให้ subscriber ของแต่ละ tree
+// ใช้ connection แยกจาก pool เพราะ LISTEN ต้องค้าง session ไว้
+// (Supabase transaction pooler ไม่รองรับ LISTEN ต้องใช้ direct / session connection)
+type EventListener struct {
+	listenURL string
+	repo      *EventRepo
+
+	mu     sync.Mutex
+	nextID int
+	subs   map[string]map[int]chan *event.Event
+
+	// lastSeq seq ล่าสุดที่เห็น (ทุก tree — seq มาจาก sequence เดียวกันและเรียงตาม commit) ใช้ตามเก็บ event ที่หลุดตอน connection หลุด
+	// ตั้งครั้งแรกตอน LISTEN สำเร็จ (seen = false = ยังไม่เคยต่อได้)
+	lastSeq int64
+	seen    bool
+}
+
+func NewEventListener(listenURL string, repo *EventRepo) *EventListener {
+	return &EventListener{
+		listenURL: listenURL,
+		repo:      repo,
+		subs:      make(map[string]map[int]chan *event.Event),
+	}
+}
+
+var _ event.Broker = (*EventListener)(nil)
+
+// ==================== Subscribe ====================
+
+func (l *EventListener) Subscribe(treeID string) (<-chan *event.Event, func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	id := l.nextID
+	l.nextID++
+
+	ch := make(chan *event.Event, subscriberBuffer)
+	if l.subs[treeID] == nil {
+		l.subs[treeID] = make(map[int]chan *event.Event)
+	}
+	l.subs[treeID][id] = ch
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			if c, ok := l.subs[treeID][id]; ok {
+				delete(l.subs[treeID], id)
+				close(c)
+			}
+			if len(l.subs[treeID]) == 0 {
+				delete(l.subs, treeID)
+			}
+		})
+	}
+	return ch, cancel
+}
+
+// ==================== Run ====================
+
+// Run ฟัง notification จนกว่า ctx จะถูก cancel (ต่อใหม่อัตโนมัติถ้าหลุด)
+func (l *EventListener) Run(ctx context.Context) {
+	for {
+		if err := l.listen(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("event listener disconnected, retrying", "error", err, "retry_in", listenRetryInterval)
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("event listener stopped")
+			return
+		case <-time.After(listenRetryInterval):
+		}
+	}
+}
+
+func (l *EventListener) listen(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, l.listenURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect for LISTEN: %w", err)
+	}
+	defer conn.Close(context.WithoutCancel(ctx))
+
+	if _, err := conn.Exec(ctx, "LISTEN "+eventChannel); err != nil {
+		return fmt.Errorf("failed to LISTEN %s: %w", eventChannel, err)
+	}
+	slog.Info("listening for tree events", "channel", eventChannel)
+
+	// NOTIFY ที่ส่งมาตอนไม่ได้ LISTEN อยู่หายไปแล้ว → อ่านจาก table แทน (LISTEN ก่อนจะได้ไม่มีช่องว่าง)
+	if err := l.catchUp(ctx, conn); err != nil {
+		return err
+	}
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		l.dispatch(ctx, n.Payload)
+	}
+}
+
+// catchUp ส่ง event ของทุก tree ที่มีคนฟังอยู่ที่ commit หลัง lastSeq (ช่วงที่ connection หลุด)
+// ต่อได้ครั้งแรก = ยังไม่มีอะไรให้ตาม แค่จำ seq ล่าสุดไว้
+// event ที่ซ้ำกับ NOTIFY ที่ตามมาไม่เป็นไร ผู้รับข้าม seq ที่ส่งไปแล้วเอง
+func (l *EventListener) catchUp(ctx context.Context, conn *pgx.Conn) error {
+	l.mu.Lock()
+	since, seen := l.lastSeq, l.seen
+	treeIDs := make([]string, 0, len(l.subs))
+	for treeID := range l.subs {
+		treeIDs = append(treeIDs, treeID)
+	}
+	l.mu.Unlock()
+
+	if !seen {
+		var maxSeq int64
+		if err := conn.QueryRow(ctx, `SELECT COALESCE(MAX(seq), 0) FROM tree_events`).Scan(&maxSeq); err != nil {
+			return fmt.Errorf("failed to read latest tree event: %w", err)
+		}
+		l.mu.Lock()
+		l.lastSeq = max(l.lastSeq, maxSeq)
+		l.seen = true
+		l.mu.Unlock()
+		return nil
+	}
+
+	replayed := 0
+	for _, treeID := range treeIDs {
+		after := since
+		for {
+			page, err := l.repo.ListAfter(ctx, treeID, after, catchUpPageSize)
+			if err != nil {
+				return fmt.Errorf("failed to catch up tree events: %w", err)
+			}
+			for _, e := range page {
+				l.deliver(e)
+				after = e.Seq
+			}
+			replayed += len(page)
+			if len(page) < catchUpPageSize {
+				break
+			}
+		}
+	}
+	if replayed > 0 {
+		slog.Info("caught up missed tree events", "count", replayed, "since", since)
+	}
+	return nil
+}
+
+// dispatch อ่าน event จาก table แล้วส่งต่อให้ subscriber ของ tree นั้น
+func (l *EventListener) dispatch(ctx context.Context, payload string) {
+	var msg struct {
+		ID     int64  `json:"id"`
+		Seq    int64  `json:"seq"`
+		TreeID string `json:"tree_id"`
+	}
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		slog.Warn("invalid tree event notification", "payload", payload, "error", err)
+		return
+	}
+
+	// ไม่มีใครฟัง tree นี้ → ไม่ต้อง query (แต่จำ seq ไว้สำหรับ catchUp)
+	l.mu.Lock()
+	l.lastSeq = max(l.lastSeq, msg.Seq)
+	_, watched := l.subs[msg.TreeID]
+	l.mu.Unlock()
+	if !watched {
+		return
+	}
+
+	e, err := l.repo.FindByID(ctx, msg.ID)
+	if err != nil {
+		slog.Error("failed to load tree event", "id", msg.ID, "error", err)
+		return
+	}
+	l.deliver(e)
+}
+
+// deliver ส่ง e ให้ทุก subscriber ของ tree นั้น
+func (l *EventListener) deliver(e *event.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lastSeq = max(l.lastSeq, e.Seq)
+	for id, ch := range l.subs[e.TreeID] {
+		select {
+		case ch <- e:
+		default:
+			// ผู้รับอ่านไม่ทัน → ตัดทิ้ง ให้ client reconnect แล้ว resume จาก event id ล่าสุด
+			delete(l.subs[e.TreeID], id)
+			close(ch)
+			slog.Warn("dropped slow tree event subscriber", "treeID", e.TreeID)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/event"
+)
+
+type EventRepo struct {
+	db *DB
+}
+
+func NewEventRepo(db *DB) *EventRepo {
+	return &EventRepo{db: db}
+}
+
+var _ event.Repository = (*EventRepo)(nil)
+
+// eventPayload ข้อมูลเพิ่มเติมที่ trigger ใส่ไว้ใน tree_events.payload
+type eventPayload struct {
+	OldParentIDs []string `json:"old_parent_ids"`
+	NewParentIDs []string `json:"new_parent_ids"`
+}
+
+// ==================== FindByID ====================
+
+func (r *EventRepo) FindByID(ctx context.Context, id int64) (*event.Event, error) {
+	query := `
+		SELECT id, seq, tree_id, event_type, COALESCE(node_id::text, ''), payload, created_at
+		FROM tree_events
+		WHERE id = $1
+	`
+
+	e, err := scanEvent(r.db.conn(ctx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, event.ErrEventNotFound
+		}
+		return nil, fmt.Errorf("failed to find event: %w", err)
+	}
+	return e, nil
+}
+
+// ==================== ListAfter ====================
+
+// seq ให้ตอน commit เรียงตาม commit (ดู migration 026) เห็น seq ไหนแล้ว seq ที่น้อยกว่าไม่โผล่มาทีหลัง
+func (r *EventRepo) ListAfter(ctx context.Context, treeID string, afterSeq int64, limit int) ([]*event.Event, error) {
+	query := `
+		SELECT id, seq, tree_id, event_type, COALESCE(node_id::text, ''), payload, created_at
+		FROM tree_events
+		WHERE tree_id = $1 AND seq > $2
+		ORDER BY seq ASC
+		LIMIT $3
+	`
+
+	rows, err := r.db.conn(ctx).Query(ctx, query, treeID, afterSeq, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*event.Event
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+func scanEvent(row pgx.Row) (*event.Event, error) {
+	e := &event.Event{}
+	var payloadJSON []byte
+	if err := row.Scan(&e.ID, &e.Seq, &e.TreeID, &e.Type, &e.NodeID, &payloadJSON, &e.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	var p eventPayload
+	_ = json.Unmarshal(payloadJSON, &p)
+	e.OldParentIDs = p.OldParentIDs
+	e.NewParentIDs = p.NewParentIDs
+
+	return e, nil
+}
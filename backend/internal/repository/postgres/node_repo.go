@@ -6,10 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 
 	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
 )
 
 type NodeRepo struct {
@@ -42,7 +46,7 @@ func (r *NodeRepo) Create(ctx context.Context, n *node.Node) error {
 		RETURNING id, created_at, updated_at
 	`
 
-	err = r.db.Pool.QueryRow(ctx, query,
+	err = r.db.conn(ctx).QueryRow(ctx, query,
 		n.TreeID,
 		n.Nickname,
 		n.FirstName,
@@ -76,12 +80,12 @@ func (r *NodeRepo) FindByID(ctx context.Context, id string) (*node.Node, error)
 		       COALESCE(metadata, '{}'::jsonb),
 		       created_at, updated_at
 		FROM nodes
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	n := &node.Node{}
 	var metaJSON []byte
-	err := r.db.Pool.QueryRow(ctx, query, id).Scan(
+	err := r.db.conn(ctx).QueryRow(ctx, query, id).Scan(
 		&n.ID,
 		&n.TreeID,
 		&n.Nickname,
@@ -129,11 +133,11 @@ func (r *NodeRepo) Update(ctx context.Context, n *node.Node) error {
 			status = $7,
 			generation = $8,
 			metadata = $9
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 		RETURNING updated_at
 	`
 
-	err = r.db.Pool.QueryRow(ctx, query,
+	err = r.db.conn(ctx).QueryRow(ctx, query,
 		n.ID,
 		n.Nickname,
 		n.FirstName,
@@ -159,8 +163,8 @@ func (r *NodeRepo) Update(ctx context.Context, n *node.Node) error {
 // ==================== UpdateGeneration ====================
 
 func (r *NodeRepo) UpdateGeneration(ctx context.Context, id string, generation int32) error {
-	result, err := r.db.Pool.Exec(ctx,
-		`UPDATE nodes SET generation = $2 WHERE id = $1`, id, generation,
+	result, err := r.db.conn(ctx).Exec(ctx,
+		`UPDATE nodes SET generation = $2 WHERE id = $1 AND deleted_at IS NULL`, id, generation,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update generation: %w", err)
@@ -171,19 +175,237 @@ func (r *NodeRepo) UpdateGeneration(ctx context.Context, id string, generation i
 	return nil
 }
 
-// ==================== Delete ====================
+// ==================== UpdatePositions ====================
 
-func (r *NodeRepo) Delete(ctx context.Context, id string) error {
-	result, err := r.db.Pool.Exec(ctx, `DELETE FROM nodes WHERE id = $1`, id)
+func (r *NodeRepo) UpdatePositions(ctx context.Context, treeID string, positions []node.Position) (int, error) {
+	ids := make([]string, len(positions))
+	xs := make([]float64, len(positions))
+	ys := make([]float64, len(positions))
+	for i, p := range positions {
+		ids[i], xs[i], ys[i] = p.NodeID, p.X, p.Y
+	}
+
+	query := `
+		UPDATE nodes AS n SET
+			position_x = p.x,
+			position_y = p.y
+		FROM unnest($2::uuid[], $3::float8[], $4::float8[]) AS p(id, x, y)
+		WHERE n.id = p.id AND n.tree_id = $1 AND n.deleted_at IS NULL
+	`
+
+	result, err := r.db.conn(ctx).Exec(ctx, query, treeID, ids, xs, ys)
 	if err != nil {
-		return fmt.Errorf("failed to delete node: %w", err)
+		// 22P02 = invalid_text_representation: id ใน request ไม่ใช่ uuid
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "22P02" {
+			return 0, node.ErrInvalidNodeID
+		}
+		return 0, fmt.Errorf("failed to update positions: %w", err)
+	}
+
+	slog.Info("node positions updated", "treeID", treeID, "count", result.RowsAffected())
+	return int(result.RowsAffected()), nil
+}
+
+// ==================== Trash ====================
+
+func (r *NodeRepo) MoveToTrash(ctx context.Context, id, deletedBy string, placement tree.Placement) error {
+	placementJSON, err := json.Marshal(placement)
+	if err != nil {
+		return fmt.Errorf("failed to encode trash placement: %w", err)
+	}
+
+	result, err := r.db.conn(ctx).Exec(ctx, `
+		UPDATE nodes SET deleted_at = NOW(), deleted_by = $2, trash_placement = $3
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id, deletedBy, placementJSON)
+	if err != nil {
+		return fmt.Errorf("failed to move node to trash: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return node.ErrNodeNotFound
+	}
+
+	slog.Info("node moved to trash", "id", id)
+	return nil
+}
+
+func (r *NodeRepo) ListTrash(ctx context.Context, treeID string) ([]*node.TrashedNode, error) {
+	query := `
+		SELECT ` + trashedNodeColumns + `
+		FROM nodes
+		WHERE tree_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC
+	`
+
+	rows, err := r.db.conn(ctx).Query(ctx, query, treeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trashed nodes: %w", err)
+	}
+	defer rows.Close()
+
+	var trashed []*node.TrashedNode
+	for rows.Next() {
+		t, err := scanTrashedNode(rows)
+		if err != nil {
+			return nil, err
+		}
+		trashed = append(trashed, t)
+	}
+	return trashed, rows.Err()
+}
+
+func (r *NodeRepo) FindTrashed(ctx context.Context, id string) (*node.TrashedNode, error) {
+	query := `
+		SELECT ` + trashedNodeColumns + `
+		FROM nodes
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+
+	t, err := scanTrashedNode(r.db.conn(ctx).QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, node.ErrNodeNotFound
 	}
+	return t, err
+}
 
+func (r *NodeRepo) RestoreFromTrash(ctx context.Context, id string) error {
+	result, err := r.db.conn(ctx).Exec(ctx, `
+		UPDATE nodes SET deleted_at = NULL, deleted_by = NULL, trash_placement = NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return node.ErrStudentIDTaken
+		}
+		return fmt.Errorf("failed to restore node: %w", err)
+	}
 	if result.RowsAffected() == 0 {
 		return node.ErrNodeNotFound
 	}
 
-	slog.Info("node deleted", "id", id)
+	slog.Info("node restored from trash", "id", id)
+	return nil
+}
+
+func (r *NodeRepo) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.db.conn(ctx).Exec(ctx,
+		`DELETE FROM nodes WHERE deleted_at IS NOT NULL AND deleted_at < $1`, before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge trashed nodes: %w", err)
+	}
+	return int(result.RowsAffected()), nil
+}
+
+const trashedNodeColumns = `id, tree_id,
+		       nickname, first_name, last_name, COALESCE(student_id, ''),
+		       photo_url, status, generation,
+		       position_x, position_y,
+		       COALESCE(metadata, '{}'::jsonb),
+		       created_at, updated_at,
+		       deleted_at, deleted_by::text, COALESCE(trash_placement, '{}'::jsonb)`
+
+func scanTrashedNode(row pgx.Row) (*node.TrashedNode, error) {
+	n := &node.Node{}
+	t := &node.TrashedNode{Node: n}
+	var metaJSON, placementJSON []byte
+	err := row.Scan(
+		&n.ID,
+		&n.TreeID,
+		&n.Nickname,
+		&n.FirstName,
+		&n.LastName,
+		&n.StudentID,
+		&n.PhotoURL,
+		&n.Status,
+		&n.Generation,
+		&n.PositionX,
+		&n.PositionY,
+		&metaJSON,
+		&n.CreatedAt,
+		&n.UpdatedAt,
+		&t.DeletedAt,
+		&t.DeletedBy,
+		&placementJSON,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan trashed node: %w", err)
+	}
+
+	n.Metadata = make(map[string]string)
+	_ = json.Unmarshal(metaJSON, &n.Metadata)
+	if err := json.Unmarshal(placementJSON, &t.Placement); err != nil {
+		return nil, fmt.Errorf("failed to parse trash placement: %w", err)
+	}
+	return t, nil
+}
+
+// ==================== Restore ====================
+
+func (r *NodeRepo) Restore(ctx context.Context, n *node.Node) error {
+	metaJSON, err := json.Marshal(n.Metadata)
+	if err != nil {
+		metaJSON = []byte("{}")
+	}
+
+	// WHERE ของ DO UPDATE กัน id ที่บังเอิญเป็นของ tree อื่น
+	query := `
+		INSERT INTO nodes (
+			id, tree_id,
+			nickname, first_name, last_name, student_id,
+			photo_url, status, generation,
+			position_x, position_y,
+			metadata, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (id) DO UPDATE SET
+			nickname = EXCLUDED.nickname,
+			first_name = EXCLUDED.first_name,
+			last_name = EXCLUDED.last_name,
+			student_id = EXCLUDED.student_id,
+			photo_url = EXCLUDED.photo_url,
+			status = EXCLUDED.status,
+			generation = EXCLUDED.generation,
+			position_x = EXCLUDED.position_x,
+			position_y = EXCLUDED.position_y,
+			metadata = EXCLUDED.metadata,
+			deleted_at = NULL,
+			deleted_by = NULL,
+			trash_placement = NULL
+		WHERE nodes.tree_id = EXCLUDED.tree_id
+		RETURNING updated_at
+	`
+
+	err = r.db.conn(ctx).QueryRow(ctx, query,
+		n.ID,
+		n.TreeID,
+		n.Nickname,
+		n.FirstName,
+		n.LastName,
+		n.StudentID,
+		n.PhotoURL,
+		n.Status,
+		n.Generation,
+		n.PositionX,
+		n.PositionY,
+		string(metaJSON),
+		n.CreatedAt,
+	).Scan(&n.UpdatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return node.ErrNodeNotFound
+		}
+		return fmt.Errorf("failed to restore node: %w", err)
+	}
+
+	slog.Info("node restored", "id", n.ID, "nickname", n.Nickname)
 	return nil
 }
 
@@ -198,11 +420,11 @@ func (r *NodeRepo) FindByTreeID(ctx context.Context, treeID string) ([]*node.Nod
 		       COALESCE(metadata, '{}'::jsonb),
 		       created_at, updated_at
 		FROM nodes
-		WHERE tree_id = $1
+		WHERE tree_id = $1 AND deleted_at IS NULL
 		ORDER BY created_at ASC
 	`
 
-	rows, err := r.db.Pool.Query(ctx, query, treeID)
+	rows, err := r.db.conn(ctx).Query(ctx, query, treeID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list nodes: %w", err)
 	}
@@ -243,8 +465,8 @@ func (r *NodeRepo) FindByTreeID(ctx context.Context, treeID string) ([]*node.Nod
 
 func (r *NodeRepo) CountByTreeID(ctx context.Context, treeID string) (int, error) {
 	var count int
-	err := r.db.Pool.QueryRow(ctx,
-		`SELECT COUNT(*) FROM nodes WHERE tree_id = $1`, treeID,
+	err := r.db.conn(ctx).QueryRow(ctx,
+		`SELECT COUNT(*) FROM nodes WHERE tree_id = $1 AND deleted_at IS NULL`, treeID,
 	).Scan(&count)
 
 	if err != nil {
@@ -252,3 +474,117 @@ func (r *NodeRepo) CountByTreeID(ctx context.Context, treeID string) (int, error
 	}
 	return count, nil
 }
+
+// ==================== Search ====================
+
+// Search คะแนน = ความคล้ายแบบ trigram + full-text rank + โบนัสตรงทั้งคำ / ขึ้นต้นด้วยคำค้น
+// node ที่ตรงแค่ช่องทางติดต่อได้คะแนนต่ำสุด (nameMatch = false)
+func (r *NodeRepo) Search(ctx context.Context, f node.SearchFilter) ([]*node.SearchHit, error) {
+	term := strings.ToLower(strings.TrimSpace(f.Query))
+	conds := []string{
+		"n.tree_id = ANY($1::uuid[])",
+		"n.deleted_at IS NULL",
+		"t.deleted_at IS NULL",
+	}
+	args := []any{f.TreeIDs, term, "%" + escapeLike(term) + "%"}
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, strings.ReplaceAll(cond, "?", fmt.Sprintf("$%d", len(args))))
+	}
+
+	if len(f.Generations) > 0 {
+		add("n.generation = ANY(?::int[])", f.Generations)
+	}
+	if len(f.Statuses) > 0 {
+		statuses := make([]string, len(f.Statuses))
+		for i, s := range f.Statuses {
+			statuses[i] = string(s)
+		}
+		add("n.status::text = ANY(?::text[])", statuses)
+	}
+	args = append(args, f.Limit, f.Offset)
+
+	// $2 = คำค้นตัวเล็ก, $3 = pattern ของ LIKE
+	// to_tsvector ต้องเขียนให้ตรงกับ idx_nodes_search ถึงจะใช้ index ได้
+	query := fmt.Sprintf(`
+		SELECT id, tree_id,
+		       nickname, first_name, last_name, student_id,
+		       photo_url, status, generation,
+		       position_x, position_y,
+		       metadata,
+		       created_at, updated_at,
+		       score, name_match
+		FROM (
+			SELECT n.id, n.tree_id,
+			       n.nickname, n.first_name, n.last_name, COALESCE(n.student_id, '') AS student_id,
+			       n.photo_url, n.status, n.generation,
+			       n.position_x, n.position_y,
+			       COALESCE(n.metadata, '{}'::jsonb) AS metadata,
+			       n.created_at, n.updated_at,
+			       n.search_text,
+			       (n.search_text LIKE $3
+			        OR $2 <%% n.search_text
+			        OR to_tsvector('simple', n.nickname || ' ' || n.first_name || ' ' || n.last_name)
+			           @@ plainto_tsquery('simple', $2)) AS name_match,
+			       n.contact_text LIKE $3 AS contact_match,
+			       (word_similarity($2, n.search_text)
+			       + ts_rank(to_tsvector('simple', n.nickname || ' ' || n.first_name || ' ' || n.last_name),
+			                 plainto_tsquery('simple', $2))
+			       + CASE
+			             WHEN lower(n.nickname) = $2 OR lower(COALESCE(n.student_id, '')) = $2 THEN 1.0
+			             WHEN n.search_text LIKE $2 || '%%' THEN 0.5
+			             WHEN n.search_text LIKE $3 THEN 0.25
+			             ELSE 0
+			         END)::float8 AS score
+			FROM nodes n
+			JOIN trees t ON t.id = n.tree_id
+			WHERE %s
+		) AS hits
+		WHERE name_match OR contact_match
+		ORDER BY name_match DESC, score DESC, search_text, id
+		LIMIT $%d OFFSET $%d
+	`, strings.Join(conds, " AND "), len(args)-1, len(args))
+
+	rows, err := r.db.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search nodes: %w", err)
+	}
+	defer rows.Close()
+
+	var hits []*node.SearchHit
+	for rows.Next() {
+		n := &node.Node{}
+		h := &node.SearchHit{Node: n}
+		var metaJSON []byte
+		err := rows.Scan(
+			&n.ID,
+			&n.TreeID,
+			&n.Nickname,
+			&n.FirstName,
+			&n.LastName,
+			&n.StudentID,
+			&n.PhotoURL,
+			&n.Status,
+			&n.Generation,
+			&n.PositionX,
+			&n.PositionY,
+			&metaJSON,
+			&n.CreatedAt,
+			&n.UpdatedAt,
+			&h.Score,
+			&h.NameMatch,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan search hit: %w", err)
+		}
+		n.Metadata = make(map[string]string)
+		_ = json.Unmarshal(metaJSON, &n.Metadata)
+		hits = append(hits, h)
+	}
+	return hits, rows.Err()
+}
+
+// escapeLike กัน % _ \ ในคำค้นไม่ให้กลายเป็น wildcard ของ LIKE
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
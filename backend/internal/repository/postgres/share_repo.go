@@ -2,9 +2,12 @@ package postgres
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -31,7 +34,7 @@ func (r *ShareRepo) Create(ctx context.Context, s *share.TreeShare) error {
 		RETURNING id, created_at, updated_at
 	`
 
-	err := r.db.Pool.QueryRow(ctx, query,
+	err := r.db.conn(ctx).QueryRow(ctx, query,
 		s.TreeID,
 		s.UserID,
 		s.Role,
@@ -67,7 +70,7 @@ func (r *ShareRepo) FindByTreeAndUser(ctx context.Context, treeID, userID string
 	`
 
 	s := &share.TreeShare{}
-	err := r.db.Pool.QueryRow(ctx, query, treeID, userID).Scan(
+	err := r.db.conn(ctx).QueryRow(ctx, query, treeID, userID).Scan(
 		&s.ID, &s.TreeID, &s.UserID, &s.Role, &s.InvitedBy,
 		&s.UserEmail, &s.UserDisplayName, &s.UserAvatarURL,
 		&s.CreatedAt, &s.UpdatedAt,
@@ -94,7 +97,7 @@ func (r *ShareRepo) UpdateRole(ctx context.Context, treeID, userID string, role
 	`
 
 	s := &share.TreeShare{}
-	err := r.db.Pool.QueryRow(ctx, query, treeID, userID, role).Scan(
+	err := r.db.conn(ctx).QueryRow(ctx, query, treeID, userID, role).Scan(
 		&s.ID, &s.TreeID, &s.UserID, &s.Role, &s.InvitedBy,
 		&s.CreatedAt, &s.UpdatedAt,
 	)
@@ -113,7 +116,7 @@ func (r *ShareRepo) UpdateRole(ctx context.Context, treeID, userID string, role
 // ==================== Delete ====================
 
 func (r *ShareRepo) Delete(ctx context.Context, treeID, userID string) error {
-	result, err := r.db.Pool.Exec(ctx,
+	result, err := r.db.conn(ctx).Exec(ctx,
 		`DELETE FROM tree_shares WHERE tree_id = $1 AND user_id = $2`,
 		treeID, userID,
 	)
@@ -145,7 +148,7 @@ func (r *ShareRepo) ListByTree(ctx context.Context, treeID string) ([]*share.Tre
 		ORDER BY ts.created_at ASC
 	`
 
-	rows, err := r.db.Pool.Query(ctx, query, treeID)
+	rows, err := r.db.conn(ctx).Query(ctx, query, treeID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list shares: %w", err)
 	}
@@ -177,7 +180,7 @@ func (r *ShareRepo) ListTreeIDsByUser(ctx context.Context, userID string) ([]str
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.db.Pool.Query(ctx, query, userID)
+	rows, err := r.db.conn(ctx).Query(ctx, query, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list shared tree IDs: %w", err)
 	}
@@ -201,7 +204,7 @@ func (r *ShareRepo) FindUserByEmail(ctx context.Context, email string) (string,
 	query := `SELECT id FROM auth.users WHERE email = $1`
 
 	var userID string
-	err := r.db.Pool.QueryRow(ctx, query, email).Scan(&userID)
+	err := r.db.conn(ctx).QueryRow(ctx, query, email).Scan(&userID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return "", share.ErrUserNotFound
@@ -218,7 +221,7 @@ func (r *ShareRepo) GetUserRole(ctx context.Context, treeID, userID string) (sha
 	query := `SELECT role FROM tree_shares WHERE tree_id = $1 AND user_id = $2`
 
 	var role share.Role
-	err := r.db.Pool.QueryRow(ctx, query, treeID, userID).Scan(&role)
+	err := r.db.conn(ctx).QueryRow(ctx, query, treeID, userID).Scan(&role)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return "", share.ErrShareNotFound
@@ -228,3 +231,377 @@ func (r *ShareRepo) GetUserRole(ctx context.Context, treeID, userID string) (sha
 
 	return role, nil
 }
+
+// ==================== Share links ====================
+
+const linkColumns = `
+	id, tree_id, token, role, expires_at, max_uses, use_count,
+	COALESCE(created_by::text, ''), revoked_at, created_at
+`
+
+func scanLink(row pgx.Row) (*share.Link, error) {
+	l := &share.Link{}
+	err := row.Scan(
+		&l.ID, &l.TreeID, &l.Token, &l.Role, &l.ExpiresAt, &l.MaxUses, &l.UseCount,
+		&l.CreatedBy, &l.RevokedAt, &l.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, share.ErrLinkNotFound
+		}
+		return nil, fmt.Errorf("failed to scan share link: %w", err)
+	}
+	return l, nil
+}
+
+// ==================== CreateLink ====================
+
+func (r *ShareRepo) CreateLink(ctx context.Context, l *share.Link) error {
+	// 16 bytes = 32 hex chars
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return fmt.Errorf("failed to generate random token: %w", err)
+	}
+	l.Token = hex.EncodeToString(bytes)
+
+	query := `
+		INSERT INTO share_links (tree_id, token, role, expires_at, max_uses, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, use_count, created_at
+	`
+	err := r.db.conn(ctx).QueryRow(ctx, query,
+		l.TreeID, l.Token, l.Role, l.ExpiresAt, l.MaxUses, l.CreatedBy,
+	).Scan(&l.ID, &l.UseCount, &l.CreatedAt)
+	if err != nil {
+		slog.Error("failed to create share link", "error", err)
+		return fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	slog.Info("share link created", "id", l.ID, "tree_id", l.TreeID, "role", l.Role)
+	return nil
+}
+
+// ==================== FindLinkByToken ====================
+
+func (r *ShareRepo) FindLinkByToken(ctx context.Context, token string) (*share.Link, error) {
+	return scanLink(r.db.conn(ctx).QueryRow(ctx,
+		`SELECT `+linkColumns+` FROM share_links WHERE token = $1`, token,
+	))
+}
+
+// ==================== FindLinkByID ====================
+
+func (r *ShareRepo) FindLinkByID(ctx context.Context, treeID, linkID string) (*share.Link, error) {
+	return scanLink(r.db.conn(ctx).QueryRow(ctx,
+		`SELECT `+linkColumns+` FROM share_links WHERE tree_id = $1 AND id = $2`, treeID, linkID,
+	))
+}
+
+// ==================== ListLinks ====================
+
+func (r *ShareRepo) ListLinks(ctx context.Context, treeID string) ([]*share.Link, error) {
+	rows, err := r.db.conn(ctx).Query(ctx,
+		`SELECT `+linkColumns+` FROM share_links WHERE tree_id = $1 ORDER BY created_at DESC`, treeID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list share links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []*share.Link
+	for rows.Next() {
+		l, err := scanLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, l)
+	}
+	return links, rows.Err()
+}
+
+// ==================== RevokeLink ====================
+
+func (r *ShareRepo) RevokeLink(ctx context.Context, treeID, linkID string) (*share.Link, error) {
+	l, err := scanLink(r.db.conn(ctx).QueryRow(ctx, `
+		UPDATE share_links SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE tree_id = $1 AND id = $2
+		RETURNING `+linkColumns,
+		treeID, linkID,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("share link revoked", "id", l.ID, "tree_id", l.TreeID)
+	return l, nil
+}
+
+// ==================== UseLink ====================
+
+func (r *ShareRepo) UseLink(ctx context.Context, linkID string) error {
+	result, err := r.db.conn(ctx).Exec(ctx, `
+		UPDATE share_links SET use_count = use_count + 1
+		WHERE id = $1
+		  AND revoked_at IS NULL
+		  AND (expires_at IS NULL OR expires_at > NOW())
+		  AND (max_uses IS NULL OR use_count < max_uses)
+	`, linkID)
+	if err != nil {
+		return fmt.Errorf("failed to use share link: %w", err)
+	}
+	if result.RowsAffected() > 0 {
+		return nil
+	}
+
+	// ไม่ได้นับ → หาเหตุผลให้ผู้เรียก
+	l, err := scanLink(r.db.conn(ctx).QueryRow(ctx,
+		`SELECT `+linkColumns+` FROM share_links WHERE id = $1`, linkID,
+	))
+	if err != nil {
+		return err
+	}
+	if err := l.Check(time.Now()); err != nil {
+		return err
+	}
+	return share.ErrLinkExhausted
+}
+
+// ==================== Invitations ====================
+
+const invitationColumns = `
+	id, tree_id, email, role, invited_by, send_count, last_sent_at, created_at
+`
+
+func scanInvitation(row pgx.Row) (*share.Invitation, error) {
+	inv := &share.Invitation{}
+	err := row.Scan(
+		&inv.ID, &inv.TreeID, &inv.Email, &inv.Role, &inv.InvitedBy,
+		&inv.SendCount, &inv.LastSentAt, &inv.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, share.ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to scan invitation: %w", err)
+	}
+	return inv, nil
+}
+
+// ==================== CreateInvitation ====================
+
+func (r *ShareRepo) CreateInvitation(ctx context.Context, inv *share.Invitation) error {
+	query := `
+		INSERT INTO tree_invitations (tree_id, email, role, invited_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, send_count, last_sent_at, created_at
+	`
+
+	err := r.db.conn(ctx).QueryRow(ctx, query,
+		inv.TreeID, inv.Email, inv.Role, inv.InvitedBy,
+	).Scan(&inv.ID, &inv.SendCount, &inv.LastSentAt, &inv.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return share.ErrAlreadyInvited
+		}
+		slog.Error("failed to create invitation", "error", err)
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	slog.Info("invitation created", "id", inv.ID, "tree_id", inv.TreeID, "role", inv.Role)
+	return nil
+}
+
+// ==================== ListInvitations ====================
+
+func (r *ShareRepo) ListInvitations(ctx context.Context, treeID string) ([]*share.Invitation, error) {
+	rows, err := r.db.conn(ctx).Query(ctx,
+		`SELECT `+invitationColumns+` FROM tree_invitations WHERE tree_id = $1 ORDER BY created_at ASC`, treeID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+	defer rows.Close()
+
+	var invitations []*share.Invitation
+	for rows.Next() {
+		inv, err := scanInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, inv)
+	}
+	return invitations, rows.Err()
+}
+
+// ==================== FindInvitation ====================
+
+func (r *ShareRepo) FindInvitation(ctx context.Context, treeID, invitationID string) (*share.Invitation, error) {
+	return scanInvitation(r.db.conn(ctx).QueryRow(ctx,
+		`SELECT `+invitationColumns+` FROM tree_invitations WHERE tree_id = $1 AND id = $2`,
+		treeID, invitationID,
+	))
+}
+
+// ==================== MarkInvitationSent ====================
+
+func (r *ShareRepo) MarkInvitationSent(ctx context.Context, treeID, invitationID string) (*share.Invitation, error) {
+	return scanInvitation(r.db.conn(ctx).QueryRow(ctx, `
+		UPDATE tree_invitations SET send_count = send_count + 1, last_sent_at = NOW()
+		WHERE tree_id = $1 AND id = $2
+		RETURNING `+invitationColumns,
+		treeID, invitationID,
+	))
+}
+
+// ==================== DeleteInvitation ====================
+
+func (r *ShareRepo) DeleteInvitation(ctx context.Context, treeID, invitationID string) error {
+	result, err := r.db.conn(ctx).Exec(ctx,
+		`DELETE FROM tree_invitations WHERE tree_id = $1 AND id = $2`, treeID, invitationID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete invitation: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return share.ErrInvitationNotFound
+	}
+
+	slog.Info("invitation deleted", "tree_id", treeID, "id", invitationID)
+	return nil
+}
+
+// ==================== AcceptInvitations ====================
+
+func (r *ShareRepo) AcceptInvitations(ctx context.Context, userID string) (int, error) {
+	var count *int
+	err := r.db.conn(ctx).QueryRow(ctx,
+		`SELECT accept_tree_invitations($1)`, userID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to accept invitations: %w", err)
+	}
+	// NULL = email ยังไม่ยืนยัน
+	if count == nil {
+		return 0, share.ErrEmailUnconfirmed
+	}
+
+	if *count > 0 {
+		slog.Info("invitations accepted", "user_id", userID, "count", *count)
+	}
+	return *count, nil
+}
+
+// ==================== Ownership transfers ====================
+
+const transferColumns = `
+	id, tree_id, from_user_id, to_user_id, previous_owner_role, status, created_at, resolved_at
+`
+
+func scanTransfer(row pgx.Row) (*share.OwnershipTransfer, error) {
+	t := &share.OwnershipTransfer{}
+	err := row.Scan(
+		&t.ID, &t.TreeID, &t.FromUserID, &t.ToUserID, &t.PreviousOwnerRole,
+		&t.Status, &t.CreatedAt, &t.ResolvedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, share.ErrTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to scan ownership transfer: %w", err)
+	}
+	return t, nil
+}
+
+func (r *ShareRepo) listTransfers(ctx context.Context, query string, args ...any) ([]*share.OwnershipTransfer, error) {
+	rows, err := r.db.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ownership transfers: %w", err)
+	}
+	defer rows.Close()
+
+	var transfers []*share.OwnershipTransfer
+	for rows.Next() {
+		t, err := scanTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, t)
+	}
+	return transfers, rows.Err()
+}
+
+// ==================== CreateTransfer ====================
+
+func (r *ShareRepo) CreateTransfer(ctx context.Context, t *share.OwnershipTransfer) error {
+	query := `
+		INSERT INTO tree_ownership_transfers (tree_id, from_user_id, to_user_id, previous_owner_role)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, status, created_at
+	`
+
+	err := r.db.conn(ctx).QueryRow(ctx, query,
+		t.TreeID, t.FromUserID, t.ToUserID, t.PreviousOwnerRole,
+	).Scan(&t.ID, &t.Status, &t.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return share.ErrTransferPending
+		}
+		slog.Error("failed to create ownership transfer", "error", err)
+		return fmt.Errorf("failed to create ownership transfer: %w", err)
+	}
+
+	slog.Info("ownership transfer created", "id", t.ID, "tree_id", t.TreeID)
+	return nil
+}
+
+// ==================== FindTransfer ====================
+
+func (r *ShareRepo) FindTransfer(ctx context.Context, transferID string) (*share.OwnershipTransfer, error) {
+	return scanTransfer(r.db.conn(ctx).QueryRow(ctx,
+		`SELECT `+transferColumns+` FROM tree_ownership_transfers WHERE id = $1`, transferID,
+	))
+}
+
+// ==================== ListTransfers ====================
+
+func (r *ShareRepo) ListTransfers(ctx context.Context, treeID string) ([]*share.OwnershipTransfer, error) {
+	return r.listTransfers(ctx,
+		`SELECT `+transferColumns+` FROM tree_ownership_transfers WHERE tree_id = $1 ORDER BY created_at DESC`,
+		treeID,
+	)
+}
+
+// ==================== ListPendingTransfersTo ====================
+
+func (r *ShareRepo) ListPendingTransfersTo(ctx context.Context, userID string) ([]*share.OwnershipTransfer, error) {
+	return r.listTransfers(ctx,
+		`SELECT `+transferColumns+` FROM tree_ownership_transfers
+		 WHERE to_user_id = $1 AND status = 'pending' ORDER BY created_at DESC`,
+		userID,
+	)
+}
+
+// ==================== ResolveTransfer ====================
+
+func (r *ShareRepo) ResolveTransfer(ctx context.Context, transferID string, status share.TransferStatus) (*share.OwnershipTransfer, error) {
+	t, err := scanTransfer(r.db.conn(ctx).QueryRow(ctx, `
+		UPDATE tree_ownership_transfers SET status = $2, resolved_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+		RETURNING `+transferColumns,
+		transferID, status,
+	))
+	if errors.Is(err, share.ErrTransferNotFound) {
+		// แยก "ไม่มี" กับ "ตอบไปแล้ว"
+		if _, findErr := r.FindTransfer(ctx, transferID); findErr == nil {
+			return nil, share.ErrTransferNotPending
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("ownership transfer resolved", "id", transferID, "status", status)
+	return t, nil
+}
@@ -0,0 +1,261 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/snapshot"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+)
+
+type SnapshotRepo struct {
+	db *DB
+}
+
+func NewSnapshotRepo(db *DB) *SnapshotRepo {
+	return &SnapshotRepo{db: db}
+}
+
+var _ snapshot.Repository = (*SnapshotRepo)(nil)
+
+// snapshotNode รูปแบบ node ที่เก็บใน tree_snapshots.nodes
+type snapshotNode struct {
+	ID         string            `json:"id"`
+	Nickname   string            `json:"nickname"`
+	FirstName  string            `json:"first_name"`
+	LastName   string            `json:"last_name"`
+	StudentID  string            `json:"student_id,omitempty"`
+	PhotoURL   string            `json:"photo_url"`
+	Status     node.Status       `json:"status"`
+	Generation int32             `json:"generation"`
+	PositionX  float64           `json:"position_x"`
+	PositionY  float64           `json:"position_y"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+}
+
+// snapshot ที่ไม่ได้สร้างเอง (ถูกลบเมื่อเกิน MaxAutoPerUser)
+const prunableSnapshot = `reason <> 'manual'`
+
+// snapshot ที่ undo ได้ (สร้างก่อนการแก้ / ก่อน restore)
+const undoableSnapshot = `reason NOT IN ('manual', 'undo')`
+
+// ==================== Create ====================
+
+func (r *SnapshotRepo) Create(ctx context.Context, s *snapshot.Snapshot) error {
+	structureJSON, err := s.Structure.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot structure: %w", err)
+	}
+	nodes := make([]snapshotNode, len(s.Nodes))
+	for i, n := range s.Nodes {
+		nodes[i] = snapshotNode{
+			ID:         n.ID,
+			Nickname:   n.Nickname,
+			FirstName:  n.FirstName,
+			LastName:   n.LastName,
+			StudentID:  n.StudentID,
+			PhotoURL:   n.PhotoURL,
+			Status:     n.Status,
+			Generation: n.Generation,
+			PositionX:  n.PositionX,
+			PositionY:  n.PositionY,
+			Metadata:   n.Metadata,
+			CreatedAt:  n.CreatedAt,
+			UpdatedAt:  n.UpdatedAt,
+		}
+	}
+	nodesJSON, err := json.Marshal(nodes)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot nodes: %w", err)
+	}
+	s.NodeCount = int32(len(nodes))
+
+	query := `
+		INSERT INTO tree_snapshots (
+			tree_id, created_by, reason, label, scope, node_id, undo_of,
+			structure_revision, structure, nodes, node_count
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at
+	`
+
+	err = r.db.conn(ctx).QueryRow(ctx, query,
+		s.TreeID,
+		s.CreatedBy,
+		s.Reason,
+		s.Label,
+		s.Scope,
+		s.NodeID,
+		s.UndoOf,
+		s.StructureRevision,
+		structureJSON,
+		nodesJSON,
+		s.NodeCount,
+	).Scan(&s.ID, &s.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot: %w", err)
+	}
+
+	result, err := r.db.conn(ctx).Exec(ctx, `
+		DELETE FROM tree_snapshots
+		WHERE id IN (
+			SELECT id FROM tree_snapshots
+			WHERE tree_id = $1 AND created_by = $2 AND `+prunableSnapshot+`
+			ORDER BY created_at DESC
+			OFFSET $3
+		)
+	`, s.TreeID, s.CreatedBy, snapshot.MaxAutoPerUser)
+	if err != nil {
+		return fmt.Errorf("failed to prune snapshots: %w", err)
+	}
+	if result.RowsAffected() > 0 {
+		slog.Info("old snapshots pruned", "treeID", s.TreeID, "userID", s.CreatedBy, "count", result.RowsAffected())
+	}
+
+	slog.Info("snapshot created", "id", s.ID, "treeID", s.TreeID, "reason", s.Reason)
+	return nil
+}
+
+// ==================== FindByID ====================
+
+func (r *SnapshotRepo) FindByID(ctx context.Context, treeID, id string) (*snapshot.Snapshot, error) {
+	return r.findOne(ctx, `tree_id = $1 AND id::text = $2`, treeID, id)
+}
+
+// ==================== List ====================
+
+func (r *SnapshotRepo) List(ctx context.Context, treeID string, limit int) ([]*snapshot.Snapshot, error) {
+	query := `
+		SELECT id, tree_id, created_by, reason, label, scope, node_id::text, undo_of::text,
+		       undone_at, structure_revision, node_count, created_at
+		FROM tree_snapshots
+		WHERE tree_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.conn(ctx).Query(ctx, query, treeID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []*snapshot.Snapshot
+	for rows.Next() {
+		s := &snapshot.Snapshot{}
+		err := rows.Scan(
+			&s.ID, &s.TreeID, &s.CreatedBy, &s.Reason, &s.Label, &s.Scope, &s.NodeID, &s.UndoOf,
+			&s.UndoneAt, &s.StructureRevision, &s.NodeCount, &s.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan snapshot: %w", err)
+		}
+		snapshots = append(snapshots, s)
+	}
+	return snapshots, rows.Err()
+}
+
+// ==================== Undo / Redo ====================
+
+func (r *SnapshotRepo) LatestUndoable(ctx context.Context, treeID, userID string) (*snapshot.Snapshot, error) {
+	return r.findOne(ctx, `
+		tree_id = $1 AND created_by = $2 AND `+undoableSnapshot+` AND undone_at IS NULL
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, treeID, userID)
+}
+
+func (r *SnapshotRepo) LatestRedoable(ctx context.Context, treeID, userID string) (*snapshot.Snapshot, error) {
+	// แก้อะไรใหม่หลัง undo = มี snapshot อัตโนมัติของ user ที่ใหม่กว่า → redo ไม่ได้แล้ว
+	return r.findOne(ctx, `
+		tree_id = $1 AND created_by = $2 AND reason = 'undo' AND undone_at IS NULL
+		AND NOT EXISTS (
+			SELECT 1 FROM tree_snapshots a
+			WHERE a.tree_id = s.tree_id AND a.created_by = s.created_by
+			  AND a.`+undoableSnapshot+` AND a.created_at > s.created_at
+		)
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, treeID, userID)
+}
+
+func (r *SnapshotRepo) SetUndone(ctx context.Context, id string, undone bool) error {
+	result, err := r.db.conn(ctx).Exec(ctx, `
+		UPDATE tree_snapshots
+		SET undone_at = CASE WHEN $2 THEN clock_timestamp() END
+		WHERE id = $1
+	`, id, undone)
+	if err != nil {
+		return fmt.Errorf("failed to update snapshot: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return snapshot.ErrSnapshotNotFound
+	}
+	return nil
+}
+
+// findOne หา snapshot ตัวเดียวพร้อม structure + nodes (where ใช้ alias s)
+func (r *SnapshotRepo) findOne(ctx context.Context, where string, args ...any) (*snapshot.Snapshot, error) {
+	query := `
+		SELECT s.id, s.tree_id, s.created_by, s.reason, s.label, s.scope, s.node_id::text, s.undo_of::text,
+		       s.undone_at, s.structure_revision, s.structure, s.nodes, s.node_count, s.created_at
+		FROM tree_snapshots s
+		WHERE ` + where
+
+	s := &snapshot.Snapshot{}
+	var structureJSON, nodesJSON []byte
+	err := r.db.conn(ctx).QueryRow(ctx, query, args...).Scan(
+		&s.ID, &s.TreeID, &s.CreatedBy, &s.Reason, &s.Label, &s.Scope, &s.NodeID, &s.UndoOf,
+		&s.UndoneAt, &s.StructureRevision, &structureJSON, &nodesJSON, &s.NodeCount, &s.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, snapshot.ErrSnapshotNotFound
+		}
+		return nil, fmt.Errorf("failed to find snapshot: %w", err)
+	}
+
+	structure, err := tree.ParseStructure(structureJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot structure: %w", err)
+	}
+	s.Structure = *structure
+
+	var nodes []snapshotNode
+	if err := json.Unmarshal(nodesJSON, &nodes); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot nodes: %w", err)
+	}
+	s.Nodes = make([]*node.Node, len(nodes))
+	for i, n := range nodes {
+		metadata := n.Metadata
+		if metadata == nil {
+			metadata = make(map[string]string)
+		}
+		s.Nodes[i] = &node.Node{
+			ID:         n.ID,
+			TreeID:     s.TreeID,
+			Nickname:   n.Nickname,
+			FirstName:  n.FirstName,
+			LastName:   n.LastName,
+			StudentID:  n.StudentID,
+			PhotoURL:   n.PhotoURL,
+			Status:     n.Status,
+			Generation: n.Generation,
+			PositionX:  n.PositionX,
+			PositionY:  n.PositionY,
+			Metadata:   metadata,
+			CreatedAt:  n.CreatedAt,
+			UpdatedAt:  n.UpdatedAt,
+		}
+	}
+	return s, nil
+}
@@ -2,14 +2,16 @@ package postgres
 
 import (
 	"context"
-	"crypto/rand"
-	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/privacy"
 	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
 )
 
@@ -27,17 +29,19 @@ var _ tree.Repository = (*TreeRepo)(nil)
 
 func (r *TreeRepo) Create(ctx context.Context, t *tree.Tree) error {
 	query := `
-		INSERT INTO trees (name, description, faculty, department, created_by)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO trees (name, description, faculty, department, created_by, cloned_from, template_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		RETURNING id, created_at, updated_at
 	`
 
-	err := r.db.Pool.QueryRow(ctx, query,
+	err := r.db.conn(ctx).QueryRow(ctx, query,
 		t.Name,
 		t.Description,
 		t.Faculty,
 		t.Department,
 		t.CreatedBy,
+		t.ClonedFrom,
+		t.TemplateID,
 	).Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt)
 
 	if err != nil {
@@ -55,26 +59,30 @@ func (r *TreeRepo) Create(ctx context.Context, t *tree.Tree) error {
 func (r *TreeRepo) FindByID(ctx context.Context, id string) (*tree.Tree, error) {
 	query := `
 		SELECT id, name, description, faculty, department,
-		       created_by, share_token, is_public, structure,
-		       created_at, updated_at
+		       created_by, is_public, structure,
+		       structure_revision, contact_visibility, created_at, updated_at,
+		       cloned_from::text, template_id::text
 		FROM trees
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	t := &tree.Tree{}
-	var structureJSON []byte
-	err := r.db.Pool.QueryRow(ctx, query, id).Scan(
+	var structureJSON, privacyJSON []byte
+	err := r.db.conn(ctx).QueryRow(ctx, query, id).Scan(
 		&t.ID,
 		&t.Name,
 		&t.Description,
 		&t.Faculty,
 		&t.Department,
 		&t.CreatedBy,
-		&t.ShareToken,
 		&t.IsPublic,
 		&structureJSON,
+		&t.StructureRevision,
+		&privacyJSON,
 		&t.CreatedAt,
 		&t.UpdatedAt,
+		&t.ClonedFrom,
+		&t.TemplateID,
 	)
 
 	if err != nil {
@@ -90,31 +98,137 @@ func (r *TreeRepo) FindByID(ctx context.Context, id string) (*tree.Tree, error)
 	}
 	t.Structure = *s
 
+	if err := json.Unmarshal(privacyJSON, &t.ContactPrivacy); err != nil {
+		return nil, fmt.Errorf("failed to parse contact visibility: %w", err)
+	}
+
 	return t, nil
 }
 
-// ==================== ListByUser ====================
+// ==================== ListIDs ====================
 
-func (r *TreeRepo) ListByUser(ctx context.Context, userID string) ([]*tree.Tree, error) {
-	query := `
-		SELECT id, name, description, faculty, department,
-		       created_by, share_token, is_public, structure,
-		       created_at, updated_at
-		FROM trees
-		WHERE created_by = $1
-		ORDER BY created_at DESC
-	`
+func (r *TreeRepo) ListIDs(ctx context.Context) ([]string, error) {
+	rows, err := r.db.conn(ctx).Query(ctx,
+		`SELECT id FROM trees WHERE deleted_at IS NULL ORDER BY created_at`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tree IDs: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan tree ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// ==================== ListSummaries ====================
+
+// summarySorts คอลัมน์ที่เรียง + type ของค่าใน cursor
+var summarySorts = map[tree.SortField]struct{ column, cast string }{
+	tree.SortUpdatedAt: {"updated_at", "timestamptz"},
+	tree.SortCreatedAt: {"created_at", "timestamptz"},
+	tree.SortName:      {"name", "text"},
+	tree.SortNodeCount: {"node_count", "bigint"},
+}
+
+func (r *TreeRepo) ListSummaries(ctx context.Context, q tree.ListQuery) ([]*tree.Summary, error) {
+	sort, ok := summarySorts[q.Sort]
+	if !ok {
+		q.Sort = tree.SortUpdatedAt
+		sort = summarySorts[q.Sort]
+	}
 
-	rows, err := r.db.Pool.Query(ctx, query, userID)
+	conds := []string{"TRUE"}
+	args := []any{q.UserID}
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, strings.ReplaceAll(cond, "?", fmt.Sprintf("$%d", len(args))))
+	}
+
+	switch q.Scope {
+	case tree.ScopeOwned:
+		conds = append(conds, "NOT shared")
+	case tree.ScopeShared:
+		conds = append(conds, "shared")
+	}
+	if q.Faculty != "" {
+		add("lower(faculty) = lower(?)", q.Faculty)
+	}
+	if q.Department != "" {
+		add("lower(department) = lower(?)", q.Department)
+	}
+	if len(q.Roles) > 0 {
+		roles := make([]string, len(q.Roles))
+		for i, role := range q.Roles {
+			roles[i] = string(role)
+		}
+		add("role = ANY(?::text[])", roles)
+	}
+
+	dir, op := "ASC", ">"
+	if q.Sort.Descending(q.Reverse) {
+		dir, op = "DESC", "<"
+	}
+	if q.After != nil {
+		args = append(args, q.After.Value, q.After.ID)
+		conds = append(conds, fmt.Sprintf("(%s, id) %s ($%d::%s, $%d::uuid)",
+			sort.column, op, len(args)-1, sort.cast, len(args)))
+	}
+	limit := ""
+	if q.Limit > 0 {
+		args = append(args, q.Limit)
+		limit = fmt.Sprintf("LIMIT $%d", len(args))
+	}
+
+	// creator ได้ role owner เสมอ share ของ tree ตัวเอง (ถ้ามีค้างจากการโอน) ไม่นับซ้ำ
+	query := fmt.Sprintf(`
+		WITH accessible AS (
+			SELECT id AS tree_id, 'owner' AS role, FALSE AS shared
+			FROM trees
+			WHERE created_by = $1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT s.tree_id, s.role::text, TRUE
+			FROM tree_shares s
+			JOIN trees t ON t.id = s.tree_id
+			WHERE s.user_id = $1 AND t.created_by <> $1 AND t.deleted_at IS NULL
+		)
+		SELECT id, name, description, faculty, department,
+		       created_by, is_public,
+		       structure_revision, contact_visibility, created_at, updated_at,
+		       cloned_from, template_id,
+		       role, node_count
+		FROM (
+			SELECT t.id, t.name, t.description, t.faculty, t.department,
+			       t.created_by, t.is_public,
+			       t.structure_revision, t.contact_visibility, t.created_at, t.updated_at,
+			       t.cloned_from::text AS cloned_from, t.template_id::text AS template_id,
+			       a.role, a.shared,
+			       (SELECT COUNT(*) FROM nodes n WHERE n.tree_id = t.id AND n.deleted_at IS NULL) AS node_count
+			FROM accessible a
+			JOIN trees t ON t.id = a.tree_id
+		) AS summaries
+		WHERE %s
+		ORDER BY %s %s, id %s
+		%s
+	`, strings.Join(conds, " AND "), sort.column, dir, dir, limit)
+
+	rows, err := r.db.conn(ctx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list trees: %w", err)
 	}
 	defer rows.Close()
 
-	var trees []*tree.Tree
+	var summaries []*tree.Summary
 	for rows.Next() {
-		t := &tree.Tree{}
-		var structureJSON []byte
+		t := &tree.Tree{Structure: tree.NewEmptyStructure()}
+		sum := &tree.Summary{Tree: t}
+		var privacyJSON []byte
 		err := rows.Scan(
 			&t.ID,
 			&t.Name,
@@ -122,228 +236,461 @@ func (r *TreeRepo) ListByUser(ctx context.Context, userID string) ([]*tree.Tree,
 			&t.Faculty,
 			&t.Department,
 			&t.CreatedBy,
-			&t.ShareToken,
 			&t.IsPublic,
-			&structureJSON,
+			&t.StructureRevision,
+			&privacyJSON,
 			&t.CreatedAt,
 			&t.UpdatedAt,
+			&t.ClonedFrom,
+			&t.TemplateID,
+			&sum.Role,
+			&sum.NodeCount,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan tree: %w", err)
+			return nil, fmt.Errorf("failed to scan tree summary: %w", err)
 		}
 
-		s, err := tree.ParseStructure(structureJSON)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse tree structure: %w", err)
+		if err := json.Unmarshal(privacyJSON, &t.ContactPrivacy); err != nil {
+			return nil, fmt.Errorf("failed to parse contact visibility: %w", err)
 		}
-		t.Structure = *s
 
-		trees = append(trees, t)
+		summaries = append(summaries, sum)
 	}
 
-	return trees, nil
+	return summaries, rows.Err()
 }
 
-// ==================== FindByShareToken ====================
+// ==================== RollupPublic ====================
 
-func (r *TreeRepo) FindByShareToken(ctx context.Context, token string) (*tree.Tree, error) {
+func (r *TreeRepo) RollupPublic(ctx context.Context, faculty string) ([]*tree.Rollup, error) {
 	query := `
-		SELECT id, name, description, faculty, department,
-		       created_by, share_token, is_public, structure,
-		       created_at, updated_at
-		FROM trees
-		WHERE share_token = $1
+		SELECT COALESCE(t.faculty, '') AS faculty, COALESCE(t.department, '') AS department,
+		       COUNT(DISTINCT t.id),
+		       COUNT(n.id),
+		       COUNT(n.id) FILTER (WHERE n.status = 'studying'),
+		       COUNT(n.id) FILTER (WHERE n.status = 'graduated'),
+		       COUNT(n.id) FILTER (WHERE n.status = 'retired')
+		FROM trees t
+		LEFT JOIN nodes n ON n.tree_id = t.id AND n.deleted_at IS NULL
+		WHERE t.is_public AND t.deleted_at IS NULL
+		  AND ($1 = '' OR lower(t.faculty) = lower($1))
+		GROUP BY 1, 2
+		ORDER BY 1, 2
 	`
 
-	t := &tree.Tree{}
-	var structureJSON []byte
-	err := r.db.Pool.QueryRow(ctx, query, token).Scan(
+	rows, err := r.db.conn(ctx).Query(ctx, query, faculty)
+	if err != nil {
+		return nil, fmt.Errorf("failed to roll up public trees: %w", err)
+	}
+	defer rows.Close()
+
+	var rollups []*tree.Rollup
+	for rows.Next() {
+		ru := &tree.Rollup{}
+		var studying, graduated, retired int
+		if err := rows.Scan(
+			&ru.Faculty,
+			&ru.Department,
+			&ru.Trees,
+			&ru.Members,
+			&studying,
+			&graduated,
+			&retired,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan rollup: %w", err)
+		}
+		ru.ByStatus = map[string]int{
+			"studying":  studying,
+			"graduated": graduated,
+			"retired":   retired,
+		}
+		rollups = append(rollups, ru)
+	}
+	return rollups, rows.Err()
+}
+
+// ==================== UpdateContactPrivacy ====================
+
+func (r *TreeRepo) UpdateContactPrivacy(ctx context.Context, treeID string, settings privacy.Settings) error {
+	if settings == nil {
+		settings = privacy.Settings{}
+	}
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to encode contact visibility: %w", err)
+	}
+
+	result, err := r.db.conn(ctx).Exec(ctx,
+		`UPDATE trees SET contact_visibility = $2 WHERE id = $1`,
+		treeID, data,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update contact visibility: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return tree.ErrTreeNotFound
+	}
+	return nil
+}
+
+// ==================== UpdateOwner ====================
+
+func (r *TreeRepo) UpdateOwner(ctx context.Context, treeID, fromUserID, toUserID string) error {
+	result, err := r.db.conn(ctx).Exec(ctx,
+		`UPDATE trees SET created_by = $3 WHERE id = $1 AND created_by = $2`,
+		treeID, fromUserID, toUserID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update tree owner: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		if _, err := r.FindByID(ctx, treeID); err != nil {
+			return err
+		}
+		return tree.ErrOwnerChanged
+	}
+
+	slog.Info("tree owner updated", "tree_id", treeID, "from", fromUserID, "to", toUserID)
+	return nil
+}
+
+// ==================== Templates ====================
+
+func (r *TreeRepo) CreateTemplate(ctx context.Context, t *tree.Template) error {
+	structureJSON, err := t.Structure.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to encode template structure: %w", err)
+	}
+	nodesJSON, err := json.Marshal(t.Nodes)
+	if err != nil {
+		return fmt.Errorf("failed to encode template nodes: %w", err)
+	}
+
+	query := `
+		INSERT INTO tree_templates (owner_id, name, description, faculty, department, structure, nodes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at
+	`
+
+	err = r.db.conn(ctx).QueryRow(ctx, query,
+		t.OwnerID,
+		t.Name,
+		t.Description,
+		t.Faculty,
+		t.Department,
+		structureJSON,
+		nodesJSON,
+	).Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create tree template: %w", err)
+	}
+
+	slog.Info("tree template created", "id", t.ID, "nodes", len(t.Nodes))
+	return nil
+}
+
+func (r *TreeRepo) FindTemplate(ctx context.Context, id string) (*tree.Template, error) {
+	query := `
+		SELECT ` + templateColumns + `
+		FROM tree_templates
+		WHERE id = $1
+	`
+
+	t, err := scanTemplate(r.db.conn(ctx).QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, tree.ErrTemplateNotFound
+	}
+	return t, err
+}
+
+func (r *TreeRepo) ListTemplates(ctx context.Context, ownerID string) ([]*tree.Template, error) {
+	query := `
+		SELECT ` + templateColumns + `
+		FROM tree_templates
+		WHERE owner_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.conn(ctx).Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tree templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*tree.Template
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
+
+func (r *TreeRepo) DeleteTemplate(ctx context.Context, id, ownerID string) error {
+	result, err := r.db.conn(ctx).Exec(ctx,
+		`DELETE FROM tree_templates WHERE id = $1 AND owner_id = $2`, id, ownerID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete tree template: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return tree.ErrTemplateNotFound
+	}
+	return nil
+}
+
+const templateColumns = `id, owner_id, name, description, faculty, department,
+		       structure, nodes, created_at, updated_at`
+
+func scanTemplate(row pgx.Row) (*tree.Template, error) {
+	t := &tree.Template{}
+	var structureJSON, nodesJSON []byte
+	err := row.Scan(
 		&t.ID,
+		&t.OwnerID,
 		&t.Name,
 		&t.Description,
 		&t.Faculty,
 		&t.Department,
-		&t.CreatedBy,
-		&t.ShareToken,
-		&t.IsPublic,
 		&structureJSON,
+		&nodesJSON,
 		&t.CreatedAt,
 		&t.UpdatedAt,
 	)
-
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, tree.ErrTreeNotFound
+			return nil, err
 		}
-		return nil, fmt.Errorf("failed to find tree by share token: %w", err)
+		return nil, fmt.Errorf("failed to scan tree template: %w", err)
 	}
 
 	s, err := tree.ParseStructure(structureJSON)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse tree structure: %w", err)
+		return nil, fmt.Errorf("failed to parse template structure: %w", err)
 	}
 	t.Structure = *s
-
+	if err := json.Unmarshal(nodesJSON, &t.Nodes); err != nil {
+		return nil, fmt.Errorf("failed to parse template nodes: %w", err)
+	}
 	return t, nil
 }
 
-// ==================== GenerateShareToken ====================
+// ==================== Trash ====================
 
-func (r *TreeRepo) GenerateShareToken(ctx context.Context, treeID string) (string, error) {
-	// ตรวจว่ามี token อยู่แล้วไหม
-	var existing *string
-	err := r.db.Pool.QueryRow(ctx,
-		`SELECT share_token FROM trees WHERE id = $1`, treeID,
-	).Scan(&existing)
+func (r *TreeRepo) MoveToTrash(ctx context.Context, id, deletedBy string) error {
+	result, err := r.db.conn(ctx).Exec(ctx, `
+		UPDATE trees SET deleted_at = NOW(), deleted_by = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id, deletedBy)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return "", tree.ErrTreeNotFound
-		}
-		return "", fmt.Errorf("failed to check existing token: %w", err)
+		return fmt.Errorf("failed to move tree to trash: %w", err)
 	}
 
-	// ถ้ามี token อยู่แล้ว ใช้ตัวเดิม
-	if existing != nil && *existing != "" {
-		return *existing, nil
-	}
-
-	// สร้าง token ใหม่ (16 bytes = 32 hex chars)
-	bytes := make([]byte, 16)
-	if _, err := rand.Read(bytes); err != nil {
-		return "", fmt.Errorf("failed to generate random token: %w", err)
-	}
-	token := hex.EncodeToString(bytes)
-
-	// บันทึก token
-	_, err = r.db.Pool.Exec(ctx,
-		`UPDATE trees SET share_token = $1 WHERE id = $2`,
-		token, treeID,
-	)
-	if err != nil {
-		return "", fmt.Errorf("failed to save share token: %w", err)
+	if result.RowsAffected() == 0 {
+		return tree.ErrTreeNotFound
 	}
 
-	slog.Info("share token generated", "treeID", treeID, "token", token)
-	return token, nil
+	slog.Info("tree moved to trash", "id", id)
+	return nil
 }
 
-// ==================== FindByIDs ====================
-
-func (r *TreeRepo) FindByIDs(ctx context.Context, ids []string) ([]*tree.Tree, error) {
-	if len(ids) == 0 {
-		return nil, nil
-	}
-
+func (r *TreeRepo) ListTrash(ctx context.Context, ownerID string) ([]*tree.Tree, error) {
 	query := `
-		SELECT id, name, description, faculty, department,
-		       created_by, share_token, is_public, structure,
-		       created_at, updated_at
+		SELECT ` + trashedTreeColumns + `
 		FROM trees
-		WHERE id = ANY($1)
-		ORDER BY created_at DESC
+		WHERE created_by = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC
 	`
 
-	rows, err := r.db.Pool.Query(ctx, query, ids)
+	rows, err := r.db.conn(ctx).Query(ctx, query, ownerID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find trees by IDs: %w", err)
+		return nil, fmt.Errorf("failed to list trashed trees: %w", err)
 	}
 	defer rows.Close()
 
 	var trees []*tree.Tree
 	for rows.Next() {
-		t := &tree.Tree{}
-		var structureJSON []byte
-		err := rows.Scan(
-			&t.ID,
-			&t.Name,
-			&t.Description,
-			&t.Faculty,
-			&t.Department,
-			&t.CreatedBy,
-			&t.ShareToken,
-			&t.IsPublic,
-			&structureJSON,
-			&t.CreatedAt,
-			&t.UpdatedAt,
-		)
+		t, err := scanTrashedTree(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan tree: %w", err)
+			return nil, err
 		}
-
-		s, err := tree.ParseStructure(structureJSON)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse tree structure: %w", err)
-		}
-		t.Structure = *s
-
 		trees = append(trees, t)
 	}
-
-	return trees, nil
+	return trees, rows.Err()
 }
 
-// ==================== Delete ====================
+func (r *TreeRepo) FindTrashed(ctx context.Context, id string) (*tree.Tree, error) {
+	query := `
+		SELECT ` + trashedTreeColumns + `
+		FROM trees
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
 
-func (r *TreeRepo) Delete(ctx context.Context, id string) error {
-	result, err := r.db.Pool.Exec(ctx, `DELETE FROM trees WHERE id = $1`, id)
-	if err != nil {
-		return fmt.Errorf("failed to delete tree: %w", err)
+	t, err := scanTrashedTree(r.db.conn(ctx).QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, tree.ErrTreeNotFound
 	}
+	return t, err
+}
 
+func (r *TreeRepo) RestoreFromTrash(ctx context.Context, id string) error {
+	result, err := r.db.conn(ctx).Exec(ctx, `
+		UPDATE trees SET deleted_at = NULL, deleted_by = NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore tree: %w", err)
+	}
 	if result.RowsAffected() == 0 {
 		return tree.ErrTreeNotFound
 	}
 
-	slog.Info("tree deleted", "id", id)
+	slog.Info("tree restored from trash", "id", id)
 	return nil
 }
 
-// ==================== Structure Operations ====================
+func (r *TreeRepo) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	// cascade ลบ nodes / shares / snapshots ด้วย — audit ไม่มี FK จึงอยู่ต่อ
+	result, err := r.db.conn(ctx).Exec(ctx,
+		`DELETE FROM trees WHERE deleted_at IS NOT NULL AND deleted_at < $1`, before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge trashed trees: %w", err)
+	}
+	return int(result.RowsAffected()), nil
+}
 
-// AddNodeToStructure เรียก DB function เพิ่ม node เข้า tree structure
-func (r *TreeRepo) AddNodeToStructure(ctx context.Context, treeID, nodeID string, parentID *string) error {
-	query := `SELECT public.add_node_to_structure($1, $2, $3)`
-	var result []byte
-	err := r.db.Pool.QueryRow(ctx, query, treeID, nodeID, parentID).Scan(&result)
+// trashedTreeColumns ไม่โหลด structure (ถังขยะแสดงแค่ชื่อ / เวลา)
+const trashedTreeColumns = `id, name, description, faculty, department, created_by, is_public,
+		       structure_revision, created_at, updated_at, deleted_at, deleted_by::text`
+
+func scanTrashedTree(row pgx.Row) (*tree.Tree, error) {
+	t := &tree.Tree{Structure: tree.NewEmptyStructure()}
+	err := row.Scan(
+		&t.ID,
+		&t.Name,
+		&t.Description,
+		&t.Faculty,
+		&t.Department,
+		&t.CreatedBy,
+		&t.IsPublic,
+		&t.StructureRevision,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+		&t.DeletedAt,
+		&t.DeletedBy,
+	)
 	if err != nil {
-		return fmt.Errorf("failed to add node to structure: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan trashed tree: %w", err)
 	}
-	slog.Info("node added to structure", "treeID", treeID, "nodeID", nodeID, "parentID", parentID)
-	return nil
+	return t, nil
+}
+
+// ==================== BumpStructureRevision ====================
+
+func (r *TreeRepo) BumpStructureRevision(ctx context.Context, treeID string, expected *int64) (int64, error) {
+	// UPDATE ล็อก row ไว้จนจบ transaction → structure operation ที่ตามมาไม่ชนกับคนอื่น
+	query := `
+		UPDATE trees
+		SET structure_revision = structure_revision + 1
+		WHERE id = $1 AND deleted_at IS NULL AND ($2::bigint IS NULL OR structure_revision = $2)
+		RETURNING structure_revision
+	`
+
+	var revision int64
+	err := r.db.conn(ctx).QueryRow(ctx, query, treeID, expected).Scan(&revision)
+	if err == nil {
+		return revision, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("failed to bump structure revision: %w", err)
+	}
+
+	// ไม่มี row ถูกแก้: tree ไม่มีอยู่ หรือ revision ไม่ตรง
+	var exists bool
+	if err := r.db.conn(ctx).QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM trees WHERE id = $1 AND deleted_at IS NULL)`, treeID,
+	).Scan(&exists); err != nil {
+		return 0, fmt.Errorf("failed to check tree: %w", err)
+	}
+	if !exists {
+		return 0, tree.ErrTreeNotFound
+	}
+
+	slog.Warn("structure revision conflict", "treeID", treeID, "expected", *expected)
+	return 0, tree.ErrRevisionConflict
 }
 
-// RemoveNodeFromStructure เรียก DB function ลบ node ออกจาก tree structure
-func (r *TreeRepo) RemoveNodeFromStructure(ctx context.Context, treeID, nodeID string) error {
-	query := `SELECT public.remove_node_from_structure($1, $2)`
-	var result []byte
-	err := r.db.Pool.QueryRow(ctx, query, treeID, nodeID).Scan(&result)
+// ==================== LockStructureShared ====================
+
+func (r *TreeRepo) LockStructureShared(ctx context.Context, treeID string) error {
+	var id string
+	err := r.db.conn(ctx).QueryRow(ctx,
+		`SELECT id FROM trees WHERE id = $1 AND deleted_at IS NULL FOR SHARE`, treeID,
+	).Scan(&id)
 	if err != nil {
-		return fmt.Errorf("failed to remove node from structure: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return tree.ErrTreeNotFound
+		}
+		return fmt.Errorf("failed to lock tree: %w", err)
 	}
-	slog.Info("node removed from structure", "treeID", treeID, "nodeID", nodeID)
 	return nil
 }
 
-// MoveNodeInStructure เรียก DB function ย้าย node ไป parent ใหม่
-func (r *TreeRepo) MoveNodeInStructure(ctx context.Context, treeID, nodeID string, newParentID *string) error {
-	query := `SELECT public.move_node_in_structure($1, $2, $3)`
-	var result []byte
-	err := r.db.Pool.QueryRow(ctx, query, treeID, nodeID, newParentID).Scan(&result)
+// ==================== Structure Operations ====================
+
+// ReplaceStructure เขียนทับ structure ทั้งก้อน
+func (r *TreeRepo) ReplaceStructure(ctx context.Context, treeID string, s tree.TreeStructure) error {
+	structureJSON, err := s.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to encode tree structure: %w", err)
+	}
+	result, err := r.db.conn(ctx).Exec(ctx,
+		`UPDATE trees SET structure = $2 WHERE id = $1`, treeID, structureJSON,
+	)
 	if err != nil {
-		return fmt.Errorf("failed to move node in structure: %w", err)
+		return fmt.Errorf("failed to replace tree structure: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return tree.ErrTreeNotFound
 	}
-	slog.Info("node moved in structure", "treeID", treeID, "nodeID", nodeID, "newParentID", newParentID)
+	slog.Info("tree structure replaced", "treeID", treeID, "nodes", len(s.Edges))
 	return nil
 }
 
-// AddChildToParent เพิ่ม parent ให้ node โดยไม่ลบ parent เดิม (multi-parent / DAG)
-func (r *TreeRepo) AddChildToParent(ctx context.Context, treeID, nodeID, parentID string) error {
-	query := `SELECT public.add_child_to_parent($1::uuid, $2::uuid, $3::uuid)`
-	var result []byte
-	err := r.db.Pool.QueryRow(ctx, query, treeID, nodeID, parentID).Scan(&result)
+// UpdateStructure อ่าน structure แบบ FOR UPDATE ให้ fn แก้แล้วเขียนกลับ
+func (r *TreeRepo) UpdateStructure(ctx context.Context, treeID string, fn func(s *tree.TreeStructure) error) (*tree.TreeStructure, error) {
+	var structureJSON []byte
+	err := r.db.conn(ctx).QueryRow(ctx,
+		`SELECT structure FROM trees WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, treeID,
+	).Scan(&structureJSON)
 	if err != nil {
-		return fmt.Errorf("failed to add child to parent: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, tree.ErrTreeNotFound
+		}
+		return nil, fmt.Errorf("failed to load tree structure: %w", err)
 	}
-	slog.Info("child added to parent", "treeID", treeID, "nodeID", nodeID, "parentID", parentID)
-	return nil
+	s, err := tree.ParseStructure(structureJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tree structure: %w", err)
+	}
+
+	if err := fn(s); err != nil {
+		return nil, err
+	}
+
+	if structureJSON, err = s.ToJSON(); err != nil {
+		return nil, fmt.Errorf("failed to encode tree structure: %w", err)
+	}
+	if _, err := r.db.conn(ctx).Exec(ctx,
+		`UPDATE trees SET structure = $2 WHERE id = $1`, treeID, structureJSON,
+	); err != nil {
+		return nil, fmt.Errorf("failed to update tree structure: %w", err)
+	}
+	return s, nil
 }
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tx"
+)
+
+// querier คือ method ที่ repository ใช้ ซึ่งมีทั้งใน *pgxpool.Pool และ pgx.Tx
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type txKey struct{}
+
+// conn คืน transaction ที่ผูกกับ ctx (ถ้ามี) ไม่งั้นใช้ pool ตามปกติ
+func (db *DB) conn(ctx context.Context) querier {
+	if t, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return t
+	}
+	return db.Pool
+}
+
+// beginner เปิด transaction ได้ (*pgxpool.Pool ในของจริง)
+type beginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+type TxManager struct {
+	pool beginner
+}
+
+func NewTxManager(db *DB) *TxManager {
+	return &TxManager{pool: db.Pool}
+}
+
+var _ tx.Manager = (*TxManager)(nil)
+
+// ==================== WithinTx ====================
+
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	// อยู่ใน transaction แล้ว → ใช้ตัวเดิม ให้ตัวนอกสุดเป็นคน commit/rollback
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	t, err := m.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = t.Rollback(context.WithoutCancel(ctx))
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, t)); err != nil {
+		// ใช้ WithoutCancel เพื่อให้ rollback ได้แม้ request ถูก cancel ไปแล้ว
+		if rbErr := t.Rollback(context.WithoutCancel(ctx)); rbErr != nil {
+			slog.Error("failed to rollback transaction", "error", rbErr)
+		}
+		return err
+	}
+
+	if err := t.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
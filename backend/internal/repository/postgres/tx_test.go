@@ -0,0 +1,170 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var errInjected = errors.New("injected failure")
+
+// fakeTx นับว่าถูก commit / rollback กี่ครั้ง (method อื่นของ pgx.Tx ไม่ถูกเรียกใน test)
+type fakeTx struct {
+	pgx.Tx
+	commits   int
+	rollbacks int
+	commitErr error
+}
+
+func (t *fakeTx) Commit(context.Context) error {
+	t.commits++
+	return t.commitErr
+}
+
+func (t *fakeTx) Rollback(context.Context) error {
+	t.rollbacks++
+	return nil
+}
+
+// fakePool คืน fakeTx ตัวใหม่ทุกครั้งที่ Begin
+type fakePool struct {
+	txs      []*fakeTx
+	beginErr error
+}
+
+func (p *fakePool) Begin(context.Context) (pgx.Tx, error) {
+	if p.beginErr != nil {
+		return nil, p.beginErr
+	}
+	t := &fakeTx{}
+	p.txs = append(p.txs, t)
+	return t, nil
+}
+
+func newTestTxManager() (*TxManager, *fakePool) {
+	pool := &fakePool{}
+	return &TxManager{pool: pool}, pool
+}
+
+func TestWithinTxCommits(t *testing.T) {
+	m, pool := newTestTxManager()
+	db := &DB{}
+
+	err := m.WithinTx(context.Background(), func(ctx context.Context) error {
+		if got := db.conn(ctx); got != pool.txs[0] {
+			t.Errorf("conn = %v, want the transaction", got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pool.txs) != 1 || pool.txs[0].commits != 1 || pool.txs[0].rollbacks != 0 {
+		t.Errorf("txs = %d, commits = %d, rollbacks = %d, want 1 / 1 / 0",
+			len(pool.txs), pool.txs[0].commits, pool.txs[0].rollbacks)
+	}
+}
+
+func TestWithinTxRollsBackOnError(t *testing.T) {
+	m, pool := newTestTxManager()
+
+	err := m.WithinTx(context.Background(), func(context.Context) error {
+		return errInjected
+	})
+	if !errors.Is(err, errInjected) {
+		t.Fatalf("err = %v, want injected failure", err)
+	}
+	if tx := pool.txs[0]; tx.commits != 0 || tx.rollbacks != 1 {
+		t.Errorf("commits = %d, rollbacks = %d, want 0 / 1", tx.commits, tx.rollbacks)
+	}
+}
+
+// WithinTx ซ้อนกันต้องใช้ transaction เดิม และตัวนอกสุดเท่านั้นที่ commit / rollback
+func TestWithinTxNested(t *testing.T) {
+	tests := []struct {
+		name          string
+		innerErr      error
+		outerErr      error
+		wantCommits   int
+		wantRollbacks int
+	}{
+		{"both succeed", nil, nil, 1, 0},
+		{"inner fails", errInjected, nil, 0, 1},
+		{"outer fails after inner", nil, errInjected, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, pool := newTestTxManager()
+			db := &DB{}
+
+			err := m.WithinTx(context.Background(), func(ctx context.Context) error {
+				outer := db.conn(ctx)
+				if err := m.WithinTx(ctx, func(ctx context.Context) error {
+					if inner := db.conn(ctx); inner != outer {
+						t.Errorf("inner conn = %v, want outer transaction %v", inner, outer)
+					}
+					return tt.innerErr
+				}); err != nil {
+					return err
+				}
+				if tx := pool.txs[0]; tx.commits != 0 || tx.rollbacks != 0 {
+					t.Errorf("inner WithinTx ended the transaction (commits %d, rollbacks %d)", tx.commits, tx.rollbacks)
+				}
+				return tt.outerErr
+			})
+			if !errors.Is(err, errInjected) && (tt.innerErr != nil || tt.outerErr != nil) {
+				t.Fatalf("err = %v, want injected failure", err)
+			}
+			if len(pool.txs) != 1 {
+				t.Fatalf("began %d transactions, want 1", len(pool.txs))
+			}
+			if tx := pool.txs[0]; tx.commits != tt.wantCommits || tx.rollbacks != tt.wantRollbacks {
+				t.Errorf("commits = %d, rollbacks = %d, want %d / %d",
+					tx.commits, tx.rollbacks, tt.wantCommits, tt.wantRollbacks)
+			}
+		})
+	}
+}
+
+func TestWithinTxRollsBackOnPanic(t *testing.T) {
+	m, pool := newTestTxManager()
+
+	defer func() {
+		if p := recover(); p != "boom" {
+			t.Errorf("recovered %v, want the original panic", p)
+		}
+		if tx := pool.txs[0]; tx.commits != 0 || tx.rollbacks != 1 {
+			t.Errorf("commits = %d, rollbacks = %d, want 0 / 1", tx.commits, tx.rollbacks)
+		}
+	}()
+	_ = m.WithinTx(context.Background(), func(context.Context) error {
+		panic("boom")
+	})
+}
+
+func TestWithinTxBeginAndCommitErrors(t *testing.T) {
+	m, pool := newTestTxManager()
+	pool.beginErr = errInjected
+	called := false
+	err := m.WithinTx(context.Background(), func(context.Context) error {
+		called = true
+		return nil
+	})
+	if !errors.Is(err, errInjected) || called {
+		t.Errorf("begin failure: err = %v, fn called = %v", err, called)
+	}
+
+	m, pool = newTestTxManager()
+	err = m.WithinTx(context.Background(), func(ctx context.Context) error {
+		ctx.Value(txKey{}).(*fakeTx).commitErr = errInjected
+		return nil
+	})
+	if !errors.Is(err, errInjected) {
+		t.Errorf("commit failure: err = %v, want injected failure", err)
+	}
+	if tx := pool.txs[0]; tx.commits != 1 {
+		t.Errorf("commits = %d, want 1", tx.commits)
+	}
+}
@@ -0,0 +1,115 @@
+package access
+
+import (
+	treev1 "github.com/TitleKung-01/code-tree-backend/gen/tree/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/privacy"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+)
+
+// CanSeeContact ระดับนี้เห็นช่องทางติดต่อที่ตั้ง visibility เป็น v ได้ไหม
+func (l Level) CanSeeContact(v privacy.Visibility) bool {
+	switch v {
+	case privacy.Public:
+		return l.CanView()
+	case privacy.Editors:
+		return l.CanEdit()
+	default:
+		return l.IsMember()
+	}
+}
+
+// VisibleContacts ช่องทางติดต่อของ n ที่ caller ระดับ l เห็นได้ (key = node.MetaKey*)
+// ค่าราย node มีผลก่อนค่าของ tree
+func VisibleContacts(n *node.Node, t *tree.Tree, l Level) map[string]string {
+	own := n.ContactPrivacy()
+	visible := make(map[string]string, len(node.ContactFields))
+	for _, field := range node.ContactFields {
+		value := n.Metadata[field]
+		if value == "" {
+			continue
+		}
+		if l.CanSeeContact(privacy.Resolve(field, own, t.ContactPrivacy)) {
+			visible[field] = value
+		}
+	}
+	return visible
+}
+
+// RedactContacts คืนสำเนา node ที่ลบช่องทางติดต่อซึ่ง caller ไม่มีสิทธิ์เห็น
+// (รวมค่า visibility ราย node ถ้าไม่ใช่ editor) — ใช้ก่อนส่ง node ออกนอก service เช่น export
+func RedactContacts(n *node.Node, t *tree.Tree, l Level) *node.Node {
+	if l.CanEdit() {
+		return n
+	}
+	visible := VisibleContacts(n, t, l)
+	c := *n
+	c.Metadata = make(map[string]string, len(n.Metadata))
+	for k, v := range n.Metadata {
+		c.Metadata[k] = v
+	}
+	for _, field := range node.ContactFields {
+		if v, ok := visible[field]; ok {
+			c.Metadata[field] = v
+		} else {
+			delete(c.Metadata, field)
+		}
+	}
+	c.SetContactPrivacy(nil)
+	return &c
+}
+
+// ==================== Proto conversion ====================
+
+func PrivacyToProto(s privacy.Settings) *treev1.ContactPrivacy {
+	return &treev1.ContactPrivacy{
+		Phone:    visibilityToProto(s[node.MetaKeyPhone]),
+		Email:    visibilityToProto(s[node.MetaKeyEmail]),
+		LineId:   visibilityToProto(s[node.MetaKeyLineID]),
+		Discord:  visibilityToProto(s[node.MetaKeyDiscord]),
+		Facebook: visibilityToProto(s[node.MetaKeyFacebook]),
+	}
+}
+
+// PrivacyFromProto แปลงเป็น Settings (UNSPECIFIED = ไม่ใส่ key → ใช้ค่าชั้นถัดไป)
+func PrivacyFromProto(p *treev1.ContactPrivacy) privacy.Settings {
+	s := privacy.Settings{}
+	for field, v := range map[string]treev1.ContactVisibility{
+		node.MetaKeyPhone:    p.GetPhone(),
+		node.MetaKeyEmail:    p.GetEmail(),
+		node.MetaKeyLineID:   p.GetLineId(),
+		node.MetaKeyDiscord:  p.GetDiscord(),
+		node.MetaKeyFacebook: p.GetFacebook(),
+	} {
+		if vis := visibilityFromProto(v); vis != "" {
+			s[field] = vis
+		}
+	}
+	return s
+}
+
+func visibilityToProto(v privacy.Visibility) treev1.ContactVisibility {
+	switch v {
+	case privacy.Public:
+		return treev1.ContactVisibility_CONTACT_VISIBILITY_PUBLIC
+	case privacy.Members:
+		return treev1.ContactVisibility_CONTACT_VISIBILITY_MEMBERS
+	case privacy.Editors:
+		return treev1.ContactVisibility_CONTACT_VISIBILITY_EDITORS
+	default:
+		return treev1.ContactVisibility_CONTACT_VISIBILITY_UNSPECIFIED
+	}
+}
+
+func visibilityFromProto(v treev1.ContactVisibility) privacy.Visibility {
+	switch v {
+	case treev1.ContactVisibility_CONTACT_VISIBILITY_PUBLIC:
+		return privacy.Public
+	case treev1.ContactVisibility_CONTACT_VISIBILITY_MEMBERS:
+		return privacy.Members
+	case treev1.ContactVisibility_CONTACT_VISIBILITY_EDITORS:
+		return privacy.Editors
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,150 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/share"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+)
+
+// Level ระดับสิทธิ์ของผู้เรียกต่อ tree หนึ่ง (เรียงจากน้อยไปมาก)
+type Level int
+
+const (
+	None   Level = iota // ไม่มีสิทธิ์ (ต้องไม่รู้ด้วยซ้ำว่า tree มีอยู่)
+	Public              // ไม่ใช่สมาชิก แต่ tree เป็น public
+	Viewer              // ถูกแชร์ role viewer
+	Editor              // ถูกแชร์ role editor
+	Owner               // creator หรือถูกแชร์ role owner
+)
+
+func (l Level) CanView() bool  { return l >= Public }
+func (l Level) IsMember() bool { return l >= Viewer }
+func (l Level) CanEdit() bool  { return l >= Editor }
+
+// Evaluate ตัดสินสิทธิ์จากข้อมูลที่มีครบแล้ว (ไม่แตะ DB)
+// role = nil ถ้า user ไม่ได้ถูกแชร์ tree นี้ / userID = "" ถ้าไม่ได้ login
+func Evaluate(t *tree.Tree, userID string, role *share.Role) Level {
+	if userID != "" {
+		if t.CreatedBy == userID {
+			return Owner
+		}
+		if role != nil {
+			switch *role {
+			case share.RoleOwner:
+				return Owner
+			case share.RoleEditor:
+				return Editor
+			case share.RoleViewer:
+				return Viewer
+			}
+		}
+	}
+	if t.IsPublic {
+		return Public
+	}
+	return None
+}
+
+// Policy จุดเดียวที่ใช้ตัดสินสิทธิ์อ่าน / แก้ tree ของทุก service
+type Policy struct {
+	shares share.Repository
+}
+
+func NewPolicy(shares share.Repository) *Policy {
+	return &Policy{shares: shares}
+}
+
+// Resolve หา Level ของ user กับ tree (ดึง share role จาก DB เมื่อจำเป็น)
+func (p *Policy) Resolve(ctx context.Context, t *tree.Tree, userID string) (Level, error) {
+	if userID == "" || t.CreatedBy == userID {
+		return Evaluate(t, userID, nil), nil
+	}
+
+	role, err := p.shares.GetUserRole(ctx, t.ID, userID)
+	if err != nil {
+		if errors.Is(err, share.ErrShareNotFound) {
+			return Evaluate(t, userID, nil), nil
+		}
+		return None, err
+	}
+	return Evaluate(t, userID, &role), nil
+}
+
+// OpenShareLink หาลิงก์แชร์จาก token ที่ยังเปิดดูได้ (ไม่ถูกยกเลิก / ไม่หมดอายุ) — คืน domain error
+func (p *Policy) OpenShareLink(ctx context.Context, token string) (*share.Link, error) {
+	if token == "" {
+		return nil, share.ErrLinkNotFound
+	}
+	l, err := p.shares.FindLinkByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.Check(time.Now()); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// RequireShareLink เหมือน OpenShareLink แต่คืน connect error
+func (p *Policy) RequireShareLink(ctx context.Context, token string) (*share.Link, error) {
+	l, err := p.OpenShareLink(ctx, token)
+	if err != nil {
+		return nil, LinkError(err)
+	}
+	return l, nil
+}
+
+// LinkError แปลง error ของลิงก์แชร์เป็น connect error
+func LinkError(err error) error {
+	switch {
+	case errors.Is(err, share.ErrLinkNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, share.ErrLinkRevoked),
+		errors.Is(err, share.ErrLinkExpired),
+		errors.Is(err, share.ErrLinkExhausted),
+		errors.Is(err, share.ErrLinkNotJoinable):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
+
+// ResolveShareLink หา Level ของคนที่เปิดผ่าน share link — ถือลิงก์ = ดูได้อย่างน้อยแบบ Public
+func (p *Policy) ResolveShareLink(ctx context.Context, t *tree.Tree, userID string) (Level, error) {
+	level, err := p.Resolve(ctx, t, userID)
+	if err != nil {
+		return None, err
+	}
+	return max(level, Public), nil
+}
+
+// RequireView คืน connect error ถ้าดู tree ไม่ได้
+// ใช้ CodeNotFound แทน PermissionDenied เพื่อไม่บอกว่า tree id นี้มีอยู่จริง
+func (p *Policy) RequireView(ctx context.Context, t *tree.Tree, userID string) (Level, error) {
+	level, err := p.Resolve(ctx, t, userID)
+	if err != nil {
+		return None, connect.NewError(connect.CodeInternal, err)
+	}
+	if !level.CanView() {
+		return None, connect.NewError(connect.CodeNotFound, tree.ErrTreeNotFound)
+	}
+	return level, nil
+}
+
+// RequireEdit คืน connect error ถ้าแก้ tree ไม่ได้
+// คนที่ดูไม่ได้เลยได้ CodeNotFound ส่วนคนที่ดูได้แต่แก้ไม่ได้ได้ CodePermissionDenied
+func (p *Policy) RequireEdit(ctx context.Context, t *tree.Tree, userID string) (Level, error) {
+	level, err := p.RequireView(ctx, t, userID)
+	if err != nil {
+		return None, err
+	}
+	if !level.CanEdit() {
+		return level, connect.NewError(connect.CodePermissionDenied, tree.ErrUnauthorized)
+	}
+	return level, nil
+}
@@ -0,0 +1,202 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/share"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+)
+
+const (
+	creatorID = "creator"
+	memberID  = "member"
+)
+
+// fakeShares ใช้เฉพาะ GetUserRole (method อื่น panic เพราะ Policy ไม่ควรเรียก)
+type fakeShares struct {
+	share.Repository
+	roles map[string]share.Role // userID → role ของ tree ที่ทดสอบ
+	err   error
+}
+
+func (f *fakeShares) GetUserRole(_ context.Context, _, userID string) (share.Role, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	role, ok := f.roles[userID]
+	if !ok {
+		return "", share.ErrShareNotFound
+	}
+	return role, nil
+}
+
+func rolePtr(r share.Role) *share.Role { return &r }
+
+// caller ผู้เรียกแต่ละแบบ: userID + role ที่ถูกแชร์ (nil = ไม่ได้ถูกแชร์)
+type caller struct {
+	name   string
+	userID string
+	role   *share.Role
+}
+
+var callers = []caller{
+	{name: "creator", userID: creatorID},
+	{name: "creator also shared as viewer", userID: creatorID, role: rolePtr(share.RoleViewer)},
+	{name: "shared owner", userID: memberID, role: rolePtr(share.RoleOwner)},
+	{name: "editor", userID: memberID, role: rolePtr(share.RoleEditor)},
+	{name: "viewer", userID: memberID, role: rolePtr(share.RoleViewer)},
+	{name: "unknown role", userID: memberID, role: rolePtr(share.Role("admin"))},
+	{name: "logged in, not shared", userID: memberID},
+	{name: "anonymous", userID: ""},
+}
+
+// expected ผลที่ต้องได้ต่อ caller × public/private
+type expected struct {
+	level Level
+	view  connect.Code // 0 = ผ่าน
+	edit  connect.Code
+}
+
+var expectations = map[string]map[bool]expected{
+	"creator": {
+		true:  {level: Owner},
+		false: {level: Owner},
+	},
+	"creator also shared as viewer": {
+		true:  {level: Owner},
+		false: {level: Owner},
+	},
+	"shared owner": {
+		true:  {level: Owner},
+		false: {level: Owner},
+	},
+	"editor": {
+		true:  {level: Editor},
+		false: {level: Editor},
+	},
+	"viewer": {
+		true:  {level: Viewer, edit: connect.CodePermissionDenied},
+		false: {level: Viewer, edit: connect.CodePermissionDenied},
+	},
+	"unknown role": {
+		true:  {level: Public, edit: connect.CodePermissionDenied},
+		false: {level: None, view: connect.CodeNotFound, edit: connect.CodeNotFound},
+	},
+	"logged in, not shared": {
+		true:  {level: Public, edit: connect.CodePermissionDenied},
+		false: {level: None, view: connect.CodeNotFound, edit: connect.CodeNotFound},
+	},
+	"anonymous": {
+		true:  {level: Public, edit: connect.CodePermissionDenied},
+		false: {level: None, view: connect.CodeNotFound, edit: connect.CodeNotFound},
+	},
+}
+
+func testTree(public bool) *tree.Tree {
+	return &tree.Tree{ID: "tree-1", CreatedBy: creatorID, IsPublic: public}
+}
+
+func visibility(public bool) string {
+	if public {
+		return "public"
+	}
+	return "private"
+}
+
+func codeOf(err error) connect.Code {
+	if err == nil {
+		return 0
+	}
+	return connect.CodeOf(err)
+}
+
+func TestEvaluate(t *testing.T) {
+	for _, c := range callers {
+		for _, public := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s/%s", c.name, visibility(public)), func(t *testing.T) {
+				want := expectations[c.name][public]
+				if got := Evaluate(testTree(public), c.userID, c.role); got != want.level {
+					t.Errorf("Evaluate = %v, want %v", got, want.level)
+				}
+			})
+		}
+	}
+}
+
+func TestEvaluateAnonymousIgnoresRole(t *testing.T) {
+	// ไม่ได้ login แต่มี role ติดมา (ไม่ควรเกิด) ต้องไม่ได้สิทธิ์สมาชิก
+	for _, public := range []bool{true, false} {
+		want := None
+		if public {
+			want = Public
+		}
+		if got := Evaluate(testTree(public), "", rolePtr(share.RoleOwner)); got != want {
+			t.Errorf("%s: Evaluate = %v, want %v", visibility(public), got, want)
+		}
+	}
+}
+
+func TestRequireViewAndEdit(t *testing.T) {
+	for _, c := range callers {
+		for _, public := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s/%s", c.name, visibility(public)), func(t *testing.T) {
+				shares := &fakeShares{roles: map[string]share.Role{}}
+				if c.role != nil && c.userID != "" {
+					shares.roles[c.userID] = *c.role
+				}
+				p := NewPolicy(shares)
+				want := expectations[c.name][public]
+
+				level, err := p.RequireView(context.Background(), testTree(public), c.userID)
+				if got := codeOf(err); got != want.view {
+					t.Fatalf("RequireView code = %v, want %v (err %v)", got, want.view, err)
+				}
+				if err == nil && level != want.level {
+					t.Errorf("RequireView level = %v, want %v", level, want.level)
+				}
+				if err != nil && !errors.Is(err, tree.ErrTreeNotFound) {
+					t.Errorf("RequireView err = %v, want ErrTreeNotFound", err)
+				}
+
+				_, err = p.RequireEdit(context.Background(), testTree(public), c.userID)
+				if got := codeOf(err); got != want.edit {
+					t.Errorf("RequireEdit code = %v, want %v (err %v)", got, want.edit, err)
+				}
+			})
+		}
+	}
+}
+
+func TestRequireViewPrivateTreeHidesExistence(t *testing.T) {
+	p := NewPolicy(&fakeShares{})
+	for _, userID := range []string{"", memberID} {
+		_, err := p.RequireView(context.Background(), testTree(false), userID)
+		if code := codeOf(err); code != connect.CodeNotFound {
+			t.Errorf("user %q: code = %v, want NotFound (not PermissionDenied)", userID, code)
+		}
+	}
+}
+
+func TestResolveRepositoryError(t *testing.T) {
+	boom := errors.New("db down")
+	p := NewPolicy(&fakeShares{err: boom})
+
+	if _, err := p.Resolve(context.Background(), testTree(true), memberID); !errors.Is(err, boom) {
+		t.Errorf("Resolve err = %v, want %v", err, boom)
+	}
+	if _, err := p.RequireView(context.Background(), testTree(true), memberID); codeOf(err) != connect.CodeInternal {
+		t.Errorf("RequireView code = %v, want Internal", codeOf(err))
+	}
+
+	// creator / anonymous ไม่ต้องถาม DB
+	for _, userID := range []string{creatorID, ""} {
+		if _, err := p.Resolve(context.Background(), testTree(true), userID); err != nil {
+			t.Errorf("user %q: Resolve err = %v, want nil", userID, err)
+		}
+	}
+}
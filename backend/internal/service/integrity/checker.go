@@ -0,0 +1,163 @@
+package integrity
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strconv"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/audit"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tx"
+)
+
+// errAlreadyConsistent ตรวจซ้ำตอนล็อกแล้วไม่เจอปัญหา → rollback ไม่ให้ revision เพิ่มเปล่าๆ
+var errAlreadyConsistent = errors.New("tree structure is already consistent")
+
+// Result ผลตรวจ structure ของ tree หนึ่ง
+type Result struct {
+	TreeID    string
+	Issues    []tree.Issue
+	Structure tree.TreeStructure // structure หลังแก้ (dry run = ที่จะเขียนถ้าแก้จริง)
+	Revision  int64              // structure_revision หลังแก้ (ไม่ได้แก้ = ค่าปัจจุบัน)
+	Repaired  bool               // เขียน structure ที่แก้แล้วลง DB แล้ว
+}
+
+// Checker ตรวจ / ซ่อม trees.structure ให้ตรงกับตาราง nodes (ใช้ทั้ง RPC และ CLI)
+type Checker struct {
+	treeRepo  tree.Repository
+	nodeRepo  node.Repository
+	auditRepo audit.Repository
+	txm       tx.Manager
+}
+
+func NewChecker(treeRepo tree.Repository, nodeRepo node.Repository, auditRepo audit.Repository, txm tx.Manager) *Checker {
+	return &Checker{
+		treeRepo:  treeRepo,
+		nodeRepo:  nodeRepo,
+		auditRepo: auditRepo,
+		txm:       txm,
+	}
+}
+
+// CheckTree ตรวจ tree เดียว repair = เขียนผลแก้ลง DB ถ้ามีปัญหา
+// actorID ≠ "" = บันทึก audit ในชื่อคนนั้น (CLI ไม่มี actor จึงแค่ log)
+func (c *Checker) CheckTree(ctx context.Context, treeID string, repair bool, actorID string) (*Result, error) {
+	res, err := c.inspect(ctx, treeID, false)
+	if err != nil || !repair || len(res.Issues) == 0 {
+		return res, err
+	}
+
+	// มีปัญหา → ล็อก row แบบ exclusive แล้วตรวจใหม่ (ระหว่างนั้นอาจมีคนแก้ไปแล้ว)
+	err = c.txm.WithinTx(ctx, func(ctx context.Context) error {
+		res, err = c.inspect(ctx, treeID, true)
+		if err != nil {
+			return err
+		}
+		if len(res.Issues) == 0 {
+			return errAlreadyConsistent
+		}
+		if err := c.treeRepo.ReplaceStructure(ctx, treeID, res.Structure); err != nil {
+			return err
+		}
+		res.Repaired = true
+		if actorID == "" {
+			return nil
+		}
+		return c.auditRepo.Record(ctx, &audit.Entry{
+			TreeID:    treeID,
+			ActorID:   actorID,
+			Action:    audit.ActionStructureRepaired,
+			TargetIDs: affectedNodes(res.Issues),
+			After: audit.FieldsSnapshot(map[string]string{
+				"issues": strconv.Itoa(len(res.Issues)),
+			}),
+		})
+	})
+	if errors.Is(err, errAlreadyConsistent) {
+		return c.inspect(ctx, treeID, false)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Repaired {
+		slog.Info("tree structure repaired", "tree_id", treeID, "issues", len(res.Issues), "revision", res.Revision)
+	}
+	return res, nil
+}
+
+// CheckAll ตรวจทุก tree ที่ไม่อยู่ในถังขยะทีละ tree เรียก report ทุก tree (รวมที่ปกติ)
+// tree ที่ตรวจไม่ได้จะ log แล้วข้ามไป คืนจำนวนที่มีปัญหา
+func (c *Checker) CheckAll(ctx context.Context, repair bool, report func(*Result)) (int, error) {
+	ids, err := c.treeRepo.ListIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	broken := 0
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return broken, err
+		}
+		res, err := c.CheckTree(ctx, id, repair, "")
+		if err != nil {
+			slog.Error("failed to check tree structure", "tree_id", id, "error", err)
+			continue
+		}
+		if len(res.Issues) > 0 {
+			broken++
+		}
+		report(res)
+	}
+	return broken, nil
+}
+
+// inspect อ่าน structure + nodes ให้ตรงกันแล้วหาปัญหา
+// exclusive = เพิ่ม revision (ล็อก row จนจบ transaction ของ caller) ไม่งั้นล็อกแบบ share ใน transaction ของตัวเอง
+func (c *Checker) inspect(ctx context.Context, treeID string, exclusive bool) (*Result, error) {
+	res := &Result{TreeID: treeID}
+	read := func(ctx context.Context) error {
+		t, err := c.treeRepo.FindByID(ctx, treeID)
+		if err != nil {
+			return err
+		}
+		nodes, err := c.nodeRepo.FindByTreeID(ctx, treeID)
+		if err != nil {
+			return err
+		}
+		ids := make([]string, len(nodes))
+		for i, n := range nodes {
+			ids[i] = n.ID
+		}
+		res.Structure, res.Issues = t.Structure.Repair(ids)
+		res.Revision = t.StructureRevision
+		return nil
+	}
+
+	if exclusive {
+		if _, err := c.treeRepo.BumpStructureRevision(ctx, treeID, nil); err != nil {
+			return nil, err
+		}
+		return res, read(ctx)
+	}
+	err := c.txm.WithinTx(ctx, func(ctx context.Context) error {
+		if err := c.treeRepo.LockStructureShared(ctx, treeID); err != nil {
+			return err
+		}
+		return read(ctx)
+	})
+	return res, err
+}
+
+// affectedNodes node ที่ถูกแก้ (ไม่ซ้ำ เรียงตาม id)
+func affectedNodes(issues []tree.Issue) []string {
+	var ids []string
+	for _, i := range issues {
+		ids = append(ids, i.NodeID)
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
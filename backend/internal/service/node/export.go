@@ -0,0 +1,156 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"mime"
+	"net/http"
+
+	"connectrpc.com/connect"
+
+	nodev1 "github.com/TitleKung-01/code-tree-backend/gen/node/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/exchange"
+	"github.com/TitleKung-01/code-tree-backend/internal/middleware"
+	"github.com/TitleKung-01/code-tree-backend/internal/service/access"
+)
+
+// exportFile ผลลัพธ์ของการ export หนึ่งครั้ง
+type exportFile struct {
+	filename    string
+	contentType string
+	data        []byte
+}
+
+// ==================== ExportTree ====================
+
+func (s *Service) ExportTree(
+	ctx context.Context,
+	req *connect.Request[nodev1.ExportTreeRequest],
+) (*connect.Response[nodev1.ExportTreeResponse], error) {
+
+	format, ok := protoExportFormatToDomain(req.Msg.Format)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInvalidArgument, exchange.ErrUnsupportedExport)
+	}
+
+	f, err := s.exportTree(ctx, req.Msg.TreeId, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&nodev1.ExportTreeResponse{
+		Filename:    f.filename,
+		ContentType: f.contentType,
+		Data:        f.data,
+	}), nil
+}
+
+// ==================== HTTP download ====================
+
+// HandleExport GET /export/{treeId}?format=json|dot|graphml|gedcom
+// ให้ browser ดาวน์โหลดไฟล์ได้ตรง ๆ (auth ผ่าน Authorization header เหมือน RPC)
+func (s *Service) HandleExport(w http.ResponseWriter, r *http.Request) {
+	format, ok := exchange.ParseFormat(r.URL.Query().Get("format"))
+	if !ok {
+		writeExportError(w, http.StatusBadRequest, "unsupported export format")
+		return
+	}
+
+	f, err := s.exportTree(r.Context(), r.PathValue("treeId"), format)
+	if err != nil {
+		code := connect.CodeOf(err)
+		writeExportError(w, httpStatusFromCode(code), code.String())
+		return
+	}
+
+	w.Header().Set("Content-Type", f.contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": f.filename}))
+	w.Write(f.data)
+}
+
+// writeExportError ตอบ error เป็น JSON {"error": msg}
+func writeExportError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
+
+// exportTree โหลด tree + node ทั้งหมด ตรวจสิทธิ์ดู แล้วเขียนเป็นไฟล์
+func (s *Service) exportTree(ctx context.Context, treeID string, format exchange.Format) (*exportFile, error) {
+	if treeID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, node.ErrTreeIDRequired)
+	}
+
+	t, err := s.treeRepo.FindByID(ctx, treeID)
+	if err != nil {
+		if errors.Is(err, tree.ErrTreeNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	userID, _ := middleware.GetUserID(ctx)
+	level, err := s.access.RequireView(ctx, t, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := s.nodeRepo.FindByTreeID(ctx, t.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	// ไฟล์ export ต้องไม่มีช่องทางติดต่อที่ผู้ export เองยังไม่มีสิทธิ์เห็น
+	for i, n := range nodes {
+		nodes[i] = access.RedactContacts(n, t, level)
+	}
+
+	var buf bytes.Buffer
+	if err := exchange.Export(&buf, format, t, nodes); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	slog.Info("tree exported", "treeID", t.ID, "format", format, "nodes", len(nodes))
+
+	return &exportFile{
+		filename:    format.Filename(t),
+		contentType: format.ContentType(),
+		data:        buf.Bytes(),
+	}, nil
+}
+
+func protoExportFormatToDomain(f nodev1.ExportFormat) (exchange.Format, bool) {
+	switch f {
+	case nodev1.ExportFormat_EXPORT_FORMAT_UNSPECIFIED, nodev1.ExportFormat_EXPORT_FORMAT_JSON_SNAPSHOT:
+		return exchange.FormatSnapshot, true
+	case nodev1.ExportFormat_EXPORT_FORMAT_DOT:
+		return exchange.FormatDOT, true
+	case nodev1.ExportFormat_EXPORT_FORMAT_GRAPHML:
+		return exchange.FormatGraphML, true
+	case nodev1.ExportFormat_EXPORT_FORMAT_GEDCOM:
+		return exchange.FormatGEDCOM, true
+	default:
+		return "", false
+	}
+}
+
+// httpStatusFromCode แปลง connect code เป็น HTTP status สำหรับ route ที่ไม่ใช่ RPC
+func httpStatusFromCode(code connect.Code) int {
+	switch code {
+	case connect.CodeInvalidArgument:
+		return http.StatusBadRequest
+	case connect.CodeUnauthenticated:
+		return http.StatusUnauthorized
+	case connect.CodePermissionDenied:
+		return http.StatusForbidden
+	case connect.CodeNotFound:
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,224 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"connectrpc.com/connect"
+
+	nodev1 "github.com/TitleKung-01/code-tree-backend/gen/node/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/audit"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/snapshot"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/exchange"
+	"github.com/TitleKung-01/code-tree-backend/internal/middleware"
+)
+
+// maxImportSize ขนาดไฟล์ import สูงสุด
+const maxImportSize = 5 << 20
+
+// errImportRejected ใช้ rollback transaction เมื่อตรวจซ้ำใต้ lock แล้วเจอ issue
+var errImportRejected = errors.New("import rejected")
+
+// ==================== ImportNodes ====================
+
+func (s *Service) ImportNodes(
+	ctx context.Context,
+	req *connect.Request[nodev1.ImportNodesRequest],
+) (*connect.Response[nodev1.ImportNodesResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.TreeId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, node.ErrTreeIDRequired)
+	}
+	if len(req.Msg.Data) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, exchange.ErrEmptyFile)
+	}
+	if len(req.Msg.Data) > maxImportSize {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("file is larger than %d bytes", maxImportSize))
+	}
+
+	t, err := s.treeRepo.FindByID(ctx, req.Msg.TreeId)
+	if err != nil {
+		if errors.Is(err, tree.ErrTreeNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	level, err := s.access.RequireEdit(ctx, t, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	total, planner, parseIssues, err := parseImportFile(t.ID, req.Msg.Format, req.Msg.Data)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	resp := &nodev1.ImportNodesResponse{
+		TotalRows:         int32(total),
+		StructureRevision: t.StructureRevision,
+	}
+
+	// dry run / มี issue ตั้งแต่อ่านไฟล์ → รายงานอย่างเดียว
+	if req.Msg.DryRun || len(parseIssues) > 0 {
+		existing, err := s.nodeRepo.FindByTreeID(ctx, t.ID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		_, planIssues := planner(existing)
+		resp.Issues = issuesToProto(append(parseIssues, planIssues...))
+		return connect.NewResponse(resp), nil
+	}
+
+	// apply: ล็อก structure แล้วตรวจซ้ำกับข้อมูลล่าสุด ก่อนสร้างทั้งหมดใน transaction เดียว
+	var created []*node.Node
+	var updatedTree *tree.Tree
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		locked, err := s.lockAndLoadTree(ctx, t.ID, req.Msg.ExpectedRevision)
+		if err != nil {
+			return err
+		}
+
+		// snapshot ก่อน import = สถานะปัจจุบันทั้ง tree (undo ลบทุก node ที่ import เข้ามา)
+		snap, err := s.captureSnapshot(ctx, locked, userID, snapshot.Reason(audit.ActionNodeImported), snapshot.ScopeTree, nil)
+		if err != nil {
+			return err
+		}
+		plan, issues := planner(snap.Nodes)
+		if len(issues) > 0 {
+			resp.Issues = issuesToProto(issues)
+			return errImportRejected
+		}
+		if err := s.saveSnapshot(ctx, snap); err != nil {
+			return err
+		}
+
+		if created, err = s.applyImportPlan(ctx, t.ID, plan); err != nil {
+			return err
+		}
+
+		updatedTree, err = s.treeRepo.FindByID(ctx, t.ID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		entries := make([]*audit.Entry, len(created))
+		for i, n := range created {
+			after := audit.NodeSnapshot(n, &updatedTree.Structure)
+			entries[i] = &audit.Entry{
+				TreeID:    t.ID,
+				ActorID:   userID,
+				Action:    audit.ActionNodeImported,
+				NodeID:    &n.ID,
+				TargetIDs: after.ParentIDs,
+				After:     after,
+			}
+		}
+		return s.record(ctx, entries...)
+	})
+	if errors.Is(err, errImportRejected) {
+		return connect.NewResponse(resp), nil
+	}
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	slog.Info("nodes imported", "treeID", t.ID, "count", len(created))
+
+	resp.Applied = true
+	resp.StructureRevision = updatedTree.StructureRevision
+	resp.Nodes = make([]*nodev1.Node, len(created))
+	for i, n := range created {
+		resp.Nodes[i] = domainToProto(n, updatedTree, level)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// applyImportPlan สร้าง node ตามลำดับใน plan แล้วต่อเข้า structure (ต้องอยู่ใน transaction)
+func (s *Service) applyImportPlan(ctx context.Context, treeID string, plan *exchange.Plan) ([]*node.Node, error) {
+	created := make([]*node.Node, 0, len(plan.Nodes))
+	parents := make([][]string, len(plan.Nodes))
+	for i, pn := range plan.Nodes {
+		if err := s.nodeRepo.Create(ctx, pn.Node); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("line %d: %w", pn.Line, err))
+		}
+
+		parents[i] = make([]string, len(pn.Parents))
+		for j, p := range pn.Parents {
+			if p.ExistingID != "" {
+				parents[i][j] = p.ExistingID
+			} else {
+				parents[i][j] = plan.Nodes[p.PlanIndex].Node.ID
+			}
+		}
+		created = append(created, pn.Node)
+	}
+
+	// ต่อทุก node เข้า structure ในการเขียนครั้งเดียว (plan เรียง parent มาก่อน child แล้ว)
+	_, err := s.treeRepo.UpdateStructure(ctx, treeID, func(st *tree.TreeStructure) error {
+		for i, pn := range plan.Nodes {
+			if _, err := addToStructure(st, pn.Node.ID, parents[i]); err != nil {
+				return fmt.Errorf("line %d: %w", pn.Line, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, structureError(err)
+	}
+	return created, nil
+}
+
+// importPlanner ตรวจข้อมูลจากไฟล์เทียบกับ node ที่มีอยู่ใน tree แล้วคืน plan
+type importPlanner func(existing []*node.Node) (*exchange.Plan, []exchange.Issue)
+
+// parseImportFile อ่านไฟล์ตาม format คืนจำนวนแถว + planner + issue ที่เจอตอนอ่าน
+func parseImportFile(treeID string, format nodev1.ImportFormat, data []byte) (int, importPlanner, []exchange.Issue, error) {
+	var rows []exchange.Row
+	var issues []exchange.Issue
+	var err error
+
+	switch format {
+	case nodev1.ImportFormat_IMPORT_FORMAT_CSV:
+		rows, issues, err = exchange.ParseCSV(data)
+	case nodev1.ImportFormat_IMPORT_FORMAT_XLSX:
+		rows, issues, err = exchange.ParseXLSX(data)
+	case nodev1.ImportFormat_IMPORT_FORMAT_JSON_SNAPSHOT:
+		snap, err := exchange.ParseSnapshot(data)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		return len(snap.Nodes), func(existing []*node.Node) (*exchange.Plan, []exchange.Issue) {
+			return exchange.PlanSnapshot(treeID, snap, existing)
+		}, nil, nil
+	default:
+		return 0, nil, nil, exchange.ErrUnsupportedFile
+	}
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	return len(rows), func(existing []*node.Node) (*exchange.Plan, []exchange.Issue) {
+		return exchange.PlanRows(treeID, rows, existing)
+	}, issues, nil
+}
+
+func issuesToProto(issues []exchange.Issue) []*nodev1.ImportIssue {
+	out := make([]*nodev1.ImportIssue, len(issues))
+	for i, is := range issues {
+		out[i] = &nodev1.ImportIssue{
+			Line:    int32(is.Line),
+			Column:  is.Column,
+			Message: is.Message,
+		}
+	}
+	return out
+}
@@ -0,0 +1,132 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"connectrpc.com/connect"
+
+	nodev1 "github.com/TitleKung-01/code-tree-backend/gen/node/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/audit"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/middleware"
+)
+
+// maxLayoutBatch จำนวนตำแหน่งสูงสุดต่อหนึ่ง request
+const maxLayoutBatch = 5000
+
+// ==================== UpdateLayout ====================
+
+// UpdateLayout บันทึกตำแหน่งการ์ดหลาย node พร้อมกัน (ไม่แตะ structure จึงไม่เพิ่ม revision)
+// node ที่ไม่อยู่ใน tree นี้ทำให้ทั้ง request ถูกยกเลิก
+func (s *Service) UpdateLayout(
+	ctx context.Context,
+	req *connect.Request[nodev1.UpdateLayoutRequest],
+) (*connect.Response[nodev1.UpdateLayoutResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.TreeId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, node.ErrTreeIDRequired)
+	}
+	if len(req.Msg.Positions) > maxLayoutBatch {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("too many positions (max %d)", maxLayoutBatch))
+	}
+
+	// node ซ้ำใน request ใช้ค่าสุดท้าย
+	positions := make([]node.Position, 0, len(req.Msg.Positions))
+	index := make(map[string]int, len(req.Msg.Positions))
+	for _, p := range req.Msg.Positions {
+		if p.NodeId == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("node_id is required"))
+		}
+		if !isFinite(p.PositionX) || !isFinite(p.PositionY) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid position for node %s", p.NodeId))
+		}
+		pos := node.Position{NodeID: p.NodeId, X: p.PositionX, Y: p.PositionY}
+		if i, dup := index[p.NodeId]; dup {
+			positions[i] = pos
+			continue
+		}
+		index[p.NodeId] = len(positions)
+		positions = append(positions, pos)
+	}
+
+	t, err := s.treeRepo.FindByID(ctx, req.Msg.TreeId)
+	if err != nil {
+		if errors.Is(err, tree.ErrTreeNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if _, err := s.access.RequireEdit(ctx, t, userID); err != nil {
+		return nil, err
+	}
+
+	if len(positions) == 0 {
+		return connect.NewResponse(&nodev1.UpdateLayoutResponse{}), nil
+	}
+
+	var updated int
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := s.nodeRepo.FindByTreeID(ctx, t.ID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		updated, err = s.nodeRepo.UpdatePositions(ctx, t.ID, positions)
+		if errors.Is(err, node.ErrInvalidNodeID) {
+			return connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		if updated != len(positions) {
+			return connect.NewError(connect.CodeNotFound, node.ErrNodeNotFound)
+		}
+		return s.record(ctx, layoutEntries(existing, index, positions, userID)...)
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&nodev1.UpdateLayoutResponse{
+		UpdatedCount: int32(updated),
+	}), nil
+}
+
+// layoutEntries audit หนึ่ง entry ต่อ node ที่ตำแหน่งเปลี่ยนจริง
+func layoutEntries(existing []*node.Node, index map[string]int, positions []node.Position, userID string) []*audit.Entry {
+	var entries []*audit.Entry
+	for _, n := range existing {
+		i, ok := index[n.ID]
+		if !ok {
+			continue
+		}
+		p := positions[i]
+		if p.X == n.PositionX && p.Y == n.PositionY {
+			continue
+		}
+		after := audit.NodeSnapshot(n, nil)
+		after.Node.PositionX, after.Node.PositionY = p.X, p.Y
+		entries = append(entries, &audit.Entry{
+			TreeID:  n.TreeID,
+			ActorID: userID,
+			Action:  audit.ActionLayoutUpdated,
+			NodeID:  &n.ID,
+			Before:  audit.NodeSnapshot(n, nil),
+			After:   after,
+		})
+	}
+	return entries
+}
+
+func isFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
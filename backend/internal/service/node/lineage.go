@@ -0,0 +1,170 @@
+package node
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	nodev1 "github.com/TitleKung-01/code-tree-backend/gen/node/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/middleware"
+	"github.com/TitleKung-01/code-tree-backend/internal/service/access"
+)
+
+// ==================== GetAncestors ====================
+
+func (s *Service) GetAncestors(
+	ctx context.Context,
+	req *connect.Request[nodev1.GetAncestorsRequest],
+) (*connect.Response[nodev1.GetAncestorsResponse], error) {
+
+	if req.Msg.NodeId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("node_id is required"))
+	}
+
+	n, t, level, err := s.loadViewableNode(ctx, req.Msg.NodeId)
+	if err != nil {
+		return nil, err
+	}
+
+	relatives := t.Structure.Ancestors(n.ID, int(req.Msg.MaxDepth))
+	lineage, err := s.lineageToProto(ctx, t, level, relatives)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&nodev1.GetAncestorsResponse{Ancestors: lineage}), nil
+}
+
+// ==================== GetDescendants ====================
+
+func (s *Service) GetDescendants(
+	ctx context.Context,
+	req *connect.Request[nodev1.GetDescendantsRequest],
+) (*connect.Response[nodev1.GetDescendantsResponse], error) {
+
+	if req.Msg.NodeId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("node_id is required"))
+	}
+
+	n, t, level, err := s.loadViewableNode(ctx, req.Msg.NodeId)
+	if err != nil {
+		return nil, err
+	}
+
+	relatives := t.Structure.Descendants(n.ID, int(req.Msg.MaxDepth))
+	lineage, err := s.lineageToProto(ctx, t, level, relatives)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&nodev1.GetDescendantsResponse{Descendants: lineage}), nil
+}
+
+// ==================== FindRelationship ====================
+
+func (s *Service) FindRelationship(
+	ctx context.Context,
+	req *connect.Request[nodev1.FindRelationshipRequest],
+) (*connect.Response[nodev1.FindRelationshipResponse], error) {
+
+	if req.Msg.NodeAId == "" || req.Msg.NodeBId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("node_a_id and node_b_id are required"))
+	}
+
+	a, t, _, err := s.loadViewableNode(ctx, req.Msg.NodeAId)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.nodeRepo.FindByID(ctx, req.Msg.NodeBId)
+	if err != nil {
+		if errors.Is(err, node.ErrNodeNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if b.TreeID != a.TreeID {
+		return nil, connect.NewError(connect.CodeInvalidArgument, node.ErrDifferentTrees)
+	}
+
+	rel := t.Structure.Relationship(a.ID, b.ID)
+	resp := &nodev1.FindRelationshipResponse{
+		Kind:  relationshipKindToProto(rel.Kind),
+		Label: rel.Label(),
+		Up:    int32(rel.Up),
+		Down:  int32(rel.Down),
+		Path:  rel.Path,
+	}
+	if rel.CommonAncestor != "" {
+		resp.CommonAncestorId = &rel.CommonAncestor
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// loadViewableNode โหลด node + tree ของมัน แล้วตรวจว่า caller ดู tree นั้นได้ (ไม่ต้อง login ถ้า tree public)
+func (s *Service) loadViewableNode(ctx context.Context, nodeID string) (*node.Node, *tree.Tree, access.Level, error) {
+	n, err := s.nodeRepo.FindByID(ctx, nodeID)
+	if err != nil {
+		if errors.Is(err, node.ErrNodeNotFound) {
+			return nil, nil, access.None, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, nil, access.None, connect.NewError(connect.CodeInternal, err)
+	}
+	t, err := s.treeRepo.FindByID(ctx, n.TreeID)
+	if err != nil {
+		if errors.Is(err, tree.ErrTreeNotFound) {
+			return nil, nil, access.None, connect.NewError(connect.CodeNotFound, node.ErrNodeNotFound)
+		}
+		return nil, nil, access.None, connect.NewError(connect.CodeInternal, err)
+	}
+
+	// tree private ตอบ NotFound ให้คนที่ไม่มีสิทธิ์ เหมือนไม่มี node นี้
+	userID, _ := middleware.GetUserID(ctx)
+	level, err := s.access.RequireView(ctx, t, userID)
+	if err != nil {
+		return nil, nil, access.None, err
+	}
+	return n, t, level, nil
+}
+
+// lineageToProto โหลด node ของ relatives (query เดียวทั้ง tree) แล้วแปลงตามสิทธิ์ของ caller
+func (s *Service) lineageToProto(ctx context.Context, t *tree.Tree, level access.Level, relatives []tree.Relative) ([]*nodev1.LineageNode, error) {
+	if len(relatives) == 0 {
+		return nil, nil
+	}
+	nodes, err := s.nodeRepo.FindByTreeID(ctx, t.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	byID := indexNodes(nodes)
+
+	out := make([]*nodev1.LineageNode, 0, len(relatives))
+	for _, r := range relatives {
+		n, ok := byID[r.ID]
+		if !ok {
+			continue
+		}
+		out = append(out, &nodev1.LineageNode{
+			Node:  domainToProto(n, t, level),
+			Depth: int32(r.Depth),
+		})
+	}
+	return out, nil
+}
+
+func relationshipKindToProto(k tree.RelationshipKind) nodev1.RelationshipKind {
+	switch k {
+	case tree.RelationSelf:
+		return nodev1.RelationshipKind_RELATIONSHIP_KIND_SELF
+	case tree.RelationAncestor:
+		return nodev1.RelationshipKind_RELATIONSHIP_KIND_ANCESTOR
+	case tree.RelationDescendant:
+		return nodev1.RelationshipKind_RELATIONSHIP_KIND_DESCENDANT
+	case tree.RelationSibling:
+		return nodev1.RelationshipKind_RELATIONSHIP_KIND_SIBLING
+	case tree.RelationCousin:
+		return nodev1.RelationshipKind_RELATIONSHIP_KIND_COUSIN
+	default:
+		return nodev1.RelationshipKind_RELATIONSHIP_KIND_UNRELATED
+	}
+}
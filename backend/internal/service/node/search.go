@@ -0,0 +1,148 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	nodev1 "github.com/TitleKung-01/code-tree-backend/gen/node/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/middleware"
+	"github.com/TitleKung-01/code-tree-backend/internal/service/access"
+)
+
+const (
+	defaultSearchPageSize = 20
+	maxSearchPageSize     = 100
+)
+
+// ==================== SearchNodes ====================
+
+func (s *Service) SearchNodes(
+	ctx context.Context,
+	req *connect.Request[nodev1.SearchNodesRequest],
+) (*connect.Response[nodev1.SearchNodesResponse], error) {
+
+	query := strings.TrimSpace(req.Msg.Query)
+	if query == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, node.ErrEmptyQuery)
+	}
+
+	filter := node.SearchFilter{
+		Query:       query,
+		Generations: req.Msg.Generations,
+		Limit:       defaultSearchPageSize,
+	}
+	if size := int(req.Msg.PageSize); size > 0 {
+		filter.Limit = min(size, maxSearchPageSize)
+	}
+	if req.Msg.PageToken != "" {
+		offset, err := strconv.Atoi(req.Msg.PageToken)
+		if err != nil || offset < 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, node.ErrInvalidPageToken)
+		}
+		filter.Offset = offset
+	}
+	for _, st := range req.Msg.Statuses {
+		if st != nodev1.NodeStatus_NODE_STATUS_UNSPECIFIED {
+			filter.Statuses = append(filter.Statuses, protoStatusToDomain(st))
+		}
+	}
+
+	trees, err := s.searchableTrees(ctx, req.Msg.GetTreeId())
+	if err != nil {
+		return nil, err
+	}
+	resp := &nodev1.SearchNodesResponse{Hits: []*nodev1.SearchHit{}}
+	if len(trees) == 0 {
+		return connect.NewResponse(resp), nil
+	}
+	for id := range trees {
+		filter.TreeIDs = append(filter.TreeIDs, id)
+	}
+
+	// ขอเกิน 1 แถวไว้ดูว่ายังมีหน้าถัดไปไหม
+	filter.Limit++
+	hits, err := s.nodeRepo.Search(ctx, filter)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if len(hits) == filter.Limit {
+		hits = hits[:len(hits)-1]
+		// offset นับแถวที่ DB คืน ไม่ใช่จำนวนที่ส่งออก (ผลที่ถูกตัดจะไม่กลับมาในหน้าถัดไป)
+		resp.NextPageToken = strconv.Itoa(filter.Offset + len(hits))
+	}
+
+	for _, h := range hits {
+		st := trees[h.Node.TreeID]
+		// ตรงแค่ช่องทางติดต่อ → ต้องเป็นช่องที่ caller เห็นได้ ไม่งั้นเท่ากับบอกใบ้ค่าที่ซ่อนไว้
+		if !h.NameMatch && !contactMatches(h.Node, st.tree, st.level, query) {
+			continue
+		}
+		resp.Hits = append(resp.Hits, &nodev1.SearchHit{
+			Node:     domainToProto(h.Node, st.tree, st.level),
+			Score:    h.Score,
+			TreeName: st.tree.Name,
+		})
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// searchableTree tree ที่ caller ค้นได้ พร้อมระดับสิทธิ์ (ใช้ตัดช่องทางติดต่อ)
+type searchableTree struct {
+	tree  *tree.Tree
+	level access.Level
+}
+
+// searchableTrees treeID = "" คือทุก tree ที่ caller เป็นเจ้าของหรือถูกแชร์ (ต้อง login)
+// ระบุ treeID = tree นั้น tree เดียว (public tree ค้นได้โดยไม่ต้อง login)
+func (s *Service) searchableTrees(ctx context.Context, treeID string) (map[string]searchableTree, error) {
+	userID, authErr := middleware.GetUserID(ctx)
+
+	if treeID != "" {
+		t, err := s.treeRepo.FindByID(ctx, treeID)
+		if err != nil {
+			if errors.Is(err, tree.ErrTreeNotFound) {
+				return nil, connect.NewError(connect.CodeNotFound, err)
+			}
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		level, err := s.access.RequireView(ctx, t, userID)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]searchableTree{t.ID: {tree: t, level: level}}, nil
+	}
+
+	if authErr != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, authErr)
+	}
+
+	summaries, err := s.treeRepo.ListSummaries(ctx, tree.ListQuery{UserID: userID, Scope: tree.ScopeAccessible})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	// ใช้แค่ข้อมูลระดับ tree (privacy / ชื่อ) ไม่ต้องโหลด structure
+	out := make(map[string]searchableTree, len(summaries))
+	for _, sum := range summaries {
+		out[sum.Tree.ID] = searchableTree{tree: sum.Tree, level: access.Evaluate(sum.Tree, userID, &sum.Role)}
+	}
+	return out, nil
+}
+
+// contactMatches คำค้นอยู่ในช่องทางติดต่อที่ระดับ level เห็นได้ไหม (เทียบแบบเดียวกับ contact_text ใน DB)
+func contactMatches(n *node.Node, t *tree.Tree, level access.Level, query string) bool {
+	q := strings.ToLower(query)
+	for _, value := range access.VisibleContacts(n, t, level) {
+		if strings.Contains(strings.ToLower(value), q) {
+			return true
+		}
+	}
+	return false
+}
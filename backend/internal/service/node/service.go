@@ -3,43 +3,65 @@ package node
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 
 	"connectrpc.com/connect"
 
 	nodev1 "github.com/TitleKung-01/code-tree-backend/gen/node/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/audit"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/event"
 	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
 	"github.com/TitleKung-01/code-tree-backend/internal/domain/share"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/snapshot"
 	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tx"
 	"github.com/TitleKung-01/code-tree-backend/internal/middleware"
+	"github.com/TitleKung-01/code-tree-backend/internal/service/access"
 )
 
 type Service struct {
-	nodeRepo  node.Repository
-	treeRepo  tree.Repository
-	shareRepo share.Repository
+	nodeRepo     node.Repository
+	treeRepo     tree.Repository
+	shareRepo    share.Repository
+	eventRepo    event.Repository
+	auditRepo    audit.Repository
+	snapshotRepo snapshot.Repository
+	broker       event.Broker
+	txm          tx.Manager
+	access       *access.Policy
+
+	// trashRetention เวลาที่ของในถังขยะอยู่ได้ก่อน purge job ลบจริง
+	trashRetention time.Duration
 }
 
-func NewService(nodeRepo node.Repository, treeRepo tree.Repository, shareRepo share.Repository) *Service {
+func NewService(
+	nodeRepo node.Repository,
+	treeRepo tree.Repository,
+	shareRepo share.Repository,
+	eventRepo event.Repository,
+	auditRepo audit.Repository,
+	snapshotRepo snapshot.Repository,
+	broker event.Broker,
+	txm tx.Manager,
+	trashRetention time.Duration,
+) *Service {
 	return &Service{
-		nodeRepo:  nodeRepo,
-		treeRepo:  treeRepo,
-		shareRepo: shareRepo,
+		nodeRepo:     nodeRepo,
+		treeRepo:     treeRepo,
+		shareRepo:    shareRepo,
+		eventRepo:    eventRepo,
+		auditRepo:    auditRepo,
+		snapshotRepo: snapshotRepo,
+		broker:       broker,
+		txm:          txm,
+		access:       access.NewPolicy(shareRepo),
+
+		trashRetention: trashRetention,
 	}
 }
 
-// canEditTree ตรวจว่า user มีสิทธิ์แก้ไข tree (owner, creator, editor)
-func (s *Service) canEditTree(ctx context.Context, t *tree.Tree, userID string) bool {
-	if t.CreatedBy == userID {
-		return true
-	}
-	role, err := s.shareRepo.GetUserRole(ctx, t.ID, userID)
-	if err != nil {
-		return false
-	}
-	return role.CanEdit()
-}
-
 // ==================== CreateNode ====================
 
 func (s *Service) CreateNode(
@@ -66,8 +88,9 @@ func (s *Service) CreateNode(
 		}
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
-	if !s.canEditTree(ctx, t, userID) {
-		return nil, connect.NewError(connect.CodePermissionDenied, tree.ErrUnauthorized)
+	level, err := s.access.RequireEdit(ctx, t, userID)
+	if err != nil {
+		return nil, err
 	}
 
 	// รวม parentIDs จาก parent_ids (multi) หรือ parent_id (single / backward compat)
@@ -76,9 +99,8 @@ func (s *Service) CreateNode(
 		parentIDs = []string{*req.Msg.ParentId}
 	}
 
-	// ตรวจ parent ทุกตัวว่าอยู่ tree เดียวกัน + คำนวณรุ่นจาก parent ตัวแรก
-	generation := req.Msg.Generation
-	for i, pid := range parentIDs {
+	// ตรวจ parent ทุกตัวว่าอยู่ tree เดียวกัน (รุ่นคำนวณจาก parent ตัวแรกใน transaction)
+	for _, pid := range parentIDs {
 		parentNode, err := s.nodeRepo.FindByID(ctx, pid)
 		if err != nil {
 			if errors.Is(err, node.ErrNodeNotFound) {
@@ -89,9 +111,6 @@ func (s *Service) CreateNode(
 		if parentNode.TreeID != req.Msg.TreeId {
 			return nil, connect.NewError(connect.CodeInvalidArgument, node.ErrCrossTreeMove)
 		}
-		if i == 0 {
-			generation = parentNode.Generation + 1
-		}
 	}
 
 	status := protoStatusToDomain(req.Msg.Status)
@@ -104,43 +123,76 @@ func (s *Service) CreateNode(
 		StudentID:  req.Msg.StudentId,
 		PhotoURL:   req.Msg.PhotoUrl,
 		Status:     status,
-		Generation: generation,
+		Generation: req.Msg.Generation,
 	}
 	n.SetContact(req.Msg.Phone, req.Msg.Email, req.Msg.LineId, req.Msg.Discord, req.Msg.Facebook)
+	n.SetContactPrivacy(access.PrivacyFromProto(req.Msg.ContactPrivacy))
 
-	if err := s.nodeRepo.Create(ctx, n); err != nil {
-		slog.Error("failed to create node", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, err)
-	}
+	// สร้าง node + ต่อเข้า structure ใน transaction เดียว (พังกลางทาง = ไม่มี node ค้าง)
+	var updatedTree *tree.Tree
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		locked, err := s.lockAndLoadTree(ctx, req.Msg.TreeId, req.Msg.ExpectedRevision)
+		if err != nil {
+			return err
+		}
+		snap, err := s.captureSnapshot(ctx, locked, userID, snapshot.Reason(audit.ActionNodeCreated), snapshot.ScopeSubtree, nil)
+		if err != nil {
+			return err
+		}
 
-	// เพิ่ม node เข้า tree structure ด้วย parent ตัวแรก (หรือ root)
-	var firstParentID *string
-	if len(parentIDs) > 0 {
-		firstParentID = &parentIDs[0]
-	}
-	if err := s.treeRepo.AddNodeToStructure(ctx, req.Msg.TreeId, n.ID, firstParentID); err != nil {
-		slog.Error("failed to add node to structure", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, err)
-	}
+		if len(parentIDs) > 0 {
+			if n.Generation, err = s.childGeneration(ctx, parentIDs[0]); err != nil {
+				return err
+			}
+		}
+		if err := s.nodeRepo.Create(ctx, n); err != nil {
+			slog.Error("failed to create node", "error", err)
+			return connect.NewError(connect.CodeInternal, err)
+		}
 
-	// เพิ่ม parent ตัวที่ 2+ (multi-parent)
-	if len(parentIDs) > 1 {
-		for _, pid := range parentIDs[1:] {
-			if err := s.treeRepo.AddChildToParent(ctx, req.Msg.TreeId, n.ID, pid); err != nil {
-				slog.Error("failed to add additional parent", "error", err, "parentID", pid)
-				return nil, connect.NewError(connect.CodeInternal, err)
+		// เพิ่ม node เข้า tree structure แล้วจัดลำดับใน children ของ parent ตัวแรก (ไม่ส่งมา = ต่อท้าย)
+		_, err = s.treeRepo.UpdateStructure(ctx, req.Msg.TreeId, func(st *tree.TreeStructure) error {
+			firstParentID, err := addToStructure(st, n.ID, parentIDs)
+			if err != nil {
+				return err
+			}
+			if req.Msg.SiblingOrder != nil {
+				st.Reorder(firstParentID, n.ID, *req.Msg.SiblingOrder)
 			}
+			return nil
+		})
+		if err != nil {
+			slog.Error("failed to add node to structure", "error", err)
+			return structureError(err)
 		}
-	}
 
-	// ดึง structure ใหม่สำหรับ response
-	updatedTree, err := s.treeRepo.FindByID(ctx, req.Msg.TreeId)
+		// ดึง structure ใหม่สำหรับ response
+		updatedTree, err = s.treeRepo.FindByID(ctx, req.Msg.TreeId)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		// undo = ลบ node ที่เพิ่งสร้าง (id รู้หลังสร้างเท่านั้น)
+		snap.NodeID = &n.ID
+		if err := s.saveSnapshot(ctx, snap); err != nil {
+			return err
+		}
+		return s.record(ctx, &audit.Entry{
+			TreeID:    n.TreeID,
+			ActorID:   userID,
+			Action:    audit.ActionNodeCreated,
+			NodeID:    &n.ID,
+			TargetIDs: parentIDs,
+			After:     audit.NodeSnapshot(n, &updatedTree.Structure),
+		})
+	})
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, toConnectError(err)
 	}
 
 	return connect.NewResponse(&nodev1.CreateNodeResponse{
-		Node: domainToProto(n, &updatedTree.Structure),
+		Node:              domainToProto(n, updatedTree, level),
+		StructureRevision: updatedTree.StructureRevision,
 	}), nil
 }
 
@@ -175,10 +227,13 @@ func (s *Service) UpdateNode(
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
-	if !s.canEditTree(ctx, t, userID) {
-		return nil, connect.NewError(connect.CodePermissionDenied, tree.ErrUnauthorized)
+	level, err := s.access.RequireEdit(ctx, t, userID)
+	if err != nil {
+		return nil, err
 	}
 
+	before := audit.NodeSnapshot(existing, nil)
+
 	existing.Nickname = req.Msg.Nickname
 	existing.FirstName = req.Msg.FirstName
 	existing.LastName = req.Msg.LastName
@@ -187,13 +242,45 @@ func (s *Service) UpdateNode(
 	existing.Status = protoStatusToDomain(req.Msg.Status)
 	existing.Generation = req.Msg.Generation
 	existing.SetContact(req.Msg.Phone, req.Msg.Email, req.Msg.LineId, req.Msg.Discord, req.Msg.Facebook)
+	if req.Msg.ContactPrivacy != nil {
+		existing.SetContactPrivacy(access.PrivacyFromProto(req.Msg.ContactPrivacy))
+	}
 
-	if err := s.nodeRepo.Update(ctx, existing); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		// แก้ข้อมูลอย่างเดียว: ล็อกแบบ share ไม่เพิ่ม revision / undo ย้อนเฉพาะ node นี้
+		if err := s.treeRepo.LockStructureShared(ctx, existing.TreeID); err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		current, err := s.treeRepo.FindByID(ctx, existing.TreeID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		snap, err := s.captureSnapshot(ctx, current, userID, snapshot.Reason(audit.ActionNodeUpdated), snapshot.ScopeNode, &existing.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.saveSnapshot(ctx, snap); err != nil {
+			return err
+		}
+
+		if err := s.nodeRepo.Update(ctx, existing); err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		return s.record(ctx, &audit.Entry{
+			TreeID:  existing.TreeID,
+			ActorID: userID,
+			Action:  audit.ActionNodeUpdated,
+			NodeID:  &existing.ID,
+			Before:  before,
+			After:   audit.NodeSnapshot(existing, nil),
+		})
+	})
+	if err != nil {
+		return nil, toConnectError(err)
 	}
 
 	return connect.NewResponse(&nodev1.UpdateNodeResponse{
-		Node: domainToProto(existing, &t.Structure),
+		Node: domainToProto(existing, t, level),
 	}), nil
 }
 
@@ -225,22 +312,48 @@ func (s *Service) DeleteNode(
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
-	if !s.canEditTree(ctx, t, userID) {
-		return nil, connect.NewError(connect.CodePermissionDenied, tree.ErrUnauthorized)
+	if _, err := s.access.RequireEdit(ctx, t, userID); err != nil {
+		return nil, err
 	}
 
-	// ลบ node ออกจาก structure ก่อน (ย้าย children ขึ้น parent)
-	if err := s.treeRepo.RemoveNodeFromStructure(ctx, existing.TreeID, req.Msg.Id); err != nil {
-		slog.Error("failed to remove node from structure", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, err)
-	}
+	var revision int64
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		locked, err := s.lockAndLoadTree(ctx, existing.TreeID, req.Msg.ExpectedRevision)
+		if err != nil {
+			return err
+		}
+		if err := s.snapshotBefore(ctx, locked, userID, audit.ActionNodeDeleted, existing.ID); err != nil {
+			return err
+		}
+		revision = locked.StructureRevision
+		before := audit.NodeSnapshot(existing, &locked.Structure)
 
-	// ลบ node จาก nodes table
-	if err := s.nodeRepo.Delete(ctx, req.Msg.Id); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		// ถอดออกจาก structure (children ย้ายขึ้น parent) แล้วย้ายลงถังขยะพร้อมตำแหน่งเดิม
+		structure := locked.Structure.Clone()
+		if err := s.trashNode(ctx, &structure, existing.ID, userID); err != nil {
+			return err
+		}
+		if err := s.treeRepo.ReplaceStructure(ctx, existing.TreeID, structure); err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		// target = children ที่ถูกย้ายขึ้นไปต่อกับ parent ของ node นี้
+		return s.record(ctx, &audit.Entry{
+			TreeID:    existing.TreeID,
+			ActorID:   userID,
+			Action:    audit.ActionNodeDeleted,
+			NodeID:    &existing.ID,
+			TargetIDs: before.ChildIDs,
+			Before:    before,
+		})
+	})
+	if err != nil {
+		return nil, toConnectError(err)
 	}
 
-	return connect.NewResponse(&nodev1.DeleteNodeResponse{}), nil
+	return connect.NewResponse(&nodev1.DeleteNodeResponse{
+		StructureRevision: revision,
+	}), nil
 }
 
 // ==================== GetTreeNodes ====================
@@ -262,6 +375,13 @@ func (s *Service) GetTreeNodes(
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
+	// tree private ตอบ NotFound ให้คนที่ไม่มีสิทธิ์ เหมือนไม่มี tree นี้
+	userID, _ := middleware.GetUserID(ctx)
+	level, err := s.access.RequireView(ctx, t, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	nodes, err := s.nodeRepo.FindByTreeID(ctx, req.Msg.TreeId)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
@@ -269,11 +389,12 @@ func (s *Service) GetTreeNodes(
 
 	protoNodes := make([]*nodev1.Node, len(nodes))
 	for i, n := range nodes {
-		protoNodes[i] = domainToProto(n, &t.Structure)
+		protoNodes[i] = domainToProto(n, t, level)
 	}
 
 	return connect.NewResponse(&nodev1.GetTreeNodesResponse{
-		Nodes: protoNodes,
+		Nodes:             protoNodes,
+		StructureRevision: t.StructureRevision,
 	}), nil
 }
 
@@ -308,8 +429,9 @@ func (s *Service) MoveNode(
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
-	if !s.canEditTree(ctx, t, userID) {
-		return nil, connect.NewError(connect.CodePermissionDenied, tree.ErrUnauthorized)
+	level, err := s.access.RequireEdit(ctx, t, userID)
+	if err != nil {
+		return nil, err
 	}
 
 	newParent, err := s.nodeRepo.FindByID(ctx, req.Msg.NewParentId)
@@ -327,32 +449,73 @@ func (s *Service) MoveNode(
 		return nil, connect.NewError(connect.CodeInvalidArgument, node.ErrCrossTreeMove)
 	}
 
-	// ตรวจ circular reference จาก structure
-	if t.Structure.IsDescendant(req.Msg.NodeId, req.Msg.NewParentId) {
-		return nil, connect.NewError(connect.CodeInvalidArgument, node.ErrCircularReference)
-	}
-
 	newParentID := req.Msg.NewParentId
-	if err := s.treeRepo.MoveNodeInStructure(ctx, n.TreeID, req.Msg.NodeId, &newParentID); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
-	}
+	var newGen int32
+	var updatedTree *tree.Tree
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		locked, err := s.lockAndLoadTree(ctx, n.TreeID, req.Msg.ExpectedRevision)
+		if err != nil {
+			return err
+		}
+		if err := s.snapshotBefore(ctx, locked, userID, audit.ActionNodeMoved, n.ID); err != nil {
+			return err
+		}
 
-	// ดึง tree ใหม่หลัง move
-	updatedTree, err := s.treeRepo.FindByID(ctx, n.TreeID)
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
-	}
+		// ตรวจ circular reference จาก structure ล่าสุด (ล็อกไว้แล้ว)
+		if locked.Structure.IsDescendant(req.Msg.NodeId, req.Msg.NewParentId) {
+			return connect.NewError(connect.CodeInvalidArgument, node.ErrCircularReference)
+		}
+		if newGen, err = s.childGeneration(ctx, newParentID); err != nil {
+			return err
+		}
 
-	// คำนวณรุ่นใหม่อัตโนมัติ: node = parent + 1, cascade ลง descendants
-	newGen := newParent.Generation + 1
-	if err := s.recalcDescendantGenerations(ctx, req.Msg.NodeId, newGen, &updatedTree.Structure); err != nil {
-		slog.Error("failed to recalc generations after move", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, err)
+		before := audit.NodeSnapshot(n, &locked.Structure)
+
+		_, err = s.treeRepo.UpdateStructure(ctx, n.TreeID, func(st *tree.TreeStructure) error {
+			if err := st.MoveNode(req.Msg.NodeId, newParentID); err != nil {
+				return err
+			}
+			if req.Msg.SiblingOrder != nil {
+				st.Reorder(newParentID, req.Msg.NodeId, *req.Msg.SiblingOrder)
+			}
+			return nil
+		})
+		if err != nil {
+			return structureError(err)
+		}
+
+		// ดึง tree ใหม่หลัง move
+		updatedTree, err = s.treeRepo.FindByID(ctx, n.TreeID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		// คำนวณรุ่นใหม่อัตโนมัติ: node = parent + 1, cascade ลง descendants
+		if err := s.recalcDescendantGenerations(ctx, req.Msg.NodeId, newGen, &updatedTree.Structure); err != nil {
+			slog.Error("failed to recalc generations after move", "error", err)
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		after := audit.NodeSnapshot(n, &updatedTree.Structure)
+		after.Node.Generation = newGen
+		return s.record(ctx, &audit.Entry{
+			TreeID:    n.TreeID,
+			ActorID:   userID,
+			Action:    audit.ActionNodeMoved,
+			NodeID:    &n.ID,
+			TargetIDs: []string{newParentID},
+			Before:    before,
+			After:     after,
+		})
+	})
+	if err != nil {
+		return nil, toConnectError(err)
 	}
 	n.Generation = newGen
 
 	return connect.NewResponse(&nodev1.MoveNodeResponse{
-		Node: domainToProto(n, &updatedTree.Structure),
+		Node:              domainToProto(n, updatedTree, level),
+		StructureRevision: updatedTree.StructureRevision,
 	}), nil
 }
 
@@ -384,22 +547,51 @@ func (s *Service) UnlinkNode(
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
-	if !s.canEditTree(ctx, t, userID) {
-		return nil, connect.NewError(connect.CodePermissionDenied, tree.ErrUnauthorized)
+	level, err := s.access.RequireEdit(ctx, t, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	// ย้ายเป็น root (parent = nil)
-	if err := s.treeRepo.MoveNodeInStructure(ctx, n.TreeID, req.Msg.NodeId, nil); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
-	}
+	var updatedTree *tree.Tree
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		locked, err := s.lockAndLoadTree(ctx, n.TreeID, req.Msg.ExpectedRevision)
+		if err != nil {
+			return err
+		}
+		if err := s.snapshotBefore(ctx, locked, userID, audit.ActionNodeUnlinked, n.ID); err != nil {
+			return err
+		}
+		before := audit.NodeSnapshot(n, &locked.Structure)
+
+		// ตัดทุก parent แล้วย้ายเป็น root
+		_, err = s.treeRepo.UpdateStructure(ctx, n.TreeID, func(st *tree.TreeStructure) error {
+			return st.MoveNode(req.Msg.NodeId, "")
+		})
+		if err != nil {
+			return structureError(err)
+		}
 
-	updatedTree, err := s.treeRepo.FindByID(ctx, n.TreeID)
+		updatedTree, err = s.treeRepo.FindByID(ctx, n.TreeID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		return s.record(ctx, &audit.Entry{
+			TreeID:    n.TreeID,
+			ActorID:   userID,
+			Action:    audit.ActionNodeUnlinked,
+			NodeID:    &n.ID,
+			TargetIDs: before.ParentIDs,
+			Before:    before,
+			After:     audit.NodeSnapshot(n, &updatedTree.Structure),
+		})
+	})
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, toConnectError(err)
 	}
 
 	return connect.NewResponse(&nodev1.UnlinkNodeResponse{
-		Node: domainToProto(n, &updatedTree.Structure),
+		Node:              domainToProto(n, updatedTree, level),
+		StructureRevision: updatedTree.StructureRevision,
 	}), nil
 }
 
@@ -442,38 +634,80 @@ func (s *Service) AddParent(
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
-	if !s.canEditTree(ctx, t, userID) {
-		return nil, connect.NewError(connect.CodePermissionDenied, tree.ErrUnauthorized)
+	level, err := s.access.RequireEdit(ctx, t, userID)
+	if err != nil {
+		return nil, err
 	}
 	if n.TreeID != parentNode.TreeID {
 		return nil, connect.NewError(connect.CodeInvalidArgument, node.ErrCrossTreeMove)
 	}
 
-	// ตรวจ circular
-	if t.Structure.IsDescendant(req.Msg.NodeId, req.Msg.ParentId) {
-		return nil, connect.NewError(connect.CodeInvalidArgument, node.ErrCircularReference)
-	}
+	var newGen int32
+	var updatedTree *tree.Tree
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		locked, err := s.lockAndLoadTree(ctx, n.TreeID, req.Msg.ExpectedRevision)
+		if err != nil {
+			return err
+		}
+		if err := s.snapshotBefore(ctx, locked, userID, audit.ActionParentAdded, n.ID); err != nil {
+			return err
+		}
 
-	// เพิ่ม parent ใหม่ให้ node (ไม่ลบ parent เดิม — multi-parent / DAG)
-	if err := s.treeRepo.AddChildToParent(ctx, n.TreeID, req.Msg.NodeId, req.Msg.ParentId); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
-	}
+		// ตรวจ circular จาก structure ล่าสุด (ล็อกไว้แล้ว)
+		if locked.Structure.IsDescendant(req.Msg.NodeId, req.Msg.ParentId) {
+			return connect.NewError(connect.CodeInvalidArgument, node.ErrCircularReference)
+		}
+		if newGen, err = s.childGeneration(ctx, req.Msg.ParentId); err != nil {
+			return err
+		}
 
-	updatedTree, err := s.treeRepo.FindByID(ctx, n.TreeID)
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
-	}
+		before := audit.NodeSnapshot(n, &locked.Structure)
 
-	// คำนวณรุ่นใหม่อัตโนมัติ: node = parent + 1, cascade ลง descendants
-	newGen := parentNode.Generation + 1
-	if err := s.recalcDescendantGenerations(ctx, req.Msg.NodeId, newGen, &updatedTree.Structure); err != nil {
-		slog.Error("failed to recalc generations after add parent", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, err)
+		// เพิ่ม parent ใหม่ให้ node (ไม่ลบ parent เดิม — multi-parent / DAG)
+		_, err = s.treeRepo.UpdateStructure(ctx, n.TreeID, func(st *tree.TreeStructure) error {
+			if err := st.AddParent(req.Msg.NodeId, req.Msg.ParentId); err != nil {
+				return err
+			}
+			if req.Msg.SiblingOrder != nil {
+				st.Reorder(req.Msg.ParentId, req.Msg.NodeId, *req.Msg.SiblingOrder)
+			}
+			return nil
+		})
+		if err != nil {
+			return structureError(err)
+		}
+
+		updatedTree, err = s.treeRepo.FindByID(ctx, n.TreeID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		// คำนวณรุ่นใหม่อัตโนมัติ: node = parent + 1, cascade ลง descendants
+		if err := s.recalcDescendantGenerations(ctx, req.Msg.NodeId, newGen, &updatedTree.Structure); err != nil {
+			slog.Error("failed to recalc generations after add parent", "error", err)
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		after := audit.NodeSnapshot(n, &updatedTree.Structure)
+		after.Node.Generation = newGen
+		return s.record(ctx, &audit.Entry{
+			TreeID:    n.TreeID,
+			ActorID:   userID,
+			Action:    audit.ActionParentAdded,
+			NodeID:    &n.ID,
+			TargetIDs: []string{req.Msg.ParentId},
+			Before:    before,
+			After:     after,
+		})
+	})
+	if err != nil {
+		return nil, toConnectError(err)
 	}
 	n.Generation = newGen
 
 	return connect.NewResponse(&nodev1.AddParentResponse{
-		Node: domainToProto(n, &updatedTree.Structure),
+		Node:              domainToProto(n, updatedTree, level),
+		StructureRevision: updatedTree.StructureRevision,
 	}), nil
 }
 
@@ -505,22 +739,76 @@ func (s *Service) RemoveParent(
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
-	if !s.canEditTree(ctx, t, userID) {
-		return nil, connect.NewError(connect.CodePermissionDenied, tree.ErrUnauthorized)
+	level, err := s.access.RequireEdit(ctx, t, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	// ย้ายเป็น root (ตัดสาย parent)
-	if err := s.treeRepo.MoveNodeInStructure(ctx, n.TreeID, req.Msg.NodeId, nil); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
-	}
+	var updatedTree *tree.Tree
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		locked, err := s.lockAndLoadTree(ctx, n.TreeID, req.Msg.ExpectedRevision)
+		if err != nil {
+			return err
+		}
+		if err := s.snapshotBefore(ctx, locked, userID, audit.ActionParentRemoved, n.ID); err != nil {
+			return err
+		}
+		if locked.Structure.SiblingIndex(req.Msg.ParentId, req.Msg.NodeId) < 0 {
+			return connect.NewError(connect.CodeFailedPrecondition, node.ErrNotAParent)
+		}
+		entry := &audit.Entry{
+			TreeID:    n.TreeID,
+			ActorID:   userID,
+			Action:    audit.ActionParentRemoved,
+			NodeID:    &n.ID,
+			TargetIDs: []string{req.Msg.ParentId},
+			Before:    audit.NodeSnapshot(n, &locked.Structure),
+		}
+
+		// ตัดเฉพาะเส้นจาก parent นี้ (parent อื่นยังอยู่ / ไม่เหลือ parent = เป็น root)
+		_, err = s.treeRepo.UpdateStructure(ctx, n.TreeID, func(st *tree.TreeStructure) error {
+			st.RemoveParent(req.Msg.NodeId, req.Msg.ParentId)
+			return nil
+		})
+		if err != nil {
+			return structureError(err)
+		}
 
-	updatedTree, err := s.treeRepo.FindByID(ctx, n.TreeID)
+		updatedTree, err = s.treeRepo.FindByID(ctx, n.TreeID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		// คำนวณรุ่นใหม่จาก parent ที่เหลือ (รุ่นมากสุด + 1) แล้ว cascade ลง descendants
+		// ไม่เหลือ parent = เป็น root ใช้รุ่นเดิม
+		remaining := updatedTree.Structure.FindParentIDs(req.Msg.NodeId)
+		if len(remaining) == 0 {
+			entry.After = audit.NodeSnapshot(n, &updatedTree.Structure)
+			return s.record(ctx, entry)
+		}
+		newGen := int32(-1)
+		for _, pid := range remaining {
+			p, err := s.nodeRepo.FindByID(ctx, pid)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, err)
+			}
+			newGen = max(newGen, p.Generation+1)
+		}
+		if err := s.recalcDescendantGenerations(ctx, req.Msg.NodeId, newGen, &updatedTree.Structure); err != nil {
+			slog.Error("failed to recalc generations after remove parent", "error", err)
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		n.Generation = newGen
+		entry.After = audit.NodeSnapshot(n, &updatedTree.Structure)
+		return s.record(ctx, entry)
+	})
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, toConnectError(err)
 	}
 
 	return connect.NewResponse(&nodev1.RemoveParentResponse{
-		Node: domainToProto(n, &updatedTree.Structure),
+		Node:              domainToProto(n, updatedTree, level),
+		StructureRevision: updatedTree.StructureRevision,
 	}), nil
 }
 
@@ -535,7 +823,13 @@ func (s *Service) GetNodesByShareToken(
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("share_token is required"))
 	}
 
-	t, err := s.treeRepo.FindByShareToken(ctx, req.Msg.ShareToken)
+	// ลิงก์ที่ถูกยกเลิก / หมดอายุใช้ไม่ได้
+	link, err := s.access.RequireShareLink(ctx, req.Msg.ShareToken)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.treeRepo.FindByID(ctx, link.TreeID)
 	if err != nil {
 		if errors.Is(err, tree.ErrTreeNotFound) {
 			return nil, connect.NewError(connect.CodeNotFound, err)
@@ -543,6 +837,13 @@ func (s *Service) GetNodesByShareToken(
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
+	// ผู้ถือลิงก์ดูได้อย่างน้อยแบบ public — ช่องทางติดต่อจึงเห็นเฉพาะที่ตั้งเป็น public
+	userID, _ := middleware.GetUserID(ctx)
+	level, err := s.access.ResolveShareLink(ctx, t, userID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
 	nodes, err := s.nodeRepo.FindByTreeID(ctx, t.ID)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
@@ -550,7 +851,7 @@ func (s *Service) GetNodesByShareToken(
 
 	protoNodes := make([]*nodev1.Node, len(nodes))
 	for i, n := range nodes {
-		protoNodes[i] = domainToProto(n, &t.Structure)
+		protoNodes[i] = domainToProto(n, t, level)
 	}
 
 	return connect.NewResponse(&nodev1.GetNodesByShareTokenResponse{
@@ -560,6 +861,87 @@ func (s *Service) GetNodesByShareToken(
 
 // ==================== Helpers ====================
 
+// addToStructure เพิ่ม nodeID ใต้ parent ตัวแรก (ไม่มี = root) แล้วเพิ่ม parent ที่เหลือ (multi-parent)
+// คืน parent ตัวแรก ("" = root) ไว้จัดลำดับต่อ
+func addToStructure(st *tree.TreeStructure, nodeID string, parentIDs []string) (string, error) {
+	if len(parentIDs) == 0 {
+		return "", st.AddNode(nodeID, "")
+	}
+	if err := st.AddNode(nodeID, parentIDs[0]); err != nil {
+		return "", err
+	}
+	for _, pid := range parentIDs[1:] {
+		if err := st.AddParent(nodeID, pid); err != nil {
+			return "", fmt.Errorf("parent %s: %w", pid, err)
+		}
+	}
+	return parentIDs[0], nil
+}
+
+// lockStructure ล็อก tree row + ตรวจ expected revision (optimistic concurrency)
+// ต้องเรียกใน transaction ก่อนแก้ structure เสมอ
+func (s *Service) lockStructure(ctx context.Context, treeID string, expected *int64) (int64, error) {
+	revision, err := s.treeRepo.BumpStructureRevision(ctx, treeID, expected)
+	if err != nil {
+		switch {
+		case errors.Is(err, tree.ErrRevisionConflict):
+			return 0, connect.NewError(connect.CodeAborted, err)
+		case errors.Is(err, tree.ErrTreeNotFound):
+			return 0, connect.NewError(connect.CodeNotFound, err)
+		}
+		return 0, connect.NewError(connect.CodeInternal, err)
+	}
+	return revision, nil
+}
+
+// lockAndLoadTree ล็อก structure แล้วอ่าน tree ล่าสุด ใช้ตรวจ circular ก่อนแก้
+func (s *Service) lockAndLoadTree(ctx context.Context, treeID string, expected *int64) (*tree.Tree, error) {
+	if _, err := s.lockStructure(ctx, treeID, expected); err != nil {
+		return nil, err
+	}
+	t, err := s.treeRepo.FindByID(ctx, treeID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return t, nil
+}
+
+// snapshotBefore บันทึกสถานะก่อนการแก้ที่แตะ nodeID กับ descendants (undo ย้อนเฉพาะส่วนนั้น)
+// เรียกหลัง lockAndLoadTree และก่อนแก้อะไร
+func (s *Service) snapshotBefore(ctx context.Context, locked *tree.Tree, userID string, action audit.Action, nodeID string) error {
+	snap, err := s.captureSnapshot(ctx, locked, userID, snapshot.Reason(action), snapshot.ScopeSubtree, &nodeID)
+	if err != nil {
+		return err
+	}
+	return s.saveSnapshot(ctx, snap)
+}
+
+// trashNode ถอด nodeID ออกจาก structure (แก้ในที่ ผู้เรียกบันทึก structure เอง)
+// แล้วย้าย node ลงถังขยะพร้อมตำแหน่งเดิม
+func (s *Service) trashNode(ctx context.Context, structure *tree.TreeStructure, nodeID, userID string) error {
+	placement := structure.Detach(nodeID)
+	if err := s.nodeRepo.MoveToTrash(ctx, nodeID, userID, placement); err != nil {
+		if errors.Is(err, node.ErrNodeNotFound) {
+			return connect.NewError(connect.CodeNotFound, err)
+		}
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	return nil
+}
+
+// childGeneration รุ่นของ node ที่จะไปอยู่ใต้ parentID (parent + 1)
+// ต้องเรียกหลังล็อก structure — รุ่นของ parent ที่อ่านก่อนเข้า transaction อาจถูก move ของคนอื่นเปลี่ยนไปแล้ว
+func (s *Service) childGeneration(ctx context.Context, parentID string) (int32, error) {
+	parent, err := s.nodeRepo.FindByID(ctx, parentID)
+	if err != nil {
+		if errors.Is(err, node.ErrNodeNotFound) {
+			return 0, connect.NewError(connect.CodeNotFound, node.ErrParentNotFound)
+		}
+		return 0, connect.NewError(connect.CodeInternal, err)
+	}
+	return parent.Generation + 1, nil
+}
+
 // recalcDescendantGenerations คำนวณรุ่นใหม่ให้ node และ descendants ทั้งหมด
 func (s *Service) recalcDescendantGenerations(ctx context.Context, nodeID string, generation int32, structure *tree.TreeStructure) error {
 	if err := s.nodeRepo.UpdateGeneration(ctx, nodeID, generation); err != nil {
@@ -578,9 +960,44 @@ func (s *Service) recalcDescendantGenerations(ctx context.Context, nodeID string
 	return nil
 }
 
-// domainToProto แปลง domain Node → proto Node
-// ดึง parent info จาก structure (ถ้ามี)
-func domainToProto(n *node.Node, structure *tree.TreeStructure) *nodev1.Node {
+// record บันทึก audit — เรียกใน transaction เดียวกับการแก้ (บันทึกไม่ได้ = ยกเลิกการแก้ทั้งหมด)
+func (s *Service) record(ctx context.Context, entries ...*audit.Entry) error {
+	if err := s.auditRepo.Record(ctx, entries...); err != nil {
+		slog.Error("failed to record audit event", "error", err)
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	return nil
+}
+
+// toConnectError คืน error เดิมถ้าเป็น connect error อยู่แล้ว (เช่นจากใน transaction)
+// ไม่งั้นห่อเป็น CodeInternal (เช่น commit ไม่ผ่าน)
+func toConnectError(err error) error {
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		return err
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
+// structureError แปลง error จาก UpdateStructure: การแก้ที่ structure ไม่ยอม (วน / node ไม่อยู่) เป็นความผิดของ request
+func structureError(err error) error {
+	switch {
+	case errors.Is(err, tree.ErrStructureCycle):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, tree.ErrNodeNotInStructure), errors.Is(err, tree.ErrNodeInStructure):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, tree.ErrRevisionConflict):
+		return connect.NewError(connect.CodeAborted, err)
+	case errors.Is(err, tree.ErrTreeNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	}
+	return toConnectError(err)
+}
+
+// domainToProto แปลง node เป็น proto ตามสิทธิ์ของ caller (level)
+// ช่องทางติดต่อที่ caller ไม่มีสิทธิ์เห็นจะเป็น "" และค่า visibility ราย node ส่งให้ editor เท่านั้น
+func domainToProto(n *node.Node, t *tree.Tree, level access.Level) *nodev1.Node {
+	contacts := access.VisibleContacts(n, t, level)
 	pn := &nodev1.Node{
 		Id:         n.ID,
 		TreeId:     n.TreeID,
@@ -593,22 +1010,32 @@ func domainToProto(n *node.Node, structure *tree.TreeStructure) *nodev1.Node {
 		Generation: n.Generation,
 		PositionX:  n.PositionX,
 		PositionY:  n.PositionY,
-		Phone:      n.Phone(),
-		Email:      n.Email(),
-		LineId:     n.LineID(),
-		Discord:    n.Discord(),
-		Facebook:   n.Facebook(),
+		Phone:      contacts[node.MetaKeyPhone],
+		Email:      contacts[node.MetaKeyEmail],
+		LineId:     contacts[node.MetaKeyLineID],
+		Discord:    contacts[node.MetaKeyDiscord],
+		Facebook:   contacts[node.MetaKeyFacebook],
 		CreatedAt:  n.CreatedAt.Format("2006-01-02T15:04:05Z"),
 		UpdatedAt:  n.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 	}
 
-	// เติม parent_ids จาก structure (multi-parent / DAG)
-	if structure != nil {
-		parentIDs := structure.FindParentIDs(n.ID)
-		if len(parentIDs) > 0 {
-			pn.ParentId = &parentIDs[0]
-			pn.ParentIds = parentIDs
+	if level.CanEdit() {
+		pn.ContactPrivacy = access.PrivacyToProto(n.ContactPrivacy())
+	}
+
+	// เติม parent_ids + ลำดับใน children ของแต่ละ parent จาก structure (multi-parent / DAG)
+	structure := &t.Structure
+	parentIDs := structure.FindParentIDs(n.ID)
+	if len(parentIDs) > 0 {
+		pn.ParentId = &parentIDs[0]
+		pn.ParentIds = parentIDs
+		pn.SiblingOrders = make(map[string]int32, len(parentIDs))
+		for _, pid := range parentIDs {
+			pn.SiblingOrders[pid] = int32(structure.SiblingIndex(pid, n.ID))
 		}
+		pn.SiblingOrder = pn.SiblingOrders[parentIDs[0]]
+	} else if idx := structure.SiblingIndex("", n.ID); idx >= 0 {
+		pn.SiblingOrder = int32(idx)
 	}
 
 	return pn
@@ -0,0 +1,499 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	nodev1 "github.com/TitleKung-01/code-tree-backend/gen/node/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/audit"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/snapshot"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/middleware"
+)
+
+const (
+	testUserID = "user-1"
+	testTreeID = "tree-1"
+)
+
+var errInjected = errors.New("injected failure")
+
+// errOutsideTx fake repo ถูกเขียนนอก transaction (การแก้หลายขั้นต้องอยู่ใน WithinTx ทั้งหมด)
+var errOutsideTx = errors.New("write outside transaction")
+
+// ==================== in-memory store ====================
+
+// memStore ข้อมูลที่ fake repo ทุกตัวใช้ร่วมกัน (เหมือน DB ก้อนเดียว)
+type memStore struct {
+	nodes     map[string]*node.Node
+	tr        *tree.Tree
+	snapshots []*snapshot.Snapshot
+	audits    []*audit.Entry
+	seq       int
+
+	// fail ชื่อ method ("nodes.Create", "trees.UpdateStructure", ...) → error ที่ต้องคืน
+	fail map[string]error
+
+	// onLock เรียกตอนล็อก structure (จำลอง transaction อื่นที่ commit ก่อนเราได้ล็อก)
+	onLock func()
+}
+
+// clone สำเนาข้อมูลทั้งหมด (ใช้เป็นจุด rollback และเทียบก่อน / หลัง)
+func (m *memStore) clone() *memStore {
+	c := &memStore{
+		nodes:     make(map[string]*node.Node, len(m.nodes)),
+		snapshots: slices.Clone(m.snapshots),
+		audits:    slices.Clone(m.audits),
+		seq:       m.seq,
+		fail:      m.fail,
+		onLock:    m.onLock,
+	}
+	for id, n := range m.nodes {
+		c.nodes[id] = copyNode(n)
+	}
+	t := *m.tr
+	t.Structure = m.tr.Structure.Clone()
+	c.tr = &t
+	return c
+}
+
+// write ตรวจก่อนเขียน: ต้องอยู่ใน transaction และไม่ได้ถูกสั่งให้พัง
+func (m *memStore) write(ctx context.Context, method string) error {
+	if _, ok := ctx.Value(txKey{}).(bool); !ok {
+		return fmt.Errorf("%s: %w", method, errOutsideTx)
+	}
+	return m.fail[method]
+}
+
+func copyNode(n *node.Node) *node.Node {
+	c := *n
+	c.Metadata = maps.Clone(n.Metadata)
+	return &c
+}
+
+// ==================== fake tx manager ====================
+
+type txKey struct{}
+
+// fakeTxm rollback = คืนค่า store เป็นสำเนาก่อนเริ่ม transaction นอกสุด
+type fakeTxm struct {
+	store *memStore
+}
+
+func (f *fakeTxm) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(bool); ok {
+		return fn(ctx)
+	}
+	backup := f.store.clone()
+	if err := fn(context.WithValue(ctx, txKey{}, true)); err != nil {
+		*f.store = *backup
+		return err
+	}
+	return nil
+}
+
+// ==================== fake repositories ====================
+
+// fake แต่ละตัว embed interface ไว้ — method ที่ service ไม่ควรเรียกจะ panic
+
+type fakeNodes struct {
+	node.Repository
+	store *memStore
+}
+
+func (f *fakeNodes) Create(ctx context.Context, n *node.Node) error {
+	if err := f.store.write(ctx, "nodes.Create"); err != nil {
+		return err
+	}
+	f.store.seq++
+	n.ID = fmt.Sprintf("new-%d", f.store.seq)
+	n.CreatedAt = time.Unix(int64(f.store.seq), 0)
+	f.store.nodes[n.ID] = copyNode(n)
+	return nil
+}
+
+func (f *fakeNodes) FindByID(_ context.Context, id string) (*node.Node, error) {
+	n, ok := f.store.nodes[id]
+	if !ok {
+		return nil, node.ErrNodeNotFound
+	}
+	return copyNode(n), nil
+}
+
+func (f *fakeNodes) FindByTreeID(_ context.Context, treeID string) ([]*node.Node, error) {
+	var out []*node.Node
+	for _, id := range slices.Sorted(maps.Keys(f.store.nodes)) {
+		if n := f.store.nodes[id]; n.TreeID == treeID {
+			out = append(out, copyNode(n))
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeNodes) UpdateGeneration(ctx context.Context, id string, generation int32) error {
+	if err := f.store.write(ctx, "nodes.UpdateGeneration"); err != nil {
+		return err
+	}
+	n, ok := f.store.nodes[id]
+	if !ok {
+		return node.ErrNodeNotFound
+	}
+	n.Generation = generation
+	return nil
+}
+
+type fakeTrees struct {
+	tree.Repository
+	store *memStore
+}
+
+func (f *fakeTrees) FindByID(_ context.Context, id string) (*tree.Tree, error) {
+	if f.store.tr.ID != id {
+		return nil, tree.ErrTreeNotFound
+	}
+	t := *f.store.tr
+	t.Structure = f.store.tr.Structure.Clone()
+	return &t, nil
+}
+
+func (f *fakeTrees) BumpStructureRevision(ctx context.Context, id string, expected *int64) (int64, error) {
+	if err := f.store.write(ctx, "trees.BumpStructureRevision"); err != nil {
+		return 0, err
+	}
+	if f.store.onLock != nil {
+		f.store.onLock()
+	}
+	if f.store.tr.ID != id {
+		return 0, tree.ErrTreeNotFound
+	}
+	if expected != nil && *expected != f.store.tr.StructureRevision {
+		return 0, tree.ErrRevisionConflict
+	}
+	f.store.tr.StructureRevision++
+	return f.store.tr.StructureRevision, nil
+}
+
+func (f *fakeTrees) UpdateStructure(ctx context.Context, id string, fn func(s *tree.TreeStructure) error) (*tree.TreeStructure, error) {
+	if err := f.store.write(ctx, "trees.UpdateStructure"); err != nil {
+		return nil, err
+	}
+	if f.store.tr.ID != id {
+		return nil, tree.ErrTreeNotFound
+	}
+	s := f.store.tr.Structure.Clone()
+	if err := fn(&s); err != nil {
+		return nil, err
+	}
+	f.store.tr.Structure = s
+	return &s, nil
+}
+
+type fakeSnapshots struct {
+	snapshot.Repository
+	store *memStore
+}
+
+func (f *fakeSnapshots) Create(ctx context.Context, s *snapshot.Snapshot) error {
+	if err := f.store.write(ctx, "snapshots.Create"); err != nil {
+		return err
+	}
+	f.store.snapshots = append(f.store.snapshots, s)
+	return nil
+}
+
+type fakeAudit struct {
+	audit.Repository
+	store *memStore
+}
+
+func (f *fakeAudit) Record(ctx context.Context, entries ...*audit.Entry) error {
+	if err := f.store.write(ctx, "audit.Record"); err != nil {
+		return err
+	}
+	f.store.audits = append(f.store.audits, entries...)
+	return nil
+}
+
+// ==================== helpers ====================
+
+// newTestService tree ของ testUserID: a → b, a → c (b, c รุ่น 2)
+func newTestService() (*Service, *memStore) {
+	st := tree.NewEmptyStructure()
+	st.RootIDs = []string{"a"}
+	st.Edges["a"] = tree.TreeStructureEdge{Children: []string{"b", "c"}}
+	st.Edges["b"] = tree.TreeStructureEdge{Children: []string{}}
+	st.Edges["c"] = tree.TreeStructureEdge{Children: []string{}}
+
+	store := &memStore{
+		nodes: map[string]*node.Node{
+			"a": {ID: "a", TreeID: testTreeID, Nickname: "a", Generation: 1},
+			"b": {ID: "b", TreeID: testTreeID, Nickname: "b", Generation: 2},
+			"c": {ID: "c", TreeID: testTreeID, Nickname: "c", Generation: 2},
+		},
+		tr: &tree.Tree{
+			ID:                testTreeID,
+			CreatedBy:         testUserID,
+			Structure:         st,
+			StructureRevision: 7,
+		},
+		fail: map[string]error{},
+	}
+	s := NewService(
+		&fakeNodes{store: store},
+		&fakeTrees{store: store},
+		nil, nil,
+		&fakeAudit{store: store},
+		&fakeSnapshots{store: store},
+		nil,
+		&fakeTxm{store: store},
+		time.Hour,
+	)
+	return s, store
+}
+
+func userCtx() context.Context {
+	return context.WithValue(context.Background(), middleware.UserIDKey, testUserID)
+}
+
+// assertUnchanged ไม่มีอะไรจากการแก้ที่พังค้างอยู่: node row, structure, revision, snapshot, audit
+func assertUnchanged(t *testing.T, before, after *memStore) {
+	t.Helper()
+	if !reflect.DeepEqual(after.nodes, before.nodes) {
+		t.Errorf("nodes changed:\n got %v\nwant %v", after.nodes, before.nodes)
+	}
+	if !reflect.DeepEqual(after.tr.Structure, before.tr.Structure) {
+		t.Errorf("structure changed:\n got %+v\nwant %+v", after.tr.Structure, before.tr.Structure)
+	}
+	if after.tr.StructureRevision != before.tr.StructureRevision {
+		t.Errorf("revision = %d, want %d", after.tr.StructureRevision, before.tr.StructureRevision)
+	}
+	if len(after.snapshots) != len(before.snapshots) {
+		t.Errorf("snapshots = %d, want %d", len(after.snapshots), len(before.snapshots))
+	}
+	if len(after.audits) != len(before.audits) {
+		t.Errorf("audit entries = %d, want %d", len(after.audits), len(before.audits))
+	}
+}
+
+// ==================== tests ====================
+
+// ทุก step ที่เขียนของ CreateNode พังได้ — พังตรงไหนก็ต้องไม่มี node ค้างหรือ structure เปลี่ยน
+func TestCreateNodeRollsBackOnFailure(t *testing.T) {
+	for _, method := range []string{
+		"trees.BumpStructureRevision",
+		"snapshots.Create",
+		"nodes.Create",
+		"trees.UpdateStructure",
+		"audit.Record",
+	} {
+		t.Run(method, func(t *testing.T) {
+			s, store := newTestService()
+			store.fail[method] = errInjected
+			before := store.clone()
+
+			_, err := s.CreateNode(userCtx(), connect.NewRequest(&nodev1.CreateNodeRequest{
+				TreeId:    testTreeID,
+				Nickname:  "new",
+				ParentIds: []string{"b", "c"},
+			}))
+			if !errors.Is(err, errInjected) {
+				t.Fatalf("err = %v, want injected failure", err)
+			}
+			assertUnchanged(t, before, store)
+		})
+	}
+}
+
+// node ถูก insert ไปแล้วแต่ structure ไม่ยอมรับ (เช่น parent หายไประหว่างทาง) = ไม่มี node ค้าง
+func TestCreateNodeRollsBackWhenStructureRejects(t *testing.T) {
+	s, store := newTestService()
+	// parent ยังมี row แต่ไม่อยู่ใน structure แล้ว
+	store.nodes["orphan"] = &node.Node{ID: "orphan", TreeID: testTreeID, Nickname: "orphan"}
+	before := store.clone()
+
+	_, err := s.CreateNode(userCtx(), connect.NewRequest(&nodev1.CreateNodeRequest{
+		TreeId:    testTreeID,
+		Nickname:  "new",
+		ParentIds: []string{"orphan"},
+	}))
+	if code := connect.CodeOf(err); code != connect.CodeFailedPrecondition {
+		t.Fatalf("code = %v, want FailedPrecondition (err %v)", code, err)
+	}
+	assertUnchanged(t, before, store)
+}
+
+func TestCreateNodeCommits(t *testing.T) {
+	s, store := newTestService()
+	res, err := s.CreateNode(userCtx(), connect.NewRequest(&nodev1.CreateNodeRequest{
+		TreeId:    testTreeID,
+		Nickname:  "new",
+		ParentIds: []string{"b", "c"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := res.Msg.Node.Id
+	if _, ok := store.nodes[id]; !ok {
+		t.Fatalf("node %s not stored", id)
+	}
+	if parents := store.tr.Structure.FindParentIDs(id); len(parents) != 2 {
+		t.Errorf("parents = %v, want [b c]", parents)
+	}
+	if store.tr.StructureRevision != 8 || res.Msg.StructureRevision != 8 {
+		t.Errorf("revision = %d (response %d), want 8", store.tr.StructureRevision, res.Msg.StructureRevision)
+	}
+	if len(store.snapshots) != 1 || len(store.audits) != 1 {
+		t.Errorf("snapshots = %d, audits = %d, want 1 each", len(store.snapshots), len(store.audits))
+	}
+}
+
+func TestMoveNodeRollsBackOnFailure(t *testing.T) {
+	for _, method := range []string{
+		"snapshots.Create",
+		"trees.UpdateStructure",
+		"nodes.UpdateGeneration", // structure เขียนไปแล้ว
+		"audit.Record",           // ทุกอย่างเขียนไปแล้ว เหลือ audit
+	} {
+		t.Run(method, func(t *testing.T) {
+			s, store := newTestService()
+			store.fail[method] = errInjected
+			before := store.clone()
+
+			_, err := s.MoveNode(userCtx(), connect.NewRequest(&nodev1.MoveNodeRequest{
+				NodeId:      "c",
+				NewParentId: "b",
+			}))
+			if !errors.Is(err, errInjected) {
+				t.Fatalf("err = %v, want injected failure", err)
+			}
+			assertUnchanged(t, before, store)
+		})
+	}
+}
+
+func TestMoveNodeCommits(t *testing.T) {
+	s, store := newTestService()
+	if _, err := s.MoveNode(userCtx(), connect.NewRequest(&nodev1.MoveNodeRequest{
+		NodeId:      "c",
+		NewParentId: "b",
+	})); err != nil {
+		t.Fatal(err)
+	}
+	if parents := store.tr.Structure.FindParentIDs("c"); !slices.Equal(parents, []string{"b"}) {
+		t.Errorf("parents of c = %v, want [b]", parents)
+	}
+	if g := store.nodes["c"].Generation; g != 3 {
+		t.Errorf("generation of c = %d, want 3", g)
+	}
+	if store.tr.StructureRevision != 8 {
+		t.Errorf("revision = %d, want 8", store.tr.StructureRevision)
+	}
+}
+
+// client ถือ revision เก่า = Aborted และไม่มีอะไรถูกเขียน
+func TestMoveNodeRevisionConflict(t *testing.T) {
+	s, store := newTestService()
+	before := store.clone()
+	stale := int64(6)
+
+	_, err := s.MoveNode(userCtx(), connect.NewRequest(&nodev1.MoveNodeRequest{
+		NodeId:           "c",
+		NewParentId:      "b",
+		ExpectedRevision: &stale,
+	}))
+	if code := connect.CodeOf(err); code != connect.CodeAborted {
+		t.Fatalf("code = %v, want Aborted (err %v)", code, err)
+	}
+	assertUnchanged(t, before, store)
+}
+
+func TestAddParentRollsBackOnFailure(t *testing.T) {
+	for _, method := range []string{
+		"snapshots.Create",
+		"trees.UpdateStructure",
+		"nodes.UpdateGeneration",
+		"audit.Record",
+	} {
+		t.Run(method, func(t *testing.T) {
+			s, store := newTestService()
+			store.fail[method] = errInjected
+			before := store.clone()
+
+			_, err := s.AddParent(userCtx(), connect.NewRequest(&nodev1.AddParentRequest{
+				NodeId:   "c",
+				ParentId: "b",
+			}))
+			if !errors.Is(err, errInjected) {
+				t.Fatalf("err = %v, want injected failure", err)
+			}
+			assertUnchanged(t, before, store)
+		})
+	}
+}
+
+func TestAddParentCommits(t *testing.T) {
+	s, store := newTestService()
+	if _, err := s.AddParent(userCtx(), connect.NewRequest(&nodev1.AddParentRequest{
+		NodeId:   "c",
+		ParentId: "b",
+	})); err != nil {
+		t.Fatal(err)
+	}
+	if parents := store.tr.Structure.FindParentIDs("c"); len(parents) != 2 {
+		t.Errorf("parents of c = %v, want [a b]", parents)
+	}
+	if g := store.nodes["c"].Generation; g != 3 {
+		t.Errorf("generation of c = %d, want 3", g)
+	}
+}
+
+// รุ่นของ parent เปลี่ยนหลังตรวจล่วงหน้าแต่ก่อนได้ล็อก (มีคนย้าย parent ไปแล้ว) → ต้องใช้ค่าล่าสุด
+func TestGenerationReadsParentAfterLock(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(s *Service) error
+	}{
+		{"MoveNode", func(s *Service) error {
+			_, err := s.MoveNode(userCtx(), connect.NewRequest(&nodev1.MoveNodeRequest{NodeId: "c", NewParentId: "b"}))
+			return err
+		}},
+		{"AddParent", func(s *Service) error {
+			_, err := s.AddParent(userCtx(), connect.NewRequest(&nodev1.AddParentRequest{NodeId: "c", ParentId: "b"}))
+			return err
+		}},
+		{"CreateNode", func(s *Service) error {
+			_, err := s.CreateNode(userCtx(), connect.NewRequest(&nodev1.CreateNodeRequest{
+				TreeId:    testTreeID,
+				Nickname:  "c2",
+				ParentIds: []string{"b"},
+			}))
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, store := newTestService()
+			store.onLock = func() { store.nodes["b"].Generation = 5 }
+			if err := tt.call(s); err != nil {
+				t.Fatal(err)
+			}
+			for _, id := range store.tr.Structure.Edges["b"].Children {
+				if g := store.nodes[id].Generation; g != 6 {
+					t.Errorf("generation of %s = %d, want 6", id, g)
+				}
+			}
+			if len(store.tr.Structure.Edges["b"].Children) != 1 {
+				t.Errorf("children of b = %v, want one", store.tr.Structure.Edges["b"].Children)
+			}
+		})
+	}
+}
@@ -0,0 +1,535 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"unicode/utf8"
+
+	"connectrpc.com/connect"
+
+	nodev1 "github.com/TitleKung-01/code-tree-backend/gen/node/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/audit"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/snapshot"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/middleware"
+	"github.com/TitleKung-01/code-tree-backend/internal/service/access"
+)
+
+const (
+	maxSnapshotLabelLength = 100
+	defaultSnapshotLimit   = 50
+	maxSnapshotLimit       = 200
+)
+
+// ==================== CreateSnapshot ====================
+
+func (s *Service) CreateSnapshot(
+	ctx context.Context,
+	req *connect.Request[nodev1.CreateSnapshotRequest],
+) (*connect.Response[nodev1.CreateSnapshotResponse], error) {
+
+	userID, t, _, err := s.loadEditableTree(ctx, req.Msg.TreeId)
+	if err != nil {
+		return nil, err
+	}
+	if utf8.RuneCountInString(req.Msg.Label) > maxSnapshotLabelLength {
+		return nil, connect.NewError(connect.CodeInvalidArgument, snapshot.ErrLabelTooLong)
+	}
+
+	var snap *snapshot.Snapshot
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		// ล็อกแบบ share: structure กับ nodes ตรงกัน โดยไม่ทำให้ revision ของคนอื่นชน
+		if err := s.treeRepo.LockStructureShared(ctx, t.ID); err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		current, err := s.treeRepo.FindByID(ctx, t.ID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		snap, err = s.captureSnapshot(ctx, current, userID, snapshot.ReasonManual, snapshot.ScopeTree, nil)
+		if err != nil {
+			return err
+		}
+		snap.Label = req.Msg.Label
+		if err := s.saveSnapshot(ctx, snap); err != nil {
+			return err
+		}
+		return s.record(ctx, &audit.Entry{
+			TreeID:    t.ID,
+			ActorID:   userID,
+			Action:    audit.ActionSnapshotCreated,
+			TargetIDs: []string{snap.ID},
+			After:     audit.FieldsSnapshot(map[string]string{"label": snap.Label}),
+		})
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&nodev1.CreateSnapshotResponse{
+		Snapshot: snapshotToProto(snap),
+	}), nil
+}
+
+// ==================== ListSnapshots ====================
+
+func (s *Service) ListSnapshots(
+	ctx context.Context,
+	req *connect.Request[nodev1.ListSnapshotsRequest],
+) (*connect.Response[nodev1.ListSnapshotsResponse], error) {
+
+	_, t, _, err := s.loadEditableTree(ctx, req.Msg.TreeId)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(req.Msg.Limit)
+	if limit <= 0 {
+		limit = defaultSnapshotLimit
+	}
+	limit = min(limit, maxSnapshotLimit)
+
+	snapshots, err := s.snapshotRepo.List(ctx, t.ID, limit)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	resp := &nodev1.ListSnapshotsResponse{
+		Snapshots: make([]*nodev1.Snapshot, len(snapshots)),
+	}
+	for i, snap := range snapshots {
+		resp.Snapshots[i] = snapshotToProto(snap)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ==================== DiffSnapshot ====================
+
+func (s *Service) DiffSnapshot(
+	ctx context.Context,
+	req *connect.Request[nodev1.DiffSnapshotRequest],
+) (*connect.Response[nodev1.DiffSnapshotResponse], error) {
+
+	_, t, _, err := s.loadEditableTree(ctx, req.Msg.TreeId)
+	if err != nil {
+		return nil, err
+	}
+
+	snap, err := s.findSnapshot(ctx, t.ID, req.Msg.SnapshotId)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := s.nodeRepo.FindByTreeID(ctx, t.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	changes := snapshot.Diff(
+		snapshot.State{Nodes: snap.Nodes, Structure: &snap.Structure},
+		snapshot.State{Nodes: snap.Comparable(&t.Structure, nodes), Structure: &t.Structure},
+	)
+
+	resp := &nodev1.DiffSnapshotResponse{
+		Changes: make([]*nodev1.SnapshotChange, len(changes)),
+	}
+	for i, c := range changes {
+		resp.Changes[i] = &nodev1.SnapshotChange{
+			NodeId:          c.NodeID,
+			Nickname:        c.Nickname,
+			Type:            changeTypeToProto(c.Type),
+			Fields:          c.Fields,
+			ParentIdsBefore: c.ParentsBefore,
+			ParentIdsAfter:  c.ParentsAfter,
+		}
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ==================== RestoreSnapshot ====================
+
+func (s *Service) RestoreSnapshot(
+	ctx context.Context,
+	req *connect.Request[nodev1.RestoreSnapshotRequest],
+) (*connect.Response[nodev1.RestoreSnapshotResponse], error) {
+
+	userID, t, level, err := s.loadEditableTree(ctx, req.Msg.TreeId)
+	if err != nil {
+		return nil, err
+	}
+	if req.Msg.SnapshotId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("snapshot_id is required"))
+	}
+
+	scope, rootID := snapshot.ScopeTree, req.Msg.GetRootNodeId()
+	var scopeNodeID *string
+	if rootID != "" {
+		scope, scopeNodeID = snapshot.ScopeSubtree, &rootID
+	}
+
+	var backup *snapshot.Snapshot
+	var result *restoreResult
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		locked, err := s.lockAndLoadTree(ctx, t.ID, req.Msg.ExpectedRevision)
+		if err != nil {
+			return err
+		}
+		snap, err := s.findSnapshot(ctx, t.ID, req.Msg.SnapshotId)
+		if err != nil {
+			return err
+		}
+		// snapshot ที่เก็บแค่บางส่วน ไม่ระบุ root = restore เท่าที่มันเก็บไว้
+		if rootID == "" && snap.Partial() {
+			scope, scopeNodeID, rootID = snap.Scope, snap.NodeID, snap.ScopeNodeID()
+		}
+
+		// เก็บสถานะก่อน restore ไว้ (undo ได้ / restore กลับได้)
+		backup, err = s.captureSnapshot(ctx, locked, userID, snapshot.ReasonRestore, scope, scopeNodeID)
+		if err != nil {
+			return err
+		}
+		plan, err := snapshot.PlanRestore(snap, &backup.Structure, backup.Nodes, scope, rootID)
+		if err != nil {
+			if errors.Is(err, snapshot.ErrNodeNotInScope) || errors.Is(err, snapshot.ErrOutsideSnapshot) {
+				return connect.NewError(connect.CodeInvalidArgument, err)
+			}
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		if err := s.saveSnapshot(ctx, backup); err != nil {
+			return err
+		}
+
+		result, err = s.applyRestorePlan(ctx, backup, plan)
+		if err != nil {
+			return err
+		}
+		return s.record(ctx, &audit.Entry{
+			TreeID:    t.ID,
+			ActorID:   userID,
+			Action:    audit.ActionSnapshotRestored,
+			NodeID:    scopeNodeID,
+			TargetIDs: plan.ChangedIDs(),
+			After: audit.FieldsSnapshot(map[string]string{
+				"snapshot_id": snap.ID,
+				"backup_id":   backup.ID,
+			}),
+		})
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	slog.Info("snapshot restored", "treeID", t.ID, "snapshotID", req.Msg.SnapshotId, "rootID", rootID)
+
+	return connect.NewResponse(&nodev1.RestoreSnapshotResponse{
+		Nodes:             result.toProto(level),
+		StructureRevision: result.tree.StructureRevision,
+		Backup:            snapshotToProto(backup),
+	}), nil
+}
+
+// ==================== Undo / Redo ====================
+
+func (s *Service) Undo(
+	ctx context.Context,
+	req *connect.Request[nodev1.UndoRequest],
+) (*connect.Response[nodev1.UndoResponse], error) {
+
+	userID, t, level, err := s.loadEditableTree(ctx, req.Msg.TreeId)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *snapshot.Snapshot
+	var result *restoreResult
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		locked, err := s.lockAndLoadTree(ctx, t.ID, req.Msg.ExpectedRevision)
+		if err != nil {
+			return err
+		}
+
+		// snapshot ก่อนการแก้ล่าสุดของ caller ที่ยังไม่ถูก undo
+		target, err = s.snapshotRepo.LatestUndoable(ctx, t.ID, userID)
+		if err != nil {
+			if errors.Is(err, snapshot.ErrSnapshotNotFound) {
+				return connect.NewError(connect.CodeFailedPrecondition, snapshot.ErrNothingToUndo)
+			}
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		// สถานะก่อน undo ขอบเขตเดียวกับ target (redo = restore ตัวนี้)
+		backup, err := s.captureSnapshot(ctx, locked, userID, snapshot.ReasonUndo, target.Scope, target.NodeID)
+		if err != nil {
+			return err
+		}
+		backup.UndoOf = &target.ID
+
+		result, err = s.restoreScoped(ctx, target, backup)
+		if err != nil {
+			return err
+		}
+		if err := s.saveSnapshot(ctx, backup); err != nil {
+			return err
+		}
+		if err := s.snapshotRepo.SetUndone(ctx, target.ID, true); err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		return s.record(ctx, &audit.Entry{
+			TreeID:    t.ID,
+			ActorID:   userID,
+			Action:    audit.ActionUndo,
+			NodeID:    target.NodeID,
+			TargetIDs: result.changed,
+			After: audit.FieldsSnapshot(map[string]string{
+				"snapshot_id": target.ID,
+				"reason":      string(target.Reason),
+			}),
+		})
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&nodev1.UndoResponse{
+		Nodes:             result.toProto(level),
+		StructureRevision: result.tree.StructureRevision,
+		UndoneReason:      string(target.Reason),
+	}), nil
+}
+
+func (s *Service) Redo(
+	ctx context.Context,
+	req *connect.Request[nodev1.RedoRequest],
+) (*connect.Response[nodev1.RedoResponse], error) {
+
+	userID, t, level, err := s.loadEditableTree(ctx, req.Msg.TreeId)
+	if err != nil {
+		return nil, err
+	}
+
+	var redone snapshot.Reason
+	var result *restoreResult
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		locked, err := s.lockAndLoadTree(ctx, t.ID, req.Msg.ExpectedRevision)
+		if err != nil {
+			return err
+		}
+
+		undo, err := s.snapshotRepo.LatestRedoable(ctx, t.ID, userID)
+		if err != nil {
+			if errors.Is(err, snapshot.ErrSnapshotNotFound) {
+				return connect.NewError(connect.CodeFailedPrecondition, snapshot.ErrNothingToRedo)
+			}
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		current, err := s.captureSnapshot(ctx, locked, userID, snapshot.ReasonUndo, undo.Scope, undo.NodeID)
+		if err != nil {
+			return err
+		}
+		result, err = s.restoreScoped(ctx, undo, current)
+		if err != nil {
+			return err
+		}
+		if err := s.snapshotRepo.SetUndone(ctx, undo.ID, true); err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		// การแก้เดิมกลับมาแล้ว → undo ได้อีกครั้ง (snapshot อาจถูกลบไปแล้วถ้าเก่ามาก)
+		if undo.UndoOf != nil {
+			target, err := s.snapshotRepo.FindByID(ctx, t.ID, *undo.UndoOf)
+			switch {
+			case err == nil:
+				redone = target.Reason
+				if err := s.snapshotRepo.SetUndone(ctx, target.ID, false); err != nil {
+					return connect.NewError(connect.CodeInternal, err)
+				}
+			case !errors.Is(err, snapshot.ErrSnapshotNotFound):
+				return connect.NewError(connect.CodeInternal, err)
+			}
+		}
+		return s.record(ctx, &audit.Entry{
+			TreeID:    t.ID,
+			ActorID:   userID,
+			Action:    audit.ActionRedo,
+			NodeID:    undo.NodeID,
+			TargetIDs: result.changed,
+			After: audit.FieldsSnapshot(map[string]string{
+				"snapshot_id": undo.ID,
+				"reason":      string(redone),
+			}),
+		})
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&nodev1.RedoResponse{
+		Nodes:             result.toProto(level),
+		StructureRevision: result.tree.StructureRevision,
+		RedoneReason:      string(redone),
+	}), nil
+}
+
+// ==================== Helpers ====================
+
+// restoreResult สถานะ tree หลัง restore สำหรับ response
+type restoreResult struct {
+	tree    *tree.Tree
+	nodes   []*node.Node
+	changed []string
+}
+
+func (r *restoreResult) toProto(level access.Level) []*nodev1.Node {
+	nodes := make([]*nodev1.Node, len(r.nodes))
+	for i, n := range r.nodes {
+		nodes[i] = domainToProto(n, r.tree, level)
+	}
+	return nodes
+}
+
+// loadEditableTree ตรวจ login + สิทธิ์แก้ tree (snapshot มีข้อมูลทุก node จึงให้ editor ขึ้นไปเท่านั้น)
+func (s *Service) loadEditableTree(ctx context.Context, treeID string) (string, *tree.Tree, access.Level, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return "", nil, access.None, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	if treeID == "" {
+		return "", nil, access.None, connect.NewError(connect.CodeInvalidArgument, node.ErrTreeIDRequired)
+	}
+
+	t, err := s.treeRepo.FindByID(ctx, treeID)
+	if err != nil {
+		if errors.Is(err, tree.ErrTreeNotFound) {
+			return "", nil, access.None, connect.NewError(connect.CodeNotFound, err)
+		}
+		return "", nil, access.None, connect.NewError(connect.CodeInternal, err)
+	}
+	level, err := s.access.RequireEdit(ctx, t, userID)
+	if err != nil {
+		return "", nil, access.None, err
+	}
+	return userID, t, level, nil
+}
+
+func (s *Service) findSnapshot(ctx context.Context, treeID, id string) (*snapshot.Snapshot, error) {
+	if id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("snapshot_id is required"))
+	}
+	snap, err := s.snapshotRepo.FindByID(ctx, treeID, id)
+	if err != nil {
+		if errors.Is(err, snapshot.ErrSnapshotNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return snap, nil
+}
+
+// captureSnapshot snapshot ของ t ที่ยังไม่บันทึก เก็บ node เฉพาะในขอบเขต scope (ดู snapshot.Capture)
+// ต้องเรียกใน transaction หลังล็อก structure — บันทึกด้วย saveSnapshot หลังการแก้ได้ (ค่าเป็นของก่อนแก้)
+func (s *Service) captureSnapshot(
+	ctx context.Context,
+	t *tree.Tree,
+	userID string,
+	reason snapshot.Reason,
+	scope snapshot.Scope,
+	nodeID *string,
+) (*snapshot.Snapshot, error) {
+	nodes, err := s.nodeRepo.FindByTreeID(ctx, t.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return snapshot.Capture(t, nodes, userID, reason, scope, nodeID), nil
+}
+
+func (s *Service) saveSnapshot(ctx context.Context, snap *snapshot.Snapshot) error {
+	if err := s.snapshotRepo.Create(ctx, snap); err != nil {
+		slog.Error("failed to save snapshot", "error", err, "treeID", snap.TreeID)
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	return nil
+}
+
+// restoreScoped restore snap ทับ current ตามขอบเขตของ snap (ใช้กับ undo / redo)
+func (s *Service) restoreScoped(ctx context.Context, snap, current *snapshot.Snapshot) (*restoreResult, error) {
+	plan, err := snapshot.PlanRestore(snap, &current.Structure, current.Nodes, snap.Scope, snap.ScopeNodeID())
+	if err != nil {
+		// เช่น node ที่ถูกแก้ถูกคนอื่นลบไปแล้ว
+		if errors.Is(err, snapshot.ErrNodeNotInScope) || errors.Is(err, snapshot.ErrOutsideSnapshot) {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return s.applyRestorePlan(ctx, current, plan)
+}
+
+// applyRestorePlan ลงถังขยะ → เขียน node กลับ → เขียน structure ทับ
+// current = สถานะก่อน restore (ต้องอยู่ใน transaction ที่ล็อก structure แล้ว)
+func (s *Service) applyRestorePlan(ctx context.Context, current *snapshot.Snapshot, plan *snapshot.Plan) (*restoreResult, error) {
+	treeID := current.TreeID
+
+	// ลงถังขยะก่อน: student_id ของ node ที่ถูกลบว่างให้ node ที่ restore กลับมา
+	// ตำแหน่งในถังขยะคิดจาก structure ก่อน restore
+	scratch := current.Structure.Clone()
+	for _, id := range plan.Deletes {
+		if err := s.trashNode(ctx, &scratch, id, current.CreatedBy); err != nil {
+			return nil, err
+		}
+	}
+	for _, n := range plan.Upserts {
+		restored := *n
+		restored.TreeID = treeID
+		if err := s.nodeRepo.Restore(ctx, &restored); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+	if err := s.treeRepo.ReplaceStructure(ctx, treeID, plan.Structure); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	t, err := s.treeRepo.FindByID(ctx, treeID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	nodes, err := s.nodeRepo.FindByTreeID(ctx, treeID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return &restoreResult{tree: t, nodes: nodes, changed: plan.ChangedIDs()}, nil
+}
+
+func snapshotToProto(snap *snapshot.Snapshot) *nodev1.Snapshot {
+	p := &nodev1.Snapshot{
+		Id:                snap.ID,
+		TreeId:            snap.TreeID,
+		CreatedBy:         snap.CreatedBy,
+		Reason:            string(snap.Reason),
+		Label:             snap.Label,
+		Scope:             string(snap.Scope),
+		NodeId:            snap.NodeID,
+		StructureRevision: snap.StructureRevision,
+		NodeCount:         snap.NodeCount,
+		CreatedAt:         snap.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if snap.UndoneAt != nil {
+		undoneAt := snap.UndoneAt.Format("2006-01-02T15:04:05Z")
+		p.UndoneAt = &undoneAt
+	}
+	return p
+}
+
+func changeTypeToProto(t snapshot.ChangeType) nodev1.SnapshotChangeType {
+	switch t {
+	case snapshot.ChangeAdded:
+		return nodev1.SnapshotChangeType_SNAPSHOT_CHANGE_TYPE_ADDED
+	case snapshot.ChangeRemoved:
+		return nodev1.SnapshotChangeType_SNAPSHOT_CHANGE_TYPE_REMOVED
+	case snapshot.ChangeModified:
+		return nodev1.SnapshotChangeType_SNAPSHOT_CHANGE_TYPE_MODIFIED
+	default:
+		return nodev1.SnapshotChangeType_SNAPSHOT_CHANGE_TYPE_UNSPECIFIED
+	}
+}
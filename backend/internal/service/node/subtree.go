@@ -0,0 +1,533 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"connectrpc.com/connect"
+
+	nodev1 "github.com/TitleKung-01/code-tree-backend/gen/node/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/audit"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/snapshot"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/middleware"
+	"github.com/TitleKung-01/code-tree-backend/internal/service/access"
+)
+
+// copyOffset ระยะเลื่อนการ์ดของสำเนาใน tree เดียวกัน (ไม่ให้ทับตัวเดิม)
+const copyOffset = 40
+
+// ==================== DeleteSubtree ====================
+
+func (s *Service) DeleteSubtree(
+	ctx context.Context,
+	req *connect.Request[nodev1.DeleteSubtreeRequest],
+) (*connect.Response[nodev1.DeleteSubtreeResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.NodeId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("node_id is required"))
+	}
+
+	root, t, _, err := s.loadEditableNode(ctx, req.Msg.NodeId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := sharedPolicyFromProto(req.Msg.SharedPolicy)
+	var sub tree.Subtree
+	var revision int64
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		locked, err := s.lockAndLoadTree(ctx, t.ID, req.Msg.ExpectedRevision)
+		if err != nil {
+			return err
+		}
+		if err := s.snapshotBefore(ctx, locked, userID, audit.ActionSubtreeDeleted, root.ID); err != nil {
+			return err
+		}
+		revision = locked.StructureRevision
+
+		sub = locked.Structure.Subtree(root.ID, policy)
+		nodes, err := s.nodeRepo.FindByTreeID(ctx, t.ID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		byID := indexNodes(nodes)
+
+		entries := make([]*audit.Entry, 0, len(sub.NodeIDs))
+		for _, id := range sub.NodeIDs {
+			n, ok := byID[id]
+			if !ok {
+				continue
+			}
+			before := audit.NodeSnapshot(n, &locked.Structure)
+			entries = append(entries, &audit.Entry{
+				TreeID:    t.ID,
+				ActorID:   userID,
+				Action:    audit.ActionSubtreeDeleted,
+				NodeID:    &n.ID,
+				TargetIDs: []string{root.ID},
+				Before:    before,
+			})
+		}
+
+		// ถอดจากล่างขึ้นบน (ย้อน pre-order): child ในสายออกก่อน parent เสมอ จึงไม่มีใครถูกย้ายขึ้นไปแทนที่
+		// shared descendant ที่ไม่อยู่ในสายยังมี parent นอกสายอยู่ เลยถูกตัดแค่เส้นจากในสาย
+		structure := locked.Structure.Clone()
+		for _, id := range slices.Backward(sub.NodeIDs) {
+			if _, ok := byID[id]; !ok {
+				continue
+			}
+			if err := s.trashNode(ctx, &structure, id, userID); err != nil {
+				return err
+			}
+		}
+		if err := s.treeRepo.ReplaceStructure(ctx, t.ID, structure); err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		return s.record(ctx, entries...)
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&nodev1.DeleteSubtreeResponse{
+		DeletedNodeIds:    sub.NodeIDs,
+		SharedNodeIds:     sub.Shared,
+		StructureRevision: revision,
+	}), nil
+}
+
+// ==================== MoveSubtree ====================
+
+func (s *Service) MoveSubtree(
+	ctx context.Context,
+	req *connect.Request[nodev1.MoveSubtreeRequest],
+) (*connect.Response[nodev1.MoveSubtreeResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.NodeId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("node_id is required"))
+	}
+	newParentID := req.Msg.GetNewParentId()
+	if newParentID == req.Msg.NodeId {
+		return nil, connect.NewError(connect.CodeInvalidArgument, node.ErrSelfParent)
+	}
+
+	root, t, level, err := s.loadEditableNode(ctx, req.Msg.NodeId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if newParentID != "" {
+		newParent, err := s.nodeRepo.FindByID(ctx, newParentID)
+		if err != nil {
+			if errors.Is(err, node.ErrNodeNotFound) {
+				return nil, connect.NewError(connect.CodeNotFound, node.ErrParentNotFound)
+			}
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		if newParent.TreeID != root.TreeID {
+			return nil, connect.NewError(connect.CodeInvalidArgument, node.ErrCrossTreeMove)
+		}
+	}
+
+	policy := sharedPolicyFromProto(req.Msg.SharedPolicy)
+	var newGen int32
+	var sub tree.Subtree
+	var moved []*node.Node
+	var updatedTree *tree.Tree
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		locked, err := s.lockAndLoadTree(ctx, t.ID, req.Msg.ExpectedRevision)
+		if err != nil {
+			return err
+		}
+		if err := s.snapshotBefore(ctx, locked, userID, audit.ActionSubtreeMoved, root.ID); err != nil {
+			return err
+		}
+
+		// ตรวจ circular จาก structure ล่าสุด (ล็อกไว้แล้ว) — parent ใหม่อยู่ในสายเดิมไม่ได้ แม้จะเป็น shared descendant
+		if newParentID != "" && locked.Structure.IsDescendant(root.ID, newParentID) {
+			return connect.NewError(connect.CodeInvalidArgument, node.ErrCircularReference)
+		}
+		if newGen, err = s.subtreeRootGeneration(ctx, root.ID, newParentID); err != nil {
+			return err
+		}
+
+		sub = locked.Structure.Subtree(root.ID, policy)
+		before := audit.NodeSnapshot(root, &locked.Structure)
+
+		// ตัดทุกเส้นที่ข้ามขอบสาย แล้วย้าย root ไปใต้ parent ใหม่
+		//   SharedLeave: เส้นจาก node ในสายไปหา shared descendant (มันอยู่กับ parent นอกสายต่อ)
+		//   SharedTake:  เส้นจาก parent นอกสายมาหา shared descendant (มันย้ายไปกับสาย)
+		structure := locked.Structure.Clone()
+		for _, id := range sub.NodeIDs {
+			for _, child := range locked.Structure.Edges[id].Children {
+				if !sub.Contains(child) {
+					structure.RemoveChild(id, child)
+				}
+			}
+			if id == root.ID {
+				continue
+			}
+			for _, pid := range locked.Structure.FindParentIDs(id) {
+				if !sub.Contains(pid) {
+					structure.RemoveChild(pid, id)
+				}
+			}
+		}
+		for _, pid := range locked.Structure.FindParentIDs(root.ID) {
+			structure.RemoveChild(pid, root.ID)
+		}
+		structure.RemoveChild("", root.ID)
+		structure.InsertChild(newParentID, root.ID, req.Msg.SiblingOrder)
+
+		if err := s.treeRepo.ReplaceStructure(ctx, t.ID, structure); err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		// คำนวณรุ่นใหม่ทั้งสาย (หลังตัดเส้นแล้ว descendants ของ root = node ในสายพอดี)
+		if err := s.recalcDescendantGenerations(ctx, root.ID, newGen, &structure); err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		updatedTree, err = s.treeRepo.FindByID(ctx, t.ID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		if moved, err = s.findNodes(ctx, t.ID, sub.NodeIDs); err != nil {
+			return err
+		}
+
+		var targets []string
+		if newParentID != "" {
+			targets = []string{newParentID}
+		}
+		after := audit.NodeSnapshot(root, &updatedTree.Structure)
+		after.Node.Generation = newGen
+		return s.record(ctx, &audit.Entry{
+			TreeID:    t.ID,
+			ActorID:   userID,
+			Action:    audit.ActionSubtreeMoved,
+			NodeID:    &root.ID,
+			TargetIDs: targets,
+			Before:    before,
+			After:     after,
+		})
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	resp := &nodev1.MoveSubtreeResponse{
+		SharedNodeIds:     sub.Shared,
+		StructureRevision: updatedTree.StructureRevision,
+	}
+	for _, n := range moved {
+		resp.Nodes = append(resp.Nodes, domainToProto(n, updatedTree, level))
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ==================== CopySubtree ====================
+
+func (s *Service) CopySubtree(
+	ctx context.Context,
+	req *connect.Request[nodev1.CopySubtreeRequest],
+) (*connect.Response[nodev1.CopySubtreeResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.NodeId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("node_id is required"))
+	}
+
+	// ต้นทางต้องแก้ได้ด้วย (สำเนามีช่องทางติดต่อทุก field ที่ viewer อาจไม่มีสิทธิ์เห็น)
+	root, src, level, err := s.loadEditableNode(ctx, req.Msg.NodeId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dst := src
+	if req.Msg.TargetTreeId != "" && req.Msg.TargetTreeId != src.ID {
+		if _, dst, level, err = s.loadEditableTree(ctx, req.Msg.TargetTreeId); err != nil {
+			return nil, err
+		}
+	}
+	sameTree := dst.ID == src.ID
+
+	newParentID := req.Msg.GetNewParentId()
+	if newParentID != "" {
+		newParent, err := s.nodeRepo.FindByID(ctx, newParentID)
+		if err != nil {
+			if errors.Is(err, node.ErrNodeNotFound) {
+				return nil, connect.NewError(connect.CodeNotFound, node.ErrParentNotFound)
+			}
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		if newParent.TreeID != dst.ID {
+			return nil, connect.NewError(connect.CodeInvalidArgument, node.ErrCrossTreeMove)
+		}
+	}
+
+	policy := sharedPolicyFromProto(req.Msg.SharedPolicy)
+	var newGen int32
+	var sub tree.Subtree
+	var copies []*node.Node
+	var cleared []string
+	idMap := map[string]string{}
+	var updatedTree *tree.Tree
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		locked, source, err := s.lockCopyTrees(ctx, src.ID, dst.ID, req.Msg.ExpectedRevision)
+		if err != nil {
+			return err
+		}
+		snap, err := s.captureSnapshot(ctx, locked, userID, snapshot.Reason(audit.ActionSubtreeCopied), snapshot.ScopeSubtree, nil)
+		if err != nil {
+			return err
+		}
+		if newGen, err = s.subtreeRootGeneration(ctx, root.ID, newParentID); err != nil {
+			return err
+		}
+
+		sub = source.Structure.Subtree(root.ID, policy)
+		srcNodes, err := s.nodeRepo.FindByTreeID(ctx, src.ID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		byID := indexNodes(srcNodes)
+
+		// student_id ห้ามซ้ำใน tree เดียวกัน: ชนกับที่มีอยู่ = สำเนาไม่มีรหัส
+		taken := map[string]bool{}
+		dstNodes := srcNodes
+		if !sameTree {
+			if dstNodes, err = s.nodeRepo.FindByTreeID(ctx, dst.ID); err != nil {
+				return connect.NewError(connect.CodeInternal, err)
+			}
+		}
+		for _, n := range dstNodes {
+			if n.StudentID != "" {
+				taken[n.StudentID] = true
+			}
+		}
+
+		for _, id := range sub.NodeIDs {
+			orig, ok := byID[id]
+			if !ok {
+				continue
+			}
+			c := orig.CopyTo(dst.ID)
+			if sameTree {
+				c.PositionX += copyOffset
+				c.PositionY += copyOffset
+			}
+			clearStudentID := c.StudentID != "" && taken[c.StudentID]
+			if clearStudentID {
+				c.StudentID = ""
+			} else if c.StudentID != "" {
+				taken[c.StudentID] = true
+			}
+			if err := s.nodeRepo.Create(ctx, c); err != nil {
+				return connect.NewError(connect.CodeInternal, err)
+			}
+			if clearStudentID {
+				cleared = append(cleared, c.ID)
+			}
+			idMap[id] = c.ID
+			copies = append(copies, c)
+		}
+		if len(copies) == 0 {
+			return connect.NewError(connect.CodeNotFound, node.ErrNodeNotFound)
+		}
+
+		// เส้นระหว่างสำเนาตามลำดับเดิม (เฉพาะเส้นภายในสาย) แล้วต่อสำเนา root เข้า tree ปลายทาง
+		structure := locked.Structure.Clone()
+		for _, id := range sub.NodeIDs {
+			copyID, ok := idMap[id]
+			if !ok {
+				continue
+			}
+			children := []string{}
+			for _, child := range source.Structure.Edges[id].Children {
+				if childCopy, ok := idMap[child]; ok {
+					children = append(children, childCopy)
+				}
+			}
+			structure.Edges[copyID] = tree.TreeStructureEdge{Children: children}
+		}
+		rootCopyID := idMap[root.ID]
+		structure.InsertChild(newParentID, rootCopyID, req.Msg.SiblingOrder)
+
+		if err := s.treeRepo.ReplaceStructure(ctx, dst.ID, structure); err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		if err := s.recalcDescendantGenerations(ctx, rootCopyID, newGen, &structure); err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		// undo = ลบสำเนาทั้งสาย (id รู้หลังสร้างเท่านั้น)
+		snap.NodeID = &rootCopyID
+		if err := s.saveSnapshot(ctx, snap); err != nil {
+			return err
+		}
+
+		updatedTree, err = s.treeRepo.FindByID(ctx, dst.ID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		copyIDs := make([]string, len(copies))
+		for i, c := range copies {
+			copyIDs[i] = c.ID
+		}
+		if copies, err = s.findNodes(ctx, dst.ID, copyIDs); err != nil {
+			return err
+		}
+
+		entries := make([]*audit.Entry, 0, len(copies))
+		for _, c := range copies {
+			entries = append(entries, &audit.Entry{
+				TreeID:    dst.ID,
+				ActorID:   userID,
+				Action:    audit.ActionSubtreeCopied,
+				NodeID:    &c.ID,
+				TargetIDs: []string{root.ID},
+				After:     audit.NodeSnapshot(c, &updatedTree.Structure),
+			})
+		}
+		return s.record(ctx, entries...)
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	resp := &nodev1.CopySubtreeResponse{
+		IdMap:             idMap,
+		SharedNodeIds:     sub.Shared,
+		ClearedStudentIds: cleared,
+		StructureRevision: updatedTree.StructureRevision,
+	}
+	for _, c := range copies {
+		resp.Nodes = append(resp.Nodes, domainToProto(c, updatedTree, level))
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// lockCopyTrees ล็อก tree ปลายทาง (แก้ structure) และ tree ต้นทางแบบ shared (อ่านอย่างเดียว)
+// ล็อกตามลำดับ id เสมอ สอง request ที่คัดลอกสวนทางกันจะไม่ deadlock
+// คืน tree ปลายทาง + tree ต้นทางล่าสุด (tree เดียวกัน = ตัวเดียวกัน)
+func (s *Service) lockCopyTrees(ctx context.Context, srcID, dstID string, expected *int64) (*tree.Tree, *tree.Tree, error) {
+	if srcID == dstID {
+		locked, err := s.lockAndLoadTree(ctx, dstID, expected)
+		return locked, locked, err
+	}
+
+	lockSource := func() error {
+		if err := s.treeRepo.LockStructureShared(ctx, srcID); err != nil {
+			if errors.Is(err, tree.ErrTreeNotFound) {
+				return connect.NewError(connect.CodeNotFound, err)
+			}
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		return nil
+	}
+	if srcID < dstID {
+		if err := lockSource(); err != nil {
+			return nil, nil, err
+		}
+	}
+	locked, err := s.lockAndLoadTree(ctx, dstID, expected)
+	if err != nil {
+		return nil, nil, err
+	}
+	if srcID > dstID {
+		if err := lockSource(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	source, err := s.treeRepo.FindByID(ctx, srcID)
+	if err != nil {
+		return nil, nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return locked, source, nil
+}
+
+// subtreeRootGeneration รุ่นของ root ของสายที่ย้าย / คัดลอก: ใต้ parent ใหม่ = parent + 1, เป็น root = รุ่นเดิมของ root
+// ต้องเรียกหลังล็อก structure (อ่านใหม่ ไม่ใช้ค่าที่อ่านไว้ก่อนเข้า transaction)
+func (s *Service) subtreeRootGeneration(ctx context.Context, rootID, newParentID string) (int32, error) {
+	if newParentID != "" {
+		return s.childGeneration(ctx, newParentID)
+	}
+	root, err := s.nodeRepo.FindByID(ctx, rootID)
+	if err != nil {
+		if errors.Is(err, node.ErrNodeNotFound) {
+			return 0, connect.NewError(connect.CodeNotFound, err)
+		}
+		return 0, connect.NewError(connect.CodeInternal, err)
+	}
+	return root.Generation, nil
+}
+
+// loadEditableNode โหลด node + tree ของมัน แล้วตรวจว่า caller แก้ tree นั้นได้
+func (s *Service) loadEditableNode(ctx context.Context, nodeID, userID string) (*node.Node, *tree.Tree, access.Level, error) {
+	n, err := s.nodeRepo.FindByID(ctx, nodeID)
+	if err != nil {
+		if errors.Is(err, node.ErrNodeNotFound) {
+			return nil, nil, access.None, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, nil, access.None, connect.NewError(connect.CodeInternal, err)
+	}
+	t, err := s.treeRepo.FindByID(ctx, n.TreeID)
+	if err != nil {
+		return nil, nil, access.None, connect.NewError(connect.CodeInternal, err)
+	}
+	level, err := s.access.RequireEdit(ctx, t, userID)
+	if err != nil {
+		return nil, nil, access.None, err
+	}
+	return n, t, level, nil
+}
+
+// findNodes โหลด node ตาม ids เรียงตามลำดับของ ids (ข้ามตัวที่ไม่มีแล้ว)
+func (s *Service) findNodes(ctx context.Context, treeID string, ids []string) ([]*node.Node, error) {
+	nodes, err := s.nodeRepo.FindByTreeID(ctx, treeID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	byID := indexNodes(nodes)
+	out := make([]*node.Node, 0, len(ids))
+	for _, id := range ids {
+		if n, ok := byID[id]; ok {
+			out = append(out, n)
+		}
+	}
+	return out, nil
+}
+
+func indexNodes(nodes []*node.Node) map[string]*node.Node {
+	byID := make(map[string]*node.Node, len(nodes))
+	for _, n := range nodes {
+		byID[n.ID] = n
+	}
+	return byID
+}
+
+func sharedPolicyFromProto(p nodev1.SharedDescendantPolicy) tree.SharedPolicy {
+	if p == nodev1.SharedDescendantPolicy_SHARED_DESCENDANT_POLICY_TAKE {
+		return tree.SharedTake
+	}
+	return tree.SharedLeave
+}
@@ -0,0 +1,213 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+
+	nodev1 "github.com/TitleKung-01/code-tree-backend/gen/node/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/audit"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/middleware"
+)
+
+// ==================== ListTrash ====================
+
+func (s *Service) ListTrash(
+	ctx context.Context,
+	req *connect.Request[nodev1.ListTrashRequest],
+) (*connect.Response[nodev1.ListTrashResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	resp := &nodev1.ListTrashResponse{}
+
+	// ไม่ระบุ tree = tree ที่ caller เป็นเจ้าของและอยู่ในถังขยะ
+	if req.Msg.TreeId == "" {
+		trees, err := s.treeRepo.ListTrash(ctx, userID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, t := range trees {
+			resp.Items = append(resp.Items, &nodev1.TrashItem{
+				Type:      nodev1.TrashItemType_TRASH_ITEM_TYPE_TREE,
+				Id:        t.ID,
+				TreeId:    t.ID,
+				Name:      t.Name,
+				DeletedBy: t.DeletedBy,
+				DeletedAt: t.DeletedAt.Format("2006-01-02T15:04:05Z"),
+				PurgeAt:   s.purgeAt(*t.DeletedAt),
+			})
+		}
+		return connect.NewResponse(resp), nil
+	}
+
+	_, t, _, err := s.loadEditableTree(ctx, req.Msg.TreeId)
+	if err != nil {
+		return nil, err
+	}
+	trashed, err := s.nodeRepo.ListTrash(ctx, t.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	for _, tn := range trashed {
+		parentIDs := make([]string, len(tn.Placement.Parents))
+		for i, slot := range tn.Placement.Parents {
+			parentIDs[i] = slot.ParentID
+		}
+		resp.Items = append(resp.Items, &nodev1.TrashItem{
+			Type:      nodev1.TrashItemType_TRASH_ITEM_TYPE_NODE,
+			Id:        tn.Node.ID,
+			TreeId:    tn.Node.TreeID,
+			Name:      tn.Node.Nickname,
+			DeletedBy: tn.DeletedBy,
+			DeletedAt: tn.DeletedAt.Format("2006-01-02T15:04:05Z"),
+			PurgeAt:   s.purgeAt(tn.DeletedAt),
+			ParentIds: parentIDs,
+		})
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ==================== RestoreFromTrash ====================
+
+func (s *Service) RestoreFromTrash(
+	ctx context.Context,
+	req *connect.Request[nodev1.RestoreFromTrashRequest],
+) (*connect.Response[nodev1.RestoreFromTrashResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id is required"))
+	}
+
+	switch req.Msg.Type {
+	case nodev1.TrashItemType_TRASH_ITEM_TYPE_TREE:
+		if err := s.restoreTree(ctx, req.Msg.Id, userID); err != nil {
+			return nil, err
+		}
+		return connect.NewResponse(&nodev1.RestoreFromTrashResponse{}), nil
+	case nodev1.TrashItemType_TRASH_ITEM_TYPE_NODE:
+		return s.restoreNode(ctx, req.Msg, userID)
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("type is required"))
+	}
+}
+
+// restoreTree กู้คืน tree ทั้งต้น (เจ้าของเท่านั้น เหมือน DeleteTree)
+func (s *Service) restoreTree(ctx context.Context, treeID, userID string) error {
+	t, err := s.treeRepo.FindTrashed(ctx, treeID)
+	if err != nil {
+		if errors.Is(err, tree.ErrTreeNotFound) {
+			return connect.NewError(connect.CodeNotFound, err)
+		}
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	// ไม่ใช่เจ้าของ = ไม่บอกว่ามี tree นี้ในถังขยะ
+	if t.CreatedBy != userID {
+		return connect.NewError(connect.CodeNotFound, tree.ErrTreeNotFound)
+	}
+
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.treeRepo.RestoreFromTrash(ctx, t.ID); err != nil {
+			if errors.Is(err, tree.ErrTreeNotFound) {
+				return connect.NewError(connect.CodeNotFound, err)
+			}
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		return s.record(ctx, &audit.Entry{
+			TreeID:  t.ID,
+			ActorID: userID,
+			Action:  audit.ActionTreeRestored,
+			After:   audit.FieldsSnapshot(map[string]string{"name": t.Name}),
+		})
+	})
+	if err != nil {
+		return toConnectError(err)
+	}
+	return nil
+}
+
+// restoreNode กู้คืน node กลับไปต่อกับ parent เดิมตามลำดับเดิม (parent ที่ไม่มีแล้วถูกข้าม)
+func (s *Service) restoreNode(
+	ctx context.Context,
+	msg *nodev1.RestoreFromTrashRequest,
+	userID string,
+) (*connect.Response[nodev1.RestoreFromTrashResponse], error) {
+	trashed, err := s.nodeRepo.FindTrashed(ctx, msg.Id)
+	if err != nil {
+		if errors.Is(err, node.ErrNodeNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	// tree ที่อยู่ในถังขยะต้องกู้คืน tree ก่อน
+	_, t, level, err := s.loadEditableTree(ctx, trashed.Node.TreeID)
+	if err != nil {
+		return nil, err
+	}
+
+	n := trashed.Node
+	var updatedTree *tree.Tree
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		locked, err := s.lockAndLoadTree(ctx, t.ID, msg.ExpectedRevision)
+		if err != nil {
+			return err
+		}
+		if err := s.snapshotBefore(ctx, locked, userID, audit.ActionNodeRestored, n.ID); err != nil {
+			return err
+		}
+
+		if err := s.nodeRepo.RestoreFromTrash(ctx, n.ID); err != nil {
+			switch {
+			case errors.Is(err, node.ErrStudentIDTaken):
+				return connect.NewError(connect.CodeAlreadyExists, err)
+			case errors.Is(err, node.ErrNodeNotFound):
+				return connect.NewError(connect.CodeNotFound, err)
+			}
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		structure := locked.Structure.Clone()
+		structure.Reattach(n.ID, trashed.Placement)
+		if err := s.treeRepo.ReplaceStructure(ctx, t.ID, structure); err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		updatedTree, err = s.treeRepo.FindByID(ctx, t.ID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		after := audit.NodeSnapshot(n, &updatedTree.Structure)
+		return s.record(ctx, &audit.Entry{
+			TreeID:    t.ID,
+			ActorID:   userID,
+			Action:    audit.ActionNodeRestored,
+			NodeID:    &n.ID,
+			TargetIDs: after.ParentIDs,
+			After:     after,
+		})
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&nodev1.RestoreFromTrashResponse{
+		Node:              domainToProto(n, updatedTree, level),
+		StructureRevision: updatedTree.StructureRevision,
+	}), nil
+}
+
+// purgeAt เวลาที่ของในถังขยะจะถูกลบจริง
+func (s *Service) purgeAt(deletedAt time.Time) string {
+	return deletedAt.Add(s.trashRetention).Format("2006-01-02T15:04:05Z")
+}
@@ -0,0 +1,154 @@
+package node
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	nodev1 "github.com/TitleKung-01/code-tree-backend/gen/node/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/event"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/middleware"
+	"github.com/TitleKung-01/code-tree-backend/internal/service/access"
+)
+
+// replayPageSize จำนวน event ที่อ่านต่อรอบตอน resume
+const replayPageSize = 500
+
+// ==================== WatchTree (server-streaming) ====================
+
+func (s *Service) WatchTree(
+	ctx context.Context,
+	req *connect.Request[nodev1.WatchTreeRequest],
+	stream *connect.ServerStream[nodev1.WatchTreeResponse],
+) error {
+
+	if req.Msg.TreeId == "" {
+		return connect.NewError(connect.CodeInvalidArgument, node.ErrTreeIDRequired)
+	}
+
+	t, err := s.treeRepo.FindByID(ctx, req.Msg.TreeId)
+	if err != nil {
+		if errors.Is(err, tree.ErrTreeNotFound) {
+			return connect.NewError(connect.CodeNotFound, err)
+		}
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	userID, _ := middleware.GetUserID(ctx)
+	level, err := s.access.RequireView(ctx, t, userID)
+	if err != nil {
+		return err
+	}
+
+	// subscribe ก่อน replay เพื่อไม่ให้ event ที่เกิดระหว่าง replay หายไป
+	events, cancel := s.broker.Subscribe(req.Msg.TreeId)
+	defer cancel()
+
+	// t อ่านก่อน subscribe อาจพลาดการแก้ช่วงนั้น → ให้โหลดใหม่ตอนใช้ครั้งแรก
+	w := &treeWatch{s: s, stream: stream, stale: true, level: level}
+
+	// seq เรียงตาม commit (ทั้งตอน replay และ NOTIFY) → seq ที่ไม่เกิน lastSent คือส่งไปแล้วแน่นอน
+	lastSent := req.Msg.LastEventId
+	if lastSent > 0 {
+		for {
+			page, err := s.eventRepo.ListAfter(ctx, req.Msg.TreeId, lastSent, replayPageSize)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, err)
+			}
+			for _, e := range page {
+				if err := w.send(ctx, e); err != nil {
+					return err
+				}
+				lastSent = e.Seq
+			}
+			if len(page) < replayPageSize {
+				break
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-events:
+			if !ok {
+				return connect.NewError(connect.CodeUnavailable, event.ErrStreamLagged)
+			}
+			// ข้าม event ที่ส่งไปแล้วตอน replay
+			if e.Seq <= lastSent {
+				continue
+			}
+			if err := w.send(ctx, e); err != nil {
+				return err
+			}
+			lastSent = e.Seq
+		}
+	}
+}
+
+// treeWatch stream ของผู้ดูหนึ่งคน: เก็บ tree ไว้ใช้ทั้ง stream โหลดใหม่เฉพาะเมื่อ structure เปลี่ยน
+type treeWatch struct {
+	s      *Service
+	stream *connect.ServerStream[nodev1.WatchTreeResponse]
+	tree   *tree.Tree
+	stale  bool // structure เปลี่ยนหลังโหลด tree ไว้ → โหลดใหม่ก่อนใช้ครั้งถัดไป
+	level  access.Level
+}
+
+// send แปลง event เป็น proto (เติมข้อมูล node ล่าสุด ตามสิทธิ์ของผู้ดู) แล้วส่งลง stream
+func (w *treeWatch) send(ctx context.Context, e *event.Event) error {
+	resp := &nodev1.WatchTreeResponse{
+		EventId:      e.Seq,
+		Type:         domainEventTypeToProto(e.Type),
+		NodeId:       e.NodeID,
+		OldParentIds: e.OldParentIDs,
+		NewParentIds: e.NewParentIDs,
+		CreatedAt:    e.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	// node_updated ไม่แตะ structure นอกนั้นเปลี่ยน parent / children ของใครสักคน
+	if e.Type != event.TypeNodeUpdated {
+		w.stale = true
+	}
+
+	if e.Type != event.TypeNodeDeleted && e.NodeID != "" {
+		n, err := w.s.nodeRepo.FindByID(ctx, e.NodeID)
+		switch {
+		case err == nil:
+			if w.stale {
+				t, err := w.s.treeRepo.FindByID(ctx, e.TreeID)
+				if err != nil {
+					return connect.NewError(connect.CodeInternal, err)
+				}
+				w.tree, w.stale = t, false
+			}
+			resp.Node = domainToProto(n, w.tree, w.level)
+		case !errors.Is(err, node.ErrNodeNotFound):
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		// node ถูกลบไปแล้ว → ส่ง event ไปโดยไม่มี node (จะมี node_deleted ตามมา)
+	}
+
+	return w.stream.Send(resp)
+}
+
+func domainEventTypeToProto(t event.Type) nodev1.TreeEventType {
+	switch t {
+	case event.TypeNodeCreated:
+		return nodev1.TreeEventType_TREE_EVENT_TYPE_NODE_CREATED
+	case event.TypeNodeUpdated:
+		return nodev1.TreeEventType_TREE_EVENT_TYPE_NODE_UPDATED
+	case event.TypeNodeDeleted:
+		return nodev1.TreeEventType_TREE_EVENT_TYPE_NODE_DELETED
+	case event.TypeNodeMoved:
+		return nodev1.TreeEventType_TREE_EVENT_TYPE_NODE_MOVED
+	case event.TypeNodeParentsChanged:
+		return nodev1.TreeEventType_TREE_EVENT_TYPE_NODE_PARENTS_CHANGED
+	default:
+		return nodev1.TreeEventType_TREE_EVENT_TYPE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,54 @@
+package preview
+
+import (
+	"sync"
+	"time"
+)
+
+// imageCache เก็บภาพที่ render แล้วในหน่วยความจำ (key รวม revision ของข้อมูลไว้แล้ว
+// ข้อมูลเปลี่ยน = key ใหม่ ภาพเก่าหมดอายุเองตาม ttl หรือถูกไล่ออกเมื่อเต็ม)
+type imageCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	max     int
+	entries map[string]cacheEntry
+	order   []string // ลำดับที่ใส่เข้า ใช้ไล่ตัวเก่าสุดออก
+}
+
+type cacheEntry struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+func newImageCache(max int, ttl time.Duration) *imageCache {
+	return &imageCache{
+		ttl:     ttl,
+		max:     max,
+		entries: make(map[string]cacheEntry, max),
+	}
+}
+
+func (c *imageCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.expiresAt) {
+		return nil, false
+	}
+	return e.data, true
+}
+
+func (c *imageCache) Put(key string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.entries[key]; !exists {
+		for len(c.order) >= c.max {
+			delete(c.entries, c.order[0])
+			c.order = c.order[1:]
+		}
+		c.order = append(c.order, key)
+	}
+	c.entries[key] = cacheEntry{data: data, expiresAt: time.Now().Add(c.ttl)}
+}
@@ -0,0 +1,153 @@
+package preview
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/render"
+)
+
+// ogThumbnailGenerations จำนวนรุ่นบนสุดที่แสดงในภาพย่อ
+const ogThumbnailGenerations = 3
+
+// ogMetadata ข้อมูลสำหรับสร้าง OG tags ของหน้า /share/{token}
+type ogMetadata struct {
+	Title           string `json:"title"`
+	Description     string `json:"description"`
+	Image           string `json:"image"`
+	ImageWidth      int    `json:"image_width"`
+	ImageHeight     int    `json:"image_height"`
+	Faculty         string `json:"faculty"`
+	Department      string `json:"department"`
+	NodeCount       int    `json:"node_count"`
+	GenerationCount int    `json:"generation_count"`
+}
+
+// ==================== Open Graph ====================
+
+// HandleOGMetadata GET /share/{token}/og — metadata สำหรับ og:title / og:description / og:image
+func (s *Service) HandleOGMetadata(w http.ResponseWriter, r *http.Request) {
+	t, nodes, ok := s.loadShared(w, r)
+	if !ok {
+		return
+	}
+
+	token := r.PathValue("token")
+	meta := ogMetadata{
+		Title:           t.Name,
+		Description:     ogDescription(t, nodes),
+		Image:           fmt.Sprintf("%s/share/%s/og.png?v=%s", s.publicURL(r), token, ogVersion(t, nodes)),
+		ImageWidth:      render.OGWidth,
+		ImageHeight:     render.OGHeight,
+		Faculty:         t.Faculty,
+		Department:      t.Department,
+		NodeCount:       len(nodes),
+		GenerationCount: countGenerations(nodes),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	json.NewEncoder(w).Encode(meta)
+}
+
+// HandleOGImage GET /share/{token}/og.png — ภาพ preview (cache ตาม version ของข้อมูล)
+func (s *Service) HandleOGImage(w http.ResponseWriter, r *http.Request) {
+	t, nodes, ok := s.loadShared(w, r)
+	if !ok {
+		return
+	}
+
+	version := ogVersion(t, nodes)
+	etag := `"` + version + `"`
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	key := t.ID + ":" + version
+	data, hit := s.ogCache.Get(key)
+	if !hit {
+		var buf bytes.Buffer
+		err := s.png.RenderCard(&buf, render.Card{
+			Title:    t.Name,
+			Subtitle: joinNonEmpty(" · ", t.Faculty, t.Department),
+			Stats:    fmt.Sprintf("%d คน · %d รุ่น", len(nodes), countGenerations(nodes)),
+			Layout:   render.Top(&t.Structure, nodes, ogThumbnailGenerations),
+		})
+		if err != nil {
+			slog.Error("failed to render og image", "treeID", t.ID, "error", err)
+			writeError(w, http.StatusInternalServerError, "failed to render preview")
+			return
+		}
+		data = buf.Bytes()
+		s.ogCache.Put(key, data)
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.Header().Set("ETag", etag)
+	w.Write(data)
+}
+
+// ==================== Helpers ====================
+
+// ogVersion hash ของสิ่งที่แสดงบนภาพ — เปลี่ยนเมื่อ tree / structure / node ใด ๆ ถูกแก้
+// ใช้เป็นทั้ง cache key และ ?v= ให้ LINE / Discord ดึงภาพใหม่
+func ogVersion(t *tree.Tree, nodes []*node.Node) string {
+	latest := t.UpdatedAt
+	for _, n := range nodes {
+		if n.UpdatedAt.After(latest) {
+			latest = n.UpdatedAt
+		}
+	}
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s|%d|%d|%d", t.ID, t.StructureRevision, len(nodes), latest.UnixNano())
+	return fmt.Sprintf("%x", h.Sum64())
+}
+
+func ogDescription(t *tree.Tree, nodes []*node.Node) string {
+	stats := fmt.Sprintf("%d คน · %d รุ่น", len(nodes), countGenerations(nodes))
+	if t.Description != "" {
+		return t.Description + " — " + stats
+	}
+	return joinNonEmpty(" · ", t.Faculty, t.Department, stats)
+}
+
+func countGenerations(nodes []*node.Node) int {
+	seen := make(map[int32]bool)
+	for _, n := range nodes {
+		seen[n.Generation] = true
+	}
+	return len(seen)
+}
+
+// publicURL URL ของ backend สำหรับลิงก์ absolute — ใช้ค่าจาก config (PUBLIC_BASE_URL)
+// ไม่เชื่อ X-Forwarded-* เพราะ client ใส่อะไรมาก็ได้ (ลิงก์จะชี้ไป host ของคนอื่น)
+// ไม่ได้ตั้งไว้ = ใช้ Host ของ request ตรงๆ (dev)
+func (s *Service) publicURL(r *http.Request) string {
+	if s.baseURL != "" {
+		return s.baseURL
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, sep)
+}
@@ -0,0 +1,144 @@
+package preview
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/share"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/render"
+	"github.com/TitleKung-01/code-tree-backend/internal/service/access"
+)
+
+// maxScale ขยาย PNG ได้สูงสุด (สำหรับพิมพ์โปสเตอร์)
+const maxScale = 4
+
+// ขนาด / อายุ cache ของภาพ OG
+const (
+	ogCacheSize = 256
+	ogCacheTTL  = time.Hour
+)
+
+// Service route HTTP สำหรับลิงก์แชร์ (/share/{token}/...) — ไม่ต้อง login ใช้ share token แทน
+type Service struct {
+	treeRepo tree.Repository
+	nodeRepo node.Repository
+	access   *access.Policy
+	png      *render.PNGRenderer
+	ogCache  *imageCache
+	baseURL  string // URL ภายนอกของ backend จาก config ("" = ใช้ Host ของ request)
+}
+
+func NewService(treeRepo tree.Repository, nodeRepo node.Repository, shareRepo share.Repository, png *render.PNGRenderer, baseURL string) *Service {
+	return &Service{
+		treeRepo: treeRepo,
+		nodeRepo: nodeRepo,
+		access:   access.NewPolicy(shareRepo),
+		png:      png,
+		ogCache:  newImageCache(ogCacheSize, ogCacheTTL),
+		baseURL:  baseURL,
+	}
+}
+
+// ==================== Render ====================
+
+// HandleRenderSVG GET /share/{token}/tree.svg
+func (s *Service) HandleRenderSVG(w http.ResponseWriter, r *http.Request) {
+	t, nodes, ok := s.loadShared(w, r)
+	if !ok {
+		return
+	}
+
+	var buf bytes.Buffer
+	layout := render.Compute(&t.Structure, nodes)
+	if err := render.WriteSVG(&buf, layout, render.Options{Title: t.Name}); err != nil {
+		slog.Error("failed to render svg", "treeID", t.ID, "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to render tree")
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	w.Write(buf.Bytes())
+}
+
+// HandleRenderPNG GET /share/{token}/tree.png?scale=2
+func (s *Service) HandleRenderPNG(w http.ResponseWriter, r *http.Request) {
+	scale := 1.0
+	if v := r.URL.Query().Get("scale"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f <= 0 || f > maxScale {
+			writeError(w, http.StatusBadRequest, "invalid scale")
+			return
+		}
+		scale = f
+	}
+
+	t, nodes, ok := s.loadShared(w, r)
+	if !ok {
+		return
+	}
+
+	var buf bytes.Buffer
+	layout := render.Compute(&t.Structure, nodes)
+	if err := s.png.Render(&buf, layout, render.Options{Title: t.Name, Scale: scale}); err != nil {
+		slog.Error("failed to render png", "treeID", t.ID, "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to render tree")
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	w.Write(buf.Bytes())
+}
+
+// ==================== Helpers ====================
+
+// loadShared หา tree จาก share token ใน path แล้วโหลด node ทั้งหมด (เขียน error ให้เองถ้าไม่เจอ)
+func (s *Service) loadShared(w http.ResponseWriter, r *http.Request) (*tree.Tree, []*node.Node, bool) {
+	t, err := s.findByToken(r.Context(), r.PathValue("token"))
+	if err != nil {
+		switch {
+		case errors.Is(err, tree.ErrTreeNotFound), errors.Is(err, share.ErrLinkNotFound):
+			writeError(w, http.StatusNotFound, "tree not found")
+		case errors.Is(err, share.ErrLinkRevoked), errors.Is(err, share.ErrLinkExpired):
+			writeError(w, http.StatusGone, err.Error())
+		default:
+			slog.Error("failed to find shared tree", "error", err)
+			writeError(w, http.StatusInternalServerError, "internal error")
+		}
+		return nil, nil, false
+	}
+
+	nodes, err := s.nodeRepo.FindByTreeID(r.Context(), t.ID)
+	if err != nil {
+		slog.Error("failed to load shared tree nodes", "treeID", t.ID, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return nil, nil, false
+	}
+	return t, nodes, true
+}
+
+// findByToken หา tree ของลิงก์แชร์ที่ยังใช้ได้
+func (s *Service) findByToken(ctx context.Context, token string) (*tree.Tree, error) {
+	link, err := s.access.OpenShareLink(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.treeRepo.FindByID(ctx, link.TreeID)
+}
+
+// writeError ตอบ error เป็น JSON {"error": msg}
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
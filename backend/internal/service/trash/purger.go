@@ -0,0 +1,62 @@
+package trash
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+)
+
+// purgeInterval ความถี่ที่ตรวจหาของหมดอายุในถังขยะ
+const purgeInterval = time.Hour
+
+// Purger ลบ tree / node ที่อยู่ในถังขยะนานเกิน retention ออกจริง
+type Purger struct {
+	treeRepo  tree.Repository
+	nodeRepo  node.Repository
+	retention time.Duration
+}
+
+func NewPurger(treeRepo tree.Repository, nodeRepo node.Repository, retention time.Duration) *Purger {
+	return &Purger{
+		treeRepo:  treeRepo,
+		nodeRepo:  nodeRepo,
+		retention: retention,
+	}
+}
+
+// Run purge ครั้งแรกทันที แล้วทุก purgeInterval จนกว่า ctx จะถูกยกเลิก
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		p.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Purger) purge(ctx context.Context) {
+	before := time.Now().Add(-p.retention)
+
+	// tree ก่อน (node ของ tree ถูกลบตามด้วย cascade)
+	trees, err := p.treeRepo.PurgeTrash(ctx, before)
+	if err != nil {
+		slog.Error("failed to purge trashed trees", "error", err)
+	}
+	nodes, err := p.nodeRepo.PurgeTrash(ctx, before)
+	if err != nil {
+		slog.Error("failed to purge trashed nodes", "error", err)
+	}
+
+	if trees > 0 || nodes > 0 {
+		slog.Info("trash purged", "trees", trees, "nodes", nodes, "before", before)
+	}
+}
@@ -0,0 +1,203 @@
+package tree
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+
+	treev1 "github.com/TitleKung-01/code-tree-backend/gen/tree/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/audit"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/privacy"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/share"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/middleware"
+)
+
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 200
+)
+
+// ==================== ListAuditEvents ====================
+
+func (s *Service) ListAuditEvents(
+	ctx context.Context,
+	req *connect.Request[treev1.ListAuditEventsRequest],
+) (*connect.Response[treev1.ListAuditEventsResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.TreeId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("tree_id is required"))
+	}
+
+	filter := audit.Filter{
+		TreeID:  req.Msg.TreeId,
+		NodeID:  req.Msg.GetNodeId(),
+		ActorID: req.Msg.GetActorId(),
+		Limit:   defaultAuditPageSize,
+	}
+	if size := int(req.Msg.PageSize); size > 0 {
+		filter.Limit = min(size, maxAuditPageSize)
+	}
+	if req.Msg.PageToken != "" {
+		filter.BeforeID, err = strconv.ParseInt(req.Msg.PageToken, 10, 64)
+		if err != nil || filter.BeforeID <= 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, audit.ErrInvalidPageToken)
+		}
+	}
+	if filter.Since, err = parseOptionalTime(req.Msg.Since); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, audit.ErrInvalidTimeRange)
+	}
+	if filter.Until, err = parseOptionalTime(req.Msg.Until); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, audit.ErrInvalidTimeRange)
+	}
+	if filter.Since != nil && filter.Until != nil && !filter.Since.Before(*filter.Until) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, audit.ErrInvalidTimeRange)
+	}
+
+	t, err := s.loadManagedTree(ctx, req.Msg.TreeId, userID)
+	if err != nil {
+		return nil, err
+	}
+	filter.TreeID = t.ID
+
+	// ขอเกิน 1 แถวไว้ดูว่ายังมีหน้าถัดไปไหม
+	filter.Limit++
+	entries, err := s.auditRepo.List(ctx, filter)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	resp := &treev1.ListAuditEventsResponse{}
+	if len(entries) == filter.Limit {
+		entries = entries[:len(entries)-1]
+		resp.NextPageToken = strconv.FormatInt(entries[len(entries)-1].ID, 10)
+	}
+	resp.Events = make([]*treev1.AuditEvent, len(entries))
+	for i, e := range entries {
+		resp.Events[i] = auditEntryToProto(e)
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// ==================== Helpers ====================
+
+// record บันทึก audit — เรียกใน transaction เดียวกับการแก้ (บันทึกไม่ได้ = ยกเลิกการแก้ทั้งหมด)
+func (s *Service) record(ctx context.Context, entries ...*audit.Entry) error {
+	if err := s.auditRepo.Record(ctx, entries...); err != nil {
+		slog.Error("failed to record audit event", "error", err)
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	return nil
+}
+
+func parseOptionalTime(s *string) (*time.Time, error) {
+	if s == nil {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, *s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func auditEntryToProto(e *audit.Entry) *treev1.AuditEvent {
+	return &treev1.AuditEvent{
+		Id:        e.ID,
+		TreeId:    e.TreeID,
+		ActorId:   e.ActorID,
+		Action:    string(e.Action),
+		NodeId:    stringPtrToString(e.NodeID),
+		TargetIds: e.TargetIDs,
+		Before:    auditSnapshotToProto(e.Before),
+		After:     auditSnapshotToProto(e.After),
+		CreatedAt: e.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+func auditSnapshotToProto(s *audit.Snapshot) *treev1.AuditSnapshot {
+	if s == nil {
+		return nil
+	}
+	p := &treev1.AuditSnapshot{
+		ParentIds: s.ParentIDs,
+		ChildIds:  s.ChildIDs,
+		Fields:    s.Fields,
+	}
+	if n := s.Node; n != nil {
+		p.Node = &treev1.AuditNodeState{
+			Nickname:   n.Nickname,
+			FirstName:  n.FirstName,
+			LastName:   n.LastName,
+			StudentId:  n.StudentID,
+			PhotoUrl:   n.PhotoURL,
+			Status:     n.Status,
+			Generation: n.Generation,
+			PositionX:  n.PositionX,
+			PositionY:  n.PositionY,
+			Metadata:   n.Metadata,
+		}
+	}
+	return p
+}
+
+func treeFields(t *tree.Tree) map[string]string {
+	fields := map[string]string{
+		"name":        t.Name,
+		"description": t.Description,
+		"faculty":     t.Faculty,
+		"department":  t.Department,
+		"created_by":  t.CreatedBy,
+	}
+	if t.ClonedFrom != nil {
+		fields["cloned_from"] = *t.ClonedFrom
+	}
+	if t.TemplateID != nil {
+		fields["template_id"] = *t.TemplateID
+	}
+	return fields
+}
+
+func privacyFields(settings privacy.Settings) map[string]string {
+	fields := make(map[string]string, len(settings))
+	for field, v := range settings {
+		fields[field] = string(v)
+	}
+	return fields
+}
+
+func roleFields(role share.Role) map[string]string {
+	return map[string]string{"role": string(role)}
+}
+
+func linkFields(l *share.Link) map[string]string {
+	fields := map[string]string{"role": string(l.Role)}
+	if l.ExpiresAt != nil {
+		fields["expires_at"] = l.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	if l.MaxUses != nil {
+		fields["max_uses"] = strconv.Itoa(int(*l.MaxUses))
+	}
+	if l.RevokedAt != nil {
+		fields["revoked_at"] = l.RevokedAt.UTC().Format(time.RFC3339)
+	}
+	return fields
+}
+
+func invitationFields(inv *share.Invitation) map[string]string {
+	return map[string]string{
+		"email":      inv.Email,
+		"role":       string(inv.Role),
+		"send_count": strconv.Itoa(int(inv.SendCount)),
+	}
+}
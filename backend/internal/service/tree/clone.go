@@ -0,0 +1,331 @@
+package tree
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"connectrpc.com/connect"
+
+	treev1 "github.com/TitleKung-01/code-tree-backend/gen/tree/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/audit"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/middleware"
+	"github.com/TitleKung-01/code-tree-backend/internal/service/access"
+)
+
+// ==================== CloneTree ====================
+
+func (s *Service) CloneTree(
+	ctx context.Context,
+	req *connect.Request[treev1.CloneTreeRequest],
+) (*connect.Response[treev1.CloneTreeResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.TreeId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("tree_id is required"))
+	}
+
+	src, err := s.repo.FindByID(ctx, req.Msg.TreeId)
+	if err != nil {
+		if errors.Is(err, tree.ErrTreeNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	// ดูได้ = clone ได้ (ช่องทางติดต่อคัดลอกเฉพาะที่ caller เห็น)
+	level, err := s.access.RequireView(ctx, src, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := req.Msg.Name
+	if name == "" {
+		name = src.Name + " (สำเนา)"
+	}
+	t := &tree.Tree{
+		Name:        name,
+		Description: src.Description,
+		Faculty:     src.Faculty,
+		Department:  src.Department,
+		CreatedBy:   userID,
+		ClonedFrom:  &src.ID,
+	}
+
+	var nodeCount int
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		// ล็อกต้นฉบับไว้อ่าน structure + nodes ให้ตรงกัน
+		if err := s.repo.LockStructureShared(ctx, src.ID); err != nil {
+			if errors.Is(err, tree.ErrTreeNotFound) {
+				return connect.NewError(connect.CodeNotFound, err)
+			}
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		source, err := s.repo.FindByID(ctx, src.ID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		nodes, err := s.nodeRepo.FindByTreeID(ctx, src.ID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		if err := s.repo.Create(ctx, t); err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		if len(source.ContactPrivacy) > 0 {
+			if err := s.repo.UpdateContactPrivacy(ctx, t.ID, source.ContactPrivacy); err != nil {
+				return connect.NewError(connect.CodeInternal, err)
+			}
+			t.ContactPrivacy = source.ContactPrivacy
+		}
+
+		ids := make(map[string]string, len(nodes))
+		for _, n := range nodes {
+			var c *node.Node
+			if req.Msg.IncludeContacts {
+				c = access.RedactContacts(n, source, level).CopyTo(t.ID)
+			} else {
+				c = n.CopyTo(t.ID)
+				c.ClearContacts()
+			}
+			if err := s.nodeRepo.Create(ctx, c); err != nil {
+				return connect.NewError(connect.CodeInternal, err)
+			}
+			ids[n.ID] = c.ID
+		}
+		nodeCount = len(ids)
+
+		if err := s.writeStructure(ctx, t, source.Structure.Remap(ids)); err != nil {
+			return err
+		}
+		return s.record(ctx, &audit.Entry{
+			TreeID:  t.ID,
+			ActorID: userID,
+			Action:  audit.ActionTreeCloned,
+			After:   audit.FieldsSnapshot(treeFields(t)),
+		})
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	slog.Info("tree cloned", "from", src.ID, "to", t.ID, "nodes", nodeCount)
+
+	proto := domainToProto(t)
+	proto.MyRole = treev1.ShareRole_SHARE_ROLE_OWNER
+	return connect.NewResponse(&treev1.CloneTreeResponse{
+		Tree:      proto,
+		NodeCount: int32(nodeCount),
+	}), nil
+}
+
+// ==================== SaveTreeAsTemplate ====================
+
+func (s *Service) SaveTreeAsTemplate(
+	ctx context.Context,
+	req *connect.Request[treev1.SaveTreeAsTemplateRequest],
+) (*connect.Response[treev1.SaveTreeAsTemplateResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.TreeId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("tree_id is required"))
+	}
+	if req.Msg.Name == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, tree.ErrTemplateNoName)
+	}
+
+	src, err := s.repo.FindByID(ctx, req.Msg.TreeId)
+	if err != nil {
+		if errors.Is(err, tree.ErrTreeNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if _, err := s.access.RequireView(ctx, src, userID); err != nil {
+		return nil, err
+	}
+
+	tmpl := &tree.Template{
+		OwnerID:     userID,
+		Name:        req.Msg.Name,
+		Description: req.Msg.Description,
+		Faculty:     src.Faculty,
+		Department:  src.Department,
+	}
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.LockStructureShared(ctx, src.ID); err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		source, err := s.repo.FindByID(ctx, src.ID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		nodes, err := s.nodeRepo.FindByTreeID(ctx, src.ID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		// เก็บแค่ตำแหน่ง + รุ่น (และชื่อเล่นถ้าขอ) ไม่มีข้อมูลอื่นของคน
+		generations := make(map[string]int32, len(nodes))
+		labels := map[string]string{}
+		for _, n := range nodes {
+			generations[n.ID] = n.Generation
+			if req.Msg.KeepNames {
+				labels[n.ID] = n.Nickname
+			}
+		}
+		tmpl.Structure, tmpl.Nodes = source.Structure.Skeleton(generations, labels)
+		if len(tmpl.Nodes) == 0 {
+			return connect.NewError(connect.CodeFailedPrecondition, tree.ErrTemplateEmpty)
+		}
+
+		if err := s.repo.CreateTemplate(ctx, tmpl); err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&treev1.SaveTreeAsTemplateResponse{
+		Template: templateToProto(tmpl),
+	}), nil
+}
+
+// ==================== ListTreeTemplates ====================
+
+func (s *Service) ListTreeTemplates(
+	ctx context.Context,
+	req *connect.Request[treev1.ListTreeTemplatesRequest],
+) (*connect.Response[treev1.ListTreeTemplatesResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	templates, err := s.repo.ListTemplates(ctx, userID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	resp := &treev1.ListTreeTemplatesResponse{}
+	for _, t := range templates {
+		resp.Templates = append(resp.Templates, templateToProto(t))
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ==================== DeleteTreeTemplate ====================
+
+func (s *Service) DeleteTreeTemplate(
+	ctx context.Context,
+	req *connect.Request[treev1.DeleteTreeTemplateRequest],
+) (*connect.Response[treev1.DeleteTreeTemplateResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id is required"))
+	}
+
+	// tree ที่เคยสร้างจาก template นี้ยังอยู่ (template_id เป็น NULL)
+	if err := s.repo.DeleteTemplate(ctx, req.Msg.Id, userID); err != nil {
+		if errors.Is(err, tree.ErrTemplateNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&treev1.DeleteTreeTemplateResponse{}), nil
+}
+
+// ==================== Helpers ====================
+
+// findOwnTemplate template ของคนอื่นตอบ NotFound เหมือนไม่มี
+func (s *Service) findOwnTemplate(ctx context.Context, id, userID string) (*tree.Template, error) {
+	tmpl, err := s.repo.FindTemplate(ctx, id)
+	if err != nil {
+		if errors.Is(err, tree.ErrTemplateNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if tmpl.OwnerID != userID {
+		return nil, connect.NewError(connect.CodeNotFound, tree.ErrTemplateNotFound)
+	}
+	return tmpl, nil
+}
+
+// instantiateTemplate สร้าง node ตามโครงของ template ใน tree ที่เพิ่งสร้าง (ต้องอยู่ใน transaction)
+// รุ่นของแต่ละตำแหน่ง = baseGeneration + offset (0 = เริ่มที่รุ่น 1)
+func (s *Service) instantiateTemplate(ctx context.Context, t *tree.Tree, tmpl *tree.Template, baseGeneration int32) (int, error) {
+	if baseGeneration <= 0 {
+		baseGeneration = 1
+	}
+
+	ids := make(map[string]string, len(tmpl.Nodes))
+	for _, tn := range tmpl.Nodes {
+		generation := baseGeneration + tn.GenerationOffset
+		nickname := tn.Label
+		if nickname == "" {
+			nickname = tree.PlaceholderLabel(generation)
+		}
+		n := &node.Node{
+			TreeID:     t.ID,
+			Nickname:   nickname,
+			Status:     node.StatusStudying,
+			Generation: generation,
+		}
+		if err := s.nodeRepo.Create(ctx, n); err != nil {
+			return 0, connect.NewError(connect.CodeInternal, err)
+		}
+		ids[tn.Key] = n.ID
+	}
+
+	if err := s.writeStructure(ctx, t, tmpl.Structure.Remap(ids)); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+// writeStructure เขียน structure ทั้งก้อนให้ tree ใหม่ แล้วอัพเดท t ให้ตรงกับ DB
+func (s *Service) writeStructure(ctx context.Context, t *tree.Tree, structure tree.TreeStructure) error {
+	revision, err := s.repo.BumpStructureRevision(ctx, t.ID, nil)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if err := s.repo.ReplaceStructure(ctx, t.ID, structure); err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	t.Structure = structure
+	t.StructureRevision = revision
+	return nil
+}
+
+func templateToProto(t *tree.Template) *treev1.TreeTemplate {
+	return &treev1.TreeTemplate{
+		Id:              t.ID,
+		Name:            t.Name,
+		Description:     t.Description,
+		Faculty:         t.Faculty,
+		Department:      t.Department,
+		NodeCount:       int32(len(t.Nodes)),
+		GenerationCount: t.Generations(),
+		CreatedAt:       t.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
@@ -0,0 +1,64 @@
+package tree
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	treev1 "github.com/TitleKung-01/code-tree-backend/gen/tree/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/middleware"
+)
+
+// ==================== RepairTreeStructure ====================
+
+func (s *Service) RepairTreeStructure(
+	ctx context.Context,
+	req *connect.Request[treev1.RepairTreeStructureRequest],
+) (*connect.Response[treev1.RepairTreeStructureResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.TreeId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("tree_id is required"))
+	}
+
+	t, err := s.loadManagedTree(ctx, req.Msg.TreeId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.integrity.CheckTree(ctx, t.ID, !req.Msg.DryRun, userID)
+	if err != nil {
+		if errors.Is(err, tree.ErrTreeNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	resp := &treev1.RepairTreeStructureResponse{
+		Issues:            make([]*treev1.StructureIssue, len(res.Issues)),
+		Repaired:          res.Repaired,
+		StructureRevision: res.Revision,
+	}
+	for i, issue := range res.Issues {
+		resp.Issues[i] = issueToProto(issue)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func issueToProto(i tree.Issue) *treev1.StructureIssue {
+	p := &treev1.StructureIssue{
+		Kind:        string(i.Kind),
+		NodeId:      i.NodeID,
+		Description: i.String(),
+	}
+	if i.ParentID != "" {
+		p.ParentId = &i.ParentID
+	}
+	return p
+}
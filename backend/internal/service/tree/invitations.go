@@ -0,0 +1,236 @@
+package tree
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"connectrpc.com/connect"
+
+	treev1 "github.com/TitleKung-01/code-tree-backend/gen/tree/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/audit"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/share"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/middleware"
+)
+
+// minResendInterval ส่ง email เชิญซ้ำได้ไม่ถี่กว่านี้
+const minResendInterval = time.Minute
+
+// inviteByEmail เก็บคำเชิญให้ email ที่ยังไม่มีบัญชี แล้วลองส่ง email เชิญ (ส่งไม่ผ่านก็ยังเก็บคำเชิญไว้)
+func (s *Service) inviteByEmail(
+	ctx context.Context,
+	t *tree.Tree,
+	email string,
+	role share.Role,
+	userID string,
+) (*connect.Response[treev1.ShareTreeResponse], error) {
+
+	inv := &share.Invitation{
+		TreeID:    t.ID,
+		Email:     email,
+		Role:      role,
+		InvitedBy: &userID,
+	}
+	err := s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.shareRepo.CreateInvitation(ctx, inv); err != nil {
+			if errors.Is(err, share.ErrAlreadyInvited) {
+				return connect.NewError(connect.CodeAlreadyExists, err)
+			}
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		return s.record(ctx, &audit.Entry{
+			TreeID:    t.ID,
+			ActorID:   userID,
+			Action:    audit.ActionInvitationCreated,
+			TargetIDs: []string{inv.ID},
+			After:     audit.FieldsSnapshot(invitationFields(inv)),
+		})
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	if s.invites != nil {
+		if sent, err := s.deliverInvitation(ctx, inv); err != nil {
+			slog.Warn("failed to send invitation email", "invitationID", inv.ID, "error", err)
+		} else {
+			inv = sent
+		}
+	}
+
+	return connect.NewResponse(&treev1.ShareTreeResponse{
+		Invitation: invitationToProto(inv),
+	}), nil
+}
+
+// deliverInvitation ส่ง email เชิญแล้วนับครั้งที่ส่ง
+func (s *Service) deliverInvitation(ctx context.Context, inv *share.Invitation) (*share.Invitation, error) {
+	if err := s.invites.SendInvite(ctx, inv.Email); err != nil {
+		return nil, err
+	}
+	return s.shareRepo.MarkInvitationSent(ctx, inv.TreeID, inv.ID)
+}
+
+// ==================== ListTreeInvitations ====================
+
+func (s *Service) ListTreeInvitations(
+	ctx context.Context,
+	req *connect.Request[treev1.ListTreeInvitationsRequest],
+) (*connect.Response[treev1.ListTreeInvitationsResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.TreeId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("tree_id is required"))
+	}
+
+	t, err := s.loadManagedTree(ctx, req.Msg.TreeId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.shareRepo.ListInvitations(ctx, t.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	protoInvitations := make([]*treev1.TreeInvitation, len(invitations))
+	for i, inv := range invitations {
+		protoInvitations[i] = invitationToProto(inv)
+	}
+
+	return connect.NewResponse(&treev1.ListTreeInvitationsResponse{
+		Invitations: protoInvitations,
+	}), nil
+}
+
+// ==================== ResendTreeInvitation ====================
+
+func (s *Service) ResendTreeInvitation(
+	ctx context.Context,
+	req *connect.Request[treev1.ResendTreeInvitationRequest],
+) (*connect.Response[treev1.ResendTreeInvitationResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.TreeId == "" || req.Msg.InvitationId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("tree_id and invitation_id are required"))
+	}
+
+	if s.invites == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, share.ErrInviteEmailOff)
+	}
+
+	t, err := s.loadManagedTree(ctx, req.Msg.TreeId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	inv, err := s.shareRepo.FindInvitation(ctx, t.ID, req.Msg.InvitationId)
+	if err != nil {
+		if errors.Is(err, share.ErrInvitationNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if inv.LastSentAt != nil && time.Since(*inv.LastSentAt) < minResendInterval {
+		return nil, connect.NewError(connect.CodeResourceExhausted, share.ErrResendTooSoon)
+	}
+
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		before := invitationFields(inv)
+		inv, err = s.deliverInvitation(ctx, inv)
+		if err != nil {
+			slog.Error("failed to resend invitation email", "invitationID", req.Msg.InvitationId, "error", err)
+			return connect.NewError(connect.CodeUnavailable, err)
+		}
+		return s.record(ctx, &audit.Entry{
+			TreeID:    t.ID,
+			ActorID:   userID,
+			Action:    audit.ActionInvitationResent,
+			TargetIDs: []string{inv.ID},
+			Before:    audit.FieldsSnapshot(before),
+			After:     audit.FieldsSnapshot(invitationFields(inv)),
+		})
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&treev1.ResendTreeInvitationResponse{
+		Invitation: invitationToProto(inv),
+	}), nil
+}
+
+// ==================== CancelTreeInvitation ====================
+
+func (s *Service) CancelTreeInvitation(
+	ctx context.Context,
+	req *connect.Request[treev1.CancelTreeInvitationRequest],
+) (*connect.Response[treev1.CancelTreeInvitationResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.TreeId == "" || req.Msg.InvitationId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("tree_id and invitation_id are required"))
+	}
+
+	t, err := s.loadManagedTree(ctx, req.Msg.TreeId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		inv, err := s.shareRepo.FindInvitation(ctx, t.ID, req.Msg.InvitationId)
+		if err != nil {
+			if errors.Is(err, share.ErrInvitationNotFound) {
+				return connect.NewError(connect.CodeNotFound, err)
+			}
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		if err := s.shareRepo.DeleteInvitation(ctx, t.ID, inv.ID); err != nil {
+			if errors.Is(err, share.ErrInvitationNotFound) {
+				return connect.NewError(connect.CodeNotFound, err)
+			}
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		return s.record(ctx, &audit.Entry{
+			TreeID:    t.ID,
+			ActorID:   userID,
+			Action:    audit.ActionInvitationCancelled,
+			TargetIDs: []string{inv.ID},
+			Before:    audit.FieldsSnapshot(invitationFields(inv)),
+		})
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&treev1.CancelTreeInvitationResponse{}), nil
+}
+
+func invitationToProto(inv *share.Invitation) *treev1.TreeInvitation {
+	return &treev1.TreeInvitation{
+		Id:         inv.ID,
+		TreeId:     inv.TreeID,
+		Email:      inv.Email,
+		Role:       domainRoleToProto(inv.Role),
+		InvitedBy:  stringPtrToString(inv.InvitedBy),
+		SendCount:  inv.SendCount,
+		LastSentAt: formatTime(inv.LastSentAt),
+		CreatedAt:  inv.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
@@ -0,0 +1,473 @@
+package tree
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"connectrpc.com/connect"
+
+	treev1 "github.com/TitleKung-01/code-tree-backend/gen/tree/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/audit"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/share"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/middleware"
+	"github.com/TitleKung-01/code-tree-backend/internal/service/access"
+)
+
+// ==================== GenerateShareLink ====================
+
+func (s *Service) GenerateShareLink(
+	ctx context.Context,
+	req *connect.Request[treev1.GenerateShareLinkRequest],
+) (*connect.Response[treev1.GenerateShareLinkResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.TreeId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("tree_id is required"))
+	}
+
+	role := protoLinkRoleToDomain(req.Msg.Role)
+	if !role.IsValid() {
+		return nil, connect.NewError(connect.CodeInvalidArgument, share.ErrInvalidLinkRole)
+	}
+
+	var expiresAt *time.Time
+	if req.Msg.ExpiresAt != nil {
+		at, err := time.Parse(time.RFC3339, *req.Msg.ExpiresAt)
+		if err != nil || !at.After(time.Now()) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, share.ErrInvalidExpiry)
+		}
+		expiresAt = &at
+	}
+
+	if req.Msg.MaxUses != nil {
+		if _, joinable := role.JoinRole(); !joinable || *req.Msg.MaxUses <= 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, share.ErrInvalidMaxUses)
+		}
+	}
+
+	t, err := s.loadManagedTree(ctx, req.Msg.TreeId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// ไม่ได้ตั้งอะไรเลย = ใช้ลิงก์ดูอย่างเดียวแบบถาวรตัวเดิม (เหมือนพฤติกรรมเดิมก่อนมีหลายลิงก์)
+	plain := req.Msg.Role == treev1.ShareLinkRole_SHARE_LINK_ROLE_UNSPECIFIED &&
+		req.Msg.ExpiresAt == nil && req.Msg.MaxUses == nil
+
+	var link *share.Link
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		if plain {
+			links, err := s.shareRepo.ListLinks(ctx, t.ID)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, err)
+			}
+			for _, l := range links {
+				if l.Role == share.LinkRoleView && l.ExpiresAt == nil && l.RevokedAt == nil {
+					link = l
+					return nil
+				}
+			}
+		}
+
+		link = &share.Link{
+			TreeID:    t.ID,
+			Role:      role,
+			ExpiresAt: expiresAt,
+			MaxUses:   req.Msg.MaxUses,
+			CreatedBy: userID,
+		}
+		if err := s.shareRepo.CreateLink(ctx, link); err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		return s.record(ctx, &audit.Entry{
+			TreeID:    t.ID,
+			ActorID:   userID,
+			Action:    audit.ActionLinkCreated,
+			TargetIDs: []string{link.ID},
+			After:     audit.FieldsSnapshot(linkFields(link)),
+		})
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&treev1.GenerateShareLinkResponse{
+		ShareToken: link.Token,
+		ShareUrl:   shareURL(link),
+		Link:       linkToProto(link, time.Now()),
+	}), nil
+}
+
+// ==================== ListShareLinks ====================
+
+func (s *Service) ListShareLinks(
+	ctx context.Context,
+	req *connect.Request[treev1.ListShareLinksRequest],
+) (*connect.Response[treev1.ListShareLinksResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.TreeId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("tree_id is required"))
+	}
+
+	t, err := s.loadManagedTree(ctx, req.Msg.TreeId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.shareRepo.ListLinks(ctx, t.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	now := time.Now()
+	protoLinks := make([]*treev1.ShareLink, len(links))
+	for i, l := range links {
+		protoLinks[i] = linkToProto(l, now)
+	}
+
+	return connect.NewResponse(&treev1.ListShareLinksResponse{
+		Links: protoLinks,
+	}), nil
+}
+
+// ==================== RevokeShareLink ====================
+
+func (s *Service) RevokeShareLink(
+	ctx context.Context,
+	req *connect.Request[treev1.RevokeShareLinkRequest],
+) (*connect.Response[treev1.RevokeShareLinkResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.TreeId == "" || req.Msg.LinkId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("tree_id and link_id are required"))
+	}
+
+	t, err := s.loadManagedTree(ctx, req.Msg.TreeId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var link *share.Link
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.shareRepo.FindLinkByID(ctx, t.ID, req.Msg.LinkId)
+		if err != nil {
+			return access.LinkError(err)
+		}
+
+		link, err = s.shareRepo.RevokeLink(ctx, t.ID, req.Msg.LinkId)
+		if err != nil {
+			return access.LinkError(err)
+		}
+		return s.record(ctx, &audit.Entry{
+			TreeID:    t.ID,
+			ActorID:   userID,
+			Action:    audit.ActionLinkRevoked,
+			TargetIDs: []string{link.ID},
+			Before:    audit.FieldsSnapshot(linkFields(before)),
+			After:     audit.FieldsSnapshot(linkFields(link)),
+		})
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&treev1.RevokeShareLinkResponse{
+		Link: linkToProto(link, time.Now()),
+	}), nil
+}
+
+// ==================== RotateShareLink ====================
+
+func (s *Service) RotateShareLink(
+	ctx context.Context,
+	req *connect.Request[treev1.RotateShareLinkRequest],
+) (*connect.Response[treev1.RotateShareLinkResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.TreeId == "" || req.Msg.LinkId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("tree_id and link_id are required"))
+	}
+
+	t, err := s.loadManagedTree(ctx, req.Msg.TreeId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// ยกเลิกตัวเดิม + สร้างตัวใหม่ใน transaction เดียว (ไม่มีช่วงที่ไม่มีลิงก์ / มีสองลิงก์)
+	var link *share.Link
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		old, err := s.shareRepo.RevokeLink(ctx, t.ID, req.Msg.LinkId)
+		if err != nil {
+			return access.LinkError(err)
+		}
+
+		link = &share.Link{
+			TreeID:    t.ID,
+			Role:      old.Role,
+			ExpiresAt: old.ExpiresAt,
+			MaxUses:   old.MaxUses,
+			CreatedBy: userID,
+		}
+		if err := s.shareRepo.CreateLink(ctx, link); err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		return s.record(ctx, &audit.Entry{
+			TreeID:    t.ID,
+			ActorID:   userID,
+			Action:    audit.ActionLinkRotated,
+			TargetIDs: []string{old.ID, link.ID},
+			Before:    audit.FieldsSnapshot(linkFields(old)),
+			After:     audit.FieldsSnapshot(linkFields(link)),
+		})
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	slog.Info("share link rotated", "treeID", t.ID, "oldLinkID", req.Msg.LinkId, "newLinkID", link.ID)
+
+	return connect.NewResponse(&treev1.RotateShareLinkResponse{
+		Link: linkToProto(link, time.Now()),
+	}), nil
+}
+
+// ==================== JoinShareLink ====================
+
+func (s *Service) JoinShareLink(
+	ctx context.Context,
+	req *connect.Request[treev1.JoinShareLinkRequest],
+) (*connect.Response[treev1.JoinShareLinkResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.ShareToken == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("share_token is required"))
+	}
+
+	link, err := s.access.RequireShareLink(ctx, req.Msg.ShareToken)
+	if err != nil {
+		return nil, err
+	}
+	role, joinable := link.Role.JoinRole()
+	if !joinable {
+		return nil, access.LinkError(share.ErrLinkNotJoinable)
+	}
+
+	t, err := s.repo.FindByID(ctx, link.TreeID)
+	if err != nil {
+		if errors.Is(err, tree.ErrTreeNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	current, err := s.access.Resolve(ctx, t, userID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	joined := access.Evaluate(t, userID, &role)
+
+	// มีสิทธิ์เท่าหรือมากกว่าที่ลิงก์ให้อยู่แล้ว → ไม่นับการใช้ลิงก์
+	if current < joined {
+		err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+			if err := s.shareRepo.UseLink(ctx, link.ID); err != nil {
+				return access.LinkError(err)
+			}
+
+			entry := &audit.Entry{
+				TreeID:    t.ID,
+				ActorID:   userID,
+				Action:    audit.ActionLinkJoined,
+				TargetIDs: []string{link.ID},
+				After:     audit.FieldsSnapshot(roleFields(role)),
+			}
+
+			if current.IsMember() {
+				before, err := s.shareRepo.GetUserRole(ctx, t.ID, userID)
+				if err != nil {
+					return connect.NewError(connect.CodeInternal, err)
+				}
+				if _, err := s.shareRepo.UpdateRole(ctx, t.ID, userID, role); err != nil {
+					return connect.NewError(connect.CodeInternal, err)
+				}
+				entry.Before = audit.FieldsSnapshot(roleFields(before))
+				return s.record(ctx, entry)
+			}
+
+			// คนสร้างลิงก์ถูกลบไปแล้ว = ไม่มีผู้เชิญ
+			var invitedBy *string
+			if link.CreatedBy != "" {
+				invitedBy = &link.CreatedBy
+			}
+			ts := &share.TreeShare{
+				TreeID:    t.ID,
+				UserID:    userID,
+				Role:      role,
+				InvitedBy: invitedBy,
+			}
+			if err := s.shareRepo.Create(ctx, ts); err != nil {
+				if errors.Is(err, share.ErrAlreadyShared) {
+					return connect.NewError(connect.CodeAlreadyExists, err)
+				}
+				return connect.NewError(connect.CodeInternal, err)
+			}
+			return s.record(ctx, entry)
+		})
+		if err != nil {
+			return nil, toConnectError(err)
+		}
+
+		slog.Info("joined tree via share link", "treeID", t.ID, "userID", userID, "role", role)
+		current = joined
+	}
+
+	proto := domainToProto(t)
+	proto.MyRole = levelToProto(current)
+
+	return connect.NewResponse(&treev1.JoinShareLinkResponse{
+		Tree: proto,
+	}), nil
+}
+
+// ==================== GetTreeByShareToken ====================
+
+func (s *Service) GetTreeByShareToken(
+	ctx context.Context,
+	req *connect.Request[treev1.GetTreeByShareTokenRequest],
+) (*connect.Response[treev1.GetTreeByShareTokenResponse], error) {
+
+	if req.Msg.ShareToken == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("share_token is required"))
+	}
+
+	// ลิงก์ที่ถูกยกเลิก / หมดอายุใช้ไม่ได้
+	link, err := s.access.RequireShareLink(ctx, req.Msg.ShareToken)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.repo.FindByID(ctx, link.TreeID)
+	if err != nil {
+		if errors.Is(err, tree.ErrTreeNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	userID, _ := middleware.GetUserID(ctx)
+	level, err := s.access.ResolveShareLink(ctx, t, userID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	proto := domainToProto(t)
+	proto.MyRole = levelToProto(level)
+
+	return connect.NewResponse(&treev1.GetTreeByShareTokenResponse{
+		Tree:          proto,
+		LinkRole:      linkRoleToProto(link.Role),
+		LinkExpiresAt: formatTime(link.ExpiresAt),
+	}), nil
+}
+
+// ==================== Link helpers ====================
+
+// loadManagedTree โหลด tree ที่ user จัดการลิงก์แชร์ได้ (creator / co-owner)
+func (s *Service) loadManagedTree(ctx context.Context, treeID, userID string) (*tree.Tree, error) {
+	t, err := s.repo.FindByID(ctx, treeID)
+	if err != nil {
+		if errors.Is(err, tree.ErrTreeNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	level, err := s.access.RequireView(ctx, t, userID)
+	if err != nil {
+		return nil, err
+	}
+	if level != access.Owner {
+		return nil, connect.NewError(connect.CodePermissionDenied, share.ErrNotShareOwner)
+	}
+	return t, nil
+}
+
+func shareURL(l *share.Link) string {
+	return "/share/" + l.Token
+}
+
+func linkToProto(l *share.Link, now time.Time) *treev1.ShareLink {
+	_, joinable := l.Role.JoinRole()
+	return &treev1.ShareLink{
+		Id:        l.ID,
+		TreeId:    l.TreeID,
+		Token:     l.Token,
+		ShareUrl:  shareURL(l),
+		Role:      linkRoleToProto(l.Role),
+		ExpiresAt: formatTime(l.ExpiresAt),
+		MaxUses:   l.MaxUses,
+		UseCount:  l.UseCount,
+		CreatedBy: l.CreatedBy,
+		RevokedAt: formatTime(l.RevokedAt),
+		CreatedAt: l.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		Active:    l.Check(now) == nil && !(joinable && l.Exhausted()),
+	}
+}
+
+func linkRoleToProto(r share.LinkRole) treev1.ShareLinkRole {
+	switch r {
+	case share.LinkRoleView:
+		return treev1.ShareLinkRole_SHARE_LINK_ROLE_VIEW
+	case share.LinkRoleJoinViewer:
+		return treev1.ShareLinkRole_SHARE_LINK_ROLE_JOIN_VIEWER
+	case share.LinkRoleJoinEditor:
+		return treev1.ShareLinkRole_SHARE_LINK_ROLE_JOIN_EDITOR
+	default:
+		return treev1.ShareLinkRole_SHARE_LINK_ROLE_UNSPECIFIED
+	}
+}
+
+// protoLinkRoleToDomain UNSPECIFIED = ดูอย่างเดียว
+func protoLinkRoleToDomain(r treev1.ShareLinkRole) share.LinkRole {
+	switch r {
+	case treev1.ShareLinkRole_SHARE_LINK_ROLE_UNSPECIFIED, treev1.ShareLinkRole_SHARE_LINK_ROLE_VIEW:
+		return share.LinkRoleView
+	case treev1.ShareLinkRole_SHARE_LINK_ROLE_JOIN_VIEWER:
+		return share.LinkRoleJoinViewer
+	case treev1.ShareLinkRole_SHARE_LINK_ROLE_JOIN_EDITOR:
+		return share.LinkRoleJoinEditor
+	default:
+		return ""
+	}
+}
+
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.UTC().Format("2006-01-02T15:04:05Z")
+	return &s
+}
@@ -0,0 +1,108 @@
+package tree
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+
+	treev1 "github.com/TitleKung-01/code-tree-backend/gen/tree/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/middleware"
+)
+
+const (
+	defaultTreePageSize = 50
+	maxTreePageSize     = 200
+)
+
+// ==================== ListAccessibleTrees ====================
+
+func (s *Service) ListAccessibleTrees(
+	ctx context.Context,
+	req *connect.Request[treev1.ListAccessibleTreesRequest],
+) (*connect.Response[treev1.ListAccessibleTreesResponse], error) {
+
+	trees, next, err := s.listTrees(ctx, tree.ScopeAccessible, req.Msg.Options)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&treev1.ListAccessibleTreesResponse{
+		Trees:         trees,
+		NextPageToken: next,
+	}), nil
+}
+
+// ==================== Helpers ====================
+
+// listTrees list tree ของ caller ตาม scope (ใช้ summary ไม่โหลด structure) คืน proto + next page token
+func (s *Service) listTrees(ctx context.Context, scope tree.ListScope, opts *treev1.TreeListOptions) ([]*treev1.Tree, string, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, "", connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	q := tree.ListQuery{
+		UserID:     userID,
+		Scope:      scope,
+		Faculty:    opts.GetFaculty(),
+		Department: opts.GetDepartment(),
+		Sort:       sortFieldFromProto(opts.GetSortBy()),
+		Reverse:    opts.GetReverse(),
+	}
+	// ไม่ส่ง options = client เดิมที่ยังไม่รู้จัก page token → คืนทั้งหมดเหมือนก่อนมี pagination
+	if opts != nil {
+		q.Limit = defaultTreePageSize
+		if size := int(opts.GetPageSize()); size > 0 {
+			q.Limit = min(size, maxTreePageSize)
+		}
+	}
+	for _, role := range opts.GetRoles() {
+		if r := protoRoleToDomain(role); r != "" {
+			q.Roles = append(q.Roles, r)
+		}
+	}
+	if token := opts.GetPageToken(); token != "" {
+		q.After, err = tree.DecodeCursor(token, q.Sort, q.Reverse)
+		if err != nil {
+			return nil, "", connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+
+	// ขอเกิน 1 แถวไว้ดูว่ายังมีหน้าถัดไปไหม
+	paged := q.Limit > 0
+	if paged {
+		q.Limit++
+	}
+	summaries, err := s.repo.ListSummaries(ctx, q)
+	if err != nil {
+		return nil, "", connect.NewError(connect.CodeInternal, err)
+	}
+
+	var next string
+	if paged && len(summaries) == q.Limit {
+		summaries = summaries[:len(summaries)-1]
+		next = summaries[len(summaries)-1].Cursor(q.Sort, q.Reverse).Encode()
+	}
+
+	trees := make([]*treev1.Tree, len(summaries))
+	for i, sum := range summaries {
+		proto := domainToProto(sum.Tree)
+		proto.MyRole = domainRoleToProto(sum.Role)
+		proto.NodeCount = int32(sum.NodeCount)
+		trees[i] = proto
+	}
+	return trees, next, nil
+}
+
+func sortFieldFromProto(f treev1.TreeSortField) tree.SortField {
+	switch f {
+	case treev1.TreeSortField_TREE_SORT_FIELD_CREATED_AT:
+		return tree.SortCreatedAt
+	case treev1.TreeSortField_TREE_SORT_FIELD_NAME:
+		return tree.SortName
+	case treev1.TreeSortField_TREE_SORT_FIELD_NODE_COUNT:
+		return tree.SortNodeCount
+	default:
+		return tree.SortUpdatedAt
+	}
+}
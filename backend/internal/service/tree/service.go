@@ -4,22 +4,43 @@ import (
     "context"
     "errors"
     "log/slog"
+    "strings"
 
     "connectrpc.com/connect"
 
     treev1 "github.com/TitleKung-01/code-tree-backend/gen/tree/v1"
+    "github.com/TitleKung-01/code-tree-backend/internal/domain/audit"
+    "github.com/TitleKung-01/code-tree-backend/internal/domain/node"
     "github.com/TitleKung-01/code-tree-backend/internal/domain/share"
     "github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+    "github.com/TitleKung-01/code-tree-backend/internal/domain/tx"
     "github.com/TitleKung-01/code-tree-backend/internal/middleware"
+    "github.com/TitleKung-01/code-tree-backend/internal/service/access"
+    "github.com/TitleKung-01/code-tree-backend/internal/service/integrity"
 )
 
 type Service struct {
     repo      tree.Repository
+    nodeRepo  node.Repository // ใช้ตอน clone / สร้าง tree จาก template
     shareRepo share.Repository
+    auditRepo audit.Repository
+    invites   share.InviteSender // nil = ไม่ส่ง email เชิญ (เก็บคำเชิญไว้อย่างเดียว)
+    txm       tx.Manager
+    access    *access.Policy
+    integrity *integrity.Checker
 }
 
-func NewService(repo tree.Repository, shareRepo share.Repository) *Service {
-    return &Service{repo: repo, shareRepo: shareRepo}
+func NewService(repo tree.Repository, nodeRepo node.Repository, shareRepo share.Repository, auditRepo audit.Repository, invites share.InviteSender, txm tx.Manager) *Service {
+    return &Service{
+        repo:      repo,
+        nodeRepo:  nodeRepo,
+        shareRepo: shareRepo,
+        auditRepo: auditRepo,
+        invites:   invites,
+        txm:       txm,
+        access:    access.NewPolicy(shareRepo),
+        integrity: integrity.NewChecker(repo, nodeRepo, auditRepo, txm),
+    }
 }
 
 // ==================== CreateTree ====================
@@ -52,15 +73,49 @@ func (s *Service) CreateTree(
         CreatedBy:   userID,
     }
 
-    // Save to DB
-    if err := s.repo.Create(ctx, t); err != nil {
-        slog.Error("failed to create tree", "error", err)
-        return nil, connect.NewError(connect.CodeInternal, err)
+    // เริ่มจาก template: faculty / department ที่ไม่ได้ส่งมาใช้ค่าของ template
+    var tmpl *tree.Template
+    if templateID := req.Msg.GetTemplateId(); templateID != "" {
+        tmpl, err = s.findOwnTemplate(ctx, templateID, userID)
+        if err != nil {
+            return nil, err
+        }
+        if t.Faculty == "" {
+            t.Faculty = tmpl.Faculty
+        }
+        if t.Department == "" {
+            t.Department = tmpl.Department
+        }
+        t.TemplateID = &tmpl.ID
+    }
+
+    // Save to DB (พร้อม node จาก template + audit ใน transaction เดียว)
+    var nodeCount int
+    err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+        if err := s.repo.Create(ctx, t); err != nil {
+            slog.Error("failed to create tree", "error", err)
+            return connect.NewError(connect.CodeInternal, err)
+        }
+        if tmpl != nil {
+            if nodeCount, err = s.instantiateTemplate(ctx, t, tmpl, req.Msg.BaseGeneration); err != nil {
+                return err
+            }
+        }
+        return s.record(ctx, &audit.Entry{
+            TreeID:  t.ID,
+            ActorID: userID,
+            Action:  audit.ActionTreeCreated,
+            After:   audit.FieldsSnapshot(treeFields(t)),
+        })
+    })
+    if err != nil {
+        return nil, toConnectError(err)
     }
 
     // Return response
     return connect.NewResponse(&treev1.CreateTreeResponse{
-        Tree: domainToProto(t),
+        Tree:      domainToProto(t),
+        NodeCount: int32(nodeCount),
     }), nil
 }
 
@@ -86,12 +141,18 @@ func (s *Service) GetTree(
         return nil, connect.NewError(connect.CodeInternal, err)
     }
 
+    // tree private ตอบ NotFound ให้คนที่ไม่มีสิทธิ์ เหมือนไม่มี tree นี้
+    userID, _ := middleware.GetUserID(ctx)
+    level, err := s.access.RequireView(ctx, t, userID)
+    if err != nil {
+        return nil, err
+    }
+
     proto := domainToProto(t)
 
     // ใส่ my_role ให้ response
-    userID, _ := middleware.GetUserID(ctx)
     if userID != "" {
-        proto.MyRole = s.resolveMyRole(ctx, t, userID)
+        proto.MyRole = levelToProto(level)
     }
 
     return connect.NewResponse(&treev1.GetTreeResponse{
@@ -106,26 +167,14 @@ func (s *Service) ListMyTrees(
     req *connect.Request[treev1.ListMyTreesRequest],
 ) (*connect.Response[treev1.ListMyTreesResponse], error) {
 
-    userID, err := middleware.GetUserID(ctx)
-    if err != nil {
-        return nil, connect.NewError(connect.CodeUnauthenticated, err)
-    }
-
-    trees, err := s.repo.ListByUser(ctx, userID)
+    trees, next, err := s.listTrees(ctx, tree.ScopeOwned, req.Msg.Options)
     if err != nil {
-        return nil, connect.NewError(connect.CodeInternal, err)
-    }
-
-    // แปลง domain → proto (ใส่ my_role = OWNER เพราะเป็น tree ของตัวเอง)
-    protoTrees := make([]*treev1.Tree, len(trees))
-    for i, t := range trees {
-        proto := domainToProto(t)
-        proto.MyRole = treev1.ShareRole_SHARE_ROLE_OWNER
-        protoTrees[i] = proto
+        return nil, err
     }
 
     return connect.NewResponse(&treev1.ListMyTreesResponse{
-        Trees: protoTrees,
+        Trees:         trees,
+        NextPageToken: next,
     }), nil
 }
 
@@ -157,14 +206,88 @@ func (s *Service) DeleteTree(
         )
     }
 
-    // ลบ (cascade ลบ nodes ด้วย เพราะ ON DELETE CASCADE)
-    if err := s.repo.Delete(ctx, req.Msg.Id); err != nil {
-        return nil, connect.NewError(connect.CodeInternal, err)
+    // ย้ายลงถังขยะ (nodes ยังอยู่ครบ กู้คืนได้จนกว่า purge job จะลบจริง)
+    err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+        if err := s.repo.MoveToTrash(ctx, req.Msg.Id, userID); err != nil {
+            return connect.NewError(connect.CodeInternal, err)
+        }
+        return s.record(ctx, &audit.Entry{
+            TreeID:  t.ID,
+            ActorID: userID,
+            Action:  audit.ActionTreeDeleted,
+            Before:  audit.FieldsSnapshot(treeFields(t)),
+        })
+    })
+    if err != nil {
+        return nil, toConnectError(err)
     }
 
     return connect.NewResponse(&treev1.DeleteTreeResponse{}), nil
 }
 
+// ==================== UpdateContactPrivacy ====================
+
+func (s *Service) UpdateContactPrivacy(
+    ctx context.Context,
+    req *connect.Request[treev1.UpdateContactPrivacyRequest],
+) (*connect.Response[treev1.UpdateContactPrivacyResponse], error) {
+
+    userID, err := middleware.GetUserID(ctx)
+    if err != nil {
+        return nil, connect.NewError(connect.CodeUnauthenticated, err)
+    }
+
+    if req.Msg.TreeId == "" {
+        return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("tree_id is required"))
+    }
+
+    t, err := s.repo.FindByID(ctx, req.Msg.TreeId)
+    if err != nil {
+        if errors.Is(err, tree.ErrTreeNotFound) {
+            return nil, connect.NewError(connect.CodeNotFound, err)
+        }
+        return nil, connect.NewError(connect.CodeInternal, err)
+    }
+
+    // เจ้าของเท่านั้นที่ตัดสินใจว่าจะเปิดเผยข้อมูลติดต่อแค่ไหน
+    level, err := s.access.RequireView(ctx, t, userID)
+    if err != nil {
+        return nil, err
+    }
+    if level != access.Owner {
+        return nil, connect.NewError(connect.CodePermissionDenied, tree.ErrUnauthorized)
+    }
+
+    settings := access.PrivacyFromProto(req.Msg.ContactPrivacy)
+    err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+        if err := s.repo.UpdateContactPrivacy(ctx, t.ID, settings); err != nil {
+            if errors.Is(err, tree.ErrTreeNotFound) {
+                return connect.NewError(connect.CodeNotFound, err)
+            }
+            slog.Error("failed to update contact privacy", "error", err)
+            return connect.NewError(connect.CodeInternal, err)
+        }
+        return s.record(ctx, &audit.Entry{
+            TreeID:  t.ID,
+            ActorID: userID,
+            Action:  audit.ActionContactPrivacyUpdated,
+            Before:  audit.FieldsSnapshot(privacyFields(t.ContactPrivacy)),
+            After:   audit.FieldsSnapshot(privacyFields(settings)),
+        })
+    })
+    if err != nil {
+        return nil, toConnectError(err)
+    }
+    t.ContactPrivacy = settings
+
+    proto := domainToProto(t)
+    proto.MyRole = levelToProto(level)
+
+    return connect.NewResponse(&treev1.UpdateContactPrivacyResponse{
+        Tree: proto,
+    }), nil
+}
+
 // ==================== ShareTree ====================
 
 func (s *Service) ShareTree(
@@ -177,7 +300,9 @@ func (s *Service) ShareTree(
         return nil, connect.NewError(connect.CodeUnauthenticated, err)
     }
 
-    if req.Msg.TreeId == "" || req.Msg.Email == "" {
+    // Supabase เก็บ email เป็นตัวพิมพ์เล็ก
+    email := strings.ToLower(strings.TrimSpace(req.Msg.Email))
+    if req.Msg.TreeId == "" || email == "" {
         return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("tree_id and email are required"))
     }
 
@@ -201,10 +326,11 @@ func (s *Service) ShareTree(
     }
 
     // หา user จาก email
-    targetUserID, err := s.shareRepo.FindUserByEmail(ctx, req.Msg.Email)
+    targetUserID, err := s.shareRepo.FindUserByEmail(ctx, email)
     if err != nil {
         if errors.Is(err, share.ErrUserNotFound) {
-            return nil, connect.NewError(connect.CodeNotFound, share.ErrUserNotFound)
+            // ยังไม่มีบัญชี → เก็บเป็นคำเชิญ รอเจ้าของ email สมัคร
+            return s.inviteByEmail(ctx, t, email, role, userID)
         }
         return nil, connect.NewError(connect.CodeInternal, err)
     }
@@ -222,17 +348,30 @@ func (s *Service) ShareTree(
         InvitedBy: &userID,
     }
 
-    if err := s.shareRepo.Create(ctx, ts); err != nil {
-        if errors.Is(err, share.ErrAlreadyShared) {
-            return nil, connect.NewError(connect.CodeAlreadyExists, err)
+    var fullShare *share.TreeShare
+    err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+        if err := s.shareRepo.Create(ctx, ts); err != nil {
+            if errors.Is(err, share.ErrAlreadyShared) {
+                return connect.NewError(connect.CodeAlreadyExists, err)
+            }
+            return connect.NewError(connect.CodeInternal, err)
         }
-        return nil, connect.NewError(connect.CodeInternal, err)
-    }
 
-    // ดึงข้อมูล user profile กลับมาด้วย
-    fullShare, err := s.shareRepo.FindByTreeAndUser(ctx, req.Msg.TreeId, targetUserID)
+        // ดึงข้อมูล user profile กลับมาด้วย
+        fullShare, err = s.shareRepo.FindByTreeAndUser(ctx, req.Msg.TreeId, targetUserID)
+        if err != nil {
+            return connect.NewError(connect.CodeInternal, err)
+        }
+        return s.record(ctx, &audit.Entry{
+            TreeID:    t.ID,
+            ActorID:   userID,
+            Action:    audit.ActionShareCreated,
+            TargetIDs: []string{targetUserID},
+            After:     audit.FieldsSnapshot(roleFields(role)),
+        })
+    })
     if err != nil {
-        return nil, connect.NewError(connect.CodeInternal, err)
+        return nil, toConnectError(err)
     }
 
     return connect.NewResponse(&treev1.ShareTreeResponse{
@@ -273,12 +412,34 @@ func (s *Service) UpdateShare(
         return nil, connect.NewError(connect.CodePermissionDenied, share.ErrNotShareOwner)
     }
 
-    updated, err := s.shareRepo.UpdateRole(ctx, req.Msg.TreeId, req.Msg.UserId, role)
-    if err != nil {
-        if errors.Is(err, share.ErrShareNotFound) {
-            return nil, connect.NewError(connect.CodeNotFound, err)
+    var updated *share.TreeShare
+    err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+        before, err := s.shareRepo.GetUserRole(ctx, req.Msg.TreeId, req.Msg.UserId)
+        if err != nil {
+            if errors.Is(err, share.ErrShareNotFound) {
+                return connect.NewError(connect.CodeNotFound, err)
+            }
+            return connect.NewError(connect.CodeInternal, err)
         }
-        return nil, connect.NewError(connect.CodeInternal, err)
+
+        updated, err = s.shareRepo.UpdateRole(ctx, req.Msg.TreeId, req.Msg.UserId, role)
+        if err != nil {
+            if errors.Is(err, share.ErrShareNotFound) {
+                return connect.NewError(connect.CodeNotFound, err)
+            }
+            return connect.NewError(connect.CodeInternal, err)
+        }
+        return s.record(ctx, &audit.Entry{
+            TreeID:    t.ID,
+            ActorID:   userID,
+            Action:    audit.ActionShareUpdated,
+            TargetIDs: []string{req.Msg.UserId},
+            Before:    audit.FieldsSnapshot(roleFields(before)),
+            After:     audit.FieldsSnapshot(roleFields(role)),
+        })
+    })
+    if err != nil {
+        return nil, toConnectError(err)
     }
 
     fullShare, err := s.shareRepo.FindByTreeAndUser(ctx, req.Msg.TreeId, req.Msg.UserId)
@@ -321,11 +482,31 @@ func (s *Service) RemoveShare(
         return nil, connect.NewError(connect.CodePermissionDenied, share.ErrNotShareOwner)
     }
 
-    if err := s.shareRepo.Delete(ctx, req.Msg.TreeId, req.Msg.UserId); err != nil {
-        if errors.Is(err, share.ErrShareNotFound) {
-            return nil, connect.NewError(connect.CodeNotFound, err)
+    err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+        before, err := s.shareRepo.GetUserRole(ctx, req.Msg.TreeId, req.Msg.UserId)
+        if err != nil {
+            if errors.Is(err, share.ErrShareNotFound) {
+                return connect.NewError(connect.CodeNotFound, err)
+            }
+            return connect.NewError(connect.CodeInternal, err)
         }
-        return nil, connect.NewError(connect.CodeInternal, err)
+
+        if err := s.shareRepo.Delete(ctx, req.Msg.TreeId, req.Msg.UserId); err != nil {
+            if errors.Is(err, share.ErrShareNotFound) {
+                return connect.NewError(connect.CodeNotFound, err)
+            }
+            return connect.NewError(connect.CodeInternal, err)
+        }
+        return s.record(ctx, &audit.Entry{
+            TreeID:    t.ID,
+            ActorID:   userID,
+            Action:    audit.ActionShareRemoved,
+            TargetIDs: []string{req.Msg.UserId},
+            Before:    audit.FieldsSnapshot(roleFields(before)),
+        })
+    })
+    if err != nil {
+        return nil, toConnectError(err)
     }
 
     return connect.NewResponse(&treev1.RemoveShareResponse{}), nil
@@ -356,12 +537,11 @@ func (s *Service) ListTreeShares(
         return nil, connect.NewError(connect.CodeInternal, err)
     }
 
-    hasAccess := t.CreatedBy == userID
-    if !hasAccess {
-        _, err := s.shareRepo.GetUserRole(ctx, req.Msg.TreeId, userID)
-        hasAccess = err == nil
+    level, err := s.access.RequireView(ctx, t, userID)
+    if err != nil {
+        return nil, err
     }
-    if !hasAccess {
+    if !level.IsMember() {
         return nil, connect.NewError(connect.CodePermissionDenied, tree.ErrUnauthorized)
     }
 
@@ -387,40 +567,14 @@ func (s *Service) ListSharedWithMe(
     req *connect.Request[treev1.ListSharedWithMeRequest],
 ) (*connect.Response[treev1.ListSharedWithMeResponse], error) {
 
-    userID, err := middleware.GetUserID(ctx)
+    trees, next, err := s.listTrees(ctx, tree.ScopeShared, req.Msg.Options)
     if err != nil {
-        return nil, connect.NewError(connect.CodeUnauthenticated, err)
-    }
-
-    treeIDs, err := s.shareRepo.ListTreeIDsByUser(ctx, userID)
-    if err != nil {
-        return nil, connect.NewError(connect.CodeInternal, err)
-    }
-
-    if len(treeIDs) == 0 {
-        return connect.NewResponse(&treev1.ListSharedWithMeResponse{
-            Trees: []*treev1.Tree{},
-        }), nil
-    }
-
-    trees, err := s.repo.FindByIDs(ctx, treeIDs)
-    if err != nil {
-        return nil, connect.NewError(connect.CodeInternal, err)
-    }
-
-    protoTrees := make([]*treev1.Tree, len(trees))
-    for i, t := range trees {
-        proto := domainToProto(t)
-        // ใส่ role สำหรับแต่ละ tree
-        role, err := s.shareRepo.GetUserRole(ctx, t.ID, userID)
-        if err == nil {
-            proto.MyRole = domainRoleToProto(role)
-        }
-        protoTrees[i] = proto
+        return nil, err
     }
 
     return connect.NewResponse(&treev1.ListSharedWithMeResponse{
-        Trees: protoTrees,
+        Trees:         trees,
+        NextPageToken: next,
     }), nil
 }
 
@@ -457,101 +611,45 @@ func (s *Service) GetMyRole(
     }), nil
 }
 
-// ==================== GenerateShareLink ====================
-
-func (s *Service) GenerateShareLink(
-    ctx context.Context,
-    req *connect.Request[treev1.GenerateShareLinkRequest],
-) (*connect.Response[treev1.GenerateShareLinkResponse], error) {
-
-    userID, err := middleware.GetUserID(ctx)
-    if err != nil {
-        return nil, connect.NewError(connect.CodeUnauthenticated, err)
-    }
-
-    if req.Msg.TreeId == "" {
-        return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("tree_id is required"))
-    }
-
-    t, err := s.repo.FindByID(ctx, req.Msg.TreeId)
-    if err != nil {
-        if errors.Is(err, tree.ErrTreeNotFound) {
-            return nil, connect.NewError(connect.CodeNotFound, err)
-        }
-        return nil, connect.NewError(connect.CodeInternal, err)
-    }
-
-    if !s.isTreeOwnerOrShareOwner(ctx, t, userID) {
-        return nil, connect.NewError(connect.CodePermissionDenied, tree.ErrUnauthorized)
-    }
-
-    token, err := s.repo.GenerateShareToken(ctx, req.Msg.TreeId)
-    if err != nil {
-        return nil, connect.NewError(connect.CodeInternal, err)
-    }
-
-    return connect.NewResponse(&treev1.GenerateShareLinkResponse{
-        ShareToken: token,
-        ShareUrl:   "/share/" + token,
-    }), nil
-}
-
-// ==================== GetTreeByShareToken ====================
-
-func (s *Service) GetTreeByShareToken(
-    ctx context.Context,
-    req *connect.Request[treev1.GetTreeByShareTokenRequest],
-) (*connect.Response[treev1.GetTreeByShareTokenResponse], error) {
-
-    if req.Msg.ShareToken == "" {
-        return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("share_token is required"))
-    }
-
-    t, err := s.repo.FindByShareToken(ctx, req.Msg.ShareToken)
-    if err != nil {
-        if errors.Is(err, tree.ErrTreeNotFound) {
-            return nil, connect.NewError(connect.CodeNotFound, err)
-        }
-        return nil, connect.NewError(connect.CodeInternal, err)
-    }
-
-    proto := domainToProto(t)
-    proto.MyRole = treev1.ShareRole_SHARE_ROLE_VIEWER
-
-    return connect.NewResponse(&treev1.GetTreeByShareTokenResponse{
-        Tree: proto,
-    }), nil
-}
-
 // ==================== Helpers ====================
 
 // resolveMyRole คำนวณ role ที่ user มีกับ tree
 func (s *Service) resolveMyRole(ctx context.Context, t *tree.Tree, userID string) treev1.ShareRole {
-    if t.CreatedBy == userID {
-        return treev1.ShareRole_SHARE_ROLE_OWNER
-    }
-
-    role, err := s.shareRepo.GetUserRole(ctx, t.ID, userID)
+    level, err := s.access.Resolve(ctx, t, userID)
     if err != nil {
-        if t.IsPublic {
-            return treev1.ShareRole_SHARE_ROLE_VIEWER
-        }
         return treev1.ShareRole_SHARE_ROLE_UNSPECIFIED
     }
-
-    return domainRoleToProto(role)
+    return levelToProto(level)
 }
 
 // isTreeOwnerOrShareOwner ตรวจว่า user เป็น creator หรือ co-owner
 func (s *Service) isTreeOwnerOrShareOwner(ctx context.Context, t *tree.Tree, userID string) bool {
-    if t.CreatedBy == userID {
-        return true
+    level, err := s.access.Resolve(ctx, t, userID)
+    return err == nil && level == access.Owner
+}
+
+// levelToProto แปลงระดับสิทธิ์เป็น role ที่ส่งให้ client (public ที่ไม่ใช่สมาชิกนับเป็น viewer)
+func levelToProto(l access.Level) treev1.ShareRole {
+    switch l {
+    case access.Owner:
+        return treev1.ShareRole_SHARE_ROLE_OWNER
+    case access.Editor:
+        return treev1.ShareRole_SHARE_ROLE_EDITOR
+    case access.Viewer, access.Public:
+        return treev1.ShareRole_SHARE_ROLE_VIEWER
+    default:
+        return treev1.ShareRole_SHARE_ROLE_UNSPECIFIED
     }
-    role, err := s.shareRepo.GetUserRole(ctx, t.ID, userID)
-    if err != nil {
-        return false
+}
+
+// toConnectError คืน error เดิมถ้าเป็น connect error อยู่แล้ว (เช่นจากใน transaction)
+// ไม่งั้นห่อเป็น CodeInternal (เช่น commit ไม่ผ่าน)
+func toConnectError(err error) error {
+    var connectErr *connect.Error
+    if errors.As(err, &connectErr) {
+        return err
     }
-    return role == share.RoleOwner
+    return connect.NewError(connect.CodeInternal, err)
 }
 
 func domainToProto(t *tree.Tree) *treev1.Tree {
@@ -564,6 +662,11 @@ func domainToProto(t *tree.Tree) *treev1.Tree {
         CreatedBy:   t.CreatedBy,
         CreatedAt:   t.CreatedAt.Format("2006-01-02T15:04:05Z"),
         UpdatedAt:   t.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+
+        StructureRevision: t.StructureRevision,
+        ContactPrivacy:    access.PrivacyToProto(t.ContactPrivacy),
+        ClonedFrom:        t.ClonedFrom,
+        TemplateId:        t.TemplateID,
     }
 }
 
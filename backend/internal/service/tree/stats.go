@@ -0,0 +1,159 @@
+package tree
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+
+	treev1 "github.com/TitleKung-01/code-tree-backend/gen/tree/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/node"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/middleware"
+)
+
+// ==================== GetTreeStats ====================
+
+func (s *Service) GetTreeStats(
+	ctx context.Context,
+	req *connect.Request[treev1.GetTreeStatsRequest],
+) (*connect.Response[treev1.GetTreeStatsResponse], error) {
+
+	if req.Msg.TreeId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("tree_id is required"))
+	}
+
+	t, err := s.repo.FindByID(ctx, req.Msg.TreeId)
+	if err != nil {
+		if errors.Is(err, tree.ErrTreeNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	userID, _ := middleware.GetUserID(ctx)
+	if _, err := s.access.RequireView(ctx, t, userID); err != nil {
+		return nil, err
+	}
+
+	nodes, err := s.nodeRepo.FindByTreeID(ctx, t.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&treev1.GetTreeStatsResponse{
+		Stats: treeStats(t, nodes),
+	}), nil
+}
+
+// ==================== GetFacultyStats ====================
+
+func (s *Service) GetFacultyStats(
+	ctx context.Context,
+	req *connect.Request[treev1.GetFacultyStatsRequest],
+) (*connect.Response[treev1.GetFacultyStatsResponse], error) {
+
+	rollups, err := s.repo.RollupPublic(ctx, req.Msg.GetFaculty())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	// rollups เรียงตามคณะมาแล้ว → รวมแถวของคณะเดียวกันที่อยู่ติดกัน
+	resp := &treev1.GetFacultyStatsResponse{Faculties: []*treev1.FacultyStats{}}
+	var current *treev1.FacultyStats
+	for _, ru := range rollups {
+		if current == nil || current.Faculty != ru.Faculty {
+			current = &treev1.FacultyStats{Faculty: ru.Faculty, Statuses: &treev1.StatusCounts{}}
+			resp.Faculties = append(resp.Faculties, current)
+		}
+		dept := &treev1.DepartmentStats{
+			Department:  ru.Department,
+			TreeCount:   int32(ru.Trees),
+			MemberCount: int32(ru.Members),
+			Statuses:    statusCountsToProto(ru.ByStatus),
+		}
+		current.Departments = append(current.Departments, dept)
+		current.TreeCount += dept.TreeCount
+		current.MemberCount += dept.MemberCount
+		current.Statuses.Studying += dept.Statuses.Studying
+		current.Statuses.Graduated += dept.Statuses.Graduated
+		current.Statuses.Retired += dept.Statuses.Retired
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// ==================== Helpers ====================
+
+// treeStats รวมตัวเลขจาก node rows (รุ่น / สถานะ / trend) กับตัวเลขจาก structure
+func treeStats(t *tree.Tree, nodes []*node.Node) *treev1.TreeStats {
+	generations := map[int32]int32{}
+	statuses := map[string]int{}
+	months := map[string]int32{}
+	for _, n := range nodes {
+		generations[n.Generation]++
+		statuses[string(n.Status)]++
+		months[n.CreatedAt.UTC().Format("2006-01")]++
+	}
+
+	st := t.Structure.Stats()
+	stats := &treev1.TreeStats{
+		MemberCount:          int32(len(nodes)),
+		Statuses:             statusCountsToProto(statuses),
+		SeniorCount:          int32(st.Seniors),
+		AvgChildrenPerSenior: st.AvgChildren(),
+		MaxChildren:          int32(st.MaxChildren),
+		DeepestLineage:       int32(len(st.DeepestPath)),
+		DeepestPath:          st.DeepestPath,
+		RootCount:            int32(len(t.Structure.RootIDs)),
+		OrphanRootIds:        st.OrphanRoots,
+		MultiParentCount:     int32(st.MultiParent),
+		Trend:                trend(nodes, months),
+	}
+
+	keys := make([]int32, 0, len(generations))
+	for g := range generations {
+		keys = append(keys, g)
+	}
+	slices.Sort(keys)
+	for _, g := range keys {
+		stats.Generations = append(stats.Generations, &treev1.GenerationCount{Generation: g, Count: generations[g]})
+	}
+	return stats
+}
+
+// trend จำนวนคนที่เพิ่มรายเดือนตั้งแต่เดือนของ node แรกถึงเดือนล่าสุด (เดือนที่ไม่มีใครเพิ่มก็มี)
+func trend(nodes []*node.Node, months map[string]int32) []*treev1.TrendPoint {
+	if len(nodes) == 0 {
+		return nil
+	}
+	first, last := nodes[0].CreatedAt.UTC(), nodes[0].CreatedAt.UTC()
+	for _, n := range nodes[1:] {
+		c := n.CreatedAt.UTC()
+		if c.Before(first) {
+			first = c
+		}
+		if c.After(last) {
+			last = c
+		}
+	}
+
+	var points []*treev1.TrendPoint
+	var total int32
+	end := time.Date(last.Year(), last.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for m := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.UTC); !m.After(end); m = m.AddDate(0, 1, 0) {
+		key := m.Format("2006-01")
+		total += months[key]
+		points = append(points, &treev1.TrendPoint{Month: key, Added: months[key], Total: total})
+	}
+	return points
+}
+
+func statusCountsToProto(byStatus map[string]int) *treev1.StatusCounts {
+	return &treev1.StatusCounts{
+		Studying:  int32(byStatus[string(node.StatusStudying)]),
+		Graduated: int32(byStatus[string(node.StatusGraduated)]),
+		Retired:   int32(byStatus[string(node.StatusRetired)]),
+	}
+}
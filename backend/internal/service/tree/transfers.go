@@ -0,0 +1,374 @@
+package tree
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"connectrpc.com/connect"
+
+	treev1 "github.com/TitleKung-01/code-tree-backend/gen/tree/v1"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/audit"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/share"
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/tree"
+	"github.com/TitleKung-01/code-tree-backend/internal/middleware"
+)
+
+// ==================== TransferOwnership ====================
+
+func (s *Service) TransferOwnership(
+	ctx context.Context,
+	req *connect.Request[treev1.TransferOwnershipRequest],
+) (*connect.Response[treev1.TransferOwnershipResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.TreeId == "" || req.Msg.ToUserId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("tree_id and to_user_id are required"))
+	}
+
+	role := protoRoleToDomain(req.Msg.PreviousOwnerRole)
+	if !role.IsValid() {
+		return nil, connect.NewError(connect.CodeInvalidArgument, share.ErrInvalidRole)
+	}
+
+	if req.Msg.ToUserId == userID {
+		return nil, connect.NewError(connect.CodeInvalidArgument, share.ErrTransferToNonMember)
+	}
+
+	t, err := s.repo.FindByID(ctx, req.Msg.TreeId)
+	if err != nil {
+		if errors.Is(err, tree.ErrTreeNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if _, err := s.access.RequireView(ctx, t, userID); err != nil {
+		return nil, err
+	}
+	// co-owner จัดการแชร์ได้ แต่โอน tree ได้เฉพาะเจ้าของตัวจริง
+	if t.CreatedBy != userID {
+		return nil, connect.NewError(connect.CodePermissionDenied, share.ErrNotTreeCreator)
+	}
+
+	if _, err := s.shareRepo.GetUserRole(ctx, t.ID, req.Msg.ToUserId); err != nil {
+		if errors.Is(err, share.ErrShareNotFound) {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, share.ErrTransferToNonMember)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	transfer := &share.OwnershipTransfer{
+		TreeID:            t.ID,
+		FromUserID:        &userID,
+		ToUserID:          &req.Msg.ToUserId,
+		PreviousOwnerRole: role,
+	}
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.shareRepo.CreateTransfer(ctx, transfer); err != nil {
+			if errors.Is(err, share.ErrTransferPending) {
+				return connect.NewError(connect.CodeAlreadyExists, err)
+			}
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		return s.record(ctx, transferEntry(transfer, userID, audit.ActionTransferRequested))
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&treev1.TransferOwnershipResponse{
+		Transfer: transferToProto(transfer),
+	}), nil
+}
+
+// ==================== RespondOwnershipTransfer ====================
+
+func (s *Service) RespondOwnershipTransfer(
+	ctx context.Context,
+	req *connect.Request[treev1.RespondOwnershipTransferRequest],
+) (*connect.Response[treev1.RespondOwnershipTransferResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.TransferId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("transfer_id is required"))
+	}
+
+	transfer, err := s.findTransfer(ctx, req.Msg.TransferId)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.ToUserID == nil || *transfer.ToUserID != userID {
+		return nil, connect.NewError(connect.CodePermissionDenied, share.ErrNotTransferRecipient)
+	}
+
+	if !req.Msg.Accept {
+		err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+			transfer, err = s.shareRepo.ResolveTransfer(ctx, transfer.ID, share.TransferDeclined)
+			if err != nil {
+				return transferConnectError(err)
+			}
+			return s.record(ctx, transferEntry(transfer, userID, audit.ActionTransferDeclined))
+		})
+		if err != nil {
+			return nil, toConnectError(err)
+		}
+		return connect.NewResponse(&treev1.RespondOwnershipTransferResponse{
+			Transfer: transferToProto(transfer),
+		}), nil
+	}
+
+	var t *tree.Tree
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		// ปิดคำขอก่อน — UPDATE ... WHERE status = 'pending' กันการตอบซ้ำพร้อมกัน
+		resolved, err := s.shareRepo.ResolveTransfer(ctx, transfer.ID, share.TransferAccepted)
+		if err != nil {
+			return transferConnectError(err)
+		}
+		if resolved.FromUserID == nil {
+			return connect.NewError(connect.CodeFailedPrecondition, tree.ErrOwnerChanged)
+		}
+		from := *resolved.FromUserID
+
+		// ผู้รับอาจถูกเอาออกจากแชร์ระหว่างรอ
+		if _, err := s.shareRepo.GetUserRole(ctx, resolved.TreeID, userID); err != nil {
+			if errors.Is(err, share.ErrShareNotFound) {
+				return connect.NewError(connect.CodeFailedPrecondition, share.ErrTransferToNonMember)
+			}
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		if err := s.repo.UpdateOwner(ctx, resolved.TreeID, from, userID); err != nil {
+			switch {
+			case errors.Is(err, tree.ErrOwnerChanged):
+				return connect.NewError(connect.CodeFailedPrecondition, err)
+			case errors.Is(err, tree.ErrTreeNotFound):
+				return connect.NewError(connect.CodeNotFound, err)
+			}
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		// เจ้าของใหม่ไม่ต้องมี share แล้ว / เจ้าของเดิมเหลือ role ที่เลือกไว้
+		if err := s.shareRepo.Delete(ctx, resolved.TreeID, userID); err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		if err := s.demotePreviousOwner(ctx, resolved.TreeID, from, resolved.PreviousOwnerRole, userID); err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		t, err = s.repo.FindByID(ctx, resolved.TreeID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		transfer = resolved
+
+		entry := transferEntry(resolved, userID, audit.ActionTransferAccepted)
+		entry.Before = audit.FieldsSnapshot(map[string]string{"created_by": from})
+		entry.After = audit.FieldsSnapshot(map[string]string{
+			"created_by":          userID,
+			"previous_owner_role": string(resolved.PreviousOwnerRole),
+		})
+		return s.record(ctx, entry)
+	})
+	if err != nil {
+		// คำขอที่ทำต่อไม่ได้แล้ว (เจ้าของเปลี่ยน / ผู้รับไม่ได้เป็นสมาชิก) ปิดทิ้ง ไม่ค้างไว้
+		if errors.Is(err, tree.ErrOwnerChanged) || errors.Is(err, share.ErrTransferToNonMember) {
+			if _, cancelErr := s.shareRepo.ResolveTransfer(ctx, transfer.ID, share.TransferCancelled); cancelErr != nil {
+				slog.Warn("failed to cancel stale ownership transfer", "transferID", transfer.ID, "error", cancelErr)
+			}
+		}
+		return nil, toConnectError(err)
+	}
+
+	slog.Info("tree ownership transferred", "treeID", t.ID, "transferID", transfer.ID, "to", userID)
+
+	proto := domainToProto(t)
+	proto.MyRole = treev1.ShareRole_SHARE_ROLE_OWNER
+
+	return connect.NewResponse(&treev1.RespondOwnershipTransferResponse{
+		Transfer: transferToProto(transfer),
+		Tree:     proto,
+	}), nil
+}
+
+// demotePreviousOwner ให้เจ้าของเดิมเป็นสมาชิกด้วย role ที่เลือกไว้ตอนขอโอน
+func (s *Service) demotePreviousOwner(ctx context.Context, treeID, userID string, role share.Role, invitedBy string) error {
+	err := s.shareRepo.Create(ctx, &share.TreeShare{
+		TreeID:    treeID,
+		UserID:    userID,
+		Role:      role,
+		InvitedBy: &invitedBy,
+	})
+	if errors.Is(err, share.ErrAlreadyShared) {
+		_, err = s.shareRepo.UpdateRole(ctx, treeID, userID, role)
+	}
+	return err
+}
+
+// ==================== CancelOwnershipTransfer ====================
+
+func (s *Service) CancelOwnershipTransfer(
+	ctx context.Context,
+	req *connect.Request[treev1.CancelOwnershipTransferRequest],
+) (*connect.Response[treev1.CancelOwnershipTransferResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.TransferId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("transfer_id is required"))
+	}
+
+	transfer, err := s.findTransfer(ctx, req.Msg.TransferId)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.FromUserID == nil || *transfer.FromUserID != userID {
+		return nil, connect.NewError(connect.CodePermissionDenied, share.ErrNotTreeCreator)
+	}
+
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		transfer, err = s.shareRepo.ResolveTransfer(ctx, transfer.ID, share.TransferCancelled)
+		if err != nil {
+			return transferConnectError(err)
+		}
+		return s.record(ctx, transferEntry(transfer, userID, audit.ActionTransferCancelled))
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&treev1.CancelOwnershipTransferResponse{
+		Transfer: transferToProto(transfer),
+	}), nil
+}
+
+// ==================== ListOwnershipTransfers ====================
+
+func (s *Service) ListOwnershipTransfers(
+	ctx context.Context,
+	req *connect.Request[treev1.ListOwnershipTransfersRequest],
+) (*connect.Response[treev1.ListOwnershipTransfersResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.TreeId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("tree_id is required"))
+	}
+
+	t, err := s.loadManagedTree(ctx, req.Msg.TreeId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers, err := s.shareRepo.ListTransfers(ctx, t.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&treev1.ListOwnershipTransfersResponse{
+		Transfers: transfersToProto(transfers),
+	}), nil
+}
+
+// ==================== ListIncomingOwnershipTransfers ====================
+
+func (s *Service) ListIncomingOwnershipTransfers(
+	ctx context.Context,
+	req *connect.Request[treev1.ListIncomingOwnershipTransfersRequest],
+) (*connect.Response[treev1.ListIncomingOwnershipTransfersResponse], error) {
+
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	transfers, err := s.shareRepo.ListPendingTransfersTo(ctx, userID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&treev1.ListIncomingOwnershipTransfersResponse{
+		Transfers: transfersToProto(transfers),
+	}), nil
+}
+
+// ==================== Helpers ====================
+
+func (s *Service) findTransfer(ctx context.Context, transferID string) (*share.OwnershipTransfer, error) {
+	transfer, err := s.shareRepo.FindTransfer(ctx, transferID)
+	if err != nil {
+		return nil, transferConnectError(err)
+	}
+	return transfer, nil
+}
+
+// transferEntry audit ของคำขอโอน (target = คำขอ + ผู้รับ)
+func transferEntry(t *share.OwnershipTransfer, actorID string, action audit.Action) *audit.Entry {
+	return &audit.Entry{
+		TreeID:    t.TreeID,
+		ActorID:   actorID,
+		Action:    action,
+		TargetIDs: []string{t.ID, stringPtrToString(t.ToUserID)},
+		After:     audit.FieldsSnapshot(map[string]string{"status": string(t.Status)}),
+	}
+}
+
+func transferConnectError(err error) error {
+	switch {
+	case errors.Is(err, share.ErrTransferNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, share.ErrTransferNotPending):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
+func transfersToProto(transfers []*share.OwnershipTransfer) []*treev1.OwnershipTransfer {
+	protoTransfers := make([]*treev1.OwnershipTransfer, len(transfers))
+	for i, t := range transfers {
+		protoTransfers[i] = transferToProto(t)
+	}
+	return protoTransfers
+}
+
+func transferToProto(t *share.OwnershipTransfer) *treev1.OwnershipTransfer {
+	return &treev1.OwnershipTransfer{
+		Id:                t.ID,
+		TreeId:            t.TreeID,
+		FromUserId:        stringPtrToString(t.FromUserID),
+		ToUserId:          stringPtrToString(t.ToUserID),
+		PreviousOwnerRole: domainRoleToProto(t.PreviousOwnerRole),
+		Status:            transferStatusToProto(t.Status),
+		CreatedAt:         t.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		ResolvedAt:        formatTime(t.ResolvedAt),
+	}
+}
+
+func transferStatusToProto(s share.TransferStatus) treev1.OwnershipTransferStatus {
+	switch s {
+	case share.TransferPending:
+		return treev1.OwnershipTransferStatus_OWNERSHIP_TRANSFER_STATUS_PENDING
+	case share.TransferAccepted:
+		return treev1.OwnershipTransferStatus_OWNERSHIP_TRANSFER_STATUS_ACCEPTED
+	case share.TransferDeclined:
+		return treev1.OwnershipTransferStatus_OWNERSHIP_TRANSFER_STATUS_DECLINED
+	case share.TransferCancelled:
+		return treev1.OwnershipTransferStatus_OWNERSHIP_TRANSFER_STATUS_CANCELLED
+	default:
+		return treev1.OwnershipTransferStatus_OWNERSHIP_TRANSFER_STATUS_UNSPECIFIED
+	}
+}
@@ -0,0 +1,74 @@
+package supabase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/TitleKung-01/code-tree-backend/internal/domain/share"
+)
+
+// errAlreadyRegistered Supabase ตอบ 422 เมื่อ email มีบัญชีแล้ว (เช่นเคยถูกเชิญแต่ยังไม่ยืนยัน)
+var errAlreadyRegistered = errors.New("email already registered")
+
+// InviteSender ส่ง email เชิญผ่าน Supabase Auth (ต้องใช้ service role key)
+type InviteSender struct {
+	baseURL    string
+	serviceKey string
+	client     *http.Client
+}
+
+var _ share.InviteSender = (*InviteSender)(nil)
+
+func NewInviteSender(supabaseURL, serviceKey string) *InviteSender {
+	return &InviteSender{
+		baseURL:    strings.TrimRight(supabaseURL, "/"),
+		serviceKey: serviceKey,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SendInvite ส่ง email เชิญสมัคร — ถ้า email มีบัญชีอยู่แล้ว (ยังไม่ยืนยัน) ส่ง magic link แทน
+func (s *InviteSender) SendInvite(ctx context.Context, email string) error {
+	err := s.post(ctx, "/auth/v1/invite", map[string]any{"email": email})
+	if errors.Is(err, errAlreadyRegistered) {
+		err = s.post(ctx, "/auth/v1/otp", map[string]any{"email": email, "create_user": false})
+	}
+	return err
+}
+
+func (s *InviteSender) post(ctx context.Context, path string, body map[string]any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("apikey", s.serviceKey)
+	req.Header.Set("Authorization", "Bearer "+s.serviceKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call supabase auth: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 300 {
+		return nil
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if resp.StatusCode == http.StatusUnprocessableEntity {
+		return fmt.Errorf("%w: %s", errAlreadyRegistered, msg)
+	}
+	return fmt.Errorf("supabase auth %s returned %d: %s", path, resp.StatusCode, msg)
+}
@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { AddParentRequest, AddParentResponse, CreateNodeRequest, CreateNodeResponse, DeleteNodeRequest, DeleteNodeResponse, GetNodesByShareTokenRequest, GetNodesByShareTokenResponse, GetTreeNodesRequest, GetTreeNodesResponse, MoveNodeRequest, MoveNodeResponse, RemoveParentRequest, RemoveParentResponse, UnlinkNodeRequest, UnlinkNodeResponse, UpdateNodeRequest, UpdateNodeResponse } from "./node_pb.js";
+import { AddParentRequest, AddParentResponse, CopySubtreeRequest, CopySubtreeResponse, CreateNodeRequest, CreateNodeResponse, CreateSnapshotRequest, CreateSnapshotResponse, DeleteNodeRequest, DeleteNodeResponse, DeleteSubtreeRequest, DeleteSubtreeResponse, DiffSnapshotRequest, DiffSnapshotResponse, ExportTreeRequest, ExportTreeResponse, FindRelationshipRequest, FindRelationshipResponse, GetAncestorsRequest, GetAncestorsResponse, GetDescendantsRequest, GetDescendantsResponse, GetNodesByShareTokenRequest, GetNodesByShareTokenResponse, GetTreeNodesRequest, GetTreeNodesResponse, ImportNodesRequest, ImportNodesResponse, ListSnapshotsRequest, ListSnapshotsResponse, ListTrashRequest, ListTrashResponse, MoveNodeRequest, MoveNodeResponse, MoveSubtreeRequest, MoveSubtreeResponse, RedoRequest, RedoResponse, RemoveParentRequest, RemoveParentResponse, RestoreFromTrashRequest, RestoreFromTrashResponse, RestoreSnapshotRequest, RestoreSnapshotResponse, SearchNodesRequest, SearchNodesResponse, UndoRequest, UndoResponse, UnlinkNodeRequest, UnlinkNodeResponse, UpdateLayoutRequest, UpdateLayoutResponse, UpdateNodeRequest, UpdateNodeResponse, WatchTreeRequest, WatchTreeResponse } from "./node_pb.js";
 import { MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -84,6 +84,193 @@ export const NodeService = {
       O: RemoveParentResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * @generated from rpc node.v1.NodeService.UpdateLayout
+     */
+    updateLayout: {
+      name: "UpdateLayout",
+      I: UpdateLayoutRequest,
+      O: UpdateLayoutResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ★ Bulk import
+     *
+     * @generated from rpc node.v1.NodeService.ImportNodes
+     */
+    importNodes: {
+      name: "ImportNodes",
+      I: ImportNodesRequest,
+      O: ImportNodesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ★ Export (ดาวน์โหลดผ่าน HTTP ได้ที่ GET /export/{treeId}?format=...)
+     *
+     * @generated from rpc node.v1.NodeService.ExportTree
+     */
+    exportTree: {
+      name: "ExportTree",
+      I: ExportTreeRequest,
+      O: ExportTreeResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ★ Snapshot / restore / undo
+     *
+     * @generated from rpc node.v1.NodeService.CreateSnapshot
+     */
+    createSnapshot: {
+      name: "CreateSnapshot",
+      I: CreateSnapshotRequest,
+      O: CreateSnapshotResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc node.v1.NodeService.ListSnapshots
+     */
+    listSnapshots: {
+      name: "ListSnapshots",
+      I: ListSnapshotsRequest,
+      O: ListSnapshotsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc node.v1.NodeService.DiffSnapshot
+     */
+    diffSnapshot: {
+      name: "DiffSnapshot",
+      I: DiffSnapshotRequest,
+      O: DiffSnapshotResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc node.v1.NodeService.RestoreSnapshot
+     */
+    restoreSnapshot: {
+      name: "RestoreSnapshot",
+      I: RestoreSnapshotRequest,
+      O: RestoreSnapshotResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc node.v1.NodeService.Undo
+     */
+    undo: {
+      name: "Undo",
+      I: UndoRequest,
+      O: UndoResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc node.v1.NodeService.Redo
+     */
+    redo: {
+      name: "Redo",
+      I: RedoRequest,
+      O: RedoResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ★ Trash
+     *
+     * @generated from rpc node.v1.NodeService.ListTrash
+     */
+    listTrash: {
+      name: "ListTrash",
+      I: ListTrashRequest,
+      O: ListTrashResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc node.v1.NodeService.RestoreFromTrash
+     */
+    restoreFromTrash: {
+      name: "RestoreFromTrash",
+      I: RestoreFromTrashRequest,
+      O: RestoreFromTrashResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ★ Subtree
+     *
+     * @generated from rpc node.v1.NodeService.DeleteSubtree
+     */
+    deleteSubtree: {
+      name: "DeleteSubtree",
+      I: DeleteSubtreeRequest,
+      O: DeleteSubtreeResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc node.v1.NodeService.CopySubtree
+     */
+    copySubtree: {
+      name: "CopySubtree",
+      I: CopySubtreeRequest,
+      O: CopySubtreeResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc node.v1.NodeService.MoveSubtree
+     */
+    moveSubtree: {
+      name: "MoveSubtree",
+      I: MoveSubtreeRequest,
+      O: MoveSubtreeResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ★ Lineage
+     *
+     * @generated from rpc node.v1.NodeService.GetAncestors
+     */
+    getAncestors: {
+      name: "GetAncestors",
+      I: GetAncestorsRequest,
+      O: GetAncestorsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc node.v1.NodeService.GetDescendants
+     */
+    getDescendants: {
+      name: "GetDescendants",
+      I: GetDescendantsRequest,
+      O: GetDescendantsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc node.v1.NodeService.FindRelationship
+     */
+    findRelationship: {
+      name: "FindRelationship",
+      I: FindRelationshipRequest,
+      O: FindRelationshipResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ★ Search
+     *
+     * @generated from rpc node.v1.NodeService.SearchNodes
+     */
+    searchNodes: {
+      name: "SearchNodes",
+      I: SearchNodesRequest,
+      O: SearchNodesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ★ Realtime (server-streaming)
+     *
+     * @generated from rpc node.v1.NodeService.WatchTree
+     */
+    watchTree: {
+      name: "WatchTree",
+      I: WatchTreeRequest,
+      O: WatchTreeResponse,
+      kind: MethodKind.ServerStreaming,
+    },
     /**
      * ★ Public (ไม่ต้อง login)
      *
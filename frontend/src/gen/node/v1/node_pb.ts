@@ -4,13 +4,15 @@
 
 import type { GenEnum, GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
 import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { ContactPrivacy } from "../../tree/v1/tree_pb";
+import { file_tree_v1_tree } from "../../tree/v1/tree_pb";
 import type { Message } from "@bufbuild/protobuf";
 
 /**
  * Describes the file node/v1/node.proto.
  */
 export const file_node_v1_node: GenFile = /*@__PURE__*/
-  fileDesc("ChJub2RlL3YxL25vZGUucHJvdG8SB25vZGUudjEirwMKBE5vZGUSCgoCaWQYASABKAkSDwoHdHJlZV9pZBgCIAEoCRIWCglwYXJlbnRfaWQYAyABKAlIAIgBARIQCghuaWNrbmFtZRgEIAEoCRISCgpmaXJzdF9uYW1lGAUgASgJEhEKCWxhc3RfbmFtZRgGIAEoCRISCgpzdHVkZW50X2lkGAcgASgJEhIKCmdlbmVyYXRpb24YCCABKAUSEQoJcGhvdG9fdXJsGAkgASgJEiMKBnN0YXR1cxgKIAEoDjITLm5vZGUudjEuTm9kZVN0YXR1cxIVCg1zaWJsaW5nX29yZGVyGAsgASgFEhIKCnBvc2l0aW9uX3gYDCABKAESEgoKcG9zaXRpb25feRgNIAEoARISCgpjcmVhdGVkX2F0GA4gASgJEhIKCnVwZGF0ZWRfYXQYDyABKAkSEgoKcGFyZW50X2lkcxgQIAMoCRINCgVwaG9uZRgRIAEoCRINCgVlbWFpbBgSIAEoCRIPCgdsaW5lX2lkGBMgASgJEg8KB2Rpc2NvcmQYFCABKAkSEAoIZmFjZWJvb2sYFSABKAlCDAoKX3BhcmVudF9pZCLJAgoRQ3JlYXRlTm9kZVJlcXVlc3QSDwoHdHJlZV9pZBgBIAEoCRIWCglwYXJlbnRfaWQYAiABKAlIAIgBARIQCghuaWNrbmFtZRgDIAEoCRISCgpmaXJzdF9uYW1lGAQgASgJEhEKCWxhc3RfbmFtZRgFIAEoCRISCgpzdHVkZW50X2lkGAYgASgJEhEKCXBob3RvX3VybBgHIAEoCRIjCgZzdGF0dXMYCCABKA4yEy5ub2RlLnYxLk5vZGVTdGF0dXMSEgoKZ2VuZXJhdGlvbhgJIAEoBRISCgpwYXJlbnRfaWRzGAogAygJEg0KBXBob25lGAsgASgJEg0KBWVtYWlsGAwgASgJEg8KB2xpbmVfaWQYDSABKAkSDwoHZGlzY29yZBgOIAEoCRIQCghmYWNlYm9vaxgPIAEoCUIMCgpfcGFyZW50X2lkIjEKEkNyZWF0ZU5vZGVSZXNwb25zZRIbCgRub2RlGAEgASgLMg0ubm9kZS52MS5Ob2RlIooCChFVcGRhdGVOb2RlUmVxdWVzdBIKCgJpZBgBIAEoCRIQCghuaWNrbmFtZRgCIAEoCRISCgpmaXJzdF9uYW1lGAMgASgJEhEKCWxhc3RfbmFtZRgEIAEoCRISCgpzdHVkZW50X2lkGAUgASgJEhEKCXBob3RvX3VybBgGIAEoCRIjCgZzdGF0dXMYByABKA4yEy5ub2RlLnYxLk5vZGVTdGF0dXMSEgoKZ2VuZXJhdGlvbhgIIAEoBRINCgVwaG9uZRgJIAEoCRINCgVlbWFpbBgKIAEoCRIPCgdsaW5lX2lkGAsgASgJEg8KB2Rpc2NvcmQYDCABKAkSEAoIZmFjZWJvb2sYDSABKAkiMQoSVXBkYXRlTm9kZVJlc3BvbnNlEhsKBG5vZGUYASABKAsyDS5ub2RlLnYxLk5vZGUiHwoRRGVsZXRlTm9kZVJlcXVlc3QSCgoCaWQYASABKAkiFAoSRGVsZXRlTm9kZVJlc3BvbnNlIlAKD01vdmVOb2RlUmVxdWVzdBIPCgdub2RlX2lkGAEgASgJEhUKDW5ld19wYXJlbnRfaWQYAiABKAkSFQoNc2libGluZ19vcmRlchgDIAEoBSIvChBNb3ZlTm9kZVJlc3BvbnNlEhsKBG5vZGUYASABKAsyDS5ub2RlLnYxLk5vZGUiJgoTR2V0VHJlZU5vZGVzUmVxdWVzdBIPCgd0cmVlX2lkGAEgASgJIjQKFEdldFRyZWVOb2Rlc1Jlc3BvbnNlEhwKBW5vZGVzGAEgAygLMg0ubm9kZS52MS5Ob2RlIiQKEVVubGlua05vZGVSZXF1ZXN0Eg8KB25vZGVfaWQYASABKAkiMQoSVW5saW5rTm9kZVJlc3BvbnNlEhsKBG5vZGUYASABKAsyDS5ub2RlLnYxLk5vZGUiNgoQQWRkUGFyZW50UmVxdWVzdBIPCgdub2RlX2lkGAEgASgJEhEKCXBhcmVudF9pZBgCIAEoCSIwChFBZGRQYXJlbnRSZXNwb25zZRIbCgRub2RlGAEgASgLMg0ubm9kZS52MS5Ob2RlIjkKE1JlbW92ZVBhcmVudFJlcXVlc3QSDwoHbm9kZV9pZBgBIAEoCRIRCglwYXJlbnRfaWQYAiABKAkiMwoUUmVtb3ZlUGFyZW50UmVzcG9uc2USGwoEbm9kZRgBIAEoCzINLm5vZGUudjEuTm9kZSIyChtHZXROb2Rlc0J5U2hhcmVUb2tlblJlcXVlc3QSEwoLc2hhcmVfdG9rZW4YASABKAkiPAocR2V0Tm9kZXNCeVNoYXJlVG9rZW5SZXNwb25zZRIcCgVub2RlcxgBIAMoCzINLm5vZGUudjEuTm9kZSp3CgpOb2RlU3RhdHVzEhsKF05PREVfU1RBVFVTX1VOU1BFQ0lGSUVEEAASGAoUTk9ERV9TVEFUVVNfU1RVRFlJTkcQARIZChVOT0RFX1NUQVRVU19HUkFEVUFURUQQAhIXChNOT0RFX1NUQVRVU19SRVRJUkVEEAMyrQUKC05vZGVTZXJ2aWNlEkUKCkNyZWF0ZU5vZGUSGi5ub2RlLnYxLkNyZWF0ZU5vZGVSZXF1ZXN0Ghsubm9kZS52MS5DcmVhdGVOb2RlUmVzcG9uc2USRQoKVXBkYXRlTm9kZRIaLm5vZGUudjEuVXBkYXRlTm9kZVJlcXVlc3QaGy5ub2RlLnYxLlVwZGF0ZU5vZGVSZXNwb25zZRJFCgpEZWxldGVOb2RlEhoubm9kZS52MS5EZWxldGVOb2RlUmVxdWVzdBobLm5vZGUudjEuRGVsZXRlTm9kZVJlc3BvbnNlEj8KCE1vdmVOb2RlEhgubm9kZS52MS5Nb3ZlTm9kZVJlcXVlc3QaGS5ub2RlLnYxLk1vdmVOb2RlUmVzcG9uc2USRQoKVW5saW5rTm9kZRIaLm5vZGUudjEuVW5saW5rTm9kZVJlcXVlc3QaGy5ub2RlLnYxLlVubGlua05vZGVSZXNwb25zZRJLCgxHZXRUcmVlTm9kZXMSHC5ub2RlLnYxLkdldFRyZWVOb2Rlc1JlcXVlc3QaHS5ub2RlLnYxLkdldFRyZWVOb2Rlc1Jlc3BvbnNlEkIKCUFkZFBhcmVudBIZLm5vZGUudjEuQWRkUGFyZW50UmVxdWVzdBoaLm5vZGUudjEuQWRkUGFyZW50UmVzcG9uc2USSwoMUmVtb3ZlUGFyZW50Ehwubm9kZS52MS5SZW1vdmVQYXJlbnRSZXF1ZXN0Gh0ubm9kZS52MS5SZW1vdmVQYXJlbnRSZXNwb25zZRJjChRHZXROb2Rlc0J5U2hhcmVUb2tlbhIkLm5vZGUudjEuR2V0Tm9kZXNCeVNoYXJlVG9rZW5SZXF1ZXN0GiUubm9kZS52MS5HZXROb2Rlc0J5U2hhcmVUb2tlblJlc3BvbnNlQj5aPGdpdGh1Yi5jb20vVGl0bGVLdW5nLTAxL2NvZGUtdHJlZS1iYWNrZW5kL2dlbi9ub2RlL3YxO25vZGV2MWIGcHJvdG8z");
+  fileDesc("ChJub2RlL3YxL25vZGUucHJvdG8SB25vZGUudjEi0QQKBE5vZGUSCgoCaWQYASABKAkSDwoHdHJlZV9pZBgCIAEoCRIWCglwYXJlbnRfaWQYAyABKAlIAIgBARIQCghuaWNrbmFtZRgEIAEoCRISCgpmaXJzdF9uYW1lGAUgASgJEhEKCWxhc3RfbmFtZRgGIAEoCRISCgpzdHVkZW50X2lkGAcgASgJEhIKCmdlbmVyYXRpb24YCCABKAUSEQoJcGhvdG9fdXJsGAkgASgJEiMKBnN0YXR1cxgKIAEoDjITLm5vZGUudjEuTm9kZVN0YXR1cxIVCg1zaWJsaW5nX29yZGVyGAsgASgFEhIKCnBvc2l0aW9uX3gYDCABKAESEgoKcG9zaXRpb25feRgNIAEoARISCgpjcmVhdGVkX2F0GA4gASgJEhIKCnVwZGF0ZWRfYXQYDyABKAkSEgoKcGFyZW50X2lkcxgQIAMoCRINCgVwaG9uZRgRIAEoCRINCgVlbWFpbBgSIAEoCRIPCgdsaW5lX2lkGBMgASgJEg8KB2Rpc2NvcmQYFCABKAkSEAoIZmFjZWJvb2sYFSABKAkSOAoOc2libGluZ19vcmRlcnMYFiADKAsyIC5ub2RlLnYxLk5vZGUuU2libGluZ09yZGVyc0VudHJ5EjAKD2NvbnRhY3RfcHJpdmFjeRgXIAEoCzIXLnRyZWUudjEuQ29udGFjdFByaXZhY3kaNAoSU2libGluZ09yZGVyc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoBToCOAFCDAoKX3BhcmVudF9pZCLfAwoRQ3JlYXRlTm9kZVJlcXVlc3QSDwoHdHJlZV9pZBgBIAEoCRIWCglwYXJlbnRfaWQYAiABKAlIAIgBARIQCghuaWNrbmFtZRgDIAEoCRISCgpmaXJzdF9uYW1lGAQgASgJEhEKCWxhc3RfbmFtZRgFIAEoCRISCgpzdHVkZW50X2lkGAYgASgJEhEKCXBob3RvX3VybBgHIAEoCRIjCgZzdGF0dXMYCCABKA4yEy5ub2RlLnYxLk5vZGVTdGF0dXMSEgoKZ2VuZXJhdGlvbhgJIAEoBRISCgpwYXJlbnRfaWRzGAogAygJEg0KBXBob25lGAsgASgJEg0KBWVtYWlsGAwgASgJEg8KB2xpbmVfaWQYDSABKAkSDwoHZGlzY29yZBgOIAEoCRIQCghmYWNlYm9vaxgPIAEoCRIeChFleHBlY3RlZF9yZXZpc2lvbhgQIAEoA0gBiAEBEhoKDXNpYmxpbmdfb3JkZXIYESABKAVIAogBARIwCg9jb250YWN0X3ByaXZhY3kYEiABKAsyFy50cmVlLnYxLkNvbnRhY3RQcml2YWN5QgwKCl9wYXJlbnRfaWRCFAoSX2V4cGVjdGVkX3JldmlzaW9uQhAKDl9zaWJsaW5nX29yZGVyIk0KEkNyZWF0ZU5vZGVSZXNwb25zZRIbCgRub2RlGAEgASgLMg0ubm9kZS52MS5Ob2RlEhoKEnN0cnVjdHVyZV9yZXZpc2lvbhgCIAEoAyK8AgoRVXBkYXRlTm9kZVJlcXVlc3QSCgoCaWQYASABKAkSEAoIbmlja25hbWUYAiABKAkSEgoKZmlyc3RfbmFtZRgDIAEoCRIRCglsYXN0X25hbWUYBCABKAkSEgoKc3R1ZGVudF9pZBgFIAEoCRIRCglwaG90b191cmwYBiABKAkSIwoGc3RhdHVzGAcgASgOMhMubm9kZS52MS5Ob2RlU3RhdHVzEhIKCmdlbmVyYXRpb24YCCABKAUSDQoFcGhvbmUYCSABKAkSDQoFZW1haWwYCiABKAkSDwoHbGluZV9pZBgLIAEoCRIPCgdkaXNjb3JkGAwgASgJEhAKCGZhY2Vib29rGA0gASgJEjAKD2NvbnRhY3RfcHJpdmFjeRgOIAEoCzIXLnRyZWUudjEuQ29udGFjdFByaXZhY3kiMQoSVXBkYXRlTm9kZVJlc3BvbnNlEhsKBG5vZGUYASABKAsyDS5ub2RlLnYxLk5vZGUiVQoRRGVsZXRlTm9kZVJlcXVlc3QSCgoCaWQYASABKAkSHgoRZXhwZWN0ZWRfcmV2aXNpb24YAiABKANIAIgBAUIUChJfZXhwZWN0ZWRfcmV2aXNpb24iMAoSRGVsZXRlTm9kZVJlc3BvbnNlEhoKEnN0cnVjdHVyZV9yZXZpc2lvbhgBIAEoAyKdAQoPTW92ZU5vZGVSZXF1ZXN0Eg8KB25vZGVfaWQYASABKAkSFQoNbmV3X3BhcmVudF9pZBgCIAEoCRIaCg1zaWJsaW5nX29yZGVyGAMgASgFSACIAQESHgoRZXhwZWN0ZWRfcmV2aXNpb24YBCABKANIAYgBAUIQCg5fc2libGluZ19vcmRlckIUChJfZXhwZWN0ZWRfcmV2aXNpb24iSwoQTW92ZU5vZGVSZXNwb25zZRIbCgRub2RlGAEgASgLMg0ubm9kZS52MS5Ob2RlEhoKEnN0cnVjdHVyZV9yZXZpc2lvbhgCIAEoAyImChNHZXRUcmVlTm9kZXNSZXF1ZXN0Eg8KB3RyZWVfaWQYASABKAkiUAoUR2V0VHJlZU5vZGVzUmVzcG9uc2USHAoFbm9kZXMYASADKAsyDS5ub2RlLnYxLk5vZGUSGgoSc3RydWN0dXJlX3JldmlzaW9uGAIgASgDIloKEVVubGlua05vZGVSZXF1ZXN0Eg8KB25vZGVfaWQYASABKAkSHgoRZXhwZWN0ZWRfcmV2aXNpb24YAiABKANIAIgBAUIUChJfZXhwZWN0ZWRfcmV2aXNpb24iTQoSVW5saW5rTm9kZVJlc3BvbnNlEhsKBG5vZGUYASABKAsyDS5ub2RlLnYxLk5vZGUSGgoSc3RydWN0dXJlX3JldmlzaW9uGAIgASgDIpoBChBBZGRQYXJlbnRSZXF1ZXN0Eg8KB25vZGVfaWQYASABKAkSEQoJcGFyZW50X2lkGAIgASgJEh4KEWV4cGVjdGVkX3JldmlzaW9uGAMgASgDSACIAQESGgoNc2libGluZ19vcmRlchgEIAEoBUgBiAEBQhQKEl9leHBlY3RlZF9yZXZpc2lvbkIQCg5fc2libGluZ19vcmRlciJMChFBZGRQYXJlbnRSZXNwb25zZRIbCgRub2RlGAEgASgLMg0ubm9kZS52MS5Ob2RlEhoKEnN0cnVjdHVyZV9yZXZpc2lvbhgCIAEoAyJvChNSZW1vdmVQYXJlbnRSZXF1ZXN0Eg8KB25vZGVfaWQYASABKAkSEQoJcGFyZW50X2lkGAIgASgJEh4KEWV4cGVjdGVkX3JldmlzaW9uGAMgASgDSACIAQFCFAoSX2V4cGVjdGVkX3JldmlzaW9uIk8KFFJlbW92ZVBhcmVudFJlc3BvbnNlEhsKBG5vZGUYASABKAsyDS5ub2RlLnYxLk5vZGUSGgoSc3RydWN0dXJlX3JldmlzaW9uGAIgASgDIkcKDE5vZGVQb3NpdGlvbhIPCgdub2RlX2lkGAEgASgJEhIKCnBvc2l0aW9uX3gYAiABKAESEgoKcG9zaXRpb25feRgDIAEoASJQChNVcGRhdGVMYXlvdXRSZXF1ZXN0Eg8KB3RyZWVfaWQYASABKAkSKAoJcG9zaXRpb25zGAIgAygLMhUubm9kZS52MS5Ob2RlUG9zaXRpb24iLQoUVXBkYXRlTGF5b3V0UmVzcG9uc2USFQoNdXBkYXRlZF9jb3VudBgBIAEoBSIyChtHZXROb2Rlc0J5U2hhcmVUb2tlblJlcXVlc3QSEwoLc2hhcmVfdG9rZW4YASABKAkiPAocR2V0Tm9kZXNCeVNoYXJlVG9rZW5SZXNwb25zZRIcCgVub2RlcxgBIAMoCzINLm5vZGUudjEuTm9kZSKhAQoSSW1wb3J0Tm9kZXNSZXF1ZXN0Eg8KB3RyZWVfaWQYASABKAkSJQoGZm9ybWF0GAIgASgOMhUubm9kZS52MS5JbXBvcnRGb3JtYXQSDAoEZGF0YRgDIAEoDBIPCgdkcnlfcnVuGAQgASgIEh4KEWV4cGVjdGVkX3JldmlzaW9uGAUgASgDSACIAQFCFAoSX2V4cGVjdGVkX3JldmlzaW9uIjwKC0ltcG9ydElzc3VlEgwKBGxpbmUYASABKAUSDgoGY29sdW1uGAIgASgJEg8KB21lc3NhZ2UYAyABKAkimgEKE0ltcG9ydE5vZGVzUmVzcG9uc2USEgoKdG90YWxfcm93cxgBIAEoBRIkCgZpc3N1ZXMYAiADKAsyFC5ub2RlLnYxLkltcG9ydElzc3VlEg8KB2FwcGxpZWQYAyABKAgSHAoFbm9kZXMYBCADKAsyDS5ub2RlLnYxLk5vZGUSGgoSc3RydWN0dXJlX3JldmlzaW9uGAUgASgDIksKEUV4cG9ydFRyZWVSZXF1ZXN0Eg8KB3RyZWVfaWQYASABKAkSJQoGZm9ybWF0GAIgASgOMhUubm9kZS52MS5FeHBvcnRGb3JtYXQiSgoSRXhwb3J0VHJlZVJlc3BvbnNlEhAKCGZpbGVuYW1lGAEgASgJEhQKDGNvbnRlbnRfdHlwZRgCIAEoCRIMCgRkYXRhGAMgASgMIjoKEFdhdGNoVHJlZVJlcXVlc3QSDwoHdHJlZV9pZBgBIAEoCRIVCg1sYXN0X2V2ZW50X2lkGAIgASgDIr0BChFXYXRjaFRyZWVSZXNwb25zZRIQCghldmVudF9pZBgBIAEoAxIkCgR0eXBlGAIgASgOMhYubm9kZS52MS5UcmVlRXZlbnRUeXBlEg8KB25vZGVfaWQYAyABKAkSGwoEbm9kZRgEIAEoCzINLm5vZGUudjEuTm9kZRIWCg5vbGRfcGFyZW50X2lkcxgFIAMoCRIWCg5uZXdfcGFyZW50X2lkcxgGIAMoCRISCgpjcmVhdGVkX2F0GAcgASgJIvUBCghTbmFwc2hvdBIKCgJpZBgBIAEoCRIPCgd0cmVlX2lkGAIgASgJEhIKCmNyZWF0ZWRfYnkYAyABKAkSDgoGcmVhc29uGAQgASgJEg0KBWxhYmVsGAUgASgJEg0KBXNjb3BlGAsgASgJEhQKB25vZGVfaWQYBiABKAlIAIgBARIWCgl1bmRvbmVfYXQYByABKAlIAYgBARIaChJzdHJ1Y3R1cmVfcmV2aXNpb24YCCABKAMSEgoKbm9kZV9jb3VudBgJIAEoBRISCgpjcmVhdGVkX2F0GAogASgJQgoKCF9ub2RlX2lkQgwKCl91bmRvbmVfYXQiNwoVQ3JlYXRlU25hcHNob3RSZXF1ZXN0Eg8KB3RyZWVfaWQYASABKAkSDQoFbGFiZWwYAiABKAkiPQoWQ3JlYXRlU25hcHNob3RSZXNwb25zZRIjCghzbmFwc2hvdBgBIAEoCzIRLm5vZGUudjEuU25hcHNob3QiNgoUTGlzdFNuYXBzaG90c1JlcXVlc3QSDwoHdHJlZV9pZBgBIAEoCRINCgVsaW1pdBgCIAEoBSI9ChVMaXN0U25hcHNob3RzUmVzcG9uc2USJAoJc25hcHNob3RzGAEgAygLMhEubm9kZS52MS5TbmFwc2hvdCKjAQoOU25hcHNob3RDaGFuZ2USDwoHbm9kZV9pZBgBIAEoCRIQCghuaWNrbmFtZRgCIAEoCRIpCgR0eXBlGAMgASgOMhsubm9kZS52MS5TbmFwc2hvdENoYW5nZVR5cGUSDgoGZmllbGRzGAQgAygJEhkKEXBhcmVudF9pZHNfYmVmb3JlGAUgAygJEhgKEHBhcmVudF9pZHNfYWZ0ZXIYBiADKAkiOwoTRGlmZlNuYXBzaG90UmVxdWVzdBIPCgd0cmVlX2lkGAEgASgJEhMKC3NuYXBzaG90X2lkGAIgASgJIkAKFERpZmZTbmFwc2hvdFJlc3BvbnNlEigKB2NoYW5nZXMYASADKAsyFy5ub2RlLnYxLlNuYXBzaG90Q2hhbmdlIqABChZSZXN0b3JlU25hcHNob3RSZXF1ZXN0Eg8KB3RyZWVfaWQYASABKAkSEwoLc25hcHNob3RfaWQYAiABKAkSGQoMcm9vdF9ub2RlX2lkGAMgASgJSACIAQESHgoRZXhwZWN0ZWRfcmV2aXNpb24YBCABKANIAYgBAUIPCg1fcm9vdF9ub2RlX2lkQhQKEl9leHBlY3RlZF9yZXZpc2lvbiJ2ChdSZXN0b3JlU25hcHNob3RSZXNwb25zZRIcCgVub2RlcxgBIAMoCzINLm5vZGUudjEuTm9kZRIaChJzdHJ1Y3R1cmVfcmV2aXNpb24YAiABKAMSIQoGYmFja3VwGAMgASgLMhEubm9kZS52MS5TbmFwc2hvdCJUCgtVbmRvUmVxdWVzdBIPCgd0cmVlX2lkGAEgASgJEh4KEWV4cGVjdGVkX3JldmlzaW9uGAIgASgDSACIAQFCFAoSX2V4cGVjdGVkX3JldmlzaW9uIl8KDFVuZG9SZXNwb25zZRIcCgVub2RlcxgBIAMoCzINLm5vZGUudjEuTm9kZRIaChJzdHJ1Y3R1cmVfcmV2aXNpb24YAiABKAMSFQoNdW5kb25lX3JlYXNvbhgDIAEoCSJUCgtSZWRvUmVxdWVzdBIPCgd0cmVlX2lkGAEgASgJEh4KEWV4cGVjdGVkX3JldmlzaW9uGAIgASgDSACIAQFCFAoSX2V4cGVjdGVkX3JldmlzaW9uIl8KDFJlZG9SZXNwb25zZRIcCgVub2RlcxgBIAMoCzINLm5vZGUudjEuTm9kZRIaChJzdHJ1Y3R1cmVfcmV2aXNpb24YAiABKAMSFQoNcmVkb25lX3JlYXNvbhgDIAEoCSK+AQoJVHJhc2hJdGVtEiQKBHR5cGUYASABKA4yFi5ub2RlLnYxLlRyYXNoSXRlbVR5cGUSCgoCaWQYAiABKAkSDwoHdHJlZV9pZBgDIAEoCRIMCgRuYW1lGAQgASgJEhcKCmRlbGV0ZWRfYnkYBSABKAlIAIgBARISCgpkZWxldGVkX2F0GAYgASgJEhAKCHB1cmdlX2F0GAcgASgJEhIKCnBhcmVudF9pZHMYCCADKAlCDQoLX2RlbGV0ZWRfYnkiIwoQTGlzdFRyYXNoUmVxdWVzdBIPCgd0cmVlX2lkGAEgASgJIjYKEUxpc3RUcmFzaFJlc3BvbnNlEiEKBWl0ZW1zGAEgAygLMhIubm9kZS52MS5UcmFzaEl0ZW0igQEKF1Jlc3RvcmVGcm9tVHJhc2hSZXF1ZXN0EiQKBHR5cGUYASABKA4yFi5ub2RlLnYxLlRyYXNoSXRlbVR5cGUSCgoCaWQYAiABKAkSHgoRZXhwZWN0ZWRfcmV2aXNpb24YAyABKANIAIgBAUIUChJfZXhwZWN0ZWRfcmV2aXNpb24iUwoYUmVzdG9yZUZyb21UcmFzaFJlc3BvbnNlEhsKBG5vZGUYASABKAsyDS5ub2RlLnYxLk5vZGUSGgoSc3RydWN0dXJlX3JldmlzaW9uGAIgASgDIpUBChREZWxldGVTdWJ0cmVlUmVxdWVzdBIPCgdub2RlX2lkGAEgASgJEjYKDXNoYXJlZF9wb2xpY3kYAiABKA4yHy5ub2RlLnYxLlNoYXJlZERlc2NlbmRhbnRQb2xpY3kSHgoRZXhwZWN0ZWRfcmV2aXNpb24YAyABKANIAIgBAUIUChJfZXhwZWN0ZWRfcmV2aXNpb24iZgoVRGVsZXRlU3VidHJlZVJlc3BvbnNlEhgKEGRlbGV0ZWRfbm9kZV9pZHMYASADKAkSFwoPc2hhcmVkX25vZGVfaWRzGAIgAygJEhoKEnN0cnVjdHVyZV9yZXZpc2lvbhgDIAEoAyKHAgoSQ29weVN1YnRyZWVSZXF1ZXN0Eg8KB25vZGVfaWQYASABKAkSFgoOdGFyZ2V0X3RyZWVfaWQYAiABKAkSGgoNbmV3X3BhcmVudF9pZBgDIAEoCUgAiAEBEhoKDXNpYmxpbmdfb3JkZXIYBCABKAVIAYgBARI2Cg1zaGFyZWRfcG9saWN5GAUgASgOMh8ubm9kZS52MS5TaGFyZWREZXNjZW5kYW50UG9saWN5Eh4KEWV4cGVjdGVkX3JldmlzaW9uGAYgASgDSAKIAQFCEAoOX25ld19wYXJlbnRfaWRCEAoOX3NpYmxpbmdfb3JkZXJCFAoSX2V4cGVjdGVkX3JldmlzaW9uIuwBChNDb3B5U3VidHJlZVJlc3BvbnNlEhwKBW5vZGVzGAEgAygLMg0ubm9kZS52MS5Ob2RlEjcKBmlkX21hcBgCIAMoCzInLm5vZGUudjEuQ29weVN1YnRyZWVSZXNwb25zZS5JZE1hcEVudHJ5EhcKD3NoYXJlZF9ub2RlX2lkcxgDIAMoCRIbChNjbGVhcmVkX3N0dWRlbnRfaWRzGAQgAygJEhoKEnN0cnVjdHVyZV9yZXZpc2lvbhgFIAEoAxosCgpJZE1hcEVudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEi7wEKEk1vdmVTdWJ0cmVlUmVxdWVzdBIPCgdub2RlX2lkGAEgASgJEhoKDW5ld19wYXJlbnRfaWQYAiABKAlIAIgBARIaCg1zaWJsaW5nX29yZGVyGAMgASgFSAGIAQESNgoNc2hhcmVkX3BvbGljeRgEIAEoDjIfLm5vZGUudjEuU2hhcmVkRGVzY2VuZGFudFBvbGljeRIeChFleHBlY3RlZF9yZXZpc2lvbhgFIAEoA0gCiAEBQhAKDl9uZXdfcGFyZW50X2lkQhAKDl9zaWJsaW5nX29yZGVyQhQKEl9leHBlY3RlZF9yZXZpc2lvbiJoChNNb3ZlU3VidHJlZVJlc3BvbnNlEhwKBW5vZGVzGAEgAygLMg0ubm9kZS52MS5Ob2RlEhcKD3NoYXJlZF9ub2RlX2lkcxgCIAMoCRIaChJzdHJ1Y3R1cmVfcmV2aXNpb24YAyABKAMiOQoLTGluZWFnZU5vZGUSGwoEbm9kZRgBIAEoCzINLm5vZGUudjEuTm9kZRINCgVkZXB0aBgCIAEoBSI5ChNHZXRBbmNlc3RvcnNSZXF1ZXN0Eg8KB25vZGVfaWQYASABKAkSEQoJbWF4X2RlcHRoGAIgASgFIj8KFEdldEFuY2VzdG9yc1Jlc3BvbnNlEicKCWFuY2VzdG9ycxgBIAMoCzIULm5vZGUudjEuTGluZWFnZU5vZGUiOwoVR2V0RGVzY2VuZGFudHNSZXF1ZXN0Eg8KB25vZGVfaWQYASABKAkSEQoJbWF4X2RlcHRoGAIgASgFIkMKFkdldERlc2NlbmRhbnRzUmVzcG9uc2USKQoLZGVzY2VuZGFudHMYASADKAsyFC5ub2RlLnYxLkxpbmVhZ2VOb2RlIj8KF0ZpbmRSZWxhdGlvbnNoaXBSZXF1ZXN0EhEKCW5vZGVfYV9pZBgBIAEoCRIRCglub2RlX2JfaWQYAiABKAkisgEKGEZpbmRSZWxhdGlvbnNoaXBSZXNwb25zZRInCgRraW5kGAEgASgOMhkubm9kZS52MS5SZWxhdGlvbnNoaXBLaW5kEg0KBWxhYmVsGAIgASgJEh8KEmNvbW1vbl9hbmNlc3Rvcl9pZBgDIAEoCUgAiAEBEgoKAnVwGAQgASgFEgwKBGRvd24YBSABKAUSDAoEcGF0aBgGIAMoCUIVChNfY29tbW9uX2FuY2VzdG9yX2lkIqgBChJTZWFyY2hOb2Rlc1JlcXVlc3QSDQoFcXVlcnkYASABKAkSFAoHdHJlZV9pZBgCIAEoCUgAiAEBEhMKC2dlbmVyYXRpb25zGAMgAygFEiUKCHN0YXR1c2VzGAQgAygOMhMubm9kZS52MS5Ob2RlU3RhdHVzEhEKCXBhZ2Vfc2l6ZRgFIAEoBRISCgpwYWdlX3Rva2VuGAYgASgJQgoKCF90cmVlX2lkIkoKCVNlYXJjaEhpdBIbCgRub2RlGAEgASgLMg0ubm9kZS52MS5Ob2RlEg0KBXNjb3JlGAIgASgBEhEKCXRyZWVfbmFtZRgDIAEoCSJQChNTZWFyY2hOb2Rlc1Jlc3BvbnNlEiAKBGhpdHMYASADKAsyEi5ub2RlLnYxLlNlYXJjaEhpdBIXCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAkqdwoKTm9kZVN0YXR1cxIbChdOT0RFX1NUQVRVU19VTlNQRUNJRklFRBAAEhgKFE5PREVfU1RBVFVTX1NUVURZSU5HEAESGQoVTk9ERV9TVEFUVVNfR1JBRFVBVEVEEAISFwoTTk9ERV9TVEFUVVNfUkVUSVJFRBADKn0KDEltcG9ydEZvcm1hdBIdChlJTVBPUlRfRk9STUFUX1VOU1BFQ0lGSUVEEAASFQoRSU1QT1JUX0ZPUk1BVF9DU1YQARIWChJJTVBPUlRfRk9STUFUX1hMU1gQAhIfChtJTVBPUlRfRk9STUFUX0pTT05fU05BUFNIT1QQAyqaAQoMRXhwb3J0Rm9ybWF0Eh0KGUVYUE9SVF9GT1JNQVRfVU5TUEVDSUZJRUQQABIfChtFWFBPUlRfRk9STUFUX0pTT05fU05BUFNIT1QQARIVChFFWFBPUlRfRk9STUFUX0RPVBACEhkKFUVYUE9SVF9GT1JNQVRfR1JBUEhNTBADEhgKFEVYUE9SVF9GT1JNQVRfR0VEQ09NEAQq4AEKDVRyZWVFdmVudFR5cGUSHwobVFJFRV9FVkVOVF9UWVBFX1VOU1BFQ0lGSUVEEAASIAocVFJFRV9FVkVOVF9UWVBFX05PREVfQ1JFQVRFRBABEiAKHFRSRUVfRVZFTlRfVFlQRV9OT0RFX1VQREFURUQQAhIgChxUUkVFX0VWRU5UX1RZUEVfTk9ERV9ERUxFVEVEEAMSHgoaVFJFRV9FVkVOVF9UWVBFX05PREVfTU9WRUQQBBIoCiRUUkVFX0VWRU5UX1RZUEVfTk9ERV9QQVJFTlRTX0NIQU5HRUQQBSqfAQoSU25hcHNob3RDaGFuZ2VUeXBlEiQKIFNOQVBTSE9UX0NIQU5HRV9UWVBFX1VOU1BFQ0lGSUVEEAASHgoaU05BUFNIT1RfQ0hBTkdFX1RZUEVfQURERUQQARIgChxTTkFQU0hPVF9DSEFOR0VfVFlQRV9SRU1PVkVEEAISIQodU05BUFNIT1RfQ0hBTkdFX1RZUEVfTU9ESUZJRUQQAypkCg1UcmFzaEl0ZW1UeXBlEh8KG1RSQVNIX0lURU1fVFlQRV9VTlNQRUNJRklFRBAAEhgKFFRSQVNIX0lURU1fVFlQRV9UUkVFEAESGAoUVFJBU0hfSVRFTV9UWVBFX05PREUQAiqJAQoWU2hhcmVkRGVzY2VuZGFudFBvbGljeRIoCiRTSEFSRURfREVTQ0VOREFOVF9QT0xJQ1lfVU5TUEVDSUZJRUQQABIiCh5TSEFSRURfREVTQ0VOREFOVF9QT0xJQ1lfTEVBVkUQARIhCh1TSEFSRURfREVTQ0VOREFOVF9QT0xJQ1lfVEFLRRACKvEBChBSZWxhdGlvbnNoaXBLaW5kEiEKHVJFTEFUSU9OU0hJUF9LSU5EX1VOU1BFQ0lGSUVEEAASHwobUkVMQVRJT05TSElQX0tJTkRfVU5SRUxBVEVEEAESGgoWUkVMQVRJT05TSElQX0tJTkRfU0VMRhACEh4KGlJFTEFUSU9OU0hJUF9LSU5EX0FOQ0VTVE9SEAMSIAocUkVMQVRJT05TSElQX0tJTkRfREVTQ0VOREFOVBAEEh0KGVJFTEFUSU9OU0hJUF9LSU5EX1NJQkxJTkcQBRIcChhSRUxBVElPTlNISVBfS0lORF9DT1VTSU4QBjLFEAoLTm9kZVNlcnZpY2USRQoKQ3JlYXRlTm9kZRIaLm5vZGUudjEuQ3JlYXRlTm9kZVJlcXVlc3QaGy5ub2RlLnYxLkNyZWF0ZU5vZGVSZXNwb25zZRJFCgpVcGRhdGVOb2RlEhoubm9kZS52MS5VcGRhdGVOb2RlUmVxdWVzdBobLm5vZGUudjEuVXBkYXRlTm9kZVJlc3BvbnNlEkUKCkRlbGV0ZU5vZGUSGi5ub2RlLnYxLkRlbGV0ZU5vZGVSZXF1ZXN0Ghsubm9kZS52MS5EZWxldGVOb2RlUmVzcG9uc2USPwoITW92ZU5vZGUSGC5ub2RlLnYxLk1vdmVOb2RlUmVxdWVzdBoZLm5vZGUudjEuTW92ZU5vZGVSZXNwb25zZRJFCgpVbmxpbmtOb2RlEhoubm9kZS52MS5VbmxpbmtOb2RlUmVxdWVzdBobLm5vZGUudjEuVW5saW5rTm9kZVJlc3BvbnNlEksKDEdldFRyZWVOb2RlcxIcLm5vZGUudjEuR2V0VHJlZU5vZGVzUmVxdWVzdBodLm5vZGUudjEuR2V0VHJlZU5vZGVzUmVzcG9uc2USQgoJQWRkUGFyZW50Ehkubm9kZS52MS5BZGRQYXJlbnRSZXF1ZXN0Ghoubm9kZS52MS5BZGRQYXJlbnRSZXNwb25zZRJLCgxSZW1vdmVQYXJlbnQSHC5ub2RlLnYxLlJlbW92ZVBhcmVudFJlcXVlc3QaHS5ub2RlLnYxLlJlbW92ZVBhcmVudFJlc3BvbnNlEksKDFVwZGF0ZUxheW91dBIcLm5vZGUudjEuVXBkYXRlTGF5b3V0UmVxdWVzdBodLm5vZGUudjEuVXBkYXRlTGF5b3V0UmVzcG9uc2USSAoLSW1wb3J0Tm9kZXMSGy5ub2RlLnYxLkltcG9ydE5vZGVzUmVxdWVzdBocLm5vZGUudjEuSW1wb3J0Tm9kZXNSZXNwb25zZRJFCgpFeHBvcnRUcmVlEhoubm9kZS52MS5FeHBvcnRUcmVlUmVxdWVzdBobLm5vZGUudjEuRXhwb3J0VHJlZVJlc3BvbnNlElEKDkNyZWF0ZVNuYXBzaG90Eh4ubm9kZS52MS5DcmVhdGVTbmFwc2hvdFJlcXVlc3QaHy5ub2RlLnYxLkNyZWF0ZVNuYXBzaG90UmVzcG9uc2USTgoNTGlzdFNuYXBzaG90cxIdLm5vZGUudjEuTGlzdFNuYXBzaG90c1JlcXVlc3QaHi5ub2RlLnYxLkxpc3RTbmFwc2hvdHNSZXNwb25zZRJLCgxEaWZmU25hcHNob3QSHC5ub2RlLnYxLkRpZmZTbmFwc2hvdFJlcXVlc3QaHS5ub2RlLnYxLkRpZmZTbmFwc2hvdFJlc3BvbnNlElQKD1Jlc3RvcmVTbmFwc2hvdBIfLm5vZGUudjEuUmVzdG9yZVNuYXBzaG90UmVxdWVzdBogLm5vZGUudjEuUmVzdG9yZVNuYXBzaG90UmVzcG9uc2USMwoEVW5kbxIULm5vZGUudjEuVW5kb1JlcXVlc3QaFS5ub2RlLnYxLlVuZG9SZXNwb25zZRIzCgRSZWRvEhQubm9kZS52MS5SZWRvUmVxdWVzdBoVLm5vZGUudjEuUmVkb1Jlc3BvbnNlEkIKCUxpc3RUcmFzaBIZLm5vZGUudjEuTGlzdFRyYXNoUmVxdWVzdBoaLm5vZGUudjEuTGlzdFRyYXNoUmVzcG9uc2USVwoQUmVzdG9yZUZyb21UcmFzaBIgLm5vZGUudjEuUmVzdG9yZUZyb21UcmFzaFJlcXVlc3QaIS5ub2RlLnYxLlJlc3RvcmVGcm9tVHJhc2hSZXNwb25zZRJOCg1EZWxldGVTdWJ0cmVlEh0ubm9kZS52MS5EZWxldGVTdWJ0cmVlUmVxdWVzdBoeLm5vZGUudjEuRGVsZXRlU3VidHJlZVJlc3BvbnNlEkgKC0NvcHlTdWJ0cmVlEhsubm9kZS52MS5Db3B5U3VidHJlZVJlcXVlc3QaHC5ub2RlLnYxLkNvcHlTdWJ0cmVlUmVzcG9uc2USSAoLTW92ZVN1YnRyZWUSGy5ub2RlLnYxLk1vdmVTdWJ0cmVlUmVxdWVzdBocLm5vZGUudjEuTW92ZVN1YnRyZWVSZXNwb25zZRJLCgxHZXRBbmNlc3RvcnMSHC5ub2RlLnYxLkdldEFuY2VzdG9yc1JlcXVlc3QaHS5ub2RlLnYxLkdldEFuY2VzdG9yc1Jlc3BvbnNlElEKDkdldERlc2NlbmRhbnRzEh4ubm9kZS52MS5HZXREZXNjZW5kYW50c1JlcXVlc3QaHy5ub2RlLnYxLkdldERlc2NlbmRhbnRzUmVzcG9uc2USVwoQRmluZFJlbGF0aW9uc2hpcBIgLm5vZGUudjEuRmluZFJlbGF0aW9uc2hpcFJlcXVlc3QaIS5ub2RlLnYxLkZpbmRSZWxhdGlvbnNoaXBSZXNwb25zZRJICgtTZWFyY2hOb2RlcxIbLm5vZGUudjEuU2VhcmNoTm9kZXNSZXF1ZXN0Ghwubm9kZS52MS5TZWFyY2hOb2Rlc1Jlc3BvbnNlEkQKCVdhdGNoVHJlZRIZLm5vZGUudjEuV2F0Y2hUcmVlUmVxdWVzdBoaLm5vZGUudjEuV2F0Y2hUcmVlUmVzcG9uc2UwARJjChRHZXROb2Rlc0J5U2hhcmVUb2tlbhIkLm5vZGUudjEuR2V0Tm9kZXNCeVNoYXJlVG9rZW5SZXF1ZXN0GiUubm9kZS52MS5HZXROb2Rlc0J5U2hhcmVUb2tlblJlc3BvbnNlQj5aPGdpdGh1Yi5jb20vVGl0bGVLdW5nLTAxL2NvZGUtdHJlZS1iYWNrZW5kL2dlbi9ub2RlL3YxO25vZGV2MWIGcHJvdG8z", [file_tree_v1_tree]);
 
 /**
  * @generated from message node.v1.Node
@@ -69,6 +71,8 @@ export type Node = Message<"node.v1.Node"> & {
   status: NodeStatus;
 
   /**
+   * ลำดับใน children ของ parent_ids[0] (root = ลำดับใน rootIds)
+   *
    * @generated from field: int32 sibling_order = 11;
    */
   siblingOrder: number;
@@ -126,6 +130,21 @@ export type Node = Message<"node.v1.Node"> & {
    * @generated from field: string facebook = 21;
    */
   facebook: string;
+
+  /**
+   * parent_id → ลำดับใน children ของ parent นั้น (multi-parent)
+   *
+   * @generated from field: map<string, int32> sibling_orders = 22;
+   */
+  siblingOrders: { [key: string]: number };
+
+  /**
+   * visibility ที่ตั้งไว้ราย node (ส่งให้ editor / owner เท่านั้น)
+   * ช่องทางติดต่อที่ caller ไม่มีสิทธิ์เห็นจะเป็น "" ใน field ด้านบน
+   *
+   * @generated from field: tree.v1.ContactPrivacy contact_privacy = 23;
+   */
+  contactPrivacy?: ContactPrivacy;
 };
 
 /**
@@ -219,6 +238,27 @@ export type CreateNodeRequest = Message<"node.v1.CreateNodeRequest"> & {
    * @generated from field: string facebook = 15;
    */
   facebook: string;
+
+  /**
+   * structure_revision ที่ client เห็นล่าสุด ถ้าไม่ตรงกับ server จะได้ CodeAborted
+   *
+   * @generated from field: optional int64 expected_revision = 16;
+   */
+  expectedRevision?: bigint;
+
+  /**
+   * ลำดับใน children ของ parent ตัวแรก (หรือใน rootIds) ไม่ส่ง = ต่อท้าย
+   *
+   * @generated from field: optional int32 sibling_order = 17;
+   */
+  siblingOrder?: number;
+
+  /**
+   * visibility ราย node (ไม่ส่ง = ใช้ค่าของ tree)
+   *
+   * @generated from field: tree.v1.ContactPrivacy contact_privacy = 18;
+   */
+  contactPrivacy?: ContactPrivacy;
 };
 
 /**
@@ -236,6 +276,13 @@ export type CreateNodeResponse = Message<"node.v1.CreateNodeResponse"> & {
    * @generated from field: node.v1.Node node = 1;
    */
   node?: Node;
+
+  /**
+   * revision ใหม่หลังแก้
+   *
+   * @generated from field: int64 structure_revision = 2;
+   */
+  structureRevision: bigint;
 };
 
 /**
@@ -315,6 +362,13 @@ export type UpdateNodeRequest = Message<"node.v1.UpdateNodeRequest"> & {
    * @generated from field: string facebook = 13;
    */
   facebook: string;
+
+  /**
+   * visibility ราย node (ไม่ส่ง = คงค่าเดิม, field เป็น UNSPECIFIED = ใช้ค่าของ tree)
+   *
+   * @generated from field: tree.v1.ContactPrivacy contact_privacy = 14;
+   */
+  contactPrivacy?: ContactPrivacy;
 };
 
 /**
@@ -342,6 +396,8 @@ export const UpdateNodeResponseSchema: GenMessage<UpdateNodeResponse> = /*@__PUR
   messageDesc(file_node_v1_node, 4);
 
 /**
+ * ลบ = ย้ายลงถังขยะ (กู้คืนด้วย RestoreFromTrash) children ย้ายขึ้นไปต่อกับ parent
+ *
  * @generated from message node.v1.DeleteNodeRequest
  */
 export type DeleteNodeRequest = Message<"node.v1.DeleteNodeRequest"> & {
@@ -349,6 +405,11 @@ export type DeleteNodeRequest = Message<"node.v1.DeleteNodeRequest"> & {
    * @generated from field: string id = 1;
    */
   id: string;
+
+  /**
+   * @generated from field: optional int64 expected_revision = 2;
+   */
+  expectedRevision?: bigint;
 };
 
 /**
@@ -362,6 +423,10 @@ export const DeleteNodeRequestSchema: GenMessage<DeleteNodeRequest> = /*@__PURE_
  * @generated from message node.v1.DeleteNodeResponse
  */
 export type DeleteNodeResponse = Message<"node.v1.DeleteNodeResponse"> & {
+  /**
+   * @generated from field: int64 structure_revision = 1;
+   */
+  structureRevision: bigint;
 };
 
 /**
@@ -388,9 +453,16 @@ export type MoveNodeRequest = Message<"node.v1.MoveNodeRequest"> & {
   newParentId: string;
 
   /**
-   * @generated from field: int32 sibling_order = 3;
+   * ลำดับใน children ของ parent ใหม่ ไม่ส่ง = ต่อท้าย
+   *
+   * @generated from field: optional int32 sibling_order = 3;
+   */
+  siblingOrder?: number;
+
+  /**
+   * @generated from field: optional int64 expected_revision = 4;
    */
-  siblingOrder: number;
+  expectedRevision?: bigint;
 };
 
 /**
@@ -408,6 +480,11 @@ export type MoveNodeResponse = Message<"node.v1.MoveNodeResponse"> & {
    * @generated from field: node.v1.Node node = 1;
    */
   node?: Node;
+
+  /**
+   * @generated from field: int64 structure_revision = 2;
+   */
+  structureRevision: bigint;
 };
 
 /**
@@ -442,6 +519,13 @@ export type GetTreeNodesResponse = Message<"node.v1.GetTreeNodesResponse"> & {
    * @generated from field: repeated node.v1.Node nodes = 1;
    */
   nodes: Node[];
+
+  /**
+   * ใช้เป็น expected_revision ตอนแก้ครั้งถัดไป
+   *
+   * @generated from field: int64 structure_revision = 2;
+   */
+  structureRevision: bigint;
 };
 
 /**
@@ -461,6 +545,11 @@ export type UnlinkNodeRequest = Message<"node.v1.UnlinkNodeRequest"> & {
    * @generated from field: string node_id = 1;
    */
   nodeId: string;
+
+  /**
+   * @generated from field: optional int64 expected_revision = 2;
+   */
+  expectedRevision?: bigint;
 };
 
 /**
@@ -478,6 +567,11 @@ export type UnlinkNodeResponse = Message<"node.v1.UnlinkNodeResponse"> & {
    * @generated from field: node.v1.Node node = 1;
    */
   node?: Node;
+
+  /**
+   * @generated from field: int64 structure_revision = 2;
+   */
+  structureRevision: bigint;
 };
 
 /**
@@ -506,6 +600,18 @@ export type AddParentRequest = Message<"node.v1.AddParentRequest"> & {
    * @generated from field: string parent_id = 2;
    */
   parentId: string;
+
+  /**
+   * @generated from field: optional int64 expected_revision = 3;
+   */
+  expectedRevision?: bigint;
+
+  /**
+   * ลำดับใน children ของ parent นี้ ไม่ส่ง = ต่อท้าย
+   *
+   * @generated from field: optional int32 sibling_order = 4;
+   */
+  siblingOrder?: number;
 };
 
 /**
@@ -523,6 +629,11 @@ export type AddParentResponse = Message<"node.v1.AddParentResponse"> & {
    * @generated from field: node.v1.Node node = 1;
    */
   node?: Node;
+
+  /**
+   * @generated from field: int64 structure_revision = 2;
+   */
+  structureRevision: bigint;
 };
 
 /**
@@ -551,6 +662,11 @@ export type RemoveParentRequest = Message<"node.v1.RemoveParentRequest"> & {
    * @generated from field: string parent_id = 2;
    */
   parentId: string;
+
+  /**
+   * @generated from field: optional int64 expected_revision = 3;
+   */
+  expectedRevision?: bigint;
 };
 
 /**
@@ -568,6 +684,11 @@ export type RemoveParentResponse = Message<"node.v1.RemoveParentResponse"> & {
    * @generated from field: node.v1.Node node = 1;
    */
   node?: Node;
+
+  /**
+   * @generated from field: int64 structure_revision = 2;
+   */
+  structureRevision: bigint;
 };
 
 /**
@@ -577,6 +698,74 @@ export type RemoveParentResponse = Message<"node.v1.RemoveParentResponse"> & {
 export const RemoveParentResponseSchema: GenMessage<RemoveParentResponse> = /*@__PURE__*/
   messageDesc(file_node_v1_node, 16);
 
+/**
+ * ★ Layout: บันทึกตำแหน่งการ์ดบน canvas หลาย node ในครั้งเดียว
+ *
+ * @generated from message node.v1.NodePosition
+ */
+export type NodePosition = Message<"node.v1.NodePosition"> & {
+  /**
+   * @generated from field: string node_id = 1;
+   */
+  nodeId: string;
+
+  /**
+   * @generated from field: double position_x = 2;
+   */
+  positionX: number;
+
+  /**
+   * @generated from field: double position_y = 3;
+   */
+  positionY: number;
+};
+
+/**
+ * Describes the message node.v1.NodePosition.
+ * Use `create(NodePositionSchema)` to create a new message.
+ */
+export const NodePositionSchema: GenMessage<NodePosition> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 17);
+
+/**
+ * @generated from message node.v1.UpdateLayoutRequest
+ */
+export type UpdateLayoutRequest = Message<"node.v1.UpdateLayoutRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+
+  /**
+   * @generated from field: repeated node.v1.NodePosition positions = 2;
+   */
+  positions: NodePosition[];
+};
+
+/**
+ * Describes the message node.v1.UpdateLayoutRequest.
+ * Use `create(UpdateLayoutRequestSchema)` to create a new message.
+ */
+export const UpdateLayoutRequestSchema: GenMessage<UpdateLayoutRequest> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 18);
+
+/**
+ * @generated from message node.v1.UpdateLayoutResponse
+ */
+export type UpdateLayoutResponse = Message<"node.v1.UpdateLayoutResponse"> & {
+  /**
+   * @generated from field: int32 updated_count = 1;
+   */
+  updatedCount: number;
+};
+
+/**
+ * Describes the message node.v1.UpdateLayoutResponse.
+ * Use `create(UpdateLayoutResponseSchema)` to create a new message.
+ */
+export const UpdateLayoutResponseSchema: GenMessage<UpdateLayoutResponse> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 19);
+
 /**
  * ★ Public: ดู nodes ผ่าน share token (ไม่ต้อง login)
  *
@@ -594,7 +783,7 @@ export type GetNodesByShareTokenRequest = Message<"node.v1.GetNodesByShareTokenR
  * Use `create(GetNodesByShareTokenRequestSchema)` to create a new message.
  */
 export const GetNodesByShareTokenRequestSchema: GenMessage<GetNodesByShareTokenRequest> = /*@__PURE__*/
-  messageDesc(file_node_v1_node, 17);
+  messageDesc(file_node_v1_node, 20);
 
 /**
  * @generated from message node.v1.GetNodesByShareTokenResponse
@@ -611,106 +800,1865 @@ export type GetNodesByShareTokenResponse = Message<"node.v1.GetNodesByShareToken
  * Use `create(GetNodesByShareTokenResponseSchema)` to create a new message.
  */
 export const GetNodesByShareTokenResponseSchema: GenMessage<GetNodesByShareTokenResponse> = /*@__PURE__*/
-  messageDesc(file_node_v1_node, 18);
+  messageDesc(file_node_v1_node, 21);
 
 /**
- * @generated from enum node.v1.NodeStatus
+ * @generated from message node.v1.ImportNodesRequest
  */
-export enum NodeStatus {
+export type ImportNodesRequest = Message<"node.v1.ImportNodesRequest"> & {
   /**
-   * @generated from enum value: NODE_STATUS_UNSPECIFIED = 0;
+   * @generated from field: string tree_id = 1;
    */
-  UNSPECIFIED = 0,
+  treeId: string;
 
   /**
-   * @generated from enum value: NODE_STATUS_STUDYING = 1;
+   * @generated from field: node.v1.ImportFormat format = 2;
    */
-  STUDYING = 1,
+  format: ImportFormat;
 
   /**
-   * @generated from enum value: NODE_STATUS_GRADUATED = 2;
+   * @generated from field: bytes data = 3;
    */
-  GRADUATED = 2,
+  data: Uint8Array;
 
   /**
-   * @generated from enum value: NODE_STATUS_RETIRED = 3;
+   * true = ตรวจอย่างเดียว ไม่บันทึก
+   *
+   * @generated from field: bool dry_run = 4;
    */
-  RETIRED = 3,
-}
+  dryRun: boolean;
+
+  /**
+   * @generated from field: optional int64 expected_revision = 5;
+   */
+  expectedRevision?: bigint;
+};
 
 /**
- * Describes the enum node.v1.NodeStatus.
+ * Describes the message node.v1.ImportNodesRequest.
+ * Use `create(ImportNodesRequestSchema)` to create a new message.
  */
-export const NodeStatusSchema: GenEnum<NodeStatus> = /*@__PURE__*/
-  enumDesc(file_node_v1_node, 0);
+export const ImportNodesRequestSchema: GenMessage<ImportNodesRequest> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 22);
 
 /**
- * @generated from service node.v1.NodeService
+ * @generated from message node.v1.ImportIssue
  */
-export const NodeService: GenService<{
+export type ImportIssue = Message<"node.v1.ImportIssue"> & {
   /**
-   * @generated from rpc node.v1.NodeService.CreateNode
+   * แถวในไฟล์ (header = 1)
+   *
+   * @generated from field: int32 line = 1;
    */
-  createNode: {
-    methodKind: "unary";
-    input: typeof CreateNodeRequestSchema;
-    output: typeof CreateNodeResponseSchema;
-  },
+  line: number;
+
   /**
-   * @generated from rpc node.v1.NodeService.UpdateNode
+   * @generated from field: string column = 2;
    */
-  updateNode: {
-    methodKind: "unary";
-    input: typeof UpdateNodeRequestSchema;
-    output: typeof UpdateNodeResponseSchema;
-  },
+  column: string;
+
   /**
-   * @generated from rpc node.v1.NodeService.DeleteNode
+   * @generated from field: string message = 3;
    */
-  deleteNode: {
-    methodKind: "unary";
-    input: typeof DeleteNodeRequestSchema;
-    output: typeof DeleteNodeResponseSchema;
-  },
+  message: string;
+};
+
+/**
+ * Describes the message node.v1.ImportIssue.
+ * Use `create(ImportIssueSchema)` to create a new message.
+ */
+export const ImportIssueSchema: GenMessage<ImportIssue> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 23);
+
+/**
+ * @generated from message node.v1.ImportNodesResponse
+ */
+export type ImportNodesResponse = Message<"node.v1.ImportNodesResponse"> & {
   /**
-   * @generated from rpc node.v1.NodeService.MoveNode
+   * @generated from field: int32 total_rows = 1;
    */
-  moveNode: {
-    methodKind: "unary";
-    input: typeof MoveNodeRequestSchema;
-    output: typeof MoveNodeResponseSchema;
-  },
+  totalRows: number;
+
   /**
-   * @generated from rpc node.v1.NodeService.UnlinkNode
+   * มี issue = ไม่ import อะไรเลย
+   *
+   * @generated from field: repeated node.v1.ImportIssue issues = 2;
    */
-  unlinkNode: {
-    methodKind: "unary";
-    input: typeof UnlinkNodeRequestSchema;
-    output: typeof UnlinkNodeResponseSchema;
-  },
+  issues: ImportIssue[];
+
   /**
-   * @generated from rpc node.v1.NodeService.GetTreeNodes
+   * @generated from field: bool applied = 3;
    */
-  getTreeNodes: {
-    methodKind: "unary";
-    input: typeof GetTreeNodesRequestSchema;
-    output: typeof GetTreeNodesResponseSchema;
-  },
+  applied: boolean;
+
   /**
-   * @generated from rpc node.v1.NodeService.AddParent
+   * node ที่สร้างใหม่ (เมื่อ applied)
+   *
+   * @generated from field: repeated node.v1.Node nodes = 4;
    */
-  addParent: {
-    methodKind: "unary";
-    input: typeof AddParentRequestSchema;
-    output: typeof AddParentResponseSchema;
-  },
+  nodes: Node[];
+
   /**
-   * @generated from rpc node.v1.NodeService.RemoveParent
+   * @generated from field: int64 structure_revision = 5;
    */
-  removeParent: {
-    methodKind: "unary";
-    input: typeof RemoveParentRequestSchema;
-    output: typeof RemoveParentResponseSchema;
+  structureRevision: bigint;
+};
+
+/**
+ * Describes the message node.v1.ImportNodesResponse.
+ * Use `create(ImportNodesResponseSchema)` to create a new message.
+ */
+export const ImportNodesResponseSchema: GenMessage<ImportNodesResponse> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 24);
+
+/**
+ * @generated from message node.v1.ExportTreeRequest
+ */
+export type ExportTreeRequest = Message<"node.v1.ExportTreeRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+
+  /**
+   * @generated from field: node.v1.ExportFormat format = 2;
+   */
+  format: ExportFormat;
+};
+
+/**
+ * Describes the message node.v1.ExportTreeRequest.
+ * Use `create(ExportTreeRequestSchema)` to create a new message.
+ */
+export const ExportTreeRequestSchema: GenMessage<ExportTreeRequest> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 25);
+
+/**
+ * @generated from message node.v1.ExportTreeResponse
+ */
+export type ExportTreeResponse = Message<"node.v1.ExportTreeResponse"> & {
+  /**
+   * @generated from field: string filename = 1;
+   */
+  filename: string;
+
+  /**
+   * @generated from field: string content_type = 2;
+   */
+  contentType: string;
+
+  /**
+   * @generated from field: bytes data = 3;
+   */
+  data: Uint8Array;
+};
+
+/**
+ * Describes the message node.v1.ExportTreeResponse.
+ * Use `create(ExportTreeResponseSchema)` to create a new message.
+ */
+export const ExportTreeResponseSchema: GenMessage<ExportTreeResponse> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 26);
+
+/**
+ * @generated from message node.v1.WatchTreeRequest
+ */
+export type WatchTreeRequest = Message<"node.v1.WatchTreeRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+
+  /**
+   * resume: event id ล่าสุดที่เห็น (0 = รับเฉพาะ event ใหม่)
+   *
+   * @generated from field: int64 last_event_id = 2;
+   */
+  lastEventId: bigint;
+};
+
+/**
+ * Describes the message node.v1.WatchTreeRequest.
+ * Use `create(WatchTreeRequestSchema)` to create a new message.
+ */
+export const WatchTreeRequestSchema: GenMessage<WatchTreeRequest> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 27);
+
+/**
+ * @generated from message node.v1.WatchTreeResponse
+ */
+export type WatchTreeResponse = Message<"node.v1.WatchTreeResponse"> & {
+  /**
+   * เรียงตามลำดับ commit (ใช้เป็น last_event_id ตอน resume)
+   *
+   * @generated from field: int64 event_id = 1;
+   */
+  eventId: bigint;
+
+  /**
+   * @generated from field: node.v1.TreeEventType type = 2;
+   */
+  type: TreeEventType;
+
+  /**
+   * @generated from field: string node_id = 3;
+   */
+  nodeId: string;
+
+  /**
+   * ข้อมูลล่าสุดของ node (ไม่มีถ้า node ถูกลบไปแล้ว)
+   *
+   * @generated from field: node.v1.Node node = 4;
+   */
+  node?: Node;
+
+  /**
+   * @generated from field: repeated string old_parent_ids = 5;
+   */
+  oldParentIds: string[];
+
+  /**
+   * @generated from field: repeated string new_parent_ids = 6;
+   */
+  newParentIds: string[];
+
+  /**
+   * @generated from field: string created_at = 7;
+   */
+  createdAt: string;
+};
+
+/**
+ * Describes the message node.v1.WatchTreeResponse.
+ * Use `create(WatchTreeResponseSchema)` to create a new message.
+ */
+export const WatchTreeResponseSchema: GenMessage<WatchTreeResponse> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 28);
+
+/**
+ * ★ Snapshot / restore / undo
+ * snapshot อัตโนมัติถูกสร้างก่อนการแก้ node ทุกครั้ง (ยกเว้น UpdateLayout)
+ *
+ * @generated from message node.v1.Snapshot
+ */
+export type Snapshot = Message<"node.v1.Snapshot"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string tree_id = 2;
+   */
+  treeId: string;
+
+  /**
+   * @generated from field: string created_by = 3;
+   */
+  createdBy: string;
+
+  /**
+   * manual | undo | restore | ชื่อ action ของ audit เช่น node_deleted
+   *
+   * @generated from field: string reason = 4;
+   */
+  reason: string;
+
+  /**
+   * @generated from field: string label = 5;
+   */
+  label: string;
+
+  /**
+   * ส่วนที่ undo ย้อนให้: tree | subtree | node
+   *
+   * @generated from field: string scope = 11;
+   */
+  scope: string;
+
+  /**
+   * node ที่การแก้ถัดจากนี้ไปแตะ (ไม่มีเมื่อ scope = tree)
+   *
+   * @generated from field: optional string node_id = 6;
+   */
+  nodeId?: string;
+
+  /**
+   * @generated from field: optional string undone_at = 7;
+   */
+  undoneAt?: string;
+
+  /**
+   * @generated from field: int64 structure_revision = 8;
+   */
+  structureRevision: bigint;
+
+  /**
+   * จำนวน node ที่เก็บไว้ (scope subtree / node เก็บเฉพาะ node ในขอบเขต)
+   *
+   * @generated from field: int32 node_count = 9;
+   */
+  nodeCount: number;
+
+  /**
+   * @generated from field: string created_at = 10;
+   */
+  createdAt: string;
+};
+
+/**
+ * Describes the message node.v1.Snapshot.
+ * Use `create(SnapshotSchema)` to create a new message.
+ */
+export const SnapshotSchema: GenMessage<Snapshot> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 29);
+
+/**
+ * @generated from message node.v1.CreateSnapshotRequest
+ */
+export type CreateSnapshotRequest = Message<"node.v1.CreateSnapshotRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+
+  /**
+   * @generated from field: string label = 2;
+   */
+  label: string;
+};
+
+/**
+ * Describes the message node.v1.CreateSnapshotRequest.
+ * Use `create(CreateSnapshotRequestSchema)` to create a new message.
+ */
+export const CreateSnapshotRequestSchema: GenMessage<CreateSnapshotRequest> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 30);
+
+/**
+ * @generated from message node.v1.CreateSnapshotResponse
+ */
+export type CreateSnapshotResponse = Message<"node.v1.CreateSnapshotResponse"> & {
+  /**
+   * @generated from field: node.v1.Snapshot snapshot = 1;
+   */
+  snapshot?: Snapshot;
+};
+
+/**
+ * Describes the message node.v1.CreateSnapshotResponse.
+ * Use `create(CreateSnapshotResponseSchema)` to create a new message.
+ */
+export const CreateSnapshotResponseSchema: GenMessage<CreateSnapshotResponse> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 31);
+
+/**
+ * @generated from message node.v1.ListSnapshotsRequest
+ */
+export type ListSnapshotsRequest = Message<"node.v1.ListSnapshotsRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+
+  /**
+   * default 50, สูงสุด 200
+   *
+   * @generated from field: int32 limit = 2;
+   */
+  limit: number;
+};
+
+/**
+ * Describes the message node.v1.ListSnapshotsRequest.
+ * Use `create(ListSnapshotsRequestSchema)` to create a new message.
+ */
+export const ListSnapshotsRequestSchema: GenMessage<ListSnapshotsRequest> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 32);
+
+/**
+ * @generated from message node.v1.ListSnapshotsResponse
+ */
+export type ListSnapshotsResponse = Message<"node.v1.ListSnapshotsResponse"> & {
+  /**
+   * @generated from field: repeated node.v1.Snapshot snapshots = 1;
+   */
+  snapshots: Snapshot[];
+};
+
+/**
+ * Describes the message node.v1.ListSnapshotsResponse.
+ * Use `create(ListSnapshotsResponseSchema)` to create a new message.
+ */
+export const ListSnapshotsResponseSchema: GenMessage<ListSnapshotsResponse> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 33);
+
+/**
+ * @generated from message node.v1.SnapshotChange
+ */
+export type SnapshotChange = Message<"node.v1.SnapshotChange"> & {
+  /**
+   * @generated from field: string node_id = 1;
+   */
+  nodeId: string;
+
+  /**
+   * @generated from field: string nickname = 2;
+   */
+  nickname: string;
+
+  /**
+   * @generated from field: node.v1.SnapshotChangeType type = 3;
+   */
+  type: SnapshotChangeType;
+
+  /**
+   * MODIFIED: field ที่เปลี่ยน ("parents" = ย้ายสาย)
+   *
+   * @generated from field: repeated string fields = 4;
+   */
+  fields: string[];
+
+  /**
+   * @generated from field: repeated string parent_ids_before = 5;
+   */
+  parentIdsBefore: string[];
+
+  /**
+   * @generated from field: repeated string parent_ids_after = 6;
+   */
+  parentIdsAfter: string[];
+};
+
+/**
+ * Describes the message node.v1.SnapshotChange.
+ * Use `create(SnapshotChangeSchema)` to create a new message.
+ */
+export const SnapshotChangeSchema: GenMessage<SnapshotChange> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 34);
+
+/**
+ * DiffSnapshot เทียบ snapshot กับสถานะปัจจุบัน (before = snapshot, after = ตอนนี้)
+ *
+ * @generated from message node.v1.DiffSnapshotRequest
+ */
+export type DiffSnapshotRequest = Message<"node.v1.DiffSnapshotRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+
+  /**
+   * @generated from field: string snapshot_id = 2;
+   */
+  snapshotId: string;
+};
+
+/**
+ * Describes the message node.v1.DiffSnapshotRequest.
+ * Use `create(DiffSnapshotRequestSchema)` to create a new message.
+ */
+export const DiffSnapshotRequestSchema: GenMessage<DiffSnapshotRequest> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 35);
+
+/**
+ * @generated from message node.v1.DiffSnapshotResponse
+ */
+export type DiffSnapshotResponse = Message<"node.v1.DiffSnapshotResponse"> & {
+  /**
+   * @generated from field: repeated node.v1.SnapshotChange changes = 1;
+   */
+  changes: SnapshotChange[];
+};
+
+/**
+ * Describes the message node.v1.DiffSnapshotResponse.
+ * Use `create(DiffSnapshotResponseSchema)` to create a new message.
+ */
+export const DiffSnapshotResponseSchema: GenMessage<DiffSnapshotResponse> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 36);
+
+/**
+ * @generated from message node.v1.RestoreSnapshotRequest
+ */
+export type RestoreSnapshotRequest = Message<"node.v1.RestoreSnapshotRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+
+  /**
+   * @generated from field: string snapshot_id = 2;
+   */
+  snapshotId: string;
+
+  /**
+   * ไม่ส่ง = ทั้ง tree, ส่ง = เฉพาะ node นี้กับ descendants
+   *
+   * @generated from field: optional string root_node_id = 3;
+   */
+  rootNodeId?: string;
+
+  /**
+   * @generated from field: optional int64 expected_revision = 4;
+   */
+  expectedRevision?: bigint;
+};
+
+/**
+ * Describes the message node.v1.RestoreSnapshotRequest.
+ * Use `create(RestoreSnapshotRequestSchema)` to create a new message.
+ */
+export const RestoreSnapshotRequestSchema: GenMessage<RestoreSnapshotRequest> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 37);
+
+/**
+ * @generated from message node.v1.RestoreSnapshotResponse
+ */
+export type RestoreSnapshotResponse = Message<"node.v1.RestoreSnapshotResponse"> & {
+  /**
+   * ทุก node ของ tree หลัง restore
+   *
+   * @generated from field: repeated node.v1.Node nodes = 1;
+   */
+  nodes: Node[];
+
+  /**
+   * @generated from field: int64 structure_revision = 2;
+   */
+  structureRevision: bigint;
+
+  /**
+   * สถานะก่อน restore (restore กลับหรือ Undo ได้)
+   *
+   * @generated from field: node.v1.Snapshot backup = 3;
+   */
+  backup?: Snapshot;
+};
+
+/**
+ * Describes the message node.v1.RestoreSnapshotResponse.
+ * Use `create(RestoreSnapshotResponseSchema)` to create a new message.
+ */
+export const RestoreSnapshotResponseSchema: GenMessage<RestoreSnapshotResponse> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 38);
+
+/**
+ * Undo / Redo การแก้ล่าสุดของ caller เอง (ไม่ย้อนของคนอื่น)
+ *
+ * @generated from message node.v1.UndoRequest
+ */
+export type UndoRequest = Message<"node.v1.UndoRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+
+  /**
+   * @generated from field: optional int64 expected_revision = 2;
+   */
+  expectedRevision?: bigint;
+};
+
+/**
+ * Describes the message node.v1.UndoRequest.
+ * Use `create(UndoRequestSchema)` to create a new message.
+ */
+export const UndoRequestSchema: GenMessage<UndoRequest> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 39);
+
+/**
+ * @generated from message node.v1.UndoResponse
+ */
+export type UndoResponse = Message<"node.v1.UndoResponse"> & {
+  /**
+   * @generated from field: repeated node.v1.Node nodes = 1;
+   */
+  nodes: Node[];
+
+  /**
+   * @generated from field: int64 structure_revision = 2;
+   */
+  structureRevision: bigint;
+
+  /**
+   * การแก้ที่ถูกย้อน เช่น node_deleted
+   *
+   * @generated from field: string undone_reason = 3;
+   */
+  undoneReason: string;
+};
+
+/**
+ * Describes the message node.v1.UndoResponse.
+ * Use `create(UndoResponseSchema)` to create a new message.
+ */
+export const UndoResponseSchema: GenMessage<UndoResponse> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 40);
+
+/**
+ * @generated from message node.v1.RedoRequest
+ */
+export type RedoRequest = Message<"node.v1.RedoRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+
+  /**
+   * @generated from field: optional int64 expected_revision = 2;
+   */
+  expectedRevision?: bigint;
+};
+
+/**
+ * Describes the message node.v1.RedoRequest.
+ * Use `create(RedoRequestSchema)` to create a new message.
+ */
+export const RedoRequestSchema: GenMessage<RedoRequest> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 41);
+
+/**
+ * @generated from message node.v1.RedoResponse
+ */
+export type RedoResponse = Message<"node.v1.RedoResponse"> & {
+  /**
+   * @generated from field: repeated node.v1.Node nodes = 1;
+   */
+  nodes: Node[];
+
+  /**
+   * @generated from field: int64 structure_revision = 2;
+   */
+  structureRevision: bigint;
+
+  /**
+   * @generated from field: string redone_reason = 3;
+   */
+  redoneReason: string;
+};
+
+/**
+ * Describes the message node.v1.RedoResponse.
+ * Use `create(RedoResponseSchema)` to create a new message.
+ */
+export const RedoResponseSchema: GenMessage<RedoResponse> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 42);
+
+/**
+ * @generated from message node.v1.TrashItem
+ */
+export type TrashItem = Message<"node.v1.TrashItem"> & {
+  /**
+   * @generated from field: node.v1.TrashItemType type = 1;
+   */
+  type: TrashItemType;
+
+  /**
+   * @generated from field: string id = 2;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string tree_id = 3;
+   */
+  treeId: string;
+
+  /**
+   * ชื่อ tree / ชื่อเล่นของ node
+   *
+   * @generated from field: string name = 4;
+   */
+  name: string;
+
+  /**
+   * @generated from field: optional string deleted_by = 5;
+   */
+  deletedBy?: string;
+
+  /**
+   * @generated from field: string deleted_at = 6;
+   */
+  deletedAt: string;
+
+  /**
+   * หลังจากนี้จะถูกลบจริง กู้คืนไม่ได้
+   *
+   * @generated from field: string purge_at = 7;
+   */
+  purgeAt: string;
+
+  /**
+   * node: parent เดิม (กู้คืนแล้วกลับไปต่อที่เดิม)
+   *
+   * @generated from field: repeated string parent_ids = 8;
+   */
+  parentIds: string[];
+};
+
+/**
+ * Describes the message node.v1.TrashItem.
+ * Use `create(TrashItemSchema)` to create a new message.
+ */
+export const TrashItemSchema: GenMessage<TrashItem> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 43);
+
+/**
+ * tree_id ว่าง = tree ของ caller ที่อยู่ในถังขยะ, มี tree_id = node ในถังขยะของ tree นั้น (editor ขึ้นไป)
+ *
+ * @generated from message node.v1.ListTrashRequest
+ */
+export type ListTrashRequest = Message<"node.v1.ListTrashRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+};
+
+/**
+ * Describes the message node.v1.ListTrashRequest.
+ * Use `create(ListTrashRequestSchema)` to create a new message.
+ */
+export const ListTrashRequestSchema: GenMessage<ListTrashRequest> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 44);
+
+/**
+ * @generated from message node.v1.ListTrashResponse
+ */
+export type ListTrashResponse = Message<"node.v1.ListTrashResponse"> & {
+  /**
+   * @generated from field: repeated node.v1.TrashItem items = 1;
+   */
+  items: TrashItem[];
+};
+
+/**
+ * Describes the message node.v1.ListTrashResponse.
+ * Use `create(ListTrashResponseSchema)` to create a new message.
+ */
+export const ListTrashResponseSchema: GenMessage<ListTrashResponse> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 45);
+
+/**
+ * @generated from message node.v1.RestoreFromTrashRequest
+ */
+export type RestoreFromTrashRequest = Message<"node.v1.RestoreFromTrashRequest"> & {
+  /**
+   * @generated from field: node.v1.TrashItemType type = 1;
+   */
+  type: TrashItemType;
+
+  /**
+   * @generated from field: string id = 2;
+   */
+  id: string;
+
+  /**
+   * เฉพาะ node
+   *
+   * @generated from field: optional int64 expected_revision = 3;
+   */
+  expectedRevision?: bigint;
+};
+
+/**
+ * Describes the message node.v1.RestoreFromTrashRequest.
+ * Use `create(RestoreFromTrashRequestSchema)` to create a new message.
+ */
+export const RestoreFromTrashRequestSchema: GenMessage<RestoreFromTrashRequest> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 46);
+
+/**
+ * @generated from message node.v1.RestoreFromTrashResponse
+ */
+export type RestoreFromTrashResponse = Message<"node.v1.RestoreFromTrashResponse"> & {
+  /**
+   * เฉพาะ node (tree: client โหลด tree ใหม่เอง)
+   *
+   * @generated from field: node.v1.Node node = 1;
+   */
+  node?: Node;
+
+  /**
+   * @generated from field: int64 structure_revision = 2;
+   */
+  structureRevision: bigint;
+};
+
+/**
+ * Describes the message node.v1.RestoreFromTrashResponse.
+ * Use `create(RestoreFromTrashResponseSchema)` to create a new message.
+ */
+export const RestoreFromTrashResponseSchema: GenMessage<RestoreFromTrashResponse> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 47);
+
+/**
+ * @generated from message node.v1.DeleteSubtreeRequest
+ */
+export type DeleteSubtreeRequest = Message<"node.v1.DeleteSubtreeRequest"> & {
+  /**
+   * root ของสาย
+   *
+   * @generated from field: string node_id = 1;
+   */
+  nodeId: string;
+
+  /**
+   * @generated from field: node.v1.SharedDescendantPolicy shared_policy = 2;
+   */
+  sharedPolicy: SharedDescendantPolicy;
+
+  /**
+   * @generated from field: optional int64 expected_revision = 3;
+   */
+  expectedRevision?: bigint;
+};
+
+/**
+ * Describes the message node.v1.DeleteSubtreeRequest.
+ * Use `create(DeleteSubtreeRequestSchema)` to create a new message.
+ */
+export const DeleteSubtreeRequestSchema: GenMessage<DeleteSubtreeRequest> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 48);
+
+/**
+ * ทุก node ในสายลงถังขยะ (Undo คืนทั้งสายพร้อมกัน)
+ *
+ * @generated from message node.v1.DeleteSubtreeResponse
+ */
+export type DeleteSubtreeResponse = Message<"node.v1.DeleteSubtreeResponse"> & {
+  /**
+   * @generated from field: repeated string deleted_node_ids = 1;
+   */
+  deletedNodeIds: string[];
+
+  /**
+   * descendant ที่มี parent นอกสาย (ถูกจัดการตาม shared_policy)
+   *
+   * @generated from field: repeated string shared_node_ids = 2;
+   */
+  sharedNodeIds: string[];
+
+  /**
+   * @generated from field: int64 structure_revision = 3;
+   */
+  structureRevision: bigint;
+};
+
+/**
+ * Describes the message node.v1.DeleteSubtreeResponse.
+ * Use `create(DeleteSubtreeResponseSchema)` to create a new message.
+ */
+export const DeleteSubtreeResponseSchema: GenMessage<DeleteSubtreeResponse> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 49);
+
+/**
+ * @generated from message node.v1.CopySubtreeRequest
+ */
+export type CopySubtreeRequest = Message<"node.v1.CopySubtreeRequest"> & {
+  /**
+   * root ของสายต้นทาง
+   *
+   * @generated from field: string node_id = 1;
+   */
+  nodeId: string;
+
+  /**
+   * ว่าง = tree เดียวกัน
+   *
+   * @generated from field: string target_tree_id = 2;
+   */
+  targetTreeId: string;
+
+  /**
+   * parent ของสำเนา root ใน tree ปลายทาง ไม่ส่ง = เป็น root
+   *
+   * @generated from field: optional string new_parent_id = 3;
+   */
+  newParentId?: string;
+
+  /**
+   * ลำดับใน children ของ parent ใหม่ ไม่ส่ง = ต่อท้าย
+   *
+   * @generated from field: optional int32 sibling_order = 4;
+   */
+  siblingOrder?: number;
+
+  /**
+   * @generated from field: node.v1.SharedDescendantPolicy shared_policy = 5;
+   */
+  sharedPolicy: SharedDescendantPolicy;
+
+  /**
+   * revision ของ tree ปลายทาง
+   *
+   * @generated from field: optional int64 expected_revision = 6;
+   */
+  expectedRevision?: bigint;
+};
+
+/**
+ * Describes the message node.v1.CopySubtreeRequest.
+ * Use `create(CopySubtreeRequestSchema)` to create a new message.
+ */
+export const CopySubtreeRequestSchema: GenMessage<CopySubtreeRequest> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 50);
+
+/**
+ * @generated from message node.v1.CopySubtreeResponse
+ */
+export type CopySubtreeResponse = Message<"node.v1.CopySubtreeResponse"> & {
+  /**
+   * สำเนาทั้งหมด (root ก่อน)
+   *
+   * @generated from field: repeated node.v1.Node nodes = 1;
+   */
+  nodes: Node[];
+
+  /**
+   * id ต้นทาง → id สำเนา
+   *
+   * @generated from field: map<string, string> id_map = 2;
+   */
+  idMap: { [key: string]: string };
+
+  /**
+   * @generated from field: repeated string shared_node_ids = 3;
+   */
+  sharedNodeIds: string[];
+
+  /**
+   * id สำเนาที่ student_id ชนใน tree ปลายทางเลยถูกเว้นว่าง
+   *
+   * @generated from field: repeated string cleared_student_ids = 4;
+   */
+  clearedStudentIds: string[];
+
+  /**
+   * revision ของ tree ปลายทาง
+   *
+   * @generated from field: int64 structure_revision = 5;
+   */
+  structureRevision: bigint;
+};
+
+/**
+ * Describes the message node.v1.CopySubtreeResponse.
+ * Use `create(CopySubtreeResponseSchema)` to create a new message.
+ */
+export const CopySubtreeResponseSchema: GenMessage<CopySubtreeResponse> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 51);
+
+/**
+ * ย้ายทั้งสายภายใน tree เดียวกัน (root ของสายออกจาก parent เดิมทั้งหมด)
+ *
+ * @generated from message node.v1.MoveSubtreeRequest
+ */
+export type MoveSubtreeRequest = Message<"node.v1.MoveSubtreeRequest"> & {
+  /**
+   * @generated from field: string node_id = 1;
+   */
+  nodeId: string;
+
+  /**
+   * ไม่ส่ง = เป็น root
+   *
+   * @generated from field: optional string new_parent_id = 2;
+   */
+  newParentId?: string;
+
+  /**
+   * @generated from field: optional int32 sibling_order = 3;
+   */
+  siblingOrder?: number;
+
+  /**
+   * @generated from field: node.v1.SharedDescendantPolicy shared_policy = 4;
+   */
+  sharedPolicy: SharedDescendantPolicy;
+
+  /**
+   * @generated from field: optional int64 expected_revision = 5;
+   */
+  expectedRevision?: bigint;
+};
+
+/**
+ * Describes the message node.v1.MoveSubtreeRequest.
+ * Use `create(MoveSubtreeRequestSchema)` to create a new message.
+ */
+export const MoveSubtreeRequestSchema: GenMessage<MoveSubtreeRequest> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 52);
+
+/**
+ * @generated from message node.v1.MoveSubtreeResponse
+ */
+export type MoveSubtreeResponse = Message<"node.v1.MoveSubtreeResponse"> & {
+  /**
+   * node ในสายหลังย้าย (รุ่นคำนวณใหม่แล้ว)
+   *
+   * @generated from field: repeated node.v1.Node nodes = 1;
+   */
+  nodes: Node[];
+
+  /**
+   * @generated from field: repeated string shared_node_ids = 2;
+   */
+  sharedNodeIds: string[];
+
+  /**
+   * @generated from field: int64 structure_revision = 3;
+   */
+  structureRevision: bigint;
+};
+
+/**
+ * Describes the message node.v1.MoveSubtreeResponse.
+ * Use `create(MoveSubtreeResponseSchema)` to create a new message.
+ */
+export const MoveSubtreeResponseSchema: GenMessage<MoveSubtreeResponse> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 53);
+
+/**
+ * @generated from message node.v1.LineageNode
+ */
+export type LineageNode = Message<"node.v1.LineageNode"> & {
+  /**
+   * @generated from field: node.v1.Node node = 1;
+   */
+  node?: Node;
+
+  /**
+   * ห่างจาก node ที่ถาม (1 = parent / child ตรง)
+   *
+   * @generated from field: int32 depth = 2;
+   */
+  depth: number;
+};
+
+/**
+ * Describes the message node.v1.LineageNode.
+ * Use `create(LineageNodeSchema)` to create a new message.
+ */
+export const LineageNodeSchema: GenMessage<LineageNode> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 54);
+
+/**
+ * @generated from message node.v1.GetAncestorsRequest
+ */
+export type GetAncestorsRequest = Message<"node.v1.GetAncestorsRequest"> & {
+  /**
+   * @generated from field: string node_id = 1;
+   */
+  nodeId: string;
+
+  /**
+   * 0 = ไม่จำกัด
+   *
+   * @generated from field: int32 max_depth = 2;
+   */
+  maxDepth: number;
+};
+
+/**
+ * Describes the message node.v1.GetAncestorsRequest.
+ * Use `create(GetAncestorsRequestSchema)` to create a new message.
+ */
+export const GetAncestorsRequestSchema: GenMessage<GetAncestorsRequest> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 55);
+
+/**
+ * เรียงจากใกล้ไปไกล (multi-parent = ผ่านทุก parent, แต่ละ node มาครั้งเดียวที่ระยะสั้นสุด)
+ *
+ * @generated from message node.v1.GetAncestorsResponse
+ */
+export type GetAncestorsResponse = Message<"node.v1.GetAncestorsResponse"> & {
+  /**
+   * @generated from field: repeated node.v1.LineageNode ancestors = 1;
+   */
+  ancestors: LineageNode[];
+};
+
+/**
+ * Describes the message node.v1.GetAncestorsResponse.
+ * Use `create(GetAncestorsResponseSchema)` to create a new message.
+ */
+export const GetAncestorsResponseSchema: GenMessage<GetAncestorsResponse> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 56);
+
+/**
+ * @generated from message node.v1.GetDescendantsRequest
+ */
+export type GetDescendantsRequest = Message<"node.v1.GetDescendantsRequest"> & {
+  /**
+   * @generated from field: string node_id = 1;
+   */
+  nodeId: string;
+
+  /**
+   * 0 = ไม่จำกัด
+   *
+   * @generated from field: int32 max_depth = 2;
+   */
+  maxDepth: number;
+};
+
+/**
+ * Describes the message node.v1.GetDescendantsRequest.
+ * Use `create(GetDescendantsRequestSchema)` to create a new message.
+ */
+export const GetDescendantsRequestSchema: GenMessage<GetDescendantsRequest> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 57);
+
+/**
+ * @generated from message node.v1.GetDescendantsResponse
+ */
+export type GetDescendantsResponse = Message<"node.v1.GetDescendantsResponse"> & {
+  /**
+   * @generated from field: repeated node.v1.LineageNode descendants = 1;
+   */
+  descendants: LineageNode[];
+};
+
+/**
+ * Describes the message node.v1.GetDescendantsResponse.
+ * Use `create(GetDescendantsResponseSchema)` to create a new message.
+ */
+export const GetDescendantsResponseSchema: GenMessage<GetDescendantsResponse> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 58);
+
+/**
+ * @generated from message node.v1.FindRelationshipRequest
+ */
+export type FindRelationshipRequest = Message<"node.v1.FindRelationshipRequest"> & {
+  /**
+   * @generated from field: string node_a_id = 1;
+   */
+  nodeAId: string;
+
+  /**
+   * @generated from field: string node_b_id = 2;
+   */
+  nodeBId: string;
+};
+
+/**
+ * Describes the message node.v1.FindRelationshipRequest.
+ * Use `create(FindRelationshipRequestSchema)` to create a new message.
+ */
+export const FindRelationshipRequestSchema: GenMessage<FindRelationshipRequest> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 59);
+
+/**
+ * @generated from message node.v1.FindRelationshipResponse
+ */
+export type FindRelationshipResponse = Message<"node.v1.FindRelationshipResponse"> & {
+  /**
+   * @generated from field: node.v1.RelationshipKind kind = 1;
+   */
+  kind: RelationshipKind;
+
+  /**
+   * เช่น "พี่รหัส 2 ชั้น", "ร่วมพี่รหัสเดียวกัน"
+   *
+   * @generated from field: string label = 2;
+   */
+  label: string;
+
+  /**
+   * พี่รหัสร่วมที่ทำให้เส้นสั้นที่สุด
+   *
+   * @generated from field: optional string common_ancestor_id = 3;
+   */
+  commonAncestorId?: string;
+
+  /**
+   * จำนวนชั้นจาก a ขึ้นไปถึง common ancestor
+   *
+   * @generated from field: int32 up = 4;
+   */
+  up: number;
+
+  /**
+   * จำนวนชั้นจาก common ancestor ลงมาถึง b
+   *
+   * @generated from field: int32 down = 5;
+   */
+  down: number;
+
+  /**
+   * node id จาก a → common ancestor → b
+   *
+   * @generated from field: repeated string path = 6;
+   */
+  path: string[];
+};
+
+/**
+ * Describes the message node.v1.FindRelationshipResponse.
+ * Use `create(FindRelationshipResponseSchema)` to create a new message.
+ */
+export const FindRelationshipResponseSchema: GenMessage<FindRelationshipResponse> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 60);
+
+/**
+ * @generated from message node.v1.SearchNodesRequest
+ */
+export type SearchNodesRequest = Message<"node.v1.SearchNodesRequest"> & {
+  /**
+   * @generated from field: string query = 1;
+   */
+  query: string;
+
+  /**
+   * ไม่ส่ง = ทุก tree ที่เป็นเจ้าของ / ถูกแชร์ (ต้อง login)
+   *
+   * @generated from field: optional string tree_id = 2;
+   */
+  treeId?: string;
+
+  /**
+   * ว่าง = ทุกรุ่น
+   *
+   * @generated from field: repeated int32 generations = 3;
+   */
+  generations: number[];
+
+  /**
+   * ว่าง = ทุกสถานะ
+   *
+   * @generated from field: repeated node.v1.NodeStatus statuses = 4;
+   */
+  statuses: NodeStatus[];
+
+  /**
+   * 0 = 20, มากสุด 100
+   *
+   * @generated from field: int32 page_size = 5;
+   */
+  pageSize: number;
+
+  /**
+   * next_page_token จากหน้าก่อน
+   *
+   * @generated from field: string page_token = 6;
+   */
+  pageToken: string;
+};
+
+/**
+ * Describes the message node.v1.SearchNodesRequest.
+ * Use `create(SearchNodesRequestSchema)` to create a new message.
+ */
+export const SearchNodesRequestSchema: GenMessage<SearchNodesRequest> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 61);
+
+/**
+ * @generated from message node.v1.SearchHit
+ */
+export type SearchHit = Message<"node.v1.SearchHit"> & {
+  /**
+   * @generated from field: node.v1.Node node = 1;
+   */
+  node?: Node;
+
+  /**
+   * มาก = ตรงกว่า (เทียบกันได้เฉพาะในการค้นครั้งเดียวกัน)
+   *
+   * @generated from field: double score = 2;
+   */
+  score: number;
+
+  /**
+   * @generated from field: string tree_name = 3;
+   */
+  treeName: string;
+};
+
+/**
+ * Describes the message node.v1.SearchHit.
+ * Use `create(SearchHitSchema)` to create a new message.
+ */
+export const SearchHitSchema: GenMessage<SearchHit> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 62);
+
+/**
+ * เรียงตามคะแนน ผลที่ตรงแค่ช่องทางติดต่อที่ caller ไม่เห็นถูกตัดออก (หน้าหนึ่งอาจได้น้อยกว่า page_size)
+ *
+ * @generated from message node.v1.SearchNodesResponse
+ */
+export type SearchNodesResponse = Message<"node.v1.SearchNodesResponse"> & {
+  /**
+   * @generated from field: repeated node.v1.SearchHit hits = 1;
+   */
+  hits: SearchHit[];
+
+  /**
+   * "" = หมดแล้ว
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+};
+
+/**
+ * Describes the message node.v1.SearchNodesResponse.
+ * Use `create(SearchNodesResponseSchema)` to create a new message.
+ */
+export const SearchNodesResponseSchema: GenMessage<SearchNodesResponse> = /*@__PURE__*/
+  messageDesc(file_node_v1_node, 63);
+
+/**
+ * @generated from enum node.v1.NodeStatus
+ */
+export enum NodeStatus {
+  /**
+   * @generated from enum value: NODE_STATUS_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: NODE_STATUS_STUDYING = 1;
+   */
+  STUDYING = 1,
+
+  /**
+   * @generated from enum value: NODE_STATUS_GRADUATED = 2;
+   */
+  GRADUATED = 2,
+
+  /**
+   * @generated from enum value: NODE_STATUS_RETIRED = 3;
+   */
+  RETIRED = 3,
+}
+
+/**
+ * Describes the enum node.v1.NodeStatus.
+ */
+export const NodeStatusSchema: GenEnum<NodeStatus> = /*@__PURE__*/
+  enumDesc(file_node_v1_node, 0);
+
+/**
+ * ★ Bulk import: นำเข้าสายรหัสทั้งสายจากไฟล์
+ * columns: nickname, first_name, last_name, student_id, generation, status,
+ *          phone, email, line_id, discord, facebook, parent_student_ids (คั่นด้วย ;)
+ *
+ * @generated from enum node.v1.ImportFormat
+ */
+export enum ImportFormat {
+  /**
+   * @generated from enum value: IMPORT_FORMAT_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: IMPORT_FORMAT_CSV = 1;
+   */
+  CSV = 1,
+
+  /**
+   * @generated from enum value: IMPORT_FORMAT_XLSX = 2;
+   */
+  XLSX = 2,
+
+  /**
+   * ไฟล์จาก ExportTree (EXPORT_FORMAT_JSON_SNAPSHOT)
+   *
+   * @generated from enum value: IMPORT_FORMAT_JSON_SNAPSHOT = 3;
+   */
+  JSON_SNAPSHOT = 3,
+}
+
+/**
+ * Describes the enum node.v1.ImportFormat.
+ */
+export const ImportFormatSchema: GenEnum<ImportFormat> = /*@__PURE__*/
+  enumDesc(file_node_v1_node, 1);
+
+/**
+ * ★ Export: ดึงข้อมูลทั้ง tree ออกเป็นไฟล์ (เก็บเส้น multi-parent ครบทุกแบบ)
+ *
+ * @generated from enum node.v1.ExportFormat
+ */
+export enum ExportFormat {
+  /**
+   * = JSON snapshot
+   *
+   * @generated from enum value: EXPORT_FORMAT_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * ครบทุก field, import กลับได้
+   *
+   * @generated from enum value: EXPORT_FORMAT_JSON_SNAPSHOT = 1;
+   */
+  JSON_SNAPSHOT = 1,
+
+  /**
+   * Graphviz
+   *
+   * @generated from enum value: EXPORT_FORMAT_DOT = 2;
+   */
+  DOT = 2,
+
+  /**
+   * @generated from enum value: EXPORT_FORMAT_GRAPHML = 3;
+   */
+  GRAPHML = 3,
+
+  /**
+   * @generated from enum value: EXPORT_FORMAT_GEDCOM = 4;
+   */
+  GEDCOM = 4,
+}
+
+/**
+ * Describes the enum node.v1.ExportFormat.
+ */
+export const ExportFormatSchema: GenEnum<ExportFormat> = /*@__PURE__*/
+  enumDesc(file_node_v1_node, 2);
+
+/**
+ * ★ Realtime: ติดตามการเปลี่ยนแปลงของ tree (server-streaming)
+ *
+ * @generated from enum node.v1.TreeEventType
+ */
+export enum TreeEventType {
+  /**
+   * @generated from enum value: TREE_EVENT_TYPE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: TREE_EVENT_TYPE_NODE_CREATED = 1;
+   */
+  NODE_CREATED = 1,
+
+  /**
+   * @generated from enum value: TREE_EVENT_TYPE_NODE_UPDATED = 2;
+   */
+  NODE_UPDATED = 2,
+
+  /**
+   * @generated from enum value: TREE_EVENT_TYPE_NODE_DELETED = 3;
+   */
+  NODE_DELETED = 3,
+
+  /**
+   * parent ชุดใหม่ไม่ซ้ำชุดเดิม (ย้ายสาย / ตัดเป็น root)
+   *
+   * @generated from enum value: TREE_EVENT_TYPE_NODE_MOVED = 4;
+   */
+  NODE_MOVED = 4,
+
+  /**
+   * เพิ่ม/ตัด parent บางตัว
+   *
+   * @generated from enum value: TREE_EVENT_TYPE_NODE_PARENTS_CHANGED = 5;
+   */
+  NODE_PARENTS_CHANGED = 5,
+}
+
+/**
+ * Describes the enum node.v1.TreeEventType.
+ */
+export const TreeEventTypeSchema: GenEnum<TreeEventType> = /*@__PURE__*/
+  enumDesc(file_node_v1_node, 3);
+
+/**
+ * @generated from enum node.v1.SnapshotChangeType
+ */
+export enum SnapshotChangeType {
+  /**
+   * @generated from enum value: SNAPSHOT_CHANGE_TYPE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * มีตอนนี้ ไม่มีใน snapshot
+   *
+   * @generated from enum value: SNAPSHOT_CHANGE_TYPE_ADDED = 1;
+   */
+  ADDED = 1,
+
+  /**
+   * มีใน snapshot ไม่มีตอนนี้
+   *
+   * @generated from enum value: SNAPSHOT_CHANGE_TYPE_REMOVED = 2;
+   */
+  REMOVED = 2,
+
+  /**
+   * @generated from enum value: SNAPSHOT_CHANGE_TYPE_MODIFIED = 3;
+   */
+  MODIFIED = 3,
+}
+
+/**
+ * Describes the enum node.v1.SnapshotChangeType.
+ */
+export const SnapshotChangeTypeSchema: GenEnum<SnapshotChangeType> = /*@__PURE__*/
+  enumDesc(file_node_v1_node, 4);
+
+/**
+ * ★ Trash: node / tree ที่ลบแล้วอยู่ในถังขยะจนกว่าจะครบ retention แล้วถูกลบจริง
+ *
+ * @generated from enum node.v1.TrashItemType
+ */
+export enum TrashItemType {
+  /**
+   * @generated from enum value: TRASH_ITEM_TYPE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: TRASH_ITEM_TYPE_TREE = 1;
+   */
+  TREE = 1,
+
+  /**
+   * @generated from enum value: TRASH_ITEM_TYPE_NODE = 2;
+   */
+  NODE = 2,
+}
+
+/**
+ * Describes the enum node.v1.TrashItemType.
+ */
+export const TrashItemTypeSchema: GenEnum<TrashItemType> = /*@__PURE__*/
+  enumDesc(file_node_v1_node, 5);
+
+/**
+ * SharedDescendantPolicy วิธีจัดการ descendant ที่มี parent อยู่นอกสาย (multi-parent)
+ *
+ * @generated from enum node.v1.SharedDescendantPolicy
+ */
+export enum SharedDescendantPolicy {
+  /**
+   * = LEAVE
+   *
+   * @generated from enum value: SHARED_DESCENDANT_POLICY_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * ไม่แตะ: อยู่กับ parent นอกสายต่อ (descendants ของมันที่ไม่มีทางอื่นก็อยู่ต่อด้วย)
+   * ลบ = ตัดแค่เส้นจากในสาย, คัดลอก = ไม่คัดลอก, ย้าย = ตัดเส้นจากในสายแล้วทิ้งไว้ที่เดิม
+   *
+   * @generated from enum value: SHARED_DESCENDANT_POLICY_LEAVE = 1;
+   */
+  LEAVE = 1,
+
+  /**
+   * เอาไปด้วย: ลบ = ลบด้วย, คัดลอก = คัดลอกด้วย (สำเนาต่อกับ parent ในสายเท่านั้น),
+   * ย้าย = ย้ายไปด้วยและตัดเส้นจาก parent นอกสาย
+   *
+   * @generated from enum value: SHARED_DESCENDANT_POLICY_TAKE = 2;
+   */
+  TAKE = 2,
+}
+
+/**
+ * Describes the enum node.v1.SharedDescendantPolicy.
+ */
+export const SharedDescendantPolicySchema: GenEnum<SharedDescendantPolicy> = /*@__PURE__*/
+  enumDesc(file_node_v1_node, 6);
+
+/**
+ * ความสัมพันธ์ของ node_b เมื่อมองจาก node_a
+ *
+ * @generated from enum node.v1.RelationshipKind
+ */
+export enum RelationshipKind {
+  /**
+   * @generated from enum value: RELATIONSHIP_KIND_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * ไม่มีพี่รหัสร่วมกัน
+   *
+   * @generated from enum value: RELATIONSHIP_KIND_UNRELATED = 1;
+   */
+  UNRELATED = 1,
+
+  /**
+   * @generated from enum value: RELATIONSHIP_KIND_SELF = 2;
+   */
+  SELF = 2,
+
+  /**
+   * b เป็นพี่รหัส (ทุกชั้น) ของ a
+   *
+   * @generated from enum value: RELATIONSHIP_KIND_ANCESTOR = 3;
+   */
+  ANCESTOR = 3,
+
+  /**
+   * b เป็นน้องรหัส (ทุกชั้น) ของ a
+   *
+   * @generated from enum value: RELATIONSHIP_KIND_DESCENDANT = 4;
+   */
+  DESCENDANT = 4,
+
+  /**
+   * มีพี่รหัสคนเดียวกัน
+   *
+   * @generated from enum value: RELATIONSHIP_KIND_SIBLING = 5;
+   */
+  SIBLING = 5,
+
+  /**
+   * มีพี่รหัสร่วมกันหลายชั้นขึ้นไป
+   *
+   * @generated from enum value: RELATIONSHIP_KIND_COUSIN = 6;
+   */
+  COUSIN = 6,
+}
+
+/**
+ * Describes the enum node.v1.RelationshipKind.
+ */
+export const RelationshipKindSchema: GenEnum<RelationshipKind> = /*@__PURE__*/
+  enumDesc(file_node_v1_node, 7);
+
+/**
+ * @generated from service node.v1.NodeService
+ */
+export const NodeService: GenService<{
+  /**
+   * @generated from rpc node.v1.NodeService.CreateNode
+   */
+  createNode: {
+    methodKind: "unary";
+    input: typeof CreateNodeRequestSchema;
+    output: typeof CreateNodeResponseSchema;
+  },
+  /**
+   * @generated from rpc node.v1.NodeService.UpdateNode
+   */
+  updateNode: {
+    methodKind: "unary";
+    input: typeof UpdateNodeRequestSchema;
+    output: typeof UpdateNodeResponseSchema;
+  },
+  /**
+   * @generated from rpc node.v1.NodeService.DeleteNode
+   */
+  deleteNode: {
+    methodKind: "unary";
+    input: typeof DeleteNodeRequestSchema;
+    output: typeof DeleteNodeResponseSchema;
+  },
+  /**
+   * @generated from rpc node.v1.NodeService.MoveNode
+   */
+  moveNode: {
+    methodKind: "unary";
+    input: typeof MoveNodeRequestSchema;
+    output: typeof MoveNodeResponseSchema;
+  },
+  /**
+   * @generated from rpc node.v1.NodeService.UnlinkNode
+   */
+  unlinkNode: {
+    methodKind: "unary";
+    input: typeof UnlinkNodeRequestSchema;
+    output: typeof UnlinkNodeResponseSchema;
+  },
+  /**
+   * @generated from rpc node.v1.NodeService.GetTreeNodes
+   */
+  getTreeNodes: {
+    methodKind: "unary";
+    input: typeof GetTreeNodesRequestSchema;
+    output: typeof GetTreeNodesResponseSchema;
+  },
+  /**
+   * @generated from rpc node.v1.NodeService.AddParent
+   */
+  addParent: {
+    methodKind: "unary";
+    input: typeof AddParentRequestSchema;
+    output: typeof AddParentResponseSchema;
+  },
+  /**
+   * @generated from rpc node.v1.NodeService.RemoveParent
+   */
+  removeParent: {
+    methodKind: "unary";
+    input: typeof RemoveParentRequestSchema;
+    output: typeof RemoveParentResponseSchema;
+  },
+  /**
+   * @generated from rpc node.v1.NodeService.UpdateLayout
+   */
+  updateLayout: {
+    methodKind: "unary";
+    input: typeof UpdateLayoutRequestSchema;
+    output: typeof UpdateLayoutResponseSchema;
+  },
+  /**
+   * ★ Bulk import
+   *
+   * @generated from rpc node.v1.NodeService.ImportNodes
+   */
+  importNodes: {
+    methodKind: "unary";
+    input: typeof ImportNodesRequestSchema;
+    output: typeof ImportNodesResponseSchema;
+  },
+  /**
+   * ★ Export (ดาวน์โหลดผ่าน HTTP ได้ที่ GET /export/{treeId}?format=...)
+   *
+   * @generated from rpc node.v1.NodeService.ExportTree
+   */
+  exportTree: {
+    methodKind: "unary";
+    input: typeof ExportTreeRequestSchema;
+    output: typeof ExportTreeResponseSchema;
+  },
+  /**
+   * ★ Snapshot / restore / undo
+   *
+   * @generated from rpc node.v1.NodeService.CreateSnapshot
+   */
+  createSnapshot: {
+    methodKind: "unary";
+    input: typeof CreateSnapshotRequestSchema;
+    output: typeof CreateSnapshotResponseSchema;
+  },
+  /**
+   * @generated from rpc node.v1.NodeService.ListSnapshots
+   */
+  listSnapshots: {
+    methodKind: "unary";
+    input: typeof ListSnapshotsRequestSchema;
+    output: typeof ListSnapshotsResponseSchema;
+  },
+  /**
+   * @generated from rpc node.v1.NodeService.DiffSnapshot
+   */
+  diffSnapshot: {
+    methodKind: "unary";
+    input: typeof DiffSnapshotRequestSchema;
+    output: typeof DiffSnapshotResponseSchema;
+  },
+  /**
+   * @generated from rpc node.v1.NodeService.RestoreSnapshot
+   */
+  restoreSnapshot: {
+    methodKind: "unary";
+    input: typeof RestoreSnapshotRequestSchema;
+    output: typeof RestoreSnapshotResponseSchema;
+  },
+  /**
+   * @generated from rpc node.v1.NodeService.Undo
+   */
+  undo: {
+    methodKind: "unary";
+    input: typeof UndoRequestSchema;
+    output: typeof UndoResponseSchema;
+  },
+  /**
+   * @generated from rpc node.v1.NodeService.Redo
+   */
+  redo: {
+    methodKind: "unary";
+    input: typeof RedoRequestSchema;
+    output: typeof RedoResponseSchema;
+  },
+  /**
+   * ★ Trash
+   *
+   * @generated from rpc node.v1.NodeService.ListTrash
+   */
+  listTrash: {
+    methodKind: "unary";
+    input: typeof ListTrashRequestSchema;
+    output: typeof ListTrashResponseSchema;
+  },
+  /**
+   * @generated from rpc node.v1.NodeService.RestoreFromTrash
+   */
+  restoreFromTrash: {
+    methodKind: "unary";
+    input: typeof RestoreFromTrashRequestSchema;
+    output: typeof RestoreFromTrashResponseSchema;
+  },
+  /**
+   * ★ Subtree
+   *
+   * @generated from rpc node.v1.NodeService.DeleteSubtree
+   */
+  deleteSubtree: {
+    methodKind: "unary";
+    input: typeof DeleteSubtreeRequestSchema;
+    output: typeof DeleteSubtreeResponseSchema;
+  },
+  /**
+   * @generated from rpc node.v1.NodeService.CopySubtree
+   */
+  copySubtree: {
+    methodKind: "unary";
+    input: typeof CopySubtreeRequestSchema;
+    output: typeof CopySubtreeResponseSchema;
+  },
+  /**
+   * @generated from rpc node.v1.NodeService.MoveSubtree
+   */
+  moveSubtree: {
+    methodKind: "unary";
+    input: typeof MoveSubtreeRequestSchema;
+    output: typeof MoveSubtreeResponseSchema;
+  },
+  /**
+   * ★ Lineage
+   *
+   * @generated from rpc node.v1.NodeService.GetAncestors
+   */
+  getAncestors: {
+    methodKind: "unary";
+    input: typeof GetAncestorsRequestSchema;
+    output: typeof GetAncestorsResponseSchema;
+  },
+  /**
+   * @generated from rpc node.v1.NodeService.GetDescendants
+   */
+  getDescendants: {
+    methodKind: "unary";
+    input: typeof GetDescendantsRequestSchema;
+    output: typeof GetDescendantsResponseSchema;
+  },
+  /**
+   * @generated from rpc node.v1.NodeService.FindRelationship
+   */
+  findRelationship: {
+    methodKind: "unary";
+    input: typeof FindRelationshipRequestSchema;
+    output: typeof FindRelationshipResponseSchema;
+  },
+  /**
+   * ★ Search
+   *
+   * @generated from rpc node.v1.NodeService.SearchNodes
+   */
+  searchNodes: {
+    methodKind: "unary";
+    input: typeof SearchNodesRequestSchema;
+    output: typeof SearchNodesResponseSchema;
+  },
+  /**
+   * ★ Realtime (server-streaming)
+   *
+   * @generated from rpc node.v1.NodeService.WatchTree
+   */
+  watchTree: {
+    methodKind: "server_streaming";
+    input: typeof WatchTreeRequestSchema;
+    output: typeof WatchTreeResponseSchema;
   },
   /**
    * ★ Public (ไม่ต้อง login)
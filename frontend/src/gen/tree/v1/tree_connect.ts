@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { CreateTreeRequest, CreateTreeResponse, DeleteTreeRequest, DeleteTreeResponse, GenerateShareLinkRequest, GenerateShareLinkResponse, GetMyRoleRequest, GetMyRoleResponse, GetTreeByShareTokenRequest, GetTreeByShareTokenResponse, GetTreeRequest, GetTreeResponse, ListMyTreesRequest, ListMyTreesResponse, ListSharedWithMeRequest, ListSharedWithMeResponse, ListTreeSharesRequest, ListTreeSharesResponse, RemoveShareRequest, RemoveShareResponse, ShareTreeRequest, ShareTreeResponse, UpdateShareRequest, UpdateShareResponse } from "./tree_pb.js";
+import { CancelOwnershipTransferRequest, CancelOwnershipTransferResponse, CancelTreeInvitationRequest, CancelTreeInvitationResponse, CloneTreeRequest, CloneTreeResponse, CreateTreeRequest, CreateTreeResponse, DeleteTreeRequest, DeleteTreeResponse, DeleteTreeTemplateRequest, DeleteTreeTemplateResponse, GenerateShareLinkRequest, GenerateShareLinkResponse, GetFacultyStatsRequest, GetFacultyStatsResponse, GetMyRoleRequest, GetMyRoleResponse, GetTreeByShareTokenRequest, GetTreeByShareTokenResponse, GetTreeRequest, GetTreeResponse, GetTreeStatsRequest, GetTreeStatsResponse, JoinShareLinkRequest, JoinShareLinkResponse, ListAccessibleTreesRequest, ListAccessibleTreesResponse, ListAuditEventsRequest, ListAuditEventsResponse, ListIncomingOwnershipTransfersRequest, ListIncomingOwnershipTransfersResponse, ListMyTreesRequest, ListMyTreesResponse, ListOwnershipTransfersRequest, ListOwnershipTransfersResponse, ListShareLinksRequest, ListShareLinksResponse, ListSharedWithMeRequest, ListSharedWithMeResponse, ListTreeInvitationsRequest, ListTreeInvitationsResponse, ListTreeSharesRequest, ListTreeSharesResponse, ListTreeTemplatesRequest, ListTreeTemplatesResponse, RemoveShareRequest, RemoveShareResponse, RepairTreeStructureRequest, RepairTreeStructureResponse, ResendTreeInvitationRequest, ResendTreeInvitationResponse, RespondOwnershipTransferRequest, RespondOwnershipTransferResponse, RevokeShareLinkRequest, RevokeShareLinkResponse, RotateShareLinkRequest, RotateShareLinkResponse, SaveTreeAsTemplateRequest, SaveTreeAsTemplateResponse, ShareTreeRequest, ShareTreeResponse, TransferOwnershipRequest, TransferOwnershipResponse, UpdateContactPrivacyRequest, UpdateContactPrivacyResponse, UpdateShareRequest, UpdateShareResponse } from "./tree_pb.js";
 import { MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -48,6 +48,53 @@ export const TreeService = {
       O: DeleteTreeResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * @generated from rpc tree.v1.TreeService.UpdateContactPrivacy
+     */
+    updateContactPrivacy: {
+      name: "UpdateContactPrivacy",
+      I: UpdateContactPrivacyRequest,
+      O: UpdateContactPrivacyResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ★ Clone / templates
+     *
+     * @generated from rpc tree.v1.TreeService.CloneTree
+     */
+    cloneTree: {
+      name: "CloneTree",
+      I: CloneTreeRequest,
+      O: CloneTreeResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc tree.v1.TreeService.SaveTreeAsTemplate
+     */
+    saveTreeAsTemplate: {
+      name: "SaveTreeAsTemplate",
+      I: SaveTreeAsTemplateRequest,
+      O: SaveTreeAsTemplateResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc tree.v1.TreeService.ListTreeTemplates
+     */
+    listTreeTemplates: {
+      name: "ListTreeTemplates",
+      I: ListTreeTemplatesRequest,
+      O: ListTreeTemplatesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc tree.v1.TreeService.DeleteTreeTemplate
+     */
+    deleteTreeTemplate: {
+      name: "DeleteTreeTemplate",
+      I: DeleteTreeTemplateRequest,
+      O: DeleteTreeTemplateResponse,
+      kind: MethodKind.Unary,
+    },
     /**
      * ★ Sharing (ต้อง login)
      *
@@ -95,6 +142,15 @@ export const TreeService = {
       O: ListSharedWithMeResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * @generated from rpc tree.v1.TreeService.ListAccessibleTrees
+     */
+    listAccessibleTrees: {
+      name: "ListAccessibleTrees",
+      I: ListAccessibleTreesRequest,
+      O: ListAccessibleTreesResponse,
+      kind: MethodKind.Unary,
+    },
     /**
      * @generated from rpc tree.v1.TreeService.GetMyRole
      */
@@ -104,6 +160,122 @@ export const TreeService = {
       O: GetMyRoleResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * @generated from rpc tree.v1.TreeService.ListTreeInvitations
+     */
+    listTreeInvitations: {
+      name: "ListTreeInvitations",
+      I: ListTreeInvitationsRequest,
+      O: ListTreeInvitationsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc tree.v1.TreeService.ResendTreeInvitation
+     */
+    resendTreeInvitation: {
+      name: "ResendTreeInvitation",
+      I: ResendTreeInvitationRequest,
+      O: ResendTreeInvitationResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc tree.v1.TreeService.CancelTreeInvitation
+     */
+    cancelTreeInvitation: {
+      name: "CancelTreeInvitation",
+      I: CancelTreeInvitationRequest,
+      O: CancelTreeInvitationResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ★ Ownership transfer
+     *
+     * @generated from rpc tree.v1.TreeService.TransferOwnership
+     */
+    transferOwnership: {
+      name: "TransferOwnership",
+      I: TransferOwnershipRequest,
+      O: TransferOwnershipResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc tree.v1.TreeService.RespondOwnershipTransfer
+     */
+    respondOwnershipTransfer: {
+      name: "RespondOwnershipTransfer",
+      I: RespondOwnershipTransferRequest,
+      O: RespondOwnershipTransferResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc tree.v1.TreeService.CancelOwnershipTransfer
+     */
+    cancelOwnershipTransfer: {
+      name: "CancelOwnershipTransfer",
+      I: CancelOwnershipTransferRequest,
+      O: CancelOwnershipTransferResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc tree.v1.TreeService.ListOwnershipTransfers
+     */
+    listOwnershipTransfers: {
+      name: "ListOwnershipTransfers",
+      I: ListOwnershipTransfersRequest,
+      O: ListOwnershipTransfersResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc tree.v1.TreeService.ListIncomingOwnershipTransfers
+     */
+    listIncomingOwnershipTransfers: {
+      name: "ListIncomingOwnershipTransfers",
+      I: ListIncomingOwnershipTransfersRequest,
+      O: ListIncomingOwnershipTransfersResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ★ Stats
+     *
+     * @generated from rpc tree.v1.TreeService.GetTreeStats
+     */
+    getTreeStats: {
+      name: "GetTreeStats",
+      I: GetTreeStatsRequest,
+      O: GetTreeStatsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc tree.v1.TreeService.GetFacultyStats
+     */
+    getFacultyStats: {
+      name: "GetFacultyStats",
+      I: GetFacultyStatsRequest,
+      O: GetFacultyStatsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ★ Integrity
+     *
+     * @generated from rpc tree.v1.TreeService.RepairTreeStructure
+     */
+    repairTreeStructure: {
+      name: "RepairTreeStructure",
+      I: RepairTreeStructureRequest,
+      O: RepairTreeStructureResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ★ Audit
+     *
+     * @generated from rpc tree.v1.TreeService.ListAuditEvents
+     */
+    listAuditEvents: {
+      name: "ListAuditEvents",
+      I: ListAuditEventsRequest,
+      O: ListAuditEventsResponse,
+      kind: MethodKind.Unary,
+    },
     /**
      * ★ Public share link
      *
@@ -124,6 +296,42 @@ export const TreeService = {
       O: GetTreeByShareTokenResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * @generated from rpc tree.v1.TreeService.ListShareLinks
+     */
+    listShareLinks: {
+      name: "ListShareLinks",
+      I: ListShareLinksRequest,
+      O: ListShareLinksResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc tree.v1.TreeService.RevokeShareLink
+     */
+    revokeShareLink: {
+      name: "RevokeShareLink",
+      I: RevokeShareLinkRequest,
+      O: RevokeShareLinkResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc tree.v1.TreeService.RotateShareLink
+     */
+    rotateShareLink: {
+      name: "RotateShareLink",
+      I: RotateShareLinkRequest,
+      O: RotateShareLinkResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc tree.v1.TreeService.JoinShareLink
+     */
+    joinShareLink: {
+      name: "JoinShareLink",
+      I: JoinShareLinkRequest,
+      O: JoinShareLinkResponse,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
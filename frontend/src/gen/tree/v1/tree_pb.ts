@@ -10,7 +10,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file tree/v1/tree.proto.
  */
 export const file_tree_v1_tree: GenFile = /*@__PURE__*/
-  fileDesc("ChJ0cmVlL3YxL3RyZWUucHJvdG8SB3RyZWUudjEiuwEKBFRyZWUSCgoCaWQYASABKAkSDAoEbmFtZRgCIAEoCRITCgtkZXNjcmlwdGlvbhgDIAEoCRIPCgdmYWN1bHR5GAQgASgJEhIKCmRlcGFydG1lbnQYBSABKAkSEgoKY3JlYXRlZF9ieRgGIAEoCRISCgpjcmVhdGVkX2F0GAcgASgJEhIKCnVwZGF0ZWRfYXQYCCABKAkSIwoHbXlfcm9sZRgJIAEoDjISLnRyZWUudjEuU2hhcmVSb2xlIssBCglUcmVlU2hhcmUSCgoCaWQYASABKAkSDwoHdHJlZV9pZBgCIAEoCRIPCgd1c2VyX2lkGAMgASgJEiAKBHJvbGUYBCABKA4yEi50cmVlLnYxLlNoYXJlUm9sZRISCgp1c2VyX2VtYWlsGAUgASgJEhkKEXVzZXJfZGlzcGxheV9uYW1lGAYgASgJEhcKD3VzZXJfYXZhdGFyX3VybBgHIAEoCRISCgppbnZpdGVkX2J5GAggASgJEhIKCmNyZWF0ZWRfYXQYCSABKAkiWwoRQ3JlYXRlVHJlZVJlcXVlc3QSDAoEbmFtZRgBIAEoCRITCgtkZXNjcmlwdGlvbhgCIAEoCRIPCgdmYWN1bHR5GAMgASgJEhIKCmRlcGFydG1lbnQYBCABKAkiMQoSQ3JlYXRlVHJlZVJlc3BvbnNlEhsKBHRyZWUYASABKAsyDS50cmVlLnYxLlRyZWUiHAoOR2V0VHJlZVJlcXVlc3QSCgoCaWQYASABKAkiLgoPR2V0VHJlZVJlc3BvbnNlEhsKBHRyZWUYASABKAsyDS50cmVlLnYxLlRyZWUiFAoSTGlzdE15VHJlZXNSZXF1ZXN0IjMKE0xpc3RNeVRyZWVzUmVzcG9uc2USHAoFdHJlZXMYASADKAsyDS50cmVlLnYxLlRyZWUiHwoRRGVsZXRlVHJlZVJlcXVlc3QSCgoCaWQYASABKAkiFAoSRGVsZXRlVHJlZVJlc3BvbnNlIlQKEFNoYXJlVHJlZVJlcXVlc3QSDwoHdHJlZV9pZBgBIAEoCRINCgVlbWFpbBgCIAEoCRIgCgRyb2xlGAMgASgOMhIudHJlZS52MS5TaGFyZVJvbGUiNgoRU2hhcmVUcmVlUmVzcG9uc2USIQoFc2hhcmUYASABKAsyEi50cmVlLnYxLlRyZWVTaGFyZSJYChJVcGRhdGVTaGFyZVJlcXVlc3QSDwoHdHJlZV9pZBgBIAEoCRIPCgd1c2VyX2lkGAIgASgJEiAKBHJvbGUYAyABKA4yEi50cmVlLnYxLlNoYXJlUm9sZSI4ChNVcGRhdGVTaGFyZVJlc3BvbnNlEiEKBXNoYXJlGAEgASgLMhIudHJlZS52MS5UcmVlU2hhcmUiNgoSUmVtb3ZlU2hhcmVSZXF1ZXN0Eg8KB3RyZWVfaWQYASABKAkSDwoHdXNlcl9pZBgCIAEoCSIVChNSZW1vdmVTaGFyZVJlc3BvbnNlIigKFUxpc3RUcmVlU2hhcmVzUmVxdWVzdBIPCgd0cmVlX2lkGAEgASgJIjwKFkxpc3RUcmVlU2hhcmVzUmVzcG9uc2USIgoGc2hhcmVzGAEgAygLMhIudHJlZS52MS5UcmVlU2hhcmUiGQoXTGlzdFNoYXJlZFdpdGhNZVJlcXVlc3QiOAoYTGlzdFNoYXJlZFdpdGhNZVJlc3BvbnNlEhwKBXRyZWVzGAEgAygLMg0udHJlZS52MS5UcmVlIiMKEEdldE15Um9sZVJlcXVlc3QSDwoHdHJlZV9pZBgBIAEoCSJJChFHZXRNeVJvbGVSZXNwb25zZRIgCgRyb2xlGAEgASgOMhIudHJlZS52MS5TaGFyZVJvbGUSEgoKaXNfY3JlYXRvchgCIAEoCCIrChhHZW5lcmF0ZVNoYXJlTGlua1JlcXVlc3QSDwoHdHJlZV9pZBgBIAEoCSJDChlHZW5lcmF0ZVNoYXJlTGlua1Jlc3BvbnNlEhMKC3NoYXJlX3Rva2VuGAEgASgJEhEKCXNoYXJlX3VybBgCIAEoCSIxChpHZXRUcmVlQnlTaGFyZVRva2VuUmVxdWVzdBITCgtzaGFyZV90b2tlbhgBIAEoCSI6ChtHZXRUcmVlQnlTaGFyZVRva2VuUmVzcG9uc2USGwoEdHJlZRgBIAEoCzINLnRyZWUudjEuVHJlZSprCglTaGFyZVJvbGUSGgoWU0hBUkVfUk9MRV9VTlNQRUNJRklFRBAAEhUKEVNIQVJFX1JPTEVfVklFV0VSEAESFQoRU0hBUkVfUk9MRV9FRElUT1IQAhIUChBTSEFSRV9ST0xFX09XTkVSEAMyqQcKC1RyZWVTZXJ2aWNlEkUKCkNyZWF0ZVRyZWUSGi50cmVlLnYxLkNyZWF0ZVRyZWVSZXF1ZXN0GhsudHJlZS52MS5DcmVhdGVUcmVlUmVzcG9uc2USPAoHR2V0VHJlZRIXLnRyZWUudjEuR2V0VHJlZVJlcXVlc3QaGC50cmVlLnYxLkdldFRyZWVSZXNwb25zZRJICgtMaXN0TXlUcmVlcxIbLnRyZWUudjEuTGlzdE15VHJlZXNSZXF1ZXN0GhwudHJlZS52MS5MaXN0TXlUcmVlc1Jlc3BvbnNlEkUKCkRlbGV0ZVRyZWUSGi50cmVlLnYxLkRlbGV0ZVRyZWVSZXF1ZXN0GhsudHJlZS52MS5EZWxldGVUcmVlUmVzcG9uc2USQgoJU2hhcmVUcmVlEhkudHJlZS52MS5TaGFyZVRyZWVSZXF1ZXN0GhoudHJlZS52MS5TaGFyZVRyZWVSZXNwb25zZRJICgtVcGRhdGVTaGFyZRIbLnRyZWUudjEuVXBkYXRlU2hhcmVSZXF1ZXN0GhwudHJlZS52MS5VcGRhdGVTaGFyZVJlc3BvbnNlEkgKC1JlbW92ZVNoYXJlEhsudHJlZS52MS5SZW1vdmVTaGFyZVJlcXVlc3QaHC50cmVlLnYxLlJlbW92ZVNoYXJlUmVzcG9uc2USUQoOTGlzdFRyZWVTaGFyZXMSHi50cmVlLnYxLkxpc3RUcmVlU2hhcmVzUmVxdWVzdBofLnRyZWUudjEuTGlzdFRyZWVTaGFyZXNSZXNwb25zZRJXChBMaXN0U2hhcmVkV2l0aE1lEiAudHJlZS52MS5MaXN0U2hhcmVkV2l0aE1lUmVxdWVzdBohLnRyZWUudjEuTGlzdFNoYXJlZFdpdGhNZVJlc3BvbnNlEkIKCUdldE15Um9sZRIZLnRyZWUudjEuR2V0TXlSb2xlUmVxdWVzdBoaLnRyZWUudjEuR2V0TXlSb2xlUmVzcG9uc2USWgoRR2VuZXJhdGVTaGFyZUxpbmsSIS50cmVlLnYxLkdlbmVyYXRlU2hhcmVMaW5rUmVxdWVzdBoiLnRyZWUudjEuR2VuZXJhdGVTaGFyZUxpbmtSZXNwb25zZRJgChNHZXRUcmVlQnlTaGFyZVRva2VuEiMudHJlZS52MS5HZXRUcmVlQnlTaGFyZVRva2VuUmVxdWVzdBokLnRyZWUudjEuR2V0VHJlZUJ5U2hhcmVUb2tlblJlc3BvbnNlQj5aPGdpdGh1Yi5jb20vVGl0bGVLdW5nLTAxL2NvZGUtdHJlZS1iYWNrZW5kL2dlbi90cmVlL3YxO3RyZWV2MWIGcHJvdG8z");
+  fileDesc("ChJ0cmVlL3YxL3RyZWUucHJvdG8SB3RyZWUudjEi8QIKBFRyZWUSCgoCaWQYASABKAkSDAoEbmFtZRgCIAEoCRITCgtkZXNjcmlwdGlvbhgDIAEoCRIPCgdmYWN1bHR5GAQgASgJEhIKCmRlcGFydG1lbnQYBSABKAkSEgoKY3JlYXRlZF9ieRgGIAEoCRISCgpjcmVhdGVkX2F0GAcgASgJEhIKCnVwZGF0ZWRfYXQYCCABKAkSIwoHbXlfcm9sZRgJIAEoDjISLnRyZWUudjEuU2hhcmVSb2xlEhoKEnN0cnVjdHVyZV9yZXZpc2lvbhgKIAEoAxIwCg9jb250YWN0X3ByaXZhY3kYCyABKAsyFy50cmVlLnYxLkNvbnRhY3RQcml2YWN5EhgKC2Nsb25lZF9mcm9tGAwgASgJSACIAQESGAoLdGVtcGxhdGVfaWQYDSABKAlIAYgBARISCgpub2RlX2NvdW50GA4gASgFQg4KDF9jbG9uZWRfZnJvbUIOCgxfdGVtcGxhdGVfaWQixgEKDlRyZWVJbnZpdGF0aW9uEgoKAmlkGAEgASgJEg8KB3RyZWVfaWQYAiABKAkSDQoFZW1haWwYAyABKAkSIAoEcm9sZRgEIAEoDjISLnRyZWUudjEuU2hhcmVSb2xlEhIKCmludml0ZWRfYnkYBSABKAkSEgoKc2VuZF9jb3VudBgGIAEoBRIZCgxsYXN0X3NlbnRfYXQYByABKAlIAIgBARISCgpjcmVhdGVkX2F0GAggASgJQg8KDV9sYXN0X3NlbnRfYXQirwIKCVNoYXJlTGluaxIKCgJpZBgBIAEoCRIPCgd0cmVlX2lkGAIgASgJEg0KBXRva2VuGAMgASgJEhEKCXNoYXJlX3VybBgEIAEoCRIkCgRyb2xlGAUgASgOMhYudHJlZS52MS5TaGFyZUxpbmtSb2xlEhcKCmV4cGlyZXNfYXQYBiABKAlIAIgBARIVCghtYXhfdXNlcxgHIAEoBUgBiAEBEhEKCXVzZV9jb3VudBgIIAEoBRISCgpjcmVhdGVkX2J5GAkgASgJEhcKCnJldm9rZWRfYXQYCiABKAlIAogBARISCgpjcmVhdGVkX2F0GAsgASgJEg4KBmFjdGl2ZRgMIAEoCEINCgtfZXhwaXJlc19hdEILCglfbWF4X3VzZXNCDQoLX3Jldm9rZWRfYXQi7gEKDkNvbnRhY3RQcml2YWN5EikKBXBob25lGAEgASgOMhoudHJlZS52MS5Db250YWN0VmlzaWJpbGl0eRIpCgVlbWFpbBgCIAEoDjIaLnRyZWUudjEuQ29udGFjdFZpc2liaWxpdHkSKwoHbGluZV9pZBgDIAEoDjIaLnRyZWUudjEuQ29udGFjdFZpc2liaWxpdHkSKwoHZGlzY29yZBgEIAEoDjIaLnRyZWUudjEuQ29udGFjdFZpc2liaWxpdHkSLAoIZmFjZWJvb2sYBSABKA4yGi50cmVlLnYxLkNvbnRhY3RWaXNpYmlsaXR5IvsBChFPd25lcnNoaXBUcmFuc2ZlchIKCgJpZBgBIAEoCRIPCgd0cmVlX2lkGAIgASgJEhQKDGZyb21fdXNlcl9pZBgDIAEoCRISCgp0b191c2VyX2lkGAQgASgJEi8KE3ByZXZpb3VzX293bmVyX3JvbGUYBSABKA4yEi50cmVlLnYxLlNoYXJlUm9sZRIwCgZzdGF0dXMYBiABKA4yIC50cmVlLnYxLk93bmVyc2hpcFRyYW5zZmVyU3RhdHVzEhIKCmNyZWF0ZWRfYXQYByABKAkSGAoLcmVzb2x2ZWRfYXQYCCABKAlIAIgBAUIOCgxfcmVzb2x2ZWRfYXQipgIKDkF1ZGl0Tm9kZVN0YXRlEhAKCG5pY2tuYW1lGAEgASgJEhIKCmZpcnN0X25hbWUYAiABKAkSEQoJbGFzdF9uYW1lGAMgASgJEhIKCnN0dWRlbnRfaWQYBCABKAkSEQoJcGhvdG9fdXJsGAUgASgJEg4KBnN0YXR1cxgGIAEoCRISCgpnZW5lcmF0aW9uGAcgASgFEhIKCnBvc2l0aW9uX3gYCCABKAESEgoKcG9zaXRpb25feRgJIAEoARI3CghtZXRhZGF0YRgKIAMoCzIlLnRyZWUudjEuQXVkaXROb2RlU3RhdGUuTWV0YWRhdGFFbnRyeRovCg1NZXRhZGF0YUVudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEizgEKDUF1ZGl0U25hcHNob3QSKgoEbm9kZRgBIAEoCzIXLnRyZWUudjEuQXVkaXROb2RlU3RhdGVIAIgBARISCgpwYXJlbnRfaWRzGAIgAygJEhEKCWNoaWxkX2lkcxgDIAMoCRIyCgZmaWVsZHMYBCADKAsyIi50cmVlLnYxLkF1ZGl0U25hcHNob3QuRmllbGRzRW50cnkaLQoLRmllbGRzRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4AUIHCgVfbm9kZSLTAQoKQXVkaXRFdmVudBIKCgJpZBgBIAEoAxIPCgd0cmVlX2lkGAIgASgJEhAKCGFjdG9yX2lkGAMgASgJEg4KBmFjdGlvbhgEIAEoCRIPCgdub2RlX2lkGAUgASgJEhIKCnRhcmdldF9pZHMYBiADKAkSJgoGYmVmb3JlGAcgASgLMhYudHJlZS52MS5BdWRpdFNuYXBzaG90EiUKBWFmdGVyGAggASgLMhYudHJlZS52MS5BdWRpdFNuYXBzaG90EhIKCmNyZWF0ZWRfYXQYCSABKAkipAEKDFRyZWVUZW1wbGF0ZRIKCgJpZBgBIAEoCRIMCgRuYW1lGAIgASgJEhMKC2Rlc2NyaXB0aW9uGAMgASgJEg8KB2ZhY3VsdHkYBCABKAkSEgoKZGVwYXJ0bWVudBgFIAEoCRISCgpub2RlX2NvdW50GAYgASgFEhgKEGdlbmVyYXRpb25fY291bnQYByABKAUSEgoKY3JlYXRlZF9hdBgIIAEoCSLLAQoJVHJlZVNoYXJlEgoKAmlkGAEgASgJEg8KB3RyZWVfaWQYAiABKAkSDwoHdXNlcl9pZBgDIAEoCRIgCgRyb2xlGAQgASgOMhIudHJlZS52MS5TaGFyZVJvbGUSEgoKdXNlcl9lbWFpbBgFIAEoCRIZChF1c2VyX2Rpc3BsYXlfbmFtZRgGIAEoCRIXCg91c2VyX2F2YXRhcl91cmwYByABKAkSEgoKaW52aXRlZF9ieRgIIAEoCRISCgpjcmVhdGVkX2F0GAkgASgJIp4BChFDcmVhdGVUcmVlUmVxdWVzdBIMCgRuYW1lGAEgASgJEhMKC2Rlc2NyaXB0aW9uGAIgASgJEg8KB2ZhY3VsdHkYAyABKAkSEgoKZGVwYXJ0bWVudBgEIAEoCRIYCgt0ZW1wbGF0ZV9pZBgFIAEoCUgAiAEBEhcKD2Jhc2VfZ2VuZXJhdGlvbhgGIAEoBUIOCgxfdGVtcGxhdGVfaWQiRQoSQ3JlYXRlVHJlZVJlc3BvbnNlEhsKBHRyZWUYASABKAsyDS50cmVlLnYxLlRyZWUSEgoKbm9kZV9jb3VudBgCIAEoBSIcCg5HZXRUcmVlUmVxdWVzdBIKCgJpZBgBIAEoCSIuCg9HZXRUcmVlUmVzcG9uc2USGwoEdHJlZRgBIAEoCzINLnRyZWUudjEuVHJlZSLfAQoPVHJlZUxpc3RPcHRpb25zEhQKB2ZhY3VsdHkYASABKAlIAIgBARIXCgpkZXBhcnRtZW50GAIgASgJSAGIAQESIQoFcm9sZXMYAyADKA4yEi50cmVlLnYxLlNoYXJlUm9sZRInCgdzb3J0X2J5GAQgASgOMhYudHJlZS52MS5UcmVlU29ydEZpZWxkEg8KB3JldmVyc2UYBSABKAgSEQoJcGFnZV9zaXplGAYgASgFEhIKCnBhZ2VfdG9rZW4YByABKAlCCgoIX2ZhY3VsdHlCDQoLX2RlcGFydG1lbnQiPwoSTGlzdE15VHJlZXNSZXF1ZXN0EikKB29wdGlvbnMYASABKAsyGC50cmVlLnYxLlRyZWVMaXN0T3B0aW9ucyJMChNMaXN0TXlUcmVlc1Jlc3BvbnNlEhwKBXRyZWVzGAEgAygLMg0udHJlZS52MS5UcmVlEhcKD25leHRfcGFnZV90b2tlbhgCIAEoCSIfChFEZWxldGVUcmVlUmVxdWVzdBIKCgJpZBgBIAEoCSIUChJEZWxldGVUcmVlUmVzcG9uc2UiYAobVXBkYXRlQ29udGFjdFByaXZhY3lSZXF1ZXN0Eg8KB3RyZWVfaWQYASABKAkSMAoPY29udGFjdF9wcml2YWN5GAIgASgLMhcudHJlZS52MS5Db250YWN0UHJpdmFjeSI7ChxVcGRhdGVDb250YWN0UHJpdmFjeVJlc3BvbnNlEhsKBHRyZWUYASABKAsyDS50cmVlLnYxLlRyZWUiSwoQQ2xvbmVUcmVlUmVxdWVzdBIPCgd0cmVlX2lkGAEgASgJEgwKBG5hbWUYAiABKAkSGAoQaW5jbHVkZV9jb250YWN0cxgDIAEoCCJEChFDbG9uZVRyZWVSZXNwb25zZRIbCgR0cmVlGAEgASgLMg0udHJlZS52MS5UcmVlEhIKCm5vZGVfY291bnQYAiABKAUiYwoZU2F2ZVRyZWVBc1RlbXBsYXRlUmVxdWVzdBIPCgd0cmVlX2lkGAEgASgJEgwKBG5hbWUYAiABKAkSEwoLZGVzY3JpcHRpb24YAyABKAkSEgoKa2VlcF9uYW1lcxgEIAEoCCJFChpTYXZlVHJlZUFzVGVtcGxhdGVSZXNwb25zZRInCgh0ZW1wbGF0ZRgBIAEoCzIVLnRyZWUudjEuVHJlZVRlbXBsYXRlIhoKGExpc3RUcmVlVGVtcGxhdGVzUmVxdWVzdCJFChlMaXN0VHJlZVRlbXBsYXRlc1Jlc3BvbnNlEigKCXRlbXBsYXRlcxgBIAMoCzIVLnRyZWUudjEuVHJlZVRlbXBsYXRlIicKGURlbGV0ZVRyZWVUZW1wbGF0ZVJlcXVlc3QSCgoCaWQYASABKAkiHAoaRGVsZXRlVHJlZVRlbXBsYXRlUmVzcG9uc2UiVAoQU2hhcmVUcmVlUmVxdWVzdBIPCgd0cmVlX2lkGAEgASgJEg0KBWVtYWlsGAIgASgJEiAKBHJvbGUYAyABKA4yEi50cmVlLnYxLlNoYXJlUm9sZSJjChFTaGFyZVRyZWVSZXNwb25zZRIhCgVzaGFyZRgBIAEoCzISLnRyZWUudjEuVHJlZVNoYXJlEisKCmludml0YXRpb24YAiABKAsyFy50cmVlLnYxLlRyZWVJbnZpdGF0aW9uIlgKElVwZGF0ZVNoYXJlUmVxdWVzdBIPCgd0cmVlX2lkGAEgASgJEg8KB3VzZXJfaWQYAiABKAkSIAoEcm9sZRgDIAEoDjISLnRyZWUudjEuU2hhcmVSb2xlIjgKE1VwZGF0ZVNoYXJlUmVzcG9uc2USIQoFc2hhcmUYASABKAsyEi50cmVlLnYxLlRyZWVTaGFyZSI2ChJSZW1vdmVTaGFyZVJlcXVlc3QSDwoHdHJlZV9pZBgBIAEoCRIPCgd1c2VyX2lkGAIgASgJIhUKE1JlbW92ZVNoYXJlUmVzcG9uc2UiKAoVTGlzdFRyZWVTaGFyZXNSZXF1ZXN0Eg8KB3RyZWVfaWQYASABKAkiPAoWTGlzdFRyZWVTaGFyZXNSZXNwb25zZRIiCgZzaGFyZXMYASADKAsyEi50cmVlLnYxLlRyZWVTaGFyZSItChpMaXN0VHJlZUludml0YXRpb25zUmVxdWVzdBIPCgd0cmVlX2lkGAEgASgJIksKG0xpc3RUcmVlSW52aXRhdGlvbnNSZXNwb25zZRIsCgtpbnZpdGF0aW9ucxgBIAMoCzIXLnRyZWUudjEuVHJlZUludml0YXRpb24iRQobUmVzZW5kVHJlZUludml0YXRpb25SZXF1ZXN0Eg8KB3RyZWVfaWQYASABKAkSFQoNaW52aXRhdGlvbl9pZBgCIAEoCSJLChxSZXNlbmRUcmVlSW52aXRhdGlvblJlc3BvbnNlEisKCmludml0YXRpb24YASABKAsyFy50cmVlLnYxLlRyZWVJbnZpdGF0aW9uIkUKG0NhbmNlbFRyZWVJbnZpdGF0aW9uUmVxdWVzdBIPCgd0cmVlX2lkGAEgASgJEhUKDWludml0YXRpb25faWQYAiABKAkiHgocQ2FuY2VsVHJlZUludml0YXRpb25SZXNwb25zZSJEChdMaXN0U2hhcmVkV2l0aE1lUmVxdWVzdBIpCgdvcHRpb25zGAEgASgLMhgudHJlZS52MS5UcmVlTGlzdE9wdGlvbnMiUQoYTGlzdFNoYXJlZFdpdGhNZVJlc3BvbnNlEhwKBXRyZWVzGAEgAygLMg0udHJlZS52MS5UcmVlEhcKD25leHRfcGFnZV90b2tlbhgCIAEoCSJHChpMaXN0QWNjZXNzaWJsZVRyZWVzUmVxdWVzdBIpCgdvcHRpb25zGAEgASgLMhgudHJlZS52MS5UcmVlTGlzdE9wdGlvbnMiVAobTGlzdEFjY2Vzc2libGVUcmVlc1Jlc3BvbnNlEhwKBXRyZWVzGAEgAygLMg0udHJlZS52MS5UcmVlEhcKD25leHRfcGFnZV90b2tlbhgCIAEoCSIjChBHZXRNeVJvbGVSZXF1ZXN0Eg8KB3RyZWVfaWQYASABKAkiSQoRR2V0TXlSb2xlUmVzcG9uc2USIAoEcm9sZRgBIAEoDjISLnRyZWUudjEuU2hhcmVSb2xlEhIKCmlzX2NyZWF0b3IYAiABKAgicAoYVHJhbnNmZXJPd25lcnNoaXBSZXF1ZXN0Eg8KB3RyZWVfaWQYASABKAkSEgoKdG9fdXNlcl9pZBgCIAEoCRIvChNwcmV2aW91c19vd25lcl9yb2xlGAMgASgOMhIudHJlZS52MS5TaGFyZVJvbGUiSQoZVHJhbnNmZXJPd25lcnNoaXBSZXNwb25zZRIsCgh0cmFuc2ZlchgBIAEoCzIaLnRyZWUudjEuT3duZXJzaGlwVHJhbnNmZXIiRgofUmVzcG9uZE93bmVyc2hpcFRyYW5zZmVyUmVxdWVzdBITCgt0cmFuc2Zlcl9pZBgBIAEoCRIOCgZhY2NlcHQYAiABKAgibQogUmVzcG9uZE93bmVyc2hpcFRyYW5zZmVyUmVzcG9uc2USLAoIdHJhbnNmZXIYASABKAsyGi50cmVlLnYxLk93bmVyc2hpcFRyYW5zZmVyEhsKBHRyZWUYAiABKAsyDS50cmVlLnYxLlRyZWUiNQoeQ2FuY2VsT3duZXJzaGlwVHJhbnNmZXJSZXF1ZXN0EhMKC3RyYW5zZmVyX2lkGAEgASgJIk8KH0NhbmNlbE93bmVyc2hpcFRyYW5zZmVyUmVzcG9uc2USLAoIdHJhbnNmZXIYASABKAsyGi50cmVlLnYxLk93bmVyc2hpcFRyYW5zZmVyIjAKHUxpc3RPd25lcnNoaXBUcmFuc2ZlcnNSZXF1ZXN0Eg8KB3RyZWVfaWQYASABKAkiTwoeTGlzdE93bmVyc2hpcFRyYW5zZmVyc1Jlc3BvbnNlEi0KCXRyYW5zZmVycxgBIAMoCzIaLnRyZWUudjEuT3duZXJzaGlwVHJhbnNmZXIiJwolTGlzdEluY29taW5nT3duZXJzaGlwVHJhbnNmZXJzUmVxdWVzdCJXCiZMaXN0SW5jb21pbmdPd25lcnNoaXBUcmFuc2ZlcnNSZXNwb25zZRItCgl0cmFuc2ZlcnMYASADKAsyGi50cmVlLnYxLk93bmVyc2hpcFRyYW5zZmVyIjQKD0dlbmVyYXRpb25Db3VudBISCgpnZW5lcmF0aW9uGAEgASgFEg0KBWNvdW50GAIgASgFIkQKDFN0YXR1c0NvdW50cxIQCghzdHVkeWluZxgBIAEoBRIRCglncmFkdWF0ZWQYAiABKAUSDwoHcmV0aXJlZBgDIAEoBSI5CgpUcmVuZFBvaW50Eg0KBW1vbnRoGAEgASgJEg0KBWFkZGVkGAIgASgFEg0KBXRvdGFsGAMgASgFIuICCglUcmVlU3RhdHMSFAoMbWVtYmVyX2NvdW50GAEgASgFEi0KC2dlbmVyYXRpb25zGAIgAygLMhgudHJlZS52MS5HZW5lcmF0aW9uQ291bnQSJwoIc3RhdHVzZXMYAyABKAsyFS50cmVlLnYxLlN0YXR1c0NvdW50cxIUCgxzZW5pb3JfY291bnQYBCABKAUSHwoXYXZnX2NoaWxkcmVuX3Blcl9zZW5pb3IYBSABKAESFAoMbWF4X2NoaWxkcmVuGAYgASgFEhcKD2RlZXBlc3RfbGluZWFnZRgHIAEoBRIUCgxkZWVwZXN0X3BhdGgYCCADKAkSEgoKcm9vdF9jb3VudBgJIAEoBRIXCg9vcnBoYW5fcm9vdF9pZHMYCiADKAkSGgoSbXVsdGlfcGFyZW50X2NvdW50GAsgASgFEiIKBXRyZW5kGAwgAygLMhMudHJlZS52MS5UcmVuZFBvaW50IiYKE0dldFRyZWVTdGF0c1JlcXVlc3QSDwoHdHJlZV9pZBgBIAEoCSI5ChRHZXRUcmVlU3RhdHNSZXNwb25zZRIhCgVzdGF0cxgBIAEoCzISLnRyZWUudjEuVHJlZVN0YXRzIngKD0RlcGFydG1lbnRTdGF0cxISCgpkZXBhcnRtZW50GAEgASgJEhIKCnRyZWVfY291bnQYAiABKAUSFAoMbWVtYmVyX2NvdW50GAMgASgFEicKCHN0YXR1c2VzGAQgASgLMhUudHJlZS52MS5TdGF0dXNDb3VudHMioQEKDEZhY3VsdHlTdGF0cxIPCgdmYWN1bHR5GAEgASgJEhIKCnRyZWVfY291bnQYAiABKAUSFAoMbWVtYmVyX2NvdW50GAMgASgFEicKCHN0YXR1c2VzGAQgASgLMhUudHJlZS52MS5TdGF0dXNDb3VudHMSLQoLZGVwYXJ0bWVudHMYBSADKAsyGC50cmVlLnYxLkRlcGFydG1lbnRTdGF0cyI6ChZHZXRGYWN1bHR5U3RhdHNSZXF1ZXN0EhQKB2ZhY3VsdHkYASABKAlIAIgBAUIKCghfZmFjdWx0eSJDChdHZXRGYWN1bHR5U3RhdHNSZXNwb25zZRIoCglmYWN1bHRpZXMYASADKAsyFS50cmVlLnYxLkZhY3VsdHlTdGF0cyJqCg5TdHJ1Y3R1cmVJc3N1ZRIMCgRraW5kGAEgASgJEg8KB25vZGVfaWQYAiABKAkSFgoJcGFyZW50X2lkGAMgASgJSACIAQESEwoLZGVzY3JpcHRpb24YBCABKAlCDAoKX3BhcmVudF9pZCI+ChpSZXBhaXJUcmVlU3RydWN0dXJlUmVxdWVzdBIPCgd0cmVlX2lkGAEgASgJEg8KB2RyeV9ydW4YAiABKAgidAobUmVwYWlyVHJlZVN0cnVjdHVyZVJlc3BvbnNlEicKBmlzc3VlcxgBIAMoCzIXLnRyZWUudjEuU3RydWN0dXJlSXNzdWUSEAoIcmVwYWlyZWQYAiABKAgSGgoSc3RydWN0dXJlX3JldmlzaW9uGAMgASgDItIBChZMaXN0QXVkaXRFdmVudHNSZXF1ZXN0Eg8KB3RyZWVfaWQYASABKAkSFAoHbm9kZV9pZBgCIAEoCUgAiAEBEhUKCGFjdG9yX2lkGAMgASgJSAGIAQESEgoFc2luY2UYBCABKAlIAogBARISCgV1bnRpbBgFIAEoCUgDiAEBEhEKCXBhZ2Vfc2l6ZRgGIAEoBRISCgpwYWdlX3Rva2VuGAcgASgJQgoKCF9ub2RlX2lkQgsKCV9hY3Rvcl9pZEIICgZfc2luY2VCCAoGX3VudGlsIlcKF0xpc3RBdWRpdEV2ZW50c1Jlc3BvbnNlEiMKBmV2ZW50cxgBIAMoCzITLnRyZWUudjEuQXVkaXRFdmVudBIXCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAkinQEKGEdlbmVyYXRlU2hhcmVMaW5rUmVxdWVzdBIPCgd0cmVlX2lkGAEgASgJEiQKBHJvbGUYAiABKA4yFi50cmVlLnYxLlNoYXJlTGlua1JvbGUSFwoKZXhwaXJlc19hdBgDIAEoCUgAiAEBEhUKCG1heF91c2VzGAQgASgFSAGIAQFCDQoLX2V4cGlyZXNfYXRCCwoJX21heF91c2VzImUKGUdlbmVyYXRlU2hhcmVMaW5rUmVzcG9uc2USEwoLc2hhcmVfdG9rZW4YASABKAkSEQoJc2hhcmVfdXJsGAIgASgJEiAKBGxpbmsYAyABKAsyEi50cmVlLnYxLlNoYXJlTGluayIoChVMaXN0U2hhcmVMaW5rc1JlcXVlc3QSDwoHdHJlZV9pZBgBIAEoCSI7ChZMaXN0U2hhcmVMaW5rc1Jlc3BvbnNlEiEKBWxpbmtzGAEgAygLMhIudHJlZS52MS5TaGFyZUxpbmsiOgoWUmV2b2tlU2hhcmVMaW5rUmVxdWVzdBIPCgd0cmVlX2lkGAEgASgJEg8KB2xpbmtfaWQYAiABKAkiOwoXUmV2b2tlU2hhcmVMaW5rUmVzcG9uc2USIAoEbGluaxgBIAEoCzISLnRyZWUudjEuU2hhcmVMaW5rIjoKFlJvdGF0ZVNoYXJlTGlua1JlcXVlc3QSDwoHdHJlZV9pZBgBIAEoCRIPCgdsaW5rX2lkGAIgASgJIjsKF1JvdGF0ZVNoYXJlTGlua1Jlc3BvbnNlEiAKBGxpbmsYASABKAsyEi50cmVlLnYxLlNoYXJlTGluayIrChRKb2luU2hhcmVMaW5rUmVxdWVzdBITCgtzaGFyZV90b2tlbhgBIAEoCSI0ChVKb2luU2hhcmVMaW5rUmVzcG9uc2USGwoEdHJlZRgBIAEoCzINLnRyZWUudjEuVHJlZSIxChpHZXRUcmVlQnlTaGFyZVRva2VuUmVxdWVzdBITCgtzaGFyZV90b2tlbhgBIAEoCSKXAQobR2V0VHJlZUJ5U2hhcmVUb2tlblJlc3BvbnNlEhsKBHRyZWUYASABKAsyDS50cmVlLnYxLlRyZWUSKQoJbGlua19yb2xlGAIgASgOMhYudHJlZS52MS5TaGFyZUxpbmtSb2xlEhwKD2xpbmtfZXhwaXJlc19hdBgDIAEoCUgAiAEBQhIKEF9saW5rX2V4cGlyZXNfYXQqawoJU2hhcmVSb2xlEhoKFlNIQVJFX1JPTEVfVU5TUEVDSUZJRUQQABIVChFTSEFSRV9ST0xFX1ZJRVdFUhABEhUKEVNIQVJFX1JPTEVfRURJVE9SEAISFAoQU0hBUkVfUk9MRV9PV05FUhADKowBCg1TaGFyZUxpbmtSb2xlEh8KG1NIQVJFX0xJTktfUk9MRV9VTlNQRUNJRklFRBAAEhgKFFNIQVJFX0xJTktfUk9MRV9WSUVXEAESHwobU0hBUkVfTElOS19ST0xFX0pPSU5fVklFV0VSEAISHwobU0hBUkVfTElOS19ST0xFX0pPSU5fRURJVE9SEAMqlgEKEUNvbnRhY3RWaXNpYmlsaXR5EiIKHkNPTlRBQ1RfVklTSUJJTElUWV9VTlNQRUNJRklFRBAAEh0KGUNPTlRBQ1RfVklTSUJJTElUWV9QVUJMSUMQARIeChpDT05UQUNUX1ZJU0lCSUxJVFlfTUVNQkVSUxACEh4KGkNPTlRBQ1RfVklTSUJJTElUWV9FRElUT1JTEAMq5AEKF093bmVyc2hpcFRyYW5zZmVyU3RhdHVzEikKJU9XTkVSU0hJUF9UUkFOU0ZFUl9TVEFUVVNfVU5TUEVDSUZJRUQQABIlCiFPV05FUlNISVBfVFJBTlNGRVJfU1RBVFVTX1BFTkRJTkcQARImCiJPV05FUlNISVBfVFJBTlNGRVJfU1RBVFVTX0FDQ0VQVEVEEAISJgoiT1dORVJTSElQX1RSQU5TRkVSX1NUQVRVU19ERUNMSU5FRBADEicKI09XTkVSU0hJUF9UUkFOU0ZFUl9TVEFUVVNfQ0FOQ0VMTEVEEAQqqgEKDVRyZWVTb3J0RmllbGQSHwobVFJFRV9TT1JUX0ZJRUxEX1VOU1BFQ0lGSUVEEAASHgoaVFJFRV9TT1JUX0ZJRUxEX1VQREFURURfQVQQARIeChpUUkVFX1NPUlRfRklFTERfQ1JFQVRFRF9BVBACEhgKFFRSRUVfU09SVF9GSUVMRF9OQU1FEAMSHgoaVFJFRV9TT1JUX0ZJRUxEX05PREVfQ09VTlQQBDLOFwoLVHJlZVNlcnZpY2USRQoKQ3JlYXRlVHJlZRIaLnRyZWUudjEuQ3JlYXRlVHJlZVJlcXVlc3QaGy50cmVlLnYxLkNyZWF0ZVRyZWVSZXNwb25zZRI8CgdHZXRUcmVlEhcudHJlZS52MS5HZXRUcmVlUmVxdWVzdBoYLnRyZWUudjEuR2V0VHJlZVJlc3BvbnNlEkgKC0xpc3RNeVRyZWVzEhsudHJlZS52MS5MaXN0TXlUcmVlc1JlcXVlc3QaHC50cmVlLnYxLkxpc3RNeVRyZWVzUmVzcG9uc2USRQoKRGVsZXRlVHJlZRIaLnRyZWUudjEuRGVsZXRlVHJlZVJlcXVlc3QaGy50cmVlLnYxLkRlbGV0ZVRyZWVSZXNwb25zZRJjChRVcGRhdGVDb250YWN0UHJpdmFjeRIkLnRyZWUudjEuVXBkYXRlQ29udGFjdFByaXZhY3lSZXF1ZXN0GiUudHJlZS52MS5VcGRhdGVDb250YWN0UHJpdmFjeVJlc3BvbnNlEkIKCUNsb25lVHJlZRIZLnRyZWUudjEuQ2xvbmVUcmVlUmVxdWVzdBoaLnRyZWUudjEuQ2xvbmVUcmVlUmVzcG9uc2USXQoSU2F2ZVRyZWVBc1RlbXBsYXRlEiIudHJlZS52MS5TYXZlVHJlZUFzVGVtcGxhdGVSZXF1ZXN0GiMudHJlZS52MS5TYXZlVHJlZUFzVGVtcGxhdGVSZXNwb25zZRJaChFMaXN0VHJlZVRlbXBsYXRlcxIhLnRyZWUudjEuTGlzdFRyZWVUZW1wbGF0ZXNSZXF1ZXN0GiIudHJlZS52MS5MaXN0VHJlZVRlbXBsYXRlc1Jlc3BvbnNlEl0KEkRlbGV0ZVRyZWVUZW1wbGF0ZRIiLnRyZWUudjEuRGVsZXRlVHJlZVRlbXBsYXRlUmVxdWVzdBojLnRyZWUudjEuRGVsZXRlVHJlZVRlbXBsYXRlUmVzcG9uc2USQgoJU2hhcmVUcmVlEhkudHJlZS52MS5TaGFyZVRyZWVSZXF1ZXN0GhoudHJlZS52MS5TaGFyZVRyZWVSZXNwb25zZRJICgtVcGRhdGVTaGFyZRIbLnRyZWUudjEuVXBkYXRlU2hhcmVSZXF1ZXN0GhwudHJlZS52MS5VcGRhdGVTaGFyZVJlc3BvbnNlEkgKC1JlbW92ZVNoYXJlEhsudHJlZS52MS5SZW1vdmVTaGFyZVJlcXVlc3QaHC50cmVlLnYxLlJlbW92ZVNoYXJlUmVzcG9uc2USUQoOTGlzdFRyZWVTaGFyZXMSHi50cmVlLnYxLkxpc3RUcmVlU2hhcmVzUmVxdWVzdBofLnRyZWUudjEuTGlzdFRyZWVTaGFyZXNSZXNwb25zZRJXChBMaXN0U2hhcmVkV2l0aE1lEiAudHJlZS52MS5MaXN0U2hhcmVkV2l0aE1lUmVxdWVzdBohLnRyZWUudjEuTGlzdFNoYXJlZFdpdGhNZVJlc3BvbnNlEmAKE0xpc3RBY2Nlc3NpYmxlVHJlZXMSIy50cmVlLnYxLkxpc3RBY2Nlc3NpYmxlVHJlZXNSZXF1ZXN0GiQudHJlZS52MS5MaXN0QWNjZXNzaWJsZVRyZWVzUmVzcG9uc2USQgoJR2V0TXlSb2xlEhkudHJlZS52MS5HZXRNeVJvbGVSZXF1ZXN0GhoudHJlZS52MS5HZXRNeVJvbGVSZXNwb25zZRJgChNMaXN0VHJlZUludml0YXRpb25zEiMudHJlZS52MS5MaXN0VHJlZUludml0YXRpb25zUmVxdWVzdBokLnRyZWUudjEuTGlzdFRyZWVJbnZpdGF0aW9uc1Jlc3BvbnNlEmMKFFJlc2VuZFRyZWVJbnZpdGF0aW9uEiQudHJlZS52MS5SZXNlbmRUcmVlSW52aXRhdGlvblJlcXVlc3QaJS50cmVlLnYxLlJlc2VuZFRyZWVJbnZpdGF0aW9uUmVzcG9uc2USYwoUQ2FuY2VsVHJlZUludml0YXRpb24SJC50cmVlLnYxLkNhbmNlbFRyZWVJbnZpdGF0aW9uUmVxdWVzdBolLnRyZWUudjEuQ2FuY2VsVHJlZUludml0YXRpb25SZXNwb25zZRJaChFUcmFuc2Zlck93bmVyc2hpcBIhLnRyZWUudjEuVHJhbnNmZXJPd25lcnNoaXBSZXF1ZXN0GiIudHJlZS52MS5UcmFuc2Zlck93bmVyc2hpcFJlc3BvbnNlEm8KGFJlc3BvbmRPd25lcnNoaXBUcmFuc2ZlchIoLnRyZWUudjEuUmVzcG9uZE93bmVyc2hpcFRyYW5zZmVyUmVxdWVzdBopLnRyZWUudjEuUmVzcG9uZE93bmVyc2hpcFRyYW5zZmVyUmVzcG9uc2USbAoXQ2FuY2VsT3duZXJzaGlwVHJhbnNmZXISJy50cmVlLnYxLkNhbmNlbE93bmVyc2hpcFRyYW5zZmVyUmVxdWVzdBooLnRyZWUudjEuQ2FuY2VsT3duZXJzaGlwVHJhbnNmZXJSZXNwb25zZRJpChZMaXN0T3duZXJzaGlwVHJhbnNmZXJzEiYudHJlZS52MS5MaXN0T3duZXJzaGlwVHJhbnNmZXJzUmVxdWVzdBonLnRyZWUudjEuTGlzdE93bmVyc2hpcFRyYW5zZmVyc1Jlc3BvbnNlEoEBCh5MaXN0SW5jb21pbmdPd25lcnNoaXBUcmFuc2ZlcnMSLi50cmVlLnYxLkxpc3RJbmNvbWluZ093bmVyc2hpcFRyYW5zZmVyc1JlcXVlc3QaLy50cmVlLnYxLkxpc3RJbmNvbWluZ093bmVyc2hpcFRyYW5zZmVyc1Jlc3BvbnNlEksKDEdldFRyZWVTdGF0cxIcLnRyZWUudjEuR2V0VHJlZVN0YXRzUmVxdWVzdBodLnRyZWUudjEuR2V0VHJlZVN0YXRzUmVzcG9uc2USVAoPR2V0RmFjdWx0eVN0YXRzEh8udHJlZS52MS5HZXRGYWN1bHR5U3RhdHNSZXF1ZXN0GiAudHJlZS52MS5HZXRGYWN1bHR5U3RhdHNSZXNwb25zZRJgChNSZXBhaXJUcmVlU3RydWN0dXJlEiMudHJlZS52MS5SZXBhaXJUcmVlU3RydWN0dXJlUmVxdWVzdBokLnRyZWUudjEuUmVwYWlyVHJlZVN0cnVjdHVyZVJlc3BvbnNlElQKD0xpc3RBdWRpdEV2ZW50cxIfLnRyZWUudjEuTGlzdEF1ZGl0RXZlbnRzUmVxdWVzdBogLnRyZWUudjEuTGlzdEF1ZGl0RXZlbnRzUmVzcG9uc2USWgoRR2VuZXJhdGVTaGFyZUxpbmsSIS50cmVlLnYxLkdlbmVyYXRlU2hhcmVMaW5rUmVxdWVzdBoiLnRyZWUudjEuR2VuZXJhdGVTaGFyZUxpbmtSZXNwb25zZRJgChNHZXRUcmVlQnlTaGFyZVRva2VuEiMudHJlZS52MS5HZXRUcmVlQnlTaGFyZVRva2VuUmVxdWVzdBokLnRyZWUudjEuR2V0VHJlZUJ5U2hhcmVUb2tlblJlc3BvbnNlElEKDkxpc3RTaGFyZUxpbmtzEh4udHJlZS52MS5MaXN0U2hhcmVMaW5rc1JlcXVlc3QaHy50cmVlLnYxLkxpc3RTaGFyZUxpbmtzUmVzcG9uc2USVAoPUmV2b2tlU2hhcmVMaW5rEh8udHJlZS52MS5SZXZva2VTaGFyZUxpbmtSZXF1ZXN0GiAudHJlZS52MS5SZXZva2VTaGFyZUxpbmtSZXNwb25zZRJUCg9Sb3RhdGVTaGFyZUxpbmsSHy50cmVlLnYxLlJvdGF0ZVNoYXJlTGlua1JlcXVlc3QaIC50cmVlLnYxLlJvdGF0ZVNoYXJlTGlua1Jlc3BvbnNlEk4KDUpvaW5TaGFyZUxpbmsSHS50cmVlLnYxLkpvaW5TaGFyZUxpbmtSZXF1ZXN0Gh4udHJlZS52MS5Kb2luU2hhcmVMaW5rUmVzcG9uc2VCPlo8Z2l0aHViLmNvbS9UaXRsZUt1bmctMDEvY29kZS10cmVlLWJhY2tlbmQvZ2VuL3RyZWUvdjE7dHJlZXYxYgZwcm90bzM");
 
 /**
  * @generated from message tree.v1.Tree
@@ -62,6 +62,41 @@ export type Tree = Message<"tree.v1.Tree"> & {
    * @generated from field: tree.v1.ShareRole my_role = 9;
    */
   myRole: ShareRole;
+
+  /**
+   * เพิ่มขึ้นทุกครั้งที่ structure ถูกแก้ (ใช้กับ expected_revision)
+   *
+   * @generated from field: int64 structure_revision = 10;
+   */
+  structureRevision: bigint;
+
+  /**
+   * ค่า default ของทั้ง tree (node ตั้งทับได้)
+   *
+   * @generated from field: tree.v1.ContactPrivacy contact_privacy = 11;
+   */
+  contactPrivacy?: ContactPrivacy;
+
+  /**
+   * tree ต้นฉบับ (CloneTree)
+   *
+   * @generated from field: optional string cloned_from = 12;
+   */
+  clonedFrom?: string;
+
+  /**
+   * template ที่ใช้เริ่ม tree
+   *
+   * @generated from field: optional string template_id = 13;
+   */
+  templateId?: string;
+
+  /**
+   * มีเฉพาะใน ListMyTrees / ListSharedWithMe / ListAccessibleTrees
+   *
+   * @generated from field: int32 node_count = 14;
+   */
+  nodeCount: number;
 };
 
 /**
@@ -72,9 +107,11 @@ export const TreeSchema: GenMessage<Tree> = /*@__PURE__*/
   messageDesc(file_tree_v1_tree, 0);
 
 /**
- * @generated from message tree.v1.TreeShare
+ * คำเชิญที่ค้างให้ email ที่ยังไม่มีบัญชี (สมัครแล้วจะกลายเป็น TreeShare)
+ *
+ * @generated from message tree.v1.TreeInvitation
  */
-export type TreeShare = Message<"tree.v1.TreeShare"> & {
+export type TreeInvitation = Message<"tree.v1.TreeInvitation"> & {
   /**
    * @generated from field: string id = 1;
    */
@@ -86,9 +123,9 @@ export type TreeShare = Message<"tree.v1.TreeShare"> & {
   treeId: string;
 
   /**
-   * @generated from field: string user_id = 3;
+   * @generated from field: string email = 3;
    */
-  userId: string;
+  email: string;
 
   /**
    * @generated from field: tree.v1.ShareRole role = 4;
@@ -96,316 +133,2108 @@ export type TreeShare = Message<"tree.v1.TreeShare"> & {
   role: ShareRole;
 
   /**
-   * @generated from field: string user_email = 5;
+   * @generated from field: string invited_by = 5;
    */
-  userEmail: string;
+  invitedBy: string;
 
   /**
-   * @generated from field: string user_display_name = 6;
+   * จำนวนครั้งที่ส่ง email เชิญแล้ว
+   *
+   * @generated from field: int32 send_count = 6;
    */
-  userDisplayName: string;
+  sendCount: number;
 
   /**
-   * @generated from field: string user_avatar_url = 7;
+   * @generated from field: optional string last_sent_at = 7;
    */
-  userAvatarUrl: string;
+  lastSentAt?: string;
 
   /**
-   * @generated from field: string invited_by = 8;
+   * @generated from field: string created_at = 8;
    */
-  invitedBy: string;
+  createdAt: string;
+};
+
+/**
+ * Describes the message tree.v1.TreeInvitation.
+ * Use `create(TreeInvitationSchema)` to create a new message.
+ */
+export const TreeInvitationSchema: GenMessage<TreeInvitation> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 1);
 
+/**
+ * ลิงก์แชร์ (tree หนึ่งมีได้หลายลิงก์)
+ *
+ * @generated from message tree.v1.ShareLink
+ */
+export type ShareLink = Message<"tree.v1.ShareLink"> & {
   /**
-   * @generated from field: string created_at = 9;
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string tree_id = 2;
+   */
+  treeId: string;
+
+  /**
+   * @generated from field: string token = 3;
+   */
+  token: string;
+
+  /**
+   * @generated from field: string share_url = 4;
+   */
+  shareUrl: string;
+
+  /**
+   * @generated from field: tree.v1.ShareLinkRole role = 5;
+   */
+  role: ShareLinkRole;
+
+  /**
+   * ไม่มี = ไม่หมดอายุ
+   *
+   * @generated from field: optional string expires_at = 6;
+   */
+  expiresAt?: string;
+
+  /**
+   * จำนวนครั้งที่เข้าร่วมได้ (ลิงก์ join เท่านั้น) ไม่มี = ไม่จำกัด
+   *
+   * @generated from field: optional int32 max_uses = 7;
+   */
+  maxUses?: number;
+
+  /**
+   * @generated from field: int32 use_count = 8;
+   */
+  useCount: number;
+
+  /**
+   * @generated from field: string created_by = 9;
+   */
+  createdBy: string;
+
+  /**
+   * @generated from field: optional string revoked_at = 10;
+   */
+  revokedAt?: string;
+
+  /**
+   * @generated from field: string created_at = 11;
    */
   createdAt: string;
+
+  /**
+   * ยังใช้ได้ (ไม่ถูกยกเลิก / ไม่หมดอายุ / ยังไม่ครบจำนวน)
+   *
+   * @generated from field: bool active = 12;
+   */
+  active: boolean;
 };
 
 /**
- * Describes the message tree.v1.TreeShare.
- * Use `create(TreeShareSchema)` to create a new message.
+ * Describes the message tree.v1.ShareLink.
+ * Use `create(ShareLinkSchema)` to create a new message.
  */
-export const TreeShareSchema: GenMessage<TreeShare> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 1);
+export const ShareLinkSchema: GenMessage<ShareLink> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 2);
 
 /**
- * @generated from message tree.v1.CreateTreeRequest
+ * visibility ราย field ของช่องทางติดต่อ
+ *
+ * @generated from message tree.v1.ContactPrivacy
  */
-export type CreateTreeRequest = Message<"tree.v1.CreateTreeRequest"> & {
+export type ContactPrivacy = Message<"tree.v1.ContactPrivacy"> & {
   /**
-   * @generated from field: string name = 1;
+   * @generated from field: tree.v1.ContactVisibility phone = 1;
    */
-  name: string;
+  phone: ContactVisibility;
 
   /**
-   * @generated from field: string description = 2;
+   * @generated from field: tree.v1.ContactVisibility email = 2;
    */
-  description: string;
+  email: ContactVisibility;
 
   /**
-   * @generated from field: string faculty = 3;
+   * @generated from field: tree.v1.ContactVisibility line_id = 3;
    */
-  faculty: string;
+  lineId: ContactVisibility;
+
+  /**
+   * @generated from field: tree.v1.ContactVisibility discord = 4;
+   */
+  discord: ContactVisibility;
+
+  /**
+   * @generated from field: tree.v1.ContactVisibility facebook = 5;
+   */
+  facebook: ContactVisibility;
+};
+
+/**
+ * Describes the message tree.v1.ContactPrivacy.
+ * Use `create(ContactPrivacySchema)` to create a new message.
+ */
+export const ContactPrivacySchema: GenMessage<ContactPrivacy> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 3);
+
+/**
+ * คำขอโอนความเป็นเจ้าของ tree (เก็บไว้เป็นประวัติทุกคำขอ)
+ *
+ * @generated from message tree.v1.OwnershipTransfer
+ */
+export type OwnershipTransfer = Message<"tree.v1.OwnershipTransfer"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string tree_id = 2;
+   */
+  treeId: string;
+
+  /**
+   * @generated from field: string from_user_id = 3;
+   */
+  fromUserId: string;
+
+  /**
+   * @generated from field: string to_user_id = 4;
+   */
+  toUserId: string;
+
+  /**
+   * role ที่เจ้าของเดิมได้หลังโอนสำเร็จ
+   *
+   * @generated from field: tree.v1.ShareRole previous_owner_role = 5;
+   */
+  previousOwnerRole: ShareRole;
+
+  /**
+   * @generated from field: tree.v1.OwnershipTransferStatus status = 6;
+   */
+  status: OwnershipTransferStatus;
+
+  /**
+   * @generated from field: string created_at = 7;
+   */
+  createdAt: string;
+
+  /**
+   * @generated from field: optional string resolved_at = 8;
+   */
+  resolvedAt?: string;
+};
+
+/**
+ * Describes the message tree.v1.OwnershipTransfer.
+ * Use `create(OwnershipTransferSchema)` to create a new message.
+ */
+export const OwnershipTransferSchema: GenMessage<OwnershipTransfer> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 4);
+
+/**
+ * ค่าของ node ณ ขณะนั้น (ใน audit)
+ *
+ * @generated from message tree.v1.AuditNodeState
+ */
+export type AuditNodeState = Message<"tree.v1.AuditNodeState"> & {
+  /**
+   * @generated from field: string nickname = 1;
+   */
+  nickname: string;
+
+  /**
+   * @generated from field: string first_name = 2;
+   */
+  firstName: string;
+
+  /**
+   * @generated from field: string last_name = 3;
+   */
+  lastName: string;
+
+  /**
+   * @generated from field: string student_id = 4;
+   */
+  studentId: string;
+
+  /**
+   * @generated from field: string photo_url = 5;
+   */
+  photoUrl: string;
+
+  /**
+   * @generated from field: string status = 6;
+   */
+  status: string;
+
+  /**
+   * @generated from field: int32 generation = 7;
+   */
+  generation: number;
+
+  /**
+   * @generated from field: double position_x = 8;
+   */
+  positionX: number;
+
+  /**
+   * @generated from field: double position_y = 9;
+   */
+  positionY: number;
+
+  /**
+   * @generated from field: map<string, string> metadata = 10;
+   */
+  metadata: { [key: string]: string };
+};
+
+/**
+ * Describes the message tree.v1.AuditNodeState.
+ * Use `create(AuditNodeStateSchema)` to create a new message.
+ */
+export const AuditNodeStateSchema: GenMessage<AuditNodeState> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 5);
+
+/**
+ * สถานะก่อน / หลังแก้
+ *
+ * @generated from message tree.v1.AuditSnapshot
+ */
+export type AuditSnapshot = Message<"tree.v1.AuditSnapshot"> & {
+  /**
+   * @generated from field: optional tree.v1.AuditNodeState node = 1;
+   */
+  node?: AuditNodeState;
+
+  /**
+   * @generated from field: repeated string parent_ids = 2;
+   */
+  parentIds: string[];
+
+  /**
+   * @generated from field: repeated string child_ids = 3;
+   */
+  childIds: string[];
+
+  /**
+   * ค่าระดับ tree เช่น role, ชื่อ tree
+   *
+   * @generated from field: map<string, string> fields = 4;
+   */
+  fields: { [key: string]: string };
+};
+
+/**
+ * Describes the message tree.v1.AuditSnapshot.
+ * Use `create(AuditSnapshotSchema)` to create a new message.
+ */
+export const AuditSnapshotSchema: GenMessage<AuditSnapshot> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 6);
+
+/**
+ * การแก้ไขหนึ่งครั้ง
+ *
+ * @generated from message tree.v1.AuditEvent
+ */
+export type AuditEvent = Message<"tree.v1.AuditEvent"> & {
+  /**
+   * @generated from field: int64 id = 1;
+   */
+  id: bigint;
+
+  /**
+   * @generated from field: string tree_id = 2;
+   */
+  treeId: string;
+
+  /**
+   * @generated from field: string actor_id = 3;
+   */
+  actorId: string;
+
+  /**
+   * เช่น node_created, node_moved, share_updated
+   *
+   * @generated from field: string action = 4;
+   */
+  action: string;
+
+  /**
+   * "" = action ระดับ tree
+   *
+   * @generated from field: string node_id = 5;
+   */
+  nodeId: string;
+
+  /**
+   * @generated from field: repeated string target_ids = 6;
+   */
+  targetIds: string[];
+
+  /**
+   * ไม่มี = เพิ่งสร้าง
+   *
+   * @generated from field: tree.v1.AuditSnapshot before = 7;
+   */
+  before?: AuditSnapshot;
+
+  /**
+   * ไม่มี = ถูกลบ
+   *
+   * @generated from field: tree.v1.AuditSnapshot after = 8;
+   */
+  after?: AuditSnapshot;
+
+  /**
+   * @generated from field: string created_at = 9;
+   */
+  createdAt: string;
+};
+
+/**
+ * Describes the message tree.v1.AuditEvent.
+ * Use `create(AuditEventSchema)` to create a new message.
+ */
+export const AuditEventSchema: GenMessage<AuditEvent> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 7);
+
+/**
+ * โครง tree ที่บันทึกไว้เริ่ม tree ใหม่ (ไม่มีข้อมูลคน)
+ *
+ * @generated from message tree.v1.TreeTemplate
+ */
+export type TreeTemplate = Message<"tree.v1.TreeTemplate"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string description = 3;
+   */
+  description: string;
+
+  /**
+   * @generated from field: string faculty = 4;
+   */
+  faculty: string;
+
+  /**
+   * @generated from field: string department = 5;
+   */
+  department: string;
+
+  /**
+   * @generated from field: int32 node_count = 6;
+   */
+  nodeCount: number;
+
+  /**
+   * จำนวนรุ่นในโครง
+   *
+   * @generated from field: int32 generation_count = 7;
+   */
+  generationCount: number;
+
+  /**
+   * @generated from field: string created_at = 8;
+   */
+  createdAt: string;
+};
+
+/**
+ * Describes the message tree.v1.TreeTemplate.
+ * Use `create(TreeTemplateSchema)` to create a new message.
+ */
+export const TreeTemplateSchema: GenMessage<TreeTemplate> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 8);
+
+/**
+ * @generated from message tree.v1.TreeShare
+ */
+export type TreeShare = Message<"tree.v1.TreeShare"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string tree_id = 2;
+   */
+  treeId: string;
+
+  /**
+   * @generated from field: string user_id = 3;
+   */
+  userId: string;
+
+  /**
+   * @generated from field: tree.v1.ShareRole role = 4;
+   */
+  role: ShareRole;
+
+  /**
+   * @generated from field: string user_email = 5;
+   */
+  userEmail: string;
+
+  /**
+   * @generated from field: string user_display_name = 6;
+   */
+  userDisplayName: string;
+
+  /**
+   * @generated from field: string user_avatar_url = 7;
+   */
+  userAvatarUrl: string;
+
+  /**
+   * @generated from field: string invited_by = 8;
+   */
+  invitedBy: string;
+
+  /**
+   * @generated from field: string created_at = 9;
+   */
+  createdAt: string;
+};
+
+/**
+ * Describes the message tree.v1.TreeShare.
+ * Use `create(TreeShareSchema)` to create a new message.
+ */
+export const TreeShareSchema: GenMessage<TreeShare> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 9);
+
+/**
+ * @generated from message tree.v1.CreateTreeRequest
+ */
+export type CreateTreeRequest = Message<"tree.v1.CreateTreeRequest"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string description = 2;
+   */
+  description: string;
+
+  /**
+   * ว่าง + ใช้ template = ค่าของ template
+   *
+   * @generated from field: string faculty = 3;
+   */
+  faculty: string;
+
+  /**
+   * @generated from field: string department = 4;
+   */
+  department: string;
+
+  /**
+   * เริ่มจากโครงของ template (ของ caller เอง)
+   *
+   * @generated from field: optional string template_id = 5;
+   */
+  templateId?: string;
+
+  /**
+   * รุ่นของตำแหน่งบนสุดใน template (0 = รุ่น 1)
+   *
+   * @generated from field: int32 base_generation = 6;
+   */
+  baseGeneration: number;
+};
+
+/**
+ * Describes the message tree.v1.CreateTreeRequest.
+ * Use `create(CreateTreeRequestSchema)` to create a new message.
+ */
+export const CreateTreeRequestSchema: GenMessage<CreateTreeRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 10);
+
+/**
+ * @generated from message tree.v1.CreateTreeResponse
+ */
+export type CreateTreeResponse = Message<"tree.v1.CreateTreeResponse"> & {
+  /**
+   * @generated from field: tree.v1.Tree tree = 1;
+   */
+  tree?: Tree;
+
+  /**
+   * จำนวน node ที่สร้างจาก template
+   *
+   * @generated from field: int32 node_count = 2;
+   */
+  nodeCount: number;
+};
+
+/**
+ * Describes the message tree.v1.CreateTreeResponse.
+ * Use `create(CreateTreeResponseSchema)` to create a new message.
+ */
+export const CreateTreeResponseSchema: GenMessage<CreateTreeResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 11);
+
+/**
+ * @generated from message tree.v1.GetTreeRequest
+ */
+export type GetTreeRequest = Message<"tree.v1.GetTreeRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message tree.v1.GetTreeRequest.
+ * Use `create(GetTreeRequestSchema)` to create a new message.
+ */
+export const GetTreeRequestSchema: GenMessage<GetTreeRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 12);
+
+/**
+ * @generated from message tree.v1.GetTreeResponse
+ */
+export type GetTreeResponse = Message<"tree.v1.GetTreeResponse"> & {
+  /**
+   * @generated from field: tree.v1.Tree tree = 1;
+   */
+  tree?: Tree;
+};
+
+/**
+ * Describes the message tree.v1.GetTreeResponse.
+ * Use `create(GetTreeResponseSchema)` to create a new message.
+ */
+export const GetTreeResponseSchema: GenMessage<GetTreeResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 13);
+
+/**
+ * ตัวเลือกของรายการ tree (ใช้ร่วมกันทุก RPC ที่ list tree ของ user)
+ * ไม่ส่ง options มาเลย = ได้ทุก tree ในหน้าเดียว ไม่มี next_page_token (แบบเดิมก่อนมี pagination)
+ *
+ * @generated from message tree.v1.TreeListOptions
+ */
+export type TreeListOptions = Message<"tree.v1.TreeListOptions"> & {
+  /**
+   * ตรงทั้งคำ ไม่สนตัวพิมพ์
+   *
+   * @generated from field: optional string faculty = 1;
+   */
+  faculty?: string;
+
+  /**
+   * @generated from field: optional string department = 2;
+   */
+  department?: string;
+
+  /**
+   * ว่าง = ทุก role (tree ที่ตัวเองสร้าง = OWNER)
+   *
+   * @generated from field: repeated tree.v1.ShareRole roles = 3;
+   */
+  roles: ShareRole[];
+
+  /**
+   * @generated from field: tree.v1.TreeSortField sort_by = 4;
+   */
+  sortBy: TreeSortField;
+
+  /**
+   * กลับทิศจากลำดับปกติของ sort_by
+   *
+   * @generated from field: bool reverse = 5;
+   */
+  reverse: boolean;
+
+  /**
+   * 0 = 50, มากสุด 200
+   *
+   * @generated from field: int32 page_size = 6;
+   */
+  pageSize: number;
+
+  /**
+   * next_page_token จากหน้าก่อน (ต้องใช้ sort_by / reverse เดิม)
+   *
+   * @generated from field: string page_token = 7;
+   */
+  pageToken: string;
+};
+
+/**
+ * Describes the message tree.v1.TreeListOptions.
+ * Use `create(TreeListOptionsSchema)` to create a new message.
+ */
+export const TreeListOptionsSchema: GenMessage<TreeListOptions> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 14);
+
+/**
+ * @generated from message tree.v1.ListMyTreesRequest
+ */
+export type ListMyTreesRequest = Message<"tree.v1.ListMyTreesRequest"> & {
+  /**
+   * @generated from field: tree.v1.TreeListOptions options = 1;
+   */
+  options?: TreeListOptions;
+};
+
+/**
+ * Describes the message tree.v1.ListMyTreesRequest.
+ * Use `create(ListMyTreesRequestSchema)` to create a new message.
+ */
+export const ListMyTreesRequestSchema: GenMessage<ListMyTreesRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 15);
+
+/**
+ * @generated from message tree.v1.ListMyTreesResponse
+ */
+export type ListMyTreesResponse = Message<"tree.v1.ListMyTreesResponse"> & {
+  /**
+   * @generated from field: repeated tree.v1.Tree trees = 1;
+   */
+  trees: Tree[];
+
+  /**
+   * "" = หมดแล้ว
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+};
+
+/**
+ * Describes the message tree.v1.ListMyTreesResponse.
+ * Use `create(ListMyTreesResponseSchema)` to create a new message.
+ */
+export const ListMyTreesResponseSchema: GenMessage<ListMyTreesResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 16);
+
+/**
+ * @generated from message tree.v1.DeleteTreeRequest
+ */
+export type DeleteTreeRequest = Message<"tree.v1.DeleteTreeRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message tree.v1.DeleteTreeRequest.
+ * Use `create(DeleteTreeRequestSchema)` to create a new message.
+ */
+export const DeleteTreeRequestSchema: GenMessage<DeleteTreeRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 17);
+
+/**
+ * @generated from message tree.v1.DeleteTreeResponse
+ */
+export type DeleteTreeResponse = Message<"tree.v1.DeleteTreeResponse"> & {
+};
+
+/**
+ * Describes the message tree.v1.DeleteTreeResponse.
+ * Use `create(DeleteTreeResponseSchema)` to create a new message.
+ */
+export const DeleteTreeResponseSchema: GenMessage<DeleteTreeResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 18);
+
+/**
+ * ตั้งค่า visibility ของช่องทางติดต่อทั้ง tree (เจ้าของเท่านั้น)
+ *
+ * @generated from message tree.v1.UpdateContactPrivacyRequest
+ */
+export type UpdateContactPrivacyRequest = Message<"tree.v1.UpdateContactPrivacyRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+
+  /**
+   * @generated from field: tree.v1.ContactPrivacy contact_privacy = 2;
+   */
+  contactPrivacy?: ContactPrivacy;
+};
+
+/**
+ * Describes the message tree.v1.UpdateContactPrivacyRequest.
+ * Use `create(UpdateContactPrivacyRequestSchema)` to create a new message.
+ */
+export const UpdateContactPrivacyRequestSchema: GenMessage<UpdateContactPrivacyRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 19);
+
+/**
+ * @generated from message tree.v1.UpdateContactPrivacyResponse
+ */
+export type UpdateContactPrivacyResponse = Message<"tree.v1.UpdateContactPrivacyResponse"> & {
+  /**
+   * @generated from field: tree.v1.Tree tree = 1;
+   */
+  tree?: Tree;
+};
+
+/**
+ * Describes the message tree.v1.UpdateContactPrivacyResponse.
+ * Use `create(UpdateContactPrivacyResponseSchema)` to create a new message.
+ */
+export const UpdateContactPrivacyResponseSchema: GenMessage<UpdateContactPrivacyResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 20);
+
+/**
+ * คัดลอก tree ทั้งต้น (ข้อมูล tree + ทุก node + structure ด้วย id ใหม่) caller เป็นเจ้าของ tree ใหม่
+ *
+ * @generated from message tree.v1.CloneTreeRequest
+ */
+export type CloneTreeRequest = Message<"tree.v1.CloneTreeRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+
+  /**
+   * ว่าง = "<ชื่อเดิม> (สำเนา)"
+   *
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * คัดลอกช่องทางติดต่อด้วย (เฉพาะที่ caller มีสิทธิ์เห็น)
+   *
+   * @generated from field: bool include_contacts = 3;
+   */
+  includeContacts: boolean;
+};
+
+/**
+ * Describes the message tree.v1.CloneTreeRequest.
+ * Use `create(CloneTreeRequestSchema)` to create a new message.
+ */
+export const CloneTreeRequestSchema: GenMessage<CloneTreeRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 21);
+
+/**
+ * @generated from message tree.v1.CloneTreeResponse
+ */
+export type CloneTreeResponse = Message<"tree.v1.CloneTreeResponse"> & {
+  /**
+   * @generated from field: tree.v1.Tree tree = 1;
+   */
+  tree?: Tree;
+
+  /**
+   * @generated from field: int32 node_count = 2;
+   */
+  nodeCount: number;
+};
+
+/**
+ * Describes the message tree.v1.CloneTreeResponse.
+ * Use `create(CloneTreeResponseSchema)` to create a new message.
+ */
+export const CloneTreeResponseSchema: GenMessage<CloneTreeResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 22);
+
+/**
+ * บันทึกโครงของ tree เป็น template (ดูได้ = บันทึกได้)
+ *
+ * @generated from message tree.v1.SaveTreeAsTemplateRequest
+ */
+export type SaveTreeAsTemplateRequest = Message<"tree.v1.SaveTreeAsTemplateRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string description = 3;
+   */
+  description: string;
+
+  /**
+   * เก็บชื่อเล่นเป็น label (ไม่เก็บ = ใช้ "รุ่น N")
+   *
+   * @generated from field: bool keep_names = 4;
+   */
+  keepNames: boolean;
+};
+
+/**
+ * Describes the message tree.v1.SaveTreeAsTemplateRequest.
+ * Use `create(SaveTreeAsTemplateRequestSchema)` to create a new message.
+ */
+export const SaveTreeAsTemplateRequestSchema: GenMessage<SaveTreeAsTemplateRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 23);
+
+/**
+ * @generated from message tree.v1.SaveTreeAsTemplateResponse
+ */
+export type SaveTreeAsTemplateResponse = Message<"tree.v1.SaveTreeAsTemplateResponse"> & {
+  /**
+   * @generated from field: tree.v1.TreeTemplate template = 1;
+   */
+  template?: TreeTemplate;
+};
+
+/**
+ * Describes the message tree.v1.SaveTreeAsTemplateResponse.
+ * Use `create(SaveTreeAsTemplateResponseSchema)` to create a new message.
+ */
+export const SaveTreeAsTemplateResponseSchema: GenMessage<SaveTreeAsTemplateResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 24);
+
+/**
+ * @generated from message tree.v1.ListTreeTemplatesRequest
+ */
+export type ListTreeTemplatesRequest = Message<"tree.v1.ListTreeTemplatesRequest"> & {
+};
+
+/**
+ * Describes the message tree.v1.ListTreeTemplatesRequest.
+ * Use `create(ListTreeTemplatesRequestSchema)` to create a new message.
+ */
+export const ListTreeTemplatesRequestSchema: GenMessage<ListTreeTemplatesRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 25);
+
+/**
+ * @generated from message tree.v1.ListTreeTemplatesResponse
+ */
+export type ListTreeTemplatesResponse = Message<"tree.v1.ListTreeTemplatesResponse"> & {
+  /**
+   * @generated from field: repeated tree.v1.TreeTemplate templates = 1;
+   */
+  templates: TreeTemplate[];
+};
+
+/**
+ * Describes the message tree.v1.ListTreeTemplatesResponse.
+ * Use `create(ListTreeTemplatesResponseSchema)` to create a new message.
+ */
+export const ListTreeTemplatesResponseSchema: GenMessage<ListTreeTemplatesResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 26);
+
+/**
+ * @generated from message tree.v1.DeleteTreeTemplateRequest
+ */
+export type DeleteTreeTemplateRequest = Message<"tree.v1.DeleteTreeTemplateRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message tree.v1.DeleteTreeTemplateRequest.
+ * Use `create(DeleteTreeTemplateRequestSchema)` to create a new message.
+ */
+export const DeleteTreeTemplateRequestSchema: GenMessage<DeleteTreeTemplateRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 27);
+
+/**
+ * @generated from message tree.v1.DeleteTreeTemplateResponse
+ */
+export type DeleteTreeTemplateResponse = Message<"tree.v1.DeleteTreeTemplateResponse"> & {
+};
+
+/**
+ * Describes the message tree.v1.DeleteTreeTemplateResponse.
+ * Use `create(DeleteTreeTemplateResponseSchema)` to create a new message.
+ */
+export const DeleteTreeTemplateResponseSchema: GenMessage<DeleteTreeTemplateResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 28);
+
+/**
+ * แชร์ tree ให้ user ด้วย email
+ *
+ * @generated from message tree.v1.ShareTreeRequest
+ */
+export type ShareTreeRequest = Message<"tree.v1.ShareTreeRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+
+  /**
+   * @generated from field: string email = 2;
+   */
+  email: string;
+
+  /**
+   * @generated from field: tree.v1.ShareRole role = 3;
+   */
+  role: ShareRole;
+};
+
+/**
+ * Describes the message tree.v1.ShareTreeRequest.
+ * Use `create(ShareTreeRequestSchema)` to create a new message.
+ */
+export const ShareTreeRequestSchema: GenMessage<ShareTreeRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 29);
+
+/**
+ * email ยังไม่มีบัญชี → ได้ invitation แทน share
+ *
+ * @generated from message tree.v1.ShareTreeResponse
+ */
+export type ShareTreeResponse = Message<"tree.v1.ShareTreeResponse"> & {
+  /**
+   * @generated from field: tree.v1.TreeShare share = 1;
+   */
+  share?: TreeShare;
+
+  /**
+   * @generated from field: tree.v1.TreeInvitation invitation = 2;
+   */
+  invitation?: TreeInvitation;
+};
+
+/**
+ * Describes the message tree.v1.ShareTreeResponse.
+ * Use `create(ShareTreeResponseSchema)` to create a new message.
+ */
+export const ShareTreeResponseSchema: GenMessage<ShareTreeResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 30);
+
+/**
+ * อัปเดต role ของ share
+ *
+ * @generated from message tree.v1.UpdateShareRequest
+ */
+export type UpdateShareRequest = Message<"tree.v1.UpdateShareRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+
+  /**
+   * @generated from field: string user_id = 2;
+   */
+  userId: string;
+
+  /**
+   * @generated from field: tree.v1.ShareRole role = 3;
+   */
+  role: ShareRole;
+};
+
+/**
+ * Describes the message tree.v1.UpdateShareRequest.
+ * Use `create(UpdateShareRequestSchema)` to create a new message.
+ */
+export const UpdateShareRequestSchema: GenMessage<UpdateShareRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 31);
+
+/**
+ * @generated from message tree.v1.UpdateShareResponse
+ */
+export type UpdateShareResponse = Message<"tree.v1.UpdateShareResponse"> & {
+  /**
+   * @generated from field: tree.v1.TreeShare share = 1;
+   */
+  share?: TreeShare;
+};
+
+/**
+ * Describes the message tree.v1.UpdateShareResponse.
+ * Use `create(UpdateShareResponseSchema)` to create a new message.
+ */
+export const UpdateShareResponseSchema: GenMessage<UpdateShareResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 32);
+
+/**
+ * ลบ share (เอาสิทธิ์ออก)
+ *
+ * @generated from message tree.v1.RemoveShareRequest
+ */
+export type RemoveShareRequest = Message<"tree.v1.RemoveShareRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+
+  /**
+   * @generated from field: string user_id = 2;
+   */
+  userId: string;
+};
+
+/**
+ * Describes the message tree.v1.RemoveShareRequest.
+ * Use `create(RemoveShareRequestSchema)` to create a new message.
+ */
+export const RemoveShareRequestSchema: GenMessage<RemoveShareRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 33);
+
+/**
+ * @generated from message tree.v1.RemoveShareResponse
+ */
+export type RemoveShareResponse = Message<"tree.v1.RemoveShareResponse"> & {
+};
+
+/**
+ * Describes the message tree.v1.RemoveShareResponse.
+ * Use `create(RemoveShareResponseSchema)` to create a new message.
+ */
+export const RemoveShareResponseSchema: GenMessage<RemoveShareResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 34);
+
+/**
+ * ดูรายการคนที่ถูกแชร์ใน tree
+ *
+ * @generated from message tree.v1.ListTreeSharesRequest
+ */
+export type ListTreeSharesRequest = Message<"tree.v1.ListTreeSharesRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+};
+
+/**
+ * Describes the message tree.v1.ListTreeSharesRequest.
+ * Use `create(ListTreeSharesRequestSchema)` to create a new message.
+ */
+export const ListTreeSharesRequestSchema: GenMessage<ListTreeSharesRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 35);
+
+/**
+ * @generated from message tree.v1.ListTreeSharesResponse
+ */
+export type ListTreeSharesResponse = Message<"tree.v1.ListTreeSharesResponse"> & {
+  /**
+   * @generated from field: repeated tree.v1.TreeShare shares = 1;
+   */
+  shares: TreeShare[];
+};
+
+/**
+ * Describes the message tree.v1.ListTreeSharesResponse.
+ * Use `create(ListTreeSharesResponseSchema)` to create a new message.
+ */
+export const ListTreeSharesResponseSchema: GenMessage<ListTreeSharesResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 36);
+
+/**
+ * ดูคำเชิญที่ค้างของ tree (เจ้าของเท่านั้น)
+ *
+ * @generated from message tree.v1.ListTreeInvitationsRequest
+ */
+export type ListTreeInvitationsRequest = Message<"tree.v1.ListTreeInvitationsRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+};
+
+/**
+ * Describes the message tree.v1.ListTreeInvitationsRequest.
+ * Use `create(ListTreeInvitationsRequestSchema)` to create a new message.
+ */
+export const ListTreeInvitationsRequestSchema: GenMessage<ListTreeInvitationsRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 37);
+
+/**
+ * @generated from message tree.v1.ListTreeInvitationsResponse
+ */
+export type ListTreeInvitationsResponse = Message<"tree.v1.ListTreeInvitationsResponse"> & {
+  /**
+   * @generated from field: repeated tree.v1.TreeInvitation invitations = 1;
+   */
+  invitations: TreeInvitation[];
+};
+
+/**
+ * Describes the message tree.v1.ListTreeInvitationsResponse.
+ * Use `create(ListTreeInvitationsResponseSchema)` to create a new message.
+ */
+export const ListTreeInvitationsResponseSchema: GenMessage<ListTreeInvitationsResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 38);
+
+/**
+ * ส่ง email เชิญซ้ำ
+ *
+ * @generated from message tree.v1.ResendTreeInvitationRequest
+ */
+export type ResendTreeInvitationRequest = Message<"tree.v1.ResendTreeInvitationRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+
+  /**
+   * @generated from field: string invitation_id = 2;
+   */
+  invitationId: string;
+};
+
+/**
+ * Describes the message tree.v1.ResendTreeInvitationRequest.
+ * Use `create(ResendTreeInvitationRequestSchema)` to create a new message.
+ */
+export const ResendTreeInvitationRequestSchema: GenMessage<ResendTreeInvitationRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 39);
+
+/**
+ * @generated from message tree.v1.ResendTreeInvitationResponse
+ */
+export type ResendTreeInvitationResponse = Message<"tree.v1.ResendTreeInvitationResponse"> & {
+  /**
+   * @generated from field: tree.v1.TreeInvitation invitation = 1;
+   */
+  invitation?: TreeInvitation;
+};
+
+/**
+ * Describes the message tree.v1.ResendTreeInvitationResponse.
+ * Use `create(ResendTreeInvitationResponseSchema)` to create a new message.
+ */
+export const ResendTreeInvitationResponseSchema: GenMessage<ResendTreeInvitationResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 40);
+
+/**
+ * ยกเลิกคำเชิญ
+ *
+ * @generated from message tree.v1.CancelTreeInvitationRequest
+ */
+export type CancelTreeInvitationRequest = Message<"tree.v1.CancelTreeInvitationRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+
+  /**
+   * @generated from field: string invitation_id = 2;
+   */
+  invitationId: string;
+};
+
+/**
+ * Describes the message tree.v1.CancelTreeInvitationRequest.
+ * Use `create(CancelTreeInvitationRequestSchema)` to create a new message.
+ */
+export const CancelTreeInvitationRequestSchema: GenMessage<CancelTreeInvitationRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 41);
+
+/**
+ * @generated from message tree.v1.CancelTreeInvitationResponse
+ */
+export type CancelTreeInvitationResponse = Message<"tree.v1.CancelTreeInvitationResponse"> & {
+};
+
+/**
+ * Describes the message tree.v1.CancelTreeInvitationResponse.
+ * Use `create(CancelTreeInvitationResponseSchema)` to create a new message.
+ */
+export const CancelTreeInvitationResponseSchema: GenMessage<CancelTreeInvitationResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 42);
+
+/**
+ * ดูรายการ tree ที่ถูกแชร์มาให้ฉัน
+ *
+ * @generated from message tree.v1.ListSharedWithMeRequest
+ */
+export type ListSharedWithMeRequest = Message<"tree.v1.ListSharedWithMeRequest"> & {
+  /**
+   * @generated from field: tree.v1.TreeListOptions options = 1;
+   */
+  options?: TreeListOptions;
+};
+
+/**
+ * Describes the message tree.v1.ListSharedWithMeRequest.
+ * Use `create(ListSharedWithMeRequestSchema)` to create a new message.
+ */
+export const ListSharedWithMeRequestSchema: GenMessage<ListSharedWithMeRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 43);
+
+/**
+ * @generated from message tree.v1.ListSharedWithMeResponse
+ */
+export type ListSharedWithMeResponse = Message<"tree.v1.ListSharedWithMeResponse"> & {
+  /**
+   * @generated from field: repeated tree.v1.Tree trees = 1;
+   */
+  trees: Tree[];
+
+  /**
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+};
+
+/**
+ * Describes the message tree.v1.ListSharedWithMeResponse.
+ * Use `create(ListSharedWithMeResponseSchema)` to create a new message.
+ */
+export const ListSharedWithMeResponseSchema: GenMessage<ListSharedWithMeResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 44);
+
+/**
+ * tree ที่เป็นเจ้าของ + ถูกแชร์มา รวมเป็นรายการเดียว
+ *
+ * @generated from message tree.v1.ListAccessibleTreesRequest
+ */
+export type ListAccessibleTreesRequest = Message<"tree.v1.ListAccessibleTreesRequest"> & {
+  /**
+   * @generated from field: tree.v1.TreeListOptions options = 1;
+   */
+  options?: TreeListOptions;
+};
+
+/**
+ * Describes the message tree.v1.ListAccessibleTreesRequest.
+ * Use `create(ListAccessibleTreesRequestSchema)` to create a new message.
+ */
+export const ListAccessibleTreesRequestSchema: GenMessage<ListAccessibleTreesRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 45);
+
+/**
+ * @generated from message tree.v1.ListAccessibleTreesResponse
+ */
+export type ListAccessibleTreesResponse = Message<"tree.v1.ListAccessibleTreesResponse"> & {
+  /**
+   * @generated from field: repeated tree.v1.Tree trees = 1;
+   */
+  trees: Tree[];
+
+  /**
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+};
+
+/**
+ * Describes the message tree.v1.ListAccessibleTreesResponse.
+ * Use `create(ListAccessibleTreesResponseSchema)` to create a new message.
+ */
+export const ListAccessibleTreesResponseSchema: GenMessage<ListAccessibleTreesResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 46);
+
+/**
+ * ดู role ของ user ปัจจุบันกับ tree
+ *
+ * @generated from message tree.v1.GetMyRoleRequest
+ */
+export type GetMyRoleRequest = Message<"tree.v1.GetMyRoleRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+};
+
+/**
+ * Describes the message tree.v1.GetMyRoleRequest.
+ * Use `create(GetMyRoleRequestSchema)` to create a new message.
+ */
+export const GetMyRoleRequestSchema: GenMessage<GetMyRoleRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 47);
+
+/**
+ * @generated from message tree.v1.GetMyRoleResponse
+ */
+export type GetMyRoleResponse = Message<"tree.v1.GetMyRoleResponse"> & {
+  /**
+   * @generated from field: tree.v1.ShareRole role = 1;
+   */
+  role: ShareRole;
+
+  /**
+   * @generated from field: bool is_creator = 2;
+   */
+  isCreator: boolean;
+};
+
+/**
+ * Describes the message tree.v1.GetMyRoleResponse.
+ * Use `create(GetMyRoleResponseSchema)` to create a new message.
+ */
+export const GetMyRoleResponseSchema: GenMessage<GetMyRoleResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 48);
+
+/**
+ * ขอโอน tree ให้สมาชิก (เจ้าของเท่านั้น) — มีผลเมื่อผู้รับกดยอมรับ
+ *
+ * @generated from message tree.v1.TransferOwnershipRequest
+ */
+export type TransferOwnershipRequest = Message<"tree.v1.TransferOwnershipRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+
+  /**
+   * ต้องถูกแชร์ tree อยู่แล้ว
+   *
+   * @generated from field: string to_user_id = 2;
+   */
+  toUserId: string;
+
+  /**
+   * role ของเจ้าของเดิมหลังโอน
+   *
+   * @generated from field: tree.v1.ShareRole previous_owner_role = 3;
+   */
+  previousOwnerRole: ShareRole;
+};
+
+/**
+ * Describes the message tree.v1.TransferOwnershipRequest.
+ * Use `create(TransferOwnershipRequestSchema)` to create a new message.
+ */
+export const TransferOwnershipRequestSchema: GenMessage<TransferOwnershipRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 49);
+
+/**
+ * @generated from message tree.v1.TransferOwnershipResponse
+ */
+export type TransferOwnershipResponse = Message<"tree.v1.TransferOwnershipResponse"> & {
+  /**
+   * @generated from field: tree.v1.OwnershipTransfer transfer = 1;
+   */
+  transfer?: OwnershipTransfer;
+};
+
+/**
+ * Describes the message tree.v1.TransferOwnershipResponse.
+ * Use `create(TransferOwnershipResponseSchema)` to create a new message.
+ */
+export const TransferOwnershipResponseSchema: GenMessage<TransferOwnershipResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 50);
+
+/**
+ * ผู้รับตอบคำขอโอน
+ *
+ * @generated from message tree.v1.RespondOwnershipTransferRequest
+ */
+export type RespondOwnershipTransferRequest = Message<"tree.v1.RespondOwnershipTransferRequest"> & {
+  /**
+   * @generated from field: string transfer_id = 1;
+   */
+  transferId: string;
+
+  /**
+   * @generated from field: bool accept = 2;
+   */
+  accept: boolean;
+};
+
+/**
+ * Describes the message tree.v1.RespondOwnershipTransferRequest.
+ * Use `create(RespondOwnershipTransferRequestSchema)` to create a new message.
+ */
+export const RespondOwnershipTransferRequestSchema: GenMessage<RespondOwnershipTransferRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 51);
+
+/**
+ * @generated from message tree.v1.RespondOwnershipTransferResponse
+ */
+export type RespondOwnershipTransferResponse = Message<"tree.v1.RespondOwnershipTransferResponse"> & {
+  /**
+   * @generated from field: tree.v1.OwnershipTransfer transfer = 1;
+   */
+  transfer?: OwnershipTransfer;
+
+  /**
+   * tree หลังโอน (เฉพาะตอนยอมรับ)
+   *
+   * @generated from field: tree.v1.Tree tree = 2;
+   */
+  tree?: Tree;
+};
+
+/**
+ * Describes the message tree.v1.RespondOwnershipTransferResponse.
+ * Use `create(RespondOwnershipTransferResponseSchema)` to create a new message.
+ */
+export const RespondOwnershipTransferResponseSchema: GenMessage<RespondOwnershipTransferResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 52);
+
+/**
+ * เจ้าของยกเลิกคำขอที่ยังรออยู่
+ *
+ * @generated from message tree.v1.CancelOwnershipTransferRequest
+ */
+export type CancelOwnershipTransferRequest = Message<"tree.v1.CancelOwnershipTransferRequest"> & {
+  /**
+   * @generated from field: string transfer_id = 1;
+   */
+  transferId: string;
+};
+
+/**
+ * Describes the message tree.v1.CancelOwnershipTransferRequest.
+ * Use `create(CancelOwnershipTransferRequestSchema)` to create a new message.
+ */
+export const CancelOwnershipTransferRequestSchema: GenMessage<CancelOwnershipTransferRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 53);
+
+/**
+ * @generated from message tree.v1.CancelOwnershipTransferResponse
+ */
+export type CancelOwnershipTransferResponse = Message<"tree.v1.CancelOwnershipTransferResponse"> & {
+  /**
+   * @generated from field: tree.v1.OwnershipTransfer transfer = 1;
+   */
+  transfer?: OwnershipTransfer;
+};
+
+/**
+ * Describes the message tree.v1.CancelOwnershipTransferResponse.
+ * Use `create(CancelOwnershipTransferResponseSchema)` to create a new message.
+ */
+export const CancelOwnershipTransferResponseSchema: GenMessage<CancelOwnershipTransferResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 54);
+
+/**
+ * ประวัติการโอนของ tree (เจ้าของ / co-owner)
+ *
+ * @generated from message tree.v1.ListOwnershipTransfersRequest
+ */
+export type ListOwnershipTransfersRequest = Message<"tree.v1.ListOwnershipTransfersRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
+};
+
+/**
+ * Describes the message tree.v1.ListOwnershipTransfersRequest.
+ * Use `create(ListOwnershipTransfersRequestSchema)` to create a new message.
+ */
+export const ListOwnershipTransfersRequestSchema: GenMessage<ListOwnershipTransfersRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 55);
+
+/**
+ * @generated from message tree.v1.ListOwnershipTransfersResponse
+ */
+export type ListOwnershipTransfersResponse = Message<"tree.v1.ListOwnershipTransfersResponse"> & {
+  /**
+   * @generated from field: repeated tree.v1.OwnershipTransfer transfers = 1;
+   */
+  transfers: OwnershipTransfer[];
+};
+
+/**
+ * Describes the message tree.v1.ListOwnershipTransfersResponse.
+ * Use `create(ListOwnershipTransfersResponseSchema)` to create a new message.
+ */
+export const ListOwnershipTransfersResponseSchema: GenMessage<ListOwnershipTransfersResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 56);
+
+/**
+ * คำขอโอนที่รอฉันตอบ
+ *
+ * @generated from message tree.v1.ListIncomingOwnershipTransfersRequest
+ */
+export type ListIncomingOwnershipTransfersRequest = Message<"tree.v1.ListIncomingOwnershipTransfersRequest"> & {
+};
+
+/**
+ * Describes the message tree.v1.ListIncomingOwnershipTransfersRequest.
+ * Use `create(ListIncomingOwnershipTransfersRequestSchema)` to create a new message.
+ */
+export const ListIncomingOwnershipTransfersRequestSchema: GenMessage<ListIncomingOwnershipTransfersRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 57);
+
+/**
+ * @generated from message tree.v1.ListIncomingOwnershipTransfersResponse
+ */
+export type ListIncomingOwnershipTransfersResponse = Message<"tree.v1.ListIncomingOwnershipTransfersResponse"> & {
+  /**
+   * @generated from field: repeated tree.v1.OwnershipTransfer transfers = 1;
+   */
+  transfers: OwnershipTransfer[];
+};
+
+/**
+ * Describes the message tree.v1.ListIncomingOwnershipTransfersResponse.
+ * Use `create(ListIncomingOwnershipTransfersResponseSchema)` to create a new message.
+ */
+export const ListIncomingOwnershipTransfersResponseSchema: GenMessage<ListIncomingOwnershipTransfersResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 58);
+
+/**
+ * @generated from message tree.v1.GenerationCount
+ */
+export type GenerationCount = Message<"tree.v1.GenerationCount"> & {
+  /**
+   * @generated from field: int32 generation = 1;
+   */
+  generation: number;
+
+  /**
+   * @generated from field: int32 count = 2;
+   */
+  count: number;
+};
+
+/**
+ * Describes the message tree.v1.GenerationCount.
+ * Use `create(GenerationCountSchema)` to create a new message.
+ */
+export const GenerationCountSchema: GenMessage<GenerationCount> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 59);
+
+/**
+ * @generated from message tree.v1.StatusCounts
+ */
+export type StatusCounts = Message<"tree.v1.StatusCounts"> & {
+  /**
+   * @generated from field: int32 studying = 1;
+   */
+  studying: number;
+
+  /**
+   * @generated from field: int32 graduated = 2;
+   */
+  graduated: number;
+
+  /**
+   * @generated from field: int32 retired = 3;
+   */
+  retired: number;
+};
+
+/**
+ * Describes the message tree.v1.StatusCounts.
+ * Use `create(StatusCountsSchema)` to create a new message.
+ */
+export const StatusCountsSchema: GenMessage<StatusCounts> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 60);
+
+/**
+ * จำนวนคนที่ถูกเพิ่มในแต่ละเดือน (ตาม created_at ของ node, UTC)
+ *
+ * @generated from message tree.v1.TrendPoint
+ */
+export type TrendPoint = Message<"tree.v1.TrendPoint"> & {
+  /**
+   * "2026-02"
+   *
+   * @generated from field: string month = 1;
+   */
+  month: string;
+
+  /**
+   * @generated from field: int32 added = 2;
+   */
+  added: number;
+
+  /**
+   * สะสมถึงสิ้นเดือนนี้
+   *
+   * @generated from field: int32 total = 3;
+   */
+  total: number;
+};
+
+/**
+ * Describes the message tree.v1.TrendPoint.
+ * Use `create(TrendPointSchema)` to create a new message.
+ */
+export const TrendPointSchema: GenMessage<TrendPoint> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 61);
+
+/**
+ * @generated from message tree.v1.TreeStats
+ */
+export type TreeStats = Message<"tree.v1.TreeStats"> & {
+  /**
+   * @generated from field: int32 member_count = 1;
+   */
+  memberCount: number;
+
+  /**
+   * เรียงตามรุ่น
+   *
+   * @generated from field: repeated tree.v1.GenerationCount generations = 2;
+   */
+  generations: GenerationCount[];
+
+  /**
+   * @generated from field: tree.v1.StatusCounts statuses = 3;
+   */
+  statuses?: StatusCounts;
+
+  /**
+   * คนที่มีน้องรหัสอย่างน้อย 1 คน
+   *
+   * @generated from field: int32 senior_count = 4;
+   */
+  seniorCount: number;
+
+  /**
+   * @generated from field: double avg_children_per_senior = 5;
+   */
+  avgChildrenPerSenior: number;
+
+  /**
+   * @generated from field: int32 max_children = 6;
+   */
+  maxChildren: number;
+
+  /**
+   * จำนวนคนในสายที่ยาวที่สุด (root → ล่างสุด)
+   *
+   * @generated from field: int32 deepest_lineage = 7;
+   */
+  deepestLineage: number;
+
+  /**
+   * node id ของสายนั้น
+   *
+   * @generated from field: repeated string deepest_path = 8;
+   */
+  deepestPath: string[];
+
+  /**
+   * @generated from field: int32 root_count = 9;
+   */
+  rootCount: number;
+
+  /**
+   * root ที่ไม่มีน้องรหัส (ยังไม่ได้ต่อสาย)
+   *
+   * @generated from field: repeated string orphan_root_ids = 10;
+   */
+  orphanRootIds: string[];
+
+  /**
+   * คนที่มีพี่รหัสมากกว่า 1 คน
+   *
+   * @generated from field: int32 multi_parent_count = 11;
+   */
+  multiParentCount: number;
 
   /**
-   * @generated from field: string department = 4;
+   * ตั้งแต่เดือนแรกถึงเดือนล่าสุด (เดือนที่ไม่มีคนเพิ่ม = 0)
+   *
+   * @generated from field: repeated tree.v1.TrendPoint trend = 12;
    */
-  department: string;
+  trend: TrendPoint[];
 };
 
 /**
- * Describes the message tree.v1.CreateTreeRequest.
- * Use `create(CreateTreeRequestSchema)` to create a new message.
+ * Describes the message tree.v1.TreeStats.
+ * Use `create(TreeStatsSchema)` to create a new message.
  */
-export const CreateTreeRequestSchema: GenMessage<CreateTreeRequest> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 2);
+export const TreeStatsSchema: GenMessage<TreeStats> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 62);
 
 /**
- * @generated from message tree.v1.CreateTreeResponse
+ * ดู tree ได้ = ดูสถิติได้
+ *
+ * @generated from message tree.v1.GetTreeStatsRequest
  */
-export type CreateTreeResponse = Message<"tree.v1.CreateTreeResponse"> & {
+export type GetTreeStatsRequest = Message<"tree.v1.GetTreeStatsRequest"> & {
   /**
-   * @generated from field: tree.v1.Tree tree = 1;
+   * @generated from field: string tree_id = 1;
    */
-  tree?: Tree;
+  treeId: string;
 };
 
 /**
- * Describes the message tree.v1.CreateTreeResponse.
- * Use `create(CreateTreeResponseSchema)` to create a new message.
+ * Describes the message tree.v1.GetTreeStatsRequest.
+ * Use `create(GetTreeStatsRequestSchema)` to create a new message.
  */
-export const CreateTreeResponseSchema: GenMessage<CreateTreeResponse> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 3);
+export const GetTreeStatsRequestSchema: GenMessage<GetTreeStatsRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 63);
 
 /**
- * @generated from message tree.v1.GetTreeRequest
+ * @generated from message tree.v1.GetTreeStatsResponse
  */
-export type GetTreeRequest = Message<"tree.v1.GetTreeRequest"> & {
+export type GetTreeStatsResponse = Message<"tree.v1.GetTreeStatsResponse"> & {
   /**
-   * @generated from field: string id = 1;
+   * @generated from field: tree.v1.TreeStats stats = 1;
    */
-  id: string;
+  stats?: TreeStats;
 };
 
 /**
- * Describes the message tree.v1.GetTreeRequest.
- * Use `create(GetTreeRequestSchema)` to create a new message.
+ * Describes the message tree.v1.GetTreeStatsResponse.
+ * Use `create(GetTreeStatsResponseSchema)` to create a new message.
  */
-export const GetTreeRequestSchema: GenMessage<GetTreeRequest> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 4);
+export const GetTreeStatsResponseSchema: GenMessage<GetTreeStatsResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 64);
 
 /**
- * @generated from message tree.v1.GetTreeResponse
+ * @generated from message tree.v1.DepartmentStats
  */
-export type GetTreeResponse = Message<"tree.v1.GetTreeResponse"> & {
+export type DepartmentStats = Message<"tree.v1.DepartmentStats"> & {
   /**
-   * @generated from field: tree.v1.Tree tree = 1;
+   * @generated from field: string department = 1;
    */
-  tree?: Tree;
+  department: string;
+
+  /**
+   * @generated from field: int32 tree_count = 2;
+   */
+  treeCount: number;
+
+  /**
+   * @generated from field: int32 member_count = 3;
+   */
+  memberCount: number;
+
+  /**
+   * @generated from field: tree.v1.StatusCounts statuses = 4;
+   */
+  statuses?: StatusCounts;
 };
 
 /**
- * Describes the message tree.v1.GetTreeResponse.
- * Use `create(GetTreeResponseSchema)` to create a new message.
+ * Describes the message tree.v1.DepartmentStats.
+ * Use `create(DepartmentStatsSchema)` to create a new message.
  */
-export const GetTreeResponseSchema: GenMessage<GetTreeResponse> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 5);
+export const DepartmentStatsSchema: GenMessage<DepartmentStats> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 65);
 
 /**
- * @generated from message tree.v1.ListMyTreesRequest
+ * @generated from message tree.v1.FacultyStats
  */
-export type ListMyTreesRequest = Message<"tree.v1.ListMyTreesRequest"> & {
+export type FacultyStats = Message<"tree.v1.FacultyStats"> & {
+  /**
+   * @generated from field: string faculty = 1;
+   */
+  faculty: string;
+
+  /**
+   * @generated from field: int32 tree_count = 2;
+   */
+  treeCount: number;
+
+  /**
+   * @generated from field: int32 member_count = 3;
+   */
+  memberCount: number;
+
+  /**
+   * @generated from field: tree.v1.StatusCounts statuses = 4;
+   */
+  statuses?: StatusCounts;
+
+  /**
+   * @generated from field: repeated tree.v1.DepartmentStats departments = 5;
+   */
+  departments: DepartmentStats[];
 };
 
 /**
- * Describes the message tree.v1.ListMyTreesRequest.
- * Use `create(ListMyTreesRequestSchema)` to create a new message.
+ * Describes the message tree.v1.FacultyStats.
+ * Use `create(FacultyStatsSchema)` to create a new message.
  */
-export const ListMyTreesRequestSchema: GenMessage<ListMyTreesRequest> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 6);
+export const FacultyStatsSchema: GenMessage<FacultyStats> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 66);
 
 /**
- * @generated from message tree.v1.ListMyTreesResponse
+ * สรุปรวมของ public tree ต่อคณะ / ภาควิชา (ไม่ต้อง login)
+ *
+ * @generated from message tree.v1.GetFacultyStatsRequest
  */
-export type ListMyTreesResponse = Message<"tree.v1.ListMyTreesResponse"> & {
+export type GetFacultyStatsRequest = Message<"tree.v1.GetFacultyStatsRequest"> & {
   /**
-   * @generated from field: repeated tree.v1.Tree trees = 1;
+   * ไม่ส่ง = ทุกคณะ
+   *
+   * @generated from field: optional string faculty = 1;
    */
-  trees: Tree[];
+  faculty?: string;
 };
 
 /**
- * Describes the message tree.v1.ListMyTreesResponse.
- * Use `create(ListMyTreesResponseSchema)` to create a new message.
+ * Describes the message tree.v1.GetFacultyStatsRequest.
+ * Use `create(GetFacultyStatsRequestSchema)` to create a new message.
  */
-export const ListMyTreesResponseSchema: GenMessage<ListMyTreesResponse> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 7);
+export const GetFacultyStatsRequestSchema: GenMessage<GetFacultyStatsRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 67);
 
 /**
- * @generated from message tree.v1.DeleteTreeRequest
+ * @generated from message tree.v1.GetFacultyStatsResponse
  */
-export type DeleteTreeRequest = Message<"tree.v1.DeleteTreeRequest"> & {
+export type GetFacultyStatsResponse = Message<"tree.v1.GetFacultyStatsResponse"> & {
   /**
-   * @generated from field: string id = 1;
+   * @generated from field: repeated tree.v1.FacultyStats faculties = 1;
    */
-  id: string;
+  faculties: FacultyStats[];
 };
 
 /**
- * Describes the message tree.v1.DeleteTreeRequest.
- * Use `create(DeleteTreeRequestSchema)` to create a new message.
+ * Describes the message tree.v1.GetFacultyStatsResponse.
+ * Use `create(GetFacultyStatsResponseSchema)` to create a new message.
  */
-export const DeleteTreeRequestSchema: GenMessage<DeleteTreeRequest> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 8);
+export const GetFacultyStatsResponseSchema: GenMessage<GetFacultyStatsResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 68);
 
 /**
- * @generated from message tree.v1.DeleteTreeResponse
+ * จุดที่ trees.structure ไม่ตรงกับตาราง nodes พร้อมสิ่งที่ repair ทำ
+ *
+ * @generated from message tree.v1.StructureIssue
  */
-export type DeleteTreeResponse = Message<"tree.v1.DeleteTreeResponse"> & {
+export type StructureIssue = Message<"tree.v1.StructureIssue"> & {
+  /**
+   * missing_node | duplicate_root | duplicate_child | missing_edge | root_has_parent | cycle | detached_node
+   *
+   * @generated from field: string kind = 1;
+   */
+  kind: string;
+
+  /**
+   * @generated from field: string node_id = 2;
+   */
+  nodeId: string;
+
+  /**
+   * เส้น parent → node ที่เกี่ยวข้อง
+   *
+   * @generated from field: optional string parent_id = 3;
+   */
+  parentId?: string;
+
+  /**
+   * @generated from field: string description = 4;
+   */
+  description: string;
 };
 
 /**
- * Describes the message tree.v1.DeleteTreeResponse.
- * Use `create(DeleteTreeResponseSchema)` to create a new message.
+ * Describes the message tree.v1.StructureIssue.
+ * Use `create(StructureIssueSchema)` to create a new message.
  */
-export const DeleteTreeResponseSchema: GenMessage<DeleteTreeResponse> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 9);
+export const StructureIssueSchema: GenMessage<StructureIssue> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 69);
 
 /**
- * แชร์ tree ให้ user ด้วย email
+ * ตรวจ structure แล้วซ่อม (เจ้าของ / co-owner เท่านั้น) dry_run = ตรวจอย่างเดียว ไม่เขียน
  *
- * @generated from message tree.v1.ShareTreeRequest
+ * @generated from message tree.v1.RepairTreeStructureRequest
  */
-export type ShareTreeRequest = Message<"tree.v1.ShareTreeRequest"> & {
+export type RepairTreeStructureRequest = Message<"tree.v1.RepairTreeStructureRequest"> & {
   /**
    * @generated from field: string tree_id = 1;
    */
   treeId: string;
 
   /**
-   * @generated from field: string email = 2;
-   */
-  email: string;
-
-  /**
-   * @generated from field: tree.v1.ShareRole role = 3;
+   * @generated from field: bool dry_run = 2;
    */
-  role: ShareRole;
+  dryRun: boolean;
 };
 
 /**
- * Describes the message tree.v1.ShareTreeRequest.
- * Use `create(ShareTreeRequestSchema)` to create a new message.
+ * Describes the message tree.v1.RepairTreeStructureRequest.
+ * Use `create(RepairTreeStructureRequestSchema)` to create a new message.
  */
-export const ShareTreeRequestSchema: GenMessage<ShareTreeRequest> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 10);
+export const RepairTreeStructureRequestSchema: GenMessage<RepairTreeStructureRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 70);
 
 /**
- * @generated from message tree.v1.ShareTreeResponse
+ * @generated from message tree.v1.RepairTreeStructureResponse
  */
-export type ShareTreeResponse = Message<"tree.v1.ShareTreeResponse"> & {
+export type RepairTreeStructureResponse = Message<"tree.v1.RepairTreeStructureResponse"> & {
   /**
-   * @generated from field: tree.v1.TreeShare share = 1;
+   * ว่าง = structure ปกติ
+   *
+   * @generated from field: repeated tree.v1.StructureIssue issues = 1;
    */
-  share?: TreeShare;
+  issues: StructureIssue[];
+
+  /**
+   * เขียน structure ที่แก้แล้วลง DB แล้ว
+   *
+   * @generated from field: bool repaired = 2;
+   */
+  repaired: boolean;
+
+  /**
+   * @generated from field: int64 structure_revision = 3;
+   */
+  structureRevision: bigint;
 };
 
 /**
- * Describes the message tree.v1.ShareTreeResponse.
- * Use `create(ShareTreeResponseSchema)` to create a new message.
+ * Describes the message tree.v1.RepairTreeStructureResponse.
+ * Use `create(RepairTreeStructureResponseSchema)` to create a new message.
  */
-export const ShareTreeResponseSchema: GenMessage<ShareTreeResponse> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 11);
+export const RepairTreeStructureResponseSchema: GenMessage<RepairTreeStructureResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 71);
 
 /**
- * อัปเดต role ของ share
+ * ดูประวัติการแก้ของ tree (เจ้าของ / co-owner) ใหม่สุดก่อน
  *
- * @generated from message tree.v1.UpdateShareRequest
+ * @generated from message tree.v1.ListAuditEventsRequest
  */
-export type UpdateShareRequest = Message<"tree.v1.UpdateShareRequest"> & {
+export type ListAuditEventsRequest = Message<"tree.v1.ListAuditEventsRequest"> & {
   /**
    * @generated from field: string tree_id = 1;
    */
   treeId: string;
 
   /**
-   * @generated from field: string user_id = 2;
+   * รวม event ที่ node นี้เป็น target (เช่นเป็น parent)
+   *
+   * @generated from field: optional string node_id = 2;
    */
-  userId: string;
+  nodeId?: string;
 
   /**
-   * @generated from field: tree.v1.ShareRole role = 3;
+   * @generated from field: optional string actor_id = 3;
    */
-  role: ShareRole;
+  actorId?: string;
+
+  /**
+   * RFC3339 (รวม)
+   *
+   * @generated from field: optional string since = 4;
+   */
+  since?: string;
+
+  /**
+   * RFC3339 (ไม่รวม)
+   *
+   * @generated from field: optional string until = 5;
+   */
+  until?: string;
+
+  /**
+   * 0 = 50, มากสุด 200
+   *
+   * @generated from field: int32 page_size = 6;
+   */
+  pageSize: number;
+
+  /**
+   * next_page_token จากหน้าก่อน
+   *
+   * @generated from field: string page_token = 7;
+   */
+  pageToken: string;
 };
 
 /**
- * Describes the message tree.v1.UpdateShareRequest.
- * Use `create(UpdateShareRequestSchema)` to create a new message.
+ * Describes the message tree.v1.ListAuditEventsRequest.
+ * Use `create(ListAuditEventsRequestSchema)` to create a new message.
  */
-export const UpdateShareRequestSchema: GenMessage<UpdateShareRequest> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 12);
+export const ListAuditEventsRequestSchema: GenMessage<ListAuditEventsRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 72);
 
 /**
- * @generated from message tree.v1.UpdateShareResponse
+ * @generated from message tree.v1.ListAuditEventsResponse
  */
-export type UpdateShareResponse = Message<"tree.v1.UpdateShareResponse"> & {
+export type ListAuditEventsResponse = Message<"tree.v1.ListAuditEventsResponse"> & {
   /**
-   * @generated from field: tree.v1.TreeShare share = 1;
+   * @generated from field: repeated tree.v1.AuditEvent events = 1;
    */
-  share?: TreeShare;
+  events: AuditEvent[];
+
+  /**
+   * "" = หมดแล้ว
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
 };
 
 /**
- * Describes the message tree.v1.UpdateShareResponse.
- * Use `create(UpdateShareResponseSchema)` to create a new message.
+ * Describes the message tree.v1.ListAuditEventsResponse.
+ * Use `create(ListAuditEventsResponseSchema)` to create a new message.
  */
-export const UpdateShareResponseSchema: GenMessage<UpdateShareResponse> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 13);
+export const ListAuditEventsResponseSchema: GenMessage<ListAuditEventsResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 73);
 
 /**
- * ลบ share (เอาสิทธิ์ออก)
+ * สร้างลิงก์แชร์ (ต้อง login, เจ้าของเท่านั้น)
+ * ไม่ได้ตั้งอะไรเลย = ใช้ลิงก์ดูอย่างเดียวแบบถาวรตัวเดิมถ้ามี
  *
- * @generated from message tree.v1.RemoveShareRequest
+ * @generated from message tree.v1.GenerateShareLinkRequest
  */
-export type RemoveShareRequest = Message<"tree.v1.RemoveShareRequest"> & {
+export type GenerateShareLinkRequest = Message<"tree.v1.GenerateShareLinkRequest"> & {
   /**
    * @generated from field: string tree_id = 1;
    */
   treeId: string;
 
   /**
-   * @generated from field: string user_id = 2;
+   * UNSPECIFIED = VIEW
+   *
+   * @generated from field: tree.v1.ShareLinkRole role = 2;
    */
-  userId: string;
+  role: ShareLinkRole;
+
+  /**
+   * RFC3339 ต้องเป็นเวลาในอนาคต
+   *
+   * @generated from field: optional string expires_at = 3;
+   */
+  expiresAt?: string;
+
+  /**
+   * ลิงก์ join เท่านั้น
+   *
+   * @generated from field: optional int32 max_uses = 4;
+   */
+  maxUses?: number;
 };
 
 /**
- * Describes the message tree.v1.RemoveShareRequest.
- * Use `create(RemoveShareRequestSchema)` to create a new message.
+ * Describes the message tree.v1.GenerateShareLinkRequest.
+ * Use `create(GenerateShareLinkRequestSchema)` to create a new message.
  */
-export const RemoveShareRequestSchema: GenMessage<RemoveShareRequest> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 14);
+export const GenerateShareLinkRequestSchema: GenMessage<GenerateShareLinkRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 74);
 
 /**
- * @generated from message tree.v1.RemoveShareResponse
+ * @generated from message tree.v1.GenerateShareLinkResponse
  */
-export type RemoveShareResponse = Message<"tree.v1.RemoveShareResponse"> & {
+export type GenerateShareLinkResponse = Message<"tree.v1.GenerateShareLinkResponse"> & {
+  /**
+   * @generated from field: string share_token = 1;
+   */
+  shareToken: string;
+
+  /**
+   * @generated from field: string share_url = 2;
+   */
+  shareUrl: string;
+
+  /**
+   * @generated from field: tree.v1.ShareLink link = 3;
+   */
+  link?: ShareLink;
 };
 
 /**
- * Describes the message tree.v1.RemoveShareResponse.
- * Use `create(RemoveShareResponseSchema)` to create a new message.
+ * Describes the message tree.v1.GenerateShareLinkResponse.
+ * Use `create(GenerateShareLinkResponseSchema)` to create a new message.
  */
-export const RemoveShareResponseSchema: GenMessage<RemoveShareResponse> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 15);
+export const GenerateShareLinkResponseSchema: GenMessage<GenerateShareLinkResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 75);
 
 /**
- * ดูรายการคนที่ถูกแชร์ใน tree
+ * ดูลิงก์ทั้งหมดของ tree (เจ้าของเท่านั้น)
  *
- * @generated from message tree.v1.ListTreeSharesRequest
+ * @generated from message tree.v1.ListShareLinksRequest
  */
-export type ListTreeSharesRequest = Message<"tree.v1.ListTreeSharesRequest"> & {
+export type ListShareLinksRequest = Message<"tree.v1.ListShareLinksRequest"> & {
   /**
    * @generated from field: string tree_id = 1;
    */
@@ -413,142 +2242,146 @@ export type ListTreeSharesRequest = Message<"tree.v1.ListTreeSharesRequest"> & {
 };
 
 /**
- * Describes the message tree.v1.ListTreeSharesRequest.
- * Use `create(ListTreeSharesRequestSchema)` to create a new message.
+ * Describes the message tree.v1.ListShareLinksRequest.
+ * Use `create(ListShareLinksRequestSchema)` to create a new message.
  */
-export const ListTreeSharesRequestSchema: GenMessage<ListTreeSharesRequest> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 16);
+export const ListShareLinksRequestSchema: GenMessage<ListShareLinksRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 76);
 
 /**
- * @generated from message tree.v1.ListTreeSharesResponse
+ * @generated from message tree.v1.ListShareLinksResponse
  */
-export type ListTreeSharesResponse = Message<"tree.v1.ListTreeSharesResponse"> & {
+export type ListShareLinksResponse = Message<"tree.v1.ListShareLinksResponse"> & {
   /**
-   * @generated from field: repeated tree.v1.TreeShare shares = 1;
+   * @generated from field: repeated tree.v1.ShareLink links = 1;
    */
-  shares: TreeShare[];
+  links: ShareLink[];
 };
 
 /**
- * Describes the message tree.v1.ListTreeSharesResponse.
- * Use `create(ListTreeSharesResponseSchema)` to create a new message.
+ * Describes the message tree.v1.ListShareLinksResponse.
+ * Use `create(ListShareLinksResponseSchema)` to create a new message.
  */
-export const ListTreeSharesResponseSchema: GenMessage<ListTreeSharesResponse> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 17);
+export const ListShareLinksResponseSchema: GenMessage<ListShareLinksResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 77);
 
 /**
- * ดูรายการ tree ที่ถูกแชร์มาให้ฉัน
+ * ยกเลิกลิงก์ (คนที่เปิดลิงก์นี้จะได้ error, คนที่เข้าร่วมไปแล้วยังอยู่)
  *
- * @generated from message tree.v1.ListSharedWithMeRequest
- */
-export type ListSharedWithMeRequest = Message<"tree.v1.ListSharedWithMeRequest"> & {
-};
-
-/**
- * Describes the message tree.v1.ListSharedWithMeRequest.
- * Use `create(ListSharedWithMeRequestSchema)` to create a new message.
+ * @generated from message tree.v1.RevokeShareLinkRequest
  */
-export const ListSharedWithMeRequestSchema: GenMessage<ListSharedWithMeRequest> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 18);
+export type RevokeShareLinkRequest = Message<"tree.v1.RevokeShareLinkRequest"> & {
+  /**
+   * @generated from field: string tree_id = 1;
+   */
+  treeId: string;
 
-/**
- * @generated from message tree.v1.ListSharedWithMeResponse
- */
-export type ListSharedWithMeResponse = Message<"tree.v1.ListSharedWithMeResponse"> & {
   /**
-   * @generated from field: repeated tree.v1.Tree trees = 1;
+   * @generated from field: string link_id = 2;
    */
-  trees: Tree[];
+  linkId: string;
 };
 
 /**
- * Describes the message tree.v1.ListSharedWithMeResponse.
- * Use `create(ListSharedWithMeResponseSchema)` to create a new message.
+ * Describes the message tree.v1.RevokeShareLinkRequest.
+ * Use `create(RevokeShareLinkRequestSchema)` to create a new message.
  */
-export const ListSharedWithMeResponseSchema: GenMessage<ListSharedWithMeResponse> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 19);
+export const RevokeShareLinkRequestSchema: GenMessage<RevokeShareLinkRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 78);
 
 /**
- * ดู role ของ user ปัจจุบันกับ tree
- *
- * @generated from message tree.v1.GetMyRoleRequest
+ * @generated from message tree.v1.RevokeShareLinkResponse
  */
-export type GetMyRoleRequest = Message<"tree.v1.GetMyRoleRequest"> & {
+export type RevokeShareLinkResponse = Message<"tree.v1.RevokeShareLinkResponse"> & {
   /**
-   * @generated from field: string tree_id = 1;
+   * @generated from field: tree.v1.ShareLink link = 1;
    */
-  treeId: string;
+  link?: ShareLink;
 };
 
 /**
- * Describes the message tree.v1.GetMyRoleRequest.
- * Use `create(GetMyRoleRequestSchema)` to create a new message.
+ * Describes the message tree.v1.RevokeShareLinkResponse.
+ * Use `create(RevokeShareLinkResponseSchema)` to create a new message.
  */
-export const GetMyRoleRequestSchema: GenMessage<GetMyRoleRequest> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 20);
+export const RevokeShareLinkResponseSchema: GenMessage<RevokeShareLinkResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 79);
 
 /**
- * @generated from message tree.v1.GetMyRoleResponse
+ * เปลี่ยน token: ยกเลิกลิงก์เดิมแล้วสร้างลิงก์ใหม่ที่ตั้งค่าเหมือนเดิม (use_count เริ่มใหม่)
+ *
+ * @generated from message tree.v1.RotateShareLinkRequest
  */
-export type GetMyRoleResponse = Message<"tree.v1.GetMyRoleResponse"> & {
+export type RotateShareLinkRequest = Message<"tree.v1.RotateShareLinkRequest"> & {
   /**
-   * @generated from field: tree.v1.ShareRole role = 1;
+   * @generated from field: string tree_id = 1;
    */
-  role: ShareRole;
+  treeId: string;
 
   /**
-   * @generated from field: bool is_creator = 2;
+   * @generated from field: string link_id = 2;
    */
-  isCreator: boolean;
+  linkId: string;
 };
 
 /**
- * Describes the message tree.v1.GetMyRoleResponse.
- * Use `create(GetMyRoleResponseSchema)` to create a new message.
+ * Describes the message tree.v1.RotateShareLinkRequest.
+ * Use `create(RotateShareLinkRequestSchema)` to create a new message.
  */
-export const GetMyRoleResponseSchema: GenMessage<GetMyRoleResponse> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 21);
+export const RotateShareLinkRequestSchema: GenMessage<RotateShareLinkRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 80);
 
 /**
- * สร้างลิงก์แชร์ (ต้อง login, เจ้าของเท่านั้น)
- *
- * @generated from message tree.v1.GenerateShareLinkRequest
+ * @generated from message tree.v1.RotateShareLinkResponse
  */
-export type GenerateShareLinkRequest = Message<"tree.v1.GenerateShareLinkRequest"> & {
+export type RotateShareLinkResponse = Message<"tree.v1.RotateShareLinkResponse"> & {
   /**
-   * @generated from field: string tree_id = 1;
+   * @generated from field: tree.v1.ShareLink link = 1;
    */
-  treeId: string;
+  link?: ShareLink;
 };
 
 /**
- * Describes the message tree.v1.GenerateShareLinkRequest.
- * Use `create(GenerateShareLinkRequestSchema)` to create a new message.
+ * Describes the message tree.v1.RotateShareLinkResponse.
+ * Use `create(RotateShareLinkResponseSchema)` to create a new message.
  */
-export const GenerateShareLinkRequestSchema: GenMessage<GenerateShareLinkRequest> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 22);
+export const RotateShareLinkResponseSchema: GenMessage<RotateShareLinkResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 81);
 
 /**
- * @generated from message tree.v1.GenerateShareLinkResponse
+ * เข้าร่วม tree ผ่านลิงก์ join (ต้อง login)
+ *
+ * @generated from message tree.v1.JoinShareLinkRequest
  */
-export type GenerateShareLinkResponse = Message<"tree.v1.GenerateShareLinkResponse"> & {
+export type JoinShareLinkRequest = Message<"tree.v1.JoinShareLinkRequest"> & {
   /**
    * @generated from field: string share_token = 1;
    */
   shareToken: string;
+};
+
+/**
+ * Describes the message tree.v1.JoinShareLinkRequest.
+ * Use `create(JoinShareLinkRequestSchema)` to create a new message.
+ */
+export const JoinShareLinkRequestSchema: GenMessage<JoinShareLinkRequest> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 82);
 
+/**
+ * @generated from message tree.v1.JoinShareLinkResponse
+ */
+export type JoinShareLinkResponse = Message<"tree.v1.JoinShareLinkResponse"> & {
   /**
-   * @generated from field: string share_url = 2;
+   * @generated from field: tree.v1.Tree tree = 1;
    */
-  shareUrl: string;
+  tree?: Tree;
 };
 
 /**
- * Describes the message tree.v1.GenerateShareLinkResponse.
- * Use `create(GenerateShareLinkResponseSchema)` to create a new message.
+ * Describes the message tree.v1.JoinShareLinkResponse.
+ * Use `create(JoinShareLinkResponseSchema)` to create a new message.
  */
-export const GenerateShareLinkResponseSchema: GenMessage<GenerateShareLinkResponse> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 23);
+export const JoinShareLinkResponseSchema: GenMessage<JoinShareLinkResponse> = /*@__PURE__*/
+  messageDesc(file_tree_v1_tree, 83);
 
 /**
  * ดู tree ผ่าน share token (ไม่ต้อง login)
@@ -567,7 +2400,7 @@ export type GetTreeByShareTokenRequest = Message<"tree.v1.GetTreeByShareTokenReq
  * Use `create(GetTreeByShareTokenRequestSchema)` to create a new message.
  */
 export const GetTreeByShareTokenRequestSchema: GenMessage<GetTreeByShareTokenRequest> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 24);
+  messageDesc(file_tree_v1_tree, 84);
 
 /**
  * @generated from message tree.v1.GetTreeByShareTokenResponse
@@ -577,6 +2410,18 @@ export type GetTreeByShareTokenResponse = Message<"tree.v1.GetTreeByShareTokenRe
    * @generated from field: tree.v1.Tree tree = 1;
    */
   tree?: Tree;
+
+  /**
+   * ลิงก์ join → client แสดงปุ่มเข้าร่วม
+   *
+   * @generated from field: tree.v1.ShareLinkRole link_role = 2;
+   */
+  linkRole: ShareLinkRole;
+
+  /**
+   * @generated from field: optional string link_expires_at = 3;
+   */
+  linkExpiresAt?: string;
 };
 
 /**
@@ -584,7 +2429,7 @@ export type GetTreeByShareTokenResponse = Message<"tree.v1.GetTreeByShareTokenRe
  * Use `create(GetTreeByShareTokenResponseSchema)` to create a new message.
  */
 export const GetTreeByShareTokenResponseSchema: GenMessage<GetTreeByShareTokenResponse> = /*@__PURE__*/
-  messageDesc(file_tree_v1_tree, 25);
+  messageDesc(file_tree_v1_tree, 85);
 
 /**
  * @generated from enum tree.v1.ShareRole
@@ -617,6 +2462,174 @@ export enum ShareRole {
 export const ShareRoleSchema: GenEnum<ShareRole> = /*@__PURE__*/
   enumDesc(file_tree_v1_tree, 0);
 
+/**
+ * สิ่งที่ลิงก์แชร์ให้สิทธิ์
+ *
+ * @generated from enum tree.v1.ShareLinkRole
+ */
+export enum ShareLinkRole {
+  /**
+   * @generated from enum value: SHARE_LINK_ROLE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * ดูอย่างเดียว ไม่ต้อง login
+   *
+   * @generated from enum value: SHARE_LINK_ROLE_VIEW = 1;
+   */
+  VIEW = 1,
+
+  /**
+   * login แล้วเข้าร่วมเป็น viewer
+   *
+   * @generated from enum value: SHARE_LINK_ROLE_JOIN_VIEWER = 2;
+   */
+  JOIN_VIEWER = 2,
+
+  /**
+   * login แล้วเข้าร่วมเป็น editor
+   *
+   * @generated from enum value: SHARE_LINK_ROLE_JOIN_EDITOR = 3;
+   */
+  JOIN_EDITOR = 3,
+}
+
+/**
+ * Describes the enum tree.v1.ShareLinkRole.
+ */
+export const ShareLinkRoleSchema: GenEnum<ShareLinkRole> = /*@__PURE__*/
+  enumDesc(file_tree_v1_tree, 1);
+
+/**
+ * ใครเห็นช่องทางติดต่อ (phone, email, ...) ของ node ได้
+ *
+ * @generated from enum tree.v1.ContactVisibility
+ */
+export enum ContactVisibility {
+  /**
+   * ไม่ได้ตั้ง = ใช้ค่าชั้นถัดไป (node → tree → members)
+   *
+   * @generated from enum value: CONTACT_VISIBILITY_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * ทุกคนที่ดู tree ได้ (รวม share link / tree public)
+   *
+   * @generated from enum value: CONTACT_VISIBILITY_PUBLIC = 1;
+   */
+  PUBLIC = 1,
+
+  /**
+   * เฉพาะคนที่ถูกแชร์ tree
+   *
+   * @generated from enum value: CONTACT_VISIBILITY_MEMBERS = 2;
+   */
+  MEMBERS = 2,
+
+  /**
+   * เฉพาะ editor / owner
+   *
+   * @generated from enum value: CONTACT_VISIBILITY_EDITORS = 3;
+   */
+  EDITORS = 3,
+}
+
+/**
+ * Describes the enum tree.v1.ContactVisibility.
+ */
+export const ContactVisibilitySchema: GenEnum<ContactVisibility> = /*@__PURE__*/
+  enumDesc(file_tree_v1_tree, 2);
+
+/**
+ * สถานะคำขอโอนความเป็นเจ้าของ
+ *
+ * @generated from enum tree.v1.OwnershipTransferStatus
+ */
+export enum OwnershipTransferStatus {
+  /**
+   * @generated from enum value: OWNERSHIP_TRANSFER_STATUS_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * รอผู้รับตอบ
+   *
+   * @generated from enum value: OWNERSHIP_TRANSFER_STATUS_PENDING = 1;
+   */
+  PENDING = 1,
+
+  /**
+   * @generated from enum value: OWNERSHIP_TRANSFER_STATUS_ACCEPTED = 2;
+   */
+  ACCEPTED = 2,
+
+  /**
+   * @generated from enum value: OWNERSHIP_TRANSFER_STATUS_DECLINED = 3;
+   */
+  DECLINED = 3,
+
+  /**
+   * เจ้าของยกเลิกเอง
+   *
+   * @generated from enum value: OWNERSHIP_TRANSFER_STATUS_CANCELLED = 4;
+   */
+  CANCELLED = 4,
+}
+
+/**
+ * Describes the enum tree.v1.OwnershipTransferStatus.
+ */
+export const OwnershipTransferStatusSchema: GenEnum<OwnershipTransferStatus> = /*@__PURE__*/
+  enumDesc(file_tree_v1_tree, 3);
+
+/**
+ * ลำดับของรายการ tree (UNSPECIFIED = UPDATED_AT)
+ *
+ * @generated from enum tree.v1.TreeSortField
+ */
+export enum TreeSortField {
+  /**
+   * @generated from enum value: TREE_SORT_FIELD_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * แก้ล่าสุดก่อน
+   *
+   * @generated from enum value: TREE_SORT_FIELD_UPDATED_AT = 1;
+   */
+  UPDATED_AT = 1,
+
+  /**
+   * สร้างล่าสุดก่อน
+   *
+   * @generated from enum value: TREE_SORT_FIELD_CREATED_AT = 2;
+   */
+  CREATED_AT = 2,
+
+  /**
+   * ก → ฮ / A → Z
+   *
+   * @generated from enum value: TREE_SORT_FIELD_NAME = 3;
+   */
+  NAME = 3,
+
+  /**
+   * คนเยอะสุดก่อน
+   *
+   * @generated from enum value: TREE_SORT_FIELD_NODE_COUNT = 4;
+   */
+  NODE_COUNT = 4,
+}
+
+/**
+ * Describes the enum tree.v1.TreeSortField.
+ */
+export const TreeSortFieldSchema: GenEnum<TreeSortField> = /*@__PURE__*/
+  enumDesc(file_tree_v1_tree, 4);
+
 /**
  * @generated from service tree.v1.TreeService
  */
@@ -653,6 +2666,48 @@ export const TreeService: GenService<{
     input: typeof DeleteTreeRequestSchema;
     output: typeof DeleteTreeResponseSchema;
   },
+  /**
+   * @generated from rpc tree.v1.TreeService.UpdateContactPrivacy
+   */
+  updateContactPrivacy: {
+    methodKind: "unary";
+    input: typeof UpdateContactPrivacyRequestSchema;
+    output: typeof UpdateContactPrivacyResponseSchema;
+  },
+  /**
+   * ★ Clone / templates
+   *
+   * @generated from rpc tree.v1.TreeService.CloneTree
+   */
+  cloneTree: {
+    methodKind: "unary";
+    input: typeof CloneTreeRequestSchema;
+    output: typeof CloneTreeResponseSchema;
+  },
+  /**
+   * @generated from rpc tree.v1.TreeService.SaveTreeAsTemplate
+   */
+  saveTreeAsTemplate: {
+    methodKind: "unary";
+    input: typeof SaveTreeAsTemplateRequestSchema;
+    output: typeof SaveTreeAsTemplateResponseSchema;
+  },
+  /**
+   * @generated from rpc tree.v1.TreeService.ListTreeTemplates
+   */
+  listTreeTemplates: {
+    methodKind: "unary";
+    input: typeof ListTreeTemplatesRequestSchema;
+    output: typeof ListTreeTemplatesResponseSchema;
+  },
+  /**
+   * @generated from rpc tree.v1.TreeService.DeleteTreeTemplate
+   */
+  deleteTreeTemplate: {
+    methodKind: "unary";
+    input: typeof DeleteTreeTemplateRequestSchema;
+    output: typeof DeleteTreeTemplateResponseSchema;
+  },
   /**
    * ★ Sharing (ต้อง login)
    *
@@ -695,6 +2750,14 @@ export const TreeService: GenService<{
     input: typeof ListSharedWithMeRequestSchema;
     output: typeof ListSharedWithMeResponseSchema;
   },
+  /**
+   * @generated from rpc tree.v1.TreeService.ListAccessibleTrees
+   */
+  listAccessibleTrees: {
+    methodKind: "unary";
+    input: typeof ListAccessibleTreesRequestSchema;
+    output: typeof ListAccessibleTreesResponseSchema;
+  },
   /**
    * @generated from rpc tree.v1.TreeService.GetMyRole
    */
@@ -703,6 +2766,110 @@ export const TreeService: GenService<{
     input: typeof GetMyRoleRequestSchema;
     output: typeof GetMyRoleResponseSchema;
   },
+  /**
+   * @generated from rpc tree.v1.TreeService.ListTreeInvitations
+   */
+  listTreeInvitations: {
+    methodKind: "unary";
+    input: typeof ListTreeInvitationsRequestSchema;
+    output: typeof ListTreeInvitationsResponseSchema;
+  },
+  /**
+   * @generated from rpc tree.v1.TreeService.ResendTreeInvitation
+   */
+  resendTreeInvitation: {
+    methodKind: "unary";
+    input: typeof ResendTreeInvitationRequestSchema;
+    output: typeof ResendTreeInvitationResponseSchema;
+  },
+  /**
+   * @generated from rpc tree.v1.TreeService.CancelTreeInvitation
+   */
+  cancelTreeInvitation: {
+    methodKind: "unary";
+    input: typeof CancelTreeInvitationRequestSchema;
+    output: typeof CancelTreeInvitationResponseSchema;
+  },
+  /**
+   * ★ Ownership transfer
+   *
+   * @generated from rpc tree.v1.TreeService.TransferOwnership
+   */
+  transferOwnership: {
+    methodKind: "unary";
+    input: typeof TransferOwnershipRequestSchema;
+    output: typeof TransferOwnershipResponseSchema;
+  },
+  /**
+   * @generated from rpc tree.v1.TreeService.RespondOwnershipTransfer
+   */
+  respondOwnershipTransfer: {
+    methodKind: "unary";
+    input: typeof RespondOwnershipTransferRequestSchema;
+    output: typeof RespondOwnershipTransferResponseSchema;
+  },
+  /**
+   * @generated from rpc tree.v1.TreeService.CancelOwnershipTransfer
+   */
+  cancelOwnershipTransfer: {
+    methodKind: "unary";
+    input: typeof CancelOwnershipTransferRequestSchema;
+    output: typeof CancelOwnershipTransferResponseSchema;
+  },
+  /**
+   * @generated from rpc tree.v1.TreeService.ListOwnershipTransfers
+   */
+  listOwnershipTransfers: {
+    methodKind: "unary";
+    input: typeof ListOwnershipTransfersRequestSchema;
+    output: typeof ListOwnershipTransfersResponseSchema;
+  },
+  /**
+   * @generated from rpc tree.v1.TreeService.ListIncomingOwnershipTransfers
+   */
+  listIncomingOwnershipTransfers: {
+    methodKind: "unary";
+    input: typeof ListIncomingOwnershipTransfersRequestSchema;
+    output: typeof ListIncomingOwnershipTransfersResponseSchema;
+  },
+  /**
+   * ★ Stats
+   *
+   * @generated from rpc tree.v1.TreeService.GetTreeStats
+   */
+  getTreeStats: {
+    methodKind: "unary";
+    input: typeof GetTreeStatsRequestSchema;
+    output: typeof GetTreeStatsResponseSchema;
+  },
+  /**
+   * @generated from rpc tree.v1.TreeService.GetFacultyStats
+   */
+  getFacultyStats: {
+    methodKind: "unary";
+    input: typeof GetFacultyStatsRequestSchema;
+    output: typeof GetFacultyStatsResponseSchema;
+  },
+  /**
+   * ★ Integrity
+   *
+   * @generated from rpc tree.v1.TreeService.RepairTreeStructure
+   */
+  repairTreeStructure: {
+    methodKind: "unary";
+    input: typeof RepairTreeStructureRequestSchema;
+    output: typeof RepairTreeStructureResponseSchema;
+  },
+  /**
+   * ★ Audit
+   *
+   * @generated from rpc tree.v1.TreeService.ListAuditEvents
+   */
+  listAuditEvents: {
+    methodKind: "unary";
+    input: typeof ListAuditEventsRequestSchema;
+    output: typeof ListAuditEventsResponseSchema;
+  },
   /**
    * ★ Public share link
    *
@@ -721,6 +2888,38 @@ export const TreeService: GenService<{
     input: typeof GetTreeByShareTokenRequestSchema;
     output: typeof GetTreeByShareTokenResponseSchema;
   },
+  /**
+   * @generated from rpc tree.v1.TreeService.ListShareLinks
+   */
+  listShareLinks: {
+    methodKind: "unary";
+    input: typeof ListShareLinksRequestSchema;
+    output: typeof ListShareLinksResponseSchema;
+  },
+  /**
+   * @generated from rpc tree.v1.TreeService.RevokeShareLink
+   */
+  revokeShareLink: {
+    methodKind: "unary";
+    input: typeof RevokeShareLinkRequestSchema;
+    output: typeof RevokeShareLinkResponseSchema;
+  },
+  /**
+   * @generated from rpc tree.v1.TreeService.RotateShareLink
+   */
+  rotateShareLink: {
+    methodKind: "unary";
+    input: typeof RotateShareLinkRequestSchema;
+    output: typeof RotateShareLinkResponseSchema;
+  },
+  /**
+   * @generated from rpc tree.v1.TreeService.JoinShareLink
+   */
+  joinShareLink: {
+    methodKind: "unary";
+    input: typeof JoinShareLinkRequestSchema;
+    output: typeof JoinShareLinkResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_tree_v1_tree, 0);
 
@@ -0,0 +1,9 @@
+-- =============================================
+-- Add structure_revision to trees
+-- เลข revision ของ trees.structure เพิ่มขึ้นทุกครั้งที่แก้ structure
+-- ใช้ทำ optimistic concurrency: client ส่ง revision ที่เห็นล่าสุดมา
+-- ถ้าไม่ตรงกับใน DB แปลว่ามีคนแก้ไปก่อนแล้ว → ให้ refetch แล้วลองใหม่
+-- =============================================
+
+ALTER TABLE public.trees
+    ADD COLUMN structure_revision BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,147 @@
+-- =============================================
+-- Tree Events Table
+-- log การเปลี่ยนแปลงของ nodes / trees.structure สำหรับ WatchTree (realtime)
+-- ทุก event จะ NOTIFY ช่อง 'tree_events' ตอน commit
+-- client ที่หลุดไปสามารถ resume จาก event id ล่าสุดที่เห็นได้
+-- =============================================
+
+CREATE TABLE public.tree_events (
+    id           BIGSERIAL PRIMARY KEY,
+    -- ไม่ใส่ FK ไป trees เพราะตอนลบ tree, trigger ของ nodes (cascade) ยังต้อง insert event ได้
+    tree_id      UUID NOT NULL,
+    event_type   TEXT NOT NULL,
+    node_id      UUID,
+    payload      JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indexes
+CREATE INDEX idx_tree_events_tree_id ON public.tree_events(tree_id, id);
+CREATE INDEX idx_tree_events_created_at ON public.tree_events(created_at);
+
+-- Enable RLS (backend อ่านผ่าน service connection เท่านั้น)
+ALTER TABLE public.tree_events ENABLE ROW LEVEL SECURITY;
+
+-- =============================================
+-- Helper: บันทึก event + NOTIFY
+-- =============================================
+
+CREATE OR REPLACE FUNCTION public.emit_tree_event(
+    p_tree_id UUID,
+    p_event_type TEXT,
+    p_node_id UUID,
+    p_payload JSONB DEFAULT '{}'::jsonb
+)
+RETURNS BIGINT AS $$
+DECLARE
+    v_id BIGINT;
+BEGIN
+    INSERT INTO public.tree_events (tree_id, event_type, node_id, payload)
+    VALUES (p_tree_id, p_event_type, p_node_id, COALESCE(p_payload, '{}'::jsonb))
+    RETURNING id INTO v_id;
+
+    -- ส่งแค่ id ไป (payload ของ NOTIFY จำกัด 8000 bytes) ให้ backend ไปอ่านจาก table เอง
+    PERFORM pg_notify(
+        'tree_events',
+        json_build_object('id', v_id, 'tree_id', p_tree_id)::text
+    );
+
+    RETURN v_id;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER;
+
+-- =============================================
+-- Trigger: nodes → node_created / node_updated / node_deleted
+-- =============================================
+
+CREATE OR REPLACE FUNCTION public.nodes_emit_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM public.emit_tree_event(NEW.tree_id, 'node_created', NEW.id);
+        RETURN NEW;
+    ELSIF TG_OP = 'UPDATE' THEN
+        PERFORM public.emit_tree_event(NEW.tree_id, 'node_updated', NEW.id);
+        RETURN NEW;
+    END IF;
+
+    PERFORM public.emit_tree_event(OLD.tree_id, 'node_deleted', OLD.id);
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER;
+
+CREATE TRIGGER nodes_emit_insert_delete_event
+    AFTER INSERT OR DELETE ON public.nodes
+    FOR EACH ROW
+    EXECUTE FUNCTION public.nodes_emit_event();
+
+CREATE TRIGGER nodes_emit_update_event
+    AFTER UPDATE ON public.nodes
+    FOR EACH ROW
+    WHEN (OLD.* IS DISTINCT FROM NEW.*)
+    EXECUTE FUNCTION public.nodes_emit_event();
+
+-- =============================================
+-- Trigger: trees.structure → node_moved / node_parents_changed
+-- เทียบ parent ของแต่ละ node ระหว่าง structure เก่ากับใหม่
+--   node_moved            = parent ชุดใหม่ไม่ซ้ำกับชุดเดิมเลย (ย้ายสาย / ตัดเป็น root)
+--   node_parents_changed  = เพิ่มหรือตัด parent บางตัว แต่ยังเหลือ parent เดิมอยู่
+-- node ที่เพิ่งสร้าง/เพิ่งลบไม่นับ (มี node_created / node_deleted แล้ว)
+-- =============================================
+
+CREATE OR REPLACE FUNCTION public.trees_emit_structure_events()
+RETURNS TRIGGER AS $$
+DECLARE
+    v_row RECORD;
+    v_event_type TEXT;
+BEGIN
+    FOR v_row IN
+        WITH old_links AS (
+            SELECT c.child_id AS node_id, e.key AS parent_id
+            FROM jsonb_each(OLD.structure->'edges') e,
+                 jsonb_array_elements_text(COALESCE(e.value->'children', '[]'::jsonb)) AS c(child_id)
+        ),
+        new_links AS (
+            SELECT c.child_id AS node_id, e.key AS parent_id
+            FROM jsonb_each(NEW.structure->'edges') e,
+                 jsonb_array_elements_text(COALESCE(e.value->'children', '[]'::jsonb)) AS c(child_id)
+        ),
+        kept AS (
+            SELECT k AS node_id FROM jsonb_object_keys(OLD.structure->'edges') k
+            INTERSECT
+            SELECT k FROM jsonb_object_keys(NEW.structure->'edges') k
+        )
+        SELECT
+            kept.node_id,
+            COALESCE((SELECT jsonb_agg(l.parent_id ORDER BY l.parent_id) FROM old_links l WHERE l.node_id = kept.node_id), '[]'::jsonb) AS old_parents,
+            COALESCE((SELECT jsonb_agg(l.parent_id ORDER BY l.parent_id) FROM new_links l WHERE l.node_id = kept.node_id), '[]'::jsonb) AS new_parents
+        FROM kept
+    LOOP
+        CONTINUE WHEN v_row.old_parents = v_row.new_parents;
+
+        IF v_row.old_parents ?| ARRAY(SELECT jsonb_array_elements_text(v_row.new_parents)) THEN
+            v_event_type := 'node_parents_changed';
+        ELSE
+            v_event_type := 'node_moved';
+        END IF;
+
+        PERFORM public.emit_tree_event(
+            NEW.id,
+            v_event_type,
+            v_row.node_id::uuid,
+            jsonb_build_object(
+                'old_parent_ids', v_row.old_parents,
+                'new_parent_ids', v_row.new_parents
+            )
+        );
+    END LOOP;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER;
+
+CREATE TRIGGER trees_emit_structure_events
+    AFTER UPDATE OF structure ON public.trees
+    FOR EACH ROW
+    WHEN (OLD.structure IS DISTINCT FROM NEW.structure)
+    EXECUTE FUNCTION public.trees_emit_structure_events();
@@ -0,0 +1,53 @@
+-- =============================================
+-- Helper: จัดลำดับ node ภายใน children ของ parent (หรือ rootIds ถ้า parent_id = NULL)
+-- p_order = ตำแหน่งใหม่ (เริ่มที่ 0) เกินขอบจะถูกปัดให้อยู่หัว/ท้าย
+-- ถ้า node ไม่ได้อยู่ใต้ parent นี้จะไม่เปลี่ยนอะไร
+-- =============================================
+
+CREATE OR REPLACE FUNCTION public.set_sibling_order(
+    p_tree_id UUID,
+    p_node_id UUID,
+    p_parent_id UUID,
+    p_order INT
+)
+RETURNS JSONB AS $$
+DECLARE
+    v_structure JSONB;
+    v_path TEXT[];
+    v_list JSONB;
+    v_without JSONB;
+    v_pos INT;
+BEGIN
+    SELECT structure INTO v_structure FROM public.trees WHERE id = p_tree_id;
+
+    IF p_parent_id IS NULL THEN
+        v_path := ARRAY['rootIds'];
+    ELSE
+        v_path := ARRAY['edges', p_parent_id::text, 'children'];
+    END IF;
+
+    v_list := COALESCE(v_structure #> v_path, '[]'::jsonb);
+    IF NOT v_list ? p_node_id::text THEN
+        RETURN v_structure;
+    END IF;
+
+    v_without := v_list - p_node_id::text;
+    v_pos := GREATEST(0, LEAST(p_order, jsonb_array_length(v_without)));
+
+    -- แทรก node ก่อนสมาชิกตำแหน่ง v_pos (สมาชิกเดิมได้ลำดับคู่ node ใหม่ได้ลำดับคี่)
+    SELECT COALESCE(jsonb_agg(elem ORDER BY ord), '[]'::jsonb)
+    INTO v_list
+    FROM (
+        SELECT elem, (idx - 1) * 2 AS ord
+        FROM jsonb_array_elements(v_without) WITH ORDINALITY AS t(elem, idx)
+        UNION ALL
+        SELECT to_jsonb(p_node_id::text), v_pos * 2 - 1
+    ) s;
+
+    v_structure := jsonb_set(v_structure, v_path, v_list);
+
+    UPDATE public.trees SET structure = v_structure WHERE id = p_tree_id;
+
+    RETURN v_structure;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER;
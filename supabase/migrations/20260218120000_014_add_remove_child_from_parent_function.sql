@@ -0,0 +1,49 @@
+-- =============================================
+-- Helper: ตัดเส้น parent → child เส้นเดียว (multi-parent / DAG)
+-- parent อื่นของ node ยังอยู่ครบ node จะกลายเป็น root ก็ต่อเมื่อไม่เหลือ parent แล้ว
+-- =============================================
+
+CREATE OR REPLACE FUNCTION public.remove_child_from_parent(
+    p_tree_id UUID,
+    p_node_id UUID,
+    p_parent_id UUID
+)
+RETURNS JSONB AS $$
+DECLARE
+    v_structure JSONB;
+    v_children JSONB;
+    v_has_parent BOOLEAN;
+BEGIN
+    SELECT structure INTO v_structure FROM public.trees WHERE id = p_tree_id;
+
+    v_children := COALESCE(v_structure->'edges'->p_parent_id::text->'children', '[]'::jsonb);
+    IF NOT v_children ? p_node_id::text THEN
+        -- ไม่ได้เป็นลูกของ parent นี้ ไม่ต้องทำอะไร
+        RETURN v_structure;
+    END IF;
+
+    v_structure := jsonb_set(
+        v_structure,
+        ARRAY['edges', p_parent_id::text, 'children'],
+        v_children - p_node_id::text
+    );
+
+    -- ยังมี parent อื่นเหลือไหม
+    SELECT EXISTS (
+        SELECT 1 FROM jsonb_each(v_structure->'edges') AS e(key, val)
+        WHERE e.val->'children' ? p_node_id::text
+    ) INTO v_has_parent;
+
+    IF NOT v_has_parent AND NOT (v_structure->'rootIds') ? p_node_id::text THEN
+        v_structure := jsonb_set(
+            v_structure,
+            '{rootIds}',
+            (v_structure->'rootIds') || to_jsonb(p_node_id::text)
+        );
+    END IF;
+
+    UPDATE public.trees SET structure = v_structure WHERE id = p_tree_id;
+
+    RETURN v_structure;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER;
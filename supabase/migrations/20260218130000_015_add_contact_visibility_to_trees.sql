@@ -0,0 +1,10 @@
+-- =============================================
+-- Add contact_visibility to trees
+-- ตั้งว่าใครเห็นช่องทางติดต่อของ node ใน tree ได้บ้าง
+-- key = ชื่อ field ใน nodes.metadata (phone, email, line_id, discord, facebook)
+-- value = public | members | editors (ไม่มี key = members)
+-- ค่าราย node เก็บใน nodes.metadata เป็น "<field>_visibility" และมีผลเหนือค่าของ tree
+-- =============================================
+
+ALTER TABLE public.trees
+    ADD COLUMN contact_visibility JSONB NOT NULL DEFAULT '{}'::jsonb;
@@ -0,0 +1,35 @@
+-- =============================================
+-- Share Links Table
+-- ลิงก์แชร์แยกเป็น entity: tree หนึ่งมีได้หลายลิงก์
+-- ตั้งวันหมดอายุ / จำนวนครั้งที่เข้าร่วมได้ / สิทธิ์ที่ลิงก์ให้ และยกเลิกได้
+-- แทน trees.share_token เดิม (token เดิมย้ายมาเป็นลิงก์ดูอย่างเดียว URL เดิมยังใช้ได้)
+-- =============================================
+
+CREATE TABLE public.share_links (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tree_id      UUID NOT NULL REFERENCES public.trees(id) ON DELETE CASCADE,
+    token        TEXT NOT NULL UNIQUE,
+    -- view = ดูอย่างเดียว, join_viewer / join_editor = login แล้วเข้าร่วมเป็นสมาชิก
+    role         TEXT NOT NULL DEFAULT 'view'
+                 CHECK (role IN ('view', 'join_viewer', 'join_editor')),
+    expires_at   TIMESTAMPTZ DEFAULT NULL,
+    max_uses     INTEGER DEFAULT NULL CHECK (max_uses IS NULL OR max_uses > 0),
+    use_count    INTEGER NOT NULL DEFAULT 0,
+    created_by   UUID REFERENCES public.profiles(id) ON DELETE SET NULL,
+    revoked_at   TIMESTAMPTZ DEFAULT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_share_links_tree_id ON public.share_links(tree_id);
+
+-- token เป็นความลับ: ไม่มี policy ให้ client อ่านตรง ใช้ผ่าน backend เท่านั้น
+ALTER TABLE public.share_links ENABLE ROW LEVEL SECURITY;
+
+-- ย้าย token เดิม
+INSERT INTO public.share_links (tree_id, token, role, created_by)
+SELECT id, share_token, 'view', created_by
+FROM public.trees
+WHERE share_token IS NOT NULL AND share_token <> '';
+
+DROP INDEX IF EXISTS public.idx_trees_share_token;
+ALTER TABLE public.trees DROP COLUMN share_token;
@@ -0,0 +1,93 @@
+-- =============================================
+-- Tree Invitations Table
+-- แชร์ให้ email ที่ยังไม่มีบัญชี: เก็บเป็นคำเชิญค้างไว้
+-- พอ user ยืนยัน email แล้ว คำเชิญจะกลายเป็น tree_shares
+-- (ผ่าน handle_new_user หรือ backend เรียก accept_tree_invitations ตอน request แรก)
+-- =============================================
+
+CREATE TABLE public.tree_invitations (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tree_id       UUID NOT NULL REFERENCES public.trees(id) ON DELETE CASCADE,
+    email         TEXT NOT NULL,
+    role          public.share_role NOT NULL DEFAULT 'viewer',
+    invited_by    UUID REFERENCES public.profiles(id) ON DELETE SET NULL,
+    send_count    INTEGER NOT NULL DEFAULT 0,
+    last_sent_at  TIMESTAMPTZ DEFAULT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- เชิญ email เดียวกันเข้า tree เดียวกันได้ครั้งเดียว (ไม่สนตัวพิมพ์)
+CREATE UNIQUE INDEX unique_tree_invitation_per_email
+    ON public.tree_invitations (tree_id, lower(email));
+
+CREATE INDEX idx_tree_invitations_email ON public.tree_invitations (lower(email));
+
+-- ใช้ผ่าน backend เท่านั้น
+ALTER TABLE public.tree_invitations ENABLE ROW LEVEL SECURITY;
+
+CREATE TRIGGER tree_invitations_updated_at
+    BEFORE UPDATE ON public.tree_invitations
+    FOR EACH ROW
+    EXECUTE FUNCTION public.update_updated_at();
+
+-- =============================================
+-- accept_tree_invitations
+-- เปลี่ยนคำเชิญของ email ของ user เป็น tree_shares แล้วลบคำเชิญทิ้ง
+-- รับเฉพาะ user ที่ยืนยัน email แล้ว (กันคนสมัครด้วย email คนอื่นมาเอาสิทธิ์ไป)
+-- คืนจำนวน share ที่สร้าง
+-- =============================================
+
+CREATE OR REPLACE FUNCTION public.accept_tree_invitations(p_user_id UUID)
+RETURNS INTEGER AS $$
+DECLARE
+    v_email TEXT;
+    v_count INTEGER;
+BEGIN
+    SELECT email INTO v_email
+    FROM auth.users
+    WHERE id = p_user_id AND email_confirmed_at IS NOT NULL;
+
+    IF v_email IS NULL THEN
+        RETURN 0;
+    END IF;
+
+    -- เจ้าของ tree ไม่ต้องมี share / มี share อยู่แล้วไม่ทับ role เดิม
+    INSERT INTO public.tree_shares (tree_id, user_id, role, invited_by)
+    SELECT i.tree_id, p_user_id, i.role, i.invited_by
+    FROM public.tree_invitations i
+    JOIN public.trees t ON t.id = i.tree_id
+    WHERE lower(i.email) = lower(v_email)
+      AND t.created_by <> p_user_id
+    ON CONFLICT (tree_id, user_id) DO NOTHING;
+
+    GET DIAGNOSTICS v_count = ROW_COUNT;
+
+    DELETE FROM public.tree_invitations
+    WHERE lower(email) = lower(v_email);
+
+    RETURN v_count;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER;
+
+-- =============================================
+-- handle_new_user: สมัครด้วย provider ที่ยืนยัน email แล้ว (เช่น Google) รับคำเชิญทันที
+-- =============================================
+
+CREATE OR REPLACE FUNCTION public.handle_new_user()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO public.profiles (id, display_name, avatar_url)
+    VALUES (
+        NEW.id,
+        COALESCE(NEW.raw_user_meta_data->>'display_name', NEW.email),
+        COALESCE(NEW.raw_user_meta_data->>'avatar_url', '')
+    );
+
+    IF NEW.email_confirmed_at IS NOT NULL THEN
+        PERFORM public.accept_tree_invitations(NEW.id);
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER;
@@ -0,0 +1,36 @@
+-- =============================================
+-- Tree Ownership Transfers Table
+-- โอน trees.created_by ให้สมาชิกคนอื่น (ผู้รับต้องกดยอมรับก่อน)
+-- เก็บทุกคำขอไว้เป็นประวัติ (ไม่ลบ แค่เปลี่ยน status)
+-- =============================================
+
+CREATE TYPE public.ownership_transfer_status AS ENUM (
+    'pending',
+    'accepted',
+    'declined',
+    'cancelled'
+);
+
+CREATE TABLE public.tree_ownership_transfers (
+    id                   UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tree_id              UUID NOT NULL REFERENCES public.trees(id) ON DELETE CASCADE,
+    from_user_id         UUID REFERENCES public.profiles(id) ON DELETE SET NULL,
+    to_user_id           UUID REFERENCES public.profiles(id) ON DELETE SET NULL,
+    -- role ที่เจ้าของเดิมจะได้หลังโอนสำเร็จ
+    previous_owner_role  public.share_role NOT NULL,
+    status               public.ownership_transfer_status NOT NULL DEFAULT 'pending',
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at          TIMESTAMPTZ DEFAULT NULL
+);
+
+-- tree หนึ่งมีคำขอโอนที่รออยู่ได้ทีละคำขอ
+CREATE UNIQUE INDEX unique_pending_transfer_per_tree
+    ON public.tree_ownership_transfers (tree_id)
+    WHERE status = 'pending';
+
+CREATE INDEX idx_ownership_transfers_tree_id ON public.tree_ownership_transfers (tree_id, created_at DESC);
+CREATE INDEX idx_ownership_transfers_to_user ON public.tree_ownership_transfers (to_user_id)
+    WHERE status = 'pending';
+
+-- ใช้ผ่าน backend เท่านั้น
+ALTER TABLE public.tree_ownership_transfers ENABLE ROW LEVEL SECURITY;
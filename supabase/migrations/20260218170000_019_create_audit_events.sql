@@ -0,0 +1,43 @@
+-- =============================================
+-- Audit Events Table
+-- ประวัติการแก้ tree / node / การแชร์ ว่าใครทำอะไร ค่าก่อนและหลังเป็นอย่างไร
+-- append-only: UPDATE / DELETE ถูก trigger ปฏิเสธ
+-- =============================================
+
+CREATE TABLE public.audit_events (
+    id           BIGSERIAL PRIMARY KEY,
+    -- ไม่ใส่ FK เพราะ log ต้องอยู่ต่อหลังลบ tree / node / บัญชี
+    tree_id      UUID NOT NULL,
+    actor_id     UUID NOT NULL,
+    action       TEXT NOT NULL,
+    node_id      UUID,
+    target_ids   TEXT[] NOT NULL DEFAULT '{}',
+    before       JSONB,
+    after        JSONB,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indexes (ListAuditEvents เรียงจากใหม่ไปเก่าเสมอ)
+CREATE INDEX idx_audit_events_tree_id ON public.audit_events (tree_id, id DESC);
+CREATE INDEX idx_audit_events_node_id ON public.audit_events (tree_id, node_id, id DESC);
+CREATE INDEX idx_audit_events_actor_id ON public.audit_events (tree_id, actor_id, id DESC);
+CREATE INDEX idx_audit_events_target_ids ON public.audit_events USING GIN (target_ids);
+
+-- ใช้ผ่าน backend เท่านั้น
+ALTER TABLE public.audit_events ENABLE ROW LEVEL SECURITY;
+
+-- =============================================
+-- Append-only
+-- =============================================
+
+CREATE OR REPLACE FUNCTION public.audit_events_append_only()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON public.audit_events
+    FOR EACH ROW
+    EXECUTE FUNCTION public.audit_events_append_only();
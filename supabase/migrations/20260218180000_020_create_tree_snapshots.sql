@@ -0,0 +1,34 @@
+-- =============================================
+-- Tree Snapshots Table
+-- สถานะทั้ง tree (structure + ทุก node) ณ ขณะหนึ่ง สำหรับ diff / restore / undo
+-- snapshot อัตโนมัติถูกสร้างก่อนการแก้ node ทุกครั้ง (reason = ชื่อ action ของ audit)
+-- =============================================
+
+CREATE TABLE public.tree_snapshots (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tree_id             UUID NOT NULL REFERENCES public.trees(id) ON DELETE CASCADE,
+    created_by          UUID NOT NULL,
+    -- manual | undo | restore | <audit action>
+    reason              TEXT NOT NULL,
+    label               TEXT NOT NULL DEFAULT '',
+    -- ส่วนที่ undo ย้อนให้: tree | subtree (node_id + descendants) | node (ข้อมูลของ node_id)
+    scope               TEXT NOT NULL DEFAULT 'tree',
+    -- node ที่การแก้ถัดจาก snapshot นี้ไปแตะ (NULL เมื่อ scope = tree)
+    node_id             UUID DEFAULT NULL,
+    -- snapshot ที่ถูก undo (เฉพาะ reason = undo)
+    undo_of             UUID REFERENCES public.tree_snapshots(id) ON DELETE SET NULL,
+    undone_at           TIMESTAMPTZ DEFAULT NULL,
+    structure_revision  BIGINT NOT NULL,
+    structure           JSONB NOT NULL,
+    nodes               JSONB NOT NULL DEFAULT '[]'::jsonb,
+    node_count          INTEGER NOT NULL DEFAULT 0,
+    -- clock_timestamp: หลาย snapshot ใน transaction เดียวกันยังเรียงลำดับได้
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT clock_timestamp()
+);
+
+-- Indexes
+CREATE INDEX idx_tree_snapshots_tree_id ON public.tree_snapshots (tree_id, created_at DESC);
+CREATE INDEX idx_tree_snapshots_user ON public.tree_snapshots (tree_id, created_by, created_at DESC);
+
+-- ใช้ผ่าน backend เท่านั้น
+ALTER TABLE public.tree_snapshots ENABLE ROW LEVEL SECURITY;
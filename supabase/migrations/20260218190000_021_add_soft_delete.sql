@@ -0,0 +1,93 @@
+-- =============================================
+-- Soft delete (ถังขยะ) สำหรับ trees / nodes
+-- ลบ = ตั้ง deleted_at ไว้ก่อน, กู้คืนได้จนกว่า purge job ของ backend จะลบจริงตาม retention
+-- =============================================
+
+ALTER TABLE public.trees
+    ADD COLUMN deleted_at TIMESTAMPTZ DEFAULT NULL,
+    ADD COLUMN deleted_by UUID DEFAULT NULL;
+
+ALTER TABLE public.nodes
+    ADD COLUMN deleted_at TIMESTAMPTZ DEFAULT NULL,
+    ADD COLUMN deleted_by UUID DEFAULT NULL,
+    -- ตำแหน่งเดิมใน structure: {"parents": [{"parentId", "index"}], "rootIndex", "children"}
+    ADD COLUMN trash_placement JSONB DEFAULT NULL;
+
+CREATE INDEX idx_trees_deleted_at ON public.trees (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_nodes_deleted_at ON public.nodes (tree_id, deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- student_id ของ node ในถังขยะไม่กันการใช้ซ้ำ (กู้คืนตอนมีคนใช้แล้ว = ชน)
+DROP INDEX IF EXISTS public.unique_student_id_per_tree;
+CREATE UNIQUE INDEX unique_student_id_per_tree
+    ON public.nodes (tree_id, student_id)
+    WHERE student_id IS NOT NULL AND deleted_at IS NULL;
+
+-- =============================================
+-- Realtime: ลงถังขยะ = node_deleted, กู้คืน = node_created
+-- ลบจริงตอน purge ไม่ต้องส่งซ้ำ
+-- =============================================
+
+CREATE OR REPLACE FUNCTION public.nodes_emit_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM public.emit_tree_event(NEW.tree_id, 'node_created', NEW.id);
+        RETURN NEW;
+    ELSIF TG_OP = 'UPDATE' THEN
+        IF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+            PERFORM public.emit_tree_event(NEW.tree_id, 'node_deleted', NEW.id);
+        ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+            PERFORM public.emit_tree_event(NEW.tree_id, 'node_created', NEW.id);
+        ELSIF NEW.deleted_at IS NULL THEN
+            PERFORM public.emit_tree_event(NEW.tree_id, 'node_updated', NEW.id);
+        END IF;
+        RETURN NEW;
+    END IF;
+
+    IF OLD.deleted_at IS NULL THEN
+        PERFORM public.emit_tree_event(OLD.tree_id, 'node_deleted', OLD.id);
+    END IF;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER;
+
+-- =============================================
+-- RLS: ของในถังขยะไม่ให้อ่านตรงจาก client
+-- =============================================
+
+DROP POLICY IF EXISTS "trees_select" ON public.trees;
+CREATE POLICY "trees_select"
+    ON public.trees FOR SELECT
+    USING (
+        deleted_at IS NULL
+        AND (
+            created_by = auth.uid()
+            OR is_public = true
+            OR EXISTS (
+                SELECT 1 FROM public.tree_shares
+                WHERE tree_shares.tree_id = trees.id
+                AND tree_shares.user_id = auth.uid()
+            )
+        )
+    );
+
+DROP POLICY IF EXISTS "nodes_select" ON public.nodes;
+CREATE POLICY "nodes_select"
+    ON public.nodes FOR SELECT
+    USING (
+        nodes.deleted_at IS NULL
+        AND EXISTS (
+            SELECT 1 FROM public.trees
+            WHERE trees.id = nodes.tree_id
+            AND trees.deleted_at IS NULL
+            AND (
+                trees.created_by = auth.uid()
+                OR trees.is_public = true
+                OR EXISTS (
+                    SELECT 1 FROM public.tree_shares
+                    WHERE tree_shares.tree_id = trees.id
+                    AND tree_shares.user_id = auth.uid()
+                )
+            )
+        )
+    );
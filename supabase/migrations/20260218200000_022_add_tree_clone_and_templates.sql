@@ -0,0 +1,38 @@
+-- =============================================
+-- Clone tree + Tree Templates
+-- tree ที่ clone มาจำว่ามาจาก tree ไหน / template ไหน
+-- template = โครง tree (structure + รุ่นของแต่ละตำแหน่ง) ที่ไม่มีข้อมูลคน ใช้เริ่ม tree ใหม่ทุกปี
+-- =============================================
+
+CREATE TABLE public.tree_templates (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id     UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    name         TEXT NOT NULL,
+    description  TEXT NOT NULL DEFAULT '',
+    faculty      TEXT NOT NULL DEFAULT '',
+    department   TEXT NOT NULL DEFAULT '',
+    -- structure ที่ใช้ key ของ template แทน node id
+    structure    JSONB NOT NULL DEFAULT '{"rootIds": [], "edges": {}}'::jsonb,
+    -- [{"key", "label", "generationOffset"}] รุ่นนับจาก 0 (รุ่นบนสุดของ tree ต้นแบบ)
+    nodes        JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_tree_templates_owner_id ON public.tree_templates (owner_id, created_at DESC);
+
+CREATE TRIGGER tree_templates_updated_at
+    BEFORE UPDATE ON public.tree_templates
+    FOR EACH ROW
+    EXECUTE FUNCTION public.update_updated_at();
+
+-- ใช้ผ่าน backend เท่านั้น
+ALTER TABLE public.tree_templates ENABLE ROW LEVEL SECURITY;
+
+-- =============================================
+-- ที่มาของ tree
+-- =============================================
+
+ALTER TABLE public.trees
+    ADD COLUMN cloned_from UUID REFERENCES public.trees(id) ON DELETE SET NULL,
+    ADD COLUMN template_id UUID REFERENCES public.tree_templates(id) ON DELETE SET NULL;
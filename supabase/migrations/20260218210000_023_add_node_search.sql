@@ -0,0 +1,35 @@
+-- =============================================
+-- ค้นหา node (SearchNodes)
+-- ชื่อเล่น / ชื่อ / นามสกุล / รหัสนักศึกษา: substring + fuzzy (pg_trgm) + full-text (idx_nodes_search)
+-- ช่องทางติดต่อ: substring (backend กรองซ้ำตาม contact visibility ของ caller)
+-- =============================================
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- lower() ไว้ก่อน จะได้ใช้ LIKE / trigram กับ index ตัวเดียวทั้งภาษาไทยและอังกฤษ
+ALTER TABLE public.nodes
+    ADD COLUMN search_text TEXT GENERATED ALWAYS AS (
+        lower(
+            nickname || ' ' ||
+            COALESCE(first_name, '') || ' ' ||
+            COALESCE(last_name, '') || ' ' ||
+            COALESCE(student_id, '')
+        )
+    ) STORED,
+    ADD COLUMN contact_text TEXT GENERATED ALWAYS AS (
+        lower(
+            COALESCE(metadata->>'phone', '') || ' ' ||
+            COALESCE(metadata->>'email', '') || ' ' ||
+            COALESCE(metadata->>'line_id', '') || ' ' ||
+            COALESCE(metadata->>'discord', '') || ' ' ||
+            COALESCE(metadata->>'facebook', '')
+        )
+    ) STORED;
+
+CREATE INDEX idx_nodes_search_trgm ON public.nodes
+    USING GIN (search_text gin_trgm_ops)
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_nodes_contact_trgm ON public.nodes
+    USING GIN (contact_text gin_trgm_ops)
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,12 @@
+-- =============================================
+-- ย้ายการแก้ trees.structure ไปอยู่ใน backend (tree.TreeStructure)
+-- backend ล็อก row ด้วย SELECT ... FOR UPDATE แก้ใน Go แล้วเขียนกลับใน transaction เดียว
+-- ไม่มีใครเรียก DB functions เหล่านี้แล้ว
+-- =============================================
+
+DROP FUNCTION IF EXISTS public.add_node_to_structure(UUID, UUID, UUID);
+DROP FUNCTION IF EXISTS public.remove_node_from_structure(UUID, UUID);
+DROP FUNCTION IF EXISTS public.move_node_in_structure(UUID, UUID, UUID);
+DROP FUNCTION IF EXISTS public.add_child_to_parent(UUID, UUID, UUID);
+DROP FUNCTION IF EXISTS public.remove_child_from_parent(UUID, UUID, UUID);
+DROP FUNCTION IF EXISTS public.set_sibling_order(UUID, UUID, UUID, INT);
@@ -0,0 +1,36 @@
+-- =============================================
+-- accept_tree_invitations คืน NULL เมื่อ email ยังไม่ยืนยัน (เดิมคืน 0 เหมือนไม่มีคำเชิญ)
+-- backend จะได้รู้ว่าต้องลองใหม่หลังยืนยัน email ไม่ใช่จำไว้ว่ารับครบแล้ว
+-- =============================================
+
+CREATE OR REPLACE FUNCTION public.accept_tree_invitations(p_user_id UUID)
+RETURNS INTEGER AS $$
+DECLARE
+    v_email TEXT;
+    v_count INTEGER;
+BEGIN
+    SELECT email INTO v_email
+    FROM auth.users
+    WHERE id = p_user_id AND email_confirmed_at IS NOT NULL;
+
+    IF v_email IS NULL THEN
+        RETURN NULL;
+    END IF;
+
+    -- เจ้าของ tree ไม่ต้องมี share / มี share อยู่แล้วไม่ทับ role เดิม
+    INSERT INTO public.tree_shares (tree_id, user_id, role, invited_by)
+    SELECT i.tree_id, p_user_id, i.role, i.invited_by
+    FROM public.tree_invitations i
+    JOIN public.trees t ON t.id = i.tree_id
+    WHERE lower(i.email) = lower(v_email)
+      AND t.created_by <> p_user_id
+    ON CONFLICT (tree_id, user_id) DO NOTHING;
+
+    GET DIAGNOSTICS v_count = ROW_COUNT;
+
+    DELETE FROM public.tree_invitations
+    WHERE lower(email) = lower(v_email);
+
+    RETURN v_count;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER;
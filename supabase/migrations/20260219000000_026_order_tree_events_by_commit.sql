@@ -0,0 +1,71 @@
+-- =============================================
+-- tree_events.seq ลำดับตอน commit (ใช้ resume / catch-up แทน id)
+-- id มาจาก BIGSERIAL ตอน insert ซึ่งไม่เรียงตาม commit:
+-- transaction ที่ได้ id น้อยกว่าอาจ commit ทีหลัง แล้ว event นั้นหลุดจากคนที่ resume ด้วย id ที่มากกว่าไปแล้ว
+-- seq ให้ตอน commit (deferred trigger) โดยถือ advisory lock ไว้จนจบ transaction
+-- → seq ที่มองเห็นได้เรียงตาม commit เสมอ: เห็น seq N แล้วแปลว่า seq < N commit ครบแล้ว
+-- =============================================
+
+CREATE SEQUENCE public.tree_events_seq;
+
+ALTER TABLE public.tree_events ADD COLUMN seq BIGINT;
+
+-- event เดิม commit ไปหมดแล้ว ใช้ id เป็น seq ได้เลย
+UPDATE public.tree_events SET seq = id;
+SELECT setval('public.tree_events_seq', COALESCE((SELECT MAX(id) FROM public.tree_events), 0) + 1, false);
+
+CREATE UNIQUE INDEX idx_tree_events_tree_id_seq ON public.tree_events(tree_id, seq);
+DROP INDEX IF EXISTS public.idx_tree_events_tree_id;
+
+-- =============================================
+-- emit_tree_event: แค่ insert — NOTIFY ย้ายไปตอนได้ seq แล้ว
+-- =============================================
+
+CREATE OR REPLACE FUNCTION public.emit_tree_event(
+    p_tree_id UUID,
+    p_event_type TEXT,
+    p_node_id UUID,
+    p_payload JSONB DEFAULT '{}'::jsonb
+)
+RETURNS BIGINT AS $$
+DECLARE
+    v_id BIGINT;
+BEGIN
+    INSERT INTO public.tree_events (tree_id, event_type, node_id, payload)
+    VALUES (p_tree_id, p_event_type, p_node_id, COALESCE(p_payload, '{}'::jsonb))
+    RETURNING id INTO v_id;
+
+    RETURN v_id;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER;
+
+-- =============================================
+-- Trigger: ให้ seq ตอน commit + NOTIFY
+-- lock ตัวเดียวทั้งระบบ (ไม่แยกตาม tree) จะได้ไม่ deadlock เมื่อ transaction เดียวแตะหลาย tree
+-- =============================================
+
+CREATE OR REPLACE FUNCTION public.tree_events_assign_seq()
+RETURNS TRIGGER AS $$
+DECLARE
+    v_seq BIGINT;
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('public.tree_events_seq'));
+
+    v_seq := nextval('public.tree_events_seq');
+    UPDATE public.tree_events SET seq = v_seq WHERE id = NEW.id;
+
+    -- ส่งแค่ id / seq ไป (payload ของ NOTIFY จำกัด 8000 bytes) ให้ backend ไปอ่านจาก table เอง
+    PERFORM pg_notify(
+        'tree_events',
+        json_build_object('id', NEW.id, 'seq', v_seq, 'tree_id', NEW.tree_id)::text
+    );
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER;
+
+CREATE CONSTRAINT TRIGGER tree_events_assign_seq
+    AFTER INSERT ON public.tree_events
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW
+    EXECUTE FUNCTION public.tree_events_assign_seq();
@@ -0,0 +1,28 @@
+-- =============================================
+-- nodes_select: เฉพาะเจ้าของ / คนที่ได้ share เท่านั้น (ตัด is_public ออก)
+-- anon key อยู่ใน frontend — ถ้าเปิดให้อ่าน node ของ tree สาธารณะตรงจาก table
+-- ใครก็อ่าน metadata (เบอร์ / email / ...) ได้ครบ ข้ามการซ่อน contact ตาม role ของ backend
+-- คนนอกที่ดู tree สาธารณะต้องอ่านผ่าน backend (ซ่อน contact ให้แล้ว) เท่านั้น
+-- หมายเหตุ: การซ่อน contact ราย field / ราย role บังคับที่ backend อย่างเดียว
+-- สมาชิกของ tree ยังอ่าน metadata ทั้งก้อนจาก table ได้
+-- =============================================
+
+DROP POLICY IF EXISTS "nodes_select" ON public.nodes;
+CREATE POLICY "nodes_select"
+    ON public.nodes FOR SELECT
+    USING (
+        nodes.deleted_at IS NULL
+        AND EXISTS (
+            SELECT 1 FROM public.trees
+            WHERE trees.id = nodes.tree_id
+            AND trees.deleted_at IS NULL
+            AND (
+                trees.created_by = auth.uid()
+                OR EXISTS (
+                    SELECT 1 FROM public.tree_shares
+                    WHERE tree_shares.tree_id = trees.id
+                    AND tree_shares.user_id = auth.uid()
+                )
+            )
+        )
+    );